@@ -0,0 +1,94 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+
+	"ffiii-tui/internal/firefly"
+)
+
+// cacheEnabled reports whether the last fetched data is persisted between
+// runs, it is on unless cache.persist is false.
+func cacheEnabled() bool {
+	return !viper.IsSet("cache.persist") || viper.GetBool("cache.persist")
+}
+
+func snapshotPath(profile string) (string, error) {
+	dir := viper.GetString("cache.dir")
+	if dir == "" {
+		cacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(cacheDir, "ffiii-tui")
+	}
+	return filepath.Join(dir, profileName(profile)+".json"), nil
+}
+
+// openProfile creates the client for the named profile at startup. When a
+// snapshot of the profile is cached the client is restored from it and the
+// UI refreshes the data in the background, otherwise it connects as usual.
+func openProfile(profile string) (*firefly.Api, error) {
+	config, err := apiConfig(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	if cacheEnabled() {
+		if ff, err := restoreSnapshot(profile, config); err == nil {
+			return ff, nil
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			zap.L().Warn("Ignoring cached data", zap.String("profile", profileName(profile)), zap.Error(err))
+		}
+	}
+
+	ff, err := firefly.NewApi(context.Background(), config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Firefly III: %w", err)
+	}
+	return ff, nil
+}
+
+func restoreSnapshot(profile string, config firefly.ApiConfig) (*firefly.Api, error) {
+	path, err := snapshotPath(profile)
+	if err != nil {
+		return nil, err
+	}
+	snap, err := firefly.LoadSnapshot(path)
+	if err != nil {
+		return nil, err
+	}
+	ff, err := firefly.NewApiFromSnapshot(config, snap)
+	if err != nil {
+		return nil, err
+	}
+	zap.L().Info("Restored cached data",
+		zap.String("profile", profileName(profile)),
+		zap.Time("saved_at", snap.SavedAt))
+	return ff, nil
+}
+
+// saveSnapshot persists the data of the client for the next start.
+func saveSnapshot(profile string, ff *firefly.Api) {
+	if !cacheEnabled() {
+		return
+	}
+	path, err := snapshotPath(profile)
+	if err == nil {
+		err = firefly.SaveSnapshot(path, ff.Snapshot())
+	}
+	if err != nil {
+		zap.L().Warn("Failed to save cached data", zap.Error(err))
+	}
+}
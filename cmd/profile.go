@@ -0,0 +1,125 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/viper"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui"
+)
+
+const defaultProfile = "default"
+
+// profileKey returns the config key for a setting of the named profile.
+// The default profile uses the top level firefly section.
+func profileKey(profile, setting string) string {
+	if profile == "" || profile == defaultProfile {
+		return "firefly." + setting
+	}
+	return fmt.Sprintf("profiles.%s.%s", profile, setting)
+}
+
+// apiConfig builds the client configuration of the named profile.
+func apiConfig(profile string) (firefly.ApiConfig, error) {
+	if profile != "" && profile != defaultProfile && !viper.IsSet("profiles."+profile) {
+		return firefly.ApiConfig{}, fmt.Errorf("profile %q is not configured", profile)
+	}
+
+	apiKey, err := apiKeyForProfile(profile)
+	if err != nil {
+		return firefly.ApiConfig{}, err
+	}
+	if apiKey == "" {
+		return firefly.ApiConfig{}, fmt.Errorf("firefly API key is not set")
+	}
+
+	apiUrl := viper.GetString(profileKey(profile, "api_url"))
+	if apiUrl == "" {
+		return firefly.ApiConfig{}, fmt.Errorf("firefly API URL is not set")
+	}
+
+	return firefly.ApiConfig{
+		ApiKey:          apiKey,
+		ApiUrl:          apiUrl,
+		TimeoutSeconds:  viper.GetInt("timeout"),
+		Timeouts:        timeoutConfig(),
+		PrimaryCurrency: viper.GetString(profileKey(profile, "primary_currency")),
+		TLS:             tlsConfig(profile),
+		Retry:           retryConfig(),
+		RateLimit:       rateLimitConfig(),
+	}, nil
+}
+
+// connectProfile creates a Firefly III client for the named profile.
+func connectProfile(profile string) (*firefly.Api, error) {
+	config, err := apiConfig(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	ff, err := firefly.NewApi(context.Background(), config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Firefly III: %w", err)
+	}
+	return ff, nil
+}
+
+// connectUI adapts connectProfile for the runtime profile switcher.
+func connectUI(profile string) (ui.UIAPI, error) {
+	ff, err := connectProfile(profile)
+	if err != nil {
+		return nil, err
+	}
+	watchWebhooks(ff)
+	return ff, nil
+}
+
+func tlsConfig(profile string) firefly.TLSConfig {
+	return firefly.TLSConfig{
+		CAFile:             viper.GetString(profileKey(profile, "tls.ca_file")),
+		CertFile:           viper.GetString(profileKey(profile, "tls.cert_file")),
+		KeyFile:            viper.GetString(profileKey(profile, "tls.key_file")),
+		InsecureSkipVerify: viper.GetBool(profileKey(profile, "tls.insecure_skip_verify")),
+	}
+}
+
+func retryConfig() firefly.RetryConfig {
+	cfg := firefly.DefaultRetryConfig()
+	if viper.IsSet("retry.max_retries") {
+		cfg.MaxRetries = max(viper.GetInt("retry.max_retries"), 0)
+	}
+	if viper.IsSet("retry.base_delay") {
+		cfg.BaseDelay = viper.GetDuration("retry.base_delay")
+	}
+	if viper.IsSet("retry.max_delay") {
+		cfg.MaxDelay = viper.GetDuration("retry.max_delay")
+	}
+	return cfg
+}
+
+// timeoutConfig reads the timeouts of the request classes, unset ones use
+// timeout.
+func timeoutConfig() firefly.TimeoutConfig {
+	return firefly.TimeoutConfig{
+		Metadata:     viper.GetDuration("timeouts.metadata"),
+		Transactions: viper.GetDuration("timeouts.transactions"),
+		Export:       viper.GetDuration("timeouts.export"),
+	}
+}
+
+func rateLimitConfig() firefly.RateLimitConfig {
+	cfg := firefly.DefaultRateLimitConfig()
+	if viper.IsSet("rate_limit.requests_per_second") {
+		cfg.RequestsPerSecond = viper.GetFloat64("rate_limit.requests_per_second")
+	}
+	if viper.IsSet("rate_limit.burst") {
+		cfg.Burst = viper.GetInt("rate_limit.burst")
+	}
+	return cfg
+}
@@ -0,0 +1,56 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"ffiii-tui/internal/firefly"
+)
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore FILE",
+	Short: "Restore a backup into Firefly III",
+	Long: `Restore a backup archive written by ffiii-tui, or a JSON export of the
+transactions API endpoint, into the Firefly III instance of the profile.
+
+Accounts are mapped by name and type, categories by name; the ones already
+there are skipped, as are transactions with the same date, amount,
+description and accounts, so a restore can be run again safely.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		backup, err := firefly.LoadBackup(args[0])
+		if err != nil {
+			return err
+		}
+
+		ff, err := connectProfile(viper.GetString("profile"))
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Restoring %d accounts, %d categories and %d transactions to %s\n",
+			len(backup.Accounts), len(backup.Categories), len(backup.Transactions), ff.ServerURL())
+		result, err := ff.Restore(context.Background(), backup)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println("Accounts:    ", result.Accounts)
+		fmt.Println("Categories:  ", result.Categories)
+		fmt.Println("Transactions:", result.Transactions)
+		for _, failure := range result.Failed {
+			fmt.Println("Failed:", failure)
+		}
+		if len(result.Failed) > 0 {
+			return fmt.Errorf("%d objects could not be restored", len(result.Failed))
+		}
+		return nil
+	},
+}
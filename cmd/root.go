@@ -7,14 +7,18 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"ffiii-tui/internal/demo"
 	"ffiii-tui/internal/firefly"
 	"ffiii-tui/internal/logging"
 	"ffiii-tui/internal/ui"
@@ -31,24 +35,44 @@ It allows you to view and manage your financial data directly from the terminal.
 
 Prerequisites:
   - A running instance of Firefly III with API access enabled.
-  - An API key generated from your Firefly III user settings. `,
+  - An API key generated from your Firefly III user settings.
+
+The API key is kept in the OS keyring. Without one it is written to
+secrets.enc next to the config; set FFIII_TUI_SECRETS_PASSPHRASE to encrypt
+it, otherwise its key sits beside it in secrets.key and the file is only
+obfuscated.`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		return initializeConfig(cmd)
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		debug := viper.GetBool("logging.debug")
-		logFile := viper.GetString("logging.file")
-		timeout := viper.GetInt("timeout")
-
-		if debug {
-			fmt.Println("Debug logging is enabled")
+		demoMode := viper.GetBool("demo")
+		if !demoMode && needsSetup(cmd) {
+			if err := runSetupWizard(); err != nil {
+				return err
+			}
 		}
 
+		debug := viper.GetBool("logging.debug") || viper.GetBool("debug")
+		logFile := viper.GetString("logging.file")
+
 		var (
 			logger  *zap.Logger
 			cleanup func()
 			err     error
 		)
+		if viper.GetBool("debug") && logFile == "" {
+			if logFile, err = debugLogPath(); err != nil {
+				return fmt.Errorf("failed to create debug log: %w", err)
+			}
+		}
+
+		if debug {
+			fmt.Println("Debug logging is enabled")
+			if logFile != "" {
+				fmt.Println("Writing logs to", logFile)
+			}
+		}
+
 		if logFile == "" {
 			logger, cleanup, err = logging.New(debug)
 		} else {
@@ -61,35 +85,51 @@ Prerequisites:
 
 		zap.ReplaceGlobals(logger)
 
-		apiKey := viper.GetString("firefly.api_key")
-		if apiKey == "" {
-			return fmt.Errorf("firefly API key is not set")
-		}
-
-		apiUrl := viper.GetString("firefly.api_url")
-		if apiUrl == "" {
-			return fmt.Errorf("firefly API URL is not set")
+		if demoMode {
+			// Generated data lives in memory only, nothing is written back
+			viper.Set("profile", "demo")
+			ui.Show(demo.New(1, time.Now()), nil, nil)
+			return nil
 		}
 
-		ff, err := firefly.NewApi(firefly.ApiConfig{
-			ApiKey:         apiKey,
-			ApiUrl:         apiUrl,
-			TimeoutSeconds: timeout,
-		})
+		apiKeyFromFlag = cmd.Flags().Changed("firefly.api_key")
+		profile := viper.GetString("profile")
+		ff, err := openProfile(profile)
 		if err != nil {
-			return fmt.Errorf("failed to connect to Firefly III: %w", err)
+			return err
 		}
 
-		logger.Info("Connected to Firefly III", zap.String("api_url", apiUrl), zap.String("user", ff.User.Email))
+		startWebhookListener()
+		defer stopWebhookListener()
+		watchWebhooks(ff)
 
-		ui.Show(ff)
+		logger.Info("Connected to Firefly III",
+			zap.String("profile", profile),
+			zap.String("api_url", ff.ServerURL()),
+			zap.String("user", ff.User.Email))
+
+		if final, ok := ui.Show(ff, connectUI, storeAPIKey).(*firefly.Api); ok {
+			saveSnapshot(viper.GetString("profile"), final)
+		}
 
 		viper.Set("logging.debug", false)
+		viper.Set("debug", false)
 
-		return viper.WriteConfigAs(viper.ConfigFileUsed())
+		return saveConfig()
 	},
 }
 
+// saveConfig writes the settings changed while running back to the config
+// file. Nothing is written without a config file, or when the user chose not
+// to save one in the setup wizard.
+func saveConfig() error {
+	path := viper.ConfigFileUsed()
+	if path == "" || setupDeclined {
+		return nil
+	}
+	return viper.WriteConfigAs(path)
+}
+
 var initConfigCmd = &cobra.Command{
 	Use:   "init-config",
 	Short: "Generate a default configuration file",
@@ -128,13 +168,31 @@ func Execute() {
 
 func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.config/ffiii-tui/config)")
-	rootCmd.PersistentFlags().StringP("firefly.api_key", "k", "your_firefly_api_key_here", "Firefly III API key")
+	rootCmd.PersistentFlags().StringP("firefly.api_key", "k", apiKeyPlaceholder, "Firefly III API key")
 	rootCmd.PersistentFlags().StringP("firefly.api_url", "u", "https://your-firefly-iii-instance.com/api/v1", "Firefly III API URL")
 	rootCmd.PersistentFlags().IntP("timeout", "t", 10, "Connection timeout")
+	rootCmd.PersistentFlags().Bool("firefly.tls.insecure_skip_verify", false, "Skip TLS certificate verification (self-signed instances)")
+	rootCmd.PersistentFlags().String("profile", "", "Config profile to use (default is the firefly section)")
 	rootCmd.Flags().BoolP("logging.debug", "d", false, "Enable debug logging")
 	rootCmd.Flags().StringP("logging.file", "l", "", "Log file path (if empty, logs to stdout)")
+	rootCmd.Flags().Bool("debug", false, "Write structured debug logs, including every API request, to a file")
+	rootCmd.Flags().Bool("demo", false, "Run with generated data instead of connecting to Firefly III")
 
 	rootCmd.AddCommand(initConfigCmd)
+	rootCmd.AddCommand(restoreCmd)
+}
+
+// debugLogPath returns the file --debug logs to when logging.file is not set.
+func debugLogPath() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(cacheDir, "ffiii-tui")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "debug.log"), nil
 }
 
 func initializeConfig(cmd *cobra.Command) error {
@@ -156,7 +214,7 @@ func initializeConfig(cmd *cobra.Command) error {
 
 	if err := viper.ReadInConfig(); err != nil {
 		var configFileNotFoundError viper.ConfigFileNotFoundError
-		if !errors.As(err, &configFileNotFoundError) {
+		if !errors.As(err, &configFileNotFoundError) && !errors.Is(err, fs.ErrNotExist) {
 			return fmt.Errorf("config file not found, %s", err.Error())
 		}
 	} else {
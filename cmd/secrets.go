@@ -0,0 +1,145 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+
+	"ffiii-tui/internal/secrets"
+)
+
+// apiKeyPlaceholder is the default of the --firefly.api_key flag.
+const apiKeyPlaceholder = "your_firefly_api_key_here"
+
+var (
+	// apiKeyFromFlag is set when the token was passed on the command line.
+	apiKeyFromFlag bool
+
+	secretStoreOnce sync.Once
+	secretStore     secrets.Store
+	secretStoreErr  error
+)
+
+// openSecretStore returns the store selected with secrets.storage. A nil
+// store means tokens are kept in the config file.
+func openSecretStore() (secrets.Store, error) {
+	secretStoreOnce.Do(func() {
+		secretStore, secretStoreErr = secrets.New(
+			viper.GetString("secrets.storage"),
+			filepath.Dir(configPath()),
+		)
+	})
+	return secretStore, secretStoreErr
+}
+
+// apiKeyForProfile returns the API token of the profile. A token passed on
+// the command line or found in the config file wins; both are moved to the
+// secret store, tokens from the config file on first use.
+func apiKeyForProfile(profile string) (string, error) {
+	configKey := profileKey(profile, "api_key")
+	apiKey := viper.GetString(configKey)
+	if apiKey == apiKeyPlaceholder {
+		apiKey = ""
+	}
+	fromFlag := apiKeyFromFlag && configKey == "firefly.api_key"
+
+	store, err := openSecretStore()
+	if err != nil {
+		return "", fmt.Errorf("failed to open secret store: %w", err)
+	}
+	if store == nil {
+		return apiKey, nil
+	}
+
+	if apiKey != "" {
+		switch {
+		case fromFlag:
+			storeFlagAPIKey(store, profile, configKey, apiKey)
+		case viper.InConfig(configKey):
+			migrateAPIKey(store, profile, configKey, apiKey)
+		}
+		return apiKey, nil
+	}
+
+	apiKey, err = store.Get(profileAccount(profile))
+	if errors.Is(err, secrets.ErrNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read API key from %s: %w", store.Name(), err)
+	}
+	return apiKey, nil
+}
+
+// storeAPIKey saves the token in the secret store, or in config when secret
+// storage is disabled.
+func storeAPIKey(profile, apiKey string) error {
+	store, err := openSecretStore()
+	if err != nil {
+		return fmt.Errorf("failed to open secret store: %w", err)
+	}
+	if store == nil {
+		viper.Set(profileKey(profile, "api_key"), apiKey)
+		return nil
+	}
+	if err := store.Set(profileAccount(profile), apiKey); err != nil {
+		return fmt.Errorf("failed to save API key to %s: %w", store.Name(), err)
+	}
+	viper.Set(profileKey(profile, "api_key"), "")
+	return nil
+}
+
+// migrateAPIKey moves a plaintext token out of the config file. Failures are
+// logged and the token stays where it was.
+func migrateAPIKey(store secrets.Store, profile, configKey, apiKey string) {
+	if err := store.Set(profileAccount(profile), apiKey); err != nil {
+		zap.L().Warn("Failed to migrate API key to secret store",
+			zap.String("profile", profile),
+			zap.String("store", store.Name()),
+			zap.Error(err))
+		return
+	}
+	viper.Set(configKey, "")
+
+	if path := viper.ConfigFileUsed(); path != "" {
+		if err := viper.WriteConfigAs(path); err != nil {
+			zap.L().Warn("Failed to remove API key from config", zap.Error(err))
+			return
+		}
+	}
+	zap.L().Info("Moved API key from config to secret store",
+		zap.String("profile", profileName(profile)),
+		zap.String("store", store.Name()))
+}
+
+// storeFlagAPIKey keeps a token passed on the command line in the secret
+// store. The config written on exit gets the placeholder instead, even when
+// the store fails, the token is passed again with the flag then.
+func storeFlagAPIKey(store secrets.Store, profile, configKey, apiKey string) {
+	if err := store.Set(profileAccount(profile), apiKey); err != nil {
+		zap.L().Warn("Failed to save API key to secret store",
+			zap.String("profile", profileName(profile)),
+			zap.String("store", store.Name()),
+			zap.Error(err))
+	}
+	viper.Set(configKey, apiKeyPlaceholder)
+}
+
+func profileAccount(profile string) string {
+	return "profile:" + profileName(profile)
+}
+
+func profileName(profile string) string {
+	if profile == "" {
+		return defaultProfile
+	}
+	return profile
+}
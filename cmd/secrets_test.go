@@ -0,0 +1,84 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"ffiii-tui/internal/secrets"
+)
+
+// useFileSecrets keeps the tokens in an encrypted file next to the config
+// in dir.
+func useFileSecrets(t *testing.T, dir string) {
+	t.Helper()
+	t.Setenv(secrets.PassphraseEnv, "")
+	viper.Reset()
+	cfgFile = filepath.Join(dir, "config.yaml")
+	viper.SetConfigFile(cfgFile)
+	viper.Set("secrets.storage", secrets.StorageFile)
+	secretStoreOnce, secretStore, secretStoreErr = sync.Once{}, nil, nil
+	t.Cleanup(func() {
+		viper.Reset()
+		cfgFile = ""
+		apiKeyFromFlag = false
+		secretStoreOnce, secretStore, secretStoreErr = sync.Once{}, nil, nil
+	})
+}
+
+func TestAPIKeyForProfile_FlagNotWrittenToConfig(t *testing.T) {
+	dir := t.TempDir()
+	useFileSecrets(t, dir)
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.StringP("firefly.api_key", "k", apiKeyPlaceholder, "")
+	if err := flags.Parse([]string{"-k", "flag-secret-token"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := viper.BindPFlags(flags); err != nil {
+		t.Fatal(err)
+	}
+	apiKeyFromFlag = true
+
+	apiKey, err := apiKeyForProfile("")
+	if err != nil || apiKey != "flag-secret-token" {
+		t.Fatalf("expected the token of the flag, got %q, %v", apiKey, err)
+	}
+
+	if err := viper.WriteConfigAs(cfgFile); err != nil {
+		t.Fatal(err)
+	}
+	written, err := os.ReadFile(cfgFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(written), "flag-secret-token") {
+		t.Errorf("expected no token in the config, got\n%s", written)
+	}
+	if !strings.Contains(string(written), apiKeyPlaceholder) {
+		t.Errorf("expected the placeholder in the config, got\n%s", written)
+	}
+
+	store, err := openSecretStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored, err := store.Get(profileAccount("")); err != nil || stored != "flag-secret-token" {
+		t.Errorf("expected the token in the secret store, got %q, %v", stored, err)
+	}
+
+	// The next start without the flag reads it from the store
+	apiKeyFromFlag = false
+	if apiKey, err := apiKeyForProfile(""); err != nil || apiKey != "flag-secret-token" {
+		t.Errorf("expected the stored token, got %q, %v", apiKey, err)
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"ffiii-tui/internal/firefly"
+)
+
+const apiPath = "/api/v1"
+
+// setupDeclined is set when the user chose not to write the configuration
+// in the setup wizard, it is then not written on exit either.
+var setupDeclined bool
+
+// needsSetup reports whether no config file was found and the credentials
+// were not passed on the command line or via environment.
+func needsSetup(cmd *cobra.Command) bool {
+	if viper.ConfigFileUsed() != "" {
+		if _, err := os.Stat(viper.ConfigFileUsed()); err == nil {
+			return false
+		}
+	}
+	for _, name := range []string{"firefly.api_key", "firefly.api_url"} {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil || flag.Changed || viper.GetString(name) != flag.DefValue {
+			return false
+		}
+	}
+	return true
+}
+
+// runSetupWizard asks for the server and token, checks the connection,
+// lets the user pick the primary currency and writes the config file.
+func runSetupWizard() error {
+	var (
+		baseURL string
+		apiKey  string
+		conn    connectionCheck
+	)
+
+	timeout := viper.GetInt("timeout")
+
+	credentials := huh.NewForm(
+		huh.NewGroup(
+			huh.NewNote().
+				Title("Welcome to ffiii-tui").
+				Description("No configuration found, let's connect to your Firefly III instance."),
+			huh.NewInput().
+				Title("Firefly III URL").
+				Placeholder("https://firefly.example.com").
+				Value(&baseURL).
+				Validate(validateBaseURL),
+			huh.NewInput().
+				Title("Personal access token").
+				EchoMode(huh.EchoModePassword).
+				Value(&apiKey).
+				Validate(func(token string) error {
+					if strings.TrimSpace(token) == "" {
+						return errors.New("token is required")
+					}
+					err := conn.check(firefly.ApiConfig{
+						ApiKey:         strings.TrimSpace(token),
+						ApiUrl:         apiURLFromBase(baseURL),
+						TimeoutSeconds: timeout,
+						TLS:            tlsConfig(defaultProfile),
+						Retry:          retryConfig(),
+					})
+					if err != nil {
+						return fmt.Errorf("connection failed: %w", err)
+					}
+					return nil
+				}),
+		),
+	)
+	if err := credentials.Run(); err != nil {
+		return fmt.Errorf("setup aborted: %w", err)
+	}
+
+	primary := ""
+	options := make([]huh.Option[string], 0, len(conn.currencies))
+	for _, cur := range conn.currencies {
+		if cur.Primary {
+			primary = cur.Code
+		}
+		options = append(options, huh.NewOption(fmt.Sprintf("%s - %s", cur.GetCode(), cur.Name), cur.Code))
+	}
+
+	path := configPath()
+	save := true
+	if len(options) > 0 {
+		settings := huh.NewForm(
+			huh.NewGroup(
+				huh.NewSelect[string]().
+					Title("Primary currency").
+					Description(fmt.Sprintf("Connected to Firefly III %s", conn.about.Version)).
+					Options(options...).
+					Value(&primary),
+				huh.NewConfirm().
+					Title(fmt.Sprintf("Write configuration to %s?", path)).
+					Value(&save),
+			),
+		)
+		if err := settings.Run(); err != nil {
+			return fmt.Errorf("setup aborted: %w", err)
+		}
+	}
+
+	viper.Set("firefly.api_url", apiURLFromBase(baseURL))
+	viper.Set("firefly.primary_currency", primary)
+
+	if !save {
+		setupDeclined = true
+		viper.Set("firefly.api_key", strings.TrimSpace(apiKey))
+		return nil
+	}
+
+	if err := storeAPIKey(defaultProfile, strings.TrimSpace(apiKey)); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	viper.SetConfigFile(path)
+	if err := viper.WriteConfigAs(path); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	if err := os.Chmod(path, 0o600); err != nil {
+		return fmt.Errorf("failed to set config permissions: %w", err)
+	}
+
+	fmt.Println("Configuration file created at:", path)
+	return nil
+}
+
+// connectionCheck checks the connection with the entered server and token
+// and keeps the outcome, huh validates the token both when leaving the
+// field and on submit.
+type connectionCheck struct {
+	checked    string
+	err        error
+	about      firefly.About
+	currencies []firefly.Currency
+}
+
+// check connects with config unless its server and token were checked last.
+func (c *connectionCheck) check(config firefly.ApiConfig) error {
+	key := config.ApiUrl + "\n" + config.ApiKey
+	if key == c.checked {
+		return c.err
+	}
+	c.checked = key
+	c.about, c.currencies, c.err = firefly.CheckConnection(context.Background(), config)
+	return c.err
+}
+
+func validateBaseURL(s string) error {
+	u, err := url.Parse(strings.TrimSpace(s))
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return errors.New("enter a full URL, e.g. https://firefly.example.com")
+	}
+	return nil
+}
+
+// apiURLFromBase turns the instance URL into the API endpoint URL. URLs that
+// already point at the API are kept as is.
+func apiURLFromBase(base string) string {
+	base = strings.TrimRight(strings.TrimSpace(base), "/")
+	if strings.HasSuffix(base, apiPath) {
+		return base
+	}
+	return base + apiPath
+}
+
+func configPath() string {
+	if cfgFile != "" {
+		return cfgFile
+	}
+	home, err := os.UserHomeDir()
+	cobra.CheckErr(err)
+	return filepath.Join(home, ".config", "ffiii-tui", "config.yaml")
+}
@@ -0,0 +1,83 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"ffiii-tui/internal/firefly"
+)
+
+func TestConnectionCheck_ChecksEachTokenOnce(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v1/about" {
+			_, _ = w.Write([]byte(`{"data":{"version":"6.2.0"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	var conn connectionCheck
+	config := firefly.ApiConfig{ApiKey: "token", ApiUrl: apiURLFromBase(server.URL), TimeoutSeconds: 5}
+	for range 2 {
+		if err := conn.check(config); err != nil {
+			t.Fatalf("check failed: %v", err)
+		}
+	}
+	if got := hits.Load(); got != 2 {
+		t.Errorf("expected one check of about and currencies, got %d requests", got)
+	}
+	if conn.about.Version != "6.2.0" {
+		t.Errorf("expected the server version kept, got %q", conn.about.Version)
+	}
+
+	config.ApiKey = "other"
+	if err := conn.check(config); err != nil {
+		t.Fatalf("check failed: %v", err)
+	}
+	if got := hits.Load(); got != 4 {
+		t.Errorf("expected another token checked again, got %d requests", got)
+	}
+}
+
+func TestSaveConfig_SkipsWithoutConfigFile(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.Set("firefly.api_key", "secret")
+
+	if err := saveConfig(); err != nil {
+		t.Errorf("expected nothing written without a config file, got %v", err)
+	}
+}
+
+func TestSaveConfig_SkipsWhenSetupDeclined(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	viper.Reset()
+	viper.SetConfigFile(path)
+	setupDeclined = true
+	t.Cleanup(func() {
+		viper.Reset()
+		setupDeclined = false
+	})
+	viper.Set("firefly.api_key", "secret")
+
+	if err := saveConfig(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected no config written after declining to save it, got %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+
+	"ffiii-tui/internal/firefly"
+)
+
+// liveUpdates receives the webhook calls of all profiles, nil unless
+// webhook.listen is set.
+var liveUpdates *firefly.WebhookListener
+
+// startWebhookListener listens on webhook.listen, if set. Live updates are
+// optional, so a failure is logged and the program runs without them.
+func startWebhookListener() {
+	addr := viper.GetString("webhook.listen")
+	if addr == "" {
+		return
+	}
+	if viper.GetString("webhook.url") == "" {
+		zap.L().Warn("Live updates need webhook.url, the address Firefly III reaches webhook.listen at")
+		return
+	}
+	l := firefly.NewWebhookListener(addr)
+	if err := l.Start(); err != nil {
+		zap.L().Warn("Live updates are off", zap.Error(err))
+		return
+	}
+	liveUpdates = l
+}
+
+func stopWebhookListener() {
+	if liveUpdates == nil {
+		return
+	}
+	if err := liveUpdates.Close(); err != nil {
+		zap.L().Warn("Failed to stop webhook listener", zap.Error(err))
+	}
+}
+
+// watchWebhooks registers the webhooks of ff in the background, so changes
+// made in the web interface or the apps show up without a refresh.
+func watchWebhooks(ff *firefly.Api) {
+	if liveUpdates == nil {
+		return
+	}
+	ff.ListenWebhooks(liveUpdates)
+	url := viper.GetString("webhook.url")
+	go func() {
+		if err := ff.RegisterWebhooks(context.Background(), url); err != nil {
+			zap.L().Warn("Failed to register webhooks, live updates are off",
+				zap.String("api_url", ff.ServerURL()),
+				zap.Error(err))
+		}
+	}()
+}
@@ -0,0 +1,28 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package browser opens URLs with the browser of the platform.
+package browser
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// Open starts the platform opener for url without waiting for the browser.
+func Open(url string) error {
+	name, args := command(url)
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return fmt.Errorf("no browser opener found: %w", err)
+	}
+	cmd := exec.Command(path, args...)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open %s: %w", url, err)
+	}
+	// Reap the opener once it has handed the URL to the browser
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
@@ -0,0 +1,9 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package browser
+
+func command(url string) (string, []string) {
+	return "rundll32", []string{"url.dll,FileProtocolHandler", url}
+}
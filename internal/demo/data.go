@@ -0,0 +1,267 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package demo
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+
+	"ffiii-tui/internal/firefly"
+)
+
+// months is the number of months of history generated, including the
+// current one.
+const months = 6
+
+// reconciledAfter is the age after which transactions are reconciled, as if
+// the statements up to two weeks ago were matched.
+const reconciledAfter = 14 * 24 * time.Hour
+
+// demoTags are the tags of the generated transactions by description.
+var demoTags = map[string][]string{
+	"Fuel":                 {"car"},
+	"Car loan installment": {"car"},
+	"Dinner":               {"social"},
+	"Website redesign":     {"freelance"},
+}
+
+// generator builds the demo data set. The same seed and date always give
+// the same data, so screenshots can be reproduced.
+type generator struct {
+	api *Api
+	rnd *rand.Rand
+	now time.Time
+}
+
+func (g *generator) account(accountType, name string) firefly.Account {
+	for _, a := range g.api.accounts[accountType] {
+		if a.Name == name {
+			return a
+		}
+	}
+	panic("demo: unknown account " + name)
+}
+
+func (g *generator) category(name string) firefly.Category {
+	for _, c := range g.api.categories {
+		if c.Name == name {
+			return c
+		}
+	}
+	panic("demo: unknown category " + name)
+}
+
+// amount returns a random amount between lo and hi rounded to cents.
+func (g *generator) amount(lo, hi float64) float64 {
+	return math.Round((lo+g.rnd.Float64()*(hi-lo))*100) / 100
+}
+
+func (g *generator) generate() {
+	api := g.api
+	first := time.Date(g.now.Year(), g.now.Month()-months+1, 1, 0, 0, 0, 0, g.now.Location())
+	opened := first.AddDate(0, 0, -1).Format(time.DateOnly)
+
+	for _, a := range []firefly.Account{
+		{Name: "Checking account", OpeningBalance: 3150, IBAN: "DE89 3704 0044 0532 0130 00"},
+		{Name: "Savings account", OpeningBalance: 8200, IBAN: "DE02 1203 0000 0000 2020 51", Notes: "Emergency fund"},
+		{Name: "Cash wallet", OpeningBalance: 80},
+		{
+			Name: "Credit card", OpeningBalance: -340, Role: "ccAsset", CreditLimit: 2000,
+			MonthlyPaymentDate: time.Date(g.now.Year(), g.now.Month(), 15, 0, 0, 0, 0, g.now.Location()).Format(time.DateOnly),
+		},
+	} {
+		a.Type, a.CurrencyCode, a.OpeningBalanceDate = "asset", api.currency.Code, opened
+		api.addAccount(a, a.OpeningBalance)
+	}
+	for _, name := range []string{
+		"Supermarket", "Farmers market", "Landlord", "Electricity company",
+		"Internet provider", "Mobile operator", "Streaming service", "Gym",
+		"Coffee shop", "Restaurant", "Fuel station", "Public transport",
+		"Online store", "Pharmacy",
+	} {
+		api.addAccount(firefly.Account{Name: name, Type: "expense"}, 0)
+	}
+	for _, name := range []string{"Employer", "Freelance client", "Bank interest"} {
+		api.addAccount(firefly.Account{Name: name, Type: "revenue"}, 0)
+	}
+	api.addAccount(firefly.Account{
+		Name: "Car loan", Type: "liabilities", CurrencyCode: api.currency.Code, LiabilityDirection: "debit",
+		LiabilityType: "loan", AccountNumber: "CL-2025-0815", OpeningBalance: -9600, OpeningBalanceDate: opened,
+		Interest: "4.9", InterestPeriod: "yearly",
+	}, -9600)
+	api.addAccount(firefly.Account{
+		Name: "Loan to a friend", Type: "liabilities", CurrencyCode: api.currency.Code, LiabilityDirection: "credit",
+		LiabilityType: "debt", OpeningBalance: 500, OpeningBalanceDate: opened, Notes: "To be paid back by summer",
+	}, 500)
+
+	for _, name := range []string{
+		"Salary", "Side income", "Interest", "Housing", "Utilities", "Groceries",
+		"Dining out", "Transport", "Entertainment", "Health", "Shopping",
+	} {
+		api.addCategory(name)
+	}
+
+	for month := first; !month.After(g.now); month = month.AddDate(0, 1, 0) {
+		g.month(month)
+	}
+	for _, name := range []string{"car", "freelance", "social"} {
+		api.addTag(name)
+	}
+
+	// One goal keeping pace and one falling behind
+	date := func(months int) string {
+		return first.AddDate(0, months, 0).Format(time.DateOnly)
+	}
+	api.piggyBanks = []firefly.PiggyBank{
+		{ID: api.nextID(), Name: "New laptop", Target: 1500, Saved: 250, StartDate: date(0), TargetDate: date(months)},
+		{ID: api.nextID(), Name: "Summer holiday", Target: 2400, Saved: 1600, StartDate: date(0), TargetDate: date(months + 3)},
+	}
+	for i := range api.piggyBanks {
+		api.piggyBanks[i].CurrencyCode = api.currency.Code
+	}
+
+	slices.SortStableFunc(api.transactions, func(a, b firefly.Transaction) int {
+		return strings.Compare(b.Date, a.Date)
+	})
+}
+
+// month adds the transactions of one month, up to today.
+func (g *generator) month(start time.Time) {
+	days := start.AddDate(0, 1, -1).Day()
+	on := func(day int) time.Time {
+		return time.Date(start.Year(), start.Month(), min(day, days), 0, 0, 0, 0, start.Location())
+	}
+	anyDay := func() time.Time {
+		return on(1 + g.rnd.IntN(days))
+	}
+
+	checking := g.account("asset", "Checking account")
+	savings := g.account("asset", "Savings account")
+	wallet := g.account("asset", "Cash wallet")
+
+	deposit := func(date time.Time, from, category string, amount float64, description string) {
+		g.add(date, "deposit", "", split{g.account("revenue", from), checking, category, amount, description})
+	}
+	withdraw := func(date time.Time, source firefly.Account, to, category string, amount float64, description string) {
+		g.add(date, "withdrawal", "", split{source, g.account("expense", to), category, amount, description})
+	}
+
+	deposit(on(1), "Employer", "Salary", g.amount(3700, 3900), "Salary "+start.Format("January"))
+	if g.rnd.IntN(2) == 0 {
+		deposit(anyDay(), "Freelance client", "Side income", g.amount(300, 900), "Website redesign")
+	}
+	g.add(on(days), "deposit", "", split{g.account("revenue", "Bank interest"), savings, "Interest", g.amount(8, 15), "Savings interest"})
+
+	withdraw(on(3), checking, "Landlord", "Housing", 1150, "Rent")
+	withdraw(on(5), checking, "Electricity company", "Utilities", g.amount(60, 110), "Electricity bill")
+	withdraw(on(7), checking, "Internet provider", "Utilities", 39.99, "Internet")
+	withdraw(on(9), checking, "Mobile operator", "Utilities", 25, "Phone plan")
+	withdraw(on(12), checking, "Streaming service", "Entertainment", 12.99, "Streaming subscription")
+	withdraw(on(15), checking, "Gym", "Health", 35, "Gym membership")
+
+	g.add(on(2), "transfer", "", split{checking, savings, "", g.amount(300, 500), "Monthly savings"})
+	g.add(on(4), "transfer", "", split{checking, g.account("liabilities", "Car loan"), "", 320, "Car loan installment"})
+	g.add(on(6), "transfer", "", split{checking, wallet, "", 150, "Cash withdrawal"})
+
+	for range 10 + g.rnd.IntN(5) {
+		shop := "Supermarket"
+		if g.rnd.IntN(4) == 0 {
+			shop = "Farmers market"
+		}
+		withdraw(anyDay(), checking, shop, "Groceries", g.amount(20, 120), "Groceries")
+	}
+	for range 8 + g.rnd.IntN(5) {
+		place, description, source := "Coffee shop", "Coffee", wallet
+		if g.rnd.IntN(3) == 0 {
+			place, description, source = "Restaurant", "Dinner", checking
+		}
+		lo, hi := 3.5, 9.0
+		if place == "Restaurant" {
+			lo, hi = 25, 80
+		}
+		withdraw(anyDay(), source, place, "Dining out", g.amount(lo, hi), description)
+	}
+	for range 4 + g.rnd.IntN(3) {
+		withdraw(anyDay(), checking, "Fuel station", "Transport", g.amount(40, 80), "Fuel")
+	}
+	for range 3 + g.rnd.IntN(3) {
+		withdraw(anyDay(), wallet, "Public transport", "Transport", g.amount(2.5, 30), "Tickets")
+	}
+	for range 2 + g.rnd.IntN(3) {
+		withdraw(anyDay(), checking, "Online store", "Shopping", g.amount(15, 200), "Online order")
+	}
+	if g.rnd.IntN(3) == 0 {
+		withdraw(anyDay(), checking, "Pharmacy", "Health", g.amount(10, 60), "Pharmacy")
+	}
+
+	// A receipt split between groceries and household goods
+	store := g.account("expense", "Supermarket")
+	g.add(anyDay(), "withdrawal", "Weekly shopping",
+		split{checking, store, "Groceries", g.amount(40, 90), "Food"},
+		split{checking, store, "Shopping", g.amount(10, 40), "Household goods"})
+}
+
+type split struct {
+	source, destination firefly.Account
+	category            string
+	amount              float64
+	description         string
+}
+
+// add records a generated transaction unless it lies in the future.
+func (g *generator) add(date time.Time, ttype, groupTitle string, splits ...split) {
+	if date.After(g.now) {
+		return
+	}
+	tx := firefly.Transaction{
+		TransactionID: g.api.nextID(),
+		Type:          ttype,
+		Date:          date.Format(time.RFC3339),
+		GroupTitle:    groupTitle,
+		UpdatedAt:     date,
+	}
+	for _, s := range splits {
+		var category firefly.Category
+		if s.category != "" {
+			category = g.category(s.category)
+		}
+		tx.Splits = append(tx.Splits, firefly.Split{
+			TransactionJournalID: g.api.nextID(),
+			Source:               s.source,
+			Destination:          s.destination,
+			Category:             category,
+			Currency:             g.api.currency.Code,
+			Amount:               s.amount,
+			Description:          s.description,
+			Reconciled:           g.now.Sub(date) > reconciledAfter,
+			Tags:                 slices.Clone(demoTags[s.description]),
+		})
+	}
+	g.api.transactions = append(g.api.transactions, tx)
+}
+
+// formatMoney formats an amount the way Firefly III does in summaries,
+// e.g. €-1,234.56.
+func formatMoney(symbol string, amount float64) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	whole := fmt.Sprintf("%.2f", amount)
+	intPart, frac, _ := strings.Cut(whole, ".")
+	var b strings.Builder
+	for i, r := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	return symbol + sign + b.String() + "." + frac
+}
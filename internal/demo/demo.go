@@ -0,0 +1,1229 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package demo provides an in-memory stand-in for the Firefly III client
+// with generated accounts, categories and transactions, so the UI can be
+// tried without a server.
+package demo
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"ffiii-tui/internal/firefly"
+)
+
+// ServerURL is reported as the server of the demo data.
+const ServerURL = "demo"
+
+// Api serves generated data through the same methods as firefly.Api.
+// Changes are kept in memory only.
+type Api struct {
+	mu sync.Mutex
+
+	StartDate time.Time
+	EndDate   time.Time
+
+	currency     firefly.Currency
+	accounts     map[string][]firefly.Account
+	opening      map[string]float64
+	categories   []firefly.Category
+	tags         []firefly.Tag
+	transactions []firefly.Transaction // newest first
+	recurrences  []firefly.RequestRecurrence
+	budgetLimits map[string]float64 // by budget name and month, see limitKey
+	piggyBanks   []firefly.PiggyBank
+	lastID       int
+
+	// today is when the data was generated, bills are due after it
+	today time.Time
+}
+
+// demoBudgets are the monthly limits of the budgets named like categories.
+var demoBudgets = map[string]float64{
+	"Groceries":     450,
+	"Dining out":    150,
+	"Entertainment": 80,
+}
+
+// demoBills are the subscriptions paid every month on day, named like the
+// expense accounts they are paid to.
+var demoBills = []struct {
+	name      string
+	day       int
+	amountMin float64
+	amountMax float64
+}{
+	{"Landlord", 3, 1150, 1150},
+	{"Electricity company", 5, 60, 110},
+	{"Internet provider", 7, 39.99, 39.99},
+	{"Mobile operator", 9, 25, 25},
+	{"Streaming service", 12, 12.99, 12.99},
+	{"Gym", 15, 35, 35},
+}
+
+// New generates the demo data for the months up to now.
+func New(seed uint64, now time.Time) *Api {
+	api := &Api{
+		currency: firefly.Currency{ID: "1", Code: "EUR", Name: "Euro", Symbol: "€", Primary: true},
+		accounts: make(map[string][]firefly.Account),
+		opening:  make(map[string]float64),
+
+		budgetLimits: make(map[string]float64),
+		today:        now,
+	}
+	api.SetPeriod(now.Year(), now.Month())
+
+	g := &generator{api: api, rnd: rand.New(rand.NewPCG(seed, seed)), now: now}
+	g.generate()
+	return api
+}
+
+func (api *Api) nextID() string {
+	api.lastID++
+	return strconv.Itoa(api.lastID)
+}
+
+func (api *Api) addAccount(account firefly.Account, opening float64) {
+	account.ID = api.nextID()
+	api.accounts[account.Type] = append(api.accounts[account.Type], account)
+	api.opening[account.ID] = opening
+}
+
+func (api *Api) addCategory(name string) {
+	api.categories = append(api.categories, firefly.Category{
+		ID:           api.nextID(),
+		Name:         name,
+		CurrencyCode: api.currency.Code,
+	})
+}
+
+func (api *Api) addTag(name string) {
+	api.tags = append(api.tags, firefly.Tag{ID: api.nextID(), Name: name})
+}
+
+// PeriodAPI
+
+func (api *Api) PreviousPeriod() {
+	api.SetPeriod(api.StartDate.Year(), api.StartDate.Month()-1)
+}
+
+func (api *Api) NextPeriod() {
+	api.SetPeriod(api.StartDate.Year(), api.StartDate.Month()+1)
+}
+
+func (api *Api) SetPeriod(year int, month time.Month) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	api.StartDate = time.Date(year, month, 1, 0, 0, 0, 0, time.Local)
+	api.EndDate = api.StartDate.AddDate(0, 1, 0).Add(-time.Nanosecond)
+}
+
+func (api *Api) PeriodStart() time.Time {
+	return api.StartDate
+}
+
+func (api *Api) PeriodEnd() time.Time {
+	return api.EndDate
+}
+
+// inPeriod reports whether tx falls into the selected period.
+func (api *Api) inPeriod(tx firefly.Transaction) bool {
+	date, err := time.Parse(time.RFC3339, tx.Date)
+	if err != nil {
+		return false
+	}
+	return !date.Before(api.StartDate) && !date.After(api.EndDate)
+}
+
+// eachSplit calls fn for every split of the transactions of the period.
+func (api *Api) eachSplit(fn func(tx firefly.Transaction, s firefly.Split)) {
+	for _, tx := range api.transactions {
+		if !api.inPeriod(tx) {
+			continue
+		}
+		for _, s := range tx.Splits {
+			fn(tx, s)
+		}
+	}
+}
+
+// CurrencyAPI
+
+func (api *Api) PrimaryCurrency() firefly.Currency {
+	return api.currency
+}
+
+// demoCurrencies are the other currencies accounts may be created in.
+var demoCurrencies = []firefly.Currency{
+	{ID: "2", Code: "USD", Name: "US Dollar", Symbol: "$"},
+	{ID: "3", Code: "GBP", Name: "British Pound", Symbol: "£"},
+	{ID: "4", Code: "CHF", Name: "Swiss Franc", Symbol: "CHF"},
+}
+
+func (api *Api) GetCurrencyByCode(code string) firefly.Currency {
+	for _, cur := range append([]firefly.Currency{api.currency}, demoCurrencies...) {
+		if strings.EqualFold(cur.Code, code) {
+			return cur
+		}
+	}
+	return firefly.Currency{}
+}
+
+// demoRates are the rates to EUR of the currencies accounts may be created in.
+var demoRates = map[string]float64{
+	"USD": 0.92,
+	"GBP": 1.17,
+	"CHF": 1.06,
+}
+
+func (api *Api) ConvertToPrimary(amount float64, currencyCode string) (float64, bool) {
+	rate, ok := demoRates[strings.ToUpper(currencyCode)]
+	if !ok {
+		return amount, false
+	}
+	return amount * rate, true
+}
+
+// SummaryAPI
+
+func (api *Api) UpdateSummary(_ context.Context) error {
+	return nil
+}
+
+func (api *Api) SummaryItems() map[string]firefly.SummaryItem {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	var spent, earned float64
+	api.eachSplit(func(tx firefly.Transaction, s firefly.Split) {
+		switch tx.Type {
+		case "withdrawal":
+			spent -= s.Amount
+		case "deposit":
+			earned += s.Amount
+		}
+	})
+	netWorth := 0.0
+	for _, accountType := range []string{"asset", "liabilities"} {
+		for _, a := range api.accounts[accountType] {
+			netWorth += api.balance(a.ID)
+		}
+	}
+
+	code := api.currency.Code
+	item := func(key, title string, value float64) firefly.SummaryItem {
+		return firefly.SummaryItem{
+			Key:           key + "-in-" + code,
+			Title:         fmt.Sprintf("%s (%s)", title, code),
+			MonetaryValue: value,
+			CurrencyID:    api.currency.ID,
+			CurrencyCode:  code,
+			ValueParsed:   formatMoney(api.currency.Symbol, value),
+		}
+	}
+	items := map[string]firefly.SummaryItem{}
+	for _, i := range []firefly.SummaryItem{
+		item("balance", "Balance", earned+spent),
+		item("spent", "Spent", spent),
+		item("earned", "Earned", earned),
+		item("net-worth", "Net worth", netWorth),
+	} {
+		items[i.Key] = i
+	}
+	return items
+}
+
+func (api *Api) PiggyBanks() []firefly.PiggyBank {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	return slices.Clone(api.piggyBanks)
+}
+
+// BurnRate averages the withdrawals from the asset accounts in the period
+// up to today, when the period is the current one.
+func (api *Api) BurnRate() firefly.BurnRate {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	passed, left := firefly.PeriodDays(api.StartDate, api.EndDate, api.today)
+	rate := firefly.BurnRate{
+		DaysPassed:   passed,
+		DaysLeft:     left,
+		Daily:        map[string]float64{},
+		CurrencyCode: api.currency.Code,
+	}
+	if !rate.Active() {
+		return rate
+	}
+	api.eachSplit(func(tx firefly.Transaction, s firefly.Split) {
+		if tx.Type == "withdrawal" && s.Source.Type == "asset" {
+			rate.Daily[s.Source.ID] += s.Amount / float64(passed)
+		}
+	})
+	for _, a := range api.accounts["asset"] {
+		rate.Balance += api.balance(a.ID)
+		rate.DailyTotal += rate.Daily[a.ID]
+	}
+	return rate
+}
+
+// SubscriptionsDue returns the bills expected in the DueSoonDays days
+// after today, the ones before are paid by the generated data.
+func (api *Api) SubscriptionsDue() []firefly.Subscription {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	today := time.Date(api.today.Year(), api.today.Month(), api.today.Day(), 0, 0, 0, 0, api.today.Location())
+	end := today.AddDate(0, 0, firefly.DueSoonDays)
+
+	due := []firefly.Subscription{}
+	for _, bill := range demoBills {
+		for _, month := range []time.Time{today, today.AddDate(0, 0, 1-today.Day()).AddDate(0, 1, 0)} {
+			last := time.Date(month.Year(), month.Month()+1, 0, 0, 0, 0, 0, month.Location()).Day()
+			date := time.Date(month.Year(), month.Month(), min(bill.day, last), 0, 0, 0, 0, month.Location())
+			if !date.After(today) || date.After(end) {
+				continue
+			}
+			due = append(due, firefly.Subscription{
+				ID:           bill.name,
+				Name:         bill.name,
+				AmountMin:    bill.amountMin,
+				AmountMax:    bill.amountMax,
+				CurrencyCode: api.currency.Code,
+				DueDates:     []string{date.Format(time.DateOnly)},
+			})
+		}
+	}
+	slices.SortFunc(due, func(a, b firefly.Subscription) int {
+		return strings.Compare(a.DueDates[0], b.DueDates[0])
+	})
+	return due
+}
+
+func (api *Api) GetMaxWidth() int {
+	maxLength := 0
+	for _, s := range api.SummaryItems() {
+		maxLength = max(maxLength, utf8.RuneCountInString(s.Title)+utf8.RuneCountInString(s.ValueParsed))
+	}
+	return maxLength + 1
+}
+
+// AccountsAPI
+
+func (api *Api) UpdateAccounts(_ context.Context, _ string) error {
+	return nil
+}
+
+func (api *Api) AccountsByType(accountType string) []firefly.Account {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	return slices.Clone(api.accounts[accountType])
+}
+
+func (api *Api) AccountBalance(accountID string) float64 {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	return api.balance(accountID)
+}
+
+// AccountLastActivity returns the date of the latest transaction of the
+// account.
+func (api *Api) AccountLastActivity(accountID string) string {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	last := ""
+	for _, tx := range api.transactions {
+		for _, s := range tx.Splits {
+			if s.Source.ID == accountID || s.Destination.ID == accountID {
+				last = max(last, tx.Date)
+			}
+		}
+	}
+	return last
+}
+
+func (api *Api) AccountTransactions(_ context.Context, accountID string, start, end time.Time) ([]firefly.Transaction, error) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	transactions := []firefly.Transaction{}
+	for _, tx := range api.transactions {
+		date, err := time.Parse(time.RFC3339, tx.Date)
+		if err != nil || date.Before(start) || date.After(end) {
+			continue
+		}
+		if !slices.ContainsFunc(tx.Splits, func(s firefly.Split) bool {
+			return s.Source.ID == accountID || s.Destination.ID == accountID
+		}) {
+			continue
+		}
+		tx.ID = uint(len(transactions))
+		tx.Splits = slices.Clone(tx.Splits)
+		transactions = append(transactions, tx)
+	}
+	return transactions, nil
+}
+
+// balance returns the current balance of an asset or liability account.
+func (api *Api) balance(accountID string) float64 {
+	balance := api.opening[accountID]
+	for _, tx := range api.transactions {
+		for _, s := range tx.Splits {
+			if s.Source.ID == accountID {
+				balance -= s.Amount
+			}
+			if s.Destination.ID == accountID {
+				balance += s.Amount
+			}
+		}
+	}
+	return balance
+}
+
+func (api *Api) accountByID(id string) firefly.Account {
+	for _, accounts := range api.accounts {
+		for _, a := range accounts {
+			if a.ID == id {
+				return a
+			}
+		}
+	}
+	return firefly.Account{}
+}
+
+func (api *Api) CreateAssetAccount(_ context.Context, na firefly.NewAsset) error {
+	return api.createAccount(firefly.Account{
+		Name:               na.Name,
+		Type:               "asset",
+		CurrencyCode:       strings.ToUpper(na.CurrencyCode),
+		Role:               na.Role,
+		OpeningBalance:     na.OpeningBalance,
+		OpeningBalanceDate: na.OpeningBalanceDate,
+	})
+}
+
+func (api *Api) CreateExpenseAccount(_ context.Context, name string) error {
+	return api.createAccount(firefly.Account{Name: name, Type: "expense"})
+}
+
+func (api *Api) CreateRevenueAccount(_ context.Context, name string) error {
+	return api.createAccount(firefly.Account{Name: name, Type: "revenue"})
+}
+
+func (api *Api) CreateLiabilityAccount(_ context.Context, nl firefly.NewLiability) error {
+	return api.createAccount(firefly.Account{
+		Name:               nl.Name,
+		Type:               "liabilities",
+		CurrencyCode:       strings.ToUpper(nl.CurrencyCode),
+		LiabilityDirection: nl.Direction,
+		LiabilityType:      nl.Type,
+	})
+}
+
+func (api *Api) createAccount(account firefly.Account) error {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	for _, a := range api.accounts[account.Type] {
+		if strings.EqualFold(a.Name, account.Name) {
+			return fmt.Errorf("API error: account %q already exists", account.Name)
+		}
+	}
+	api.addAccount(account, account.OpeningBalance)
+	return nil
+}
+
+func (api *Api) UpdateOpeningBalance(_ context.Context, account firefly.Account, amount float64, date string) error {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	accounts := api.accounts[account.Type]
+	i := slices.IndexFunc(accounts, func(a firefly.Account) bool { return a.ID == account.ID })
+	if i < 0 {
+		return &firefly.HTTPError{StatusCode: http.StatusNotFound}
+	}
+	if amount == 0 {
+		date = ""
+	}
+	accounts[i].OpeningBalance = amount
+	accounts[i].OpeningBalanceDate = date
+	api.opening[account.ID] = amount
+	return nil
+}
+
+// Backup returns all generated data, see firefly.Api.Backup.
+func (api *Api) Backup(_ context.Context, now time.Time) (firefly.Backup, error) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	backup := firefly.Backup{
+		CreatedAt:    now,
+		Server:       ServerURL,
+		Balances:     map[string]float64{},
+		Categories:   slices.Clone(api.categories),
+		Transactions: slices.Clone(api.transactions),
+	}
+	for _, accountType := range slices.Sorted(maps.Keys(api.accounts)) {
+		for _, a := range api.accounts[accountType] {
+			backup.Accounts = append(backup.Accounts, a)
+			backup.Balances[a.ID] = api.balance(a.ID)
+		}
+	}
+	return backup, nil
+}
+
+// Insights
+
+// ForgetInsights is a no-op, demo insights are computed on every call.
+func (api *Api) ForgetInsights() {}
+
+func (api *Api) UpdateExpenseInsights(_ context.Context) error {
+	return nil
+}
+
+func (api *Api) GetExpenseDiff(accountID string) float64 {
+	return api.accountDiffs("withdrawal")[accountID]
+}
+
+func (api *Api) GetTotalExpenseDiff() float64 {
+	return sum(api.accountDiffs("withdrawal"))
+}
+
+func (api *Api) ExpenseTotals() map[string]float64 {
+	return api.currencyDiffs("withdrawal")
+}
+
+func (api *Api) UpdateRevenueInsights(_ context.Context) error {
+	return nil
+}
+
+func (api *Api) GetRevenueDiff(accountID string) float64 {
+	return api.accountDiffs("deposit")[accountID]
+}
+
+func (api *Api) GetTotalRevenueDiff() float64 {
+	return sum(api.accountDiffs("deposit"))
+}
+
+func (api *Api) RevenueTotals() map[string]float64 {
+	return api.currencyDiffs("deposit")
+}
+
+// currencyDiffs sums the withdrawals or deposits in the period by currency.
+func (api *Api) currencyDiffs(ttype string) map[string]float64 {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	diffs := map[string]float64{}
+	api.eachSplit(func(tx firefly.Transaction, s firefly.Split) {
+		if tx.Type == ttype {
+			diffs[api.splitCurrency(s)] += s.Amount
+		}
+	})
+	return diffs
+}
+
+// splitCurrency returns the currency of s, the primary one if unset.
+func (api *Api) splitCurrency(s firefly.Split) string {
+	if s.Currency == "" {
+		return api.currency.Code
+	}
+	return s.Currency
+}
+
+// accountDiffs sums the withdrawals by expense account or the deposits by
+// revenue account in the period.
+func (api *Api) accountDiffs(ttype string) map[string]float64 {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	diffs := map[string]float64{}
+	api.eachSplit(func(tx firefly.Transaction, s firefly.Split) {
+		switch {
+		case tx.Type != ttype:
+		case ttype == "withdrawal":
+			diffs[s.Destination.ID] += s.Amount
+		default:
+			diffs[s.Source.ID] += s.Amount
+		}
+	})
+	return diffs
+}
+
+func sum(values map[string]float64) float64 {
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+// CategoriesAPI
+
+func (api *Api) UpdateCategories(_ context.Context) error {
+	return nil
+}
+
+func (api *Api) UpdateCategoriesInsights(_ context.Context) error {
+	return nil
+}
+
+func (api *Api) CategoriesList() []firefly.Category {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	return slices.Clone(api.categories)
+}
+
+func (api *Api) GetTotalSpentEarnedCategories() (spent, earned float64) {
+	spentBy, earnedBy := api.categoryTotals()
+	return sum(spentBy), sum(earnedBy)
+}
+
+func (api *Api) CategoryTotals() (spent, earned map[string]float64) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	spent, earned = map[string]float64{}, map[string]float64{}
+	api.eachSplit(func(tx firefly.Transaction, s firefly.Split) {
+		if s.Category.ID == "" {
+			return
+		}
+		switch tx.Type {
+		case "withdrawal":
+			spent[api.splitCurrency(s)] += s.Amount
+		case "deposit":
+			earned[api.splitCurrency(s)] += s.Amount
+		}
+	})
+	return spent, earned
+}
+
+func (api *Api) CategoryBudget(categoryName string) (firefly.Budget, bool) {
+	if _, ok := demoBudgets[categoryName]; !ok {
+		return firefly.Budget{}, false
+	}
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	return api.budget(categoryName), true
+}
+
+// limitKey keys the limit of a budget in the month of start.
+func limitKey(name string, start time.Time) string {
+	return name + "|" + start.Format("2006-01")
+}
+
+// budget returns the budget of the period, with the limit set for it or the
+// default one.
+func (api *Api) budget(name string) firefly.Budget {
+	limit, ok := api.budgetLimits[limitKey(name, api.StartDate)]
+	if !ok {
+		limit = demoBudgets[name]
+	}
+	budget := firefly.Budget{ID: name, Name: name, Limit: limit, CurrencyCode: api.currency.Code}
+	api.eachSplit(func(tx firefly.Transaction, s firefly.Split) {
+		if tx.Type == "withdrawal" && s.Category.Name == name {
+			budget.Spent += s.Amount
+		}
+	})
+	return budget
+}
+
+// BudgetAPI
+
+func (api *Api) Budgets() []firefly.Budget {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	budgets := []firefly.Budget{}
+	for _, name := range slices.Sorted(maps.Keys(demoBudgets)) {
+		budgets = append(budgets, api.budget(name))
+	}
+	return budgets
+}
+
+// BudgetAverages averages the withdrawals in the category of each budget
+// over the months before the month of before.
+func (api *Api) BudgetAverages(_ context.Context, months int, before time.Time) (map[string]float64, error) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	end := time.Date(before.Year(), before.Month(), 1, 0, 0, 0, 0, before.Location())
+	start := end.AddDate(0, -months, 0)
+
+	averages := make(map[string]float64)
+	for _, tx := range api.transactions {
+		date, err := time.Parse(time.RFC3339, tx.Date)
+		if err != nil || tx.Type != "withdrawal" || date.Before(start) || !date.Before(end) {
+			continue
+		}
+		for _, s := range tx.Splits {
+			if _, ok := demoBudgets[s.Category.Name]; ok {
+				averages[s.Category.Name] += s.Amount / float64(months)
+			}
+		}
+	}
+	return averages, nil
+}
+
+func (api *Api) SetBudgetLimit(_ context.Context, budget firefly.Budget, amount float64, start, end time.Time) error {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	if _, ok := demoBudgets[budget.ID]; !ok {
+		return fmt.Errorf("API error: budget %q not found", budget.ID)
+	}
+	if amount <= 0 || end.Before(start) {
+		return fmt.Errorf("API error: invalid limit %.2f from %s to %s",
+			amount, start.Format("2006-01-02"), end.Format("2006-01-02"))
+	}
+	api.budgetLimits[limitKey(budget.ID, start)] = amount
+	return nil
+}
+
+func (api *Api) CategorySpent(categoryID string) float64 {
+	spent, _ := api.categoryTotals()
+	return spent[categoryID]
+}
+
+func (api *Api) CategoryEarned(categoryID string) float64 {
+	_, earned := api.categoryTotals()
+	return earned[categoryID]
+}
+
+func (api *Api) categoryTotals() (spent, earned map[string]float64) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	spent, earned = map[string]float64{}, map[string]float64{}
+	api.eachSplit(func(tx firefly.Transaction, s firefly.Split) {
+		if s.Category.ID == "" {
+			return
+		}
+		switch tx.Type {
+		case "withdrawal":
+			spent[s.Category.ID] += s.Amount
+		case "deposit":
+			earned[s.Category.ID] += s.Amount
+		}
+	})
+	return spent, earned
+}
+
+func (api *Api) CategoryHistory(_ context.Context, categoryID string, months int, end time.Time) (firefly.CategoryHistory, error) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	first := time.Date(end.Year(), end.Month()-time.Month(months-1), 1, 0, 0, 0, 0, end.Location())
+	history := firefly.CategoryHistory{Months: make([]firefly.CategoryMonth, months)}
+	for i := range history.Months {
+		history.Months[i].Month = first.AddDate(0, i, 0)
+	}
+
+	byName := map[string]float64{}
+	for _, tx := range api.transactions {
+		date, err := time.Parse(time.RFC3339, tx.Date)
+		if err != nil || date.Before(first) {
+			continue
+		}
+		i := (date.Year()-first.Year())*12 + int(date.Month()-first.Month())
+		if i >= months {
+			continue
+		}
+		for _, s := range tx.Splits {
+			if s.Category.ID != categoryID {
+				continue
+			}
+			amount, _ := api.ConvertToPrimary(s.Amount, api.splitCurrency(s))
+			switch tx.Type {
+			case "withdrawal":
+				history.Months[i].Spent += amount
+				byName[s.Destination.Name] += amount
+			case "deposit":
+				history.Months[i].Earned += amount
+			}
+		}
+	}
+	for name, spent := range byName {
+		history.TopAccounts = append(history.TopAccounts, firefly.CategoryAccount{Name: name, Spent: spent})
+	}
+	history.TopAccounts = firefly.TopCategoryAccounts(history.TopAccounts)
+	return history, nil
+}
+
+func (api *Api) CreateCategory(_ context.Context, name, _ string) error {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	for _, c := range api.categories {
+		if strings.EqualFold(c.Name, name) {
+			return fmt.Errorf("API error: category %q already exists", name)
+		}
+	}
+	api.addCategory(name)
+	return nil
+}
+
+// TagsAPI
+
+func (api *Api) UpdateTags(_ context.Context) error {
+	return nil
+}
+
+func (api *Api) UpdateTagsInsights(_ context.Context) error {
+	return nil
+}
+
+func (api *Api) TagsList() []firefly.Tag {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	return slices.Clone(api.tags)
+}
+
+func (api *Api) TagSpent(tagID string) float64 {
+	spent, _ := api.tagTotals()
+	return spent[tagID]
+}
+
+func (api *Api) TagEarned(tagID string) float64 {
+	_, earned := api.tagTotals()
+	return earned[tagID]
+}
+
+func (api *Api) tagTotals() (spent, earned map[string]float64) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	ids := make(map[string]string, len(api.tags))
+	for _, t := range api.tags {
+		ids[t.Name] = t.ID
+	}
+	spent, earned = map[string]float64{}, map[string]float64{}
+	api.eachSplit(func(tx firefly.Transaction, s firefly.Split) {
+		for _, tag := range s.Tags {
+			switch tx.Type {
+			case "withdrawal":
+				spent[ids[tag]] += s.Amount
+			case "deposit":
+				earned[ids[tag]] += s.Amount
+			}
+		}
+	})
+	return spent, earned
+}
+
+func (api *Api) TagUsage() map[string]int {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	usage := map[string]int{}
+	for _, tx := range api.transactions {
+		if !api.inPeriod(tx) {
+			continue
+		}
+		seen := map[string]bool{}
+		for _, s := range tx.Splits {
+			for _, tag := range s.Tags {
+				if !seen[tag] {
+					seen[tag] = true
+					usage[tag]++
+				}
+			}
+		}
+	}
+	return usage
+}
+
+func (api *Api) RenameTag(_ context.Context, tag firefly.Tag, name string) error {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	i := slices.IndexFunc(api.tags, func(t firefly.Tag) bool { return t.ID == tag.ID })
+	if i < 0 {
+		return &firefly.HTTPError{StatusCode: http.StatusNotFound}
+	}
+	if slices.ContainsFunc(api.tags, func(t firefly.Tag) bool { return t.Name == name }) {
+		return fmt.Errorf("API error: tag %q already exists", name)
+	}
+	api.tags[i].Name = name
+	api.retag(tag.Name, func(tags []string) []string {
+		tags[slices.Index(tags, tag.Name)] = name
+		return tags
+	})
+	return nil
+}
+
+func (api *Api) DeleteTag(_ context.Context, tag firefly.Tag) error {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	i := slices.IndexFunc(api.tags, func(t firefly.Tag) bool { return t.ID == tag.ID })
+	if i < 0 {
+		return &firefly.HTTPError{StatusCode: http.StatusNotFound}
+	}
+	api.tags = slices.Delete(api.tags, i, i+1)
+	api.retag(tag.Name, func(tags []string) []string {
+		return slices.DeleteFunc(tags, func(t string) bool { return t == tag.Name })
+	})
+	return nil
+}
+
+// retag applies change to a copy of the tags of every split tagged name.
+func (api *Api) retag(name string, change func(tags []string) []string) {
+	for i, tx := range api.transactions {
+		for j, s := range tx.Splits {
+			if slices.Contains(s.Tags, name) {
+				api.transactions[i].Splits[j].Tags = change(slices.Clone(s.Tags))
+			}
+		}
+	}
+}
+
+// TransactionAPI
+
+// ListTransactions returns the transactions of the period, or all
+// transactions matching every word of the query.
+func (api *Api) ListTransactions(_ context.Context, query string) ([]firefly.Transaction, error) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	terms, err := url.QueryUnescape(query)
+	if err != nil {
+		terms = query
+	}
+	words := strings.Fields(strings.ToLower(terms))
+
+	transactions := []firefly.Transaction{}
+	for _, tx := range api.transactions {
+		if len(words) == 0 && !api.inPeriod(tx) {
+			continue
+		}
+		if len(words) > 0 && !matches(tx, words) {
+			continue
+		}
+		tx.ID = uint(len(transactions))
+		tx.Splits = slices.Clone(tx.Splits)
+		transactions = append(transactions, tx)
+	}
+	return transactions, nil
+}
+
+// demoPageSize is the page size StreamTransactions reports pages in, the
+// default of Firefly III.
+const demoPageSize = 50
+
+func (api *Api) StreamTransactions(ctx context.Context, query string, onPage func(firefly.TransactionsPage)) ([]firefly.Transaction, error) {
+	transactions, err := api.ListTransactions(ctx, query)
+	if err != nil || onPage == nil {
+		return transactions, err
+	}
+	for start := 0; start < len(transactions); start += demoPageSize {
+		end := min(start+demoPageSize, len(transactions))
+		onPage(firefly.TransactionsPage{
+			Transactions: transactions[start:end],
+			Loaded:       end,
+			Total:        len(transactions),
+		})
+	}
+	return transactions, nil
+}
+
+func matches(tx firefly.Transaction, words []string) bool {
+	text := strings.ToLower(tx.GroupTitle)
+	for _, s := range tx.Splits {
+		text += " " + strings.ToLower(strings.Join([]string{
+			s.Description, s.Source.Name, s.Destination.Name, s.Category.Name,
+			s.InternalReference, s.ExternalID,
+		}, " "))
+	}
+	for _, w := range words {
+		if !strings.Contains(text, w) {
+			return false
+		}
+	}
+	return true
+}
+
+func (api *Api) GetTransaction(_ context.Context, transactionID string) (firefly.Transaction, error) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	i := api.transactionIndex(transactionID)
+	if i < 0 {
+		return firefly.Transaction{}, &firefly.HTTPError{StatusCode: http.StatusNotFound}
+	}
+	tx := api.transactions[i]
+	tx.Splits = slices.Clone(tx.Splits)
+	return tx, nil
+}
+
+func (api *Api) DeleteTransaction(_ context.Context, transactionID string) error {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	i := api.transactionIndex(transactionID)
+	if i < 0 {
+		return &firefly.HTTPError{StatusCode: http.StatusNotFound}
+	}
+	api.transactions = slices.Delete(api.transactions, i, i+1)
+	return nil
+}
+
+func (api *Api) transactionIndex(transactionID string) int {
+	return slices.IndexFunc(api.transactions, func(tx firefly.Transaction) bool {
+		return tx.TransactionID == transactionID
+	})
+}
+
+// TransactionWriteAPI
+
+func (api *Api) CreateTransaction(_ context.Context, req firefly.RequestTransaction) (string, error) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	tx, err := api.fromRequest(req)
+	if err != nil {
+		return "", err
+	}
+	tx.TransactionID = api.nextID()
+	api.insert(tx)
+	return tx.TransactionID, nil
+}
+
+// LiveUpdates returns nil, demo data only changes in the program.
+func (api *Api) LiveUpdates() <-chan firefly.WebhookEvent {
+	return nil
+}
+
+// Unauthorized returns false, demo data needs no token.
+func (api *Api) Unauthorized() bool {
+	return false
+}
+
+func (api *Api) SetAPIKey(key string) {}
+
+func (api *Api) CreateTransactions(ctx context.Context, txs []firefly.RequestTransaction) firefly.BatchResult {
+	return firefly.CreateBatch(ctx, txs, firefly.BatchConcurrency, api.CreateTransaction)
+}
+
+func (api *Api) UpdateTransaction(_ context.Context, transactionID string, req firefly.RequestTransaction) (string, error) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	i := api.transactionIndex(transactionID)
+	if i < 0 {
+		return "", &firefly.HTTPError{StatusCode: http.StatusNotFound}
+	}
+	tx, err := api.fromRequest(completeRequest(api.transactions[i], req))
+	if err != nil {
+		return "", err
+	}
+	tx.TransactionID = transactionID
+	// Like the server, keep what the request leaves out
+	for j, s := range tx.Splits {
+		k := slices.IndexFunc(api.transactions[i].Splits, func(o firefly.Split) bool {
+			return o.TransactionJournalID == s.TransactionJournalID
+		})
+		if k < 0 {
+			continue
+		}
+		old := api.transactions[i].Splits[k]
+		if s.Tags == nil {
+			tx.Splits[j].Tags = old.Tags
+		}
+		if s.Location.IsEmpty() {
+			tx.Splits[j].Location = old.Location
+		}
+	}
+	api.transactions = slices.Delete(api.transactions, i, i+1)
+	api.insert(tx)
+	return transactionID, nil
+}
+
+// completeRequest fills the fields req leaves out of a split from the split
+// of old with the same journal ID, e.g. a request sending only the tags.
+func completeRequest(old firefly.Transaction, req firefly.RequestTransaction) firefly.RequestTransaction {
+	req.Transactions = slices.Clone(req.Transactions)
+	if req.GroupTitle == "" {
+		req.GroupTitle = old.GroupTitle
+	}
+	for j, s := range req.Transactions {
+		k := slices.IndexFunc(old.Splits, func(o firefly.Split) bool {
+			return o.TransactionJournalID == s.TransactionJournalID
+		})
+		if k < 0 {
+			continue
+		}
+		o := old.Splits[k]
+		fill := func(field *string, value string) {
+			if *field == "" {
+				*field = value
+			}
+		}
+		fill(&s.Type, old.Type)
+		fill(&s.Date, old.Date[:min(len(old.Date), 10)])
+		fill(&s.Amount, strconv.FormatFloat(o.Amount, 'f', 2, 64))
+		fill(&s.Description, o.Description)
+		fill(&s.SourceID, o.Source.ID)
+		fill(&s.DestinationID, o.Destination.ID)
+		fill(&s.CategoryID, o.Category.ID)
+		fill(&s.CurrencyCode, o.Currency)
+		if o.ForeignAmount != 0 {
+			fill(&s.ForeignAmount, strconv.FormatFloat(o.ForeignAmount, 'f', 2, 64))
+		}
+		fill(&s.ForeignCurrencyCode, o.ForeignCurrency)
+		fill(&s.InternalReference, o.InternalReference)
+		fill(&s.ExternalID, o.ExternalID)
+		fill(&s.InvoiceDate, o.InvoiceDate)
+		req.Transactions[j] = s
+	}
+	return req
+}
+
+// insert adds tx keeping the transactions sorted newest first.
+func (api *Api) insert(tx firefly.Transaction) {
+	i := slices.IndexFunc(api.transactions, func(t firefly.Transaction) bool {
+		return t.Date <= tx.Date
+	})
+	if i < 0 {
+		i = len(api.transactions)
+	}
+	api.transactions = slices.Insert(api.transactions, i, tx)
+}
+
+func (api *Api) fromRequest(req firefly.RequestTransaction) (firefly.Transaction, error) {
+	if len(req.Transactions) == 0 {
+		return firefly.Transaction{}, fmt.Errorf("API error: transaction has no splits")
+	}
+
+	first := req.Transactions[0]
+	date, err := time.ParseInLocation("2006-01-02", first.Date, time.Local)
+	if err != nil {
+		return firefly.Transaction{}, fmt.Errorf("API error: invalid date %q", first.Date)
+	}
+
+	tx := firefly.Transaction{
+		Type:       first.Type,
+		Date:       date.Format(time.RFC3339),
+		GroupTitle: req.GroupTitle,
+		UpdatedAt:  time.Now(),
+	}
+	for _, s := range req.Transactions {
+		amount, err := strconv.ParseFloat(s.Amount, 64)
+		if err != nil || amount <= 0 {
+			return firefly.Transaction{}, fmt.Errorf("API error: invalid amount %q", s.Amount)
+		}
+		foreignAmount, _ := strconv.ParseFloat(s.ForeignAmount, 64)
+
+		journalID := s.TransactionJournalID
+		if journalID == "" {
+			journalID = api.nextID()
+		}
+		currency := s.CurrencyCode
+		if currency == "" {
+			currency = api.currency.Code
+		}
+
+		var category firefly.Category
+		for _, c := range api.categories {
+			if c.ID == s.CategoryID {
+				category = c
+			}
+		}
+
+		for _, tag := range s.Tags {
+			if !slices.ContainsFunc(api.tags, func(t firefly.Tag) bool { return t.Name == tag }) {
+				api.addTag(tag)
+			}
+		}
+
+		var location firefly.Location
+		if s.Latitude != nil && s.Longitude != nil {
+			location = firefly.Location{Latitude: *s.Latitude, Longitude: *s.Longitude}
+			if s.ZoomLevel != nil {
+				location.ZoomLevel = *s.ZoomLevel
+			}
+		}
+
+		tx.Splits = append(tx.Splits, firefly.Split{
+			TransactionJournalID: journalID,
+			Source:               api.accountByID(s.SourceID),
+			Destination:          api.accountByID(s.DestinationID),
+			Category:             category,
+			Currency:             currency,
+			ForeignCurrency:      s.ForeignCurrencyCode,
+			Amount:               amount,
+			ForeignAmount:        foreignAmount,
+			Description:          s.Description,
+			Reconciled:           s.Reconciled,
+			Tags:                 s.Tags,
+			Location:             location,
+			InternalReference:    s.InternalReference,
+			ExternalID:           s.ExternalID,
+			InvoiceDate:          s.InvoiceDate,
+		})
+	}
+	return tx, nil
+}
+
+// RecurrenceAPI
+
+// CreateRecurrence keeps the recurrence, the demo does not create its
+// transactions.
+func (api *Api) CreateRecurrence(_ context.Context, recurrence firefly.RequestRecurrence) (string, error) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	if recurrence.Title == "" || len(recurrence.Transactions) == 0 || len(recurrence.Repetitions) == 0 {
+		return "", fmt.Errorf("API error: recurrence needs a title, a repetition and a transaction")
+	}
+	if _, err := time.Parse("2006-01-02", recurrence.FirstDate); err != nil {
+		return "", fmt.Errorf("API error: invalid first date %q", recurrence.FirstDate)
+	}
+	api.recurrences = append(api.recurrences, recurrence)
+	return api.nextID(), nil
+}
+
+// StatusAPI
+
+func (api *Api) ServerURL() string {
+	return ServerURL
+}
+
+func (api *Api) RetryStatus() (attempt, maxRetries int) {
+	return 0, 0
+}
+
+// SnapshotAPI
+
+func (api *Api) Stale() (resources []string, savedAt time.Time) {
+	return nil, time.Time{}
+}
+
+func (api *Api) CachedTransactions() []firefly.Transaction {
+	return nil
+}
+
+func (api *Api) RefreshBaseData(_ context.Context) error {
+	return nil
+}
+
+// OfflineAPI
+
+func (api *Api) Offline() bool {
+	return false
+}
+
+func (api *Api) QueuedWrites() int {
+	return 0
+}
+
+func (api *Api) Reconnect(_ context.Context) (replayed int, conflicts []firefly.Conflict, err error) {
+	return 0, nil, nil
+}
+
+// TraceAPI
+
+func (api *Api) RecentRequests() []firefly.RequestTrace {
+	return nil
+}
+
+func (api *Api) SessionStats() firefly.SessionStats {
+	return firefly.SessionStats{}
+}
+
+// HealthAPI
+
+func (api *Api) GetAbout(_ context.Context) (firefly.About, error) {
+	return firefly.About{Version: firefly.MinVersion, ApiVersion: firefly.MinVersion, OS: "demo"}, nil
+}
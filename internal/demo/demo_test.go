@@ -0,0 +1,453 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package demo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"ffiii-tui/internal/firefly"
+)
+
+var now = time.Date(2026, time.March, 18, 12, 0, 0, 0, time.UTC)
+
+func TestNew_Deterministic(t *testing.T) {
+	a, b := New(1, now), New(1, now)
+	if !reflect.DeepEqual(a.transactions, b.transactions) {
+		t.Error("Expected the same transactions for the same seed")
+	}
+	if reflect.DeepEqual(a.transactions, New(2, now).transactions) {
+		t.Error("Expected different transactions for another seed")
+	}
+	if n := len(a.transactions); n < 200 {
+		t.Errorf("Expected a few hundred transactions, got %d", n)
+	}
+	for _, tx := range a.transactions {
+		if tx.Date > now.Format(time.RFC3339) {
+			t.Fatalf("Expected no transactions after now, got %s", tx.Date)
+		}
+	}
+}
+
+func TestListTransactions_PeriodAndSearch(t *testing.T) {
+	api := New(1, now)
+
+	txs, err := api.ListTransactions(context.Background(), "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(txs) == 0 {
+		t.Fatal("Expected transactions in the current period")
+	}
+	for i, tx := range txs {
+		if tx.ID != uint(i) {
+			t.Errorf("Expected ID %d, got %d", i, tx.ID)
+		}
+		if tx.Date[:7] != "2026-03" {
+			t.Errorf("Expected March transactions only, got %s", tx.Date)
+		}
+	}
+
+	found, err := api.ListTransactions(context.Background(), url.QueryEscape("car loan"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(found) != months {
+		t.Errorf("Expected one car loan installment per month, got %d", len(found))
+	}
+}
+
+func TestInsights_MatchTransactions(t *testing.T) {
+	api := New(1, now)
+	api.SetPeriod(2026, time.February)
+
+	rent := api.accountByName("expense", "Landlord")
+	if got := api.GetExpenseDiff(rent.ID); got != 1150 {
+		t.Errorf("Expected rent 1150, got %v", got)
+	}
+
+	spent, earned := api.GetTotalSpentEarnedCategories()
+	if spent <= 0 || earned <= 0 {
+		t.Errorf("Expected spent and earned amounts, got %v, %v", spent, earned)
+	}
+	if total := api.GetTotalExpenseDiff(); math.Abs(total-spent) > 0.001 {
+		t.Errorf("Expected expenses %v to match spent categories %v", total, spent)
+	}
+
+	items := api.SummaryItems()
+	if math.Abs(items["spent-in-EUR"].MonetaryValue+spent) > 0.001 {
+		t.Errorf("Expected spent %v in the summary, got %v", -spent, items["spent-in-EUR"].MonetaryValue)
+	}
+}
+
+func TestCategoryHistory_MonthlyRent(t *testing.T) {
+	api := New(1, now)
+
+	var housing firefly.Category
+	for _, c := range api.CategoriesList() {
+		if c.Name == "Housing" {
+			housing = c
+		}
+	}
+
+	history, err := api.CategoryHistory(context.Background(), housing.ID, 3, now)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(history.Months) != 3 {
+		t.Fatalf("Expected 3 months, got %d", len(history.Months))
+	}
+	if first := history.Months[0].Month; first.Year() != 2026 || first.Month() != time.January {
+		t.Errorf("Expected history to start in January, got %v", first)
+	}
+	if got := history.Months[1].Spent; got < 1150 {
+		t.Errorf("Expected at least the rent spent in February, got %v", got)
+	}
+	if len(history.TopAccounts) == 0 || history.TopAccounts[0].Name != "Landlord" {
+		t.Errorf("Expected the landlord to top the expense accounts, got %+v", history.TopAccounts)
+	}
+}
+
+func TestCategoryBudget_SpentInPeriod(t *testing.T) {
+	api := New(1, now)
+	api.SetPeriod(2026, time.February)
+
+	budget, ok := api.CategoryBudget("Groceries")
+	if !ok {
+		t.Fatal("Expected a Groceries budget")
+	}
+	if budget.Limit != 450 || budget.Spent <= 0 {
+		t.Errorf("Expected limit 450 and spent groceries, got %+v", budget)
+	}
+	if _, ok := api.CategoryBudget("Housing"); ok {
+		t.Error("Expected no Housing budget")
+	}
+}
+
+func TestTags_UsageRenameDelete(t *testing.T) {
+	api := New(1, now)
+	api.SetPeriod(2026, time.February)
+	ctx := context.Background()
+
+	var car firefly.Tag
+	for _, tag := range api.TagsList() {
+		if tag.Name == "car" {
+			car = tag
+		}
+	}
+	if car.ID == "" {
+		t.Fatalf("Expected a car tag, got %+v", api.TagsList())
+	}
+	used := api.TagUsage()["car"]
+	if used < 5 || api.TagSpent(car.ID) <= 0 {
+		t.Fatalf("Expected fuel and the loan installment tagged car, got %d uses", used)
+	}
+
+	if err := api.RenameTag(ctx, car, "social"); err == nil {
+		t.Error("Expected an error renaming to an existing tag")
+	}
+	if err := api.RenameTag(ctx, car, "vehicle"); err != nil {
+		t.Fatalf("RenameTag: %v", err)
+	}
+	if got := api.TagUsage(); got["vehicle"] != used || got["car"] != 0 {
+		t.Errorf("Expected %d uses moved to vehicle, got %v", used, got)
+	}
+
+	car.Name = "vehicle"
+	if err := api.DeleteTag(ctx, car); err != nil {
+		t.Fatalf("DeleteTag: %v", err)
+	}
+	if api.TagUsage()["vehicle"] != 0 || len(api.TagsList()) != 2 {
+		t.Errorf("Expected the tag gone, got %v", api.TagsList())
+	}
+}
+
+func TestTransactions_PartialUpdate(t *testing.T) {
+	api := New(1, now)
+	ctx := context.Background()
+	before := api.transactions[0]
+	split := before.Splits[0]
+
+	update := func(tags []string) firefly.Transaction {
+		t.Helper()
+		req := firefly.RequestTransaction{Transactions: []firefly.RequestTransactionSplit{
+			{TransactionJournalID: split.TransactionJournalID, Tags: tags},
+		}}
+		if _, err := api.UpdateTransaction(ctx, before.TransactionID, req); err != nil {
+			t.Fatalf("UpdateTransaction: %v", err)
+		}
+		return api.transactions[api.transactionIndex(before.TransactionID)]
+	}
+
+	after := update([]string{"trip"})
+	got := after.Splits[0]
+	if !slices.Equal(got.Tags, []string{"trip"}) {
+		t.Errorf("Expected the tag set, got %v", got.Tags)
+	}
+	if after.Date != before.Date || got.Amount != split.Amount || got.Source != split.Source ||
+		got.Destination != split.Destination || got.Category != split.Category || got.Description != split.Description {
+		t.Errorf("Expected the rest kept, got %+v, was %+v", got, split)
+	}
+
+	if got := update([]string{}).Splits[0].Tags; len(got) != 0 {
+		t.Errorf("Expected the tags removed, got %v", got)
+	}
+}
+
+func TestCreateRecurrence(t *testing.T) {
+	api := New(1, now)
+	ctx := context.Background()
+	recurrence := firefly.RequestRecurrence{
+		Type:         "withdrawal",
+		Title:        "Rent",
+		FirstDate:    "2026-04-01",
+		Repetitions:  []firefly.RequestRecurrenceRepetition{firefly.MonthlyOn(1)},
+		Transactions: []firefly.RequestRecurrenceTransaction{{Description: "Rent", Amount: "900.00"}},
+	}
+	if id, err := api.CreateRecurrence(ctx, recurrence); err != nil || id == "" {
+		t.Fatalf("CreateRecurrence: %q, %v", id, err)
+	}
+	if len(api.recurrences) != 1 {
+		t.Errorf("Expected the recurrence kept, got %d", len(api.recurrences))
+	}
+
+	recurrence.FirstDate = "next month"
+	if _, err := api.CreateRecurrence(ctx, recurrence); err == nil {
+		t.Error("Expected an error for an invalid first date")
+	}
+}
+
+func TestPiggyBanks(t *testing.T) {
+	api := New(1, now)
+	goals := api.PiggyBanks()
+	if len(goals) != 2 {
+		t.Fatalf("Expected 2 savings goals, got %d", len(goals))
+	}
+	if goals[0].OnTrack(now) || !goals[1].OnTrack(now) {
+		t.Errorf("Expected the first goal behind and the second on track, got %+v", goals)
+	}
+	if goals[0].MonthlyNeeded(now) <= 0 {
+		t.Errorf("Expected a monthly contribution, got %+v", goals[0])
+	}
+}
+
+func TestBurnRate(t *testing.T) {
+	api := New(1, now)
+	rate := api.BurnRate()
+	if rate.DaysPassed != 18 || rate.DaysLeft != 13 {
+		t.Errorf("Expected 18 days passed and 13 left, got %d and %d", rate.DaysPassed, rate.DaysLeft)
+	}
+	if rate.DailyTotal <= 0 || rate.Balance == 0 {
+		t.Errorf("Expected spending and a balance, got %+v", rate)
+	}
+
+	api.PreviousPeriod()
+	if api.BurnRate().Active() {
+		t.Error("Expected no burn rate for a past period")
+	}
+}
+
+func TestSubscriptionsDue(t *testing.T) {
+	api := New(1, time.Date(2026, time.February, 27, 12, 0, 0, 0, time.UTC))
+	due := api.SubscriptionsDue()
+	names := []string{}
+	for _, s := range due {
+		names = append(names, s.Name+" "+s.DueDates[0])
+	}
+	want := []string{"Landlord 2026-03-03", "Electricity company 2026-03-05"}
+	if !slices.Equal(names, want) {
+		t.Errorf("Expected %v, got %v", want, names)
+	}
+
+	api = New(1, time.Date(2026, time.March, 8, 12, 0, 0, 0, time.UTC))
+	if due := api.SubscriptionsDue(); len(due) != 3 || due[0].Name != "Mobile operator" {
+		t.Errorf("Expected the bills of the next 7 days, got %+v", due)
+	}
+}
+
+func TestBudgets_AveragesAndLimits(t *testing.T) {
+	api := New(1, now)
+	ctx := context.Background()
+	budgets := api.Budgets()
+	if len(budgets) != len(demoBudgets) || budgets[0].Name != "Dining out" {
+		t.Fatalf("Expected the budgets by name, got %+v", budgets)
+	}
+
+	averages, err := api.BudgetAverages(ctx, 3, api.StartDate)
+	if err != nil {
+		t.Fatalf("BudgetAverages: %v", err)
+	}
+	if averages["Groceries"] <= 0 {
+		t.Errorf("Expected an average for Groceries, got %v", averages)
+	}
+
+	next := api.StartDate.AddDate(0, 1, 0)
+	groceries := budgets[slices.IndexFunc(budgets, func(b firefly.Budget) bool { return b.Name == "Groceries" })]
+	if err := api.SetBudgetLimit(ctx, groceries, 400, next, next.AddDate(0, 1, -1)); err != nil {
+		t.Fatalf("SetBudgetLimit: %v", err)
+	}
+	if b, _ := api.CategoryBudget("Groceries"); b.Limit != demoBudgets["Groceries"] {
+		t.Errorf("Expected the limit of this month kept, got %v", b.Limit)
+	}
+	api.NextPeriod()
+	if b, _ := api.CategoryBudget("Groceries"); b.Limit != 400 {
+		t.Errorf("Expected the limit of next month set, got %v", b.Limit)
+	}
+
+	if err := api.SetBudgetLimit(ctx, firefly.Budget{ID: "Travel"}, 100, next, next); err == nil {
+		t.Error("Expected an error for an unknown budget")
+	}
+}
+
+func TestTransactions_CreateBatch(t *testing.T) {
+	api := New(1, now)
+	checking := api.accountByName("asset", "Checking account")
+	shop := api.accountByName("expense", "Online store")
+
+	var txs []firefly.RequestTransaction
+	for i, date := range []string{"2026-03-01", "2026-03-02", "March 3rd", "2026-03-04"} {
+		txs = append(txs, firefly.RequestTransaction{Transactions: []firefly.RequestTransactionSplit{{
+			Type:          "withdrawal",
+			Date:          date,
+			Amount:        "10",
+			Description:   fmt.Sprintf("Batch %d", i),
+			SourceID:      checking.ID,
+			DestinationID: shop.ID,
+		}}})
+	}
+	result := api.CreateTransactions(context.Background(), txs)
+	if got := result.Summary(); got != "3/4 created, 1 failed" {
+		t.Errorf("Expected summary %q, got %q", "3/4 created, 1 failed", got)
+	}
+	if len(result.Failed) != 1 || result.Failed[0].Index != 2 || result.Failed[0].Description != "Batch 2" {
+		t.Errorf("Expected the transaction with the invalid date to fail, got %+v", result.Failed)
+	}
+	if !slices.Equal(result.Sent, []int{0, 1, 3}) {
+		t.Errorf("Expected the batch positions of the created transactions, got %v", result.Sent)
+	}
+	if created, _ := api.ListTransactions(context.Background(), "Batch"); len(created) != 3 {
+		t.Errorf("Expected 3 created transactions, got %d", len(created))
+	}
+}
+
+func TestTransactions_CreateUpdateDelete(t *testing.T) {
+	api := New(1, now)
+	checking := api.accountByName("asset", "Checking account")
+	shop := api.accountByName("expense", "Online store")
+	balance := api.AccountBalance(checking.ID)
+
+	req := firefly.RequestTransaction{Transactions: []firefly.RequestTransactionSplit{{
+		Type:          "withdrawal",
+		Date:          "2026-03-18",
+		Amount:        "42.50",
+		Description:   "Headphones",
+		SourceID:      checking.ID,
+		DestinationID: shop.ID,
+	}}}
+	id, err := api.CreateTransaction(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := api.AccountBalance(checking.ID); math.Abs(got-(balance-42.5)) > 0.001 {
+		t.Errorf("Expected balance %v, got %v", balance-42.5, got)
+	}
+
+	req.Transactions[0].Amount = "40"
+	if _, err := api.UpdateTransaction(context.Background(), id, req); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	txs, _ := api.ListTransactions(context.Background(), "Headphones")
+	if len(txs) != 1 || txs[0].Splits[0].Amount != 40 || txs[0].Splits[0].Destination.Name != "Online store" {
+		t.Errorf("Expected the updated transaction, got %+v", txs)
+	}
+
+	if tx, err := api.GetTransaction(context.Background(), id); err != nil || tx.Splits[0].Description != "Headphones" {
+		t.Errorf("Expected the transaction by its ID, got %+v, %v", tx, err)
+	}
+
+	if err := api.DeleteTransaction(context.Background(), id); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := api.AccountBalance(checking.ID); math.Abs(got-balance) > 0.001 {
+		t.Errorf("Expected balance %v after delete, got %v", balance, got)
+	}
+	var httpErr *firefly.HTTPError
+	if err := api.DeleteTransaction(context.Background(), id); !errors.As(err, &httpErr) || httpErr.StatusCode != 404 {
+		t.Errorf("Expected 404 for a deleted transaction, got %v", err)
+	}
+	if _, err := api.GetTransaction(context.Background(), id); !errors.As(err, &httpErr) || httpErr.StatusCode != 404 {
+		t.Errorf("Expected 404 getting a deleted transaction, got %v", err)
+	}
+}
+
+func TestFormatMoney(t *testing.T) {
+	for amount, want := range map[float64]string{
+		0:        "€0.00",
+		12.5:     "€12.50",
+		-1234.56: "€-1,234.56",
+		1234567:  "€1,234,567.00",
+	} {
+		if got := formatMoney("€", amount); got != want {
+			t.Errorf("formatMoney(%v) = %q, want %q", amount, got, want)
+		}
+	}
+}
+
+func (api *Api) accountByName(accountType, name string) firefly.Account {
+	for _, a := range api.AccountsByType(accountType) {
+		if a.Name == name {
+			return a
+		}
+	}
+	return firefly.Account{}
+}
+
+func TestUpdateOpeningBalance(t *testing.T) {
+	api := New(1, now)
+	ctx := context.Background()
+	account := api.AccountsByType("asset")[0]
+	before := api.AccountBalance(account.ID)
+
+	if err := api.UpdateOpeningBalance(ctx, account, account.OpeningBalance+100, "2026-01-01"); err != nil {
+		t.Fatalf("UpdateOpeningBalance: %v", err)
+	}
+	if got := api.AccountBalance(account.ID); math.Abs(got-before-100) > 0.001 {
+		t.Errorf("Expected the balance up by 100, got %.2f from %.2f", got, before)
+	}
+	if got := api.AccountsByType("asset")[0]; got.OpeningBalanceDate != "2026-01-01" {
+		t.Errorf("Expected the opening date updated, got %q", got.OpeningBalanceDate)
+	}
+	if err := api.UpdateOpeningBalance(ctx, firefly.Account{ID: "missing", Type: "asset"}, 1, "2026-01-01"); err == nil {
+		t.Error("Expected an error for an unknown account")
+	}
+}
+
+func TestBackup(t *testing.T) {
+	api := New(1, now)
+
+	backup, err := api.Backup(context.Background(), now)
+	if err != nil {
+		t.Fatalf("Backup: %v", err)
+	}
+	if len(backup.Transactions) != len(api.transactions) || len(backup.Categories) != len(api.categories) {
+		t.Errorf("Expected all transactions and categories, got %d and %d",
+			len(backup.Transactions), len(backup.Categories))
+	}
+	for _, a := range api.AccountsByType("asset") {
+		if !slices.ContainsFunc(backup.Accounts, func(b firefly.Account) bool { return b.ID == a.ID }) {
+			t.Errorf("Expected asset account %s in the backup", a.Name)
+		}
+		if backup.Balances[a.ID] != api.AccountBalance(a.ID) {
+			t.Errorf("Expected the balance of %s, got %.2f", a.Name, backup.Balances[a.ID])
+		}
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package firefly
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MinVersion is the oldest Firefly III version whose API provides every
+// endpoint the client uses.
+const MinVersion = "6.0.0"
+
+// About describes the Firefly III instance behind the API.
+type About struct {
+	Version    string
+	ApiVersion string
+	PhpVersion string
+	OS         string
+}
+
+type apiAbout struct {
+	Version    string `json:"version"`
+	ApiVersion string `json:"api_version"`
+	PhpVersion string `json:"php_version"`
+	OS         string `json:"os"`
+}
+
+func (api *Api) GetAbout(ctx context.Context) (About, error) {
+	endpoint := fmt.Sprintf("%s/about", api.Config.ApiUrl)
+
+	resp, err := api.getRequest(ctx, endpoint)
+	if err != nil {
+		return About{}, fmt.Errorf("failed to get about: %w", err)
+	}
+
+	items, err := unmarshalItems[apiAbout]([]any{resp.Data})
+	if err != nil {
+		return About{}, fmt.Errorf("failed to unmarshal about: %w", err)
+	}
+
+	return About{
+		Version:    items[0].Version,
+		ApiVersion: items[0].ApiVersion,
+		PhpVersion: items[0].PhpVersion,
+		OS:         items[0].OS,
+	}, nil
+}
+
+// Supported reports whether the server runs MinVersion or later. Versions
+// that cannot be parsed, such as development builds, are assumed to be
+// supported.
+func (a About) Supported() bool {
+	version, ok := parseVersion(a.Version)
+	if !ok {
+		return true
+	}
+	minVersion, _ := parseVersion(MinVersion)
+	for i := range version {
+		if version[i] != minVersion[i] {
+			return version[i] > minVersion[i]
+		}
+	}
+	return true
+}
+
+// parseVersion parses "major.minor.patch" with an optional "v" prefix and
+// pre-release suffix.
+func parseVersion(s string) ([3]int, bool) {
+	var version [3]int
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	s, _, _ = strings.Cut(s, "-")
+	parts := strings.Split(s, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return version, false
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return version, false
+		}
+		version[i] = n
+	}
+	return version, true
+}
+
+// CheckConnection verifies that the API is reachable with the given
+// configuration and returns the server info and enabled currencies.
+// Unlike NewApi it does not load any accounts.
+func CheckConnection(ctx context.Context, config ApiConfig) (About, []Currency, error) {
+	api, err := newClient(config)
+	if err != nil {
+		return About{}, nil, err
+	}
+
+	about, err := api.GetAbout(ctx)
+	if err != nil {
+		return About{}, nil, err
+	}
+
+	currencies, err := api.ListCurrencies(ctx)
+	if err != nil {
+		return About{}, nil, err
+	}
+
+	return about, currencies, nil
+}
@@ -5,8 +5,10 @@ SPDX-License-Identifier: Apache-2.0
 package firefly
 
 import (
+	"context"
 	"fmt"
-	"maps"
+	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -19,6 +21,29 @@ type Account struct {
 	CurrencyCode       string
 	Type               string
 	LiabilityDirection string
+	// LiabilityType is one of loan, debt or mortgage
+	LiabilityType string
+
+	IBAN               string
+	AccountNumber      string
+	OpeningBalance     float64
+	OpeningBalanceDate string
+	// Interest rate of liabilities in percent, per InterestPeriod
+	Interest       string
+	InterestPeriod string
+	Notes          string
+
+	// Role of asset accounts, ccAsset for credit cards
+	Role string
+	// MonthlyPaymentDate is the date credit cards are paid off each month
+	MonthlyPaymentDate string
+	// CreditLimit of credit cards, the virtual balance in Firefly III
+	CreditLimit float64
+}
+
+// IsCreditCard reports whether the account is a credit card.
+func (a Account) IsCreditCard() bool {
+	return a.Type == "asset" && a.Role == "ccAsset"
 }
 
 type apiAccount struct {
@@ -33,6 +58,29 @@ type apiAccountAttr struct {
 	CurrentBalance     float64 `json:"current_balance,string"`
 	Type               string  `json:"type"`
 	LiabilityDirection string  `json:"liability_direction"`
+	LiabilityType      string  `json:"liability_type"`
+	IBAN               string  `json:"iban"`
+	AccountNumber      string  `json:"account_number"`
+	OpeningBalance     string  `json:"opening_balance"`
+	OpeningBalanceDate string  `json:"opening_balance_date"`
+	Interest           string  `json:"interest"`
+	InterestPeriod     string  `json:"interest_period"`
+	Notes              string  `json:"notes"`
+	LastActivity       string  `json:"last_activity"`
+	AccountRole        string  `json:"account_role"`
+	MonthlyPaymentDate string  `json:"monthly_payment_date"`
+	VirtualBalance     string  `json:"virtual_balance"`
+}
+
+type NewAsset struct {
+	Name         string
+	CurrencyCode string
+	// Role is one of defaultAsset, savingAsset, sharedAsset or ccAsset
+	Role string
+	// OpeningBalance is only sent when not zero, as of OpeningBalanceDate
+	// (YYYY-MM-DD)
+	OpeningBalance     float64
+	OpeningBalanceDate string
 }
 
 type NewLiability struct {
@@ -42,33 +90,47 @@ type NewLiability struct {
 	Direction    string `json:"liability_direction"`
 }
 
-func (api *Api) CreateAssetAccount(name string, currencyCode string) error {
-	return api.createAccount(map[string]any{
-		"name":              name,
+func (api *Api) CreateAssetAccount(ctx context.Context, na NewAsset) error {
+	role := na.Role
+	if role == "" {
+		role = "defaultAsset"
+	}
+	payload := map[string]any{
+		"name":              na.Name,
 		"type":              "asset",
-		"currency_code":     strings.ToUpper(currencyCode),
+		"currency_code":     strings.ToUpper(na.CurrencyCode),
 		"include_net_worth": true,
 		"active":            true,
-		"account_role":      "defaultAsset",
-	})
+		"account_role":      role,
+	}
+	if na.OpeningBalance != 0 {
+		payload["opening_balance"] = strconv.FormatFloat(na.OpeningBalance, 'f', -1, 64)
+		payload["opening_balance_date"] = na.OpeningBalanceDate
+	}
+	if role == "ccAsset" {
+		// Required by Firefly III for credit cards
+		payload["credit_card_type"] = "monthlyFull"
+		payload["monthly_payment_date"] = time.Now().Format(time.DateOnly)
+	}
+	return api.createAccount(ctx, payload)
 }
 
-func (api *Api) CreateExpenseAccount(name string) error {
-	return api.createAccount(map[string]any{
+func (api *Api) CreateExpenseAccount(ctx context.Context, name string) error {
+	return api.createAccount(ctx, map[string]any{
 		"name": name,
 		"type": "expense",
 	})
 }
 
-func (api *Api) CreateRevenueAccount(name string) error {
-	return api.createAccount(map[string]any{
+func (api *Api) CreateRevenueAccount(ctx context.Context, name string) error {
+	return api.createAccount(ctx, map[string]any{
 		"name": name,
 		"type": "revenue",
 	})
 }
 
-func (api *Api) CreateLiabilityAccount(nl NewLiability) error {
-	return api.createAccount(map[string]any{
+func (api *Api) CreateLiabilityAccount(ctx context.Context, nl NewLiability) error {
+	return api.createAccount(ctx, map[string]any{
 		"name":                nl.Name,
 		"type":                "liability",
 		"currency_code":       strings.ToUpper(nl.CurrencyCode),
@@ -77,9 +139,10 @@ func (api *Api) CreateLiabilityAccount(nl NewLiability) error {
 	})
 }
 
-func (api *Api) createAccount(payload map[string]any) error {
+func (api *Api) createAccount(ctx context.Context, payload map[string]any) error {
 	endpoint := fmt.Sprintf("%s/accounts", api.Config.ApiUrl)
-	response, err := api.postRequest(endpoint, payload)
+	response, err := api.write(ctx, http.MethodPost, endpoint, payload,
+		fmt.Sprintf("create %s account %s", payload["type"], payload["name"]))
 	if err != nil {
 		return err
 	}
@@ -96,19 +159,29 @@ func (api *Api) createAccount(payload map[string]any) error {
 	return nil
 }
 
-func (api *Api) GetExpenseDiff(ID string) float64 {
-	if insight, ok := api.expenseInsights[ID]; ok {
-		return insight.Diff
+// UpdateOpeningBalance sets the opening balance of an asset account as of
+// date (YYYY-MM-DD). Firefly III keeps it as an opening balance
+// transaction: a zero amount removes it.
+func (api *Api) UpdateOpeningBalance(ctx context.Context, account Account, amount float64, date string) error {
+	endpoint := fmt.Sprintf("%s/accounts/%s", api.Config.ApiUrl, account.ID)
+
+	payload := map[string]any{
+		"name":                 account.Name,
+		"opening_balance":      strconv.FormatFloat(amount, 'f', -1, 64),
+		"opening_balance_date": date,
 	}
-	return 0
+
+	_, err := api.write(ctx, http.MethodPut, endpoint, payload,
+		fmt.Sprintf("set opening balance of %s to %.2f", account.Name, amount))
+	return err
+}
+
+func (api *Api) GetExpenseDiff(ID string) float64 {
+	return api.Data().GetExpenseDiff(ID)
 }
 
 func (api *Api) GetTotalExpenseDiff() float64 {
-	total := 0.0
-	for _, insight := range api.expenseInsights {
-		total += insight.Diff
-	}
-	return total
+	return api.Data().GetTotalExpenseDiff()
 }
 
 // GetTotalExpenseDiff2 gets total expense difference per currency
@@ -117,12 +190,12 @@ func (api *Api) GetTotalExpenseDiff() float64 {
 // Note: This function fetches insights directly from the API each time it is called
 // and does not use cached insights
 // which may have performance implications
-func (api *Api) GetTotalExpenseDiff2() (totals []struct {
+func (api *Api) GetTotalExpenseDiff2(ctx context.Context) (totals []struct {
 	CurrencyCode string
 	Diff         float64
 },
 ) {
-	spentInsights, err := api.GetInsights("expense/total")
+	spentInsights, err := api.GetInsights(ctx, "expense/total")
 	if err == nil {
 		for _, item := range spentInsights {
 			totals = append(totals, struct {
@@ -138,94 +211,138 @@ func (api *Api) GetTotalExpenseDiff2() (totals []struct {
 	return
 }
 
+// ExpenseTotals returns the expenses of the period by currency code.
+func (api *Api) ExpenseTotals() map[string]float64 {
+	return api.Data().ExpenseTotals()
+}
+
 func (api *Api) GetRevenueDiff(ID string) float64 {
-	if insight, ok := api.revenueInsights[ID]; ok {
-		return insight.Diff
-	}
-	return 0
+	return api.Data().GetRevenueDiff(ID)
 }
 
 func (api *Api) GetTotalRevenueDiff() float64 {
-	total := 0.0
-	for _, insight := range api.revenueInsights {
-		total += insight.Diff
-	}
-	return total
+	return api.Data().GetTotalRevenueDiff()
+}
+
+// RevenueTotals returns the revenues of the period by currency code.
+func (api *Api) RevenueTotals() map[string]float64 {
+	return api.Data().RevenueTotals()
+}
+
+func (api *Api) UpdateExpenseInsights(ctx context.Context) error {
+	return api.coalesce(ctx, "expense-insights:"+api.periodKey(), func(ctx context.Context) error {
+		return api.updateExpenseInsights(ctx)
+	})
 }
 
-func (api *Api) UpdateExpenseInsights() error {
+func (api *Api) updateExpenseInsights(ctx context.Context) error {
 	// TODO: Need error reporting
 	insights := make(map[string]accountInsight)
-	spentInsights, err := api.GetInsights("expense/expense")
+	totals := make(map[string]float64)
+	spentInsights, err := api.GetInsights(ctx, "expense/expense")
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
 	if err == nil {
 		for _, item := range spentInsights {
 			insights[item.ID] = accountInsight{
 				Diff: (-1) * item.DifferenceFloat,
 			}
+			totals[item.CurrencyCode] += (-1) * item.DifferenceFloat
 		}
 	}
-	api.expenseInsights = insights
+	api.store.update(func(d *StoreData) {
+		d.expenseInsights = insights
+		d.expenseTotals = totals
+	})
 
 	return nil
 }
 
-func (api *Api) UpdateRevenueInsights() error {
+func (api *Api) UpdateRevenueInsights(ctx context.Context) error {
+	return api.coalesce(ctx, "revenue-insights:"+api.periodKey(), func(ctx context.Context) error {
+		return api.updateRevenueInsights(ctx)
+	})
+}
+
+func (api *Api) updateRevenueInsights(ctx context.Context) error {
 	insights := make(map[string]accountInsight)
-	earnedInsights, err := api.GetInsights("income/revenue")
+	totals := make(map[string]float64)
+	earnedInsights, err := api.GetInsights(ctx, "income/revenue")
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
 	if err == nil {
 		for _, item := range earnedInsights {
 			insights[item.ID] = accountInsight{
 				Diff: item.DifferenceFloat,
 			}
+			totals[item.CurrencyCode] += item.DifferenceFloat
 		}
 	}
 
-	api.revenueInsights = insights
+	api.store.update(func(d *StoreData) {
+		d.revenueInsights = insights
+		d.revenueTotals = totals
+	})
 
 	return nil
 }
 
-func (api *Api) UpdateAccounts(accType string) error {
-	accounts, err := api.ListAccounts(accType)
+func (api *Api) UpdateAccounts(ctx context.Context, accType string) error {
+	return api.coalesce(ctx, "accounts:"+accType, func(ctx context.Context) error {
+		return api.updateAccounts(ctx, accType)
+	})
+}
+
+func (api *Api) updateAccounts(ctx context.Context, accType string) error {
+	accounts, err := api.ListAccounts(ctx, accType)
 	if err != nil {
 		return err
 	}
 
 	accs := make(map[string][]Account, 0)
+	balances := make(map[string]float64, len(accounts))
+	activity := make(map[string]string, len(accounts))
 
 	for _, account := range accounts {
-		api.accountBalances[account.ID] = account.Attributes.CurrentBalance
-		accs[account.Attributes.Type] = append(accs[account.Attributes.Type], Account{
-			ID:                 account.ID,
-			Name:               account.Attributes.Name,
-			CurrencyCode:       account.Attributes.CurrencyCode,
-			Type:               account.Attributes.Type,
-			LiabilityDirection: account.Attributes.LiabilityDirection,
-		})
+		balances[account.ID] = account.Attributes.CurrentBalance
+		activity[account.ID] = account.Attributes.LastActivity
+		accs[account.Attributes.Type] = append(accs[account.Attributes.Type], account.toAccount())
 	}
 
-	maps.Copy(api.Accounts, accs)
+	api.store.setAccounts(accs, balances, activity)
+	if accType == "all" {
+		api.markFresh(snapshotAccountTypes...)
+	} else {
+		api.markFresh(accType)
+	}
+
+	switch accType {
+	case "asset", "liabilities", "all":
+		api.updateExchangeRates(ctx)
+	}
 
 	switch accType {
 	case "expense":
-		api.Accounts["expense"] = append(api.Accounts["expense"], api.CashAccount())
-		err := api.UpdateExpenseInsights()
+		api.store.addAccount("expense", api.CashAccount(ctx))
+		err := api.UpdateExpenseInsights(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to update expense insights: %v", err)
 		}
 	case "revenue":
-		err := api.UpdateRevenueInsights()
+		err := api.UpdateRevenueInsights(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to update revenue insights: %v", err)
 		}
 	case "all":
-		api.Accounts["expense"] = append(api.Accounts["expense"], api.CashAccount())
+		api.store.addAccount("expense", api.CashAccount(ctx))
 		errs := []error{}
-		err1 := api.UpdateExpenseInsights()
+		err1 := api.UpdateExpenseInsights(ctx)
 		if err1 != nil {
 			errs = append(errs, fmt.Errorf("failed to update expense insights: %v", err1))
 		}
-		err2 := api.UpdateRevenueInsights()
+		err2 := api.UpdateRevenueInsights(ctx)
 		if err2 != nil {
 			errs = append(errs, fmt.Errorf("failed to update revenue insights: %v", err2))
 		}
@@ -237,8 +354,34 @@ func (api *Api) UpdateAccounts(accType string) error {
 	return nil
 }
 
-func (api *Api) ListAccounts(accountType string) ([]apiAccount, error) {
-	allData, err := api.fetchPaginated("%s/accounts?type=%s&page=%d",
+func (a apiAccount) toAccount() Account {
+	openingBalance, _ := strconv.ParseFloat(a.Attributes.OpeningBalance, 64)
+	creditLimit := 0.0
+	if a.Attributes.AccountRole == "ccAsset" {
+		creditLimit, _ = strconv.ParseFloat(a.Attributes.VirtualBalance, 64)
+	}
+	return Account{
+		ID:                 a.ID,
+		Name:               a.Attributes.Name,
+		CurrencyCode:       a.Attributes.CurrencyCode,
+		Type:               a.Attributes.Type,
+		LiabilityDirection: a.Attributes.LiabilityDirection,
+		LiabilityType:      a.Attributes.LiabilityType,
+		IBAN:               a.Attributes.IBAN,
+		AccountNumber:      a.Attributes.AccountNumber,
+		OpeningBalance:     openingBalance,
+		OpeningBalanceDate: a.Attributes.OpeningBalanceDate,
+		Interest:           a.Attributes.Interest,
+		InterestPeriod:     a.Attributes.InterestPeriod,
+		Notes:              a.Attributes.Notes,
+		Role:               a.Attributes.AccountRole,
+		MonthlyPaymentDate: a.Attributes.MonthlyPaymentDate,
+		CreditLimit:        creditLimit,
+	}
+}
+
+func (api *Api) ListAccounts(ctx context.Context, accountType string) ([]apiAccount, error) {
+	allData, err := api.fetchPaginated(ctx, "%s/accounts?type=%s&page=%d",
 		api.Config.ApiUrl,
 		accountType)
 	if err != nil {
@@ -253,33 +396,39 @@ func (api *Api) ListAccounts(accountType string) ([]apiAccount, error) {
 
 // TODO: Optimize search with a map
 func (api *Api) GetAccountByID(ID string) Account {
+	return api.accountByID(context.Background(), ID)
+}
+
+// accountByID waits up to 10 seconds for the account to be loaded, or until
+// ctx is done.
+func (api *Api) accountByID(ctx context.Context, ID string) Account {
 	const retryLimit = 10
 	const retryDelay = 1 * time.Second
 
 	var account Account
 	for attempt := 1; attempt <= retryLimit; attempt++ {
-		for _, groups := range api.Accounts {
-			for _, acc := range groups {
-				if acc.ID == ID {
-					return acc
-				}
-			}
+		if acc, ok := api.Data().AccountByID(ID); ok {
+			return acc
 		}
 
 		if attempt < retryLimit {
-			time.Sleep(retryDelay)
+			select {
+			case <-ctx.Done():
+				return account
+			case <-time.After(retryDelay):
+			}
 		}
 	}
 
 	return account
 }
 
-func (api *Api) CashAccount() Account {
+func (api *Api) CashAccount(ctx context.Context) Account {
 	if api.cashAccount != (Account{}) {
 		return api.cashAccount
 	}
 
-	accounts, err := api.ListAccounts("special")
+	accounts, err := api.ListAccounts(ctx, "special")
 	if err != nil {
 		zap.S().Errorf("Failed to fetch special accounts for cash account: %v", err)
 		return Account{}
@@ -304,16 +453,19 @@ func (api *Api) CashAccount() Account {
 // AccountsByType returns the cached accounts for the given type.
 // It returns a copy of the slice to avoid accidental mutation by callers.
 func (api *Api) AccountsByType(accountType string) []Account {
-	accounts := api.Accounts[accountType]
-	return append([]Account(nil), accounts...)
+	return api.Data().AccountsByType(accountType)
 }
 
 // AccountBalance returns the cached balance for the given account ID.
 func (api *Api) AccountBalance(accountID string) float64 {
-	if balance, ok := api.accountBalances[accountID]; ok {
-		return balance
-	}
-	return 0
+	return api.Data().AccountBalance(accountID)
+}
+
+// AccountLastActivity returns the date of the latest transaction of the
+// account, empty when unknown. It changes with every transaction, so it is
+// kept apart from Account like the balance.
+func (api *Api) AccountLastActivity(accountID string) string {
+	return api.Data().AccountLastActivity(accountID)
 }
 
 func (a *Account) GetBalance(api *Api) float64 {
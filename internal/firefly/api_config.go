@@ -12,4 +12,14 @@ type ApiConfig struct {
 	ApiUrl string
 	// TimeoutSeconds specifies the timeout for API requests in seconds.
 	TimeoutSeconds int
+	// Timeouts overrides TimeoutSeconds for classes of requests.
+	Timeouts TimeoutConfig
+	// PrimaryCurrency overrides the server primary currency by code, if set.
+	PrimaryCurrency string
+	// TLS holds custom certificates and verification options.
+	TLS TLSConfig
+	// Retry controls retries of transient failures.
+	Retry RetryConfig
+	// RateLimit limits the request rate sent to the server.
+	RateLimit RateLimitConfig
 }
@@ -0,0 +1,52 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package firefly
+
+import (
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+// ErrUnauthorized is returned once the server rejected the API token,
+// requests are not sent again until a new token is set with SetAPIKey.
+var ErrUnauthorized = errors.New("API token was rejected, a new one is required")
+
+// Is makes a 401 response match ErrUnauthorized.
+func (e *HTTPError) Is(target error) bool {
+	return target == ErrUnauthorized && e.StatusCode == http.StatusUnauthorized
+}
+
+// Unauthorized reports whether the server rejected the API token, e.g.
+// because it expired or was revoked.
+func (api *Api) Unauthorized() bool {
+	return api.unauthorized.Load()
+}
+
+// SetAPIKey replaces the API token and resumes sending requests.
+func (api *Api) SetAPIKey(key string) {
+	api.authMu.Lock()
+	api.Config.ApiKey = key
+	api.authMu.Unlock()
+	api.unauthorized.Store(false)
+	zap.L().Info("API token replaced")
+}
+
+func (api *Api) apiKey() string {
+	api.authMu.RLock()
+	defer api.authMu.RUnlock()
+	return api.Config.ApiKey
+}
+
+// trackAuthorization marks the token as rejected on a 401 response.
+func (api *Api) trackAuthorization(resp *http.Response) {
+	if resp == nil || resp.StatusCode != http.StatusUnauthorized {
+		return
+	}
+	if !api.unauthorized.Swap(true) {
+		zap.L().Warn("API token was rejected, pausing requests until it is replaced")
+	}
+}
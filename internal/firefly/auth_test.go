@@ -0,0 +1,50 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package firefly
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDo_RejectedTokenPausesRequests(t *testing.T) {
+	var hits atomic.Int32
+	api := newTestApi(t, func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if r.Header.Get("Authorization") != "Bearer new-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		writeData(w, `[]`)
+	})
+	endpoint := api.Config.ApiUrl + "/v1/accounts"
+
+	_, err := api.getRequest(context.Background(), endpoint)
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("Expected ErrUnauthorized, got %v", err)
+	}
+	if !api.Unauthorized() {
+		t.Error("Expected the token to be marked as rejected")
+	}
+
+	_, err = api.getRequest(context.Background(), endpoint)
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Expected ErrUnauthorized while paused, got %v", err)
+	}
+	if got := hits.Load(); got != 1 {
+		t.Errorf("Expected no request sent while paused, got %d requests", got)
+	}
+
+	api.SetAPIKey("new-token")
+	if _, err := api.getRequest(context.Background(), endpoint); err != nil {
+		t.Errorf("Expected requests to resume with the new token, got %v", err)
+	}
+	if api.Unauthorized() {
+		t.Error("Expected the new token to be accepted")
+	}
+}
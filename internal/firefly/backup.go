@@ -0,0 +1,68 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package firefly
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Backup is an archive of the accounts, categories and transactions
+// reachable with the API, kept as JSON.
+type Backup struct {
+	CreatedAt time.Time `json:"created_at"`
+	// Server is the API URL the data was read from
+	Server       string             `json:"server"`
+	Accounts     []Account          `json:"accounts"`
+	Balances     map[string]float64 `json:"balances"`
+	Categories   []Category         `json:"categories"`
+	Transactions []Transaction      `json:"transactions"`
+}
+
+// Backup reads all accounts, categories and transactions, whatever the
+// selected period.
+func (api *Api) Backup(ctx context.Context, now time.Time) (Backup, error) {
+	backup := Backup{
+		CreatedAt: now,
+		Server:    api.Config.ApiUrl,
+		Balances:  map[string]float64{},
+	}
+
+	accounts, err := api.ListAccounts(ctx, "all")
+	if err != nil {
+		return backup, fmt.Errorf("failed to back up accounts: %w", err)
+	}
+	for _, a := range accounts {
+		backup.Accounts = append(backup.Accounts, a.toAccount())
+		backup.Balances[a.ID] = a.Attributes.CurrentBalance
+	}
+
+	backup.Categories, err = api.ListCategories(ctx)
+	if err != nil {
+		return backup, fmt.Errorf("failed to back up categories: %w", err)
+	}
+
+	allData, err := api.fetchPaginated(ctx, "%s/transactions?page=%d", api.Config.ApiUrl)
+	if err != nil {
+		return backup, fmt.Errorf("failed to back up transactions: %w", err)
+	}
+	txs, err := unmarshalItems[ResponseTransaction](allData)
+	if err != nil {
+		return backup, fmt.Errorf("failed to unmarshal transactions: %v", err)
+	}
+	backup.Transactions = make([]Transaction, 0, len(txs))
+	for id, t := range txs {
+		backup.Transactions = append(backup.Transactions, api.fromResponse(ctx, t, uint(id)))
+	}
+
+	zap.L().Info("Backup read",
+		zap.Int("accounts", len(backup.Accounts)),
+		zap.Int("categories", len(backup.Categories)),
+		zap.Int("transactions", len(backup.Transactions)))
+	return backup, nil
+}
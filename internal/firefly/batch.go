@@ -0,0 +1,136 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package firefly
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+)
+
+// BatchConcurrency is how many transactions of a batch are sent at once.
+const BatchConcurrency = 4
+
+// BatchResult is the outcome of creating or updating a batch of transactions.
+type BatchResult struct {
+	Total   int
+	Created []string // IDs of the created or updated transactions, in batch order
+	Sent    []int    // position in the batch of each of Created
+	Queued  int      // kept while offline, sent once the server is back
+	Failed  []BatchFailure
+	// Verb is what was done to the transactions, "created" when empty
+	Verb string
+}
+
+// BatchFailure is a transaction of a batch that was not sent.
+type BatchFailure struct {
+	Index       int // position in the batch
+	Description string
+	Err         error
+}
+
+// Summary reports the outcome, e.g. "42/45 created, 3 failed".
+func (r BatchResult) Summary() string {
+	verb := r.Verb
+	if verb == "" {
+		verb = "created"
+	}
+	s := fmt.Sprintf("%d/%d %s", len(r.Created), r.Total, verb)
+	if r.Queued > 0 {
+		s += fmt.Sprintf(", %d queued", r.Queued)
+	}
+	if len(r.Failed) > 0 {
+		s += fmt.Sprintf(", %d failed", len(r.Failed))
+	}
+	return s
+}
+
+// CreateTransactions creates the transactions of a batch, at most
+// BatchConcurrency at a time. Failures do not stop the batch, they are
+// collected in the result.
+func (api *Api) CreateTransactions(ctx context.Context, txs []RequestTransaction) BatchResult {
+	return CreateBatch(ctx, txs, BatchConcurrency, api.CreateTransaction)
+}
+
+// CreateBatch creates txs with create, running at most concurrency calls at
+// once. Transactions not started before ctx is done fail with its error.
+func CreateBatch(ctx context.Context, txs []RequestTransaction, concurrency int,
+	create func(context.Context, RequestTransaction) (string, error),
+) BatchResult {
+	return runBatch(ctx, txs, concurrency, nil, func(ctx context.Context, i int) (string, error) {
+		return create(ctx, txs[i])
+	})
+}
+
+// UpdateBatch updates the transaction ids[i] with txs[i] like CreateBatch
+// creates them. progress, if set, is called with the number of finished
+// updates, from the goroutines sending them.
+func UpdateBatch(ctx context.Context, ids []string, txs []RequestTransaction, concurrency int,
+	update func(context.Context, string, RequestTransaction) (string, error),
+	progress func(done int),
+) BatchResult {
+	result := runBatch(ctx, txs, concurrency, progress, func(ctx context.Context, i int) (string, error) {
+		return update(ctx, ids[i], txs[i])
+	})
+	result.Verb = "updated"
+	return result
+}
+
+func runBatch(ctx context.Context, txs []RequestTransaction, concurrency int,
+	progress func(done int), send func(context.Context, int) (string, error),
+) BatchResult {
+	ids := make([]string, len(txs))
+	errs := make([]error, len(txs))
+
+	var done atomic.Int32
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, max(concurrency, 1))
+	for i := range txs {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			ids[i], errs[i] = send(ctx, i)
+			if progress != nil {
+				progress(int(done.Add(1)))
+			}
+		}()
+	}
+	wg.Wait()
+
+	result := BatchResult{Total: len(txs)}
+	for i, err := range errs {
+		switch {
+		case err == nil:
+			result.Created = append(result.Created, ids[i])
+			result.Sent = append(result.Sent, i)
+		case errors.Is(err, ErrQueued):
+			result.Queued++
+		default:
+			zap.L().Warn("Failed to send transaction of batch",
+				zap.Int("index", i),
+				zap.String("transaction", txs[i].describe()),
+				zap.Error(err))
+			result.Failed = append(result.Failed, BatchFailure{
+				Index:       i,
+				Description: txs[i].describe(),
+				Err:         err,
+			})
+		}
+	}
+	return result
+}
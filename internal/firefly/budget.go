@@ -4,7 +4,173 @@ SPDX-License-Identifier: Apache-2.0
 */
 package firefly
 
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
 type Budget struct {
 	ID   string
 	Name string
+	// Limit is the amount budgeted for the period, zero without a limit
+	Limit float64
+	// Spent in the period, as a positive amount
+	Spent        float64
+	CurrencyCode string
+}
+
+// Remaining is what is left of the limit, negative when overspent.
+func (b Budget) Remaining() float64 {
+	return b.Limit - b.Spent
+}
+
+type apiBudget struct {
+	ID         string        `json:"id"`
+	Attributes apiBudgetAttr `json:"attributes"`
+}
+
+type apiBudgetAttr struct {
+	Name   string           `json:"name"`
+	Active bool             `json:"active"`
+	Spent  []apiBudgetSpent `json:"spent"`
+}
+
+type apiBudgetSpent struct {
+	Sum          string `json:"sum"`
+	CurrencyCode string `json:"currency_code"`
+}
+
+type apiBudgetLimit struct {
+	ID         string             `json:"id"`
+	Attributes apiBudgetLimitAttr `json:"attributes"`
+}
+
+type apiBudgetLimitAttr struct {
+	BudgetID     string `json:"budget_id"`
+	Amount       string `json:"amount"`
+	CurrencyCode string `json:"currency_code"`
+}
+
+// updateBudgets fetches the active budgets with their limit and spent
+// amount in the period.
+func (api *Api) updateBudgets(ctx context.Context) error {
+	start, end := api.StartDate.Format("2006-01-02"), api.EndDate.Format("2006-01-02")
+
+	allData, err := api.fetchPaginated(ctx, "%s/budgets?start=%s&end=%s&page=%d", api.Config.ApiUrl, start, end)
+	if err != nil {
+		return fmt.Errorf("failed to fetch paginated budgets: %w", err)
+	}
+	items, err := unmarshalItems[apiBudget](allData)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal budgets: %v", err)
+	}
+
+	allData, err = api.fetchPaginated(ctx, "%s/budget-limits?start=%s&end=%s&page=%d", api.Config.ApiUrl, start, end)
+	if err != nil {
+		return fmt.Errorf("failed to fetch paginated budget limits: %w", err)
+	}
+	limits, err := unmarshalItems[apiBudgetLimit](allData)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal budget limits: %v", err)
+	}
+
+	budgets := make(map[string]Budget, len(items))
+	for _, item := range items {
+		if !item.Attributes.Active {
+			continue
+		}
+		budgets[item.ID] = Budget{
+			ID:           item.ID,
+			Name:         item.Attributes.Name,
+			CurrencyCode: api.PrimaryCurrency().Code,
+		}
+	}
+	for _, limit := range limits {
+		b, ok := budgets[limit.Attributes.BudgetID]
+		if !ok {
+			continue
+		}
+		amount, _ := strconv.ParseFloat(limit.Attributes.Amount, 64)
+		b.Limit += amount
+		if limit.Attributes.CurrencyCode != "" {
+			b.CurrencyCode = limit.Attributes.CurrencyCode
+		}
+		budgets[b.ID] = b
+	}
+	for _, item := range items {
+		b, ok := budgets[item.ID]
+		if !ok {
+			continue
+		}
+		for _, spent := range item.Attributes.Spent {
+			if !strings.EqualFold(spent.CurrencyCode, b.CurrencyCode) {
+				continue
+			}
+			sum, _ := strconv.ParseFloat(spent.Sum, 64)
+			b.Spent -= sum
+		}
+		budgets[b.ID] = b
+	}
+
+	api.budgets = budgets
+	return nil
+}
+
+// CategoryBudget returns the budget named like the category, if any.
+func (api *Api) CategoryBudget(categoryName string) (Budget, bool) {
+	for _, b := range api.budgets {
+		if strings.EqualFold(b.Name, categoryName) {
+			return b, true
+		}
+	}
+	return Budget{}, false
+}
+
+// Budgets returns the active budgets of the period by name.
+func (api *Api) Budgets() []Budget {
+	return slices.SortedFunc(maps.Values(api.budgets), func(a, b Budget) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+}
+
+// BudgetAverages returns the average spent per month of each budget by ID,
+// over the given number of months before the month of before, in the
+// primary currency.
+func (api *Api) BudgetAverages(ctx context.Context, months int, before time.Time) (map[string]float64, error) {
+	end := time.Date(before.Year(), before.Month(), 1, 0, 0, 0, 0, before.Location())
+	start := end.AddDate(0, -months, 0)
+
+	items, err := api.getInsights(ctx, "expense/budget", start, end.AddDate(0, 0, -1), nil)
+	if err != nil {
+		return nil, err
+	}
+	averages := make(map[string]float64, len(items))
+	for _, item := range items {
+		averages[item.ID] += api.insightInPrimary(item) * -1 / float64(months)
+	}
+	return averages, nil
+}
+
+// SetBudgetLimit sets the limit of budget from start to end, both included.
+func (api *Api) SetBudgetLimit(ctx context.Context, budget Budget, amount float64, start, end time.Time) error {
+	endpoint := fmt.Sprintf("%s/budgets/%s/limits", api.Config.ApiUrl, budget.ID)
+
+	payload := map[string]any{
+		"budget_id":     budget.ID,
+		"start":         start.Format("2006-01-02"),
+		"end":           end.Format("2006-01-02"),
+		"amount":        fmt.Sprintf("%.2f", amount),
+		"currency_code": budget.CurrencyCode,
+	}
+
+	_, err := api.write(ctx, http.MethodPost, endpoint, payload,
+		fmt.Sprintf("set limit of budget %s to %.2f", budget.Name, amount))
+	return err
 }
@@ -0,0 +1,107 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package firefly
+
+import (
+	"context"
+	"maps"
+	"time"
+)
+
+// BurnRate is the average spent per day from the asset accounts in the
+// period so far, projected over the days left in it.
+type BurnRate struct {
+	// DaysPassed includes today, both are zero outside the current period
+	DaysPassed int
+	DaysLeft   int
+	// Daily is the average spent per day by asset account ID, in the
+	// currency of the account
+	Daily map[string]float64
+	// DailyTotal and Balance total the asset accounts in CurrencyCode, the
+	// primary currency
+	DailyTotal   float64
+	Balance      float64
+	CurrencyCode string
+}
+
+// Active reports whether the period is the current one, the only one a
+// projection makes sense for.
+func (b BurnRate) Active() bool {
+	return b.DaysPassed > 0
+}
+
+// Projected is the balance left at the end of the period, spending daily.
+func (b BurnRate) Projected(balance, daily float64) float64 {
+	return balance - daily*float64(b.DaysLeft)
+}
+
+// ProjectedTotal is the balance of the asset accounts left at the end of
+// the period, in the primary currency.
+func (b BurnRate) ProjectedTotal() float64 {
+	return b.Projected(b.Balance, b.DailyTotal)
+}
+
+// PeriodDays returns the days of the period from start to end passed by
+// now, today included, and the days left after today. Both are zero when
+// now is outside the period.
+func PeriodDays(start, end, now time.Time) (passed, left int) {
+	if now.Before(start) || now.After(end) {
+		return 0, 0
+	}
+	day := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	passed = int(day(now).Sub(day(start)).Hours()/24) + 1
+	left = int(day(end).Sub(day(now)).Hours() / 24)
+	return passed, left
+}
+
+// updateBurnRate fetches what the asset accounts spent in the period up to
+// now, if the period is the current one.
+func (api *Api) updateBurnRate(ctx context.Context, now time.Time) error {
+	passed, left := PeriodDays(api.StartDate, api.EndDate, now)
+	spent := map[string]float64{}
+	if passed > 0 {
+		items, err := api.getInsights(ctx, "expense/asset", api.StartDate, now, nil)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			spent[item.ID] -= item.DifferenceFloat
+		}
+	}
+
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	api.burnSpent, api.burnPassed, api.burnLeft = spent, passed, left
+	return nil
+}
+
+// BurnRate returns the spending of the asset accounts in the period so far
+// with their current balances.
+func (api *Api) BurnRate() BurnRate {
+	api.mu.Lock()
+	spent := maps.Clone(api.burnSpent)
+	rate := BurnRate{
+		DaysPassed:   api.burnPassed,
+		DaysLeft:     api.burnLeft,
+		Daily:        map[string]float64{},
+		CurrencyCode: api.PrimaryCurrency().Code,
+	}
+	api.mu.Unlock()
+	if !rate.Active() {
+		return rate
+	}
+
+	for _, account := range api.AccountsByType("asset") {
+		daily := spent[account.ID] / float64(rate.DaysPassed)
+		rate.Daily[account.ID] = daily
+		balance, _ := api.ConvertToPrimary(api.AccountBalance(account.ID), account.CurrencyCode)
+		daily, _ = api.ConvertToPrimary(daily, account.CurrencyCode)
+		rate.Balance += balance
+		rate.DailyTotal += daily
+	}
+	return rate
+}
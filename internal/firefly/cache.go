@@ -0,0 +1,66 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package firefly
+
+import (
+	"net/http"
+	"sync"
+)
+
+const maxCacheEntries = 256
+
+// responseCache keeps GET response bodies with their validators so repeated
+// requests can be made conditional. Responses without ETag or Last-Modified
+// are not cached, there is no way to tell whether they are still fresh.
+type responseCache struct {
+	mu      sync.RWMutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	etag         string
+	lastModified string
+	body         []byte
+}
+
+func (c *responseCache) get(key string) (cacheEntry, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	entry, ok := c.entries[key]
+	return entry, ok
+}
+
+func (c *responseCache) put(key string, resp *http.Response, body []byte) {
+	entry := cacheEntry{
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+		body:         body,
+	}
+	if entry.etag == "" && entry.lastModified == "" {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil || len(c.entries) >= maxCacheEntries {
+		c.entries = make(map[string]cacheEntry)
+	}
+	c.entries[key] = entry
+}
+
+// apply makes the request conditional on the cached validators.
+func (e cacheEntry) apply(req *http.Request) {
+	if e.etag != "" {
+		req.Header.Set("If-None-Match", e.etag)
+	}
+	if e.lastModified != "" {
+		req.Header.Set("If-Modified-Since", e.lastModified)
+	}
+}
+
+// cacheKey identifies a GET response by URL and the selected period.
+func (api *Api) cacheKey(endpoint string) string {
+	return endpoint + "|" + api.periodKey()
+}
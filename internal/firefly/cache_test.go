@@ -0,0 +1,120 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package firefly
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// validatingHandler answers with an ETag and Last-Modified when set and
+// 304 when the request carries them. conditional records the validators of
+// each request.
+func validatingHandler(etag, lastModified string, conditional *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		match, since := r.Header.Get("If-None-Match"), r.Header.Get("If-Modified-Since")
+		*conditional = append(*conditional, match+"|"+since)
+		if (etag != "" && match == etag) || (lastModified != "" && since == lastModified) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		if etag != "" {
+			w.Header().Set("ETag", etag)
+		}
+		if lastModified != "" {
+			w.Header().Set("Last-Modified", lastModified)
+		}
+		writeData(w, `[{"id":"1"}]`)
+	}
+}
+
+func getIDs(t *testing.T, api *Api, endpoint string) []any {
+	t.Helper()
+	resp, err := api.getRequest(context.Background(), endpoint)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	data, _ := resp.Data.([]any)
+	return data
+}
+
+func TestCache_RevalidatesWithETag(t *testing.T) {
+	var conditional []string
+	api := newTestApi(t, validatingHandler(`"v1"`, "", &conditional))
+	endpoint := api.Config.ApiUrl + "/v1/accounts"
+
+	first := getIDs(t, api, endpoint)
+	second := getIDs(t, api, endpoint)
+
+	if len(first) != 1 || len(second) != 1 {
+		t.Errorf("Expected the cached body on 304, got %v and %v", first, second)
+	}
+	if len(conditional) != 2 || conditional[0] != "|" || conditional[1] != `"v1"|` {
+		t.Errorf("Expected the second request to carry If-None-Match, got %q", conditional)
+	}
+	if hits, lookups := api.stats.cacheHits.Load(), api.stats.cacheLookups.Load(); hits != 1 || lookups != 2 {
+		t.Errorf("Expected 1 hit in 2 lookups, got %d in %d", hits, lookups)
+	}
+}
+
+func TestCache_RevalidatesWithLastModified(t *testing.T) {
+	var conditional []string
+	modified := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC).Format(http.TimeFormat)
+	api := newTestApi(t, validatingHandler("", modified, &conditional))
+	endpoint := api.Config.ApiUrl + "/v1/accounts"
+
+	getIDs(t, api, endpoint)
+	if got := getIDs(t, api, endpoint); len(got) != 1 {
+		t.Errorf("Expected the cached body on 304, got %v", got)
+	}
+	if len(conditional) != 2 || conditional[1] != "|"+modified {
+		t.Errorf("Expected the second request to carry If-Modified-Since, got %q", conditional)
+	}
+}
+
+func TestCache_SkipsResponsesWithoutValidators(t *testing.T) {
+	var conditional []string
+	api := newTestApi(t, validatingHandler("", "", &conditional))
+	endpoint := api.Config.ApiUrl + "/v1/accounts"
+
+	getIDs(t, api, endpoint)
+	getIDs(t, api, endpoint)
+
+	if len(conditional) != 2 || conditional[1] != "|" {
+		t.Errorf("Expected unconditional requests, got %q", conditional)
+	}
+}
+
+func TestCache_KeyedByPeriod(t *testing.T) {
+	var conditional []string
+	api := newTestApi(t, validatingHandler(`"v1"`, "", &conditional))
+	endpoint := api.Config.ApiUrl + "/v1/insight/expense/expense"
+
+	api.SetPeriod(2026, time.January)
+	getIDs(t, api, endpoint)
+	api.SetPeriod(2026, time.February)
+	getIDs(t, api, endpoint)
+
+	if len(conditional) != 2 || conditional[1] != "|" {
+		t.Errorf("Expected the response of another period not to be reused, got %q", conditional)
+	}
+}
+
+func TestResponseCache_BoundedSize(t *testing.T) {
+	var c responseCache
+	resp := &http.Response{Header: http.Header{"Etag": {`"v"`}}}
+	for i := range maxCacheEntries + 1 {
+		c.put(fmt.Sprint(i), resp, nil)
+	}
+	if len(c.entries) > maxCacheEntries {
+		t.Errorf("Expected at most %d entries, got %d", maxCacheEntries, len(c.entries))
+	}
+	if _, ok := c.get(fmt.Sprint(maxCacheEntries)); !ok {
+		t.Error("Expected the latest entry to be kept")
+	}
+}
@@ -5,7 +5,11 @@ SPDX-License-Identifier: Apache-2.0
 package firefly
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+
+	"go.uber.org/zap"
 )
 
 type Category struct {
@@ -27,7 +31,7 @@ type apiCategoryAttr struct {
 	CurrencyCode string `json:"primary_currency_code"`
 }
 
-func (api *Api) CreateCategory(name, notes string) error {
+func (api *Api) CreateCategory(ctx context.Context, name, notes string) error {
 	endpoint := fmt.Sprintf("%s/categories", api.Config.ApiUrl)
 
 	payload := map[string]any{
@@ -35,7 +39,7 @@ func (api *Api) CreateCategory(name, notes string) error {
 		"notes": notes,
 	}
 
-	response, err := api.postRequest(endpoint, payload)
+	response, err := api.write(ctx, http.MethodPost, endpoint, payload, "create category "+name)
 	if err != nil {
 		return err
 	}
@@ -51,23 +55,36 @@ func (api *Api) CreateCategory(name, notes string) error {
 	return nil
 }
 
-func (api *Api) UpdateCategoriesInsights() error {
+func (api *Api) UpdateCategoriesInsights(ctx context.Context) error {
+	return api.coalesce(ctx, "category-insights:"+api.periodKey(), func(ctx context.Context) error {
+		return api.updateCategoriesInsights(ctx)
+	})
+}
+
+func (api *Api) updateCategoriesInsights(ctx context.Context) error {
 	// TODO: Need error reporting
 	insights := make(map[string]categoryInsight)
+	spentTotals := make(map[string]float64)
+	earnedTotals := make(map[string]float64)
 
-	spentInsights, err := api.GetInsights("expense/category")
+	spentInsights, err := api.GetInsights(ctx, "expense/category")
 	if err == nil {
 		for _, item := range spentInsights {
 			insights[item.ID] = categoryInsight{
 				Spent:  (-1) * item.DifferenceFloat,
 				Earned: 0,
 			}
+			spentTotals[item.CurrencyCode] += (-1) * item.DifferenceFloat
 		}
 	}
 
-	earnedInsights, err := api.GetInsights("income/category")
+	earnedInsights, err := api.GetInsights(ctx, "income/category")
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
 	if err == nil {
 		for _, item := range earnedInsights {
+			earnedTotals[item.CurrencyCode] += item.DifferenceFloat
 			if val, ok := insights[item.ID]; ok {
 				val.Earned = item.DifferenceFloat
 				insights[item.ID] = val
@@ -80,19 +97,35 @@ func (api *Api) UpdateCategoriesInsights() error {
 		}
 	}
 
-	api.categoryInsights = insights
+	api.store.update(func(d *StoreData) {
+		d.categoryInsights = insights
+		d.categorySpentTotals = spentTotals
+		d.categoryEarnedTotals = earnedTotals
+	})
+
+	// Budgets are optional, categories are shown without them
+	if err := api.updateBudgets(ctx); err != nil {
+		zap.L().Warn("Failed to update budgets", zap.Error(err))
+	}
 
 	return nil
 }
 
-func (api *Api) UpdateCategories() error {
-	categories, err := api.ListCategories()
+func (api *Api) UpdateCategories(ctx context.Context) error {
+	return api.coalesce(ctx, "categories", func(ctx context.Context) error {
+		return api.updateCategories(ctx)
+	})
+}
+
+func (api *Api) updateCategories(ctx context.Context) error {
+	categories, err := api.ListCategories(ctx)
 	if err != nil {
 		return err
 	}
-	api.Categories = categories
+	api.store.update(func(d *StoreData) { d.categories = categories })
+	api.markFresh(StaleCategories)
 
-	err = api.UpdateCategoriesInsights()
+	err = api.UpdateCategoriesInsights(ctx)
 	if err != nil {
 		return err
 	}
@@ -100,8 +133,8 @@ func (api *Api) UpdateCategories() error {
 	return nil
 }
 
-func (api *Api) ListCategories() ([]Category, error) {
-	allData, err := api.fetchPaginated("%s/categories?page=%d", api.Config.ApiUrl)
+func (api *Api) ListCategories(ctx context.Context) ([]Category, error) {
+	allData, err := api.fetchPaginated(ctx, "%s/categories?page=%d", api.Config.ApiUrl)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch paginated categories: %v", err)
 	}
@@ -125,43 +158,27 @@ func (api *Api) ListCategories() ([]Category, error) {
 }
 
 func (api *Api) GetCategoryByName(name string) Category {
-	for _, category := range api.Categories {
-		if category.Name == name {
-			return category
-		}
-	}
-	return Category{}
+	return api.Data().GetCategoryByName(name)
 }
 
 func (api *Api) GetCategoryByID(ID string) Category {
-	for _, category := range api.Categories {
-		if category.ID == ID {
-			return category
-		}
-	}
-	return Category{}
+	return api.Data().GetCategoryByID(ID)
 }
 
 // CategoriesList returns the cached categories.
 // It returns a copy of the slice to avoid accidental mutation by callers.
 func (api *Api) CategoriesList() []Category {
-	return append([]Category(nil), api.Categories...)
+	return api.Data().CategoriesList()
 }
 
 // CategorySpent returns the cached spent amount for a category.
 func (api *Api) CategorySpent(categoryID string) float64 {
-	if insight, ok := api.categoryInsights[categoryID]; ok {
-		return insight.Spent
-	}
-	return 0
+	return api.Data().CategorySpent(categoryID)
 }
 
 // CategoryEarned returns the cached earned amount for a category.
 func (api *Api) CategoryEarned(categoryID string) float64 {
-	if insight, ok := api.categoryInsights[categoryID]; ok {
-		return insight.Earned
-	}
-	return 0
+	return api.Data().CategoryEarned(categoryID)
 }
 
 func (c *Category) GetSpent(api *Api) float64 {
@@ -172,12 +189,14 @@ func (c *Category) GetEarned(api *Api) float64 {
 	return api.CategoryEarned(c.ID)
 }
 
+// CategoryTotals returns the spent and earned amounts of the period by
+// currency code.
+func (api *Api) CategoryTotals() (spent, earned map[string]float64) {
+	return api.Data().CategoryTotals()
+}
+
 func (api *Api) GetTotalSpentEarnedCategories() (spent, earned float64) {
-	for _, insight := range api.categoryInsights {
-		spent += insight.Spent
-		earned += insight.Earned
-	}
-	return
+	return api.Data().GetTotalSpentEarnedCategories()
 }
 
 func (c *Category) IsEmpty() bool {
@@ -0,0 +1,106 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package firefly
+
+import (
+	"cmp"
+	"context"
+	"net/url"
+	"slices"
+	"time"
+)
+
+// CategoryTopAccounts is how many expense accounts CategoryHistory returns.
+const CategoryTopAccounts = 5
+
+// CategoryMonth is what was spent and earned in a category in one month, in
+// the primary currency.
+type CategoryMonth struct {
+	Month  time.Time
+	Spent  float64
+	Earned float64
+}
+
+// CategoryAccount is what was spent at an expense account within a category.
+type CategoryAccount struct {
+	Name  string
+	Spent float64
+}
+
+// CategoryHistory is the monthly spent and earned of a category, oldest
+// first, with the expense accounts it was spent at the most over those months.
+type CategoryHistory struct {
+	Months      []CategoryMonth
+	TopAccounts []CategoryAccount
+}
+
+// CategoryHistory fetches the insights of a category for the given number of
+// months up to and including the month of end.
+func (api *Api) CategoryHistory(ctx context.Context, categoryID string, months int, end time.Time) (CategoryHistory, error) {
+	filter := url.Values{"categories[]": {categoryID}}
+	first := time.Date(end.Year(), end.Month()-time.Month(months-1), 1, 0, 0, 0, 0, end.Location())
+
+	history := CategoryHistory{}
+	for i := range months {
+		start := first.AddDate(0, i, 0)
+		month := CategoryMonth{Month: start}
+
+		spent, err := api.getInsights(ctx, "expense/category", start, start.AddDate(0, 1, -1), filter)
+		if err != nil {
+			return CategoryHistory{}, err
+		}
+		for _, item := range spent {
+			if item.ID == categoryID {
+				month.Spent += api.insightInPrimary(item) * -1
+			}
+		}
+
+		earned, err := api.getInsights(ctx, "income/category", start, start.AddDate(0, 1, -1), filter)
+		if err != nil {
+			return CategoryHistory{}, err
+		}
+		for _, item := range earned {
+			if item.ID == categoryID {
+				month.Earned += api.insightInPrimary(item)
+			}
+		}
+
+		history.Months = append(history.Months, month)
+	}
+
+	accounts, err := api.getInsights(ctx, "expense/expense", first, first.AddDate(0, months, -1), filter)
+	if err != nil {
+		return CategoryHistory{}, err
+	}
+	byName := map[string]float64{}
+	for _, item := range accounts {
+		byName[item.Name] += api.insightInPrimary(item) * -1
+	}
+	for name, spent := range byName {
+		history.TopAccounts = append(history.TopAccounts, CategoryAccount{Name: name, Spent: spent})
+	}
+	history.TopAccounts = TopCategoryAccounts(history.TopAccounts)
+
+	return history, nil
+}
+
+// TopCategoryAccounts sorts accounts by spent amount, highest first, and
+// keeps the first CategoryTopAccounts.
+func TopCategoryAccounts(accounts []CategoryAccount) []CategoryAccount {
+	slices.SortFunc(accounts, func(a, b CategoryAccount) int {
+		if c := cmp.Compare(b.Spent, a.Spent); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return accounts[:min(len(accounts), CategoryTopAccounts)]
+}
+
+// insightInPrimary converts the difference of an insight item to the primary
+// currency, keeping it as is when there is no exchange rate.
+func (api *Api) insightInPrimary(item insightItem) float64 {
+	amount, _ := api.ConvertToPrimary(item.DifferenceFloat, item.CurrencyCode)
+	return amount
+}
@@ -0,0 +1,169 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package firefly
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// flightGroup coalesces identical in-flight calls: callers with the same
+// key while a call is running wait for it and share its result.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	done chan struct{}
+	val  any
+	err  error
+	// waiters is the number of callers waiting for the call, it is canceled
+	// once they all gave up
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// DoContext runs fn on behalf of ctx, or joins the call with the same key
+// already running. The call runs on a context detached from the one of the
+// caller that started it and bounded by timeout if set, so a caller giving
+// up does not fail the others sharing the call. Each caller waits until the
+// call is done or its own ctx is; the call is canceled once every caller
+// gave up.
+func (g *flightGroup) DoContext(ctx context.Context, key string, timeout time.Duration, fn func(ctx context.Context) (any, error)) (val any, err error, shared bool) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+	c, shared := g.calls[key]
+	if shared {
+		zap.L().Debug("Joining in-flight request", zap.String("key", key))
+	} else {
+		callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		c = &flightCall{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = c
+		go g.run(callCtx, key, c, timeout, fn)
+	}
+	c.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.val, c.err, shared
+	case <-ctx.Done():
+		g.mu.Lock()
+		c.waiters--
+		if c.waiters == 0 {
+			c.cancel()
+			g.forget(key, c)
+		}
+		g.mu.Unlock()
+		return nil, ctx.Err(), false
+	}
+}
+
+func (g *flightGroup) run(ctx context.Context, key string, c *flightCall, timeout time.Duration, fn func(ctx context.Context) (any, error)) {
+	defer c.cancel()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	c.val, c.err = fn(ctx)
+
+	g.mu.Lock()
+	g.forget(key, c)
+	g.mu.Unlock()
+	close(c.done)
+}
+
+// forget removes c so the next caller with key starts a new call. g.mu must
+// be held.
+func (g *flightGroup) forget(key string, c *flightCall) {
+	if g.calls[key] == c {
+		delete(g.calls, key)
+	}
+}
+
+// coalesce runs fn unless a call with the same key is already running, in
+// which case it waits for that call and returns its error. The call is
+// shared like the GET requests, a caller giving up does not fail the others.
+func (api *Api) coalesce(ctx context.Context, key string, fn func(ctx context.Context) error) error {
+	_, err, _ := api.flights.DoContext(ctx, key, 0, func(ctx context.Context) (any, error) {
+		return nil, fn(ctx)
+	})
+	return err
+}
+
+// RateLimitConfig limits how many requests are sent to the server.
+type RateLimitConfig struct {
+	// RequestsPerSecond is the sustained rate, 0 disables the limiter.
+	RequestsPerSecond float64
+	// Burst is the number of requests allowed at once.
+	Burst int
+}
+
+func DefaultRateLimitConfig() RateLimitConfig {
+	return RateLimitConfig{
+		RequestsPerSecond: 20,
+		Burst:             20,
+	}
+}
+
+// rateLimiter is a token bucket. A nil limiter does not limit.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	burst    float64
+	tokens   float64
+	last     time.Time
+}
+
+func newRateLimiter(cfg RateLimitConfig) *rateLimiter {
+	if cfg.RequestsPerSecond <= 0 {
+		return nil
+	}
+	burst := float64(max(cfg.Burst, 1))
+	return &rateLimiter{
+		interval: time.Duration(float64(time.Second) / cfg.RequestsPerSecond),
+		burst:    burst,
+		tokens:   burst,
+		last:     time.Now(),
+	}
+}
+
+// Wait blocks until a request may be sent or ctx is done.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+float64(now.Sub(l.last))/float64(l.interval))
+	l.last = now
+	l.tokens--
+	var delay time.Duration
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens * float64(l.interval))
+	}
+	l.mu.Unlock()
+
+	if delay > 0 {
+		zap.L().Debug("Rate limiting request", zap.Duration("delay", delay))
+		select {
+		case <-ctx.Done():
+			l.mu.Lock()
+			l.tokens++
+			l.mu.Unlock()
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package firefly
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetRequest_CanceledCallerDoesNotFailSharedRequest(t *testing.T) {
+	var hits atomic.Int32
+	arrived := make(chan struct{})
+	release := make(chan struct{})
+	api := newTestApi(t, func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) == 1 {
+			close(arrived)
+		}
+		<-release
+		writeData(w, `[]`)
+	})
+	endpoint := api.Config.ApiUrl + "/v1/accounts"
+
+	first, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := api.getRequest(first, endpoint)
+		firstErr <- err
+	}()
+	<-arrived
+
+	secondErr := make(chan error, 1)
+	go func() {
+		_, err := api.getRequest(context.Background(), endpoint)
+		secondErr <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	cancel()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the canceled caller to stop waiting, got %v", err)
+	}
+
+	close(release)
+	if err := <-secondErr; err != nil {
+		t.Errorf("Expected the other caller to get the response, got %v", err)
+	}
+	if got := hits.Load(); got != 1 {
+		t.Errorf("Expected 1 request to the server, got %d", got)
+	}
+}
+
+func TestGetRequest_CoalescesIdenticalRequests(t *testing.T) {
+	var hits atomic.Int32
+	release := make(chan struct{})
+	api := newTestApi(t, func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		<-release
+		writeData(w, `[{"id":"1"}]`)
+	})
+	endpoint := api.Config.ApiUrl + "/v1/accounts"
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for range 5 {
+		wg.Go(func() {
+			resp, err := api.getRequest(context.Background(), endpoint)
+			if err == nil && resp.Data == nil {
+				err = errors.New("no data")
+			}
+			errs <- err
+		})
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Expected every caller to get the response, got %v", err)
+		}
+	}
+	if got := hits.Load(); got != 1 {
+		t.Errorf("Expected 1 request to the server, got %d", got)
+	}
+}
+
+func TestGetRequest_DoesNotCoalesceDifferentRequests(t *testing.T) {
+	var hits atomic.Int32
+	api := newTestApi(t, func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		writeData(w, `[]`)
+	})
+
+	for _, path := range []string{"/v1/accounts", "/v1/categories"} {
+		if _, err := api.getRequest(context.Background(), api.Config.ApiUrl+path); err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+	}
+	if got := hits.Load(); got != 2 {
+		t.Errorf("Expected 2 requests to the server, got %d", got)
+	}
+}
+
+func TestCoalesce_CanceledFirstCallerDoesNotFailOthers(t *testing.T) {
+	var hits atomic.Int32
+	arrived := make(chan struct{})
+	release := make(chan struct{})
+	api := newTestApi(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/categories" {
+			// Insights and budgets loaded with the categories
+			writeData(w, `[]`)
+			return
+		}
+		if hits.Add(1) == 1 {
+			close(arrived)
+		}
+		<-release
+		writeData(w, `[{"id":"1","attributes":{"name":"Groceries"}}]`)
+	})
+
+	first, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() { firstErr <- api.UpdateCategories(first) }()
+	<-arrived
+
+	secondErr := make(chan error, 1)
+	go func() { secondErr <- api.UpdateCategories(context.Background()) }()
+	time.Sleep(20 * time.Millisecond)
+
+	cancel()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the canceled caller to stop waiting, got %v", err)
+	}
+
+	close(release)
+	if err := <-secondErr; err != nil {
+		t.Errorf("Expected the other caller to get the categories, got %v", err)
+	}
+	if got := api.Data().CategoriesList(); len(got) != 1 || got[0].Name != "Groceries" {
+		t.Errorf("Expected the categories loaded, got %+v", got)
+	}
+	if got := hits.Load(); got != 1 {
+		t.Errorf("Expected the categories requested once, got %d", got)
+	}
+}
+
+func TestFlightGroup_CancelsCallOnceEveryCallerGaveUp(t *testing.T) {
+	var g flightGroup
+	started := make(chan struct{})
+	callErr := make(chan error, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		_, _, _ = g.DoContext(ctx, "key", 0, func(ctx context.Context) (any, error) {
+			close(started)
+			<-ctx.Done()
+			callErr <- ctx.Err()
+			return nil, ctx.Err()
+		})
+	}()
+	<-started
+	cancel()
+
+	select {
+	case err := <-callErr:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected the call canceled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the call canceled once its only caller gave up")
+	}
+
+	val, err, shared := g.DoContext(context.Background(), "key", 0, func(context.Context) (any, error) {
+		return "fresh", nil
+	})
+	if err != nil || shared || val != "fresh" {
+		t.Errorf("Expected a new call after the canceled one, got %v, %v, %v", val, err, shared)
+	}
+}
+
+func TestRateLimiter_DelaysBeyondBurst(t *testing.T) {
+	l := newRateLimiter(RateLimitConfig{RequestsPerSecond: 50, Burst: 2})
+
+	start := time.Now()
+	for range 2 {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatalf("Wait failed: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Millisecond {
+		t.Errorf("Expected the burst to pass at once, waited %v", elapsed)
+	}
+
+	for range 2 {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatalf("Wait failed: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("Expected requests beyond the burst to be spaced, waited %v", elapsed)
+	}
+}
+
+func TestRateLimiter_StopsWaitingWhenCanceled(t *testing.T) {
+	l := newRateLimiter(RateLimitConfig{RequestsPerSecond: 1, Burst: 1})
+	_ = l.Wait(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the deadline error, got %v", err)
+	}
+}
+
+func TestRateLimiter_DisabledWithoutRate(t *testing.T) {
+	l := newRateLimiter(RateLimitConfig{})
+	if l != nil {
+		t.Fatal("Expected no limiter without a rate")
+	}
+	if err := l.Wait(context.Background()); err != nil {
+		t.Errorf("Expected a nil limiter not to limit, got %v", err)
+	}
+}
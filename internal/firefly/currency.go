@@ -5,6 +5,7 @@ SPDX-License-Identifier: Apache-2.0
 package firefly
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
@@ -42,8 +43,8 @@ type apiCurrencyAttr struct {
 	Symbol  string `json:"symbol"`
 }
 
-func (api *Api) UpdateCurrencies() error {
-	currencies, err := api.ListCurrencies()
+func (api *Api) UpdateCurrencies(ctx context.Context) error {
+	currencies, err := api.ListCurrencies(ctx)
 	if err != nil {
 		return err
 	}
@@ -51,8 +52,8 @@ func (api *Api) UpdateCurrencies() error {
 	return nil
 }
 
-func (api *Api) ListCurrencies() ([]Currency, error) {
-	allData, err := api.fetchPaginated("%s/currencies?page=%d", api.Config.ApiUrl)
+func (api *Api) ListCurrencies(ctx context.Context) ([]Currency, error) {
+	allData, err := api.fetchPaginated(ctx, "%s/currencies?page=%d", api.Config.ApiUrl)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch paginated currencies: %v", err)
 	}
@@ -94,6 +95,13 @@ func (api *Api) PrimaryCurrency() Currency {
 		return api.Primary
 	}
 
+	if api.Config.PrimaryCurrency != "" {
+		if cur := api.GetCurrencyByCode(api.Config.PrimaryCurrency); cur != (Currency{}) {
+			api.Primary = cur
+			return cur
+		}
+	}
+
 	for _, cur := range api.Currencies {
 		if cur.Primary {
 			api.Primary = cur
@@ -0,0 +1,116 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package firefly
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+type apiExchangeRate struct {
+	ID         string              `json:"id"`
+	Attributes apiExchangeRateAttr `json:"attributes"`
+}
+
+type apiExchangeRateAttr struct {
+	FromCurrencyCode string `json:"from_currency_code"`
+	ToCurrencyCode   string `json:"to_currency_code"`
+	Rate             string `json:"rate"`
+	Date             string `json:"date"`
+}
+
+// ConvertToPrimary converts amount from currencyCode to the primary
+// currency with the rates fetched on the last accounts refresh. It reports
+// false when the currency is the primary one or no rate is known.
+func (api *Api) ConvertToPrimary(amount float64, currencyCode string) (float64, bool) {
+	primary := api.PrimaryCurrency().Code
+	if currencyCode == "" || strings.EqualFold(currencyCode, primary) {
+		return amount, false
+	}
+	rate, ok := api.exchangeRates[strings.ToUpper(currencyCode)]
+	if !ok {
+		return amount, false
+	}
+	return amount * rate, true
+}
+
+// updateExchangeRates fetches the latest rate to the primary currency of
+// every foreign currency used by asset and liability accounts. Servers
+// without the exchange rates endpoint keep the balances unconverted.
+func (api *Api) updateExchangeRates(ctx context.Context) {
+	primary := strings.ToUpper(api.PrimaryCurrency().Code)
+	if primary == "" {
+		return
+	}
+
+	rates := make(map[string]float64)
+	data := api.Data()
+	for _, accType := range []string{"asset", "liabilities"} {
+		for _, account := range data.AccountsByType(accType) {
+			code := strings.ToUpper(account.CurrencyCode)
+			if code == "" || code == primary {
+				continue
+			}
+			if _, ok := rates[code]; ok {
+				continue
+			}
+			rate, err := api.latestExchangeRate(ctx, code, primary)
+			if err != nil {
+				zap.S().Warnf("No exchange rate from %s to %s: %v", code, primary, err)
+				continue
+			}
+			rates[code] = rate
+		}
+	}
+	api.exchangeRates = rates
+}
+
+// latestExchangeRate returns the newest rate from one currency to another,
+// falling back to the inverse of the rate stored the other way round.
+func (api *Api) latestExchangeRate(ctx context.Context, from, to string) (float64, error) {
+	rate, err := api.fetchExchangeRate(ctx, from, to)
+	if err == nil {
+		return rate, nil
+	}
+	inverse, inverseErr := api.fetchExchangeRate(ctx, to, from)
+	if inverseErr != nil || inverse == 0 {
+		return 0, err
+	}
+	return 1 / inverse, nil
+}
+
+func (api *Api) fetchExchangeRate(ctx context.Context, from, to string) (float64, error) {
+	allData, err := api.fetchPaginated(ctx, "%s/exchange-rates/%s/%s?page=%d",
+		api.Config.ApiUrl,
+		url.PathEscape(from),
+		url.PathEscape(to))
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch exchange rates: %v", err)
+	}
+	items, err := unmarshalItems[apiExchangeRate](allData)
+	if err != nil {
+		return 0, fmt.Errorf("failed to unmarshal exchange rates: %v", err)
+	}
+
+	latest := apiExchangeRateAttr{}
+	for _, item := range items {
+		if item.Attributes.Date >= latest.Date {
+			latest = item.Attributes
+		}
+	}
+	if latest.Rate == "" {
+		return 0, fmt.Errorf("no rates stored")
+	}
+	rate, err := strconv.ParseFloat(latest.Rate, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid rate %q: %v", latest.Rate, err)
+	}
+	return rate, nil
+}
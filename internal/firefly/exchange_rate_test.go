@@ -0,0 +1,36 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package firefly
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestUpdateAccounts_LiabilitiesRefreshExchangeRates(t *testing.T) {
+	api := newTestApi(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/accounts":
+			if r.URL.Query().Get("type") != "liabilities" {
+				writeData(w, `[]`)
+				return
+			}
+			writeData(w, `[{"id":"1","attributes":{"name":"Mortgage","type":"liabilities","currency_code":"EUR","current_balance":"-1000"}}]`)
+		case "/api/exchange-rates/EUR/USD":
+			writeData(w, `[{"id":"1","attributes":{"from_currency_code":"EUR","to_currency_code":"USD","rate":"1.1","date":"2026-01-01"}}]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	api.Primary = Currency{Code: "USD"}
+
+	if err := api.UpdateAccounts(context.Background(), "liabilities"); err != nil {
+		t.Fatalf("UpdateAccounts failed: %v", err)
+	}
+	if got, ok := api.ConvertToPrimary(100, "EUR"); !ok || got < 109.99 || got > 110.01 {
+		t.Errorf("Expected the liability currency converted at 1.1, got %v, %v", got, ok)
+	}
+}
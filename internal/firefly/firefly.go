@@ -5,7 +5,11 @@ SPDX-License-Identifier: Apache-2.0
 package firefly
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -14,20 +18,22 @@ type Api struct {
 	// Config contains the API configuration details.
 	Config ApiConfig
 
-	Accounts        map[string][]Account
-	accountBalances map[string]float64
-	cashAccount     Account
+	// store holds the accounts, categories, tags and their insights
+	store       Store
+	cashAccount Account
 
-	expenseInsights map[string]accountInsight
-	revenueInsights map[string]accountInsight
-
-	// Categories holds the list of categories.
-	Categories       []Category
-	categoryInsights map[string]categoryInsight
+	// budgets of the period by ID
+	budgets map[string]Budget
+	// piggyBanks are the savings goals with a target date
+	piggyBanks []PiggyBank
+	// subscriptionsDue are the bills due in the next DueSoonDays days
+	subscriptionsDue []Subscription
 
 	// Currencies
 	Currencies []Currency
 	Primary    Currency
+	// exchangeRates maps currency codes to their rate to the primary currency
+	exchangeRates map[string]float64
 
 	// User
 	User User
@@ -38,41 +44,78 @@ type Api struct {
 
 	// Summary
 	Summary map[string]SummaryItem
+
+	// transport is nil unless custom TLS options are configured
+	transport http.RoundTripper
+
+	// Requests currently being retried and the latest retry attempt
+	retrying     atomic.Int32
+	retryAttempt atomic.Int32
+
+	// offline is set while the server cannot be reached
+	offline atomic.Bool
+	// unauthorized is set once the server rejected the API token
+	unauthorized atomic.Bool
+	// authMu guards Config.ApiKey, replaced by SetAPIKey
+	authMu sync.RWMutex
+
+	limiter *rateLimiter
+	flights flightGroup
+	cache   responseCache
+	// insights are cached per type and period until data changes
+	insights insightCache
+	tracer   requestTracer
+	stats    sessionStats
+	// live receives the calls of the registered webhooks, nil when off
+	live *WebhookListener
+
+	// Snapshot state, guarded by mu
+	mu                 sync.Mutex
+	stale              map[string]bool
+	savedAt            time.Time
+	periodTransactions []Transaction
+	periodTxStart      time.Time
+	periodTxEnd        time.Time
+	// burnSpent is what the asset accounts spent in the current period
+	// by ID over burnPassed days, burnLeft days are left in it
+	burnSpent  map[string]float64
+	burnPassed int
+	burnLeft   int
+	queue      []QueuedWrite
 }
 
 // NewApi creates a new Api instance with the provided configuration.
 // Parameters:
+//   - ctx: the context of the initial requests.
 //   - config: an ApiConfig struct containing the API configuration details.
 //
 // Returns:
 //   - A pointer to an Api struct initialized with the provided configuration.
-func NewApi(config ApiConfig) (*Api, error) {
-	api := &Api{Config: config}
+func NewApi(ctx context.Context, config ApiConfig) (*Api, error) {
+	api, err := newClient(config)
+	if err != nil {
+		return nil, err
+	}
 
 	api.StartDate = time.Now().AddDate(0, 0, -time.Now().Day()+1)
 	api.EndDate = time.Now().AddDate(0, 1, -time.Now().Day())
 
 	// Test connection and get current user
-	userEmail, err := api.GetCurrentUser()
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to Firefly III: %w", err)
-	}
-	api.User = User{
-		Email: userEmail,
+	if err := api.RefreshBaseData(ctx); err != nil {
+		return nil, err
 	}
 
-	api.Accounts = make(map[string][]Account, 0)
-	api.accountBalances = make(map[string]float64)
+	return api, nil
+}
 
-	err = api.UpdateAccounts("special")
-	if err != nil {
-		return nil, fmt.Errorf("failed to update special accounts: %w", err)
-	}
-	err = api.UpdateCurrencies()
-	if err != nil {
-		return nil, fmt.Errorf("failed to update currencies: %w", err)
+// newClient creates an Api with the HTTP settings applied but no data loaded.
+func newClient(config ApiConfig) (*Api, error) {
+	api := &Api{Config: config}
+	api.stats.since = time.Now()
+	if err := api.setupTransport(); err != nil {
+		return nil, fmt.Errorf("failed to configure TLS: %w", err)
 	}
-
+	api.limiter = newRateLimiter(config.RateLimit)
 	return api, nil
 }
 
@@ -95,6 +138,16 @@ func (api *Api) TimeoutSeconds() int {
 	return api.Config.TimeoutSeconds
 }
 
+// ServerURL returns the configured Firefly III API URL.
+func (api *Api) ServerURL() string {
+	return api.Config.ApiUrl
+}
+
+// periodKey identifies the current period in coalescing keys.
+func (api *Api) periodKey() string {
+	return api.StartDate.Format("2006-01-02") + "/" + api.EndDate.Format("2006-01-02")
+}
+
 func (api *Api) PeriodStart() time.Time {
 	return api.StartDate
 }
@@ -0,0 +1,40 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package firefly
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newTestApi returns a client of a test server answering with handler.
+func newTestApi(t *testing.T, handler http.HandlerFunc) *Api {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	api, err := newClient(ApiConfig{
+		ApiKey:         "test-token",
+		ApiUrl:         server.URL + "/api",
+		TimeoutSeconds: 5,
+		Retry: RetryConfig{
+			MaxRetries: 3,
+			BaseDelay:  time.Millisecond,
+			MaxDelay:   10 * time.Millisecond,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	return api
+}
+
+// writeData answers with an API response holding data.
+func writeData(w http.ResponseWriter, data string) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(`{"data":` + data + `}`))
+}
@@ -6,7 +6,9 @@ package firefly
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -27,17 +29,17 @@ type APIResponse struct {
 	} `json:"meta"`
 }
 
-func (api *Api) httpClient() *http.Client {
-	timeout := time.Duration(api.Config.TimeoutSeconds) * time.Second
+func (api *Api) httpClient(timeout time.Duration) *http.Client {
 	zap.L().Debug("Creating HTTP client",
 		zap.Duration("timeout", timeout))
 
 	return &http.Client{
-		Timeout: timeout,
+		Timeout:   timeout,
+		Transport: api.transport,
 	}
 }
 
-func (api *Api) makeRequest(method, endpoint string, payload any, okStatus int) (*APIResponse, error) {
+func (api *Api) makeRequest(ctx context.Context, method, endpoint string, payload any, okStatus int) (*APIResponse, error) {
 	if okStatus == 0 {
 		okStatus = 200
 	}
@@ -69,7 +71,7 @@ func (api *Api) makeRequest(method, endpoint string, payload any, okStatus int)
 			zap.String("endpoint", endpoint))
 	}
 
-	req, err := http.NewRequest(method, endpoint, body)
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
 	if err != nil {
 		zap.L().Error("Failed to create HTTP request",
 			zap.Error(err),
@@ -80,17 +82,34 @@ func (api *Api) makeRequest(method, endpoint string, payload any, okStatus int)
 
 	// Set common headers
 	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", api.Config.ApiKey))
 	req.Header.Set("Content-Type", "application/json")
 
 	zap.L().Debug("HTTP request headers set",
 		zap.String("content_type", req.Header.Get("Content-Type")),
-		zap.String("accept", req.Header.Get("Accept")),
-		zap.Bool("has_auth", req.Header.Get("Authorization") != ""))
+		zap.String("accept", req.Header.Get("Accept")))
+
+	cacheKey := api.cacheKey(endpoint)
+	cached, isCached := cacheEntry{}, false
+	if method == http.MethodGet {
+		cached, isCached = api.cache.get(cacheKey)
+		api.stats.cacheLookups.Add(1)
+		if isCached {
+			cached.apply(req)
+		}
+	}
 
-	resp, err := api.httpClient().Do(req)
+	resp, err := api.do(req)
 	requestDuration := time.Since(startTime)
 
+	if err != nil && isCached && errors.Is(err, ErrOffline) {
+		zap.L().Debug("Using cached response while offline", zap.String("endpoint", endpoint))
+		api.stats.cacheHits.Add(1)
+		apiResp := &APIResponse{}
+		if err := json.Unmarshal(cached.body, apiResp); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		return apiResp, nil
+	}
 	if err != nil {
 		zap.L().Error("HTTP request failed",
 			zap.Error(err),
@@ -124,12 +143,25 @@ func (api *Api) makeRequest(method, endpoint string, payload any, okStatus int)
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
+	statusCode := resp.StatusCode
+	if method == http.MethodGet {
+		switch {
+		case statusCode == http.StatusNotModified && isCached:
+			zap.L().Debug("Using cached response, not modified", zap.String("endpoint", endpoint))
+			api.stats.cacheHits.Add(1)
+			respBody = cached.body
+			statusCode = okStatus
+		case statusCode == okStatus:
+			api.cache.put(cacheKey, resp, respBody)
+		}
+	}
+
 	responseSize := len(respBody)
 	zap.S().Debugf("Response body read: %d bytes from %s", responseSize, endpoint)
 
 	var apiResp APIResponse
 
-	if resp.StatusCode != okStatus {
+	if statusCode != okStatus {
 		zap.L().Warn("HTTP request returned unexpected status",
 			zap.String("method", method),
 			zap.String("endpoint", endpoint),
@@ -150,7 +182,11 @@ func (api *Api) makeRequest(method, endpoint string, payload any, okStatus int)
 			}
 		}
 
-		return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
+		httpErr := &HTTPError{StatusCode: resp.StatusCode}
+		if resp.StatusCode == http.StatusUnprocessableEntity {
+			httpErr.Validation = parseValidationError(respBody)
+		}
+		return nil, httpErr
 	}
 
 	if okStatus != http.StatusNoContent {
@@ -190,31 +226,45 @@ func (api *Api) makeRequest(method, endpoint string, payload any, okStatus int)
 	return &apiResp, nil
 }
 
-func (api *Api) getRequest(endpoint string) (*APIResponse, error) {
+func (api *Api) getRequest(ctx context.Context, endpoint string) (*APIResponse, error) {
 	zap.L().Debug("Executing GET request", zap.String("endpoint", endpoint))
-	return api.makeRequest("GET", endpoint, nil, http.StatusOK)
+	timeout := api.sharedRequestTimeout(http.MethodGet, endpoint)
+	resp, err, _ := api.flights.DoContext(ctx, "GET "+endpoint, timeout, func(ctx context.Context) (any, error) {
+		return api.makeRequest(ctx, "GET", endpoint, nil, http.StatusOK)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*APIResponse), nil
 }
 
-func (api *Api) postRequest(endpoint string, payload any) (*APIResponse, error) {
+func (api *Api) postRequest(ctx context.Context, endpoint string, payload any) (*APIResponse, error) {
 	zap.L().Debug("Executing POST request",
 		zap.String("endpoint", endpoint),
 		zap.Bool("has_payload", payload != nil))
-	return api.makeRequest("POST", endpoint, payload, http.StatusOK)
+	return api.makeRequest(ctx, "POST", endpoint, payload, http.StatusOK)
 }
 
-func (api *Api) putRequest(endpoint string, payload any) (*APIResponse, error) {
+func (api *Api) putRequest(ctx context.Context, endpoint string, payload any) (*APIResponse, error) {
 	zap.L().Debug("Executing PUT request",
 		zap.String("endpoint", endpoint),
 		zap.Bool("has_payload", payload != nil))
-	return api.makeRequest("PUT", endpoint, payload, http.StatusOK)
+	return api.makeRequest(ctx, "PUT", endpoint, payload, http.StatusOK)
 }
 
-func (api *Api) deleteRequest(endpoint string) (*APIResponse, error) {
+func (api *Api) deleteRequest(ctx context.Context, endpoint string) (*APIResponse, error) {
 	zap.L().Debug("Executing DELETE request", zap.String("endpoint", endpoint))
-	return api.makeRequest("DELETE", endpoint, nil, http.StatusNoContent)
+	return api.makeRequest(ctx, "DELETE", endpoint, nil, http.StatusNoContent)
 }
 
-func (api *Api) fetchPaginated(endpointTemplate string, args ...any) ([]any, error) {
+func (api *Api) fetchPaginated(ctx context.Context, endpointTemplate string, args ...any) ([]any, error) {
+	return api.fetchPages(ctx, nil, endpointTemplate, args...)
+}
+
+// fetchPages fetches all pages like fetchPaginated, calling onPage with the
+// items of each page as it arrives, the number of items loaded so far and
+// the total reported by the server.
+func (api *Api) fetchPages(ctx context.Context, onPage func(data []any, loaded, total int) error, endpointTemplate string, args ...any) ([]any, error) {
 	zap.L().Debug("Starting paginated fetch",
 		zap.String("endpoint_template", endpointTemplate),
 		zap.Int("args_count", len(args)))
@@ -230,7 +280,7 @@ func (api *Api) fetchPaginated(endpointTemplate string, args ...any) ([]any, err
 			zap.Int("page", page),
 			zap.String("endpoint", endpoint))
 
-		resp, err := api.getRequest(endpoint)
+		resp, err := api.getRequest(ctx, endpoint)
 		if err != nil {
 			zap.L().Error("Failed to fetch page",
 				zap.Error(err),
@@ -263,6 +313,11 @@ func (api *Api) fetchPaginated(endpointTemplate string, args ...any) ([]any, err
 
 		allData = append(allData, data...)
 		totalItems += pageItemCount
+		if onPage != nil {
+			if err := onPage(data, totalItems, resp.Meta.Pagination.Total); err != nil {
+				return nil, err
+			}
+		}
 
 		if resp.Meta.Pagination.CurrentPage >= resp.Meta.Pagination.TotalPages {
 			zap.L().Debug("Reached last page",
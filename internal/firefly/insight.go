@@ -5,10 +5,14 @@ SPDX-License-Identifier: Apache-2.0
 package firefly
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"slices"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
@@ -23,6 +27,41 @@ type insightItem struct {
 	CurrencyCode    string  `json:"currency_code"`
 }
 
+// insightCache keeps insight responses by endpoint, which includes the
+// insight type, the period and any filter. Insights only change with the
+// transactions, so entries are kept until a write or an explicit refresh.
+type insightCache struct {
+	mu      sync.Mutex
+	entries map[string][]insightItem
+}
+
+func (c *insightCache) get(endpoint string) ([]insightItem, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	items, ok := c.entries[endpoint]
+	return slices.Clone(items), ok
+}
+
+func (c *insightCache) put(endpoint string, items []insightItem) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil || len(c.entries) >= maxCacheEntries {
+		c.entries = make(map[string][]insightItem)
+	}
+	c.entries[endpoint] = slices.Clone(items)
+}
+
+func (c *insightCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = nil
+}
+
+// ForgetInsights drops the cached insights so they are fetched again.
+func (api *Api) ForgetInsights() {
+	api.insights.clear()
+}
+
 type accountInsight struct {
 	Diff float64
 }
@@ -32,27 +71,42 @@ type categoryInsight struct {
 	Earned float64
 }
 
-func (api *Api) GetInsights(ep string) ([]insightItem, error) {
+type tagInsight struct {
+	Spent  float64
+	Earned float64
+}
+
+func (api *Api) GetInsights(ctx context.Context, ep string) ([]insightItem, error) {
+	return api.getInsights(ctx, ep, api.StartDate, api.EndDate, nil)
+}
+
+// getInsights fetches the insight ep between start and end, narrowed down by
+// the filter, e.g. categories[]=1.
+func (api *Api) getInsights(ctx context.Context, ep string, start, end time.Time, filter url.Values) ([]insightItem, error) {
 	endpoint := fmt.Sprintf(
 		"%s/insight/%s?start=%s&end=%s",
 		api.Config.ApiUrl,
 		ep,
-		api.StartDate.Format("2006-01-02"),
-		api.EndDate.Format("2006-01-02"))
+		start.Format("2006-01-02"),
+		end.Format("2006-01-02"))
+	if len(filter) > 0 {
+		endpoint += "&" + filter.Encode()
+	}
+	if items, ok := api.insights.get(endpoint); ok {
+		return items, nil
+	}
 
-	req, err := http.NewRequest("GET", endpoint, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Content-Type", "application/vnd.api+json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", api.Config.ApiKey))
 
-	client := &http.Client{Timeout: time.Duration(api.Config.TimeoutSeconds) * time.Second}
-	resp, err := client.Do(req)
+	resp, err := api.do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %v", err)
+		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
 	defer func() {
 		if closeErr := resp.Body.Close(); closeErr != nil {
@@ -87,5 +141,6 @@ func (api *Api) GetInsights(ep string) ([]insightItem, error) {
 		return nil, fmt.Errorf("failed to unmarshal response body: %v", err)
 	}
 
+	api.insights.put(endpoint, items)
 	return items, nil
 }
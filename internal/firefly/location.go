@@ -0,0 +1,43 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package firefly
+
+import "fmt"
+
+// DefaultZoomLevel is the map zoom stored with locations entered without
+// one, about the size of a town.
+const DefaultZoomLevel = 12
+
+// Location is where a split took place, empty when it has none.
+type Location struct {
+	Latitude  float64
+	Longitude float64
+	ZoomLevel int
+}
+
+func (l Location) IsEmpty() bool {
+	return l.Latitude == 0 && l.Longitude == 0
+}
+
+// String returns the coordinates, e.g. "52.520008, 13.404954".
+func (l Location) String() string {
+	if l.IsEmpty() {
+		return ""
+	}
+	return fmt.Sprintf("%.6f, %.6f", l.Latitude, l.Longitude)
+}
+
+// SetLocation sends the location with the split, an empty one leaves the
+// stored location as is.
+func (s *RequestTransactionSplit) SetLocation(l Location) {
+	if l.IsEmpty() {
+		return
+	}
+	zoom := l.ZoomLevel
+	if zoom == 0 {
+		zoom = DefaultZoomLevel
+	}
+	s.Latitude, s.Longitude, s.ZoomLevel = &l.Latitude, &l.Longitude, &zoom
+}
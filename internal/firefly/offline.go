@@ -0,0 +1,245 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package firefly
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrOffline is returned when the server cannot be reached.
+	ErrOffline = errors.New("server is unreachable")
+	// ErrQueued is returned by writes that were queued while offline, they
+	// are sent by Reconnect once the server is reachable again.
+	ErrQueued = errors.New("server is unreachable, change queued until it is back")
+)
+
+// QueuedWrite is a create, update or delete request waiting to be sent.
+type QueuedWrite struct {
+	Method   string
+	Endpoint string
+	Payload  json.RawMessage `json:",omitempty"`
+	Summary  string
+	QueuedAt time.Time
+}
+
+// Conflict is a queued write the server did not accept on replay.
+type Conflict struct {
+	Write  QueuedWrite
+	Reason string
+}
+
+// HTTPError is returned when the server answers with an unexpected status.
+type HTTPError struct {
+	StatusCode int
+	// Validation holds the messages per field of a 422 response
+	Validation *ValidationError
+}
+
+func (e *HTTPError) Error() string {
+	if e.Validation != nil {
+		return fmt.Sprintf("HTTP error: %d: %s", e.StatusCode, e.Validation)
+	}
+	return fmt.Sprintf("HTTP error: %d", e.StatusCode)
+}
+
+func (e *HTTPError) Unwrap() error {
+	if e.Validation == nil {
+		return nil
+	}
+	return e.Validation
+}
+
+// Offline reports whether the last request failed to reach the server.
+func (api *Api) Offline() bool {
+	return api.offline.Load()
+}
+
+// QueuedWrites returns the number of writes waiting to be sent.
+func (api *Api) QueuedWrites() int {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	return len(api.queue)
+}
+
+// trackConnectivity updates the offline state from the outcome of a
+// request and marks connection failures with ErrOffline. Other failures,
+// timeouts included, leave the state as it is.
+func (api *Api) trackConnectivity(err error) error {
+	if err == nil {
+		if api.offline.CompareAndSwap(true, false) {
+			zap.L().Info("Server is reachable again")
+		}
+		return nil
+	}
+	if !unreachable(err) {
+		return err
+	}
+	if !api.offline.Swap(true) {
+		zap.L().Warn("Server is unreachable, switching to offline mode", zap.Error(err))
+	}
+	return fmt.Errorf("%w: %w", ErrOffline, err)
+}
+
+// unreachable reports whether err means the request never reached the
+// server: the host could not be resolved or the connection not made. A
+// request that timed out or lost its connection may have been processed.
+func unreachable(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// write sends a create, update or delete request. While offline, or while
+// earlier writes are still queued, the request is queued instead and
+// ErrQueued is returned. Only requests that never reached the server are
+// queued; any other failure is returned, as the server may have applied
+// the write and sending it again could apply it twice.
+func (api *Api) write(ctx context.Context, method, endpoint string, payload any, summary string) (*APIResponse, error) {
+	if !api.Offline() && api.QueuedWrites() == 0 {
+		resp, err := api.send(ctx, method, endpoint, payload)
+		if !errors.Is(err, ErrOffline) {
+			return resp, err
+		}
+	}
+
+	w := QueuedWrite{
+		Method:   method,
+		Endpoint: endpoint,
+		Summary:  summary,
+		QueuedAt: time.Now(),
+	}
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal payload: %w", err)
+		}
+		w.Payload = data
+	}
+
+	api.mu.Lock()
+	api.queue = append(api.queue, w)
+	api.mu.Unlock()
+
+	zap.L().Info("Queued write while offline",
+		zap.String("method", method),
+		zap.String("endpoint", endpoint),
+		zap.String("summary", summary))
+	return nil, ErrQueued
+}
+
+// Reconnect checks whether the server is reachable again and sends the
+// queued writes in order. Writes the server rejects, and updates or deletes
+// of resources changed or removed on the server since they were queued, are
+// dropped and reported as conflicts. It returns ErrOffline when the server
+// is still unreachable; unsent writes stay queued.
+func (api *Api) Reconnect(ctx context.Context) (replayed int, conflicts []Conflict, err error) {
+	if api.QueuedWrites() == 0 {
+		if api.Offline() {
+			_, err = api.GetCurrentUser(ctx)
+		}
+		return 0, nil, err
+	}
+
+	for {
+		api.mu.Lock()
+		if len(api.queue) == 0 {
+			api.mu.Unlock()
+			return replayed, conflicts, nil
+		}
+		w := api.queue[0]
+		api.mu.Unlock()
+
+		reason, err := api.checkConflict(ctx, w)
+		if err == nil && reason == "" {
+			var payload any
+			if w.Payload != nil {
+				payload = w.Payload
+			}
+			_, err = api.send(ctx, w.Method, w.Endpoint, payload)
+		}
+		if errors.Is(err, ErrOffline) {
+			return replayed, conflicts, err
+		}
+		if err != nil {
+			reason = err.Error()
+		}
+
+		api.mu.Lock()
+		api.queue = api.queue[1:]
+		api.mu.Unlock()
+
+		if reason != "" {
+			zap.L().Warn("Dropped queued write",
+				zap.String("method", w.Method),
+				zap.String("endpoint", w.Endpoint),
+				zap.String("summary", w.Summary),
+				zap.ByteString("payload", w.Payload),
+				zap.String("reason", reason))
+			conflicts = append(conflicts, Conflict{Write: w, Reason: reason})
+			continue
+		}
+		replayed++
+	}
+}
+
+// checkConflict reports why a queued update or delete can no longer be
+// applied: the resource was deleted or changed on the server after the
+// write was queued.
+func (api *Api) checkConflict(ctx context.Context, w QueuedWrite) (string, error) {
+	if w.Method == http.MethodPost {
+		return "", nil
+	}
+
+	resp, err := api.getRequest(ctx, w.Endpoint)
+	if err != nil {
+		var httpErr *HTTPError
+		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+			return "deleted on the server", nil
+		}
+		return "", err
+	}
+
+	data, _ := resp.Data.(map[string]any)
+	attrs, _ := data["attributes"].(map[string]any)
+	updatedAt, _ := attrs["updated_at"].(string)
+	if updated, err := time.Parse(time.RFC3339, updatedAt); err == nil && updated.After(w.QueuedAt) {
+		return "changed on the server since " + w.QueuedAt.Format("15:04"), nil
+	}
+	return "", nil
+}
+
+func (api *Api) send(ctx context.Context, method, endpoint string, payload any) (*APIResponse, error) {
+	var (
+		resp *APIResponse
+		err  error
+	)
+	switch method {
+	case http.MethodPost:
+		resp, err = api.postRequest(ctx, endpoint, payload)
+	case http.MethodPut:
+		resp, err = api.putRequest(ctx, endpoint, payload)
+	case http.MethodDelete:
+		resp, err = api.deleteRequest(ctx, endpoint)
+	default:
+		return nil, fmt.Errorf("unsupported method %s", method)
+	}
+	if err == nil {
+		// Any write may change the amounts insights are made of
+		api.insights.clear()
+	}
+	return resp, err
+}
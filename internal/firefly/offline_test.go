@@ -0,0 +1,203 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package firefly
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// flakyServer answers requests like handler unless it is down, then it
+// refuses connections. received records the writes it got.
+type flakyServer struct {
+	down     atomic.Bool
+	mu       sync.Mutex
+	received []string
+	handler  http.HandlerFunc
+}
+
+func (s *flakyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		body, _ := io.ReadAll(r.Body)
+		s.mu.Lock()
+		s.received = append(s.received, r.Method+" "+r.URL.Path+" "+string(body))
+		s.mu.Unlock()
+	}
+	if s.handler != nil {
+		s.handler(w, r)
+		return
+	}
+	if r.Method == http.MethodDelete {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeData(w, `{"id":"1","attributes":{}}`)
+}
+
+func newFlakyApi(t *testing.T) (*Api, *flakyServer) {
+	server := &flakyServer{}
+	api := newTestApi(t, server.ServeHTTP)
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	dial := transport.DialContext
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if server.down.Load() {
+			return nil, &net.OpError{Op: "dial", Net: network, Err: syscall.ECONNREFUSED}
+		}
+		return dial(ctx, network, addr)
+	}
+	api.transport = transport
+	return api, server
+}
+
+func TestWrite_QueuesWhileOffline(t *testing.T) {
+	api, server := newFlakyApi(t)
+	endpoint := api.Config.ApiUrl + "/v1/transactions"
+	server.down.Store(true)
+
+	_, err := api.write(context.Background(), http.MethodPost, endpoint, map[string]string{"n": "1"}, "first")
+	if !errors.Is(err, ErrQueued) {
+		t.Fatalf("Expected ErrQueued, got %v", err)
+	}
+	if !api.Offline() {
+		t.Error("Expected offline mode after the failed write")
+	}
+
+	server.down.Store(false)
+	_, err = api.write(context.Background(), http.MethodPost, endpoint, map[string]string{"n": "2"}, "second")
+	if !errors.Is(err, ErrQueued) {
+		t.Errorf("Expected writes to queue behind the queued ones, got %v", err)
+	}
+	if got := api.QueuedWrites(); got != 2 {
+		t.Errorf("Expected 2 queued writes, got %d", got)
+	}
+	if len(server.received) != 0 {
+		t.Errorf("Expected nothing sent while queued, got %q", server.received)
+	}
+}
+
+func TestReconnect_ReplaysInOrder(t *testing.T) {
+	api, server := newFlakyApi(t)
+	endpoint := api.Config.ApiUrl + "/v1/transactions"
+	server.down.Store(true)
+	for _, n := range []string{"1", "2"} {
+		_, _ = api.write(context.Background(), http.MethodPost, endpoint, map[string]string{"n": n}, "write "+n)
+	}
+
+	if _, _, err := api.Reconnect(context.Background()); !errors.Is(err, ErrOffline) {
+		t.Fatalf("Expected ErrOffline while down, got %v", err)
+	}
+	if got := api.QueuedWrites(); got != 2 {
+		t.Fatalf("Expected the writes to stay queued, got %d", got)
+	}
+
+	server.down.Store(false)
+	replayed, conflicts, err := api.Reconnect(context.Background())
+	if err != nil || replayed != 2 || len(conflicts) != 0 {
+		t.Fatalf("Expected 2 writes replayed, got %d, %v, %v", replayed, conflicts, err)
+	}
+	want := []string{
+		`POST /api/v1/transactions {"n":"1"}`,
+		`POST /api/v1/transactions {"n":"2"}`,
+	}
+	if len(server.received) != 2 || server.received[0] != want[0] || server.received[1] != want[1] {
+		t.Errorf("Expected %q, got %q", want, server.received)
+	}
+	if api.Offline() || api.QueuedWrites() != 0 {
+		t.Error("Expected to be back online with an empty queue")
+	}
+}
+
+func TestReconnect_DropsConflictingWrites(t *testing.T) {
+	api, server := newFlakyApi(t)
+	server.down.Store(true)
+	changed := api.Config.ApiUrl + "/v1/transactions/1"
+	deleted := api.Config.ApiUrl + "/v1/transactions/2"
+	unchanged := api.Config.ApiUrl + "/v1/transactions/3"
+	_, _ = api.write(context.Background(), http.MethodPut, changed, map[string]string{"d": "x"}, "update 1")
+	_, _ = api.write(context.Background(), http.MethodDelete, deleted, nil, "delete 2")
+	_, _ = api.write(context.Background(), http.MethodPut, unchanged, map[string]string{"d": "y"}, "update 3")
+
+	later := time.Now().Add(time.Hour).Format(time.RFC3339)
+	server.handler = func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/transactions/1":
+			writeData(w, `{"id":"1","attributes":{"updated_at":"`+later+`"}}`)
+		case r.URL.Path == "/api/v1/transactions/2":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			writeData(w, `{"id":"3","attributes":{"updated_at":"2020-01-01T00:00:00Z"}}`)
+		}
+	}
+	server.down.Store(false)
+
+	replayed, conflicts, err := api.Reconnect(context.Background())
+	if err != nil {
+		t.Fatalf("Reconnect failed: %v", err)
+	}
+	if replayed != 1 || len(server.received) != 1 || server.received[0] != `PUT /api/v1/transactions/3 {"d":"y"}` {
+		t.Errorf("Expected only the unchanged update sent, got %q", server.received)
+	}
+	if len(conflicts) != 2 ||
+		conflicts[0].Write.Summary != "update 1" || conflicts[1].Write.Summary != "delete 2" ||
+		conflicts[1].Reason != "deleted on the server" {
+		t.Errorf("Expected the changed and deleted ones as conflicts, got %+v", conflicts)
+	}
+}
+
+func TestReconnect_ChecksServerWithoutQueuedWrites(t *testing.T) {
+	api, server := newFlakyApi(t)
+	server.down.Store(true)
+	_, _ = api.getRequest(context.Background(), api.Config.ApiUrl+"/v1/accounts")
+	if !api.Offline() {
+		t.Fatal("Expected offline mode after the failed request")
+	}
+
+	server.handler = func(w http.ResponseWriter, r *http.Request) {
+		writeData(w, `{"id":"1","attributes":{"email":"me@example.com"}}`)
+	}
+	server.down.Store(false)
+	if _, _, err := api.Reconnect(context.Background()); err != nil {
+		t.Fatalf("Reconnect failed: %v", err)
+	}
+	if api.Offline() {
+		t.Error("Expected to be back online")
+	}
+}
+
+func TestWrite_TimedOutPostIsNotQueued(t *testing.T) {
+	api, server := newFlakyApi(t)
+	api.Config.Timeouts.Metadata = 50 * time.Millisecond
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+	server.handler = func(w http.ResponseWriter, r *http.Request) {
+		// Stored, but the answer comes too late
+		<-release
+	}
+
+	_, err := api.write(context.Background(), http.MethodPost, api.Config.ApiUrl+"/v1/transactions", map[string]string{"n": "1"}, "create")
+	if err == nil || errors.Is(err, ErrQueued) || errors.Is(err, ErrOffline) {
+		t.Fatalf("Expected the timeout returned, got %v", err)
+	}
+	if api.Offline() || api.QueuedWrites() != 0 {
+		t.Fatalf("Expected nothing queued after a timeout, offline %v, queued %d", api.Offline(), api.QueuedWrites())
+	}
+
+	if replayed, _, err := api.Reconnect(context.Background()); err != nil || replayed != 0 {
+		t.Errorf("Expected nothing replayed, got %d, %v", replayed, err)
+	}
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	if len(server.received) != 1 {
+		t.Errorf("Expected the POST sent once, got %q", server.received)
+	}
+}
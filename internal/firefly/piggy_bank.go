@@ -0,0 +1,134 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package firefly
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// PiggyBank is a savings goal, saving Target by TargetDate.
+type PiggyBank struct {
+	ID           string
+	Name         string
+	CurrencyCode string
+	Target       float64
+	Saved        float64
+	// StartDate and TargetDate are YYYY-MM-DD, empty when not set
+	StartDate  string
+	TargetDate string
+}
+
+type apiPiggyBank struct {
+	ID         string           `json:"id"`
+	Attributes apiPiggyBankAttr `json:"attributes"`
+}
+
+type apiPiggyBankAttr struct {
+	Name          string `json:"name"`
+	CurrencyCode  string `json:"currency_code"`
+	TargetAmount  string `json:"target_amount"`
+	CurrentAmount string `json:"current_amount"`
+	StartDate     string `json:"start_date"`
+	TargetDate    string `json:"target_date"`
+	Active        bool   `json:"active"`
+}
+
+// Left is what is still to be saved, zero once the target is reached.
+func (p PiggyBank) Left() float64 {
+	return max(p.Target-p.Saved, 0)
+}
+
+// MonthsLeft is the number of months to save in from the month of now to
+// the month of the target date, this one included.
+func (p PiggyBank) MonthsLeft(now time.Time) int {
+	target, err := time.Parse(time.DateOnly, p.TargetDate)
+	if err != nil {
+		return 0
+	}
+	months := (target.Year()-now.Year())*12 + int(target.Month()-now.Month()) + 1
+	return max(months, 1)
+}
+
+// MonthlyNeeded is the contribution per month reaching the target on time.
+func (p PiggyBank) MonthlyNeeded(now time.Time) float64 {
+	months := p.MonthsLeft(now)
+	if months == 0 {
+		return 0
+	}
+	return math.Ceil(p.Left()/float64(months)*100) / 100
+}
+
+// OnTrack reports whether the saved amount keeps up with saving evenly from
+// the start date to the target date.
+func (p PiggyBank) OnTrack(now time.Time) bool {
+	if p.Left() == 0 {
+		return true
+	}
+	target, err := time.Parse(time.DateOnly, p.TargetDate)
+	if err != nil {
+		return true
+	}
+	if !now.Before(target.AddDate(0, 0, 1)) {
+		return false
+	}
+	start, err := time.Parse(time.DateOnly, p.StartDate)
+	if err != nil || !start.Before(target) || now.Before(start) {
+		return true
+	}
+	elapsed := now.Sub(start).Hours() / target.Sub(start).Hours()
+	return p.Saved >= p.Target*elapsed
+}
+
+// updatePiggyBanks fetches the active piggy banks with a target date, the
+// ones goals can be tracked for.
+func (api *Api) updatePiggyBanks(ctx context.Context) error {
+	allData, err := api.fetchPaginated(ctx, "%s/piggy-banks?page=%d", api.Config.ApiUrl)
+	if err != nil {
+		return fmt.Errorf("failed to fetch paginated piggy banks: %w", err)
+	}
+	items, err := unmarshalItems[apiPiggyBank](allData)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal piggy banks: %v", err)
+	}
+
+	piggyBanks := []PiggyBank{}
+	for _, item := range items {
+		attr := item.Attributes
+		if !attr.Active || attr.TargetDate == "" {
+			continue
+		}
+		target, _ := strconv.ParseFloat(attr.TargetAmount, 64)
+		if target <= 0 {
+			continue
+		}
+		saved, _ := strconv.ParseFloat(attr.CurrentAmount, 64)
+		piggyBanks = append(piggyBanks, PiggyBank{
+			ID:           item.ID,
+			Name:         attr.Name,
+			CurrencyCode: attr.CurrencyCode,
+			Target:       target,
+			Saved:        saved,
+			StartDate:    attr.StartDate[:min(len(attr.StartDate), 10)],
+			TargetDate:   attr.TargetDate[:min(len(attr.TargetDate), 10)],
+		})
+	}
+	slices.SortFunc(piggyBanks, func(a, b PiggyBank) int {
+		return cmp.Or(cmp.Compare(a.TargetDate, b.TargetDate), cmp.Compare(a.Name, b.Name))
+	})
+
+	api.piggyBanks = piggyBanks
+	return nil
+}
+
+// PiggyBanks returns the savings goals by target date.
+func (api *Api) PiggyBanks() []PiggyBank {
+	return slices.Clone(api.piggyBanks)
+}
@@ -0,0 +1,91 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package firefly
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// What a recurrence does when it falls on a weekend.
+const (
+	WeekendCreate      = 1 // create it anyway
+	WeekendSkip        = 2
+	WeekendPreviousDay = 3 // on the Friday before
+	WeekendNextMonday  = 4
+)
+
+const RepetitionMonthly = "monthly"
+
+// RequestRecurrence creates a recurrence, Firefly III then creates its
+// transactions on schedule.
+type RequestRecurrence struct {
+	Type        string `json:"type"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	FirstDate   string `json:"first_date"`
+	// RepeatUntil is the last date of the recurrence, empty to repeat
+	// forever
+	RepeatUntil  string                         `json:"repeat_until,omitempty"`
+	ApplyRules   bool                           `json:"apply_rules"`
+	Active       bool                           `json:"active"`
+	Repetitions  []RequestRecurrenceRepetition  `json:"repetitions"`
+	Transactions []RequestRecurrenceTransaction `json:"transactions"`
+}
+
+// RequestRecurrenceRepetition is when a recurrence repeats, e.g. monthly
+// at Moment, the day of the month.
+type RequestRecurrenceRepetition struct {
+	Type    string `json:"type"`
+	Moment  string `json:"moment"`
+	Skip    int    `json:"skip"`
+	Weekend int    `json:"weekend"`
+}
+
+// RequestRecurrenceTransaction is a split of the transactions a recurrence
+// creates.
+type RequestRecurrenceTransaction struct {
+	Description         string   `json:"description"`
+	Amount              string   `json:"amount"`
+	CurrencyCode        string   `json:"currency_code,omitempty"`
+	ForeignAmount       string   `json:"foreign_amount,omitempty"`
+	ForeignCurrencyCode string   `json:"foreign_currency_code,omitempty"`
+	SourceID            string   `json:"source_id"`
+	DestinationID       string   `json:"destination_id"`
+	CategoryID          string   `json:"category_id,omitempty"`
+	BudgetID            string   `json:"budget_id,omitempty"`
+	Tags                []string `json:"tags,omitempty"`
+}
+
+// MonthlyOn repeats every month on day, 1 to 31.
+func MonthlyOn(day int) RequestRecurrenceRepetition {
+	return RequestRecurrenceRepetition{
+		Type:    RepetitionMonthly,
+		Moment:  strconv.Itoa(min(max(day, 1), 31)),
+		Weekend: WeekendCreate,
+	}
+}
+
+func (api *Api) CreateRecurrence(ctx context.Context, recurrence RequestRecurrence) (id string, err error) {
+	endpoint := fmt.Sprintf("%s/recurrences", api.Config.ApiUrl)
+
+	response, err := api.write(ctx, http.MethodPost, endpoint, recurrence,
+		"create recurrence "+recurrence.Title)
+	if err != nil {
+		return "", err
+	}
+	data, ok := response.Data.(map[string]any)
+	if !ok {
+		return "", fmt.Errorf("invalid response format: missing data field")
+	}
+	id, ok = data["id"].(string)
+	if !ok || id == "" {
+		return "", fmt.Errorf("invalid response format: missing recurrence id")
+	}
+
+	return id, nil
+}
@@ -0,0 +1,396 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package firefly
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// RestoreCount is how many objects of a kind were created and how many
+// were skipped as already there.
+type RestoreCount struct {
+	Created int
+	Skipped int
+}
+
+func (c RestoreCount) String() string {
+	return fmt.Sprintf("%d created, %d skipped", c.Created, c.Skipped)
+}
+
+// RestoreResult is the outcome of restoring a backup.
+type RestoreResult struct {
+	Accounts     RestoreCount
+	Categories   RestoreCount
+	Transactions RestoreCount
+	// Failed describes the objects that could not be created
+	Failed []string
+}
+
+// Summary reports the outcome, e.g. "accounts: 4 created, 1 skipped;
+// categories: ...".
+func (r RestoreResult) Summary() string {
+	s := fmt.Sprintf("accounts: %s; categories: %s; transactions: %s",
+		r.Accounts, r.Categories, r.Transactions)
+	if len(r.Failed) > 0 {
+		s += fmt.Sprintf("; %d failed", len(r.Failed))
+	}
+	return s
+}
+
+// restoredAccountTypes are the account types created by a restore, the
+// others (cash, initial balance, reconciliation) are managed by Firefly III.
+var restoredAccountTypes = []string{"asset", "expense", "revenue", "liabilities"}
+
+// LoadBackup reads a backup archive written by Backup, or a JSON export of
+// the transactions endpoint of the API ({"data": [...]}). The accounts of
+// an export are the ones its transactions use.
+func LoadBackup(path string) (Backup, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Backup{}, err
+	}
+
+	var backup Backup
+	if err := json.Unmarshal(data, &backup); err != nil {
+		return Backup{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var export struct {
+		Data []ResponseTransaction `json:"data"`
+	}
+	if err := json.Unmarshal(data, &export); err != nil {
+		return Backup{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if len(export.Data) > 0 {
+		backup = fromExport(export.Data)
+	}
+
+	if len(backup.Accounts) == 0 && len(backup.Transactions) == 0 {
+		return Backup{}, fmt.Errorf("%s has no accounts or transactions to restore", path)
+	}
+	return backup, nil
+}
+
+// fromExport builds a backup of exported transactions, their accounts and
+// categories are only known by name.
+func fromExport(items []ResponseTransaction) Backup {
+	var backup Backup
+	accounts := map[string]bool{}
+	categories := map[string]bool{}
+	addAccount := func(a Account) {
+		key := accountKey(a.Type, a.Name)
+		if a.Name == "" || accounts[key] {
+			return
+		}
+		accounts[key] = true
+		backup.Accounts = append(backup.Accounts, a)
+	}
+
+	for _, t := range items {
+		tx := Transaction{TransactionID: t.ID, GroupTitle: t.Attributes.GroupTitle}
+		for _, s := range t.Attributes.Transactions {
+			if tx.Type == "" {
+				tx.Type = s.Type
+				tx.Date = s.Date
+			}
+			source := Account{Name: s.SourceName, Type: exportAccountType(s.SourceType), CurrencyCode: s.CurrencyCode}
+			destination := Account{Name: s.DestinationName, Type: exportAccountType(s.DestinationType), CurrencyCode: s.CurrencyCode}
+			addAccount(source)
+			addAccount(destination)
+			if s.CategoryName != "" && !categories[strings.ToLower(s.CategoryName)] {
+				categories[strings.ToLower(s.CategoryName)] = true
+				backup.Categories = append(backup.Categories, Category{Name: s.CategoryName})
+			}
+			tx.Splits = append(tx.Splits, Split{
+				Source:            source,
+				Destination:       destination,
+				Category:          Category{Name: s.CategoryName},
+				Currency:          s.CurrencyCode,
+				ForeignCurrency:   s.ForeignCurrencyCode,
+				Amount:            s.Amount,
+				ForeignAmount:     s.ForeignAmount,
+				Description:       s.Description,
+				Reconciled:        s.Reconciled,
+				Tags:              s.Tags,
+				InternalReference: s.InternalReference,
+				ExternalID:        s.ExternalID,
+				InvoiceDate:       dateOnly(s.InvoiceDate),
+			})
+		}
+		// Same order as the splits of a backup
+		slices.Reverse(tx.Splits)
+		backup.Transactions = append(backup.Transactions, tx)
+	}
+	return backup
+}
+
+// exportAccountType converts the account type named in transactions, e.g.
+// "Asset account", to the type used to list and create accounts.
+func exportAccountType(t string) string {
+	switch strings.ToLower(t) {
+	case "asset account", "default account":
+		return "asset"
+	case "expense account", "beneficiary account":
+		return "expense"
+	case "revenue account":
+		return "revenue"
+	case "loan", "debt", "mortgage", "liability credit account":
+		return "liabilities"
+	case "cash account":
+		return "cash"
+	case "initial balance account":
+		return "initial-balance"
+	case "reconciliation account":
+		return "reconciliation"
+	}
+	return strings.ToLower(t)
+}
+
+// accountKey identifies an account by type and name, which is how a backup
+// is mapped onto the accounts of another instance.
+func accountKey(accountType, name string) string {
+	return restoredType(accountType) + "/" + strings.ToLower(strings.TrimSpace(name))
+}
+
+// restoredType returns the type accounts are listed with, the API names
+// liabilities "liability" when creating them.
+func restoredType(accountType string) string {
+	if accountType == "liability" {
+		return "liabilities"
+	}
+	return accountType
+}
+
+// Restore creates the accounts, categories and transactions of a backup
+// that are not in Firefly III yet. Accounts and categories are matched by
+// name, transactions by date, amount, description and accounts, so running
+// it twice creates nothing the second time. Opening balances come with
+// their accounts and reconciliations are not restored.
+func (api *Api) Restore(ctx context.Context, backup Backup) (RestoreResult, error) {
+	var result RestoreResult
+
+	existing, err := api.ListAccounts(ctx, "all")
+	if err != nil {
+		return result, fmt.Errorf("failed to list accounts: %w", err)
+	}
+	accountIDs := map[string]string{}
+	for _, a := range existing {
+		accountIDs[accountKey(a.Attributes.Type, a.Attributes.Name)] = a.ID
+	}
+
+	created := false
+	for _, a := range backup.Accounts {
+		if !slices.Contains(restoredAccountTypes, restoredType(a.Type)) {
+			continue
+		}
+		if _, ok := accountIDs[accountKey(a.Type, a.Name)]; ok {
+			result.Accounts.Skipped++
+			continue
+		}
+		if err := api.createAccount(ctx, restoreAccountPayload(a)); err != nil {
+			result.Failed = append(result.Failed, fmt.Sprintf("%s account %s: %v", a.Type, a.Name, err))
+			continue
+		}
+		result.Accounts.Created++
+		created = true
+	}
+	if created {
+		existing, err = api.ListAccounts(ctx, "all")
+		if err != nil {
+			return result, fmt.Errorf("failed to list accounts: %w", err)
+		}
+		for _, a := range existing {
+			accountIDs[accountKey(a.Attributes.Type, a.Attributes.Name)] = a.ID
+		}
+	}
+
+	categories, err := api.ListCategories(ctx)
+	if err != nil {
+		return result, fmt.Errorf("failed to list categories: %w", err)
+	}
+	categoryNames := map[string]bool{}
+	for _, c := range categories {
+		categoryNames[strings.ToLower(c.Name)] = true
+	}
+	for _, c := range backup.Categories {
+		if categoryNames[strings.ToLower(c.Name)] {
+			result.Categories.Skipped++
+			continue
+		}
+		if err := api.CreateCategory(ctx, c.Name, c.Notes); err != nil {
+			result.Failed = append(result.Failed, fmt.Sprintf("category %s: %v", c.Name, err))
+			continue
+		}
+		categoryNames[strings.ToLower(c.Name)] = true
+		result.Categories.Created++
+	}
+
+	allData, err := api.fetchPaginated(ctx, "%s/transactions?page=%d", api.Config.ApiUrl)
+	if err != nil {
+		return result, fmt.Errorf("failed to list transactions: %w", err)
+	}
+	txs, err := unmarshalItems[ResponseTransaction](allData)
+	if err != nil {
+		return result, fmt.Errorf("failed to unmarshal transactions: %v", err)
+	}
+	known := map[string]bool{}
+	for _, t := range txs {
+		for _, s := range t.Attributes.Transactions {
+			known[transactionKey(s.Date, s.Description, s.Amount, s.SourceName, s.DestinationName)] = true
+		}
+	}
+
+	var requests []RequestTransaction
+	for _, tx := range backup.Transactions {
+		if tx.Type == "opening balance" || tx.Type == "reconciliation" || len(tx.Splits) == 0 {
+			result.Transactions.Skipped++
+			continue
+		}
+		s := tx.Splits[0]
+		if known[transactionKey(tx.Date, s.Description, s.Amount, s.Source.Name, s.Destination.Name)] {
+			result.Transactions.Skipped++
+			continue
+		}
+		requests = append(requests, restoreRequest(tx, accountIDs))
+	}
+
+	batch := api.CreateTransactions(ctx, requests)
+	result.Transactions.Created = len(batch.Created)
+	for _, f := range batch.Failed {
+		result.Failed = append(result.Failed, fmt.Sprintf("transaction %s: %v", f.Description, f.Err))
+	}
+	if batch.Queued > 0 {
+		result.Failed = append(result.Failed,
+			fmt.Sprintf("%d transactions queued while the server was unreachable, restore again once it is back", batch.Queued))
+	}
+
+	zap.L().Info("Backup restored",
+		zap.Int("accounts", result.Accounts.Created),
+		zap.Int("categories", result.Categories.Created),
+		zap.Int("transactions", result.Transactions.Created),
+		zap.Int("failed", len(result.Failed)))
+	return result, nil
+}
+
+// transactionKey identifies a transaction by its first split.
+func transactionKey(date, description string, amount float64, source, destination string) string {
+	return strings.Join([]string{
+		dateOnly(date),
+		strings.ToLower(description),
+		strconv.FormatFloat(amount, 'f', 2, 64),
+		strings.ToLower(source),
+		strings.ToLower(destination),
+	}, "|")
+}
+
+// restoreAccountPayload is the request creating a backed up account.
+func restoreAccountPayload(a Account) map[string]any {
+	accountType := a.Type
+	if restoredType(accountType) == "liabilities" {
+		accountType = "liability"
+	}
+	payload := map[string]any{
+		"name": a.Name,
+		"type": accountType,
+	}
+	if a.CurrencyCode != "" {
+		payload["currency_code"] = strings.ToUpper(a.CurrencyCode)
+	}
+	if a.IBAN != "" {
+		payload["iban"] = a.IBAN
+	}
+	if a.AccountNumber != "" {
+		payload["account_number"] = a.AccountNumber
+	}
+	if a.Notes != "" {
+		payload["notes"] = a.Notes
+	}
+	if a.OpeningBalance != 0 && a.OpeningBalanceDate != "" {
+		payload["opening_balance"] = strconv.FormatFloat(a.OpeningBalance, 'f', -1, 64)
+		payload["opening_balance_date"] = dateOnly(a.OpeningBalanceDate)
+	}
+
+	switch payload["type"] {
+	case "asset":
+		role := a.Role
+		if role == "" {
+			role = "defaultAsset"
+		}
+		payload["account_role"] = role
+		payload["include_net_worth"] = true
+		if role == "ccAsset" {
+			// Required by Firefly III for credit cards
+			payload["credit_card_type"] = "monthlyFull"
+			payload["monthly_payment_date"] = a.MonthlyPaymentDate
+			if a.MonthlyPaymentDate == "" {
+				payload["monthly_payment_date"] = a.OpeningBalanceDate
+			}
+			if a.CreditLimit != 0 {
+				payload["virtual_balance"] = strconv.FormatFloat(a.CreditLimit, 'f', -1, 64)
+			}
+		}
+	case "liability":
+		payload["liability_type"] = a.LiabilityType
+		payload["liability_direction"] = a.LiabilityDirection
+		if a.Interest != "" {
+			payload["interest"] = a.Interest
+			payload["interest_period"] = a.InterestPeriod
+		}
+	}
+	return payload
+}
+
+// restoreRequest is the request creating a backed up transaction, with
+// its accounts by ID when they exist and by name otherwise.
+func restoreRequest(tx Transaction, accountIDs map[string]string) RequestTransaction {
+	// Splits are kept in reverse order of the API
+	splits := slices.Clone(tx.Splits)
+	slices.Reverse(splits)
+
+	request := RequestTransaction{GroupTitle: tx.GroupTitle}
+	for _, s := range splits {
+		split := RequestTransactionSplit{
+			Type:              tx.Type,
+			Date:              tx.Date,
+			Amount:            strconv.FormatFloat(s.Amount, 'f', -1, 64),
+			Description:       s.Description,
+			CurrencyCode:      s.Currency,
+			CategoryName:      s.Category.Name,
+			Reconciled:        s.Reconciled,
+			Tags:              s.Tags,
+			InternalReference: s.InternalReference,
+			ExternalID:        s.ExternalID,
+			InvoiceDate:       s.InvoiceDate,
+		}
+		if s.ForeignAmount != 0 && s.ForeignCurrency != "" {
+			split.ForeignAmount = strconv.FormatFloat(s.ForeignAmount, 'f', -1, 64)
+			split.ForeignCurrencyCode = s.ForeignCurrency
+		}
+		if id, ok := accountIDs[accountKey(s.Source.Type, s.Source.Name)]; ok {
+			split.SourceID = id
+		} else {
+			split.SourceName = s.Source.Name
+		}
+		if id, ok := accountIDs[accountKey(s.Destination.Type, s.Destination.Name)]; ok {
+			split.DestinationID = id
+		} else {
+			split.DestinationName = s.Destination.Name
+		}
+		if s.Location.ZoomLevel != 0 {
+			latitude, longitude, zoom := s.Location.Latitude, s.Location.Longitude, s.Location.ZoomLevel
+			split.Latitude, split.Longitude, split.ZoomLevel = &latitude, &longitude, &zoom
+		}
+		request.Transactions = append(request.Transactions, split)
+	}
+	return request
+}
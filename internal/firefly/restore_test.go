@@ -0,0 +1,171 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package firefly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const exportJSON = `{"data":[{"id":"7","attributes":{"group_title":"","transactions":[
+	{"type":"withdrawal","date":"2026-01-05T00:00:00+00:00","amount":"12.50","currency_code":"EUR",
+	 "description":"Lunch","source_name":"Checking","source_type":"Asset account",
+	 "destination_name":"Cafe","destination_type":"Expense account","category_name":"Food"},
+	{"type":"withdrawal","date":"2026-01-05T00:00:00+00:00","amount":"3","currency_code":"EUR",
+	 "description":"Tip","source_name":"Checking","source_type":"Asset account",
+	 "destination_name":"Cafe","destination_type":"Expense account","category_name":"food"}]}}]}`
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "backup.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadBackup_FromExport(t *testing.T) {
+	backup, err := LoadBackup(writeFile(t, exportJSON))
+	if err != nil {
+		t.Fatalf("LoadBackup failed: %v", err)
+	}
+
+	if len(backup.Accounts) != 2 ||
+		backup.Accounts[0].Name != "Checking" || backup.Accounts[0].Type != "asset" ||
+		backup.Accounts[1].Name != "Cafe" || backup.Accounts[1].Type != "expense" {
+		t.Errorf("Expected the accounts used once each by type, got %+v", backup.Accounts)
+	}
+	if len(backup.Categories) != 1 || backup.Categories[0].Name != "Food" {
+		t.Errorf("Expected the category once, got %+v", backup.Categories)
+	}
+	if len(backup.Transactions) != 1 {
+		t.Fatalf("Expected 1 transaction, got %d", len(backup.Transactions))
+	}
+	tx := backup.Transactions[0]
+	if tx.Type != "withdrawal" || len(tx.Splits) != 2 || tx.Splits[0].Description != "Tip" {
+		t.Errorf("Expected the splits in backup order, got %+v", tx)
+	}
+}
+
+func TestLoadBackup_RejectsEmpty(t *testing.T) {
+	if _, err := LoadBackup(writeFile(t, `{"data":[]}`)); err == nil {
+		t.Error("Expected an error for a backup with nothing to restore")
+	}
+	if _, err := LoadBackup(writeFile(t, `not json`)); err == nil {
+		t.Error("Expected an error for an unreadable backup")
+	}
+}
+
+// restoreServer is an instance holding the Checking account, the Food
+// category and the lunch of the export. It records what is created.
+type restoreServer struct {
+	mu      sync.Mutex
+	created []string
+}
+
+func (s *restoreServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/api")
+	if r.Method == http.MethodPost {
+		var payload map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		name, _ := payload["name"].(string)
+		if splits, ok := payload["transactions"].([]any); ok {
+			first, _ := splits[0].(map[string]any)
+			name, _ = first["description"].(string)
+			if id, ok := first["source_id"].(string); ok {
+				name += " from #" + id
+			}
+		}
+		s.mu.Lock()
+		s.created = append(s.created, path+" "+name)
+		s.mu.Unlock()
+		writeData(w, `{"id":"99","attributes":{}}`)
+		return
+	}
+	if r.URL.Query().Get("page") != "1" {
+		writeData(w, `[]`)
+		return
+	}
+	switch path {
+	case "/accounts":
+		writeData(w, `[{"id":"1","attributes":{"name":"Checking","type":"asset"}}]`)
+	case "/categories":
+		writeData(w, `[{"id":"2","attributes":{"name":"Food"}}]`)
+	case "/transactions":
+		writeData(w, `[{"id":"3","attributes":{"transactions":[{"type":"withdrawal",
+			"date":"2026-01-05T00:00:00+00:00","amount":"12.5","description":"Lunch",
+			"source_name":"Checking","destination_name":"Cafe"}]}}]`)
+	default:
+		writeData(w, `[]`)
+	}
+}
+
+func TestRestore_CreatesOnlyWhatIsMissing(t *testing.T) {
+	server := &restoreServer{}
+	api := newTestApi(t, server.ServeHTTP)
+	backup := Backup{
+		Accounts: []Account{
+			{Name: "checking", Type: "asset"},
+			{Name: "Cafe", Type: "expense"},
+			{Name: "Cash", Type: "cash"},
+		},
+		Categories: []Category{{Name: "food"}, {Name: "Fun"}},
+		Transactions: []Transaction{
+			{Type: "withdrawal", Date: "2026-01-05", Splits: []Split{{
+				Description: "Lunch", Amount: 12.5,
+				Source:      Account{Name: "Checking", Type: "asset"},
+				Destination: Account{Name: "Cafe", Type: "expense"},
+			}}},
+			{Type: "withdrawal", Date: "2026-01-06", Splits: []Split{{
+				Description: "Dinner", Amount: 30,
+				Source:      Account{Name: "Checking", Type: "asset"},
+				Destination: Account{Name: "Cafe", Type: "expense"},
+			}}},
+			{Type: "opening balance", Date: "2026-01-01", Splits: []Split{{Amount: 100}}},
+		},
+	}
+
+	result, err := api.Restore(context.Background(), backup)
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+
+	want := []string{"/accounts Cafe", "/categories Fun", "/transactions Dinner from #1"}
+	if len(server.created) != len(want) {
+		t.Fatalf("Expected %q created, got %q", want, server.created)
+	}
+	for i := range want {
+		if server.created[i] != want[i] {
+			t.Errorf("Expected %q created, got %q", want, server.created)
+			break
+		}
+	}
+	if got := result.Summary(); got != "accounts: 1 created, 1 skipped; categories: 1 created, 1 skipped; transactions: 1 created, 2 skipped" {
+		t.Errorf("Unexpected summary %q", got)
+	}
+}
+
+func TestRestoreAccountPayload(t *testing.T) {
+	payload := restoreAccountPayload(Account{
+		Name: "Card", Type: "asset", Role: "ccAsset", CurrencyCode: "eur",
+		OpeningBalance: -50, OpeningBalanceDate: "2026-01-01T00:00:00+00:00",
+	})
+	if payload["currency_code"] != "EUR" || payload["credit_card_type"] != "monthlyFull" ||
+		payload["monthly_payment_date"] != "2026-01-01T00:00:00+00:00" ||
+		payload["opening_balance"] != "-50" || payload["opening_balance_date"] != "2026-01-01" {
+		t.Errorf("Unexpected credit card payload %v", payload)
+	}
+
+	liability := restoreAccountPayload(Account{Name: "Loan", Type: "liabilities", LiabilityType: "loan"})
+	if liability["type"] != "liability" || liability["liability_type"] != "loan" {
+		t.Errorf("Expected a liability payload, got %v", liability)
+	}
+}
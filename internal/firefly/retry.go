@@ -0,0 +1,170 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package firefly
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// RetryConfig controls retries of transient failures.
+type RetryConfig struct {
+	// MaxRetries is the number of retries after the first attempt, 0 disables retries.
+	MaxRetries int
+	// BaseDelay is the delay before the first retry, doubled for each next one.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between attempts.
+	MaxDelay time.Duration
+}
+
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxRetries: 3,
+		BaseDelay:  500 * time.Millisecond,
+		MaxDelay:   8 * time.Second,
+	}
+}
+
+// RetryStatus returns the attempt of the latest request being retried and
+// the configured maximum. The attempt is 0 when nothing is being retried.
+func (api *Api) RetryStatus() (attempt, maxRetries int) {
+	if api.retrying.Load() == 0 {
+		return 0, api.Config.Retry.MaxRetries
+	}
+	return int(api.retryAttempt.Load()), api.Config.Retry.MaxRetries
+}
+
+// do sends the request, retrying timeouts and 429/502/503/504 responses with
+// exponential backoff and jitter. Requests that are not idempotent are only
+// retried when the server did not process them (429, 503). Waiting stops
+// when the request context is done. Once the API token was rejected no
+// request is sent until it is replaced.
+func (api *Api) do(req *http.Request) (*http.Response, error) {
+	if api.unauthorized.Load() {
+		return nil, ErrUnauthorized
+	}
+	req.Header.Set("Authorization", "Bearer "+api.apiKey())
+	cfg := api.Config.Retry
+	client := api.httpClient(api.requestTimeout(req))
+
+	retried := false
+	defer func() {
+		if retried {
+			api.retrying.Add(-1)
+		}
+	}()
+
+	for attempt := 0; ; attempt++ {
+		if err := api.limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+		start := time.Now()
+		resp, err := client.Do(req)
+		api.trace(req, attempt, start, resp, err)
+		if req.Context().Err() != nil {
+			// Canceled by the caller, says nothing about the server
+			return resp, err
+		}
+		if attempt >= cfg.MaxRetries || !shouldRetry(req.Method, resp, err) {
+			api.trackAuthorization(resp)
+			if resp != nil {
+				resp.Body = countingBody{ReadCloser: resp.Body, n: &api.stats.bytesIn}
+			}
+			return resp, api.trackConnectivity(err)
+		}
+
+		delay := backoff(cfg, attempt)
+		var reason string
+		if err != nil {
+			reason = err.Error()
+		} else {
+			reason = resp.Status
+			if after := retryAfter(resp); after > delay {
+				delay = min(after, cfg.MaxDelay)
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		if req.Body != nil {
+			if req.GetBody == nil {
+				return nil, fmt.Errorf("cannot retry request with non-replayable body: %s", reason)
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return nil, fmt.Errorf("failed to replay request body: %w", bodyErr)
+			}
+			req.Body = body
+		}
+
+		if !retried {
+			retried = true
+			api.retrying.Add(1)
+		}
+		api.retryAttempt.Store(int32(attempt + 1))
+
+		zap.L().Warn("Retrying HTTP request",
+			zap.String("method", req.Method),
+			zap.String("endpoint", req.URL.String()),
+			zap.Int("attempt", attempt+1),
+			zap.Int("max_retries", cfg.MaxRetries),
+			zap.Duration("delay", delay),
+			zap.String("reason", reason))
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+func shouldRetry(method string, resp *http.Response, err error) bool {
+	idempotent := method != http.MethodPost
+
+	if err != nil {
+		var netErr net.Error
+		return idempotent && errors.As(err, &netErr) && netErr.Timeout()
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
+
+// backoff returns the delay before retry number attempt+1 with full jitter
+// over the upper half of the exponential window.
+func backoff(cfg RetryConfig, attempt int) time.Duration {
+	delay := cfg.BaseDelay << attempt
+	if delay <= 0 || delay > cfg.MaxDelay {
+		delay = cfg.MaxDelay
+	}
+	half := delay / 2
+	if half <= 0 {
+		return delay
+	}
+	return half + rand.N(half)
+}
+
+// retryAfter parses the Retry-After header given in seconds.
+func retryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
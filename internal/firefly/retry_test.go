@@ -0,0 +1,178 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package firefly
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// failingHandler answers the first failures requests with status, then
+// succeeds. hits counts every request.
+func failingHandler(hits *atomic.Int32, failures int32, status int, header http.Header) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) <= failures {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			return
+		}
+		writeData(w, `[]`)
+	}
+}
+
+func TestDo_RetriesServerErrors(t *testing.T) {
+	for _, status := range []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout} {
+		var hits atomic.Int32
+		api := newTestApi(t, failingHandler(&hits, 2, status, nil))
+
+		_, err := api.getRequest(context.Background(), api.Config.ApiUrl+"/v1/accounts")
+		if err != nil {
+			t.Errorf("%d: expected success after retries, got %v", status, err)
+		}
+		if got := hits.Load(); got != 3 {
+			t.Errorf("%d: expected 3 attempts, got %d", status, got)
+		}
+	}
+}
+
+func TestDo_GivesUpAfterMaxRetries(t *testing.T) {
+	var hits atomic.Int32
+	api := newTestApi(t, failingHandler(&hits, 10, http.StatusServiceUnavailable, nil))
+
+	_, err := api.getRequest(context.Background(), api.Config.ApiUrl+"/v1/accounts")
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected the last 503 to be returned, got %v", err)
+	}
+	if got, want := hits.Load(), int32(api.Config.Retry.MaxRetries+1); got != want {
+		t.Errorf("Expected %d attempts, got %d", want, got)
+	}
+}
+
+func TestDo_WaitsRetryAfterOnTooManyRequests(t *testing.T) {
+	var hits atomic.Int32
+	api := newTestApi(t, failingHandler(&hits, 1, http.StatusTooManyRequests,
+		http.Header{"Retry-After": {"1"}}))
+	api.Config.Retry.MaxDelay = 2 * time.Second
+
+	start := time.Now()
+	_, err := api.getRequest(context.Background(), api.Config.ApiUrl+"/v1/accounts")
+	if err != nil {
+		t.Fatalf("Expected success after the retry, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("Expected to wait the Retry-After second, waited %v", elapsed)
+	}
+	if got := hits.Load(); got != 2 {
+		t.Errorf("Expected 2 attempts, got %d", got)
+	}
+}
+
+func TestDo_DoesNotRetryClientErrors(t *testing.T) {
+	for _, status := range []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity} {
+		var hits atomic.Int32
+		api := newTestApi(t, failingHandler(&hits, 10, status, nil))
+
+		_, err := api.getRequest(context.Background(), api.Config.ApiUrl+"/v1/accounts")
+		if err == nil {
+			t.Errorf("%d: expected an error", status)
+		}
+		if got := hits.Load(); got != 1 {
+			t.Errorf("%d: expected 1 attempt, got %d", status, got)
+		}
+	}
+}
+
+func TestDo_DoesNotRetryPostTheServerMayHaveProcessed(t *testing.T) {
+	var hits atomic.Int32
+	api := newTestApi(t, failingHandler(&hits, 10, http.StatusBadGateway, nil))
+
+	_, err := api.postRequest(context.Background(), api.Config.ApiUrl+"/v1/transactions", map[string]string{"a": "b"})
+	if err == nil {
+		t.Error("Expected an error")
+	}
+	if got := hits.Load(); got != 1 {
+		t.Errorf("Expected 1 attempt, got %d", got)
+	}
+}
+
+func TestDo_RetriesPostTheServerDidNotProcess(t *testing.T) {
+	var hits atomic.Int32
+	var bodies atomic.Int32
+	api := newTestApi(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength > 0 {
+			bodies.Add(1)
+		}
+		failingHandler(&hits, 1, http.StatusServiceUnavailable, nil)(w, r)
+	})
+
+	_, err := api.postRequest(context.Background(), api.Config.ApiUrl+"/v1/transactions", map[string]string{"a": "b"})
+	if err != nil {
+		t.Fatalf("Expected success after the retry, got %v", err)
+	}
+	if got := hits.Load(); got != 2 {
+		t.Errorf("Expected 2 attempts, got %d", got)
+	}
+	if got := bodies.Load(); got != 2 {
+		t.Errorf("Expected the body to be sent with each attempt, got %d", got)
+	}
+}
+
+func TestDo_StopsWaitingWhenCanceled(t *testing.T) {
+	var hits atomic.Int32
+	api := newTestApi(t, failingHandler(&hits, 10, http.StatusServiceUnavailable, nil))
+	api.Config.Retry.BaseDelay = time.Minute
+	api.Config.Retry.MaxDelay = time.Minute
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := api.makeRequest(ctx, http.MethodGet, api.Config.ApiUrl+"/v1/accounts", nil, http.StatusOK)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the deadline error, got %v", err)
+	}
+}
+
+func TestBackoff_GrowsAndIsCapped(t *testing.T) {
+	cfg := RetryConfig{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	tests := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{0, 50 * time.Millisecond, 100 * time.Millisecond},
+		{1, 100 * time.Millisecond, 200 * time.Millisecond},
+		{2, 200 * time.Millisecond, 400 * time.Millisecond},
+		{5, 500 * time.Millisecond, time.Second},
+		{60, 500 * time.Millisecond, time.Second},
+	}
+	for _, tt := range tests {
+		for range 20 {
+			if d := backoff(cfg, tt.attempt); d < tt.min || d > tt.max {
+				t.Errorf("attempt %d: expected a delay in [%v, %v], got %v", tt.attempt, tt.min, tt.max, d)
+			}
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := map[string]time.Duration{
+		"":     0,
+		"3":    3 * time.Second,
+		"-1":   0,
+		"soon": 0,
+	}
+	for header, want := range tests {
+		resp := &http.Response{Header: http.Header{}}
+		resp.Header.Set("Retry-After", header)
+		if got := retryAfter(resp); got != want {
+			t.Errorf("Retry-After %q: expected %v, got %v", header, want, got)
+		}
+	}
+}
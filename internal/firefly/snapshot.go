@@ -0,0 +1,241 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package firefly
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+const snapshotVersion = 1
+
+// Resources tracked as stale after restoring a snapshot. Account resources
+// use the account type passed to UpdateAccounts.
+const (
+	StaleBase         = "base"
+	StaleCategories   = "categories"
+	StaleTransactions = "transactions"
+)
+
+var snapshotAccountTypes = []string{"asset", "expense", "revenue", "liabilities"}
+
+// Snapshot is the data persisted between runs, so the UI can render the
+// last known state before the first refresh completes.
+type Snapshot struct {
+	Version   int
+	ServerURL string
+	SavedAt   time.Time
+
+	User            User
+	Accounts        map[string][]Account
+	AccountBalances map[string]float64
+	AccountActivity map[string]string
+	CashAccount     Account
+	Categories      []Category
+	Currencies      []Currency
+
+	// Transactions of the period between StartDate and EndDate.
+	StartDate    time.Time
+	EndDate      time.Time
+	Transactions []Transaction
+
+	// Writes queued while offline and not sent yet.
+	Queue []QueuedWrite
+}
+
+// Snapshot returns the current data for persisting.
+func (api *Api) Snapshot() Snapshot {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	data := api.Data()
+	return Snapshot{
+		Version:         snapshotVersion,
+		ServerURL:       api.Config.ApiUrl,
+		SavedAt:         time.Now(),
+		User:            api.User,
+		Accounts:        data.accounts,
+		AccountBalances: data.balances,
+		AccountActivity: data.activity,
+		CashAccount:     api.cashAccount,
+		Categories:      data.categories,
+		Currencies:      api.Currencies,
+		StartDate:       api.periodTxStart,
+		EndDate:         api.periodTxEnd,
+		Transactions:    api.periodTransactions,
+		Queue:           api.queue,
+	}
+}
+
+// NewApiFromSnapshot creates an Api with the data of a snapshot without
+// contacting the server. All restored resources are reported as stale until
+// they are refreshed; RefreshBaseData must be called before editing.
+func NewApiFromSnapshot(config ApiConfig, snap Snapshot) (*Api, error) {
+	if snap.Version != snapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d", snap.Version)
+	}
+	if snap.ServerURL != config.ApiUrl {
+		return nil, errors.New("snapshot belongs to another server")
+	}
+
+	api, err := newClient(config)
+	if err != nil {
+		return nil, err
+	}
+	api.StartDate = time.Now().AddDate(0, 0, -time.Now().Day()+1)
+	api.EndDate = time.Now().AddDate(0, 1, -time.Now().Day())
+
+	api.User = snap.User
+	api.store.update(func(d *StoreData) {
+		d.accounts = snap.Accounts
+		d.balances = snap.AccountBalances
+		d.activity = snap.AccountActivity
+		d.categories = snap.Categories
+	})
+	api.cashAccount = snap.CashAccount
+	api.Currencies = snap.Currencies
+
+	if sameDay(snap.StartDate, api.StartDate) && sameDay(snap.EndDate, api.EndDate) {
+		api.periodTransactions = snap.Transactions
+		api.periodTxStart, api.periodTxEnd = snap.StartDate, snap.EndDate
+	}
+
+	api.queue = snap.Queue
+	api.savedAt = snap.SavedAt
+	api.stale = map[string]bool{
+		StaleBase:         true,
+		StaleCategories:   true,
+		StaleTransactions: true,
+	}
+	for _, t := range snapshotAccountTypes {
+		api.stale[t] = true
+	}
+
+	return api, nil
+}
+
+// RefreshBaseData loads the current user, special accounts and currencies.
+// NewApi does this on start, for a restored Api it runs in the background.
+func (api *Api) RefreshBaseData(ctx context.Context) error {
+	userEmail, err := api.GetCurrentUser(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to connect to Firefly III: %w", err)
+	}
+	api.User = User{
+		Email: userEmail,
+	}
+
+	err = api.UpdateAccounts(ctx, "special")
+	if err != nil {
+		return fmt.Errorf("failed to update special accounts: %w", err)
+	}
+	err = api.UpdateCurrencies(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to update currencies: %w", err)
+	}
+
+	api.markFresh(StaleBase)
+	return nil
+}
+
+// Stale returns the resources still showing restored data, sorted, and when
+// the snapshot was saved.
+func (api *Api) Stale() ([]string, time.Time) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	var resources []string
+	for resource, stale := range api.stale {
+		if stale {
+			resources = append(resources, resource)
+		}
+	}
+	slices.Sort(resources)
+	return resources, api.savedAt
+}
+
+// CachedTransactions returns the restored transactions of the current
+// period, or nil when they were already refreshed or not available.
+func (api *Api) CachedTransactions() []Transaction {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	if !api.stale[StaleTransactions] {
+		return nil
+	}
+	return api.periodTransactions
+}
+
+// periodCache returns the last fetched transactions when they belong to the
+// current period.
+func (api *Api) periodCache() ([]Transaction, bool) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	if api.periodTransactions == nil ||
+		!sameDay(api.periodTxStart, api.StartDate) || !sameDay(api.periodTxEnd, api.EndDate) {
+		return nil, false
+	}
+	return api.periodTransactions, true
+}
+
+func (api *Api) markFresh(resources ...string) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	for _, resource := range resources {
+		delete(api.stale, resource)
+	}
+}
+
+// rememberTransactions keeps the latest transactions of the period for the
+// next snapshot.
+func (api *Api) rememberTransactions(transactions []Transaction) {
+	api.mu.Lock()
+	api.periodTransactions = transactions
+	api.periodTxStart, api.periodTxEnd = api.StartDate, api.EndDate
+	api.mu.Unlock()
+
+	api.markFresh(StaleTransactions)
+}
+
+func LoadSnapshot(path string) (Snapshot, error) {
+	var snap Snapshot
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return snap, err
+	}
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return snap, fmt.Errorf("failed to parse snapshot: %w", err)
+	}
+	return snap, nil
+}
+
+func SaveSnapshot(path string, snap Snapshot) error {
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+func sameDay(a, b time.Time) bool {
+	return a.Format(time.DateOnly) == b.Format(time.DateOnly)
+}
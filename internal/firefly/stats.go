@@ -0,0 +1,92 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package firefly
+
+import (
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+// SessionStats sums up the requests sent since the client was created, to
+// help tuning timeouts and caching.
+type SessionStats struct {
+	Since    time.Time
+	Requests int // attempts sent, retries included
+	Failed   int // attempts without response or with an error status
+	BytesIn  int64
+	BytesOut int64
+	// GETs that could be answered from the response cache and how many
+	// were, either not modified or while offline
+	CacheLookups int
+	CacheHits    int
+	// Latency sums the duration of all attempts
+	Latency time.Duration
+}
+
+// AvgLatency is the mean duration of an attempt.
+func (s SessionStats) AvgLatency() time.Duration {
+	if s.Requests == 0 {
+		return 0
+	}
+	return s.Latency / time.Duration(s.Requests)
+}
+
+// HitRate is the share of cache lookups answered from the cache, 0 to 1.
+func (s SessionStats) HitRate() float64 {
+	if s.CacheLookups == 0 {
+		return 0
+	}
+	return float64(s.CacheHits) / float64(s.CacheLookups)
+}
+
+type sessionStats struct {
+	since        time.Time
+	requests     atomic.Int64
+	failed       atomic.Int64
+	bytesIn      atomic.Int64
+	bytesOut     atomic.Int64
+	cacheLookups atomic.Int64
+	cacheHits    atomic.Int64
+	latency      atomic.Int64
+}
+
+func (s *sessionStats) attempt(d time.Duration, sent int64, failed bool) {
+	s.requests.Add(1)
+	s.latency.Add(int64(d))
+	if sent > 0 {
+		s.bytesOut.Add(sent)
+	}
+	if failed {
+		s.failed.Add(1)
+	}
+}
+
+// countingBody counts the bytes read from a response body.
+type countingBody struct {
+	io.ReadCloser
+	n *atomic.Int64
+}
+
+func (b countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n.Add(int64(n))
+	return n, err
+}
+
+// SessionStats returns the statistics of the requests of this session.
+func (api *Api) SessionStats() SessionStats {
+	s := &api.stats
+	return SessionStats{
+		Since:        s.since,
+		Requests:     int(s.requests.Load()),
+		Failed:       int(s.failed.Load()),
+		BytesIn:      s.bytesIn.Load(),
+		BytesOut:     s.bytesOut.Load(),
+		CacheLookups: int(s.cacheLookups.Load()),
+		CacheHits:    int(s.cacheHits.Load()),
+		Latency:      time.Duration(s.latency.Load()),
+	}
+}
@@ -0,0 +1,227 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package firefly
+
+import (
+	"maps"
+	"slices"
+	"sync"
+)
+
+// Store holds the accounts, categories, tags and insights loaded from the
+// server. Loads replace whole maps and slices instead of changing them, so
+// a StoreData read from the store stays consistent while the next load
+// runs. The zero value is an empty store.
+type Store struct {
+	mu   sync.RWMutex
+	data StoreData
+}
+
+// StoreData is the content of the store at one point in time. It is read
+// through its selectors, named like the Api methods reading the latest
+// data, and never changed once published.
+type StoreData struct {
+	// accounts by type
+	accounts map[string][]Account
+	balances map[string]float64
+	activity map[string]string
+
+	expenseInsights map[string]accountInsight
+	revenueInsights map[string]accountInsight
+	// Insight totals of the period by currency code
+	expenseTotals map[string]float64
+	revenueTotals map[string]float64
+
+	categories           []Category
+	categoryInsights     map[string]categoryInsight
+	categorySpentTotals  map[string]float64
+	categoryEarnedTotals map[string]float64
+
+	tags        []Tag
+	tagInsights map[string]tagInsight
+}
+
+// Data returns the current content of the store.
+func (s *Store) Data() StoreData {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.data
+}
+
+// Data returns the accounts, categories, tags and insights as last loaded,
+// consistent with each other. Reading through the returned data instead of
+// the Api methods keeps a render from seeing a load half way.
+func (api *Api) Data() StoreData {
+	return api.store.Data()
+}
+
+// update publishes the data changed by fn. fn works on a copy of the
+// current data and must assign new maps and slices, the old ones may be in
+// use by readers.
+func (s *Store) update(fn func(d *StoreData)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data := s.data
+	fn(&data)
+	s.data = data
+}
+
+// setAccounts replaces the accounts of the loaded types and merges their
+// balances and activity.
+func (s *Store) setAccounts(accounts map[string][]Account, balances map[string]float64, activity map[string]string) {
+	s.update(func(d *StoreData) {
+		d.accounts = mergedMap(d.accounts, accounts)
+		d.balances = mergedMap(d.balances, balances)
+		d.activity = mergedMap(d.activity, activity)
+	})
+}
+
+// addAccount appends an account to the ones of its type.
+func (s *Store) addAccount(accountType string, account Account) {
+	s.update(func(d *StoreData) {
+		d.accounts = mergedMap(d.accounts, map[string][]Account{
+			accountType: append(slices.Clone(d.accounts[accountType]), account),
+		})
+	})
+}
+
+func mergedMap[K comparable, V any](old, changes map[K]V) map[K]V {
+	merged := make(map[K]V, len(old)+len(changes))
+	maps.Copy(merged, old)
+	maps.Copy(merged, changes)
+	return merged
+}
+
+// AccountsByType returns the accounts of the given type.
+func (d StoreData) AccountsByType(accountType string) []Account {
+	return slices.Clone(d.accounts[accountType])
+}
+
+// AccountByID returns the account with the ID, empty when not loaded.
+func (d StoreData) AccountByID(ID string) (Account, bool) {
+	for _, group := range d.accounts {
+		for _, acc := range group {
+			if acc.ID == ID {
+				return acc, true
+			}
+		}
+	}
+	return Account{}, false
+}
+
+// AccountBalance returns the balance of the account.
+func (d StoreData) AccountBalance(accountID string) float64 {
+	return d.balances[accountID]
+}
+
+// AccountLastActivity returns the date of the latest transaction of the
+// account, empty when unknown.
+func (d StoreData) AccountLastActivity(accountID string) string {
+	return d.activity[accountID]
+}
+
+// GetExpenseDiff returns the expenses of the period of the account.
+func (d StoreData) GetExpenseDiff(ID string) float64 {
+	return d.expenseInsights[ID].Diff
+}
+
+// GetTotalExpenseDiff returns the expenses of the period of all accounts.
+func (d StoreData) GetTotalExpenseDiff() float64 {
+	return sumInsights(d.expenseInsights)
+}
+
+// ExpenseTotals returns the expenses of the period by currency code.
+func (d StoreData) ExpenseTotals() map[string]float64 {
+	return maps.Clone(d.expenseTotals)
+}
+
+// GetRevenueDiff returns the revenues of the period of the account.
+func (d StoreData) GetRevenueDiff(ID string) float64 {
+	return d.revenueInsights[ID].Diff
+}
+
+// GetTotalRevenueDiff returns the revenues of the period of all accounts.
+func (d StoreData) GetTotalRevenueDiff() float64 {
+	return sumInsights(d.revenueInsights)
+}
+
+// RevenueTotals returns the revenues of the period by currency code.
+func (d StoreData) RevenueTotals() map[string]float64 {
+	return maps.Clone(d.revenueTotals)
+}
+
+func sumInsights(insights map[string]accountInsight) float64 {
+	total := 0.0
+	for _, insight := range insights {
+		total += insight.Diff
+	}
+	return total
+}
+
+// CategoriesList returns the categories.
+func (d StoreData) CategoriesList() []Category {
+	return slices.Clone(d.categories)
+}
+
+// GetCategoryByName returns the category with the name, empty when missing.
+func (d StoreData) GetCategoryByName(name string) Category {
+	for _, category := range d.categories {
+		if category.Name == name {
+			return category
+		}
+	}
+	return Category{}
+}
+
+// GetCategoryByID returns the category with the ID, empty when missing.
+func (d StoreData) GetCategoryByID(ID string) Category {
+	for _, category := range d.categories {
+		if category.ID == ID {
+			return category
+		}
+	}
+	return Category{}
+}
+
+// CategorySpent returns the spent amount of the period of a category.
+func (d StoreData) CategorySpent(categoryID string) float64 {
+	return d.categoryInsights[categoryID].Spent
+}
+
+// CategoryEarned returns the earned amount of the period of a category.
+func (d StoreData) CategoryEarned(categoryID string) float64 {
+	return d.categoryInsights[categoryID].Earned
+}
+
+// CategoryTotals returns the spent and earned amounts of the period by
+// currency code.
+func (d StoreData) CategoryTotals() (spent, earned map[string]float64) {
+	return maps.Clone(d.categorySpentTotals), maps.Clone(d.categoryEarnedTotals)
+}
+
+// GetTotalSpentEarnedCategories returns the spent and earned amounts of the
+// period of all categories.
+func (d StoreData) GetTotalSpentEarnedCategories() (spent, earned float64) {
+	for _, insight := range d.categoryInsights {
+		spent += insight.Spent
+		earned += insight.Earned
+	}
+	return
+}
+
+// TagsList returns the tags.
+func (d StoreData) TagsList() []Tag {
+	return slices.Clone(d.tags)
+}
+
+// TagSpent returns the spent amount of the period of a tag.
+func (d StoreData) TagSpent(tagID string) float64 {
+	return d.tagInsights[tagID].Spent
+}
+
+// TagEarned returns the earned amount of the period of a tag.
+func (d StoreData) TagEarned(tagID string) float64 {
+	return d.tagInsights[tagID].Earned
+}
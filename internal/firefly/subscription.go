@@ -4,7 +4,98 @@ SPDX-License-Identifier: Apache-2.0
 */
 package firefly
 
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// DueSoonDays is how many days ahead subscriptions count as due soon.
+const DueSoonDays = 7
+
+// Subscription is a bill expected to be paid regularly.
 type Subscription struct {
-	ID   string
-	Name string
+	ID           string
+	Name         string
+	AmountMin    float64
+	AmountMax    float64
+	CurrencyCode string
+	// DueDates are the unpaid expected dates, YYYY-MM-DD, of the next
+	// DueSoonDays days
+	DueDates []string
+}
+
+// Amount is the amount expected, between the minimum and the maximum.
+func (s Subscription) Amount() float64 {
+	return (s.AmountMin + s.AmountMax) / 2
+}
+
+type apiSubscription struct {
+	ID         string              `json:"id"`
+	Attributes apiSubscriptionAttr `json:"attributes"`
+}
+
+type apiSubscriptionAttr struct {
+	Name         string   `json:"name"`
+	AmountMin    string   `json:"amount_min"`
+	AmountMax    string   `json:"amount_max"`
+	CurrencyCode string   `json:"currency_code"`
+	Active       bool     `json:"active"`
+	PayDates     []string `json:"pay_dates"`
+	PaidDates    []struct {
+		Date string `json:"date"`
+	} `json:"paid_dates"`
+}
+
+// updateSubscriptionsDue fetches the active subscriptions expected in the
+// next DueSoonDays days and not paid yet.
+func (api *Api) updateSubscriptionsDue(ctx context.Context, now time.Time) error {
+	start := now.Format("2006-01-02")
+	end := now.AddDate(0, 0, DueSoonDays).Format("2006-01-02")
+
+	allData, err := api.fetchPaginated(ctx, "%s/bills?start=%s&end=%s&page=%d", api.Config.ApiUrl, start, end)
+	if err != nil {
+		return fmt.Errorf("failed to fetch paginated subscriptions: %w", err)
+	}
+	items, err := unmarshalItems[apiSubscription](allData)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal subscriptions: %v", err)
+	}
+
+	due := []Subscription{}
+	for _, item := range items {
+		attr := item.Attributes
+		// Payments made in the window settle the earliest expected dates
+		if !attr.Active || len(attr.PayDates) <= len(attr.PaidDates) {
+			continue
+		}
+		dates := []string{}
+		for _, date := range attr.PayDates[len(attr.PaidDates):] {
+			dates = append(dates, date[:min(len(date), 10)])
+		}
+		amountMin, _ := strconv.ParseFloat(attr.AmountMin, 64)
+		amountMax, _ := strconv.ParseFloat(attr.AmountMax, 64)
+		due = append(due, Subscription{
+			ID:           item.ID,
+			Name:         attr.Name,
+			AmountMin:    amountMin,
+			AmountMax:    amountMax,
+			CurrencyCode: attr.CurrencyCode,
+			DueDates:     dates,
+		})
+	}
+	slices.SortFunc(due, func(a, b Subscription) int {
+		return cmp.Or(cmp.Compare(a.DueDates[0], b.DueDates[0]), cmp.Compare(a.Name, b.Name))
+	})
+
+	api.subscriptionsDue = due
+	return nil
+}
+
+// SubscriptionsDue returns the subscriptions due soon, the next one first.
+func (api *Api) SubscriptionsDue() []Subscription {
+	return slices.Clone(api.subscriptionsDue)
 }
@@ -5,6 +5,7 @@ SPDX-License-Identifier: Apache-2.0
 package firefly
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -30,13 +31,13 @@ type SummaryItem struct {
 	SubTitle              string  `json:"sub_title"`
 }
 
-func (api *Api) GetSummary() (map[string]SummaryItem, error) {
+func (api *Api) GetSummary(ctx context.Context) (map[string]SummaryItem, error) {
 	endpoint := fmt.Sprintf("%s/summary/basic?start=%s&end=%s",
 		api.Config.ApiUrl,
 		api.StartDate.Format("2006-01-02"),
 		api.EndDate.Format("2006-01-02"))
 
-	req, err := http.NewRequest("GET", endpoint, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
 	if err != nil {
 		zap.L().Error("Failed to create HTTP request", zap.Error(err))
 		return nil, err
@@ -44,18 +45,16 @@ func (api *Api) GetSummary() (map[string]SummaryItem, error) {
 
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", api.Config.ApiKey))
 
 	startTime := time.Now()
-	client := &http.Client{Timeout: time.Duration(api.Config.TimeoutSeconds) * time.Second}
-	resp, err := client.Do(req)
+	resp, err := api.do(req)
 	requestDuration := time.Since(startTime)
 
 	if err != nil {
 		zap.L().Error("Failed to send HTTP request",
 			zap.Error(err),
 			zap.Duration("request_duration", requestDuration))
-		return nil, fmt.Errorf("failed to send request: %v", err)
+		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
 	defer func() {
 		if closeErr := resp.Body.Close(); closeErr != nil {
@@ -123,12 +122,29 @@ func (api *Api) GetSummary() (map[string]SummaryItem, error) {
 	return items, nil
 }
 
-func (api *Api) UpdateSummary() error {
-	summary, err := api.GetSummary()
+func (api *Api) UpdateSummary(ctx context.Context) error {
+	return api.coalesce(ctx, "summary:"+api.periodKey(), func(ctx context.Context) error {
+		return api.updateSummary(ctx)
+	})
+}
+
+func (api *Api) updateSummary(ctx context.Context) error {
+	summary, err := api.GetSummary(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get summary: %v", err)
+		return fmt.Errorf("failed to get summary: %w", err)
 	}
 	api.Summary = summary
+
+	// Goals are optional, the summary is shown without them
+	if err := api.updatePiggyBanks(ctx); err != nil {
+		zap.L().Warn("Failed to update piggy banks", zap.Error(err))
+	}
+	if err := api.updateSubscriptionsDue(ctx, time.Now()); err != nil {
+		zap.L().Warn("Failed to update subscriptions due", zap.Error(err))
+	}
+	if err := api.updateBurnRate(ctx, time.Now()); err != nil {
+		zap.L().Warn("Failed to update burn rate", zap.Error(err))
+	}
 	return nil
 }
 
@@ -139,7 +155,7 @@ func (api *Api) SummaryItems() map[string]SummaryItem {
 
 func (api *Api) GetMaxWidth() int {
 	if len(api.Summary) < 1 {
-		err := api.UpdateSummary()
+		err := api.UpdateSummary(context.Background())
 		if err != nil {
 			zap.L().Error("Failed to update summary for max width calculation", zap.Error(err))
 			return 0
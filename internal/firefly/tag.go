@@ -0,0 +1,167 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package firefly
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+type Tag struct {
+	ID          string
+	Name        string
+	Description string
+}
+
+type apiTag struct {
+	Type       string     `json:"type"`
+	ID         string     `json:"id"`
+	Attributes apiTagAttr `json:"attributes"`
+}
+
+type apiTagAttr struct {
+	Tag         string `json:"tag"`
+	Description string `json:"description"`
+}
+
+func (api *Api) UpdateTags(ctx context.Context) error {
+	return api.coalesce(ctx, "tags", func(ctx context.Context) error {
+		return api.updateTags(ctx)
+	})
+}
+
+func (api *Api) updateTags(ctx context.Context) error {
+	tags, err := api.ListTags(ctx)
+	if err != nil {
+		return err
+	}
+	api.store.update(func(d *StoreData) { d.tags = tags })
+
+	return api.UpdateTagsInsights(ctx)
+}
+
+func (api *Api) UpdateTagsInsights(ctx context.Context) error {
+	return api.coalesce(ctx, "tag-insights:"+api.periodKey(), func(ctx context.Context) error {
+		return api.updateTagsInsights(ctx)
+	})
+}
+
+func (api *Api) updateTagsInsights(ctx context.Context) error {
+	insights := make(map[string]tagInsight)
+
+	spentInsights, err := api.GetInsights(ctx, "expense/tag")
+	if err == nil {
+		for _, item := range spentInsights {
+			insights[item.ID] = tagInsight{Spent: (-1) * item.DifferenceFloat}
+		}
+	}
+
+	earnedInsights, err := api.GetInsights(ctx, "income/tag")
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err == nil {
+		for _, item := range earnedInsights {
+			val := insights[item.ID]
+			val.Earned = item.DifferenceFloat
+			insights[item.ID] = val
+		}
+	}
+
+	api.store.update(func(d *StoreData) { d.tagInsights = insights })
+
+	return nil
+}
+
+func (api *Api) ListTags(ctx context.Context) ([]Tag, error) {
+	allData, err := api.fetchPaginated(ctx, "%s/tags?page=%d", api.Config.ApiUrl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch paginated tags: %v", err)
+	}
+
+	items, err := unmarshalItems[apiTag](allData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tags: %v", err)
+	}
+
+	tags := []Tag{}
+	for _, item := range items {
+		tags = append(tags, Tag{
+			ID:          item.ID,
+			Name:        item.Attributes.Tag,
+			Description: item.Attributes.Description,
+		})
+	}
+
+	return tags, nil
+}
+
+// RenameTag changes the name of the tag on the server, the transactions
+// using it follow.
+func (api *Api) RenameTag(ctx context.Context, tag Tag, name string) error {
+	endpoint := fmt.Sprintf("%s/tags/%s", api.Config.ApiUrl, tag.ID)
+
+	payload := map[string]any{
+		"tag": name,
+	}
+
+	_, err := api.write(ctx, http.MethodPut, endpoint, payload, "rename tag "+tag.Name+" to "+name)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteTag removes the tag, the transactions using it are kept.
+func (api *Api) DeleteTag(ctx context.Context, tag Tag) error {
+	endpoint := fmt.Sprintf("%s/tags/%s", api.Config.ApiUrl, tag.ID)
+
+	_, err := api.write(ctx, http.MethodDelete, endpoint, nil, "delete tag "+tag.Name)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// TagsList returns the cached tags.
+// It returns a copy of the slice to avoid accidental mutation by callers.
+func (api *Api) TagsList() []Tag {
+	return api.Data().TagsList()
+}
+
+// TagSpent returns the cached spent amount for a tag.
+func (api *Api) TagSpent(tagID string) float64 {
+	return api.Data().TagSpent(tagID)
+}
+
+// TagEarned returns the cached earned amount for a tag.
+func (api *Api) TagEarned(tagID string) float64 {
+	return api.Data().TagEarned(tagID)
+}
+
+// TagUsage returns the number of transactions of the period using each tag,
+// by tag name. It is empty until the transactions of the period are loaded.
+func (api *Api) TagUsage() map[string]int {
+	usage := make(map[string]int)
+	transactions, ok := api.periodCache()
+	if !ok {
+		return usage
+	}
+	for _, tx := range transactions {
+		seen := make(map[string]bool)
+		for _, split := range tx.Splits {
+			for _, tag := range split.Tags {
+				if !seen[tag] {
+					seen[tag] = true
+					usage[tag]++
+				}
+			}
+		}
+	}
+	return usage
+}
@@ -0,0 +1,78 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package firefly
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// TimeoutConfig sets the timeout of each class of requests. Zero uses
+// ApiConfig.TimeoutSeconds.
+type TimeoutConfig struct {
+	// Metadata covers accounts, categories, currencies, single
+	// transactions and writes, which answer fast.
+	Metadata time.Duration
+	// Transactions covers transaction listings, searches and insights,
+	// which grow with the data in the period.
+	Transactions time.Duration
+	// Export covers data exports.
+	Export time.Duration
+}
+
+type requestClass int
+
+const (
+	metadataRequest requestClass = iota
+	transactionsRequest
+	exportRequest
+)
+
+// classifyRequest tells the class of a request from its method and path.
+func classifyRequest(method, path string) requestClass {
+	switch {
+	case strings.Contains(path, "/data/export"):
+		return exportRequest
+	case method == http.MethodGet && (strings.HasSuffix(path, "/transactions") ||
+		strings.Contains(path, "/search/transactions") ||
+		strings.Contains(path, "/insight/")):
+		return transactionsRequest
+	}
+	return metadataRequest
+}
+
+// requestTimeout returns the timeout of a request, the one of its class if
+// set.
+func (api *Api) requestTimeout(req *http.Request) time.Duration {
+	return api.classTimeout(req.Method, req.URL.Path)
+}
+
+// sharedRequestTimeout bounds a request shared by several callers, which
+// no longer ends with the caller that started it. It leaves room for every
+// attempt and the waits between them.
+func (api *Api) sharedRequestTimeout(method, endpoint string) time.Duration {
+	path, _, _ := strings.Cut(endpoint, "?")
+	retry := api.Config.Retry
+	attempts := time.Duration(max(retry.MaxRetries, 0) + 1)
+	return attempts*api.classTimeout(method, path) + (attempts-1)*retry.MaxDelay
+}
+
+func (api *Api) classTimeout(method, path string) time.Duration {
+	timeouts := api.Config.Timeouts
+	var timeout time.Duration
+	switch classifyRequest(method, path) {
+	case transactionsRequest:
+		timeout = timeouts.Transactions
+	case exportRequest:
+		timeout = timeouts.Export
+	default:
+		timeout = timeouts.Metadata
+	}
+	if timeout > 0 {
+		return timeout
+	}
+	return time.Duration(api.Config.TimeoutSeconds) * time.Second
+}
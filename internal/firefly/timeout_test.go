@@ -0,0 +1,59 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package firefly
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClassifyRequest(t *testing.T) {
+	tests := []struct {
+		method, path string
+		want         requestClass
+	}{
+		{http.MethodGet, "/api/v1/accounts", metadataRequest},
+		{http.MethodGet, "/api/v1/transactions", transactionsRequest},
+		{http.MethodGet, "/api/v1/accounts/1/transactions", transactionsRequest},
+		{http.MethodGet, "/api/v1/search/transactions", transactionsRequest},
+		{http.MethodGet, "/api/v1/insight/expense/expense", transactionsRequest},
+		{http.MethodPost, "/api/v1/transactions", metadataRequest},
+		{http.MethodGet, "/api/v1/data/export/transactions", exportRequest},
+	}
+	for _, tt := range tests {
+		if got := classifyRequest(tt.method, tt.path); got != tt.want {
+			t.Errorf("%s %s: expected class %d, got %d", tt.method, tt.path, tt.want, got)
+		}
+	}
+}
+
+func TestRequestTimeout_ClassOverridesDefault(t *testing.T) {
+	api := &Api{Config: ApiConfig{
+		TimeoutSeconds: 10,
+		Timeouts:       TimeoutConfig{Transactions: time.Minute},
+	}}
+
+	list, _ := http.NewRequest(http.MethodGet, "http://firefly/api/v1/transactions", nil)
+	if got := api.requestTimeout(list); got != time.Minute {
+		t.Errorf("Expected the transactions timeout, got %v", got)
+	}
+	accounts, _ := http.NewRequest(http.MethodGet, "http://firefly/api/v1/accounts", nil)
+	if got := api.requestTimeout(accounts); got != 10*time.Second {
+		t.Errorf("Expected the default timeout, got %v", got)
+	}
+}
+
+func TestSharedRequestTimeout_CoversRetries(t *testing.T) {
+	api := &Api{Config: ApiConfig{
+		TimeoutSeconds: 10,
+		Retry:          RetryConfig{MaxRetries: 2, MaxDelay: time.Second},
+	}}
+
+	got := api.sharedRequestTimeout(http.MethodGet, "http://firefly/api/v1/accounts?page=1")
+	if want := 3*10*time.Second + 2*time.Second; got != want {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
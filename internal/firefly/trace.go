@@ -0,0 +1,89 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package firefly
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// traceSize is the number of requests kept for RecentRequests.
+const traceSize = 200
+
+// RequestTrace describes one HTTP attempt sent to the server.
+type RequestTrace struct {
+	Method   string
+	URL      string
+	Status   int // 0 when no response was received
+	Duration time.Duration
+	Attempt  int // 0 for the first attempt, n for the nth retry
+	Err      string
+	At       time.Time
+}
+
+// requestTracer keeps the most recent requests in a ring buffer.
+type requestTracer struct {
+	mu     sync.Mutex
+	traces []RequestTrace
+	next   int
+}
+
+func (t *requestTracer) add(trace RequestTrace) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.traces) < traceSize {
+		t.traces = append(t.traces, trace)
+		return
+	}
+	t.traces[t.next] = trace
+	t.next = (t.next + 1) % traceSize
+}
+
+// recent returns the traces, newest first.
+func (t *requestTracer) recent() []RequestTrace {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := make([]RequestTrace, 0, len(t.traces))
+	for i := range t.traces {
+		out = append(out, t.traces[(t.next+len(t.traces)-1-i)%len(t.traces)])
+	}
+	return out
+}
+
+// RecentRequests returns the latest requests sent to the server, newest
+// first.
+func (api *Api) RecentRequests() []RequestTrace {
+	return api.tracer.recent()
+}
+
+// trace records one attempt of req and logs it at debug level.
+func (api *Api) trace(req *http.Request, attempt int, start time.Time, resp *http.Response, err error) {
+	t := RequestTrace{
+		Method:   req.Method,
+		URL:      req.URL.String(),
+		Duration: time.Since(start),
+		Attempt:  attempt,
+		At:       start,
+	}
+	if resp != nil {
+		t.Status = resp.StatusCode
+	}
+	if err != nil {
+		t.Err = err.Error()
+	}
+	api.tracer.add(t)
+	api.stats.attempt(t.Duration, req.ContentLength, err != nil || t.Status >= 400)
+
+	zap.L().Debug("HTTP request",
+		zap.String("method", t.Method),
+		zap.String("url", t.URL),
+		zap.Int("status", t.Status),
+		zap.Duration("duration", t.Duration),
+		zap.Int("attempt", t.Attempt),
+		zap.String("error", t.Err))
+}
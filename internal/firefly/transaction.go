@@ -5,7 +5,9 @@ SPDX-License-Identifier: Apache-2.0
 package firefly
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 )
 
 type RequestTransaction struct {
@@ -17,54 +19,59 @@ type RequestTransaction struct {
 }
 
 type RequestTransactionSplit struct {
-	TransactionJournalID string   `json:"transaction_journal_id,omitempty"`
-	Type                 string   `json:"type,omitempty"`
-	Date                 string   `json:"date,omitempty"`
-	Amount               string   `json:"amount,omitempty"`
-	Description          string   `json:"description,omitempty"`
-	Order                int      `json:"order,omitempty"`
-	CurrencyID           string   `json:"currency_id,omitempty"`
-	CurrencyCode         string   `json:"currency_code,omitempty"`
-	ForeignAmount        string   `json:"foreign_amount,omitempty"`
-	ForeignCurrencyID    string   `json:"foreign_currency_id,omitempty"`
-	ForeignCurrencyCode  string   `json:"foreign_currency_code,omitempty"`
-	BudgetID             string   `json:"budget_id,omitempty"`
-	CategoryID           string   `json:"category_id,omitempty"`
-	CategoryName         string   `json:"category_name,omitempty"`
-	SourceID             string   `json:"source_id,omitempty"`
-	SourceName           string   `json:"source_name,omitempty"`
-	SourceIBAN           string   `json:"source_iban,omitempty"`
-	DestinationID        string   `json:"destination_id,omitempty"`
-	DestinationName      string   `json:"destination_name,omitempty"`
-	DestinationIBAN      string   `json:"destination_iban,omitempty"`
-	Reconciled           bool     `json:"reconciled,omitempty"`
-	BillID               string   `json:"bill_id,omitempty"`
-	BillName             string   `json:"bill_name,omitempty"`
-	Tags                 []string `json:"tags,omitempty"`
-	Notes                string   `json:"notes,omitempty"`
-	InternalReference    string   `json:"internal_reference,omitempty"`
-	ExternalID           string   `json:"external_id,omitempty"`
-	ExternalURL          string   `json:"external_url,omitempty"`
-	SepaCC               string   `json:"sepa_cc,omitempty"`
-	SepaCTOp             string   `json:"sepa_ct_op,omitempty"`
-	SepaCTID             string   `json:"sepa_ct_id,omitempty"`
-	SepaDB               string   `json:"sepa_db,omitempty"`
-	SepaCountry          string   `json:"sepa_country,omitempty"`
-	SepaEP               string   `json:"sepa_ep,omitempty"`
-	SepaCI               string   `json:"sepa_ci,omitempty"`
-	SepaBatchID          string   `json:"sepa_batch_id,omitempty"`
-	InterestDate         string   `json:"interest_date,omitempty"`
-	BookDate             string   `json:"book_date,omitempty"`
-	ProcessDate          string   `json:"process_date,omitempty"`
-	DueDate              string   `json:"due_date,omitempty"`
-	PaymentDate          string   `json:"payment_date,omitempty"`
-	InvoiceDate          string   `json:"invoice_date,omitempty"`
+	TransactionJournalID string `json:"transaction_journal_id,omitempty"`
+	Type                 string `json:"type,omitempty"`
+	Date                 string `json:"date,omitempty"`
+	Amount               string `json:"amount,omitempty"`
+	Description          string `json:"description,omitempty"`
+	Order                int    `json:"order,omitempty"`
+	CurrencyID           string `json:"currency_id,omitempty"`
+	CurrencyCode         string `json:"currency_code,omitempty"`
+	ForeignAmount        string `json:"foreign_amount,omitempty"`
+	ForeignCurrencyID    string `json:"foreign_currency_id,omitempty"`
+	ForeignCurrencyCode  string `json:"foreign_currency_code,omitempty"`
+	BudgetID             string `json:"budget_id,omitempty"`
+	CategoryID           string `json:"category_id,omitempty"`
+	CategoryName         string `json:"category_name,omitempty"`
+	SourceID             string `json:"source_id,omitempty"`
+	SourceName           string `json:"source_name,omitempty"`
+	SourceIBAN           string `json:"source_iban,omitempty"`
+	DestinationID        string `json:"destination_id,omitempty"`
+	DestinationName      string `json:"destination_name,omitempty"`
+	DestinationIBAN      string `json:"destination_iban,omitempty"`
+	Reconciled           bool   `json:"reconciled,omitempty"`
+	BillID               string `json:"bill_id,omitempty"`
+	BillName             string `json:"bill_name,omitempty"`
+	// Tags are left as they are when nil, an empty list removes them all
+	Tags              []string `json:"tags,omitzero"`
+	Notes             string   `json:"notes,omitempty"`
+	InternalReference string   `json:"internal_reference,omitempty"`
+	ExternalID        string   `json:"external_id,omitempty"`
+	ExternalURL       string   `json:"external_url,omitempty"`
+	SepaCC            string   `json:"sepa_cc,omitempty"`
+	SepaCTOp          string   `json:"sepa_ct_op,omitempty"`
+	SepaCTID          string   `json:"sepa_ct_id,omitempty"`
+	SepaDB            string   `json:"sepa_db,omitempty"`
+	SepaCountry       string   `json:"sepa_country,omitempty"`
+	SepaEP            string   `json:"sepa_ep,omitempty"`
+	SepaCI            string   `json:"sepa_ci,omitempty"`
+	SepaBatchID       string   `json:"sepa_batch_id,omitempty"`
+	InterestDate      string   `json:"interest_date,omitempty"`
+	BookDate          string   `json:"book_date,omitempty"`
+	ProcessDate       string   `json:"process_date,omitempty"`
+	DueDate           string   `json:"due_date,omitempty"`
+	PaymentDate       string   `json:"payment_date,omitempty"`
+	InvoiceDate       string   `json:"invoice_date,omitempty"`
+	Latitude          *float64 `json:"latitude,omitempty"`
+	Longitude         *float64 `json:"longitude,omitempty"`
+	ZoomLevel         *int     `json:"zoom_level,omitempty"`
 }
 
-func (api *Api) CreateTransaction(newTransaction RequestTransaction) (id string, err error) {
+func (api *Api) CreateTransaction(ctx context.Context, newTransaction RequestTransaction) (id string, err error) {
 	endpoint := fmt.Sprintf("%s/transactions", api.Config.ApiUrl)
 
-	response, err := api.postRequest(endpoint, newTransaction)
+	response, err := api.write(ctx, http.MethodPost, endpoint, newTransaction,
+		"create transaction "+newTransaction.describe())
 	if err != nil {
 		return "", err
 	}
@@ -80,10 +87,11 @@ func (api *Api) CreateTransaction(newTransaction RequestTransaction) (id string,
 	return id, nil
 }
 
-func (api *Api) UpdateTransaction(transactionId string, transaction RequestTransaction) (id string, err error) {
+func (api *Api) UpdateTransaction(ctx context.Context, transactionId string, transaction RequestTransaction) (id string, err error) {
 	endpoint := fmt.Sprintf("%s/transactions/%s", api.Config.ApiUrl, transactionId)
 
-	response, err := api.putRequest(endpoint, transaction)
+	response, err := api.write(ctx, http.MethodPut, endpoint, transaction,
+		"update transaction "+transaction.describe())
 	if err != nil {
 		return "", err
 	}
@@ -99,13 +107,24 @@ func (api *Api) UpdateTransaction(transactionId string, transaction RequestTrans
 	return id, nil
 }
 
-func (api *Api) DeleteTransaction(transactionId string) error {
+func (api *Api) DeleteTransaction(ctx context.Context, transactionId string) error {
 	endpoint := fmt.Sprintf("%s/transactions/%s", api.Config.ApiUrl, transactionId)
 
-	_, err := api.deleteRequest(endpoint)
+	_, err := api.write(ctx, http.MethodDelete, endpoint, nil, "delete transaction #"+transactionId)
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// describe returns a short description of the transaction for the queue.
+func (t RequestTransaction) describe() string {
+	if t.GroupTitle != "" {
+		return t.GroupTitle
+	}
+	if len(t.Transactions) > 0 {
+		return t.Transactions[0].Description
+	}
+	return ""
+}
@@ -5,8 +5,11 @@ SPDX-License-Identifier: Apache-2.0
 package firefly
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"slices"
+	"time"
 )
 
 type Transaction struct {
@@ -16,6 +19,9 @@ type Transaction struct {
 	Date          string
 	GroupTitle    string
 	Splits        []Split
+	// UpdatedAt is when the transaction was created or last changed, zero
+	// when unknown
+	UpdatedAt time.Time
 }
 
 type Split struct {
@@ -23,11 +29,20 @@ type Split struct {
 	Source               Account
 	Destination          Account
 	Category             Category
-	Currency             string
-	ForeignCurrency      string
-	Amount               float64
-	ForeignAmount        float64
-	Description          string
+	// Budget has only the ID and name, empty when the split has none
+	Budget          Budget
+	Currency        string
+	ForeignCurrency string
+	Amount          float64
+	ForeignAmount   float64
+	Description     string
+	Reconciled      bool
+	Tags            []string
+	Location        Location
+	// References used to match invoices and payments
+	InternalReference string
+	ExternalID        string
+	InvoiceDate       string // YYYY-MM-DD, empty when not set
 }
 
 type ResponseTransaction struct {
@@ -45,97 +60,150 @@ type ResponseTransactionAttributes struct {
 }
 
 type ResponseTransactionSplit struct {
-	User                         string  `json:"user"`
-	TransactionJournalID         string  `json:"transaction_journal_id"`
-	Type                         string  `json:"type"`
-	Date                         string  `json:"date"`
-	Order                        int     `json:"order"`
-	ObjectHasCurrencySetting     bool    `json:"object_has_currency_setting"`
-	CurrencyID                   string  `json:"currency_id"`
-	CurrencyCode                 string  `json:"currency_code"`
-	CurrencySymbol               string  `json:"currency_symbol"`
-	CurrencyName                 string  `json:"currency_name"`
-	CurrencyDecimalPlaces        int     `json:"currency_decimal_places"`
-	ForeignCurrencyID            string  `json:"foreign_currency_id"`
-	ForeignCurrencyCode          string  `json:"foreign_currency_code"`
-	ForeignCurrencySymbol        string  `json:"foreign_currency_symbol"`
-	ForeignCurrencyDecimalPlaces int     `json:"foreign_currency_decimal_places"`
-	PrimaryCurrencyID            string  `json:"primary_currency_id"`
-	PrimaryCurrencyCode          string  `json:"primary_currency_code"`
-	PrimaryCurrencySymbol        string  `json:"primary_currency_symbol"`
-	PrimaryCurrencyDecimalPlaces int     `json:"primary_currency_decimal_places"`
-	Amount                       float64 `json:"amount,string"`
-	PCAmount                     float64 `json:"pc_amount,string"`
-	ForeignAmount                float64 `json:"foreign_amount,string"`
-	PCForeignAmount              float64 `json:"pc_foreign_amount,string"`
-	SourceBalanceAfter           string  `json:"source_balance_after"`
-	PCSourceBalanceAfter         string  `json:"pc_source_balance_after"`
-	DestinationBalanceAfter      string  `json:"destination_balance_after"`
-	PCDestinationBalanceAfter    string  `json:"pc_destination_balance_after"`
-	Description                  string  `json:"description"`
-	SourceID                     string  `json:"source_id"`
-	SourceName                   string  `json:"source_name"`
-	SourceIBAN                   string  `json:"source_iban"`
-	SourceType                   string  `json:"source_type"`
-	DestinationID                string  `json:"destination_id"`
-	DestinationName              string  `json:"destination_name"`
-	DestinationIBAN              string  `json:"destination_iban"`
-	DestinationType              string  `json:"destination_type"`
-	BudgetID                     string  `json:"budget_id"`
-	BudgetName                   string  `json:"budget_name"`
-	CategoryID                   string  `json:"category_id"`
-	CategoryName                 string  `json:"category_name"`
-	BillID                       string  `json:"bill_id"`
-	BillName                     string  `json:"bill_name"`
-	SubscriptionID               string  `json:"subscription_id"`
-	SubscriptionName             string  `json:"subscription_name"`
-	Reconciled                   bool    `json:"reconciled"`
-	Notes                        string  `json:"notes"`
-	Tags                         any     `json:"tags"`
-	InternalReference            string  `json:"internal_reference"`
-	ExternalID                   string  `json:"external_id"`
-	ExternalURL                  string  `json:"external_url"`
-	OriginalSource               string  `json:"original_source"`
-	RecurrenceID                 string  `json:"recurrence_id"`
-	RecurrenceTotal              int     `json:"recurrence_total"`
-	RecurrenceCount              int     `json:"recurrence_count"`
-	ImportHashV2                 string  `json:"import_hash_v2"`
-	SepaCC                       string  `json:"sepa_cc"`
-	SepaCTOp                     string  `json:"sepa_ct_op"`
-	SepaCTID                     string  `json:"sepa_ct_id"`
-	SepaDB                       string  `json:"sepa_db"`
-	SepaCountry                  string  `json:"sepa_country"`
-	SepaEP                       string  `json:"sepa_ep"`
-	SepaCI                       string  `json:"sepa_ci"`
-	SepaBatchID                  string  `json:"sepa_batch_id"`
-	InterestDate                 string  `json:"interest_date"`
-	BookDate                     string  `json:"book_date"`
-	ProcessDate                  string  `json:"process_date"`
-	DueDate                      string  `json:"due_date"`
-	PaymentDate                  string  `json:"payment_date"`
-	InvoiceDate                  string  `json:"invoice_date"`
-	Latitude                     float64 `json:"latitude"`
-	Longitude                    float64 `json:"longitude"`
-	ZoomLevel                    int     `json:"zoom_level"`
-	HasAttachments               bool    `json:"has_attachments"`
+	User                         string   `json:"user"`
+	TransactionJournalID         string   `json:"transaction_journal_id"`
+	Type                         string   `json:"type"`
+	Date                         string   `json:"date"`
+	Order                        int      `json:"order"`
+	ObjectHasCurrencySetting     bool     `json:"object_has_currency_setting"`
+	CurrencyID                   string   `json:"currency_id"`
+	CurrencyCode                 string   `json:"currency_code"`
+	CurrencySymbol               string   `json:"currency_symbol"`
+	CurrencyName                 string   `json:"currency_name"`
+	CurrencyDecimalPlaces        int      `json:"currency_decimal_places"`
+	ForeignCurrencyID            string   `json:"foreign_currency_id"`
+	ForeignCurrencyCode          string   `json:"foreign_currency_code"`
+	ForeignCurrencySymbol        string   `json:"foreign_currency_symbol"`
+	ForeignCurrencyDecimalPlaces int      `json:"foreign_currency_decimal_places"`
+	PrimaryCurrencyID            string   `json:"primary_currency_id"`
+	PrimaryCurrencyCode          string   `json:"primary_currency_code"`
+	PrimaryCurrencySymbol        string   `json:"primary_currency_symbol"`
+	PrimaryCurrencyDecimalPlaces int      `json:"primary_currency_decimal_places"`
+	Amount                       float64  `json:"amount,string"`
+	PCAmount                     float64  `json:"pc_amount,string"`
+	ForeignAmount                float64  `json:"foreign_amount,string"`
+	PCForeignAmount              float64  `json:"pc_foreign_amount,string"`
+	SourceBalanceAfter           string   `json:"source_balance_after"`
+	PCSourceBalanceAfter         string   `json:"pc_source_balance_after"`
+	DestinationBalanceAfter      string   `json:"destination_balance_after"`
+	PCDestinationBalanceAfter    string   `json:"pc_destination_balance_after"`
+	Description                  string   `json:"description"`
+	SourceID                     string   `json:"source_id"`
+	SourceName                   string   `json:"source_name"`
+	SourceIBAN                   string   `json:"source_iban"`
+	SourceType                   string   `json:"source_type"`
+	DestinationID                string   `json:"destination_id"`
+	DestinationName              string   `json:"destination_name"`
+	DestinationIBAN              string   `json:"destination_iban"`
+	DestinationType              string   `json:"destination_type"`
+	BudgetID                     string   `json:"budget_id"`
+	BudgetName                   string   `json:"budget_name"`
+	CategoryID                   string   `json:"category_id"`
+	CategoryName                 string   `json:"category_name"`
+	BillID                       string   `json:"bill_id"`
+	BillName                     string   `json:"bill_name"`
+	SubscriptionID               string   `json:"subscription_id"`
+	SubscriptionName             string   `json:"subscription_name"`
+	Reconciled                   bool     `json:"reconciled"`
+	Notes                        string   `json:"notes"`
+	Tags                         []string `json:"tags"`
+	InternalReference            string   `json:"internal_reference"`
+	ExternalID                   string   `json:"external_id"`
+	ExternalURL                  string   `json:"external_url"`
+	OriginalSource               string   `json:"original_source"`
+	RecurrenceID                 string   `json:"recurrence_id"`
+	RecurrenceTotal              int      `json:"recurrence_total"`
+	RecurrenceCount              int      `json:"recurrence_count"`
+	ImportHashV2                 string   `json:"import_hash_v2"`
+	SepaCC                       string   `json:"sepa_cc"`
+	SepaCTOp                     string   `json:"sepa_ct_op"`
+	SepaCTID                     string   `json:"sepa_ct_id"`
+	SepaDB                       string   `json:"sepa_db"`
+	SepaCountry                  string   `json:"sepa_country"`
+	SepaEP                       string   `json:"sepa_ep"`
+	SepaCI                       string   `json:"sepa_ci"`
+	SepaBatchID                  string   `json:"sepa_batch_id"`
+	InterestDate                 string   `json:"interest_date"`
+	BookDate                     string   `json:"book_date"`
+	ProcessDate                  string   `json:"process_date"`
+	DueDate                      string   `json:"due_date"`
+	PaymentDate                  string   `json:"payment_date"`
+	InvoiceDate                  string   `json:"invoice_date"`
+	Latitude                     float64  `json:"latitude"`
+	Longitude                    float64  `json:"longitude"`
+	ZoomLevel                    int      `json:"zoom_level"`
+	HasAttachments               bool     `json:"has_attachments"`
 }
 
-func (api *Api) ListTransactions(query string) ([]Transaction, error) {
-	var allData []any
+// TransactionsPage is one page of transactions loaded by StreamTransactions.
+type TransactionsPage struct {
+	Transactions []Transaction
+	// Loaded is the number of transactions loaded so far, Total the number
+	// the server reported for the whole list
+	Loaded int
+	Total  int
+}
+
+func (api *Api) ListTransactions(ctx context.Context, query string) ([]Transaction, error) {
+	return api.StreamTransactions(ctx, query, nil)
+}
+
+// StreamTransactions lists transactions like ListTransactions, calling
+// onPage with the transactions of each page as it arrives. It returns all
+// transactions once the last page is loaded.
+func (api *Api) StreamTransactions(ctx context.Context, query string, onPage func(TransactionsPage)) ([]Transaction, error) {
+	transactions := []Transaction{}
+	convertPage := func(data []any, loaded, total int) error {
+		txs, err := unmarshalItems[ResponseTransaction](data)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal transactions: %v", err)
+		}
+		page := make([]Transaction, 0, len(txs))
+		for _, t := range txs {
+			page = append(page, api.fromResponse(ctx, t, uint(len(transactions)+len(page))))
+		}
+		transactions = append(transactions, page...)
+		if onPage != nil {
+			onPage(TransactionsPage{Transactions: page, Loaded: loaded, Total: total})
+		}
+		return nil
+	}
+
 	var err error
 	if query != "" {
-		allData, err = api.fetchPaginated("%s/search/transactions?&query=%s&page=%d",
+		_, err = api.fetchPages(ctx, convertPage, "%s/search/transactions?&query=%s&page=%d",
 			api.Config.ApiUrl,
 			query)
 	} else {
-		allData, err = api.fetchPaginated("%s/transactions?start=%s&end=%s&page=%d",
+		_, err = api.fetchPages(ctx, convertPage, "%s/transactions?start=%s&end=%s&page=%d",
 			api.Config.ApiUrl,
 			api.StartDate.Format("2006-01-02"),
 			api.EndDate.Format("2006-01-02"))
 	}
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch paginated transactions: %v", err)
+		if cached, ok := api.periodCache(); ok && query == "" && errors.Is(err, ErrOffline) {
+			return cached, nil
+		}
+		return nil, fmt.Errorf("failed to fetch paginated transactions: %w", err)
+	}
+
+	if query == "" {
+		api.rememberTransactions(transactions)
+	}
+	return transactions, nil
+}
+
+// AccountTransactions fetches the transactions of one account between
+// start and end, regardless of the selected period.
+func (api *Api) AccountTransactions(ctx context.Context, accountID string, start, end time.Time) ([]Transaction, error) {
+	allData, err := api.fetchPaginated(ctx, "%s/accounts/%s/transactions?start=%s&end=%s&page=%d",
+		api.Config.ApiUrl,
+		accountID,
+		start.Format("2006-01-02"),
+		end.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch paginated account transactions: %w", err)
 	}
 
 	txs, err := unmarshalItems[ResponseTransaction](allData)
@@ -144,51 +212,105 @@ func (api *Api) ListTransactions(query string) ([]Transaction, error) {
 	}
 
 	transactions := []Transaction{}
-	var id uint
-	for _, t := range txs {
-		var (
-			splits []Split
-			ttype  string
-			tdate  string
-		)
-		for _, subTx := range t.Attributes.Transactions {
-			if ttype == "" {
-				ttype = subTx.Type
-			}
-			if tdate == "" {
-				tdate = subTx.Date
-			}
-
-			source := api.GetAccountByID(subTx.SourceID)
-			destination := api.GetAccountByID(subTx.DestinationID)
-			category := api.GetCategoryByID(subTx.CategoryID)
-
-			splits = append(splits, Split{
-				Source:               source,
-				Destination:          destination,
-				Category:             category,
-				Currency:             subTx.CurrencyCode,
-				ForeignCurrency:      subTx.ForeignCurrencyCode,
-				Amount:               subTx.Amount,
-				ForeignAmount:        subTx.ForeignAmount,
-				Description:          subTx.Description,
-				TransactionJournalID: subTx.TransactionJournalID,
+	for id, t := range txs {
+		transactions = append(transactions, api.fromResponse(ctx, t, uint(id)))
+	}
+	return transactions, nil
+}
+
+// GetTransaction fetches a single transaction group by its ID, whatever
+// the period it falls in.
+func (api *Api) GetTransaction(ctx context.Context, transactionID string) (Transaction, error) {
+	endpoint := fmt.Sprintf("%s/transactions/%s", api.Config.ApiUrl, transactionID)
+
+	resp, err := api.getRequest(ctx, endpoint)
+	if err != nil {
+		return Transaction{}, fmt.Errorf("failed to get transaction #%s: %w", transactionID, err)
+	}
+
+	items, err := unmarshalItems[ResponseTransaction]([]any{resp.Data})
+	if err != nil {
+		return Transaction{}, fmt.Errorf("failed to unmarshal transaction: %w", err)
+	}
+
+	return api.fromResponse(ctx, items[0], 0), nil
+}
+
+// fromResponse converts a transaction group of the API, resolving its
+// accounts and category.
+func (api *Api) fromResponse(ctx context.Context, t ResponseTransaction, id uint) Transaction {
+	var (
+		splits []Split
+		ttype  string
+		tdate  string
+	)
+	for _, subTx := range t.Attributes.Transactions {
+		if ttype == "" {
+			ttype = subTx.Type
+		}
+		if tdate == "" {
+			tdate = subTx.Date
+		}
+
+		source := api.accountByID(ctx, subTx.SourceID)
+		destination := api.accountByID(ctx, subTx.DestinationID)
+		category := api.GetCategoryByID(subTx.CategoryID)
+
+		splits = append(splits, Split{
+			Source:               source,
+			Destination:          destination,
+			Category:             category,
+			Budget:               Budget{ID: subTx.BudgetID, Name: subTx.BudgetName},
+			Currency:             subTx.CurrencyCode,
+			ForeignCurrency:      subTx.ForeignCurrencyCode,
+			Amount:               subTx.Amount,
+			ForeignAmount:        subTx.ForeignAmount,
+			Description:          subTx.Description,
+			TransactionJournalID: subTx.TransactionJournalID,
+			Reconciled:           subTx.Reconciled,
+			Tags:                 subTx.Tags,
+			Location: Location{
+				Latitude:  subTx.Latitude,
+				Longitude: subTx.Longitude,
+				ZoomLevel: subTx.ZoomLevel,
 			},
-			)
+			InternalReference: subTx.InternalReference,
+			ExternalID:        subTx.ExternalID,
+			InvoiceDate:       dateOnly(subTx.InvoiceDate),
+		},
+		)
+	}
+
+	slices.Reverse(splits)
+	return Transaction{
+		ID:            id,
+		TransactionID: t.ID,
+		Type:          ttype,
+		Date:          tdate,
+		Splits:        splits,
+		GroupTitle:    t.Attributes.GroupTitle,
+		UpdatedAt:     updatedAt(t.Attributes),
+	}
+}
+
+// updatedAt returns when the transaction was last changed, the creation
+// time when it never was.
+func updatedAt(attrs ResponseTransactionAttributes) time.Time {
+	for _, at := range []string{attrs.UpdatedAt, attrs.CreatedAt} {
+		if t, err := time.Parse(time.RFC3339, at); err == nil {
+			return t
 		}
+	}
+	return time.Time{}
+}
 
-		slices.Reverse(splits)
-		transactions = append(transactions, Transaction{
-			ID:            id,
-			TransactionID: t.ID,
-			Type:          ttype,
-			Date:          tdate,
-			Splits:        splits,
-			GroupTitle:    t.Attributes.GroupTitle,
-		})
-		id++
+// dateOnly returns the day of a date sent by the server, e.g.
+// "2026-01-15T00:00:00+01:00" becomes "2026-01-15".
+func dateOnly(date string) string {
+	if len(date) < 10 {
+		return date
 	}
-	return transactions, nil
+	return date[:10]
 }
 
 func (t *Transaction) Amount() float64 {
@@ -220,6 +342,17 @@ func (t *Transaction) Description() string {
 	return ""
 }
 
+// Reconciled reports whether all splits were matched against a bank
+// statement.
+func (t *Transaction) Reconciled() bool {
+	for _, split := range t.Splits {
+		if !split.Reconciled {
+			return false
+		}
+	}
+	return len(t.Splits) > 0
+}
+
 func (t *Transaction) Source() Account {
 	l := len(t.Splits)
 	if t.Type == "withdrawal" || t.Type == "transfer" {
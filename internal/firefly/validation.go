@@ -0,0 +1,81 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package firefly
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// duplicatePattern matches how Firefly III rejects a duplicate transaction.
+var duplicatePattern = regexp.MustCompile(`Duplicate of transaction #(\d+)`)
+
+// ValidationError is how Firefly III rejects invalid data, with 422 and
+// the messages per field, e.g. "transactions.0.amount".
+type ValidationError struct {
+	Message string
+	Fields  map[string][]string
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Fields) == 0 {
+		return e.Message
+	}
+	parts := []string{}
+	for _, field := range slices.Sorted(maps.Keys(e.Fields)) {
+		parts = append(parts, fmt.Sprintf("%s: %s", field, strings.Join(e.Fields[field], " ")))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Duplicate returns the ID of the transaction a rejected one duplicates,
+// reported when it was sent with ErrorIfDuplicateHash.
+func (e *ValidationError) Duplicate() (string, bool) {
+	messages := []string{e.Message}
+	for _, field := range e.Fields {
+		messages = append(messages, field...)
+	}
+	for _, message := range messages {
+		if match := duplicatePattern.FindStringSubmatch(message); match != nil {
+			return match[1], true
+		}
+	}
+	return "", false
+}
+
+// SplitField splits the field of a transaction split, e.g.
+// "transactions.1.amount", into the split index and the field name.
+// ok is false for fields of the whole transaction, e.g. "group_title".
+func SplitField(field string) (index int, name string, ok bool) {
+	rest, found := strings.CutPrefix(field, "transactions.")
+	if !found {
+		return 0, field, false
+	}
+	i, name, found := strings.Cut(rest, ".")
+	if !found {
+		return 0, field, false
+	}
+	if _, err := fmt.Sscan(i, &index); err != nil {
+		return 0, field, false
+	}
+	return index, name, true
+}
+
+// parseValidationError reads the validation errors of a 422 response, nil
+// when body has none.
+func parseValidationError(body []byte) *ValidationError {
+	var payload struct {
+		Message string              `json:"message"`
+		Errors  map[string][]string `json:"errors"`
+	}
+	if json.Unmarshal(body, &payload) != nil || (payload.Message == "" && len(payload.Errors) == 0) {
+		return nil
+	}
+	return &ValidationError{Message: payload.Message, Fields: payload.Errors}
+}
@@ -0,0 +1,261 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package firefly
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha3"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// webhookTriggers are the changes the live updates subscribe to.
+var webhookTriggers = []string{"STORE_TRANSACTION", "UPDATE_TRANSACTION", "DESTROY_TRANSACTION"}
+
+// webhookTitle names the webhooks registered by ffiii-tui in Firefly III.
+const webhookTitle = "ffiii-tui live updates"
+
+// maxWebhookBody limits the size of a webhook call read by the listener.
+const maxWebhookBody = 1 << 20
+
+// webhookTolerance is how far the signed timestamp of a webhook call may be
+// from now, older calls are rejected as replays.
+const webhookTolerance = 5 * time.Minute
+
+// WebhookEvent is a change reported by Firefly III through a webhook.
+type WebhookEvent struct {
+	Trigger string // e.g. STORE_TRANSACTION
+}
+
+// WebhookListener receives the calls of the webhooks registered in Firefly
+// III and reports them as events. Calls not signed with the secret of a
+// registered webhook are rejected. One listener serves all profiles.
+type WebhookListener struct {
+	addr   string
+	server *http.Server
+	events chan WebhookEvent
+
+	mu      sync.Mutex
+	secrets map[string]bool
+}
+
+type apiWebhook struct {
+	ID         string         `json:"id"`
+	Attributes apiWebhookAttr `json:"attributes"`
+}
+
+type apiWebhookAttr struct {
+	Title    string `json:"title"`
+	URL      string `json:"url"`
+	Trigger  string `json:"trigger"`
+	Response string `json:"response"`
+	Delivery string `json:"delivery"`
+	Active   bool   `json:"active"`
+	Secret   string `json:"secret,omitempty"`
+}
+
+// NewWebhookListener creates a listener for addr, e.g. ":8091".
+func NewWebhookListener(addr string) *WebhookListener {
+	return &WebhookListener{
+		addr:    addr,
+		events:  make(chan WebhookEvent, 16),
+		secrets: map[string]bool{},
+	}
+}
+
+// Start listens for webhook calls in the background.
+func (l *WebhookListener) Start() error {
+	ln, err := net.Listen("tcp", l.addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen for webhooks: %w", err)
+	}
+	l.server = &http.Server{Handler: l}
+	go func() {
+		if err := l.server.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			zap.L().Warn("Webhook listener stopped", zap.Error(err))
+		}
+	}()
+	zap.L().Info("Listening for webhooks", zap.String("addr", ln.Addr().String()))
+	return nil
+}
+
+// Close stops the listener.
+func (l *WebhookListener) Close() error {
+	if l.server == nil {
+		return nil
+	}
+	return l.server.Close()
+}
+
+// Events returns the changes reported by the webhooks. Events are dropped
+// while the channel is full, a pending one already triggers a refresh.
+func (l *WebhookListener) Events() <-chan WebhookEvent {
+	return l.events
+}
+
+func (l *WebhookListener) accept(secret string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.secrets[secret] = true
+}
+
+func (l *WebhookListener) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBody))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if !l.signed(r.Header.Get("Signature"), body) {
+		zap.L().Warn("Rejected webhook call with invalid signature",
+			zap.String("remote", r.RemoteAddr))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var payload struct {
+		Trigger string `json:"trigger"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	zap.L().Debug("Received webhook", zap.String("trigger", payload.Trigger))
+	select {
+	case l.events <- WebhookEvent{Trigger: payload.Trigger}:
+	default:
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// signed reports whether the Signature header, "t=<timestamp>,v1=<hmac>",
+// matches body for one of the accepted secrets and was made within
+// webhookTolerance. Firefly III signs "<timestamp>.<body>" with
+// HMAC-SHA3-256.
+func (l *WebhookListener) signed(header string, body []byte) bool {
+	var timestamp, signature string
+	for part := range strings.SplitSeq(header, ",") {
+		k, v, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch k {
+		case "t":
+			timestamp = v
+		case "v1":
+			signature = v
+		}
+	}
+	want, err := hex.DecodeString(signature)
+	if timestamp == "" || err != nil {
+		return false
+	}
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || time.Since(time.Unix(unix, 0)).Abs() > webhookTolerance {
+		return false
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for secret := range l.secrets {
+		if hmac.Equal(webhookSignature(secret, timestamp, body), want) {
+			return true
+		}
+	}
+	return false
+}
+
+func webhookSignature(secret, timestamp string, body []byte) []byte {
+	mac := hmac.New(func() hash.Hash { return sha3.New256() }, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// ListenWebhooks reports the calls received by l as live updates.
+func (api *Api) ListenWebhooks(l *WebhookListener) {
+	api.live = l
+}
+
+// RegisterWebhooks registers url in Firefly III as webhook for changes of
+// transactions, reusing the webhooks registered before, and lets the
+// listener accept their calls. The webhooks stay registered when the
+// program exits.
+func (api *Api) RegisterWebhooks(ctx context.Context, url string) error {
+	l := api.live
+	if l == nil {
+		return errors.New("no webhook listener")
+	}
+	allData, err := api.fetchPaginated(ctx, "%s/webhooks?page=%d", api.Config.ApiUrl)
+	if err != nil {
+		return fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	existing, err := unmarshalItems[apiWebhook](allData)
+	if err != nil {
+		return fmt.Errorf("failed to parse webhooks: %w", err)
+	}
+
+	for _, trigger := range webhookTriggers {
+		i := slices.IndexFunc(existing, func(w apiWebhook) bool {
+			return w.Attributes.URL == url && w.Attributes.Trigger == trigger && w.Attributes.Active
+		})
+		if i >= 0 {
+			l.accept(existing[i].Attributes.Secret)
+			continue
+		}
+		secret, err := api.createWebhook(ctx, url, trigger)
+		if err != nil {
+			return err
+		}
+		l.accept(secret)
+	}
+	return nil
+}
+
+// createWebhook registers a webhook and returns its secret.
+func (api *Api) createWebhook(ctx context.Context, url, trigger string) (string, error) {
+	endpoint := fmt.Sprintf("%s/webhooks", api.Config.ApiUrl)
+	payload := apiWebhookAttr{
+		Title:    webhookTitle,
+		URL:      url,
+		Trigger:  trigger,
+		Response: "RESPONSE_TRANSACTIONS",
+		Delivery: "DELIVERY_JSON",
+		Active:   true,
+	}
+	response, err := api.send(ctx, http.MethodPost, endpoint, payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to register webhook %s: %w", trigger, err)
+	}
+	items, err := unmarshalItems[apiWebhook]([]any{response.Data})
+	if err != nil || len(items) == 0 || items[0].Attributes.Secret == "" {
+		return "", fmt.Errorf("invalid response format: missing webhook secret")
+	}
+	zap.L().Info("Registered webhook", zap.String("trigger", trigger), zap.String("url", url))
+	return items[0].Attributes.Secret, nil
+}
+
+// LiveUpdates returns the changes reported by the registered webhooks, nil
+// when live updates are off.
+func (api *Api) LiveUpdates() <-chan WebhookEvent {
+	if api.live == nil {
+		return nil
+	}
+	return api.live.Events()
+}
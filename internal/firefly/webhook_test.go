@@ -0,0 +1,166 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package firefly
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// signedCall returns a webhook call for trigger signed with secret at t.
+func signedCall(secret, trigger string, t time.Time) *http.Request {
+	body := []byte(fmt.Sprintf(`{"trigger":%q}`, trigger))
+	timestamp := strconv.FormatInt(t.Unix(), 10)
+	sig := hex.EncodeToString(webhookSignature(secret, timestamp, body))
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(string(body)))
+	r.Header.Set("Signature", "t="+timestamp+",v1="+sig)
+	return r
+}
+
+func serveWebhook(l *WebhookListener, r *http.Request) int {
+	w := httptest.NewRecorder()
+	l.ServeHTTP(w, r)
+	return w.Code
+}
+
+func TestWebhookListener_AcceptsSignedCall(t *testing.T) {
+	l := NewWebhookListener("")
+	l.accept("secret")
+
+	if code := serveWebhook(l, signedCall("secret", "STORE_TRANSACTION", time.Now())); code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", code)
+	}
+	select {
+	case e := <-l.Events():
+		if e.Trigger != "STORE_TRANSACTION" {
+			t.Errorf("Expected STORE_TRANSACTION, got %q", e.Trigger)
+		}
+	default:
+		t.Error("Expected an event")
+	}
+}
+
+func TestWebhookListener_RejectsUnsignedCalls(t *testing.T) {
+	l := NewWebhookListener("")
+	l.accept("secret")
+
+	bad := signedCall("other", "STORE_TRANSACTION", time.Now())
+	missing := signedCall("secret", "STORE_TRANSACTION", time.Now())
+	missing.Header.Del("Signature")
+	malformed := signedCall("secret", "STORE_TRANSACTION", time.Now())
+	malformed.Header.Set("Signature", "t=123,v1=not-hex")
+	stale := signedCall("secret", "STORE_TRANSACTION", time.Now().Add(-time.Hour))
+	future := signedCall("secret", "STORE_TRANSACTION", time.Now().Add(time.Hour))
+	tampered := signedCall("secret", "STORE_TRANSACTION", time.Now())
+	tampered.Body = http.NoBody
+
+	tests := map[string]*http.Request{
+		"bad signature":     bad,
+		"missing signature": missing,
+		"malformed":         malformed,
+		"stale":             stale,
+		"future":            future,
+		"tampered body":     tampered,
+	}
+	for name, r := range tests {
+		if code := serveWebhook(l, r); code != http.StatusUnauthorized {
+			t.Errorf("%s: expected 401, got %d", name, code)
+		}
+	}
+	select {
+	case e := <-l.Events():
+		t.Errorf("Expected no event, got %+v", e)
+	default:
+	}
+}
+
+func TestWebhookListener_RejectsOtherMethods(t *testing.T) {
+	l := NewWebhookListener("")
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	if code := serveWebhook(l, r); code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405, got %d", code)
+	}
+}
+
+func TestWebhookListener_DropsEventsWhileFull(t *testing.T) {
+	l := NewWebhookListener("")
+	l.accept("secret")
+
+	for i := range cap(l.events) + 5 {
+		if code := serveWebhook(l, signedCall("secret", "UPDATE_TRANSACTION", time.Now())); code != http.StatusOK {
+			t.Fatalf("call %d: expected 200, got %d", i, code)
+		}
+	}
+	if got := len(l.Events()); got != cap(l.events) {
+		t.Errorf("Expected %d pending events, got %d", cap(l.events), got)
+	}
+}
+
+func TestRegisterWebhooks_AcceptsEveryTrigger(t *testing.T) {
+	const url = "http://tui:8091/"
+	var created []string
+	api := newTestApi(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeData(w, `[{"id":"1","attributes":{"url":"`+url+`","trigger":"STORE_TRANSACTION","active":true,"secret":"kept"}}]`)
+		case http.MethodPost:
+			var attr apiWebhookAttr
+			_ = json.NewDecoder(r.Body).Decode(&attr)
+			created = append(created, attr.Trigger)
+			writeData(w, `{"id":"2","attributes":{"secret":"new-`+attr.Trigger+`"}}`)
+		}
+	})
+	l := NewWebhookListener("")
+	api.ListenWebhooks(l)
+
+	if err := api.RegisterWebhooks(context.Background(), url); err != nil {
+		t.Fatalf("RegisterWebhooks failed: %v", err)
+	}
+	if len(created) != 2 || created[0] != "UPDATE_TRANSACTION" || created[1] != "DESTROY_TRANSACTION" {
+		t.Errorf("Expected the missing webhooks to be created, got %v", created)
+	}
+
+	calls := map[string]string{
+		"STORE_TRANSACTION":   "kept",
+		"UPDATE_TRANSACTION":  "new-UPDATE_TRANSACTION",
+		"DESTROY_TRANSACTION": "new-DESTROY_TRANSACTION",
+	}
+	for trigger, secret := range calls {
+		if code := serveWebhook(l, signedCall(secret, trigger, time.Now())); code != http.StatusOK {
+			t.Errorf("%s: expected 200, got %d", trigger, code)
+		}
+	}
+	got := map[string]bool{}
+	for range calls {
+		select {
+		case e := <-api.LiveUpdates():
+			got[e.Trigger] = true
+		default:
+		}
+	}
+	for trigger := range calls {
+		if !got[trigger] {
+			t.Errorf("Expected an event for %s", trigger)
+		}
+	}
+}
+
+func TestLiveUpdates_NilWithoutListener(t *testing.T) {
+	api := &Api{}
+	if api.LiveUpdates() != nil {
+		t.Error("Expected no live updates without a listener")
+	}
+	if err := api.RegisterWebhooks(context.Background(), "http://tui/"); err == nil {
+		t.Error("Expected an error without a listener")
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package hooks runs the shell commands configured for events, e.g. to
+// send desktop notifications or sync changes to another system.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Timeout stops hooks that do not finish in time.
+const Timeout = 30 * time.Second
+
+// Events a hook can be configured for, as hooks.on_<event>.
+const (
+	TransactionCreated = "transaction_created"
+	TransactionUpdated = "transaction_updated"
+	TransactionDeleted = "transaction_deleted"
+	PeriodChanged      = "period_changed"
+)
+
+// Event is the JSON payload a hook receives on stdin.
+type Event struct {
+	Name    string    `json:"event"`
+	Profile string    `json:"profile"`
+	Time    time.Time `json:"time"`
+	Data    any       `json:"data"`
+}
+
+// Command returns the shell command configured for the event, empty when
+// there is none.
+func Command(event string) string {
+	return viper.GetString("hooks.on_" + event)
+}
+
+// Run runs the hook of the event with the event on stdin and waits for it.
+// It does nothing when no hook is configured.
+func Run(ctx context.Context, e Event) error {
+	command := Command(e.Name)
+	if command == "" {
+		return nil
+	}
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", e.Name, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, Timeout)
+	defer cancel()
+	name, args := shell(command)
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdin = bytes.NewReader(payload)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("hook on_%s failed: %w: %s", e.Name, err, msg)
+		}
+		return fmt.Errorf("hook on_%s failed: %w", e.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+//go:build !windows
+
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func setHook(t *testing.T, event, command string) {
+	t.Helper()
+	viper.Set("hooks.on_"+event, command)
+	t.Cleanup(func() { viper.Set("hooks.on_"+event, "") })
+}
+
+func TestRun_PayloadOnStdin(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "payload.json")
+	setHook(t, PeriodChanged, "cat > "+out)
+
+	at := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	err := Run(context.Background(), Event{
+		Name:    PeriodChanged,
+		Profile: "work",
+		Time:    at,
+		Data:    map[string]string{"start": "2026-03-01"},
+	})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("hook did not write the payload: %v", err)
+	}
+	var got struct {
+		Event   string            `json:"event"`
+		Profile string            `json:"profile"`
+		Data    map[string]string `json:"data"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("invalid payload %q: %v", data, err)
+	}
+	if got.Event != PeriodChanged || got.Profile != "work" || got.Data["start"] != "2026-03-01" {
+		t.Errorf("unexpected payload %+v", got)
+	}
+}
+
+func TestRun_NoHook(t *testing.T) {
+	if err := Run(context.Background(), Event{Name: TransactionCreated}); err != nil {
+		t.Errorf("expected no error without a hook, got %v", err)
+	}
+}
+
+func TestRun_Failure(t *testing.T) {
+	setHook(t, TransactionDeleted, "echo broken >&2; exit 3")
+
+	err := Run(context.Background(), Event{Name: TransactionDeleted})
+	if err == nil || !strings.Contains(err.Error(), "on_transaction_deleted") || !strings.Contains(err.Error(), "broken") {
+		t.Errorf("expected the failure with stderr, got %v", err)
+	}
+}
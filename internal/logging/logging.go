@@ -12,6 +12,7 @@ import (
 // New creates a new zap logger with the specified configuration.
 // If debug is true, uses development config with debug level logging.
 // outputPaths specifies where to write logs; if empty, uses default outputs.
+// Logs written to outputPaths are JSON encoded.
 // Returns the logger, a cleanup function, and any error encountered.
 func New(debug bool, outputPaths ...string) (*zap.Logger, func(), error) {
 	var config zap.Config
@@ -27,6 +28,8 @@ func New(debug bool, outputPaths ...string) (*zap.Logger, func(), error) {
 	if len(outputPaths) > 0 {
 		config.OutputPaths = outputPaths
 		config.ErrorOutputPaths = outputPaths
+		// One JSON object per line, so log files can be filtered with jq
+		config.Encoding = "json"
 	}
 
 	logger, err := config.Build()
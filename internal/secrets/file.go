@@ -0,0 +1,228 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// PassphraseEnv, when set, is used to derive the key of the encrypted file.
+// Otherwise a random key is generated and kept next to the file, which only
+// hides the secrets from a casual look: anyone who can read the file can
+// read the key too.
+const PassphraseEnv = "FFIII_TUI_SECRETS_PASSPHRASE"
+
+const (
+	secretsFileName = "secrets.enc"
+	keyFileName     = "secrets.key"
+	saltSize        = 16
+	keySize         = 32
+	kdfIterations   = 600_000
+)
+
+// sealer encrypts the secrets file contents.
+type sealer interface {
+	seal(plain []byte) ([]byte, error)
+	open(sealed []byte) ([]byte, error)
+}
+
+// fileStore keeps all secrets in a single encrypted JSON file.
+type fileStore struct {
+	mu     sync.Mutex
+	path   string
+	name   string
+	sealer sealer
+}
+
+func newFileStore(dir string) (*fileStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create secrets directory: %w", err)
+	}
+	passphrase := os.Getenv(PassphraseEnv)
+	name := "encrypted file"
+	if passphrase == "" {
+		name = "obfuscated file, key stored alongside"
+	}
+	return &fileStore{
+		path:   filepath.Join(dir, secretsFileName),
+		name:   name,
+		sealer: &aesSealer{passphrase: passphrase, keyPath: filepath.Join(dir, keyFileName)},
+	}, nil
+}
+
+func (s *fileStore) Name() string {
+	return s.name
+}
+
+func (s *fileStore) Get(account string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.load()
+	if err != nil {
+		return "", err
+	}
+	secret, ok := all[account]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return secret, nil
+}
+
+func (s *fileStore) Set(account, secret string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.load()
+	if err != nil {
+		return err
+	}
+	all[account] = secret
+	return s.save(all)
+}
+
+func (s *fileStore) Delete(account string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := all[account]; !ok {
+		return ErrNotFound
+	}
+	delete(all, account)
+	return s.save(all)
+}
+
+func (s *fileStore) load() (map[string]string, error) {
+	all := map[string]string{}
+
+	sealed, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return all, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secrets file: %w", err)
+	}
+
+	plain, err := s.sealer.open(sealed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt secrets file: %w", err)
+	}
+	if err := json.Unmarshal(plain, &all); err != nil {
+		return nil, fmt.Errorf("failed to parse secrets file: %w", err)
+	}
+	return all, nil
+}
+
+func (s *fileStore) save(all map[string]string) error {
+	plain, err := json.Marshal(all)
+	if err != nil {
+		return fmt.Errorf("failed to encode secrets: %w", err)
+	}
+	sealed, err := s.sealer.seal(plain)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt secrets: %w", err)
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, sealed, 0o600); err != nil {
+		return fmt.Errorf("failed to write secrets file: %w", err)
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// aesSealer encrypts with AES-GCM. The file layout is salt | nonce | data.
+type aesSealer struct {
+	passphrase string
+	keyPath    string
+}
+
+func (a *aesSealer) seal(plain []byte) ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	gcm, err := a.gcm(salt, true)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	out := append(salt, nonce...)
+	return gcm.Seal(out, nonce, plain, nil), nil
+}
+
+func (a *aesSealer) open(sealed []byte) ([]byte, error) {
+	if len(sealed) < saltSize {
+		return nil, errors.New("secrets file is truncated")
+	}
+	salt, rest := sealed[:saltSize], sealed[saltSize:]
+
+	gcm, err := a.gcm(salt, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) < gcm.NonceSize() {
+		return nil, errors.New("secrets file is truncated")
+	}
+	nonce, data := rest[:gcm.NonceSize()], rest[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, data, nil)
+}
+
+func (a *aesSealer) gcm(salt []byte, create bool) (cipher.AEAD, error) {
+	passphrase := a.passphrase
+	if passphrase == "" {
+		key, err := a.loadKey(create)
+		if err != nil {
+			return nil, err
+		}
+		passphrase = string(key)
+	}
+
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, kdfIterations, keySize)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// loadKey reads the random key file, creating it when encrypting for the
+// first time.
+func (a *aesSealer) loadKey(create bool) ([]byte, error) {
+	key, err := os.ReadFile(a.keyPath)
+	if err == nil {
+		return key, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) || !create {
+		return nil, fmt.Errorf("failed to read secrets key: %w", err)
+	}
+
+	key = []byte(rand.Text())
+	if err := os.WriteFile(a.keyPath, key, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write secrets key: %w", err)
+	}
+	return key, nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package secrets
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileStore_SetGetDelete(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(PassphraseEnv, "")
+
+	store, err := newFileStore(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := store.Get("profile:default"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for empty store, got %v", err)
+	}
+
+	if err := store.Set("profile:default", "secret-token"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	got, err := store.Get("profile:default")
+	if err != nil || got != "secret-token" {
+		t.Errorf("Expected stored token, got %q, %v", got, err)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(dir, secretsFileName))
+	if err != nil {
+		t.Fatalf("Expected secrets file: %v", err)
+	}
+	if bytes.Contains(raw, []byte("secret-token")) {
+		t.Error("Expected token to be encrypted on disk")
+	}
+	info, err := os.Stat(filepath.Join(dir, keyFileName))
+	if err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("Expected key file with 0600 permissions, got %v, %v", info, err)
+	}
+
+	if err := store.Delete("profile:default"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := store.Get("profile:default"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestFileStore_Passphrase(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(PassphraseEnv, "correct horse")
+
+	store, err := newFileStore(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := store.Set("profile:work", "work-token"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, keyFileName)); !errors.Is(err, os.ErrNotExist) {
+		t.Error("Expected no key file when a passphrase is set")
+	}
+
+	t.Setenv(PassphraseEnv, "wrong")
+	other, err := newFileStore(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := other.Get("profile:work"); err == nil {
+		t.Error("Expected decryption to fail with a wrong passphrase")
+	}
+}
+
+func TestNew_Storage(t *testing.T) {
+	dir := t.TempDir()
+
+	store, err := New(StorageConfig, dir)
+	if err != nil || store != nil {
+		t.Errorf("Expected nil store for config storage, got %v, %v", store, err)
+	}
+
+	store, err = New(StorageFile, dir)
+	if _, ok := store.(*fileStore); err != nil || !ok {
+		t.Errorf("Expected file store, got %v, %v", store, err)
+	}
+
+	if _, err := New("vault", dir); err == nil {
+		t.Error("Expected error for unknown storage")
+	}
+}
+
+func TestNewFileStore_NameTellsWhetherEncrypted(t *testing.T) {
+	t.Setenv(PassphraseEnv, "")
+	store, err := newFileStore(t.TempDir())
+	if err != nil || store.Name() == "encrypted file" {
+		t.Errorf("Expected a file with its key alongside not called encrypted, got %q, %v", store.Name(), err)
+	}
+
+	t.Setenv(PassphraseEnv, "correct horse battery staple")
+	store, err = newFileStore(t.TempDir())
+	if err != nil || store.Name() != "encrypted file" {
+		t.Errorf("Expected an encrypted file with a passphrase, got %q, %v", store.Name(), err)
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package secrets
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// errItemNotFound is the exit code of security(1) for a missing item.
+const errItemNotFound = 44
+
+// keychainStore uses the macOS login keychain through security(1).
+type keychainStore struct {
+	tool string
+}
+
+func newKeyring(_ string) (Store, error) {
+	tool, err := exec.LookPath("security")
+	if err != nil {
+		return nil, ErrUnavailable
+	}
+	return &keychainStore{tool: tool}, nil
+}
+
+func (s *keychainStore) Name() string {
+	return "Keychain"
+}
+
+func (s *keychainStore) Get(account string) (string, error) {
+	out, err := s.run("find-generic-password", "-s", Service, "-a", account, "-w")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(out, "\n"), nil
+}
+
+// Set passes the command on the standard input of security -i, so the
+// secret never shows up in the argument list other users can read with ps.
+func (s *keychainStore) Set(account, secret string) error {
+	if strings.ContainsAny(secret, "\r\n") {
+		return errors.New("secret must be a single line")
+	}
+	var stderr bytes.Buffer
+	cmd := exec.Command(s.tool, "-i")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -U -s %s -a %s -w %s\n",
+		quote(Service), quote(account), quote(secret)))
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil || stderr.Len() > 0 {
+		// security -i reports failed commands on stderr, exiting with 0
+		if err == nil {
+			err = errors.New("command failed")
+		}
+		return fmt.Errorf("security add-generic-password failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// quote quotes an argument for the command line of security -i.
+func quote(arg string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(arg) + `"`
+}
+
+func (s *keychainStore) Delete(account string) error {
+	_, err := s.run("delete-generic-password", "-s", Service, "-a", account)
+	return err
+}
+
+func (s *keychainStore) run(args ...string) (string, error) {
+	var out, stderr bytes.Buffer
+	cmd := exec.Command(s.tool, args...)
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == errItemNotFound {
+			return "", ErrNotFound
+		}
+		return "", fmt.Errorf("security %s failed: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return out.String(), nil
+}
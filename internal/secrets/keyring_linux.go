@@ -0,0 +1,72 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package secrets
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// secretServiceStore uses the freedesktop Secret Service through secret-tool.
+type secretServiceStore struct {
+	tool string
+}
+
+func newKeyring(_ string) (Store, error) {
+	if os.Getenv("DBUS_SESSION_BUS_ADDRESS") == "" {
+		return nil, ErrUnavailable
+	}
+	tool, err := exec.LookPath("secret-tool")
+	if err != nil {
+		return nil, ErrUnavailable
+	}
+	return &secretServiceStore{tool: tool}, nil
+}
+
+func (s *secretServiceStore) Name() string {
+	return "Secret Service"
+}
+
+func (s *secretServiceStore) Get(account string) (string, error) {
+	var out, stderr bytes.Buffer
+	cmd := exec.Command(s.tool, "lookup", "service", Service, "account", account)
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && stderr.Len() == 0 {
+			return "", ErrNotFound
+		}
+		return "", fmt.Errorf("secret-tool lookup failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSuffix(out.String(), "\n"), nil
+}
+
+func (s *secretServiceStore) Set(account, secret string) error {
+	var stderr bytes.Buffer
+	cmd := exec.Command(s.tool, "store",
+		"--label", fmt.Sprintf("%s (%s)", Service, account),
+		"service", Service, "account", account)
+	cmd.Stdin = strings.NewReader(secret)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("secret-tool store failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+func (s *secretServiceStore) Delete(account string) error {
+	var stderr bytes.Buffer
+	cmd := exec.Command(s.tool, "clear", "service", Service, "account", account)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("secret-tool clear failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
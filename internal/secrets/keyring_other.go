@@ -0,0 +1,12 @@
+//go:build !linux && !darwin && !windows
+
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secrets
+
+func newKeyring(_ string) (Store, error) {
+	return nil, ErrUnavailable
+}
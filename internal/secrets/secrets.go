@@ -0,0 +1,55 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package secrets
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Service is the name secrets are stored under in the OS keyring.
+const Service = "ffiii-tui"
+
+// Storage backends selectable with secrets.storage in config.
+const (
+	StorageAuto    = "auto"
+	StorageKeyring = "keyring"
+	StorageFile    = "file"
+	StorageConfig  = "config"
+)
+
+var (
+	ErrNotFound    = errors.New("secret not found")
+	ErrUnavailable = errors.New("keyring is not available")
+)
+
+// Store keeps secrets by account name, one per profile.
+type Store interface {
+	Get(account string) (string, error)
+	Set(account, secret string) error
+	Delete(account string) error
+	// Name describes the backend for messages and logs.
+	Name() string
+}
+
+// New returns the store for the given storage setting. dir holds the
+// encrypted file used as a fallback when the OS keyring is not available.
+// The "config" storage returns a nil store, tokens stay in the config file.
+func New(storage, dir string) (Store, error) {
+	switch storage {
+	case StorageConfig:
+		return nil, nil
+	case StorageKeyring:
+		return newKeyring(dir)
+	case StorageFile:
+		return newFileStore(dir)
+	case "", StorageAuto:
+		if store, err := newKeyring(dir); err == nil {
+			return store, nil
+		}
+		return newFileStore(dir)
+	}
+	return nil, fmt.Errorf("unknown secrets storage %q", storage)
+}
@@ -0,0 +1,91 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"strings"
+
+	"ffiii-tui/internal/ui/money"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// accountGroupConfig is a display group configured under "account_groups",
+// e.g. "Cash" with the checking account and the wallet. The groups are
+// local to the TUI, Firefly III object groups are not used.
+type accountGroupConfig struct {
+	Name     string   `mapstructure:"name"`
+	Accounts []string `mapstructure:"accounts"`
+}
+
+func loadAccountGroups() []accountGroupConfig {
+	var groups []accountGroupConfig
+	if err := viper.UnmarshalKey("account_groups", &groups); err != nil {
+		zap.L().Warn("Invalid account_groups config", zap.Error(err))
+		return nil
+	}
+	return groups
+}
+
+// getGroupItems lists the net balance of each account group, summed over
+// its asset and liability accounts matched by name. Groups spanning several
+// currencies list a subtotal per currency.
+func getGroupItems(api AccountsAPI, groups []accountGroupConfig, styles Styles, amounts money.Formatter) []list.Item {
+	items := []list.Item{}
+	data := fromStore[accountsReader](api)
+	for _, group := range groups {
+		if group.Name == "" {
+			continue
+		}
+		totals := currencyTotals{}
+		found := false
+		for _, accountType := range []string{"asset", "liabilities"} {
+			for _, account := range data.AccountsByType(accountType) {
+				if !groupHasAccount(group, account.Name) {
+					continue
+				}
+				totals[account.CurrencyCode] += data.AccountBalance(account.ID)
+				found = true
+			}
+		}
+		if !found {
+			zap.L().Debug("Account group has no known accounts", zap.String("group", group.Name))
+			continue
+		}
+
+		item := summaryItem{
+			title:  group.Name,
+			value:  totals.Short(amounts),
+			style:  styles.Normal,
+			widget: true,
+		}
+		if item.value == "" {
+			item.value = "0.00"
+		}
+		if len(totals) == 1 {
+			for _, v := range totals {
+				switch {
+				case v < 0:
+					item.style = styles.Withdrawal
+				case v > 0:
+					item.style = styles.Deposit
+				}
+			}
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func groupHasAccount(group accountGroupConfig, name string) bool {
+	for _, a := range group.Accounts {
+		if strings.EqualFold(strings.TrimSpace(a), name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/money"
+
+	"github.com/spf13/viper"
+)
+
+func TestGetGroupItems(t *testing.T) {
+	api := newTestSummaryAPI()
+	api.accounts = map[string][]firefly.Account{
+		"asset": {
+			{ID: "1", Name: "Checking", CurrencyCode: "EUR"},
+			{ID: "2", Name: "Wallet", CurrencyCode: "EUR"},
+			{ID: "3", Name: "Broker", CurrencyCode: "USD"},
+		},
+		"liabilities": {
+			{ID: "4", Name: "Credit card", CurrencyCode: "EUR"},
+		},
+	}
+	api.balances = map[string]float64{"1": 500, "2": 20, "3": 1000, "4": -700}
+	groups := []accountGroupConfig{
+		{Name: "Cash", Accounts: []string{"checking", "Wallet"}},
+		{Name: "Everything", Accounts: []string{"Checking", "Broker", "Credit card"}},
+		{Name: "Debt", Accounts: []string{"Credit card"}},
+		{Name: "Unknown", Accounts: []string{"Savings"}},
+	}
+	styles := DefaultStyles()
+
+	items := getGroupItems(api, groups, styles, money.Formatter{})
+
+	expected := []struct {
+		title, value string
+	}{
+		{"Cash", "520.00 EUR"},
+		{"Everything", "-200.00 EUR, 1000.00 USD"},
+		{"Debt", "-700.00 EUR"},
+	}
+	if len(items) != len(expected) {
+		t.Fatalf("Expected %d groups, got %d", len(expected), len(items))
+	}
+	for i, e := range expected {
+		item := items[i].(summaryItem)
+		if item.title != e.title || item.value != e.value {
+			t.Errorf("Expected %s %q, got %s %q", e.title, e.value, item.title, item.value)
+		}
+	}
+	if items[0].(summaryItem).style.GetForeground() != styles.Deposit.GetForeground() {
+		t.Error("Expected a positive group in the deposit style")
+	}
+	if items[2].(summaryItem).style.GetForeground() != styles.Withdrawal.GetForeground() {
+		t.Error("Expected a negative group in the withdrawal style")
+	}
+}
+
+func TestSummary_AccountGroupsFromConfig(t *testing.T) {
+	viper.Set("account_groups", []map[string]any{
+		{"name": "Cash", "accounts": []string{"Checking"}},
+	})
+	defer viper.Set("account_groups", nil)
+
+	api := newTestSummaryAPI()
+	m := newModelSummary(api, newRequestScope())
+	for _, item := range m.list.Items() {
+		if item.(summaryItem).title == "Cash" {
+			t.Fatal("Expected no group before the accounts are loaded")
+		}
+	}
+
+	api.accounts = map[string][]firefly.Account{
+		"asset": {{ID: "1", Name: "Checking", CurrencyCode: "EUR"}},
+	}
+	api.balances = map[string]float64{"1": 42}
+	m, _ = updateModel(m, AssetsUpdateMsg{})
+
+	items := m.list.Items()
+	last := items[len(items)-1].(summaryItem)
+	if last.title != "Cash" || last.value != "42.00 EUR" {
+		t.Errorf("Expected the Cash group after the accounts update, got %+v", last)
+	}
+}
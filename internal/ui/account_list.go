@@ -5,33 +5,44 @@ SPDX-License-Identifier: Apache-2.0
 package ui
 
 import (
+	"context"
 	"reflect"
 
 	"ffiii-tui/internal/firefly"
-	"ffiii-tui/internal/ui/notify"
+	"ffiii-tui/internal/ui/accountdetail"
+	"ffiii-tui/internal/ui/loading"
+	"ffiii-tui/internal/ui/money"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/viper"
 )
 
+// AccountListAPI is what every account list needs, the API of each list
+// adds its own operations.
+type AccountListAPI interface {
+	AccountsAPI
+	ExchangeRateAPI
+}
+
 // AccountListModel is a generic model for account/category list views
-type AccountListModel[T ListEntity] struct {
+type AccountListModel[T ListEntity, A AccountListAPI] struct {
 	list   list.Model
-	api    any // Specific API interface
+	api    A
 	focus  bool
 	sorted bool
-	config *AccountListConfig[T]
+	config *AccountListConfig[T, A]
 	styles Styles
 	keymap AccountKeyMap
 }
 
 // NewAccountListModel creates a new generic account list model
-func NewAccountListModel[T ListEntity](api any, config *AccountListConfig[T]) AccountListModel[T] {
+func NewAccountListModel[T ListEntity, A AccountListAPI](api A, config *AccountListConfig[T, A]) AccountListModel[T, A] {
 	items := config.GetItems(api, false)
 
-	m := AccountListModel[T]{
-		list:   list.New(items, list.NewDefaultDelegate(), 0, 0),
+	m := AccountListModel[T, A]{
+		list:   list.New(items, newAccountDelegate(DefaultStyles()), 0, 0),
 		api:    api,
 		config: config,
 		styles: DefaultStyles(),
@@ -48,23 +59,21 @@ func NewAccountListModel[T ListEntity](api any, config *AccountListConfig[T]) Ac
 	return m
 }
 
-func (m AccountListModel[T]) Init() tea.Cmd {
+func (m AccountListModel[T, A]) Init() tea.Cmd {
 	return nil
 }
 
-func (m AccountListModel[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+func (m AccountListModel[T, A]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	if matchMsgType(msg, m.config.RefreshMsgType) {
-		return m, func() tea.Msg {
-			opID := startLoading("Loading accounts...")
-			defer stopLoading(opID)
-			err := m.config.RefreshItems(m.api, m.config.AccountType)
+		return m, loading.Track("Loading accounts...", func() tea.Msg {
+			err := m.api.UpdateAccounts(context.Background(), m.config.AccountType)
 			if err != nil {
-				return notify.NotifyWarn(err.Error())()
+				return dataLoadFailed(m.config.AccountType, err)
 			}
 			return m.config.UpdateMsgType
-		}
+		})
 	}
 
 	if matchMsgType(msg, m.config.UpdateMsgType) {
@@ -128,7 +137,12 @@ func (m AccountListModel[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, SetView(revenuesView)
 		case key.Matches(msg, m.keymap.ViewLiabilities):
 			return m, SetView(liabilitiesView)
+		case key.Matches(msg, m.keymap.ViewTags):
+			return m, SetView(tagsView)
 		case key.Matches(msg, m.keymap.Refresh):
+			if api, ok := any(m.api).(InsightsCacheAPI); ok {
+				api.ForgetInsights()
+			}
 			return m, Cmd(m.config.RefreshMsgType)
 		case key.Matches(msg, m.keymap.ResetFilter):
 			return m, Cmd(FilterMsg{Reset: true})
@@ -148,12 +162,37 @@ func (m AccountListModel[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, m.config.FilterFunc(i)
 			}
 			return m, nil
+		case key.Matches(msg, m.keymap.OpenInWeb):
+			i, ok := m.list.SelectedItem().(accountListItem[T])
+			if !ok {
+				return m, nil
+			}
+			account := firefly.Account(i.Entity)
+			if account.ID == "" {
+				return m, nil
+			}
+			return m, Cmd(OpenInWebMsg{Path: "accounts/show/" + account.ID})
+		case key.Matches(msg, m.keymap.PayCard):
+			i, ok := m.list.SelectedItem().(accountListItem[T])
+			if ok && m.config.PayCardFunc != nil {
+				return m, m.config.PayCardFunc(i)
+			}
+			return m, nil
+		case key.Matches(msg, m.keymap.Details):
+			i, ok := m.list.SelectedItem().(accountListItem[T])
+			if ok && m.config.DetailsFunc != nil {
+				return m, m.config.DetailsFunc(i)
+			}
+			return m, nil
 		case key.Matches(msg, m.keymap.Select):
 			i, ok := m.list.SelectedItem().(accountListItem[T])
 			if ok {
 				if m.config.HasTotalRow && i.Entity.GetName() == "Total" {
 					return m, nil
 				}
+				if m.config.DetailsFunc != nil && viper.GetBool("ui.account_details_on_enter") {
+					return m, m.config.DetailsFunc(i)
+				}
 				return m, m.config.SelectFunc(i)
 			}
 			return m, nil
@@ -164,37 +203,58 @@ func (m AccountListModel[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
-func (m AccountListModel[T]) View() string {
+// accountDetails returns a DetailsFunc opening the detail pane of the
+// selected account.
+func accountDetails(api AccountDetailsAPI) func(item list.Item) tea.Cmd {
+	return func(item list.Item) tea.Cmd {
+		i, ok := item.(accountListItem[firefly.Account])
+		if !ok || i.Entity.ID == "" {
+			return nil
+		}
+		return accountdetail.Open(
+			i.Entity,
+			api.AccountBalance(i.Entity.ID),
+			api.AccountLastActivity(i.Entity.ID),
+		)
+	}
+}
+
+func (m AccountListModel[T, A]) View() string {
 	return m.styles.LeftPanel.Render(m.list.View())
 }
 
-func (m *AccountListModel[T]) Focus() {
+func (m *AccountListModel[T, A]) Focus() {
 	m.focus = true
 }
 
-func (m *AccountListModel[T]) Blur() {
+func (m *AccountListModel[T, A]) Blur() {
 	m.focus = false
 }
 
-func (m AccountListModel[T]) createTotalEntity(primary float64) list.Item {
+func (m AccountListModel[T, A]) createTotalEntity(primary float64, totals currencyTotals) list.Item {
 	var entity T
 
-	acc := firefly.Account{Name: "Total", CurrencyCode: ""}
-	if api, ok := m.api.(interface{ PrimaryCurrency() firefly.Currency }); ok {
-		acc.CurrencyCode = api.PrimaryCurrency().Code
+	acc := firefly.Account{Name: "Total", CurrencyCode: m.api.PrimaryCurrency().Code}
+	totals = totals.inPrimary(m.api)
+	if len(totals) > 0 && !totals.spans(acc.CurrencyCode) {
+		primary = totals[acc.CurrencyCode]
 	}
 	entity = any(acc).(T)
-	return newAccountListItem(entity, "Total", primary)
+	item := newAccountListItem(entity, "Total", primary, money.New(m.api))
+	item.totals = totals
+	return item
 }
 
-func (m *AccountListModel[T]) updateItemsCmd() tea.Cmd {
-	opID := startLoading("Updating account list...")
-	defer stopLoading(opID)
+func (m *AccountListModel[T, A]) updateItemsCmd() tea.Cmd {
 	items := m.config.GetItems(m.api, m.sorted)
 
 	if m.config.HasTotalRow && m.config.GetTotalFunc != nil {
 		primary := m.config.GetTotalFunc(m.api)
-		totalEntity := m.createTotalEntity(primary)
+		var totals currencyTotals
+		if m.config.GetTotalsFunc != nil {
+			totals = m.config.GetTotalsFunc(m.api)
+		}
+		totalEntity := m.createTotalEntity(primary, totals)
 
 		cmds := []tea.Cmd{
 			m.list.SetItems(items),
@@ -11,7 +11,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 )
 
-type AccountListConfig[T firefly.Account] struct {
+type AccountListConfig[T firefly.Account, A AccountListAPI] struct {
 	// Data specific
 	AccountType string
 
@@ -19,8 +19,7 @@ type AccountListConfig[T firefly.Account] struct {
 	Title string
 
 	// Data functions
-	GetItems     func(api any, sorted bool) []list.Item
-	RefreshItems func(api any, accountType string) error
+	GetItems func(api A, sorted bool) []list.Item
 
 	// Messages
 	RefreshMsgType any
@@ -31,8 +30,13 @@ type AccountListConfig[T firefly.Account] struct {
 	HasSort       bool
 	HasTotalRow   bool
 	HasSummary    bool
-	GetTotalFunc  func(api any) float64 // for totals
-
-	FilterFunc func(item list.Item) tea.Cmd
-	SelectFunc func(item list.Item) tea.Cmd
+	GetTotalFunc  func(api A) float64 // for totals
+	// GetTotalsFunc returns the totals by currency, shown instead of the
+	// total when they span several currencies
+	GetTotalsFunc func(api A) map[string]float64
+
+	FilterFunc  func(item list.Item) tea.Cmd
+	SelectFunc  func(item list.Item) tea.Cmd
+	DetailsFunc func(item list.Item) tea.Cmd // nil when the list has no detail pane
+	PayCardFunc func(item list.Item) tea.Cmd // nil when the list has no credit cards
 }
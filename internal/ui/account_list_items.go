@@ -8,6 +8,9 @@ import (
 	"fmt"
 
 	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/money"
+
+	"github.com/spf13/viper"
 )
 
 // ListEntity is a constraint for types that can be displayed in account lists
@@ -21,6 +24,22 @@ type accountListItem[T ListEntity] struct {
 	Entity       T
 	PrimaryVal   float64
 	primaryLabel string
+
+	// converted is PrimaryVal in the primary currency, set only when
+	// convertedCode is
+	converted     float64
+	convertedCode string
+
+	// totals are set on Total rows only
+	totals currencyTotals
+
+	// extra is shown after the value, e.g. the credit card limit
+	extra string
+
+	// alert is set when the balance is below the thresholds of the account
+	alert alertLevel
+
+	amounts money.Formatter
 }
 
 // Accessors for backward compatibility with tests
@@ -28,6 +47,10 @@ func (i accountListItem[T]) GetPrimaryVal() float64 {
 	return i.PrimaryVal
 }
 
+func (i accountListItem[T]) balanceAlert() alertLevel {
+	return i.alert
+}
+
 func (i accountListItem[T]) Title() string {
 	var name string
 	switch entity := any(i.Entity).(type) {
@@ -44,7 +67,17 @@ func (i accountListItem[T]) Description() string {
 		currencyCode = entity.CurrencyCode
 	}
 
-	desc := fmt.Sprintf("%s: %.2f %s", i.primaryLabel, i.PrimaryVal, currencyCode)
+	if i.totals.spans(currencyCode) {
+		return fmt.Sprintf("%s: %s", i.primaryLabel, i.totals.Short(i.amounts))
+	}
+	desc := fmt.Sprintf("%s: %s", i.primaryLabel, i.amounts.Short(i.PrimaryVal, currencyCode))
+	if i.convertedCode != "" {
+		desc = fmt.Sprintf("%s: %s (%s)", i.primaryLabel,
+			i.amounts.Short(i.converted, i.convertedCode), i.amounts.Short(i.PrimaryVal, currencyCode))
+	}
+	if i.extra != "" {
+		desc += " | " + i.extra
+	}
 	return desc
 }
 
@@ -58,10 +91,27 @@ func (i accountListItem[T]) FilterValue() string {
 }
 
 // newAccountListItem creates a new account list item with a single value
-func newAccountListItem[T ListEntity](entity T, primaryLabel string, primaryVal float64) accountListItem[T] {
+func newAccountListItem[T ListEntity](entity T, primaryLabel string, primaryVal float64, amounts money.Formatter) accountListItem[T] {
 	return accountListItem[T]{
 		Entity:       entity,
 		PrimaryVal:   primaryVal,
 		primaryLabel: primaryLabel,
+		amounts:      amounts,
+	}
+}
+
+// convertedToPrimary shows the value of an account in a foreign currency in
+// the primary currency, with the original in parentheses, when
+// ui.convert_balances is set.
+func convertedToPrimary(item accountListItem[firefly.Account], api ExchangeRateAPI) accountListItem[firefly.Account] {
+	if !viper.GetBool("ui.convert_balances") {
+		return item
+	}
+	converted, ok := api.ConvertToPrimary(item.PrimaryVal, item.Entity.CurrencyCode)
+	if !ok {
+		return item
 	}
+	item.converted = converted
+	item.convertedCode = api.PrimaryCurrency().Code
+	return item
 }
@@ -0,0 +1,205 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package accountdetail
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/money"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type OpenMsg struct {
+	Account      firefly.Account
+	Balance      float64
+	LastActivity string
+	// Payoff is the projected payoff of liabilities
+	Payoff string
+}
+
+type CloseMsg struct{}
+
+// EditOpeningBalanceMsg asks for a new opening balance of the asset account
+// that was shown, the details are closed.
+type EditOpeningBalanceMsg struct {
+	Account firefly.Account
+}
+
+type Model struct {
+	account      firefly.Account
+	balance      float64
+	lastActivity string
+	payoff       string
+	focus        bool
+	styles       Styles
+	amounts      money.Formatter
+	Width        int
+	Height       int
+}
+
+func New(amounts money.Formatter) Model {
+	return Model{
+		styles:  DefaultStyles(),
+		amounts: amounts,
+		Width:   80,
+		Height:  24,
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case OpenMsg:
+		m.account = msg.Account
+		m.balance = msg.Balance
+		m.lastActivity = msg.LastActivity
+		m.payoff = msg.Payoff
+		m.Focus()
+		return m, nil
+	case CloseMsg:
+		m.Blur()
+		return m, nil
+	}
+
+	if !m.focus {
+		return m, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc", "enter", "q", "v":
+			return m, Close()
+		case "o":
+			if m.account.Type == "asset" {
+				m.Blur()
+				account := m.account
+				return m, func() tea.Msg {
+					return EditOpeningBalanceMsg{Account: account}
+				}
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) View() string {
+	if !m.focus {
+		return ""
+	}
+
+	borderW, borderH := m.styles.Border.GetFrameSize()
+	a := m.account
+
+	kind := a.Type
+	if a.LiabilityType != "" {
+		kind = a.LiabilityType
+	}
+	var b strings.Builder
+	hint := "  " + kind + " account (esc to close"
+	if a.Type == "asset" {
+		hint += ", o to edit the opening balance"
+	}
+	b.WriteString(m.styles.Title.Render(a.Name) +
+		m.styles.Desc.Render(hint+")") + "\n\n")
+
+	openingBalance := ""
+	if a.OpeningBalance != 0 || a.OpeningBalanceDate != "" {
+		openingBalance = m.amounts.Format(a.OpeningBalance, a.CurrencyCode)
+		if a.OpeningBalanceDate != "" {
+			openingBalance += " on " + day(a.OpeningBalanceDate)
+		}
+	}
+	interest := ""
+	if a.Interest != "" {
+		interest = a.Interest + "%"
+		if a.InterestPeriod != "" {
+			interest += " " + a.InterestPeriod
+		}
+	}
+
+	rows := [][2]string{
+		{"Balance", m.amounts.Format(m.balance, a.CurrencyCode)},
+		{"IBAN", a.IBAN},
+		{"Account number", a.AccountNumber},
+		{"Opening balance", openingBalance},
+		{"Interest", interest},
+		{"Last activity", day(m.lastActivity)},
+		{"Notes", a.Notes},
+	}
+	if a.Type == "liabilities" {
+		rows = append(rows, [2]string{"Projected payoff", m.payoff})
+	}
+	if a.IsCreditCard() {
+		rows = append(rows,
+			[2]string{"Credit limit", m.amounts.Format(a.CreditLimit, a.CurrencyCode)},
+			[2]string{"Payment date", day(a.MonthlyPaymentDate)},
+		)
+	}
+	lines := make([]string, 0, len(rows))
+	for _, row := range rows {
+		value := m.styles.Value.Render(row[1])
+		if row[1] == "" {
+			value = m.styles.Empty.Render("-")
+		}
+		lines = append(lines, m.styles.Label.Render(fmt.Sprintf("%-16s", row[0]))+value)
+	}
+	b.WriteString(strings.Join(lines, "\n"))
+
+	return m.styles.Border.
+		Width(max(m.Width-borderW, 0)).
+		Height(max(m.Height-borderH, 0)).
+		Render(b.String())
+}
+
+// day cuts an API timestamp to its date.
+func day(date string) string {
+	if len(date) > len(time.DateOnly) {
+		return date[:len(time.DateOnly)]
+	}
+	return date
+}
+
+func (m *Model) Focus() {
+	m.focus = true
+}
+
+func (m *Model) Blur() {
+	m.focus = false
+}
+
+func (m *Model) Focused() bool {
+	return m.focus
+}
+
+func (m *Model) WithSize(width, height int) *Model {
+	m.Width = width
+	m.Height = height
+	return m
+}
+
+func (m *Model) WithStyles(styles Styles) *Model {
+	m.styles = styles
+	return m
+}
+
+func Open(account firefly.Account, balance float64, lastActivity string) tea.Cmd {
+	return func() tea.Msg {
+		return OpenMsg{Account: account, Balance: balance, LastActivity: lastActivity}
+	}
+}
+
+func Close() tea.Cmd {
+	return func() tea.Msg {
+		return CloseMsg{}
+	}
+}
@@ -0,0 +1,174 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package accountdetail
+
+import (
+	"strings"
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/money"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func openModel(t *testing.T, account firefly.Account) Model {
+	t.Helper()
+	m := New(money.Formatter{})
+	updated, _ := m.Update(OpenMsg{Account: account, Balance: 1234.5, LastActivity: "2026-01-14T00:00:00+01:00"})
+	m = updated.(Model)
+	if !m.Focused() {
+		t.Fatal("Expected model to be focused after OpenMsg")
+	}
+	return m
+}
+
+func TestNew(t *testing.T) {
+	m := New(money.Formatter{})
+
+	if m.Focused() {
+		t.Error("Expected new model to be unfocused")
+	}
+	if m.View() != "" {
+		t.Error("Expected empty view when unfocused")
+	}
+}
+
+func TestView_Metadata(t *testing.T) {
+	m := openModel(t, firefly.Account{
+		ID:                 "1",
+		Name:               "Car loan",
+		Type:               "liabilities",
+		CurrencyCode:       "EUR",
+		IBAN:               "DE89 3704 0044 0532 0130 00",
+		AccountNumber:      "CL-0815",
+		OpeningBalance:     -9600,
+		OpeningBalanceDate: "2025-08-01T00:00:00+02:00",
+		Interest:           "4.9",
+		InterestPeriod:     "yearly",
+		Notes:              "Paid monthly",
+	})
+	view := m.View()
+
+	for _, want := range []string{
+		"Car loan",
+		"1234.50 EUR",
+		"DE89 3704 0044 0532 0130 00",
+		"CL-0815",
+		"-9600.00 EUR on 2025-08-01",
+		"4.9% yearly",
+		"2026-01-14",
+		"Paid monthly",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected view to contain %q", want)
+		}
+	}
+}
+
+func TestView_MissingMetadata(t *testing.T) {
+	m := openModel(t, firefly.Account{ID: "1", Name: "Cash wallet", Type: "asset", CurrencyCode: "EUR"})
+	view := m.View()
+
+	if strings.Contains(view, "on ") {
+		t.Error("Expected no opening balance date")
+	}
+	if got := strings.Count(view, " -"); got < 5 {
+		t.Errorf("Expected empty fields shown as '-', got %d", got)
+	}
+}
+
+func TestView_CreditCard(t *testing.T) {
+	m := openModel(t, firefly.Account{
+		ID: "1", Name: "Visa", Type: "asset", CurrencyCode: "EUR",
+		Role: "ccAsset", CreditLimit: 2000, MonthlyPaymentDate: "2026-01-15",
+	})
+	view := m.View()
+
+	for _, want := range []string{"Credit limit", "2000.00 EUR", "Payment date", "2026-01-15"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected view to contain %q", want)
+		}
+	}
+}
+
+func TestView_LiabilityPayoff(t *testing.T) {
+	m := New(money.Formatter{})
+	updated, _ := m.Update(OpenMsg{
+		Account: firefly.Account{ID: "1", Name: "Car loan", Type: "liabilities", LiabilityType: "loan", CurrencyCode: "EUR"},
+		Balance: -5000,
+		Payoff:  "Mar 2028 at 320.00 EUR a month",
+	})
+	m = updated.(Model)
+	view := m.View()
+
+	for _, want := range []string{"loan account", "Projected payoff", "Mar 2028 at 320.00 EUR a month"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected view to contain %q", want)
+		}
+	}
+
+	asset := openModel(t, firefly.Account{ID: "2", Name: "Checking", Type: "asset"})
+	if strings.Contains(asset.View(), "Projected payoff") {
+		t.Error("Expected no projected payoff for asset accounts")
+	}
+}
+
+func TestUpdate_Close(t *testing.T) {
+	for _, k := range []string{"esc", "q", "v"} {
+		t.Run(k, func(t *testing.T) {
+			m := openModel(t, firefly.Account{ID: "1", Name: "Checking"})
+			var msg tea.KeyMsg
+			if k == "esc" {
+				msg = tea.KeyMsg{Type: tea.KeyEsc}
+			} else {
+				msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+			}
+			updated, cmd := m.Update(msg)
+			m = updated.(Model)
+			if cmd == nil {
+				t.Fatal("Expected close command")
+			}
+			if _, ok := cmd().(CloseMsg); !ok {
+				t.Fatal("Expected CloseMsg")
+			}
+			updated, _ = m.Update(CloseMsg{})
+			m = updated.(Model)
+			if m.Focused() {
+				t.Error("Expected model to be unfocused after CloseMsg")
+			}
+		})
+	}
+}
+
+func TestUpdate_IgnoresKeysWhenUnfocused(t *testing.T) {
+	m := New(money.Formatter{})
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd != nil {
+		t.Error("Expected no command when unfocused")
+	}
+}
+
+func TestUpdate_EditOpeningBalance(t *testing.T) {
+	m := openModel(t, firefly.Account{ID: "1", Name: "Checking", Type: "asset"})
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	if cmd == nil {
+		t.Fatal("Expected a command for o on an asset account")
+	}
+	m = updated.(Model)
+	edit, ok := cmd().(EditOpeningBalanceMsg)
+	if !ok || edit.Account.ID != "1" {
+		t.Errorf("Expected EditOpeningBalanceMsg for account 1, got %v", cmd())
+	}
+	if m.Focused() {
+		t.Error("Expected the details closed")
+	}
+
+	m = openModel(t, firefly.Account{ID: "2", Name: "Car loan", Type: "liabilities"})
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}}); cmd != nil {
+		t.Error("Expected no opening balance edit on liabilities")
+	}
+}
@@ -0,0 +1,36 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package accountdetail
+
+import "github.com/charmbracelet/lipgloss"
+
+type Styles struct {
+	Border lipgloss.Style
+	Title  lipgloss.Style
+	Label  lipgloss.Style
+	Value  lipgloss.Style
+	Desc   lipgloss.Style
+	Empty  lipgloss.Style
+}
+
+func DefaultStyles() Styles {
+	return Styles{
+		Border: lipgloss.NewStyle().
+			BorderStyle(lipgloss.ThickBorder()).
+			BorderForeground(lipgloss.Color("#5F5FD7")),
+		Title: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#5F5FD7")),
+		Label: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#D75F87")),
+		Value: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#DDDADA")),
+		Desc: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#8A8A8A")),
+		Empty: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#585858")),
+	}
+}
@@ -5,6 +5,7 @@ SPDX-License-Identifier: Apache-2.0
 package ui
 
 import (
+	"context"
 	"time"
 
 	"ffiii-tui/internal/firefly"
@@ -22,100 +23,198 @@ type PeriodAPI interface {
 
 // CurrencyAPI provides access to currency configuration used in UI.
 type CurrencyAPI interface {
+	CurrencyLookupAPI
 	PrimaryCurrency() firefly.Currency
 }
 
+// CurrencyLookupAPI looks up the currencies of the server, e.g. for their
+// symbols.
+type CurrencyLookupAPI interface {
+	GetCurrencyByCode(code string) firefly.Currency
+}
+
+// BurnRateAPI provides the spending of the period so far, refreshed with
+// the summary.
+type BurnRateAPI interface {
+	BurnRate() firefly.BurnRate
+}
+
 // SummaryAPI provides summary refresh and read access.
 type SummaryAPI interface {
-	UpdateSummary() error
+	BurnRateAPI
+	AccountsAPI
+	CurrencyLookupAPI
+	UpdateSummary(ctx context.Context) error
 	GetMaxWidth() int
 	SummaryItems() map[string]firefly.SummaryItem
+	PiggyBanks() []firefly.PiggyBank
+	SubscriptionsDue() []firefly.Subscription
 }
 
 // AccountsAPI provides account refresh and read access.
 type AccountsAPI interface {
-	UpdateAccounts(accountType string) error
+	UpdateAccounts(ctx context.Context, accountType string) error
 	AccountsByType(accountType string) []firefly.Account
 	AccountBalance(accountID string) float64
 }
 
+// AccountDetailsAPI provides account data shown in the detail pane.
+type AccountDetailsAPI interface {
+	AccountBalance(accountID string) float64
+	AccountLastActivity(accountID string) string
+}
+
+// ExchangeRateAPI converts amounts to the primary currency.
+type ExchangeRateAPI interface {
+	CurrencyAPI
+	ConvertToPrimary(amount float64, currencyCode string) (float64, bool)
+}
+
 // AssetAPI is the minimal API used by the assets UI.
 type AssetAPI interface {
 	AccountsAPI
-	CreateAssetAccount(name, currencyCode string) error
+	AccountDetailsAPI
+	ExchangeRateAPI
+	BurnRateAPI
+	CreateAssetAccount(ctx context.Context, na firefly.NewAsset) error
+}
+
+// OpeningBalanceAPI edits the opening balance of asset accounts.
+type OpeningBalanceAPI interface {
+	UpdateOpeningBalance(ctx context.Context, account firefly.Account, amount float64, date string) error
+}
+
+// BackupAPI reads everything reachable for a local backup.
+type BackupAPI interface {
+	Backup(ctx context.Context, now time.Time) (firefly.Backup, error)
 }
 
 // AccountCreateAPI provides account creation operations.
 type AccountCreateAPI interface {
-	CreateAssetAccount(name, currencyCode string) error
-	CreateExpenseAccount(name string) error
-	CreateRevenueAccount(name string) error
-	CreateLiabilityAccount(nl firefly.NewLiability) error
+	CreateAssetAccount(ctx context.Context, na firefly.NewAsset) error
+	CreateExpenseAccount(ctx context.Context, name string) error
+	CreateRevenueAccount(ctx context.Context, name string) error
+	CreateLiabilityAccount(ctx context.Context, nl firefly.NewLiability) error
+}
+
+// InsightsCacheAPI drops the insights cached per type and period, so an
+// explicit refresh fetches them again.
+type InsightsCacheAPI interface {
+	ForgetInsights()
 }
 
 // ExpenseInsightsAPI provides expense insights used by the UI.
 type ExpenseInsightsAPI interface {
-	UpdateExpenseInsights() error
+	UpdateExpenseInsights(ctx context.Context) error
 	GetExpenseDiff(accountID string) float64
 	GetTotalExpenseDiff() float64
+	ExpenseTotals() map[string]float64
 }
 
 // ExpenseAPI is the minimal API used by the expenses UI.
 type ExpenseAPI interface {
 	AccountsAPI
-	CurrencyAPI
+	ExchangeRateAPI
 	ExpenseInsightsAPI
-	CreateExpenseAccount(name string) error
+	InsightsCacheAPI
+	CreateExpenseAccount(ctx context.Context, name string) error
 }
 
 // RevenueInsightsAPI provides revenue insights used by the UI.
 type RevenueInsightsAPI interface {
-	UpdateRevenueInsights() error
+	UpdateRevenueInsights(ctx context.Context) error
 	GetRevenueDiff(accountID string) float64
 	GetTotalRevenueDiff() float64
+	RevenueTotals() map[string]float64
 }
 
 // RevenueAPI is the minimal API used by the revenues UI.
 type RevenueAPI interface {
 	AccountsAPI
-	CurrencyAPI
+	ExchangeRateAPI
 	RevenueInsightsAPI
-	CreateRevenueAccount(name string) error
+	InsightsCacheAPI
+	CreateRevenueAccount(ctx context.Context, name string) error
 }
 
 // LiabilityAPI is the minimal API used by the liabilities UI.
 type LiabilityAPI interface {
 	AccountsAPI
-	CreateLiabilityAccount(nl firefly.NewLiability) error
+	AccountDetailsAPI
+	ExchangeRateAPI
+	CreateLiabilityAccount(ctx context.Context, nl firefly.NewLiability) error
+	AccountTransactions(ctx context.Context, accountID string, start, end time.Time) ([]firefly.Transaction, error)
 }
 
 // CategoriesAPI provides category refresh and read access.
 type CategoriesAPI interface {
-	UpdateCategories() error
-	UpdateCategoriesInsights() error
+	UpdateCategories(ctx context.Context) error
+	UpdateCategoriesInsights(ctx context.Context) error
 	CategoriesList() []firefly.Category
 	GetTotalSpentEarnedCategories() (spent, earned float64)
+	CategoryTotals() (spent, earned map[string]float64)
 	CategorySpent(categoryID string) float64
 	CategoryEarned(categoryID string) float64
-	CreateCategory(name, notes string) error
+	CreateCategory(ctx context.Context, name, notes string) error
+}
+
+// BudgetAPI proposes and sets the budget limits of the next period.
+type BudgetAPI interface {
+	Budgets() []firefly.Budget
+	BudgetAverages(ctx context.Context, months int, before time.Time) (map[string]float64, error)
+	SetBudgetLimit(ctx context.Context, budget firefly.Budget, amount float64, start, end time.Time) error
+	PeriodStart() time.Time
 }
 
 // CategoryAPI is the minimal API used by the categories UI.
 type CategoryAPI interface {
 	CategoriesAPI
+	ExchangeRateAPI
+	InsightsCacheAPI
+	BudgetAPI
+	CategoryHistory(ctx context.Context, categoryID string, months int, end time.Time) (firefly.CategoryHistory, error)
+	CategoryBudget(categoryName string) (firefly.Budget, bool)
+}
+
+// TagsAPI provides tag refresh, read access and maintenance.
+type TagsAPI interface {
+	UpdateTags(ctx context.Context) error
+	UpdateTagsInsights(ctx context.Context) error
+	TagsList() []firefly.Tag
+	TagSpent(tagID string) float64
+	TagEarned(tagID string) float64
+	TagUsage() map[string]int
+	RenameTag(ctx context.Context, tag firefly.Tag, name string) error
+	DeleteTag(ctx context.Context, tag firefly.Tag) error
+}
+
+// TagAPI is the minimal API used by the tags UI.
+type TagAPI interface {
+	TagsAPI
 	CurrencyAPI
+	InsightsCacheAPI
 }
 
-// TransactionAPI provides read/delete operations for the transaction list.
+// TransactionBatchAPI creates many transactions at once.
+type TransactionBatchAPI interface {
+	CreateTransactions(ctx context.Context, txs []firefly.RequestTransaction) firefly.BatchResult
+}
+
+// TransactionAPI provides read/delete operations, category assignment and
+// batch creation for the transaction list.
 type TransactionAPI interface {
-	ListTransactions(query string) ([]firefly.Transaction, error)
-	DeleteTransaction(transactionID string) error
+	TransactionBatchAPI
+	StreamTransactions(ctx context.Context, query string, onPage func(firefly.TransactionsPage)) ([]firefly.Transaction, error)
+	GetTransaction(ctx context.Context, transactionID string) (firefly.Transaction, error)
+	UpdateTransaction(ctx context.Context, transactionID string, tx firefly.RequestTransaction) (string, error)
+	DeleteTransaction(ctx context.Context, transactionID string) error
+	CategoriesList() []firefly.Category
 }
 
 // TransactionWriteAPI provides create/update operations used by the transaction form.
 type TransactionWriteAPI interface {
-	CreateTransaction(tx firefly.RequestTransaction) (string, error)
-	UpdateTransaction(transactionID string, tx firefly.RequestTransaction) (string, error)
+	CreateTransaction(ctx context.Context, tx firefly.RequestTransaction) (string, error)
+	UpdateTransaction(ctx context.Context, transactionID string, tx firefly.RequestTransaction) (string, error)
 }
 
 // TransactionFormAPI is the minimal API used by the transaction form UI.
@@ -125,20 +224,101 @@ type TransactionFormAPI interface {
 	TransactionWriteAPI
 }
 
+// RecurrenceAPI creates recurrences, repeating a transaction on schedule.
+type RecurrenceAPI interface {
+	CreateRecurrence(ctx context.Context, recurrence firefly.RequestRecurrence) (string, error)
+}
+
+// StatusAPI provides connection details shown in the status bar.
+type StatusAPI interface {
+	ServerURL() string
+	RetryStatus() (attempt, maxRetries int)
+}
+
+// SnapshotAPI exposes data restored from the on-disk cache at startup.
+type SnapshotAPI interface {
+	Stale() (resources []string, savedAt time.Time)
+	CachedTransactions() []firefly.Transaction
+	RefreshBaseData(ctx context.Context) error
+}
+
+// StoreAPI gives the loaded accounts, categories, tags and insights as one
+// consistent state, see fromStore.
+type StoreAPI interface {
+	Data() firefly.StoreData
+}
+
+// OfflineAPI reports the connection state and sends writes queued while the
+// server was unreachable.
+type OfflineAPI interface {
+	Offline() bool
+	QueuedWrites() int
+	Reconnect(ctx context.Context) (replayed int, conflicts []firefly.Conflict, err error)
+}
+
+// HealthAPI checks the server version.
+type HealthAPI interface {
+	GetAbout(ctx context.Context) (firefly.About, error)
+}
+
+// TraceAPI exposes the latest requests sent to the server and the
+// statistics of the session.
+type TraceAPI interface {
+	RecentRequests() []firefly.RequestTrace
+	SessionStats() firefly.SessionStats
+}
+
+// LiveAPI reports changes made outside of the program.
+type LiveAPI interface {
+	LiveUpdates() <-chan firefly.WebhookEvent
+}
+
+// AuthAPI reports a rejected API token and replaces it.
+type AuthAPI interface {
+	Unauthorized() bool
+	SetAPIKey(key string)
+}
+
+// ReportAPI provides the period data exported in reports.
+type ReportAPI interface {
+	CurrencyLookupAPI
+	SummaryItems() map[string]firefly.SummaryItem
+	CategoriesList() []firefly.Category
+	CategorySpent(categoryID string) float64
+	CategoryEarned(categoryID string) float64
+	CategoryTotals() (spent, earned map[string]float64)
+	AccountsByType(accountType string) []firefly.Account
+	GetExpenseDiff(accountID string) float64
+	ExpenseTotals() map[string]float64
+	PeriodStart() time.Time
+	PeriodEnd() time.Time
+}
+
 // UIAPI is the minimal API used by the root UI model.
 // It is intentionally larger since it wires multiple sub-models.
 type UIAPI interface {
 	PeriodAPI
 	SummaryAPI
 	AssetAPI
+	OpeningBalanceAPI
+	BackupAPI
 	CategoryAPI
+	TagAPI
 	ExpenseAPI
 	RevenueAPI
 	LiabilityAPI
 	TransactionAPI
 	TransactionFormAPI
+	RecurrenceAPI
+	StatusAPI
+	SnapshotAPI
+	OfflineAPI
+	TraceAPI
+	HealthAPI
+	LiveAPI
+	AuthAPI
+	CurrencyLookupAPI
 
-	TimeoutSeconds() int
 	PeriodStart() time.Time
 	PeriodEnd() time.Time
 }
@@ -0,0 +1,221 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package apilog
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"ffiii-tui/internal/firefly"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SlowRequest is the duration above which a request is highlighted.
+const SlowRequest = time.Second
+
+type OpenMsg struct {
+	Requests []firefly.RequestTrace
+	Stats    firefly.SessionStats
+}
+
+type CloseMsg struct{}
+
+type Model struct {
+	requests []firefly.RequestTrace
+	stats    firefly.SessionStats
+	offset   int
+	focus    bool
+	styles   Styles
+	Width    int
+	Height   int
+}
+
+func New() Model {
+	return Model{
+		styles: DefaultStyles(),
+		Width:  80,
+		Height: 24,
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case OpenMsg:
+		m.requests = msg.Requests
+		m.stats = msg.Stats
+		m.offset = 0
+		m.Focus()
+		return m, nil
+	case CloseMsg:
+		m.Blur()
+		return m, nil
+	}
+
+	if !m.focus {
+		return m, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc", "enter", "q":
+			return m, Close()
+		case "up", "k":
+			if m.offset > 0 {
+				m.offset--
+			}
+		case "down", "j":
+			if m.offset < len(m.requests)-1 {
+				m.offset++
+			}
+		case "home", "g":
+			m.offset = 0
+		case "end", "G":
+			m.offset = max(len(m.requests)-1, 0)
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) View() string {
+	if !m.focus {
+		return ""
+	}
+
+	borderW, borderH := m.styles.Border.GetFrameSize()
+	bodyHeight := max(m.Height-borderH-4, 1)
+
+	var b strings.Builder
+	b.WriteString(m.styles.Title.Render("API log") +
+		m.styles.Desc.Render(fmt.Sprintf("  %d recent requests, newest first (esc to close, ↑/↓ to scroll)", len(m.requests))) + "\n")
+	b.WriteString(m.styles.Desc.Render(m.statsLine()) + "\n")
+	b.WriteString(m.styles.Header.Render(fmt.Sprintf("%-8s  %-6s  %-6s  %8s  %s", "TIME", "METHOD", "STATUS", "DURATION", "URL")) + "\n")
+
+	if len(m.requests) == 0 {
+		b.WriteString("\n" + m.styles.Empty.Render("No requests yet"))
+	} else {
+		offset := min(m.offset, len(m.requests)-1)
+		end := min(offset+bodyHeight, len(m.requests))
+		rows := make([]string, 0, end-offset)
+		for _, r := range m.requests[offset:end] {
+			rows = append(rows, m.row(r))
+		}
+		b.WriteString(strings.Join(rows, "\n"))
+	}
+
+	return m.styles.Border.
+		Width(max(m.Width-borderW, 0)).
+		Height(max(m.Height-borderH, 0)).
+		Render(b.String())
+}
+
+// statsLine sums up the requests of the session.
+func (m Model) statsLine() string {
+	s := m.stats
+	if s.Requests == 0 {
+		return "Session: no requests yet"
+	}
+	line := fmt.Sprintf("Session %s: %d requests", time.Since(s.Since).Round(time.Minute), s.Requests)
+	if s.Failed > 0 {
+		line += fmt.Sprintf(" (%d failed)", s.Failed)
+	}
+	line += fmt.Sprintf(", avg %s, %s in, %s out",
+		s.AvgLatency().Round(time.Millisecond), formatBytes(s.BytesIn), formatBytes(s.BytesOut))
+	if s.CacheLookups > 0 {
+		line += fmt.Sprintf(", cache hits %.0f%% (%d/%d)", s.HitRate()*100, s.CacheHits, s.CacheLookups)
+	}
+	return line
+}
+
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", n)
+}
+
+// row renders a request, failed and slow requests are highlighted.
+func (m Model) row(r firefly.RequestTrace) string {
+	status := "-"
+	if r.Status != 0 {
+		status = fmt.Sprintf("%d", r.Status)
+	}
+
+	target := r.URL
+	if u, err := url.Parse(r.URL); err == nil {
+		target = u.RequestURI()
+	}
+	if r.Attempt > 0 {
+		target += fmt.Sprintf(" (retry %d)", r.Attempt)
+	}
+	if r.Err != "" {
+		target += ": " + r.Err
+	}
+
+	line := fmt.Sprintf("%-8s  %-6s  %-6s  %8s  %s",
+		r.At.Format("15:04:05"),
+		r.Method,
+		status,
+		r.Duration.Round(time.Millisecond),
+		target)
+	borderW, _ := m.styles.Border.GetFrameSize()
+	if width := m.Width - borderW; width > 0 && lipgloss.Width(line) > width {
+		line = string([]rune(line)[:max(width-1, 0)]) + "…"
+	}
+
+	switch {
+	case r.Err != "" || r.Status >= 400:
+		return m.styles.Failed.Render(line)
+	case r.Duration >= SlowRequest:
+		return m.styles.Slow.Render(line)
+	}
+	return m.styles.Row.Render(line)
+}
+
+func (m *Model) Focus() {
+	m.focus = true
+}
+
+func (m *Model) Blur() {
+	m.focus = false
+}
+
+func (m *Model) Focused() bool {
+	return m.focus
+}
+
+func (m *Model) WithSize(width, height int) *Model {
+	m.Width = width
+	m.Height = height
+	return m
+}
+
+func (m *Model) WithStyles(styles Styles) *Model {
+	m.styles = styles
+	return m
+}
+
+func Open(requests []firefly.RequestTrace, stats firefly.SessionStats) tea.Cmd {
+	return func() tea.Msg {
+		return OpenMsg{Requests: requests, Stats: stats}
+	}
+}
+
+func Close() tea.Cmd {
+	return func() tea.Msg {
+		return CloseMsg{}
+	}
+}
@@ -0,0 +1,208 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package apilog
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"ffiii-tui/internal/firefly"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func testRequests() []firefly.RequestTrace {
+	at := time.Date(2026, 1, 15, 10, 30, 0, 0, time.UTC)
+	return []firefly.RequestTrace{
+		{
+			Method:   "GET",
+			URL:      "https://firefly.example.com/api/v1/transactions?page=1",
+			Status:   200,
+			Duration: 120 * time.Millisecond,
+			At:       at,
+		},
+		{
+			Method:   "GET",
+			URL:      "https://firefly.example.com/api/v1/insight/income/revenue",
+			Status:   503,
+			Duration: 2 * time.Second,
+			Attempt:  2,
+			At:       at,
+		},
+		{
+			Method: "POST",
+			URL:    "https://firefly.example.com/api/v1/transactions",
+			Err:    "connection refused",
+			At:     at,
+		},
+	}
+}
+
+func openModel(t *testing.T) Model {
+	t.Helper()
+	m := New()
+	updated, _ := m.Update(OpenMsg{Requests: testRequests()})
+	m = updated.(Model)
+	if !m.Focused() {
+		t.Fatal("Expected model to be focused after OpenMsg")
+	}
+	return m
+}
+
+func pressKey(m Model, k string) (Model, tea.Cmd) {
+	var msg tea.KeyMsg
+	switch k {
+	case "esc":
+		msg = tea.KeyMsg{Type: tea.KeyEsc}
+	case "down":
+		msg = tea.KeyMsg{Type: tea.KeyDown}
+	case "up":
+		msg = tea.KeyMsg{Type: tea.KeyUp}
+	default:
+		msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+	}
+	updated, cmd := m.Update(msg)
+	return updated.(Model), cmd
+}
+
+func TestNew(t *testing.T) {
+	m := New()
+
+	if m.Focused() {
+		t.Error("Expected new model to be unfocused")
+	}
+	if m.View() != "" {
+		t.Error("Expected empty view when unfocused")
+	}
+}
+
+func TestView_Requests(t *testing.T) {
+	m := openModel(t)
+	m.WithSize(120, 24)
+	view := m.View()
+
+	for _, want := range []string{
+		"API log",
+		"10:30:00",
+		"/api/v1/transactions?page=1",
+		"200",
+		"120ms",
+		"503",
+		"(retry 2)",
+		"connection refused",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected view to contain %q", want)
+		}
+	}
+	if strings.Contains(view, "firefly.example.com") {
+		t.Error("Expected host to be stripped from URLs")
+	}
+}
+
+func TestView_Empty(t *testing.T) {
+	m := New()
+	updated, _ := m.Update(OpenMsg{})
+	m = updated.(Model)
+
+	if !strings.Contains(m.View(), "No requests yet") {
+		t.Error("Expected empty state in view")
+	}
+}
+
+func TestUpdate_Scroll(t *testing.T) {
+	m := openModel(t)
+
+	m, _ = pressKey(m, "up")
+	if m.offset != 0 {
+		t.Errorf("Expected offset to stay at 0, got %d", m.offset)
+	}
+	m, _ = pressKey(m, "down")
+	m, _ = pressKey(m, "j")
+	m, _ = pressKey(m, "down")
+	if m.offset != 2 {
+		t.Errorf("Expected offset to stop at last request, got %d", m.offset)
+	}
+	m, _ = pressKey(m, "g")
+	if m.offset != 0 {
+		t.Errorf("Expected offset 0 after g, got %d", m.offset)
+	}
+	m, _ = pressKey(m, "G")
+	if m.offset != 2 {
+		t.Errorf("Expected offset 2 after G, got %d", m.offset)
+	}
+}
+
+func TestUpdate_Close(t *testing.T) {
+	for _, k := range []string{"esc", "q"} {
+		t.Run(k, func(t *testing.T) {
+			m := openModel(t)
+			m, cmd := pressKey(m, k)
+			if cmd == nil {
+				t.Fatal("Expected close command")
+			}
+			msg := cmd()
+			if _, ok := msg.(CloseMsg); !ok {
+				t.Fatalf("Expected CloseMsg, got %T", msg)
+			}
+			updated, _ := m.Update(msg)
+			m = updated.(Model)
+			if m.Focused() {
+				t.Error("Expected model to be unfocused after CloseMsg")
+			}
+		})
+	}
+}
+
+func TestUpdate_IgnoresKeysWhenUnfocused(t *testing.T) {
+	m := New()
+	m, cmd := pressKey(m, "esc")
+	if cmd != nil {
+		t.Error("Expected no command when unfocused")
+	}
+	if m.Focused() {
+		t.Error("Expected model to stay unfocused")
+	}
+}
+
+func TestView_SessionStats(t *testing.T) {
+	m := New()
+	updated, _ := m.Update(OpenMsg{
+		Requests: testRequests(),
+		Stats: firefly.SessionStats{
+			Since:        time.Now().Add(-10 * time.Minute),
+			Requests:     40,
+			Failed:       2,
+			BytesIn:      3 << 20,
+			BytesOut:     2048,
+			CacheLookups: 20,
+			CacheHits:    5,
+			Latency:      40 * 250 * time.Millisecond,
+		},
+	})
+	m = updated.(Model)
+	m.WithSize(160, 24)
+	view := m.View()
+
+	for _, want := range []string{
+		"40 requests (2 failed)",
+		"avg 250ms",
+		"3.0 MB in",
+		"2.0 KB out",
+		"cache hits 25% (5/20)",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected view to contain %q", want)
+		}
+	}
+}
+
+func TestView_NoSessionStats(t *testing.T) {
+	m := openModel(t)
+	if view := m.View(); !strings.Contains(view, "no requests yet") {
+		t.Error("Expected a note that the session sent no requests")
+	}
+}
@@ -0,0 +1,211 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package assetform
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/formkit"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+)
+
+// OpenMsg opens the form, Currency is the code filled in by default.
+type OpenMsg struct {
+	Currency string
+}
+
+// SubmitMsg carries the account to create once the form is completed.
+type SubmitMsg struct {
+	Asset firefly.NewAsset
+}
+
+type CloseMsg struct{}
+
+// Roles are the asset account roles known to Firefly III.
+var Roles = []huh.Option[string]{
+	huh.NewOption("Default", "defaultAsset"),
+	huh.NewOption("Savings", "savingAsset"),
+	huh.NewOption("Shared", "sharedAsset"),
+	huh.NewOption("Credit card", "ccAsset"),
+}
+
+type values struct {
+	name           string
+	currency       string
+	role           string
+	openingBalance string
+	openingDate    string
+}
+
+type Model struct {
+	form   *huh.Form
+	values *values
+	focus  bool
+	styles formkit.Styles
+	Width  int
+	Height int
+}
+
+func New() Model {
+	return Model{
+		styles: formkit.DefaultStyles(),
+		Width:  80,
+		Height: 24,
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case OpenMsg:
+		m.values = &values{
+			currency:    msg.Currency,
+			role:        "defaultAsset",
+			openingDate: time.Now().Format(time.DateOnly),
+		}
+		m.form = newForm(m.values)
+		m.Focus()
+		return m, m.form.Init()
+	case CloseMsg:
+		m.Blur()
+		return m, nil
+	}
+
+	if !m.focus {
+		return m, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok && msg.String() == "esc" {
+		return m, Close()
+	}
+
+	form, cmd := m.form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.form = f
+	}
+	if m.form.State == huh.StateCompleted {
+		m.Blur()
+		asset := m.values.asset()
+		return m, func() tea.Msg {
+			return SubmitMsg{Asset: asset}
+		}
+	}
+	return m, cmd
+}
+
+func (m Model) View() string {
+	if !m.focus {
+		return ""
+	}
+
+	return m.styles.Frame("New asset account", m.form.View(), m.Width, m.Height)
+}
+
+func newForm(v *values) *huh.Form {
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Name").
+				Value(&v.name).
+				Validate(func(s string) error {
+					if strings.TrimSpace(s) == "" {
+						return errors.New("name is required")
+					}
+					return nil
+				}),
+			huh.NewInput().
+				Title("Currency").
+				Value(&v.currency).
+				Validate(func(s string) error {
+					if strings.TrimSpace(s) == "" {
+						return errors.New("currency is required")
+					}
+					return nil
+				}),
+			huh.NewSelect[string]().
+				Title("Role").
+				Options(Roles...).
+				Value(&v.role),
+			huh.NewInput().
+				Title("Opening balance").
+				Placeholder("0").
+				Value(&v.openingBalance).
+				Validate(func(s string) error {
+					if strings.TrimSpace(s) == "" {
+						return nil
+					}
+					if _, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err != nil {
+						return errors.New("please enter a number")
+					}
+					return nil
+				}),
+			huh.NewInput().
+				Title("Opening date").
+				Placeholder(time.DateOnly).
+				Value(&v.openingDate).
+				Validate(func(s string) error {
+					if _, err := time.Parse(time.DateOnly, strings.TrimSpace(s)); err != nil {
+						return errors.New("please enter a date as YYYY-MM-DD")
+					}
+					return nil
+				}),
+		),
+	).WithShowHelp(false)
+}
+
+func (v *values) asset() firefly.NewAsset {
+	balance, _ := strconv.ParseFloat(strings.TrimSpace(v.openingBalance), 64)
+	return firefly.NewAsset{
+		Name:               strings.TrimSpace(v.name),
+		CurrencyCode:       strings.TrimSpace(v.currency),
+		Role:               v.role,
+		OpeningBalance:     balance,
+		OpeningBalanceDate: strings.TrimSpace(v.openingDate),
+	}
+}
+
+func (m *Model) Focus() {
+	m.focus = true
+}
+
+func (m *Model) Blur() {
+	m.focus = false
+}
+
+func (m *Model) Focused() bool {
+	return m.focus
+}
+
+func (m *Model) WithSize(width, height int) *Model {
+	m.Width = width
+	m.Height = height
+	return m
+}
+
+func (m *Model) WithStyles(styles formkit.Styles) *Model {
+	m.styles = styles
+	return m
+}
+
+func Open(currency string) tea.Cmd {
+	return func() tea.Msg {
+		return OpenMsg{Currency: currency}
+	}
+}
+
+func Close() tea.Cmd {
+	return func() tea.Msg {
+		return CloseMsg{}
+	}
+}
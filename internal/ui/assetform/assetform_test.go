@@ -0,0 +1,114 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package assetform
+
+import (
+	"strings"
+	"testing"
+
+	"ffiii-tui/internal/ui/formkit/formtest"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var form = formtest.Harness[Model]{Out: func(msg tea.Msg) bool {
+	switch msg.(type) {
+	case SubmitMsg, CloseMsg:
+		return true
+	}
+	return false
+}}
+
+func openForm(t *testing.T) Model {
+	t.Helper()
+	m, _ := form.Send(New(), OpenMsg{Currency: "EUR"})
+	if !m.Focused() {
+		t.Fatal("Expected form to be focused after OpenMsg")
+	}
+	return m
+}
+
+func TestNew(t *testing.T) {
+	m := New()
+
+	if m.Focused() {
+		t.Error("Expected new model to be unfocused")
+	}
+	if m.View() != "" {
+		t.Error("Expected empty view when unfocused")
+	}
+}
+
+func TestView(t *testing.T) {
+	m := openForm(t)
+	view := m.View()
+
+	for _, want := range []string{"New asset account", "Name", "Currency", "Role", "Opening balance", "Opening date"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected view to contain %q", want)
+		}
+	}
+}
+
+func TestUpdate_Submit(t *testing.T) {
+	m := openForm(t)
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+
+	m = form.TypeText(m, "Travel card")
+	m, _ = form.Send(m, enter)
+	m, _ = form.Send(m, enter) // keep the default currency
+	m, _ = form.Send(m, tea.KeyMsg{Type: tea.KeyDown})
+	m, _ = form.Send(m, enter) // savings
+	m = form.TypeText(m, "250.5")
+	m, _ = form.Send(m, enter)
+	m, _ = form.Send(m, tea.KeyMsg{Type: tea.KeyCtrlU})
+	m = form.TypeText(m, "2026-01-01")
+	m, msgs := form.Send(m, enter)
+
+	if len(msgs) != 1 {
+		t.Fatalf("Expected a single SubmitMsg, got %v", msgs)
+	}
+	submit, ok := msgs[0].(SubmitMsg)
+	if !ok {
+		t.Fatalf("Expected SubmitMsg, got %T", msgs[0])
+	}
+	asset := submit.Asset
+	if asset.Name != "Travel card" || asset.CurrencyCode != "EUR" || asset.Role != "savingAsset" ||
+		asset.OpeningBalance != 250.5 || asset.OpeningBalanceDate != "2026-01-01" {
+		t.Errorf("Unexpected asset: %+v", asset)
+	}
+	if m.Focused() {
+		t.Error("Expected form to close after submit")
+	}
+}
+
+func TestUpdate_NameRequired(t *testing.T) {
+	m := openForm(t)
+
+	m, msgs := form.Send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if len(msgs) != 0 {
+		t.Fatalf("Expected no messages, got %v", msgs)
+	}
+	if !strings.Contains(m.View(), "name is required") {
+		t.Error("Expected validation error in view")
+	}
+}
+
+func TestUpdate_Close(t *testing.T) {
+	m := openForm(t)
+
+	m, msgs := form.Send(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if len(msgs) != 1 {
+		t.Fatalf("Expected CloseMsg, got %v", msgs)
+	}
+	if _, ok := msgs[0].(CloseMsg); !ok {
+		t.Fatalf("Expected CloseMsg, got %T", msgs[0])
+	}
+	updated, _ := m.Update(CloseMsg{})
+	m = updated.(Model)
+	if m.Focused() {
+		t.Error("Expected model to be unfocused after CloseMsg")
+	}
+}
@@ -5,10 +5,15 @@ SPDX-License-Identifier: Apache-2.0
 package ui
 
 import (
+	"context"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/assetform"
+	"ffiii-tui/internal/ui/money"
 	"ffiii-tui/internal/ui/notify"
 	"ffiii-tui/internal/ui/prompt"
 
@@ -19,32 +24,25 @@ import (
 type (
 	RefreshAssetsMsg struct{}
 	AssetsUpdateMsg  struct{}
-	NewAssetMsg      struct {
-		Account  string
-		Currency string
-	}
 )
 
 type assetItem = accountListItem[firefly.Account]
 
 type modelAssets struct {
-	AccountListModel[firefly.Account]
+	AccountListModel[firefly.Account, AssetAPI]
 }
 
 func newModelAssets(api AssetAPI) modelAssets {
-	config := &AccountListConfig[firefly.Account]{
+	config := &AccountListConfig[firefly.Account, AssetAPI]{
 		AccountType: "asset",
 		Title:       "Asset accounts",
-		GetItems: func(apiInterface any, sorted bool) []list.Item {
-			return getAssetsItems(apiInterface.(AssetAPI))
-		},
-		RefreshItems: func(apiInterface any, accountType string) error {
-			return apiInterface.(AssetAPI).UpdateAccounts(accountType)
+		GetItems: func(api AssetAPI, sorted bool) []list.Item {
+			return getAssetsItems(api)
 		},
 		RefreshMsgType: RefreshAssetsMsg{},
 		UpdateMsgType:  AssetsUpdateMsg{},
 		PromptNewFunc: func() tea.Cmd {
-			return CmdPromptNewAsset(SetView(assetsView))
+			return assetform.Open(api.PrimaryCurrency().Code)
 		},
 		HasSort:     false,
 		HasTotalRow: false,
@@ -65,6 +63,14 @@ func newModelAssets(api AssetAPI) modelAssets {
 			cmds = append(cmds, SetView(transactionsView))
 			return tea.Sequence(cmds...)
 		},
+		DetailsFunc: accountDetails(api),
+		PayCardFunc: func(item list.Item) tea.Cmd {
+			i, ok := item.(assetItem)
+			if !ok {
+				return nil
+			}
+			return payCreditCard(api, i.Entity)
+		},
 	}
 	return modelAssets{
 		AccountListModel: NewAccountListModel(api, config),
@@ -76,21 +82,20 @@ func (m modelAssets) Init() tea.Cmd {
 }
 
 func (m modelAssets) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	if newMsg, ok := msg.(NewAssetMsg); ok {
-		api := m.api.(AssetAPI)
-		err := api.CreateAssetAccount(newMsg.Account, newMsg.Currency)
+	if newMsg, ok := msg.(assetform.SubmitMsg); ok {
+		err := m.api.CreateAssetAccount(context.Background(), newMsg.Asset)
 		if err != nil {
-			return m, notify.NotifyWarn(err.Error())
+			return m, notify.NotifyWarn(errorText(err))
 		}
 		return m, tea.Batch(
 			Cmd(RefreshAssetsMsg{}),
-			notify.NotifyLog(fmt.Sprintf("Asset account '%s' created", newMsg.Account)),
+			notify.NotifyLog(fmt.Sprintf("Asset account '%s' created", newMsg.Asset.Name)),
 		)
 	}
 
 	if _, ok := msg.(RefreshAssetsMsg); ok {
 		updated, cmd := m.AccountListModel.Update(msg)
-		m.AccountListModel = updated.(AccountListModel[firefly.Account])
+		m.AccountListModel = updated.(AccountListModel[firefly.Account, AssetAPI])
 		if cmd != nil {
 			return m, tea.Batch(
 				cmd,
@@ -99,44 +104,95 @@ func (m modelAssets) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	}
 	updated, cmd := m.AccountListModel.Update(msg)
-	m.AccountListModel = updated.(AccountListModel[firefly.Account])
+	m.AccountListModel = updated.(AccountListModel[firefly.Account, AssetAPI])
 	return m, cmd
 }
 
-func getAssetsItems(api AccountsAPI) []list.Item {
+func getAssetsItems(api AssetAPI) []list.Item {
 	items := []list.Item{}
-	for _, account := range api.AccountsByType("asset") {
-		items = append(items, newAccountListItem(
+	rate := api.BurnRate()
+	data := fromStore[accountsReader](api)
+	alerts := loadBalanceAlerts()
+	amounts := money.New(api)
+	for _, account := range data.AccountsByType("asset") {
+		balance := data.AccountBalance(account.ID)
+		item := convertedToPrimary(newAccountListItem(
 			account,
 			"Balance",
-			api.AccountBalance(account.ID),
-		))
+			balance,
+			amounts,
+		), api)
+		item.alert = alerts.level(account.Name, balance)
+		if account.IsCreditCard() {
+			item.extra = creditCardSummary(account)
+		} else {
+			item.extra = projectionSummary(rate, account, balance)
+		}
+		items = append(items, item)
 	}
 	return items
 }
 
-func CmdPromptNewAsset(backCmd tea.Cmd) tea.Cmd {
+// creditCardSummary returns the limit and monthly payment day of a credit
+// card, e.g. "limit 2000.00, paid on day 15".
+func creditCardSummary(card firefly.Account) string {
+	parts := []string{}
+	if card.CreditLimit != 0 {
+		parts = append(parts, "limit "+money.ShortNumber(card.CreditLimit))
+	}
+	if day := paymentDay(card.MonthlyPaymentDate); day != "" {
+		parts = append(parts, "paid on day "+day)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// paymentDay returns the day of the month of a monthly payment date.
+func paymentDay(date string) string {
+	d, err := time.Parse(time.DateOnly, transactionDay(date))
+	if err != nil {
+		return ""
+	}
+	return strconv.Itoa(d.Day())
+}
+
+// payCreditCard asks for the asset account paying off card and opens the
+// transfer of the outstanding balance in the transaction form.
+func payCreditCard(api AssetAPI, card firefly.Account) tea.Cmd {
+	if !card.IsCreditCard() {
+		return notify.NotifyWarn(fmt.Sprintf("'%s' is not a credit card", card.Name))
+	}
+	suggested := ""
+	for _, a := range api.AccountsByType("asset") {
+		if !a.IsCreditCard() && a.CurrencyCode == card.CurrencyCode {
+			suggested = a.Name
+			break
+		}
+	}
+	owed := max(-api.AccountBalance(card.ID), 0)
 	return prompt.Ask(
-		"New Asset(<name>,<currency>): ",
-		"",
+		fmt.Sprintf("Pay %s from asset account: ", card.Name),
+		suggested,
 		func(value string) tea.Cmd {
-			var cmds []tea.Cmd
-			if value != "None" {
-				split := strings.SplitN(value, ",", 2)
-				if len(split) >= 2 {
-					acc := strings.TrimSpace(split[0])
-					cur := strings.TrimSpace(split[1])
-					if acc != "" && cur != "" {
-						cmds = append(cmds, Cmd(NewAssetMsg{Account: acc, Currency: cur}))
-					} else {
-						cmds = append(cmds, notify.NotifyWarn("Invalid asset name or currency"))
-					}
-				} else {
-					cmds = append(cmds, notify.NotifyWarn("Invalid asset name or currency"))
+			if value == "None" {
+				return SetView(assetsView)
+			}
+			for _, source := range api.AccountsByType("asset") {
+				if strings.EqualFold(source.Name, strings.TrimSpace(value)) && source.ID != card.ID {
+					return Cmd(NewTransactionFromMsg{Transaction: firefly.Transaction{
+						Type: "transfer",
+						Splits: []firefly.Split{{
+							Source:      source,
+							Destination: card,
+							Amount:      owed,
+							Description: "Credit card payment",
+						}},
+					}})
 				}
 			}
-			cmds = append(cmds, backCmd)
-			return tea.Sequence(cmds...)
+			return tea.Sequence(
+				notify.NotifyWarn(fmt.Sprintf("Unknown asset account '%s'", value)),
+				SetView(assetsView),
+			)
 		},
 	)
 }
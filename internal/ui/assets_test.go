@@ -6,30 +6,36 @@ SPDX-License-Identifier: Apache-2.0
 package ui
 
 import (
+	"context"
 	"errors"
 	"reflect"
 	"testing"
 
 	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/accountdetail"
+	"ffiii-tui/internal/ui/assetform"
+	"ffiii-tui/internal/ui/loading"
 	"ffiii-tui/internal/ui/notify"
 	"ffiii-tui/internal/ui/prompt"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/viper"
 )
 
 type mockAssetAPI struct {
 	updateAccountsFunc       func(accountType string) error
 	accountsByTypeFunc       func(accountType string) []firefly.Account
 	accountBalanceFunc       func(accountID string) float64
-	createAssetAccountFunc   func(name, currencyCode string) error
+	accountActivityFunc      func(accountID string) string
+	convertToPrimaryFunc     func(amount float64, currencyCode string) (float64, bool)
+	createAssetAccountFunc   func(na firefly.NewAsset) error
+	burnRate                 firefly.BurnRate
 	updateAccountsCalledWith []string
-	createAssetCalledWith    []struct {
-		name, currency string
-	}
+	createAssetCalledWith    []firefly.NewAsset
 }
 
-func (m *mockAssetAPI) UpdateAccounts(accountType string) error {
+func (m *mockAssetAPI) UpdateAccounts(_ context.Context, accountType string) error {
 	m.updateAccountsCalledWith = append(m.updateAccountsCalledWith, accountType)
 	if m.updateAccountsFunc != nil {
 		return m.updateAccountsFunc(accountType)
@@ -51,12 +57,36 @@ func (m *mockAssetAPI) AccountBalance(accountID string) float64 {
 	return 0
 }
 
-func (m *mockAssetAPI) CreateAssetAccount(name, currencyCode string) error {
-	m.createAssetCalledWith = append(m.createAssetCalledWith, struct {
-		name, currency string
-	}{name: name, currency: currencyCode})
+func (m *mockAssetAPI) AccountLastActivity(accountID string) string {
+	if m.accountActivityFunc != nil {
+		return m.accountActivityFunc(accountID)
+	}
+	return ""
+}
+
+func (m *mockAssetAPI) ConvertToPrimary(amount float64, currencyCode string) (float64, bool) {
+	if m.convertToPrimaryFunc != nil {
+		return m.convertToPrimaryFunc(amount, currencyCode)
+	}
+	return amount, false
+}
+
+func (m *mockAssetAPI) PrimaryCurrency() firefly.Currency {
+	return firefly.Currency{Code: "EUR", Symbol: "€"}
+}
+
+func (m *mockAssetAPI) GetCurrencyByCode(code string) firefly.Currency {
+	return firefly.Currency{Code: code}
+}
+
+func (m *mockAssetAPI) BurnRate() firefly.BurnRate {
+	return m.burnRate
+}
+
+func (m *mockAssetAPI) CreateAssetAccount(_ context.Context, na firefly.NewAsset) error {
+	m.createAssetCalledWith = append(m.createAssetCalledWith, na)
 	if m.createAssetAccountFunc != nil {
-		return m.createAssetAccountFunc(name, currencyCode)
+		return m.createAssetAccountFunc(na)
 	}
 	return nil
 }
@@ -68,10 +98,32 @@ func collectMsgsFromCmd(cmd tea.Cmd) []tea.Msg {
 	return collectMsgsFromMsg(cmd())
 }
 
+// trackedMsg runs a command shown by the loading indicator and returns the
+// message of the command itself.
+func trackedMsg(cmd tea.Cmd) tea.Msg {
+	msg := cmd()
+	rv := reflect.ValueOf(msg)
+	if rv.Kind() != reflect.Slice || rv.Len() != 2 {
+		return msg
+	}
+	start, ok := rv.Index(0).Interface().(tea.Cmd)
+	if !ok {
+		return msg
+	}
+	if _, ok := start().(loading.StartMsg); !ok {
+		return msg
+	}
+	return rv.Index(1).Interface().(tea.Cmd)()
+}
+
 func collectMsgsFromMsg(msg tea.Msg) []tea.Msg {
 	if msg == nil {
 		return nil
 	}
+	// The loading indicator is left out
+	if _, ok := msg.(loading.StartMsg); ok {
+		return nil
+	}
 
 	// Both tea.BatchMsg and tea.sequenceMsg are slices of tea.Cmd.
 	rv := reflect.ValueOf(msg)
@@ -200,8 +252,11 @@ func TestModelAssets_RefreshAssets_Error(t *testing.T) {
 	}
 
 	msgs := collectMsgsFromCmd(cmd)
-	if len(msgs) != 2 {
-		t.Fatalf("expected 2 messages, got %d (%T)", len(msgs), msgs)
+	if len(msgs) != 3 {
+		t.Fatalf("expected 3 messages, got %d (%T)", len(msgs), msgs)
+	}
+	if !hasMsg[DataLoadFailedMsg](msgs) {
+		t.Fatalf("expected DataLoadFailedMsg, got %v", msgs)
 	}
 	notifyMsg, ok := msgs[0].(notify.NotifyMsg)
 	if !ok {
@@ -222,13 +277,13 @@ func TestModelAssets_RefreshAssets_Error(t *testing.T) {
 func TestModelAssets_NewAsset_Error(t *testing.T) {
 	expectedErr := errors.New("create failed")
 	api := &mockAssetAPI{
-		createAssetAccountFunc: func(name, currencyCode string) error {
+		createAssetAccountFunc: func(na firefly.NewAsset) error {
 			return expectedErr
 		},
 	}
 	m := newModelAssets(api)
 
-	_, cmd := m.Update(NewAssetMsg{Account: "My Asset", Currency: "usd"})
+	_, cmd := m.Update(assetform.SubmitMsg{Asset: firefly.NewAsset{Name: "My Asset", CurrencyCode: "usd"}})
 	if cmd == nil {
 		t.Fatal("expected cmd")
 	}
@@ -248,7 +303,7 @@ func TestModelAssets_NewAsset_Error(t *testing.T) {
 	if len(api.createAssetCalledWith) != 1 {
 		t.Fatalf("expected CreateAssetAccount called once, got %d", len(api.createAssetCalledWith))
 	}
-	if api.createAssetCalledWith[0].name != "My Asset" || api.createAssetCalledWith[0].currency != "usd" {
+	if api.createAssetCalledWith[0].Name != "My Asset" || api.createAssetCalledWith[0].CurrencyCode != "usd" {
 		t.Fatalf("unexpected CreateAssetAccount args: %+v", api.createAssetCalledWith[0])
 	}
 }
@@ -257,7 +312,7 @@ func TestModelAssets_NewAsset_Success(t *testing.T) {
 	api := &mockAssetAPI{}
 	m := newModelAssets(api)
 
-	_, cmd := m.Update(NewAssetMsg{Account: "My Asset", Currency: "usd"})
+	_, cmd := m.Update(assetform.SubmitMsg{Asset: firefly.NewAsset{Name: "My Asset", CurrencyCode: "usd"}})
 	if cmd == nil {
 		t.Fatal("expected cmd")
 	}
@@ -287,7 +342,7 @@ func TestModelAssets_NewAsset_Success(t *testing.T) {
 	if len(api.createAssetCalledWith) != 1 {
 		t.Fatalf("expected CreateAssetAccount called once, got %d", len(api.createAssetCalledWith))
 	}
-	if api.createAssetCalledWith[0].name != "My Asset" || api.createAssetCalledWith[0].currency != "usd" {
+	if api.createAssetCalledWith[0].Name != "My Asset" || api.createAssetCalledWith[0].CurrencyCode != "usd" {
 		t.Fatalf("unexpected CreateAssetAccount args: %+v", api.createAssetCalledWith[0])
 	}
 }
@@ -505,7 +560,7 @@ func TestModelAssets_KeySelect_SequencesFilterAndView(t *testing.T) {
 	}
 }
 
-func TestModelAssets_KeyNew_ReturnsPromptMsg(t *testing.T) {
+func TestModelAssets_KeyNew_OpensForm(t *testing.T) {
 	m := newFocusedAssetsModelWithAccount(t, firefly.Account{ID: "a1", Name: "Checking", CurrencyCode: "USD", Type: "asset"})
 
 	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
@@ -514,15 +569,12 @@ func TestModelAssets_KeyNew_ReturnsPromptMsg(t *testing.T) {
 	}
 
 	msg := cmd()
-	p, ok := msg.(prompt.PromptMsg)
+	open, ok := msg.(assetform.OpenMsg)
 	if !ok {
-		t.Fatalf("expected prompt.PromptMsg, got %T", msg)
-	}
-	if p.Prompt != "New Asset(<name>,<currency>): " {
-		t.Fatalf("unexpected prompt: %q", p.Prompt)
+		t.Fatalf("expected assetform.OpenMsg, got %T", msg)
 	}
-	if p.Callback == nil {
-		t.Fatal("expected callback")
+	if open.Currency != "EUR" {
+		t.Fatalf("expected primary currency prefilled, got %q", open.Currency)
 	}
 }
 
@@ -588,104 +640,6 @@ func TestModelAssets_KeyViewNavigation(t *testing.T) {
 	}
 }
 
-func TestCmdPromptNewAsset_EmitsPromptMsgWithCallback(t *testing.T) {
-	backCmd := Cmd(SetFocusedViewMsg{state: assetsView})
-	cmd := CmdPromptNewAsset(backCmd)
-
-	msg := cmd()
-	p, ok := msg.(prompt.PromptMsg)
-	if !ok {
-		t.Fatalf("expected prompt.PromptMsg, got %T", msg)
-	}
-	if p.Prompt != "New Asset(<name>,<currency>): " {
-		t.Fatalf("unexpected prompt: %q", p.Prompt)
-	}
-	if p.Callback == nil {
-		t.Fatal("expected callback")
-	}
-}
-
-func TestCmdPromptNewAsset_CallbackValid_EmitsNewAssetMsgAndBackCmd(t *testing.T) {
-	backCmd := Cmd(SetFocusedViewMsg{state: assetsView})
-	cmd := CmdPromptNewAsset(backCmd)
-
-	p := cmd().(prompt.PromptMsg)
-	msgs := collectMsgsFromCmd(p.Callback("  My Asset  , usd  "))
-
-	if len(msgs) != 2 {
-		t.Fatalf("expected 2 messages, got %d (%T)", len(msgs), msgs)
-	}
-
-	newAsset, ok := msgs[0].(NewAssetMsg)
-	if !ok {
-		t.Fatalf("expected NewAssetMsg, got %T", msgs[0])
-	}
-	if newAsset.Account != "My Asset" {
-		t.Fatalf("expected account 'My Asset', got %q", newAsset.Account)
-	}
-	if newAsset.Currency != "usd" {
-		t.Fatalf("expected currency 'usd', got %q", newAsset.Currency)
-	}
-
-	focused, ok := msgs[1].(SetFocusedViewMsg)
-	if !ok {
-		t.Fatalf("expected SetFocusedViewMsg, got %T", msgs[1])
-	}
-	if focused.state != assetsView {
-		t.Fatalf("expected assetsView, got %v", focused.state)
-	}
-}
-
-func TestCmdPromptNewAsset_CallbackInvalid_EmitsWarnAndBackCmd(t *testing.T) {
-	backCmd := Cmd(SetFocusedViewMsg{state: assetsView})
-	cmd := CmdPromptNewAsset(backCmd)
-
-	p := cmd().(prompt.PromptMsg)
-	msgs := collectMsgsFromCmd(p.Callback("invalid"))
-
-	if len(msgs) != 2 {
-		t.Fatalf("expected 2 messages, got %d (%T)", len(msgs), msgs)
-	}
-
-	n, ok := msgs[0].(notify.NotifyMsg)
-	if !ok {
-		t.Fatalf("expected notify.NotifyMsg, got %T", msgs[0])
-	}
-	if n.Level != notify.Warn {
-		t.Fatalf("expected warn notify level, got %v", n.Level)
-	}
-	if n.Message != "Invalid asset name or currency" {
-		t.Fatalf("unexpected notify message: %q", n.Message)
-	}
-
-	focused, ok := msgs[1].(SetFocusedViewMsg)
-	if !ok {
-		t.Fatalf("expected SetFocusedViewMsg, got %T", msgs[1])
-	}
-	if focused.state != assetsView {
-		t.Fatalf("expected assetsView, got %v", focused.state)
-	}
-}
-
-func TestCmdPromptNewAsset_CallbackNone_EmitsOnlyBackCmd(t *testing.T) {
-	backCmd := Cmd(SetFocusedViewMsg{state: assetsView})
-	cmd := CmdPromptNewAsset(backCmd)
-
-	p := cmd().(prompt.PromptMsg)
-	msgs := collectMsgsFromCmd(p.Callback("None"))
-
-	if len(msgs) != 1 {
-		t.Fatalf("expected 1 message, got %d (%T)", len(msgs), msgs)
-	}
-	focused, ok := msgs[0].(SetFocusedViewMsg)
-	if !ok {
-		t.Fatalf("expected SetFocusedViewMsg, got %T", msgs[0])
-	}
-	if focused.state != assetsView {
-		t.Fatalf("expected assetsView, got %v", focused.state)
-	}
-}
-
 // Edge case tests
 
 func TestModelAssets_EmptyAccountList(t *testing.T) {
@@ -864,8 +818,8 @@ func TestModelAssets_MissingCurrencyCode(t *testing.T) {
 
 	// Verify description handles empty currency
 	desc := item.Description()
-	if desc != "Balance: 100.00 " {
-		t.Errorf("expected description %q, got %q", "Balance: 100.00 ", desc)
+	if desc != "Balance: 100.00" {
+		t.Errorf("expected description %q, got %q", "Balance: 100.00", desc)
 	}
 }
 
@@ -984,3 +938,139 @@ func TestModelAssets_UpdatePositions_WithVariousDimensions(t *testing.T) {
 		})
 	}
 }
+
+func TestModelAssets_Details(t *testing.T) {
+	acc := firefly.Account{ID: "a1", Name: "Checking", CurrencyCode: "EUR", IBAN: "DE89 3704 0044 0532 0130 00"}
+	api := &mockAssetAPI{
+		accountsByTypeFunc:  func(accountType string) []firefly.Account { return []firefly.Account{acc} },
+		accountBalanceFunc:  func(accountID string) float64 { return 150 },
+		accountActivityFunc: func(accountID string) string { return "2026-01-14" },
+	}
+	m := newModelAssets(api)
+	(&m).Focus()
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	if cmd == nil {
+		t.Fatal("Expected command from details key")
+	}
+	open, ok := cmd().(accountdetail.OpenMsg)
+	if !ok {
+		t.Fatal("Expected accountdetail.OpenMsg")
+	}
+	if open.Account != acc || open.Balance != 150 || open.LastActivity != "2026-01-14" {
+		t.Errorf("Unexpected details: %+v", open)
+	}
+}
+
+func TestModelAssets_DetailsOnEnter(t *testing.T) {
+	acc := firefly.Account{ID: "a1", Name: "Checking", CurrencyCode: "EUR"}
+
+	m := newFocusedAssetsModelWithAccount(t, acc)
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if _, ok := findMsg[accountdetail.OpenMsg](collectMsgsFromCmd(cmd)); ok {
+		t.Error("Expected enter to filter transactions by default")
+	}
+
+	viper.Set("ui.account_details_on_enter", true)
+	defer viper.Set("ui.account_details_on_enter", false)
+
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if _, ok := findMsg[accountdetail.OpenMsg](collectMsgsFromCmd(cmd)); !ok {
+		t.Error("Expected enter to open the details when configured")
+	}
+}
+
+func TestGetAssetsItems_ConvertsToPrimary(t *testing.T) {
+	api := &mockAssetAPI{
+		accountsByTypeFunc: func(accountType string) []firefly.Account {
+			return []firefly.Account{
+				{ID: "a1", Name: "Checking", CurrencyCode: "EUR"},
+				{ID: "a2", Name: "Travel", CurrencyCode: "USD"},
+			}
+		},
+		accountBalanceFunc: func(accountID string) float64 { return 100 },
+		convertToPrimaryFunc: func(amount float64, currencyCode string) (float64, bool) {
+			if currencyCode != "USD" {
+				return amount, false
+			}
+			return amount * 0.5, true
+		},
+	}
+
+	items := getAssetsItems(api)
+	if got := items[1].(assetItem).Description(); got != "Balance: 100.00 USD" {
+		t.Errorf("Expected unconverted balance by default, got %q", got)
+	}
+
+	viper.Set("ui.convert_balances", true)
+	defer viper.Set("ui.convert_balances", false)
+
+	items = getAssetsItems(api)
+	if got := items[0].(assetItem).Description(); got != "Balance: 100.00 EUR" {
+		t.Errorf("Expected primary currency balance unchanged, got %q", got)
+	}
+	if got := items[1].(assetItem).Description(); got != "Balance: 50.00 EUR (100.00 USD)" {
+		t.Errorf("Expected converted balance, got %q", got)
+	}
+}
+
+func TestGetAssetsItems_CreditCard(t *testing.T) {
+	api := &mockAssetAPI{
+		accountsByTypeFunc: func(accountType string) []firefly.Account {
+			return []firefly.Account{{
+				ID: "cc", Name: "Visa", Type: "asset", CurrencyCode: "EUR",
+				Role: "ccAsset", CreditLimit: 2000, MonthlyPaymentDate: "2026-01-15T00:00:00+01:00",
+			}}
+		},
+		accountBalanceFunc: func(accountID string) float64 { return -340 },
+	}
+
+	items := getAssetsItems(api)
+	want := "Balance: -340.00 EUR | limit 2000.00, paid on day 15"
+	if got := items[0].(assetItem).Description(); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestPayCreditCard(t *testing.T) {
+	card := firefly.Account{ID: "cc", Name: "Visa", Type: "asset", CurrencyCode: "EUR", Role: "ccAsset"}
+	checking := firefly.Account{ID: "a1", Name: "Checking", Type: "asset", CurrencyCode: "EUR"}
+	api := &mockAssetAPI{
+		accountsByTypeFunc: func(accountType string) []firefly.Account {
+			return []firefly.Account{card, checking}
+		},
+		accountBalanceFunc: func(accountID string) float64 { return -340 },
+	}
+
+	p, ok := payCreditCard(api, card)().(prompt.PromptMsg)
+	if !ok {
+		t.Fatal("expected prompt.PromptMsg")
+	}
+	if p.Value != "Checking" {
+		t.Errorf("expected the first other asset suggested, got %q", p.Value)
+	}
+
+	msgs := collectMsgsFromCmd(p.Callback("checking"))
+	newTx, ok := findMsg[NewTransactionFromMsg](msgs)
+	if !ok {
+		t.Fatalf("expected NewTransactionFromMsg, got %v", msgs)
+	}
+	split := newTx.Transaction.Splits[0]
+	if split.Source != checking || split.Destination != card || split.Amount != 340 {
+		t.Errorf("unexpected payment split: %+v", split)
+	}
+
+	msgs = collectMsgsFromCmd(p.Callback("Unknown"))
+	if n, ok := findMsg[notify.NotifyMsg](msgs); !ok || n.Level != notify.Warn {
+		t.Errorf("expected warning for unknown account, got %v", msgs)
+	}
+}
+
+func TestPayCreditCard_NotACard(t *testing.T) {
+	api := &mockAssetAPI{}
+	msg := payCreditCard(api, firefly.Account{ID: "a1", Name: "Checking", Type: "asset"})()
+	n, ok := msg.(notify.NotifyMsg)
+	if !ok || n.Level != notify.Warn {
+		t.Fatalf("expected warning, got %v", msg)
+	}
+}
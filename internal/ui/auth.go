@@ -0,0 +1,94 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/loading"
+	"ffiii-tui/internal/ui/notify"
+	"ffiii-tui/internal/ui/prompt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"go.uber.org/zap"
+)
+
+// SaveTokenFunc stores a replaced API token for the named profile.
+type SaveTokenFunc func(profile, token string) error
+
+// tokenCheckedMsg reports whether the server accepted a new API token.
+type tokenCheckedMsg struct {
+	Token string
+	Err   error
+}
+
+// tokenCanceledMsg reports that no new API token was entered.
+type tokenCanceledMsg struct{}
+
+// checkAuth asks for a new API token once the server rejected the current
+// one. The failures of the panels are not reported meanwhile, they all
+// have the same cause.
+func (m *modelUI) checkAuth() tea.Cmd {
+	if !m.api.Unauthorized() || m.askingToken || m.tokenDismissed {
+		return nil
+	}
+	return m.askToken("API token was rejected, paste a new one: ")
+}
+
+func (m *modelUI) askToken(text string) tea.Cmd {
+	m.askingToken = true
+	m.tokenDismissed = false
+	api := m.api
+	return prompt.AskSecret(text, func(value string) tea.Cmd {
+		token := strings.TrimSpace(value)
+		if token == "" || token == "None" {
+			return Cmd(tokenCanceledMsg{})
+		}
+		return loading.Track("Checking API token...", func() tea.Msg {
+			api.SetAPIKey(token)
+			_, err := api.GetAbout(context.Background())
+			return tokenCheckedMsg{Token: token, Err: err}
+		})
+	})
+}
+
+// tokenChecked saves an accepted token and reloads everything, a rejected
+// one is asked for again.
+func (m *modelUI) tokenChecked(msg tokenCheckedMsg) tea.Cmd {
+	m.askingToken = false
+	if errors.Is(msg.Err, firefly.ErrUnauthorized) || m.api.Unauthorized() {
+		return m.askToken("API token was rejected, paste another one: ")
+	}
+	if msg.Err != nil {
+		return notify.NotifyWarn(fmt.Sprintf("Failed to check API token: %v", msg.Err))
+	}
+
+	cmds := []tea.Cmd{
+		Cmd(RefreshAllMsg{}),
+		notify.NotifyLog("API token replaced"),
+	}
+	if m.saveToken != nil {
+		if err := m.saveToken(activeProfile(), msg.Token); err != nil {
+			zap.L().Warn("Failed to save API token", zap.Error(err))
+			cmds = append(cmds, notify.NotifyWarn(fmt.Sprintf("Failed to save API token: %v", err)))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// tokenCanceled keeps requests paused until a token is entered with the
+// retry key.
+func (m *modelUI) tokenCanceled() tea.Cmd {
+	m.askingToken = false
+	m.tokenDismissed = true
+	// Logged, warnings are dropped while the token is rejected
+	return notify.NotifyLog(fmt.Sprintf(
+		"Requests paused, press %s to enter a new API token",
+		m.keymap.Retry.Help().Key))
+}
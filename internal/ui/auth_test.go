@@ -0,0 +1,132 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/notify"
+	"ffiii-tui/internal/ui/prompt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestUI_RejectedTokenAsksOnce(t *testing.T) {
+	api := newTestUIAPI()
+	api.unauthorized = true
+	m := NewModelUI(api)
+
+	model, cmd := m.Update(notify.NotifyMsg{Message: "Failed to load accounts", Level: notify.Warn})
+	m = model.(modelUI)
+	msgs := collectMsgsFromCmd(cmd)
+	ask, ok := findMsg[prompt.PromptMsg](msgs)
+	if !ok || !ask.Secret {
+		t.Fatalf("expected a secret prompt for a new token, got %v", msgs)
+	}
+	if hasMsg[notify.NotifyMsg](msgs) {
+		t.Error("expected the warning to be dropped")
+	}
+
+	_, cmd = m.Update(notify.NotifyMsg{Message: "Failed to load categories", Level: notify.Warn})
+	if msgs := collectMsgsFromCmd(cmd); hasMsg[prompt.PromptMsg](msgs) {
+		t.Error("expected a single prompt for all failing panels")
+	}
+}
+
+func TestUI_TokenPromptChecksToken(t *testing.T) {
+	api := newTestUIAPI()
+	api.unauthorized = true
+	m := NewModelUI(api)
+
+	ask := m.checkAuth()().(prompt.PromptMsg)
+	msgs := collectMsgsFromCmd(ask.Callback(" new-token "))
+	checked, ok := findMsg[tokenCheckedMsg](msgs)
+	if !ok || checked.Token != "new-token" || checked.Err != nil {
+		t.Fatalf("expected the token to be checked, got %v", msgs)
+	}
+	if api.apiKey != "new-token" || api.aboutCalled != 1 {
+		t.Errorf("expected the token to be set and checked, key %q, %d checks", api.apiKey, api.aboutCalled)
+	}
+}
+
+func TestUI_AcceptedTokenIsSaved(t *testing.T) {
+	api := newTestUIAPI()
+	m := NewModelUI(api)
+	var saved string
+	m.saveToken = func(profile, token string) error {
+		saved = token
+		return nil
+	}
+	m.askingToken = true
+
+	model, cmd := m.Update(tokenCheckedMsg{Token: "new-token"})
+	m = model.(modelUI)
+	msgs := collectMsgsFromCmd(cmd)
+
+	if saved != "new-token" {
+		t.Errorf("expected the token to be saved, got %q", saved)
+	}
+	if !hasMsg[RefreshAllMsg](msgs) {
+		t.Error("expected everything to reload")
+	}
+	if m.askingToken {
+		t.Error("expected the prompt to be done")
+	}
+}
+
+func TestUI_RejectedNewTokenAsksAgain(t *testing.T) {
+	api := newTestUIAPI()
+	api.unauthorized = true
+	m := NewModelUI(api)
+	saved := false
+	m.saveToken = func(profile, token string) error {
+		saved = true
+		return nil
+	}
+
+	_, cmd := m.Update(tokenCheckedMsg{Token: "bad", Err: &firefly.HTTPError{StatusCode: 401}})
+	if msgs := collectMsgsFromCmd(cmd); !hasMsg[prompt.PromptMsg](msgs) {
+		t.Errorf("expected to ask again, got %v", msgs)
+	}
+	if saved {
+		t.Error("expected a rejected token not to be saved")
+	}
+}
+
+func TestUI_CanceledTokenPausesUntilRetry(t *testing.T) {
+	api := newTestUIAPI()
+	api.unauthorized = true
+	m := NewModelUI(api)
+	m.askingToken = true
+
+	model, _ := m.Update(tokenCanceledMsg{})
+	m = model.(modelUI)
+	if cmd := m.checkAuth(); cmd != nil {
+		t.Error("expected no prompt after it was dismissed")
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(m.keymap.Retry.Keys()[0])})
+	if msgs := collectMsgsFromCmd(cmd); !hasMsg[prompt.PromptMsg](msgs) {
+		t.Errorf("expected the retry key to ask for a token, got %v", msgs)
+	}
+}
+
+func TestUI_RejectedTokenPausesRefreshes(t *testing.T) {
+	api := newTestUIAPI()
+	api.unauthorized = true
+	api.liveUpdates = make(chan firefly.WebhookEvent, 1)
+	m := NewModelUI(api)
+
+	_, cmd := m.Update(liveUpdateMsg{events: api.liveUpdates})
+	api.liveUpdates <- firefly.WebhookEvent{Trigger: "UPDATE_TRANSACTION"}
+	msgs := collectMsgsFromCmd(cmd)
+	if hasMsg[RefreshTransactionsMsg](msgs) {
+		t.Error("expected no reload while the token is rejected")
+	}
+	if !hasMsg[liveUpdateMsg](msgs) {
+		t.Error("expected to keep waiting for changes")
+	}
+}
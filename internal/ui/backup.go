@@ -0,0 +1,128 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"ffiii-tui/internal/ui/notify"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultBackupInterval is used when backup.interval is not set
+	defaultBackupInterval = 24 * time.Hour
+	// backupCheckInterval is how often a due backup is looked for, the first
+	// check waits for the start up loads
+	backupCheckInterval = time.Hour
+	backupStartDelay    = time.Minute
+)
+
+type (
+	backupTickMsg struct{}
+	backupDoneMsg struct {
+		Path         string
+		Transactions int
+		Err          error
+	}
+)
+
+// backupConfig returns the directory of the backups, empty when they are
+// off, and how often one is taken.
+func backupConfig() (dir string, interval time.Duration) {
+	interval = viper.GetDuration("backup.interval")
+	if interval <= 0 {
+		interval = defaultBackupInterval
+	}
+	return viper.GetString("backup.dir"), interval
+}
+
+func backupTick(delay time.Duration) tea.Cmd {
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return backupTickMsg{}
+	})
+}
+
+// backupName is the archive of profile taken at now, dated so they sort
+// by age.
+func backupName(profile string, now time.Time) string {
+	return fmt.Sprintf("%s-backup-%s.json", profile, now.Format("2006-01-02-150405"))
+}
+
+// lastBackup returns when the newest archive of profile in dir was written,
+// zero without any.
+func lastBackup(dir, profile string) time.Time {
+	matches, err := filepath.Glob(filepath.Join(dir, profile+"-backup-*.json"))
+	if err != nil {
+		return time.Time{}
+	}
+	var last time.Time
+	for _, path := range matches {
+		if info, err := os.Stat(path); err == nil && info.ModTime().After(last) {
+			last = info.ModTime()
+		}
+	}
+	return last
+}
+
+// backupIfDue starts a backup in the background once the interval passed
+// since the last one. Nothing is started while offline or while a backup
+// is still running.
+func (m *modelUI) backupIfDue(now time.Time) tea.Cmd {
+	dir, interval := backupConfig()
+	if dir == "" || m.backingUp || m.api.Offline() || m.api.Unauthorized() {
+		return nil
+	}
+	profile := activeProfile()
+	if now.Sub(lastBackup(dir, profile)) < interval {
+		return nil
+	}
+	m.backingUp = true
+	return runBackup(m.api, filepath.Join(dir, backupName(profile, now)), now)
+}
+
+func runBackup(api BackupAPI, path string, now time.Time) tea.Cmd {
+	return func() tea.Msg {
+		backup, err := api.Backup(context.Background(), now)
+		if err != nil {
+			return backupDoneMsg{Path: path, Err: err}
+		}
+		return backupDoneMsg{Path: path, Transactions: len(backup.Transactions), Err: writeJSONFile(path, backup)}
+	}
+}
+
+// writeJSONFile writes v through a temporary file, so an interrupted write
+// leaves no partial archive behind.
+func writeJSONFile(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", filepath.Base(path), err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	return os.Rename(tmp, path)
+}
+
+func (m *modelUI) backupDone(msg backupDoneMsg) tea.Cmd {
+	m.backingUp = false
+	if msg.Err != nil {
+		zap.L().Warn("Backup failed", zap.String("path", msg.Path), zap.Error(msg.Err))
+		return notify.NotifyWarn(fmt.Sprintf("Backup failed: %s", errorText(msg.Err)))
+	}
+	return notify.NotifyLog(fmt.Sprintf("Backup of %d transactions saved to %s", msg.Transactions, msg.Path))
+}
@@ -0,0 +1,98 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/notify"
+
+	"github.com/spf13/viper"
+)
+
+func setBackupDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	viper.Set("backup.dir", dir)
+	t.Cleanup(func() { viper.Set("backup.dir", nil) })
+	return dir
+}
+
+func TestBackupIfDue_Disabled(t *testing.T) {
+	m := newTestModelUI()
+	if cmd := m.backupIfDue(time.Now()); cmd != nil {
+		t.Error("expected no backup without backup.dir")
+	}
+}
+
+func TestBackupIfDue_WritesArchive(t *testing.T) {
+	dir := setBackupDir(t)
+	now := time.Date(2026, 3, 18, 9, 30, 0, 0, time.UTC)
+	m := newTestModelUI()
+	api := m.api.(*mockUIAPI)
+	api.backupTransactions = []firefly.Transaction{{TransactionID: "1"}, {TransactionID: "2"}}
+
+	cmd := m.backupIfDue(now)
+	if cmd == nil {
+		t.Fatal("expected a backup without any archive")
+	}
+	if m.backupIfDue(now) != nil {
+		t.Error("expected no second backup while one runs")
+	}
+
+	done, ok := cmd().(backupDoneMsg)
+	if !ok || done.Err != nil || done.Transactions != 2 {
+		t.Fatalf("expected the backup done, got %+v", done)
+	}
+	if want := filepath.Join(dir, "default-backup-2026-03-18-093000.json"); done.Path != want {
+		t.Errorf("expected %s, got %s", want, done.Path)
+	}
+	data, err := os.ReadFile(done.Path)
+	if err != nil {
+		t.Fatalf("expected the archive written: %v", err)
+	}
+	var backup firefly.Backup
+	if err := json.Unmarshal(data, &backup); err != nil || len(backup.Transactions) != 2 {
+		t.Errorf("expected the transactions in the archive, got %+v, %v", backup, err)
+	}
+
+	n, ok := m.backupDone(done)().(notify.NotifyMsg)
+	if !ok || n.Level != notify.Log {
+		t.Errorf("expected a completion notification, got %+v", n)
+	}
+	if m.backingUp {
+		t.Error("expected the backup finished")
+	}
+	if m.backupIfDue(now.Add(time.Hour)) != nil {
+		t.Error("expected no backup before the interval passed")
+	}
+}
+
+func TestBackupIfDue_Offline(t *testing.T) {
+	setBackupDir(t)
+	m := newTestModelUI()
+	m.api.(*mockUIAPI).offline = true
+	if m.backupIfDue(time.Now()) != nil {
+		t.Error("expected no backup while offline")
+	}
+}
+
+func TestBackupDone_Failed(t *testing.T) {
+	setBackupDir(t)
+	m := newTestModelUI()
+	m.api.(*mockUIAPI).backupErr = errors.New("boom")
+
+	done := m.backupIfDue(time.Now())().(backupDoneMsg)
+	n, ok := m.backupDone(done)().(notify.NotifyMsg)
+	if !ok || n.Level != notify.Warn {
+		t.Errorf("expected a warning, got %+v", n)
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"ffiii-tui/internal/ui/money"
+	"ffiii-tui/internal/ui/notify"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// alertLevel is how far the balance of an asset account fell below its
+// configured thresholds.
+type alertLevel int
+
+const (
+	alertNone alertLevel = iota
+	alertWarn
+	alertDanger
+)
+
+// balanceAlertsConfig is configured under "balance_alerts": the thresholds
+// of asset accounts matched by name, e.g. a warning when the checking
+// account drops below 200, and whether the breaches are listed in a
+// notification once the accounts are loaded at startup.
+type balanceAlertsConfig struct {
+	Notify   bool               `mapstructure:"notify"`
+	Accounts []balanceThreshold `mapstructure:"accounts"`
+}
+
+// balanceThreshold warns below Warn and alarms below Danger, either may be
+// left out.
+type balanceThreshold struct {
+	Account string   `mapstructure:"account"`
+	Warn    *float64 `mapstructure:"warn"`
+	Danger  *float64 `mapstructure:"danger"`
+}
+
+func loadBalanceAlerts() balanceAlertsConfig {
+	var alerts balanceAlertsConfig
+	if err := viper.UnmarshalKey("balance_alerts", &alerts); err != nil {
+		zap.L().Warn("Invalid balance_alerts config", zap.Error(err))
+		return balanceAlertsConfig{}
+	}
+	return alerts
+}
+
+// level returns how far balance is below the thresholds of the account.
+func (c balanceAlertsConfig) level(account string, balance float64) alertLevel {
+	for _, t := range c.Accounts {
+		if !strings.EqualFold(strings.TrimSpace(t.Account), account) {
+			continue
+		}
+		switch {
+		case t.Danger != nil && balance < *t.Danger:
+			return alertDanger
+		case t.Warn != nil && balance < *t.Warn:
+			return alertWarn
+		}
+	}
+	return alertNone
+}
+
+// breaches lists the asset accounts below their thresholds, e.g.
+// "Checking 150.00 EUR".
+func (c balanceAlertsConfig) breaches(api AccountsAPI, amounts money.Formatter) []string {
+	breaches := []string{}
+	data := fromStore[accountsReader](api)
+	for _, account := range data.AccountsByType("asset") {
+		balance := data.AccountBalance(account.ID)
+		if c.level(account.Name, balance) != alertNone {
+			breaches = append(breaches, fmt.Sprintf("%s %s", account.Name, amounts.Format(balance, account.CurrencyCode)))
+		}
+	}
+	return breaches
+}
+
+// notifyBalanceAlerts lists the accounts below their thresholds once the
+// assets are loaded the first time, when balance_alerts.notify is set.
+func (m *modelUI) notifyBalanceAlerts(resource string) tea.Cmd {
+	if m.alertsNotified || resource != "asset" {
+		return nil
+	}
+	m.alertsNotified = true
+	alerts := loadBalanceAlerts()
+	if !alerts.Notify {
+		return nil
+	}
+	breaches := alerts.breaches(m.api, money.New(m.api))
+	if len(breaches) == 0 {
+		return nil
+	}
+	return notify.NotifyWarn("Low balance: " + strings.Join(breaches, ", "))
+}
+
+// accountDelegate colors the description of the accounts below their
+// thresholds, amber for a warning and red past the danger threshold.
+type accountDelegate struct {
+	list.DefaultDelegate
+	styles Styles
+}
+
+func newAccountDelegate(styles Styles) accountDelegate {
+	return accountDelegate{DefaultDelegate: list.NewDefaultDelegate(), styles: styles}
+}
+
+func (d accountDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	if i, ok := item.(interface{ balanceAlert() alertLevel }); ok && i.balanceAlert() != alertNone {
+		fg := d.styles.NotifyWarn.GetForeground()
+		if i.balanceAlert() == alertDanger {
+			fg = d.styles.Withdrawal.GetForeground()
+		}
+		d.Styles.NormalDesc = d.Styles.NormalDesc.Foreground(fg)
+		d.Styles.SelectedDesc = d.Styles.SelectedDesc.Foreground(fg)
+	}
+	d.DefaultDelegate.Render(w, m, index, item)
+}
@@ -0,0 +1,112 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/notify"
+
+	"github.com/spf13/viper"
+)
+
+func setBalanceAlerts(t *testing.T, notify bool) {
+	t.Helper()
+	viper.Set("balance_alerts", map[string]any{
+		"notify": notify,
+		"accounts": []map[string]any{
+			{"account": "Checking", "warn": 200, "danger": 0},
+			{"account": "Savings", "warn": 1000},
+		},
+	})
+	t.Cleanup(func() { viper.Set("balance_alerts", nil) })
+}
+
+func alertAccountsAPI() *mockAssetAPI {
+	balances := map[string]float64{"1": 150, "2": 5000, "3": -20}
+	return &mockAssetAPI{
+		accountsByTypeFunc: func(accountType string) []firefly.Account {
+			if accountType != "asset" {
+				return nil
+			}
+			return []firefly.Account{
+				{ID: "1", Name: "Checking", CurrencyCode: "EUR", Type: "asset"},
+				{ID: "2", Name: "Savings", CurrencyCode: "EUR", Type: "asset"},
+				{ID: "3", Name: "Wallet", CurrencyCode: "EUR", Type: "asset"},
+			}
+		},
+		accountBalanceFunc: func(accountID string) float64 { return balances[accountID] },
+	}
+}
+
+func TestBalanceAlerts_Level(t *testing.T) {
+	setBalanceAlerts(t, false)
+	alerts := loadBalanceAlerts()
+
+	tests := []struct {
+		account string
+		balance float64
+		want    alertLevel
+	}{
+		{"Checking", 500, alertNone},
+		{"Checking", 150, alertWarn},
+		{"checking", -1, alertDanger},
+		{"Savings", 999, alertWarn},
+		{"Savings", -50, alertWarn},
+		{"Wallet", -50, alertNone},
+	}
+	for _, tt := range tests {
+		if got := alerts.level(tt.account, tt.balance); got != tt.want {
+			t.Errorf("level(%s, %v) = %v, want %v", tt.account, tt.balance, got, tt.want)
+		}
+	}
+}
+
+func TestGetAssetsItems_BalanceAlerts(t *testing.T) {
+	setBalanceAlerts(t, false)
+
+	items := getAssetsItems(alertAccountsAPI())
+	want := []alertLevel{alertWarn, alertNone, alertNone}
+	for i, item := range items {
+		if got := item.(assetItem).alert; got != want[i] {
+			t.Errorf("expected alert %v for %s, got %v", want[i], item.(assetItem).Title(), got)
+		}
+	}
+}
+
+func TestNotifyBalanceAlerts(t *testing.T) {
+	setBalanceAlerts(t, true)
+	api := newTestUIAPI()
+	mock := alertAccountsAPI()
+	api.accountsByTypeFunc = mock.accountsByTypeFunc
+	api.accountBalanceFunc = mock.accountBalanceFunc
+	m := NewModelUI(api)
+
+	if cmd := m.notifyBalanceAlerts("categories"); cmd != nil {
+		t.Error("expected no check before the assets are loaded")
+	}
+	msg := m.notifyBalanceAlerts("asset")()
+	note, ok := msg.(notify.NotifyMsg)
+	if !ok || note.Level != notify.Warn || note.Message != "Low balance: Checking 150.00 EUR" {
+		t.Errorf("expected the breach listed, got %#v", msg)
+	}
+	if cmd := m.notifyBalanceAlerts("asset"); cmd != nil {
+		t.Error("expected the breaches listed once")
+	}
+}
+
+func TestNotifyBalanceAlerts_Disabled(t *testing.T) {
+	setBalanceAlerts(t, false)
+	api := newTestUIAPI()
+	mock := alertAccountsAPI()
+	api.accountsByTypeFunc = mock.accountsByTypeFunc
+	api.accountBalanceFunc = mock.accountBalanceFunc
+	m := NewModelUI(api)
+
+	if cmd := m.notifyBalanceAlerts("asset"); cmd != nil {
+		t.Errorf("expected no notification, got %v", cmd())
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/hooks"
+	"ffiii-tui/internal/ui/loading"
+	"ffiii-tui/internal/ui/notify"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// batchFailureDetails is how many failures of a batch the report spells out,
+// all of them are logged.
+const batchFailureDetails = 2
+
+// CreateTransactionsMsg creates many transactions at once, e.g. imported or
+// cloned ones, and reports how many of them were created.
+type CreateTransactionsMsg struct {
+	Transactions []firefly.RequestTransaction
+}
+
+// createTransactions creates a batch of transactions and reports the outcome.
+func createTransactions(api TransactionBatchAPI, txs []firefly.RequestTransaction) tea.Cmd {
+	return loading.Track(fmt.Sprintf("Creating %d transactions...", len(txs)), func() tea.Msg {
+		result := api.CreateTransactions(context.Background(), txs)
+		if len(result.Created) == 0 {
+			return batchReport(result)()
+		}
+		msgs := tea.BatchMsg{
+			batchReport(result),
+			Publish(TransactionsChanged),
+		}
+		for i, id := range result.Created {
+			saved := requestedTransaction(id, txs[result.Sent[i]])
+			msgs = append(msgs, runHook(hooks.TransactionCreated, newHookTransaction(saved)))
+		}
+		return msgs
+	})
+}
+
+// requestedTransaction is the transaction id as it was sent, for hooks of
+// batches whose saved transactions are not fetched back.
+func requestedTransaction(id string, tx firefly.RequestTransaction) firefly.Transaction {
+	saved := firefly.Transaction{TransactionID: id, GroupTitle: tx.GroupTitle}
+	for _, s := range tx.Transactions {
+		if saved.Type == "" {
+			saved.Type = s.Type
+			saved.Date = s.Date
+		}
+		amount, _ := strconv.ParseFloat(s.Amount, 64)
+		foreignAmount, _ := strconv.ParseFloat(s.ForeignAmount, 64)
+		saved.Splits = append(saved.Splits, firefly.Split{
+			TransactionJournalID: s.TransactionJournalID,
+			Source:               firefly.Account{ID: s.SourceID, Name: s.SourceName},
+			Destination:          firefly.Account{ID: s.DestinationID, Name: s.DestinationName},
+			Category:             firefly.Category{ID: s.CategoryID, Name: s.CategoryName},
+			Currency:             s.CurrencyCode,
+			ForeignCurrency:      s.ForeignCurrencyCode,
+			Amount:               amount,
+			ForeignAmount:        foreignAmount,
+			Description:          s.Description,
+			Reconciled:           s.Reconciled,
+			Tags:                 s.Tags,
+		})
+	}
+	return saved
+}
+
+// batchReport notifies the outcome of a batch, e.g. "42/45 created, 3 failed
+// (#7 Rent: ...; #12 Coffee: ...; …)".
+func batchReport(result firefly.BatchResult) tea.Cmd {
+	message := result.Summary()
+	if len(result.Failed) == 0 {
+		if result.Queued > 0 {
+			return notify.NotifyWarn(message)
+		}
+		return notify.NotifyLog(message)
+	}
+
+	var details []string
+	for _, f := range result.Failed[:min(len(result.Failed), batchFailureDetails)] {
+		details = append(details, fmt.Sprintf("#%d %s: %s", f.Index+1, f.Description, errorText(f.Err)))
+	}
+	if len(result.Failed) > batchFailureDetails {
+		details = append(details, "…")
+	}
+	message += " (" + strings.Join(details, "; ") + ")"
+	if len(result.Created) == 0 && result.Queued == 0 {
+		return notify.NotifyError(message)
+	}
+	return notify.NotifyWarn(message)
+}
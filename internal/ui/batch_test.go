@@ -0,0 +1,111 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/notify"
+)
+
+func TestBatchReport(t *testing.T) {
+	failure := func(i int, description string) firefly.BatchFailure {
+		return firefly.BatchFailure{Index: i, Description: description, Err: errors.New("duplicate")}
+	}
+	tests := []struct {
+		name   string
+		result firefly.BatchResult
+		level  notify.NotifyLevel
+		want   string
+	}{
+		{
+			name:   "all created",
+			result: firefly.BatchResult{Total: 2, Created: []string{"1", "2"}},
+			level:  notify.Log,
+			want:   "2/2 created",
+		},
+		{
+			name:   "queued while offline",
+			result: firefly.BatchResult{Total: 2, Created: []string{"1"}, Queued: 1},
+			level:  notify.Warn,
+			want:   "1/2 created, 1 queued",
+		},
+		{
+			name: "some failed",
+			result: firefly.BatchResult{
+				Total:   45,
+				Created: make([]string, 42),
+				Failed:  []firefly.BatchFailure{failure(6, "Rent"), failure(11, "Coffee"), failure(30, "Taxi")},
+			},
+			level: notify.Warn,
+			want:  "42/45 created, 3 failed (#7 Rent: duplicate; #12 Coffee: duplicate; …)",
+		},
+		{
+			name:   "all failed",
+			result: firefly.BatchResult{Total: 1, Failed: []firefly.BatchFailure{failure(0, "Rent")}},
+			level:  notify.Err,
+			want:   "0/1 created, 1 failed (#1 Rent: duplicate)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, ok := batchReport(tt.result)().(notify.NotifyMsg)
+			if !ok {
+				t.Fatal("expected a notification")
+			}
+			if msg.Message != tt.want || msg.Level != tt.level {
+				t.Errorf("expected %q at level %d, got %q at level %d", tt.want, tt.level, msg.Message, msg.Level)
+			}
+		})
+	}
+}
+
+func TestCreateTransactionsMsg(t *testing.T) {
+	txs := []firefly.RequestTransaction{{GroupTitle: "A"}, {GroupTitle: "B"}}
+	var sent []firefly.RequestTransaction
+	api := &mockTransactionAPI{
+		createTransactionsFunc: func(txs []firefly.RequestTransaction) firefly.BatchResult {
+			sent = txs
+			return firefly.BatchResult{Total: len(txs), Created: []string{"1"}, Sent: []int{0}, Failed: []firefly.BatchFailure{
+				{Index: 1, Description: "B", Err: errors.New("rejected")},
+			}}
+		},
+	}
+	m := NewModelTransactions(api)
+
+	_, cmd := m.Update(CreateTransactionsMsg{Transactions: txs})
+	msgs := dispatched(collectMsgsFromCmd(cmd))
+	if len(sent) != 2 {
+		t.Fatalf("expected the batch to be sent, got %v", sent)
+	}
+	report, ok := findMsg[notify.NotifyMsg](msgs)
+	if !ok || !strings.HasPrefix(report.Message, "1/2 created, 1 failed") {
+		t.Errorf("expected the outcome to be reported, got %v", msgs)
+	}
+	if !hasMsg[RefreshTransactionsMsg](msgs) {
+		t.Error("expected the transactions to be reloaded")
+	}
+}
+
+func TestCreateTransactionsMsg_NoneCreated(t *testing.T) {
+	api := &mockTransactionAPI{
+		createTransactionsFunc: func(txs []firefly.RequestTransaction) firefly.BatchResult {
+			return firefly.BatchResult{Total: 1, Failed: []firefly.BatchFailure{{Description: "A", Err: errors.New("rejected")}}}
+		},
+	}
+	m := NewModelTransactions(api)
+
+	_, cmd := m.Update(CreateTransactionsMsg{Transactions: []firefly.RequestTransaction{{}}})
+	msgs := dispatched(collectMsgsFromCmd(cmd))
+	if hasMsg[RefreshTransactionsMsg](msgs) {
+		t.Error("expected no reload when nothing was created")
+	}
+	if report, ok := findMsg[notify.NotifyMsg](msgs); !ok || report.Level != notify.Err {
+		t.Errorf("expected an error notification, got %v", msgs)
+	}
+}
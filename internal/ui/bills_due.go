@@ -0,0 +1,112 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/money"
+	"ffiii-tui/internal/ui/notify"
+	"ffiii-tui/internal/ui/prompt"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// dueLabel is when a subscription is due relative to now, e.g. "today" or
+// "Mar 21".
+func dueLabel(date string, now time.Time) string {
+	due, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return date
+	}
+	switch today := now.Format(time.DateOnly); {
+	case date < today:
+		return "overdue"
+	case date == today:
+		return "today"
+	case date == now.AddDate(0, 0, 1).Format(time.DateOnly):
+		return "tomorrow"
+	}
+	return due.Format("Jan 2")
+}
+
+// getDueItems lists the subscriptions due soon after the summary, the ones
+// due today highlighted.
+func getDueItems(subscriptions []firefly.Subscription, now time.Time, styles Styles, amounts money.Formatter) []list.Item {
+	items := []list.Item{}
+	today := now.Format(time.DateOnly)
+	for _, s := range subscriptions {
+		if len(s.DueDates) == 0 {
+			continue
+		}
+		item := summaryItem{
+			title:  fmt.Sprintf("%s due %s", s.Name, dueLabel(s.DueDates[0], now)),
+			value:  amounts.Short(s.Amount(), s.CurrencyCode),
+			style:  styles.Withdrawal,
+			widget: true,
+		}
+		if s.DueDates[0] <= today {
+			item.style = styles.NotifyWarn
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// subscriptionPayment is the withdrawal paying s, from the first asset
+// account in its currency to the expense account named like it, if any.
+func subscriptionPayment(api AccountsAPI, s firefly.Subscription) firefly.Transaction {
+	payment := firefly.Split{
+		Amount:      s.Amount(),
+		Description: s.Name,
+	}
+	for _, a := range api.AccountsByType("asset") {
+		if !a.IsCreditCard() && a.CurrencyCode == s.CurrencyCode {
+			payment.Source = a
+			break
+		}
+	}
+	for _, a := range api.AccountsByType("expense") {
+		if strings.EqualFold(a.Name, s.Name) {
+			payment.Destination = a
+			break
+		}
+	}
+	return firefly.Transaction{Type: "withdrawal", Splits: []firefly.Split{payment}}
+}
+
+// paySubscription opens the payment of a subscription due soon in the
+// transaction form, asking which one when several are due.
+func paySubscription(api AccountsAPI, due []firefly.Subscription) tea.Cmd {
+	switch len(due) {
+	case 0:
+		return notify.NotifyLog(fmt.Sprintf("No bills due in the next %d days", firefly.DueSoonDays))
+	case 1:
+		return Cmd(NewTransactionFromMsg{Transaction: subscriptionPayment(api, due[0])})
+	}
+	names := make([]string, 0, len(due))
+	for _, s := range due {
+		names = append(names, s.Name)
+	}
+	return prompt.Ask(
+		fmt.Sprintf("Pay bill (%s): ", strings.Join(names, ", ")),
+		due[0].Name,
+		func(value string) tea.Cmd {
+			if value == "None" {
+				return nil
+			}
+			for _, s := range due {
+				if strings.EqualFold(s.Name, strings.TrimSpace(value)) {
+					return Cmd(NewTransactionFromMsg{Transaction: subscriptionPayment(api, s)})
+				}
+			}
+			return notify.NotifyWarn(fmt.Sprintf("No bill '%s' due soon", value))
+		},
+	)
+}
@@ -0,0 +1,102 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/money"
+	"ffiii-tui/internal/ui/notify"
+	"ffiii-tui/internal/ui/prompt"
+)
+
+func TestGetDueItems(t *testing.T) {
+	styles := DefaultStyles()
+	now := time.Date(2026, time.March, 18, 9, 0, 0, 0, time.UTC)
+	due := []firefly.Subscription{
+		{Name: "Rent", AmountMin: 1150, AmountMax: 1150, CurrencyCode: "EUR", DueDates: []string{"2026-03-18"}},
+		{Name: "Internet", AmountMin: 39.99, AmountMax: 39.99, CurrencyCode: "EUR", DueDates: []string{"2026-03-19"}},
+		{Name: "Electricity", AmountMin: 60, AmountMax: 110, CurrencyCode: "EUR", DueDates: []string{"2026-03-23"}},
+		{Name: "Paid", CurrencyCode: "EUR"},
+	}
+
+	items := getDueItems(due, now, styles, money.Formatter{})
+	if len(items) != 3 {
+		t.Fatalf("expected 3 bills due, got %d", len(items))
+	}
+	tests := []struct{ title, value string }{
+		{"Rent due today", "1150.00 EUR"},
+		{"Internet due tomorrow", "39.99 EUR"},
+		{"Electricity due Mar 23", "85.00 EUR"},
+	}
+	for i, tt := range tests {
+		si := items[i].(summaryItem)
+		if si.title != tt.title || si.value != tt.value || !si.widget {
+			t.Errorf("expected %q %q, got %+v", tt.title, tt.value, si)
+		}
+	}
+	if items[0].(summaryItem).style.GetForeground() != styles.NotifyWarn.GetForeground() {
+		t.Error("expected the bill due today highlighted")
+	}
+	if items[1].(summaryItem).style.GetForeground() != styles.Withdrawal.GetForeground() {
+		t.Error("expected the bill due tomorrow shown as a withdrawal")
+	}
+}
+
+func TestSubscriptionPayment(t *testing.T) {
+	api := newTestTransactionModel().api
+	bill := firefly.Subscription{Name: "utilities", AmountMin: 80, AmountMax: 100, CurrencyCode: "USD"}
+
+	payment := subscriptionPayment(api, bill)
+	if payment.Type != "withdrawal" || len(payment.Splits) != 1 {
+		t.Fatalf("expected a withdrawal, got %+v", payment)
+	}
+	s := payment.Splits[0]
+	if s.Source != testAssetChecking || s.Destination != testExpenseUtilities {
+		t.Errorf("expected Checking to Utilities, got %s to %s", s.Source.Name, s.Destination.Name)
+	}
+	if s.Amount != 90 || s.Description != "utilities" {
+		t.Errorf("unexpected payment %+v", s)
+	}
+
+	bill.Name, bill.CurrencyCode = "Gym", "EUR"
+	s = subscriptionPayment(api, bill).Splits[0]
+	if s.Source.ID != "" || s.Destination.ID != "" {
+		t.Errorf("expected the accounts left to pick, got %s to %s", s.Source.Name, s.Destination.Name)
+	}
+}
+
+func TestPaySubscription(t *testing.T) {
+	api := newTestTransactionModel().api
+
+	if msgs := collectMsgsFromCmd(paySubscription(api, nil)); !hasMsg[notify.NotifyMsg](msgs) {
+		t.Error("expected a note without bills due")
+	}
+
+	rent := firefly.Subscription{Name: "Rent", AmountMin: 900, AmountMax: 900, CurrencyCode: "USD"}
+	open, ok := findMsg[NewTransactionFromMsg](collectMsgsFromCmd(paySubscription(api, []firefly.Subscription{rent})))
+	if !ok || open.Transaction.Splits[0].Description != "Rent" {
+		t.Fatalf("expected the single bill opened in the form, got %+v", open)
+	}
+
+	gym := firefly.Subscription{Name: "Gym", AmountMin: 35, AmountMax: 35, CurrencyCode: "USD"}
+	ask, ok := findMsg[prompt.PromptMsg](collectMsgsFromCmd(paySubscription(api, []firefly.Subscription{rent, gym})))
+	if !ok || ask.Value != "Rent" || !strings.Contains(ask.Prompt, "Rent, Gym") {
+		t.Fatalf("expected a prompt for the bill to pay, got %+v", ask)
+	}
+	open, ok = findMsg[NewTransactionFromMsg](collectMsgsFromCmd(ask.Callback("gym")))
+	if !ok || open.Transaction.Splits[0].Amount != 35 {
+		t.Errorf("expected the gym payment, got %+v", open)
+	}
+	if msgs := collectMsgsFromCmd(ask.Callback("Car")); !hasMsg[notify.NotifyMsg](msgs) {
+		t.Error("expected a warning for a bill not due")
+	}
+	if msgs := collectMsgsFromCmd(ask.Callback("None")); len(msgs) != 0 {
+		t.Errorf("expected nothing on cancel, got %v", msgs)
+	}
+}
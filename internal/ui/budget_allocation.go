@@ -0,0 +1,185 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/loading"
+	"ffiii-tui/internal/ui/notify"
+	"ffiii-tui/internal/ui/prompt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// budgetAverageMonths are the spans the average spending of a budget can
+// be taken over, the first is the default.
+var budgetAverageMonths = []string{"3", "6"}
+
+// budgetProposal is the limit proposed for a budget in the next period,
+// from its average spending.
+type budgetProposal struct {
+	budget  firefly.Budget
+	average float64
+	limit   float64 // zero to leave the budget without a new limit
+}
+
+// budgetProposalsMsg carries the proposals for the period from start to
+// end, asked for one by one.
+type budgetProposalsMsg struct {
+	proposals []budgetProposal
+	months    int
+	start     time.Time
+	end       time.Time
+}
+
+// nextPeriod returns the first and last day of the month after start.
+func nextPeriod(start time.Time) (time.Time, time.Time) {
+	first := time.Date(start.Year(), start.Month()+1, 1, 0, 0, 0, 0, start.Location())
+	return first, first.AddDate(0, 1, -1)
+}
+
+// askBudgetMonths asks how many months to average the spending over.
+func askBudgetMonths(api BudgetAPI) tea.Cmd {
+	return prompt.Ask(
+		fmt.Sprintf("Propose budget limits from the average of the last %s months: ",
+			strings.Join(budgetAverageMonths, " or ")),
+		budgetAverageMonths[0],
+		func(value string) tea.Cmd {
+			value = strings.TrimSpace(value)
+			if value == "None" {
+				return SetView(categoriesView)
+			}
+			months, err := strconv.Atoi(value)
+			if err != nil || months < 1 || months > 12 {
+				return tea.Sequence(
+					notify.NotifyWarn(fmt.Sprintf("Invalid number of months: %s", value)),
+					SetView(categoriesView))
+			}
+			return tea.Sequence(
+				SetView(categoriesView),
+				proposeBudgetLimits(api, months))
+		},
+	)
+}
+
+// proposeBudgetLimits proposes a limit for each budget of the next period,
+// its average spending over months rounded up.
+func proposeBudgetLimits(api BudgetAPI, months int) tea.Cmd {
+	return loading.Track("Loading budget spending...", func() tea.Msg {
+		budgets := api.Budgets()
+		if len(budgets) == 0 {
+			return notify.NotifyWarn("No active budgets to propose limits for")()
+		}
+		start, end := nextPeriod(api.PeriodStart())
+		averages, err := api.BudgetAverages(context.Background(), months, start)
+		if err != nil {
+			return notify.NotifyWarn(fmt.Sprintf("Failed to load budget spending: %s", errorText(err)))()
+		}
+
+		msg := budgetProposalsMsg{months: months, start: start, end: end}
+		for _, budget := range budgets {
+			average := averages[budget.ID]
+			msg.proposals = append(msg.proposals, budgetProposal{
+				budget:  budget,
+				average: average,
+				limit:   math.Ceil(average),
+			})
+		}
+		return msg
+	})
+}
+
+// askBudgetLimit asks for the limit of the proposal at i, the proposed one
+// by default, then moves on to the next one. Once all are answered, the
+// limits are submitted after a confirmation.
+func askBudgetLimit(api BudgetAPI, msg budgetProposalsMsg, i int) tea.Cmd {
+	if i >= len(msg.proposals) {
+		return confirmBudgetLimits(api, msg)
+	}
+	p := msg.proposals[i]
+	question := fmt.Sprintf("Limit of %s for %s (%d-month average %.2f, now %.2f; 0 or ESC skips): ",
+		p.budget.Name, msg.start.Format("January 2006"), msg.months, p.average, p.budget.Limit)
+	return prompt.Ask(
+		question,
+		strconv.FormatFloat(p.limit, 'f', -1, 64),
+		func(value string) tea.Cmd {
+			value = strings.TrimSpace(value)
+			if value == "None" {
+				value = "0"
+			}
+			limit, err := strconv.ParseFloat(value, 64)
+			if err != nil || limit < 0 {
+				return tea.Sequence(
+					notify.NotifyWarn(fmt.Sprintf("Invalid limit: %s", value)),
+					askBudgetLimit(api, msg, i))
+			}
+			msg.proposals[i].limit = limit
+			return askBudgetLimit(api, msg, i+1)
+		},
+	)
+}
+
+// confirmBudgetLimits submits the accepted limits once confirmed.
+func confirmBudgetLimits(api BudgetAPI, msg budgetProposalsMsg) tea.Cmd {
+	accepted := []budgetProposal{}
+	total := 0.0
+	for _, p := range msg.proposals {
+		if p.limit > 0 {
+			accepted = append(accepted, p)
+			total += p.limit
+		}
+	}
+	if len(accepted) == 0 {
+		return tea.Sequence(
+			notify.NotifyLog("No budget limits set"),
+			SetView(categoriesView))
+	}
+	return prompt.Ask(
+		fmt.Sprintf("Set %d budget limits, %.2f in total, for %s? (y - yes/ any key - no): ",
+			len(accepted), total, msg.start.Format("January 2006")),
+		"",
+		func(value string) tea.Cmd {
+			if value != "y" {
+				return SetView(categoriesView)
+			}
+			return tea.Sequence(
+				SetView(categoriesView),
+				setBudgetLimits(api, accepted, msg.start, msg.end))
+		},
+	)
+}
+
+// setBudgetLimits sets the accepted limits and reports how many were set.
+func setBudgetLimits(api BudgetAPI, accepted []budgetProposal, start, end time.Time) tea.Cmd {
+	return loading.Track("Setting budget limits...", func() tea.Msg {
+		failed := []string{}
+		for _, p := range accepted {
+			err := api.SetBudgetLimit(context.Background(), p.budget, p.limit, start, end)
+			if err != nil && !errors.Is(err, firefly.ErrQueued) {
+				failed = append(failed, fmt.Sprintf("%s: %s", p.budget.Name, errorText(err)))
+			}
+		}
+		message := fmt.Sprintf("%d/%d budget limits set for %s",
+			len(accepted)-len(failed), len(accepted), start.Format("January 2006"))
+		if len(failed) > 0 {
+			return tea.BatchMsg{
+				notify.NotifyWarn(message + " (" + strings.Join(failed, "; ") + ")"),
+				Publish(CategoriesChanged),
+			}
+		}
+		return tea.BatchMsg{
+			notify.NotifyLog(message),
+			Publish(CategoriesChanged),
+		}
+	})
+}
@@ -0,0 +1,110 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/notify"
+	"ffiii-tui/internal/ui/prompt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestNextPeriod(t *testing.T) {
+	start, end := nextPeriod(time.Date(2026, time.December, 1, 0, 0, 0, 0, time.UTC))
+	if start.Format(time.DateOnly) != "2027-01-01" || end.Format(time.DateOnly) != "2027-01-31" {
+		t.Errorf("unexpected next period %s - %s", start, end)
+	}
+}
+
+func TestCategories_ProposeBudgetLimits(t *testing.T) {
+	m := newFocusedCategoriesModelWithCategory(t, firefly.Category{ID: "c1", Name: "Groceries"})
+	api := m.api.(*mockCategoryAPI)
+	api.budgets = []firefly.Budget{
+		{ID: "b1", Name: "Dining out", Limit: 150, CurrencyCode: "USD"},
+		{ID: "b2", Name: "Groceries", Limit: 450, CurrencyCode: "USD"},
+	}
+	api.budgetAverages = map[string]float64{"b1": 120.4, "b2": 431.01}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+	ask, ok := findMsg[prompt.PromptMsg](collectMsgsFromCmd(cmd))
+	if !ok || ask.Value != "3" {
+		t.Fatalf("expected a months prompt defaulting to 3, got %+v", ask)
+	}
+
+	proposals, ok := findMsg[budgetProposalsMsg](collectMsgsFromCmd(ask.Callback("6")))
+	if !ok {
+		t.Fatal("expected budget proposals")
+	}
+	if len(api.budgetAveragesCalledWith) != 1 || api.budgetAveragesCalledWith[0] != 6 {
+		t.Errorf("expected averages over 6 months, got %v", api.budgetAveragesCalledWith)
+	}
+	if proposals.start.Format(time.DateOnly) != "2026-04-01" || proposals.end.Format(time.DateOnly) != "2026-04-30" {
+		t.Errorf("expected the next month proposed, got %s - %s", proposals.start, proposals.end)
+	}
+
+	_, cmd = m.Update(proposals)
+	ask, ok = findMsg[prompt.PromptMsg](collectMsgsFromCmd(cmd))
+	if !ok || ask.Value != "121" || !strings.Contains(ask.Prompt, "Dining out for April 2026") {
+		t.Fatalf("expected the rounded up average proposed for Dining out, got %+v", ask)
+	}
+
+	msgs := collectMsgsFromCmd(ask.Callback("abc"))
+	if !hasMsg[notify.NotifyMsg](msgs) {
+		t.Error("expected a warning for an invalid limit")
+	}
+	ask, ok = findMsg[prompt.PromptMsg](msgs)
+	if !ok || !strings.Contains(ask.Prompt, "Dining out") {
+		t.Fatalf("expected Dining out asked again, got %+v", ask)
+	}
+
+	ask, _ = findMsg[prompt.PromptMsg](collectMsgsFromCmd(ask.Callback("None")))
+	if ask.Value != "432" || !strings.Contains(ask.Prompt, "Groceries") {
+		t.Fatalf("expected Groceries asked next, got %+v", ask)
+	}
+
+	ask, _ = findMsg[prompt.PromptMsg](collectMsgsFromCmd(ask.Callback("440")))
+	if !strings.Contains(ask.Prompt, "Set 1 budget limits, 440.00 in total") {
+		t.Fatalf("expected a confirmation of the accepted limit, got %q", ask.Prompt)
+	}
+
+	msgs = dispatched(collectMsgsFromCmd(ask.Callback("y")))
+	if len(api.setBudgetLimitCalledWith) != 1 {
+		t.Fatalf("expected one limit set, got %+v", api.setBudgetLimitCalledWith)
+	}
+	if set := api.setBudgetLimitCalledWith[0]; set.budget.ID != "b2" || set.limit != 440 {
+		t.Errorf("expected Groceries limited to 440, got %+v", set)
+	}
+	if !hasMsg[RefreshCategoriesMsg](msgs) {
+		t.Error("expected categories to be refreshed")
+	}
+}
+
+func TestConfirmBudgetLimits_NothingAccepted(t *testing.T) {
+	api := &mockCategoryAPI{}
+	msg := budgetProposalsMsg{proposals: []budgetProposal{{budget: firefly.Budget{ID: "b1"}}}}
+
+	msgs := collectMsgsFromCmd(confirmBudgetLimits(api, msg))
+	if hasMsg[prompt.PromptMsg](msgs) {
+		t.Error("expected no confirmation without accepted limits")
+	}
+}
+
+func TestSetBudgetLimits_ReportsFailures(t *testing.T) {
+	api := &mockCategoryAPI{setBudgetLimitErr: errors.New("boom")}
+	accepted := []budgetProposal{{budget: firefly.Budget{ID: "b1", Name: "Groceries"}, limit: 400}}
+	start, end := nextPeriod(time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC))
+
+	msgs := collectMsgsFromCmd(setBudgetLimits(api, accepted, start, end))
+	warn, ok := findMsg[notify.NotifyMsg](msgs)
+	if !ok || !strings.Contains(warn.Message, "0/1 budget limits set") || !strings.Contains(warn.Message, "Groceries: boom") {
+		t.Errorf("expected the failure reported, got %+v", warn)
+	}
+}
@@ -0,0 +1,162 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/hooks"
+	"ffiii-tui/internal/ui/loading"
+	"ffiii-tui/internal/ui/notify"
+	"ffiii-tui/internal/ui/prompt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// markedMark is appended to the type icon of marked transactions.
+const markedMark = "•"
+
+// bulkTaggedMsg clears the marks once a bulk tag batch is sent.
+type bulkTaggedMsg struct{}
+
+// toggleMark marks the transaction under the cursor, or unmarks it.
+func (m *modelTransactions) toggleMark() {
+	trx, err := m.GetCurrentTransaction()
+	if err != nil {
+		return
+	}
+	if m.marked[trx.TransactionID] {
+		delete(m.marked, trx.TransactionID)
+	} else {
+		m.marked[trx.TransactionID] = true
+	}
+	m.updateRows(trx.TransactionID)
+}
+
+// markedTransactions returns the marked transactions in the loaded order.
+func (m *modelTransactions) markedTransactions() []firefly.Transaction {
+	txs := []firefly.Transaction{}
+	for _, tx := range m.transactions {
+		if m.marked[tx.TransactionID] {
+			txs = append(txs, tx)
+		}
+	}
+	return txs
+}
+
+// askBulkTag asks for a tag to add to the marked transactions, or with a
+// leading "-" to remove from them.
+func (m *modelTransactions) askBulkTag() tea.Cmd {
+	txs := m.markedTransactions()
+	if len(txs) == 0 {
+		return notify.NotifyWarn("No transactions marked.")
+	}
+
+	api := m.api
+	return prompt.Ask(
+		fmt.Sprintf("Tag %d marked transactions (+tag to add, -tag to remove): ", len(txs)),
+		"",
+		func(value string) tea.Cmd {
+			tag, remove := parseBulkTag(value)
+			if tag == "" {
+				return SetView(transactionsView)
+			}
+			return tea.Sequence(
+				SetView(transactionsView),
+				bulkTag(api, txs, tag, remove))
+		},
+	)
+}
+
+// parseBulkTag reads a bulk tag answer, e.g. "+car", "#car" or "-car". tag
+// is empty when there is nothing to do.
+func parseBulkTag(value string) (tag string, remove bool) {
+	value = strings.TrimSpace(value)
+	if value == "None" {
+		return "", false
+	}
+	value, remove = strings.CutPrefix(value, "-")
+	value = strings.TrimPrefix(value, "+")
+	value = strings.TrimPrefix(value, tagPrefix)
+	return strings.TrimSpace(value), remove
+}
+
+// retagSplits returns the tags of each split of tx with tag added or
+// removed; ok is false when no split changes.
+func retagSplits(tx firefly.Transaction, tag string, remove bool) (tags [][]string, ok bool) {
+	for _, split := range tx.Splits {
+		has := slices.Contains(split.Tags, tag)
+		splitTags := slices.Clone(split.Tags)
+		switch {
+		case remove && has:
+			splitTags = slices.DeleteFunc(splitTags, func(t string) bool { return t == tag })
+			ok = true
+		case !remove && !has:
+			splitTags = append(splitTags, tag)
+			ok = true
+		}
+		if splitTags == nil {
+			// Sent as an empty list, which removes the last tag
+			splitTags = []string{}
+		}
+		tags = append(tags, splitTags)
+	}
+	return tags, ok
+}
+
+// bulkTag adds tag to txs, or removes it, in one batch. Only the tags of
+// the splits are sent, the rest stays as it is.
+func bulkTag(api TransactionAPI, txs []firefly.Transaction, tag string, remove bool) tea.Cmd {
+	return loading.TrackProgress("Tagging transactions...", func(op *loading.Operation) tea.Msg {
+		ids := []string{}
+		requests := []firefly.RequestTransaction{}
+		retagged := []firefly.Transaction{}
+		for _, tx := range txs {
+			tags, ok := retagSplits(tx, tag, remove)
+			if !ok {
+				continue
+			}
+			request := firefly.RequestTransaction{}
+			tx.Splits = slices.Clone(tx.Splits)
+			for i, split := range tx.Splits {
+				tx.Splits[i].Tags = tags[i]
+				request.Transactions = append(request.Transactions, firefly.RequestTransactionSplit{
+					TransactionJournalID: split.TransactionJournalID,
+					Description:          split.Description,
+					Tags:                 tags[i],
+				})
+			}
+			ids = append(ids, tx.TransactionID)
+			requests = append(requests, request)
+			retagged = append(retagged, tx)
+		}
+		if len(requests) == 0 {
+			return tea.BatchMsg{
+				notify.NotifyLog(fmt.Sprintf("Nothing to change for tag %s%s", tagPrefix, tag)),
+				Cmd(bulkTaggedMsg{}),
+			}
+		}
+
+		op.SetMessage(fmt.Sprintf("Tagging transactions 0/%d...", len(requests)))
+		result := firefly.UpdateBatch(context.Background(), ids, requests, firefly.BatchConcurrency,
+			api.UpdateTransaction,
+			func(done int) {
+				op.SetMessage(fmt.Sprintf("Tagging transactions %d/%d...", done, len(requests)))
+			})
+		msgs := tea.BatchMsg{
+			batchReport(result),
+			Cmd(bulkTaggedMsg{}),
+			Publish(TagsChanged),
+		}
+		for _, i := range result.Sent {
+			msgs = append(msgs, runHook(hooks.TransactionUpdated, newHookTransaction(retagged[i])))
+		}
+		return msgs
+	})
+}
@@ -0,0 +1,131 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/prompt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestParseBulkTag(t *testing.T) {
+	tests := []struct {
+		value  string
+		tag    string
+		remove bool
+	}{
+		{"car", "car", false},
+		{"+car", "car", false},
+		{"#car", "car", false},
+		{" -#car ", "car", true},
+		{"-", "", true},
+		{"None", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		tag, remove := parseBulkTag(tt.value)
+		if tag != tt.tag || remove != tt.remove {
+			t.Errorf("parseBulkTag(%q) = %q, %v, want %q, %v", tt.value, tag, remove, tt.tag, tt.remove)
+		}
+	}
+}
+
+func TestRetagSplits(t *testing.T) {
+	tx := newTestTransaction(1, "tx1", "withdrawal", "2024-01-15T10:00:00Z", "Fuel")
+	tx.Splits[0].Tags = []string{"car"}
+	tx.Splits = append(tx.Splits, firefly.Split{Description: "Snacks"})
+
+	tags, ok := retagSplits(tx, "car", false)
+	if !ok || !slices.Equal(tags[0], []string{"car"}) || !slices.Equal(tags[1], []string{"car"}) {
+		t.Errorf("expected car added to the second split, got %v %v", tags, ok)
+	}
+
+	tags, ok = retagSplits(tx, "car", true)
+	if !ok || tags[0] == nil || len(tags[0]) != 0 || len(tags[1]) != 0 {
+		t.Errorf("expected car removed as an empty list, got %#v %v", tags, ok)
+	}
+	if !slices.Equal(tx.Splits[0].Tags, []string{"car"}) {
+		t.Errorf("expected the transaction left as it is, got %v", tx.Splits[0].Tags)
+	}
+
+	if _, ok := retagSplits(tx, "trip", true); ok {
+		t.Error("expected nothing to change removing a missing tag")
+	}
+}
+
+func TestTransactions_MarkAndBulkTag(t *testing.T) {
+	txs := []firefly.Transaction{
+		newTestTransaction(1, "tx1", "withdrawal", "2024-01-17T10:00:00Z", "Fuel"),
+		newTestTransaction(2, "tx2", "withdrawal", "2024-01-16T10:00:00Z", "Parking"),
+		newTestTransaction(3, "tx3", "withdrawal", "2024-01-15T10:00:00Z", "Coffee"),
+	}
+	txs[1].Splits[0].Tags = []string{"car"}
+	m := newFocusedTransactionModel(t, txs)
+
+	var mu sync.Mutex
+	sent := map[string]firefly.RequestTransaction{}
+	m.api.(*mockTransactionAPI).updateTransactionFunc = func(id string, tx firefly.RequestTransaction) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		sent[id] = tx
+		return id, nil
+	}
+
+	press := func(msg tea.KeyMsg) tea.Cmd {
+		updated, cmd := m.Update(msg)
+		m = updated.(modelTransactions)
+		return cmd
+	}
+	space := tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+
+	if msgs := collectMsgsFromCmd(press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'B'}})); hasMsg[prompt.PromptMsg](msgs) {
+		t.Fatal("expected no prompt without marked transactions")
+	}
+
+	press(space)
+	press(tea.KeyMsg{Type: tea.KeyDown})
+	press(space)
+	if !strings.HasSuffix(m.table.Rows()[0][1], markedMark) || !strings.HasSuffix(m.table.Rows()[1][1], markedMark) {
+		t.Errorf("expected the marked rows to show the mark, got %q %q", m.table.Rows()[0][1], m.table.Rows()[1][1])
+	}
+	if strings.HasSuffix(m.table.Rows()[2][1], markedMark) {
+		t.Error("expected the unmarked row without mark")
+	}
+
+	ask, ok := findMsg[prompt.PromptMsg](collectMsgsFromCmd(press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'B'}})))
+	if !ok {
+		t.Fatal("expected a tag prompt")
+	}
+	if !strings.Contains(ask.Prompt, "2 marked") {
+		t.Errorf("unexpected prompt %q", ask.Prompt)
+	}
+
+	msgs := dispatched(collectMsgsFromCmd(ask.Callback("+car")))
+	if len(sent) != 1 || !slices.Equal(sent["tx1"].Transactions[0].Tags, []string{"car"}) {
+		t.Errorf("expected only tx1 to get the tag, got %+v", sent)
+	}
+	if sent["tx1"].Transactions[0].TransactionJournalID != "split-1" {
+		t.Errorf("expected the split journal ID, got %+v", sent["tx1"])
+	}
+	if !hasMsg[RefreshTransactionsMsg](msgs) || !hasMsg[RefreshTagsMsg](msgs) {
+		t.Error("expected transactions and tags to be refreshed")
+	}
+
+	done, ok := findMsg[bulkTaggedMsg](msgs)
+	if !ok {
+		t.Fatal("expected the marks to be cleared")
+	}
+	updated, _ := m.Update(done)
+	m = updated.(modelTransactions)
+	if len(m.marked) != 0 || strings.HasSuffix(m.table.Rows()[0][1], markedMark) {
+		t.Errorf("expected no marks left, got %v", m.marked)
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"fmt"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/money"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// projectionSummary returns the balance the account is projected to end the
+// period with, e.g. "month end ≈ 1234.56", flagged when overdrawn.
+func projectionSummary(rate firefly.BurnRate, account firefly.Account, balance float64) string {
+	if !rate.Active() || account.IsCreditCard() {
+		return ""
+	}
+	projected := rate.Projected(balance, rate.Daily[account.ID])
+	if projected < 0 {
+		return fmt.Sprintf("month end ≈ %s, overdrawn", money.ShortNumber(projected))
+	}
+	return "month end ≈ " + money.ShortNumber(projected)
+}
+
+// getBurnItems lists the average spent per day and the total balance of the
+// asset accounts projected to the end of the period, red when it goes
+// negative. Past periods have no projection.
+func getBurnItems(rate firefly.BurnRate, styles Styles, amounts money.Formatter) []list.Item {
+	if !rate.Active() {
+		return nil
+	}
+	projected := summaryItem{
+		title:  "Month end balance",
+		value:  amounts.Short(rate.ProjectedTotal(), rate.CurrencyCode),
+		style:  styles.Deposit,
+		widget: true,
+	}
+	if rate.ProjectedTotal() < 0 {
+		projected.value += " !"
+		projected.style = styles.Withdrawal
+	}
+	return []list.Item{
+		summaryItem{
+			title:  "Daily spend",
+			value:  amounts.Short(rate.DailyTotal, rate.CurrencyCode),
+			style:  styles.Withdrawal,
+			widget: true,
+		},
+		projected,
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/money"
+)
+
+func TestProjectionSummary(t *testing.T) {
+	rate := firefly.BurnRate{DaysPassed: 10, DaysLeft: 20, Daily: map[string]float64{"a1": 40}}
+	checking := firefly.Account{ID: "a1", Name: "Checking", CurrencyCode: "EUR"}
+
+	if got := projectionSummary(rate, checking, 1000); got != "month end ≈ 200.00" {
+		t.Errorf("unexpected projection %q", got)
+	}
+	if got := projectionSummary(rate, checking, 500); got != "month end ≈ -300.00, overdrawn" {
+		t.Errorf("expected the overdraft flagged, got %q", got)
+	}
+	if got := projectionSummary(rate, firefly.Account{ID: "cc", Type: "asset", Role: "ccAsset"}, 500); got != "" {
+		t.Errorf("expected no projection for credit cards, got %q", got)
+	}
+	if got := projectionSummary(firefly.BurnRate{}, checking, 500); got != "" {
+		t.Errorf("expected no projection outside the current period, got %q", got)
+	}
+}
+
+func TestGetBurnItems(t *testing.T) {
+	styles := DefaultStyles()
+	if items := getBurnItems(firefly.BurnRate{}, styles, money.Formatter{}); len(items) != 0 {
+		t.Errorf("expected no rows outside the current period, got %d", len(items))
+	}
+
+	rate := firefly.BurnRate{DaysPassed: 10, DaysLeft: 20, DailyTotal: 50, Balance: 1500, CurrencyCode: "EUR"}
+	items := getBurnItems(rate, styles, money.Formatter{})
+	if len(items) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(items))
+	}
+	daily, projected := items[0].(summaryItem), items[1].(summaryItem)
+	if daily.value != "50.00 EUR" || projected.value != "500.00 EUR" {
+		t.Errorf("unexpected rows %q %q", daily.value, projected.value)
+	}
+	if projected.style.GetForeground() != styles.Deposit.GetForeground() {
+		t.Error("expected a positive projection in green")
+	}
+
+	rate.Balance = 800
+	projected = getBurnItems(rate, styles, money.Formatter{})[1].(summaryItem)
+	if projected.value != "-200.00 EUR !" || projected.style.GetForeground() != styles.Withdrawal.GetForeground() {
+		t.Errorf("expected a negative projection warned about, got %q", projected.value)
+	}
+}
+
+func TestGetAssetsItems_Projection(t *testing.T) {
+	api := &mockAssetAPI{
+		accountsByTypeFunc: func(accountType string) []firefly.Account {
+			return []firefly.Account{{ID: "a1", Name: "Checking", Type: "asset", CurrencyCode: "EUR"}}
+		},
+		accountBalanceFunc: func(accountID string) float64 { return 900 },
+		burnRate:           firefly.BurnRate{DaysPassed: 15, DaysLeft: 15, Daily: map[string]float64{"a1": 20}},
+	}
+
+	items := getAssetsItems(api)
+	want := "Balance: 900.00 EUR | month end ≈ 600.00"
+	if got := items[0].(assetItem).Description(); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
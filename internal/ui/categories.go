@@ -5,10 +5,15 @@ SPDX-License-Identifier: Apache-2.0
 package ui
 
 import (
+	"context"
 	"fmt"
 	"slices"
+	"time"
 
 	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/categorydetail"
+	"ffiii-tui/internal/ui/loading"
+	"ffiii-tui/internal/ui/money"
 	"ffiii-tui/internal/ui/notify"
 	"ffiii-tui/internal/ui/prompt"
 
@@ -19,6 +24,9 @@ import (
 
 var totalCategory = firefly.Category{Name: "Total", CurrencyCode: ""}
 
+// categoryHistoryMonths is how many months the category detail pane shows.
+const categoryHistoryMonths = 12
+
 type (
 	RefreshCategoriesMsg       struct{}
 	RefreshCategoryInsightsMsg struct{}
@@ -32,25 +40,62 @@ type categoryItem struct {
 	category firefly.Category
 	spent    float64
 	earned   float64
+	// budget named like the category, if it has a limit
+	budget firefly.Budget
+	// envelope shows what is left of the budget instead of the totals
+	envelope bool
+
+	// Set on the Total row only
+	spentTotals  currencyTotals
+	earnedTotals currencyTotals
+
+	amounts money.Formatter
 }
 
 func (i categoryItem) Title() string { return i.category.Name }
 func (i categoryItem) Description() string {
+	if i.envelope {
+		return i.envelopeDescription()
+	}
 	s := ""
-	if i.spent != 0 {
-		s += fmt.Sprintf("Spent: %.2f %s", i.spent, i.category.CurrencyCode)
+	if i.spentTotals.spans(i.category.CurrencyCode) {
+		s += "Spent: " + i.spentTotals.Short(i.amounts)
+	} else if i.spent != 0 {
+		s += "Spent: " + i.amounts.Short(i.spent, i.category.CurrencyCode)
 	}
-	if i.earned != 0 {
+	if i.earnedTotals.spans(i.category.CurrencyCode) {
 		if s != "" {
 			s += " | "
 		}
-		s += fmt.Sprintf("Earned: %.2f %s", i.earned, i.category.CurrencyCode)
+		s += "Earned: " + i.earnedTotals.Short(i.amounts)
+	} else if i.earned != 0 {
+		if s != "" {
+			s += " | "
+		}
+		s += "Earned: " + i.amounts.Short(i.earned, i.category.CurrencyCode)
 	}
 	if s == "" {
 		s = "No transactions"
 	}
+	if hint := i.budgetHint(); hint != "" {
+		s += " | " + hint
+	}
 	return s
 }
+
+// budgetHint returns the limit of the budget and what is left of it, e.g.
+// "Budget: 450.00 EUR, 120.50 left".
+func (i categoryItem) budgetHint() string {
+	if i.budget.Limit == 0 {
+		return ""
+	}
+	remaining := i.budget.Remaining()
+	left := money.ShortNumber(remaining) + " left"
+	if remaining < 0 {
+		left = money.ShortNumber(-remaining) + " over"
+	}
+	return fmt.Sprintf("Budget: %s, %s", i.amounts.Short(i.budget.Limit, i.budget.CurrencyCode), left)
+}
 func (i categoryItem) FilterValue() string { return i.category.Name }
 
 type modelCategories struct {
@@ -58,21 +103,26 @@ type modelCategories struct {
 	api    CategoryAPI
 	focus  bool
 	sorted int
-	keymap CategoryKeyMap
-	styles Styles
+	// envelopes lists the categories with a budget limit by what is left
+	envelopes bool
+	keymap    CategoryKeyMap
+	styles    Styles
+	requests  *requestScope // of the selected period
 }
 
-func newModelCategories(api CategoryAPI) modelCategories {
+func newModelCategories(api CategoryAPI, requests *requestScope) modelCategories {
 	// Set the currency code for the total category
 	totalCategory.CurrencyCode = api.PrimaryCurrency().Code
 
 	items := getCategoriesItems(api, 0)
+	styles := DefaultStyles()
 
 	m := modelCategories{
-		list:   list.New(items, list.NewDefaultDelegate(), 0, 0),
-		api:    api,
-		keymap: DefaultCategoryKeyMap(),
-		styles: DefaultStyles(),
+		list:     list.New(items, newCategoryDelegate(styles), 0, 0),
+		api:      api,
+		keymap:   DefaultCategoryKeyMap(),
+		styles:   styles,
+		requests: requests,
 	}
 	m.list.Title = "Categories"
 	m.list.Styles.HelpStyle = list.DefaultStyles().HelpStyle.PaddingLeft(4).PaddingBottom(1)
@@ -94,36 +144,33 @@ func (m modelCategories) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case RefreshCategoryInsightsMsg:
-		return m, func() tea.Msg {
-			opID := startLoading("Loading category insights...")
-			defer stopLoading(opID)
-			err := m.api.UpdateCategoriesInsights()
+		ctx := m.requests.Context()
+		return m, loading.Track("Loading category insights...", func() tea.Msg {
+			err := m.api.UpdateCategoriesInsights(ctx)
 			if err != nil {
-				return notify.NotifyWarn(err.Error())()
+				return dataLoadFailed("categories", err)
 			}
 			return CategoriesUpdateMsg{}
-		}
+		})
 	case RefreshCategoriesMsg:
-		return m, func() tea.Msg {
-			opID := startLoading("Loading categories...")
-			defer stopLoading(opID)
-			err := m.api.UpdateCategories()
+		return m, loading.Track("Loading categories...", func() tea.Msg {
+			err := m.api.UpdateCategories(context.Background())
 			if err != nil {
-				return notify.NotifyWarn(err.Error())()
+				return dataLoadFailed("categories", err)
 			}
 			return CategoriesUpdateMsg{}
-		}
+		})
 	case CategoriesUpdateMsg:
 		return m, tea.Batch(
 			m.updateItemsCmd(),
 			Cmd(DataLoadCompletedMsg{DataType: "categories"}),
 		)
+	case budgetProposalsMsg:
+		return m, askBudgetLimit(m.api, msg, 0)
 	case NewCategoryMsg:
-		opID := startLoading("Creating category...")
-		defer stopLoading(opID)
-		err := m.api.CreateCategory(msg.Category, "")
+		err := m.api.CreateCategory(context.Background(), msg.Category, "")
 		if err != nil {
-			return m, notify.NotifyWarn(err.Error())
+			return m, notify.NotifyWarn(errorText(err))
 		}
 		return m, tea.Batch(
 			Cmd(RefreshCategoriesMsg{}),
@@ -179,7 +226,29 @@ func (m modelCategories) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		case key.Matches(msg, m.keymap.ResetFilter):
 			return m, Cmd(FilterMsg{Reset: true})
+		case key.Matches(msg, m.keymap.OpenInWeb):
+			i, ok := m.list.SelectedItem().(categoryItem)
+			if !ok || i.category.ID == "" {
+				return m, nil
+			}
+			return m, Cmd(OpenInWebMsg{Path: "categories/show/" + i.category.ID})
+		case key.Matches(msg, m.keymap.Details):
+			i, ok := m.list.SelectedItem().(categoryItem)
+			if !ok || i.category == totalCategory {
+				return m, nil
+			}
+			return m, categoryHistory(m.api, i.category)
+		case key.Matches(msg, m.keymap.Budgets):
+			return m, askBudgetMonths(m.api)
+		case key.Matches(msg, m.keymap.Envelopes):
+			m.envelopes = !m.envelopes
+			m.list.Title = "Categories"
+			if m.envelopes {
+				m.list.Title = "Envelopes"
+			}
+			return m, Cmd(CategoriesUpdateMsg{})
 		case key.Matches(msg, m.keymap.Refresh):
+			m.api.ForgetInsights()
 			return m, Cmd(RefreshCategoriesMsg{})
 		case key.Matches(msg, m.keymap.Sort):
 			switch m.sorted {
@@ -203,6 +272,8 @@ func (m modelCategories) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, SetView(revenuesView)
 		case key.Matches(msg, m.keymap.ViewLiabilities):
 			return m, SetView(liabilitiesView)
+		case key.Matches(msg, m.keymap.ViewTags):
+			return m, SetView(tagsView)
 			// case "R":
 			// 	return m, Cmd(RefreshCategoriesMsg{})
 		}
@@ -225,21 +296,26 @@ func (m *modelCategories) Blur() {
 	m.focus = false
 }
 
-func getCategoriesItems(api CategoriesAPI, sorted int) []list.Item {
+func getCategoriesItems(api CategoryAPI, sorted int) []list.Item {
 	items := []list.Item{}
-	for _, category := range api.CategoriesList() {
-		spent := api.CategorySpent(category.ID)
-		earned := api.CategoryEarned(category.ID)
+	data := fromStore[categoriesReader](api)
+	amounts := money.New(api)
+	for _, category := range data.CategoriesList() {
+		spent := data.CategorySpent(category.ID)
+		earned := data.CategoryEarned(category.ID)
 		if sorted < 0 && spent == 0 {
 			continue
 		}
 		if sorted > 0 && earned == 0 {
 			continue
 		}
+		budget, _ := api.CategoryBudget(category.Name)
 		items = append(items, categoryItem{
 			category: category,
 			spent:    spent,
 			earned:   earned,
+			budget:   budget,
+			amounts:  amounts,
 		})
 	}
 	if sorted < 0 {
@@ -255,6 +331,18 @@ func getCategoriesItems(api CategoriesAPI, sorted int) []list.Item {
 	return items
 }
 
+// categoryHistory fetches the monthly history of a category and opens it in
+// the category detail pane.
+func categoryHistory(api CategoryAPI, category firefly.Category) tea.Cmd {
+	return loading.Track("Loading category history...", func() tea.Msg {
+		history, err := api.CategoryHistory(context.Background(), category.ID, categoryHistoryMonths, time.Now())
+		if err != nil {
+			return notify.NotifyWarn(fmt.Sprintf("Failed to load history of %s: %v", category.Name, err))()
+		}
+		return categorydetail.OpenMsg{Category: category, History: history}
+	})
+}
+
 func CmdPromptNewCategory(backCmd tea.Cmd) tea.Cmd {
 	return prompt.Ask(
 		"New Category(<name>): ",
@@ -271,16 +359,30 @@ func CmdPromptNewCategory(backCmd tea.Cmd) tea.Cmd {
 }
 
 func (m *modelCategories) updateItemsCmd() tea.Cmd {
-	opID := startLoading("Updating caterogy list...")
-	defer stopLoading(opID)
 	items := getCategoriesItems(m.api, m.sorted)
 	tSpent, tEarned := m.api.GetTotalSpentEarnedCategories()
+	spentBy, earnedBy := m.api.CategoryTotals()
+	amounts := money.New(m.api)
+	total := categoryItem{
+		category:     totalCategory,
+		spent:        tSpent,
+		earned:       tEarned,
+		spentTotals:  currencyTotals(spentBy).inPrimary(m.api),
+		earnedTotals: currencyTotals(earnedBy).inPrimary(m.api),
+		amounts:      amounts,
+	}
+	if len(total.spentTotals) > 0 && !total.spentTotals.spans(totalCategory.CurrencyCode) {
+		total.spent = total.spentTotals[totalCategory.CurrencyCode]
+	}
+	if len(total.earnedTotals) > 0 && !total.earnedTotals.spans(totalCategory.CurrencyCode) {
+		total.earned = total.earnedTotals[totalCategory.CurrencyCode]
+	}
+	if m.envelopes {
+		items = envelopeItems(items)
+		total = envelopeTotal(items, amounts)
+	}
 	return tea.Sequence(
 		m.list.SetItems(items),
-		m.list.InsertItem(0, categoryItem{
-			category: totalCategory,
-			spent:    tSpent,
-			earned:   tEarned,
-		}),
+		m.list.InsertItem(0, total),
 	)
 }
@@ -6,18 +6,24 @@ SPDX-License-Identifier: Apache-2.0
 package ui
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/categorydetail"
 	"ffiii-tui/internal/ui/notify"
 	"ffiii-tui/internal/ui/prompt"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/viper"
 )
 
 type mockCategoryAPI struct {
+	categoryTotalsFunc             func() (spent, earned map[string]float64)
+	convertToPrimaryFunc           func(amount float64, currencyCode string) (float64, bool)
 	updateCategoriesFunc           func() error
 	updateCategoriesInsightsFunc   func() error
 	categoriesListFunc             func() []firefly.Category
@@ -25,13 +31,22 @@ type mockCategoryAPI struct {
 	categorySpentFunc              func(categoryID string) float64
 	categoryEarnedFunc             func(categoryID string) float64
 	createCategoryFunc             func(name, notes string) error
+	categoryHistoryFunc            func(categoryID string, months int) (firefly.CategoryHistory, error)
+	categoryBudgetFunc             func(categoryName string) (firefly.Budget, bool)
+	budgets                        []firefly.Budget
+	budgetAverages                 map[string]float64
+	budgetAveragesErr              error
+	budgetAveragesCalledWith       []int
+	setBudgetLimitErr              error
+	setBudgetLimitCalledWith       []budgetProposal
 	primaryCurrencyFunc            func() firefly.Currency
 	updateCategoriesCalled         bool
 	updateCategoriesInsightsCalled bool
+	forgetInsightsCalled           int
 	createCategoryCalledWith       []struct{ name, notes string }
 }
 
-func (m *mockCategoryAPI) UpdateCategories() error {
+func (m *mockCategoryAPI) UpdateCategories(_ context.Context) error {
 	m.updateCategoriesCalled = true
 	if m.updateCategoriesFunc != nil {
 		return m.updateCategoriesFunc()
@@ -39,7 +54,11 @@ func (m *mockCategoryAPI) UpdateCategories() error {
 	return nil
 }
 
-func (m *mockCategoryAPI) UpdateCategoriesInsights() error {
+func (m *mockCategoryAPI) ForgetInsights() {
+	m.forgetInsightsCalled++
+}
+
+func (m *mockCategoryAPI) UpdateCategoriesInsights(_ context.Context) error {
 	m.updateCategoriesInsightsCalled = true
 	if m.updateCategoriesInsightsFunc != nil {
 		return m.updateCategoriesInsightsFunc()
@@ -75,7 +94,7 @@ func (m *mockCategoryAPI) CategoryEarned(categoryID string) float64 {
 	return 0
 }
 
-func (m *mockCategoryAPI) CreateCategory(name, notes string) error {
+func (m *mockCategoryAPI) CreateCategory(_ context.Context, name, notes string) error {
 	m.createCategoryCalledWith = append(m.createCategoryCalledWith, struct{ name, notes string }{name, notes})
 	if m.createCategoryFunc != nil {
 		return m.createCategoryFunc(name, notes)
@@ -83,6 +102,38 @@ func (m *mockCategoryAPI) CreateCategory(name, notes string) error {
 	return nil
 }
 
+func (m *mockCategoryAPI) CategoryHistory(_ context.Context, categoryID string, months int, _ time.Time) (firefly.CategoryHistory, error) {
+	if m.categoryHistoryFunc != nil {
+		return m.categoryHistoryFunc(categoryID, months)
+	}
+	return firefly.CategoryHistory{}, nil
+}
+
+func (m *mockCategoryAPI) CategoryBudget(categoryName string) (firefly.Budget, bool) {
+	if m.categoryBudgetFunc != nil {
+		return m.categoryBudgetFunc(categoryName)
+	}
+	return firefly.Budget{}, false
+}
+
+func (m *mockCategoryAPI) Budgets() []firefly.Budget {
+	return m.budgets
+}
+
+func (m *mockCategoryAPI) BudgetAverages(_ context.Context, months int, _ time.Time) (map[string]float64, error) {
+	m.budgetAveragesCalledWith = append(m.budgetAveragesCalledWith, months)
+	return m.budgetAverages, m.budgetAveragesErr
+}
+
+func (m *mockCategoryAPI) SetBudgetLimit(_ context.Context, budget firefly.Budget, amount float64, _, _ time.Time) error {
+	m.setBudgetLimitCalledWith = append(m.setBudgetLimitCalledWith, budgetProposal{budget: budget, limit: amount})
+	return m.setBudgetLimitErr
+}
+
+func (m *mockCategoryAPI) PeriodStart() time.Time {
+	return time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+}
+
 func (m *mockCategoryAPI) PrimaryCurrency() firefly.Currency {
 	if m.primaryCurrencyFunc != nil {
 		return m.primaryCurrencyFunc()
@@ -90,6 +141,24 @@ func (m *mockCategoryAPI) PrimaryCurrency() firefly.Currency {
 	return firefly.Currency{Code: "USD", Symbol: "$"}
 }
 
+func (m *mockCategoryAPI) GetCurrencyByCode(code string) firefly.Currency {
+	return firefly.Currency{Code: code}
+}
+
+func (m *mockCategoryAPI) ConvertToPrimary(amount float64, currencyCode string) (float64, bool) {
+	if m.convertToPrimaryFunc != nil {
+		return m.convertToPrimaryFunc(amount, currencyCode)
+	}
+	return amount, false
+}
+
+func (m *mockCategoryAPI) CategoryTotals() (spent, earned map[string]float64) {
+	if m.categoryTotalsFunc != nil {
+		return m.categoryTotalsFunc()
+	}
+	return nil, nil
+}
+
 func newFocusedCategoriesModelWithCategory(t *testing.T, cat firefly.Category) modelCategories {
 	t.Helper()
 
@@ -104,7 +173,7 @@ func newFocusedCategoriesModelWithCategory(t *testing.T, cat firefly.Category) m
 		},
 	}
 
-	m := newModelCategories(api)
+	m := newModelCategories(api, newRequestScope())
 	(&m).Focus()
 	return m
 }
@@ -175,6 +244,33 @@ func TestGetCategoriesItems_UsesCategorySpentAndEarnedAPI(t *testing.T) {
 	}
 }
 
+func TestGetCategoriesItems_MatchesBudgetsByName(t *testing.T) {
+	api := &mockCategoryAPI{
+		categoriesListFunc: func() []firefly.Category {
+			return []firefly.Category{
+				{ID: "c1", Name: "Groceries", CurrencyCode: "EUR"},
+				{ID: "c2", Name: "Transport", CurrencyCode: "EUR"},
+			}
+		},
+		categorySpentFunc:  func(categoryID string) float64 { return 0 },
+		categoryEarnedFunc: func(categoryID string) float64 { return 0 },
+		categoryBudgetFunc: func(categoryName string) (firefly.Budget, bool) {
+			if categoryName == "Groceries" {
+				return firefly.Budget{Name: "Groceries", Limit: 450, CurrencyCode: "EUR"}, true
+			}
+			return firefly.Budget{}, false
+		},
+	}
+
+	items := getCategoriesItems(api, 0)
+	if got := items[0].(categoryItem).budget.Limit; got != 450 {
+		t.Errorf("expected Groceries budget limit 450, got %.2f", got)
+	}
+	if got := items[1].(categoryItem).budget; got != (firefly.Budget{}) {
+		t.Errorf("expected no budget for Transport, got %+v", got)
+	}
+}
+
 func TestGetCategoriesItems_SortsAndFiltersCorrectly(t *testing.T) {
 	api := &mockCategoryAPI{
 		categoriesListFunc: func() []firefly.Category {
@@ -277,7 +373,7 @@ func TestNewModelCategories_InitializesCurrencyCode(t *testing.T) {
 		},
 	}
 
-	_ = newModelCategories(api)
+	_ = newModelCategories(api, newRequestScope())
 
 	if totalCategory.CurrencyCode != "EUR" {
 		t.Errorf("expected totalCategory.CurrencyCode 'EUR', got %q", totalCategory.CurrencyCode)
@@ -298,14 +394,14 @@ func TestRefreshCategoryInsightsMsg_Success(t *testing.T) {
 		},
 	}
 
-	m := newModelCategories(api)
+	m := newModelCategories(api, newRequestScope())
 	_, cmd := m.Update(RefreshCategoryInsightsMsg{})
 
 	if cmd == nil {
 		t.Fatal("expected a command, got nil")
 	}
 
-	msg := cmd()
+	msg := trackedMsg(cmd)
 	if _, ok := msg.(CategoriesUpdateMsg); !ok {
 		t.Errorf("expected CategoriesUpdateMsg, got %T", msg)
 	}
@@ -331,17 +427,21 @@ func TestRefreshCategoryInsightsMsg_Error(t *testing.T) {
 		},
 	}
 
-	m := newModelCategories(api)
+	m := newModelCategories(api, newRequestScope())
 	_, cmd := m.Update(RefreshCategoryInsightsMsg{})
 
 	if cmd == nil {
 		t.Fatal("expected a command, got nil")
 	}
 
-	msg := cmd()
-	notifyMsg, ok := msg.(notify.NotifyMsg)
+	msgs := collectMsgsFromCmd(cmd)
+	failed, ok := findMsg[DataLoadFailedMsg](msgs)
+	if !ok || failed.DataType != "categories" {
+		t.Errorf("expected DataLoadFailedMsg for categories, got %v", msgs)
+	}
+	notifyMsg, ok := msgs[0].(notify.NotifyMsg)
 	if !ok {
-		t.Fatalf("expected notify.NotifyMsg, got %T", msg)
+		t.Fatalf("expected notify.NotifyMsg, got %T", msgs[0])
 	}
 	if notifyMsg.Level != notify.Warn {
 		t.Errorf("expected notify level Warn, got %v", notifyMsg.Level)
@@ -363,14 +463,14 @@ func TestRefreshCategoriesMsg_Success(t *testing.T) {
 		},
 	}
 
-	m := newModelCategories(api)
+	m := newModelCategories(api, newRequestScope())
 	_, cmd := m.Update(RefreshCategoriesMsg{})
 
 	if cmd == nil {
 		t.Fatal("expected a command, got nil")
 	}
 
-	msg := cmd()
+	msg := trackedMsg(cmd)
 	if _, ok := msg.(CategoriesUpdateMsg); !ok {
 		t.Errorf("expected CategoriesUpdateMsg, got %T", msg)
 	}
@@ -396,17 +496,20 @@ func TestRefreshCategoriesMsg_Error(t *testing.T) {
 		},
 	}
 
-	m := newModelCategories(api)
+	m := newModelCategories(api, newRequestScope())
 	_, cmd := m.Update(RefreshCategoriesMsg{})
 
 	if cmd == nil {
 		t.Fatal("expected a command, got nil")
 	}
 
-	msg := cmd()
-	notifyMsg, ok := msg.(notify.NotifyMsg)
+	msgs := collectMsgsFromCmd(cmd)
+	if !hasMsg[DataLoadFailedMsg](msgs) {
+		t.Errorf("expected DataLoadFailedMsg, got %v", msgs)
+	}
+	notifyMsg, ok := msgs[0].(notify.NotifyMsg)
 	if !ok {
-		t.Fatalf("expected notify.NotifyMsg, got %T", msg)
+		t.Fatalf("expected notify.NotifyMsg, got %T", msgs[0])
 	}
 	if notifyMsg.Level != notify.Warn {
 		t.Errorf("expected notify level Warn, got %v", notifyMsg.Level)
@@ -431,7 +534,7 @@ func TestCategoriesUpdateMsg_SetItemsWithTotal(t *testing.T) {
 		},
 	}
 
-	m := newModelCategories(api)
+	m := newModelCategories(api, newRequestScope())
 	updated, cmd := m.Update(CategoriesUpdateMsg{})
 	m2 := updated.(modelCategories)
 
@@ -493,7 +596,7 @@ func TestNewCategoryMsg_Success(t *testing.T) {
 		},
 	}
 
-	m := newModelCategories(api)
+	m := newModelCategories(api, newRequestScope())
 	_, cmd := m.Update(NewCategoryMsg{Category: "NewCat"})
 
 	if cmd == nil {
@@ -547,7 +650,7 @@ func TestNewCategoryMsg_Error(t *testing.T) {
 		},
 	}
 
-	m := newModelCategories(api)
+	m := newModelCategories(api, newRequestScope())
 	_, cmd := m.Update(NewCategoryMsg{Category: "BadCat"})
 
 	if cmd == nil {
@@ -622,7 +725,7 @@ func TestKeyFilter_TotalCategory_NoAction(t *testing.T) {
 		},
 	}
 
-	m := newModelCategories(api)
+	m := newModelCategories(api, newRequestScope())
 	(&m).Focus()
 	updated, _ := m.Update(CategoriesUpdateMsg{})
 	m = updated.(modelCategories)
@@ -636,6 +739,64 @@ func TestKeyFilter_TotalCategory_NoAction(t *testing.T) {
 	}
 }
 
+func TestKeyDetails_OpensCategoryHistory(t *testing.T) {
+	cat := firefly.Category{ID: "c1", Name: "Groceries", CurrencyCode: "USD"}
+	m := newFocusedCategoriesModelWithCategory(t, cat)
+	api := m.api.(*mockCategoryAPI)
+	api.categoryHistoryFunc = func(categoryID string, months int) (firefly.CategoryHistory, error) {
+		if categoryID != "c1" || months != categoryHistoryMonths {
+			t.Errorf("unexpected history request for %q over %d months", categoryID, months)
+		}
+		return firefly.CategoryHistory{
+			TopAccounts: []firefly.CategoryAccount{{Name: "Supermarket", Spent: 120}},
+		}, nil
+	}
+
+	for _, k := range []tea.KeyMsg{
+		{Type: tea.KeyEnter},
+		{Type: tea.KeyRunes, Runes: []rune{'v'}},
+	} {
+		_, cmd := m.Update(k)
+		if cmd == nil {
+			t.Fatalf("expected a command for %q, got nil", k.String())
+		}
+		msg, ok := trackedMsg(cmd).(categorydetail.OpenMsg)
+		if !ok {
+			t.Fatalf("expected categorydetail.OpenMsg for %q", k.String())
+		}
+		if msg.Category != cat || len(msg.History.TopAccounts) != 1 {
+			t.Errorf("unexpected detail message %+v", msg)
+		}
+	}
+}
+
+func TestKeyDetails_Error_Notifies(t *testing.T) {
+	cat := firefly.Category{ID: "c1", Name: "Groceries", CurrencyCode: "USD"}
+	m := newFocusedCategoriesModelWithCategory(t, cat)
+	m.api.(*mockCategoryAPI).categoryHistoryFunc = func(string, int) (firefly.CategoryHistory, error) {
+		return firefly.CategoryHistory{}, errors.New("timeout")
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected a command, got nil")
+	}
+	if _, ok := trackedMsg(cmd).(notify.NotifyMsg); !ok {
+		t.Error("expected a warning when the history fails to load")
+	}
+}
+
+func TestKeyDetails_TotalCategory_NoAction(t *testing.T) {
+	m := newFocusedCategoriesModelWithCategory(t, firefly.Category{ID: "c1", Name: "Groceries"})
+	updated, _ := m.Update(CategoriesUpdateMsg{})
+	m = updated.(modelCategories)
+	m.list.Select(0)
+
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Error("expected no command for the Total category")
+	}
+}
+
 func TestKeyRefresh_SendsRefreshMsg(t *testing.T) {
 	cat := firefly.Category{ID: "c1", Name: "Groceries", CurrencyCode: "USD"}
 	m := newFocusedCategoriesModelWithCategory(t, cat)
@@ -650,6 +811,9 @@ func TestKeyRefresh_SendsRefreshMsg(t *testing.T) {
 	if _, ok := msg.(RefreshCategoriesMsg); !ok {
 		t.Errorf("expected RefreshCategoriesMsg, got %T", msg)
 	}
+	if got := m.api.(*mockCategoryAPI).forgetInsightsCalled; got != 1 {
+		t.Errorf("expected cached insights to be dropped once, got %d", got)
+	}
 }
 
 func TestKeySort_CyclesThroughStates(t *testing.T) {
@@ -956,6 +1120,32 @@ func TestCategoryItem_Description(t *testing.T) {
 			},
 			wantDesc: "No transactions",
 		},
+		{
+			name: "budget with amount left",
+			item: categoryItem{
+				category: firefly.Category{Name: "Groceries", CurrencyCode: "EUR"},
+				spent:    329.50,
+				budget:   firefly.Budget{Name: "Groceries", Limit: 450, Spent: 329.50, CurrencyCode: "EUR"},
+			},
+			wantDesc: "Spent: 329.50 EUR | Budget: 450.00 EUR, 120.50 left",
+		},
+		{
+			name: "overspent budget",
+			item: categoryItem{
+				category: firefly.Category{Name: "Dining out", CurrencyCode: "EUR"},
+				spent:    170,
+				budget:   firefly.Budget{Name: "Dining out", Limit: 150, Spent: 170, CurrencyCode: "EUR"},
+			},
+			wantDesc: "Spent: 170.00 EUR | Budget: 150.00 EUR, 20.00 over",
+		},
+		{
+			name: "budget without limit",
+			item: categoryItem{
+				category: firefly.Category{Name: "Health", CurrencyCode: "EUR"},
+				budget:   firefly.Budget{Name: "Health", CurrencyCode: "EUR"},
+			},
+			wantDesc: "No transactions",
+		},
 	}
 
 	for _, tt := range tests {
@@ -968,6 +1158,22 @@ func TestCategoryItem_Description(t *testing.T) {
 	}
 }
 
+func TestCategoryItem_DescriptionAbbreviated(t *testing.T) {
+	viper.Set("ui.abbreviate_amounts", true)
+	t.Cleanup(func() { viper.Set("ui.abbreviate_amounts", nil) })
+
+	item := categoryItem{
+		category: firefly.Category{Name: "Rent", CurrencyCode: "EUR"},
+		spent:    12345.67,
+		earned:   250,
+		budget:   firefly.Budget{Name: "Rent", Limit: 15000, Spent: 12345.67, CurrencyCode: "EUR"},
+	}
+	want := "Spent: 12.3k EUR | Earned: 250.00 EUR | Budget: 15k EUR, 2.7k left"
+	if got := item.Description(); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
 func TestCategoryItem_TitleAndFilterValue(t *testing.T) {
 	cat := firefly.Category{ID: "c1", Name: "TestCategory", CurrencyCode: "USD"}
 	item := categoryItem{
@@ -1036,7 +1242,7 @@ func TestModelCategories_LargeValues(t *testing.T) {
 		},
 	}
 
-	m := newModelCategories(api)
+	m := newModelCategories(api, newRequestScope())
 	_, cmd := m.Update(CategoriesUpdateMsg{})
 
 	if cmd == nil {
@@ -1072,7 +1278,7 @@ func TestModelCategories_NegativeValues(t *testing.T) {
 		},
 	}
 
-	m := newModelCategories(api)
+	m := newModelCategories(api, newRequestScope())
 	_, cmd := m.Update(CategoriesUpdateMsg{})
 
 	if cmd == nil {
@@ -1116,7 +1322,7 @@ func TestModelCategories_SpecialCharactersInCategoryName(t *testing.T) {
 				},
 			}
 
-			m := newModelCategories(api)
+			m := newModelCategories(api, newRequestScope())
 			items := m.list.Items()
 
 			if len(items) != 1 {
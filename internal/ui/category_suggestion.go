@@ -0,0 +1,74 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"sync"
+
+	"ffiii-tui/internal/firefly"
+)
+
+// categorySuggestions knows the category used most with each expense
+// account in the loaded transactions.
+type categorySuggestions struct {
+	mu            sync.Mutex
+	byDestination map[string]firefly.Category
+}
+
+// learn replaces the suggestions with the ones of transactions. Ties go to
+// the category seen first, the list is newest first.
+func (c *categorySuggestions) learn(transactions []firefly.Transaction) {
+	counts := map[string]map[string]int{}
+	best := map[string]firefly.Category{}
+	for _, trx := range transactions {
+		for _, s := range trx.Splits {
+			if s.Destination.Type != "expense" || s.Destination.ID == "" || s.Category.ID == "" {
+				continue
+			}
+			perCategory, ok := counts[s.Destination.ID]
+			if !ok {
+				perCategory = map[string]int{}
+				counts[s.Destination.ID] = perCategory
+			}
+			perCategory[s.Category.ID]++
+
+			current, ok := best[s.Destination.ID]
+			if !ok || perCategory[s.Category.ID] > perCategory[current.ID] {
+				best[s.Destination.ID] = s.Category
+			}
+		}
+	}
+
+	c.mu.Lock()
+	c.byDestination = best
+	c.mu.Unlock()
+}
+
+func (c *categorySuggestions) forDestination(account firefly.Account) (firefly.Category, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	category, ok := c.byDestination[account.ID]
+	return category, ok
+}
+
+// followDestinations pre-selects the suggested category of splits whose
+// destination changed, unless the user picked a category for them. before
+// are the values prior to msg.
+func (m *modelTransaction) followDestinations(before formValues, byKey bool) {
+	for i, s := range m.splits {
+		if i >= len(before.splits) {
+			break
+		}
+		if byKey && s.category != before.splits[i].category {
+			s.categoryPicked = true
+		}
+		if s.destination == before.splits[i].destination || s.categoryPicked {
+			continue
+		}
+		if category, ok := m.suggestions.forDestination(s.destination); ok {
+			s.category = category
+		}
+	}
+}
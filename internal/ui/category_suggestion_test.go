@@ -0,0 +1,133 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestCategorySuggestions_Learn(t *testing.T) {
+	trx := func(destination firefly.Account, category firefly.Category) firefly.Transaction {
+		return firefly.Transaction{Splits: []firefly.Split{{
+			Source:      testAssetChecking,
+			Destination: destination,
+			Category:    category,
+		}}}
+	}
+
+	c := &categorySuggestions{}
+	c.learn([]firefly.Transaction{
+		trx(testExpenseGroceries, testCategoryBills),
+		trx(testExpenseGroceries, testCategoryFood),
+		trx(testExpenseGroceries, testCategoryFood),
+		trx(testExpenseUtilities, testCategoryBills),
+		trx(testExpenseUtilities, testCategoryFood),
+		trx(testExpenseUtilities, firefly.Category{}),
+		trx(testAssetSavings, testCategoryIncome),
+	})
+
+	tests := []struct {
+		destination firefly.Account
+		want        firefly.Category
+		ok          bool
+	}{
+		{testExpenseGroceries, testCategoryFood, true},
+		{testExpenseUtilities, testCategoryBills, true}, // tie, newest first
+		{testAssetSavings, firefly.Category{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := c.forDestination(tt.destination)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("forDestination(%s) = %q, %v, want %q, %v", tt.destination.Name, got.Name, ok, tt.want.Name, tt.ok)
+		}
+	}
+
+	c.learn(nil)
+	if _, ok := c.forDestination(testExpenseGroceries); ok {
+		t.Error("expected suggestions to be replaced")
+	}
+}
+
+func TestCategorySuggestions_FollowDestination(t *testing.T) {
+	m := newTestTransactionModel()
+	m.suggestions.learn([]firefly.Transaction{{Splits: []firefly.Split{
+		{Destination: testExpenseUtilities, Category: testCategoryBills},
+	}}})
+	m.splits = []*split{{source: testAssetChecking, destination: testExpenseGroceries, category: testCategoryFood}}
+
+	before := m.values()
+	m.splits[0].destination = testExpenseUtilities
+	m.followDestinations(before, true)
+	if m.splits[0].category != testCategoryBills {
+		t.Errorf("expected suggested category, got %q", m.splits[0].category.Name)
+	}
+
+	before = m.values()
+	m.splits[0].category = testCategoryIncome
+	m.followDestinations(before, true)
+	before = m.values()
+	m.splits[0].destination = testExpenseGroceries
+	m.followDestinations(before, true)
+	m.splits[0].destination = testExpenseUtilities
+	m.followDestinations(before, true)
+	if m.splits[0].category != testCategoryIncome {
+		t.Errorf("expected picked category to be kept, got %q", m.splits[0].category.Name)
+	}
+}
+
+func TestCategorySuggestions_KeptByCategoryField(t *testing.T) {
+	m := newTestTransactionModel()
+	m.Focus()
+	updated, _ := m.Update(TransactionsUpdateMsg{Transactions: []firefly.Transaction{{Splits: []firefly.Split{
+		{Destination: testExpenseGroceries, Category: testCategoryBills},
+	}}}})
+	m = updated.(modelTransaction)
+
+	m.SetTransaction(firefly.Transaction{}, true)
+	m.created = true
+	m.UpdateForm()
+	m = settleForm(m, m.form.Init())
+	if m.splits[0].destination != testExpenseGroceries || m.splits[0].category != testCategoryBills {
+		t.Fatalf("expected the category of Groceries, got %q -> %q", m.splits[0].destination.Name, m.splits[0].category.Name)
+	}
+
+	// Source, destination, then leave the category field as it is
+	for range 3 {
+		updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		m = settleForm(updated.(modelTransaction), cmd)
+	}
+	if m.splits[0].category != testCategoryBills {
+		t.Errorf("expected the suggestion to stay selected, got %q", m.splits[0].category.Name)
+	}
+	if m.splits[0].categoryPicked {
+		t.Error("expected confirming the suggestion not to count as picking")
+	}
+}
+
+func TestCategorySuggestions_EditKeepsCategory(t *testing.T) {
+	m := newTestTransactionModel()
+	m.suggestions.learn([]firefly.Transaction{{Splits: []firefly.Split{
+		{Destination: testExpenseUtilities, Category: testCategoryBills},
+	}}})
+	m.SetTransaction(firefly.Transaction{
+		TransactionID: "1",
+		Type:          "withdrawal",
+		Date:          "2026-02-03",
+		Splits: []firefly.Split{
+			{Source: testAssetChecking, Destination: testExpenseGroceries, Category: testCategoryFood},
+		},
+	}, false)
+
+	before := m.values()
+	m.splits[0].destination = testExpenseUtilities
+	m.followDestinations(before, true)
+	if m.splits[0].category != testCategoryFood {
+		t.Errorf("expected the category of the transaction to be kept, got %q", m.splits[0].category.Name)
+	}
+}
@@ -0,0 +1,173 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package categorydetail
+
+import (
+	"fmt"
+	"strings"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/money"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// labelWidth and amountWidth are the columns around the bars of a month.
+const (
+	labelWidth  = 10
+	amountWidth = 11
+)
+
+type OpenMsg struct {
+	Category firefly.Category
+	History  firefly.CategoryHistory
+}
+
+type CloseMsg struct{}
+
+type Model struct {
+	category firefly.Category
+	history  firefly.CategoryHistory
+	focus    bool
+	styles   Styles
+	amounts  money.Formatter
+	Width    int
+	Height   int
+}
+
+func New(amounts money.Formatter) Model {
+	return Model{
+		styles:  DefaultStyles(),
+		amounts: amounts,
+		Width:   80,
+		Height:  24,
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case OpenMsg:
+		m.category = msg.Category
+		m.history = msg.History
+		m.Focus()
+		return m, nil
+	case CloseMsg:
+		m.Blur()
+		return m, nil
+	}
+
+	if !m.focus {
+		return m, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc", "enter", "q", "v":
+			return m, Close()
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) View() string {
+	if !m.focus {
+		return ""
+	}
+
+	borderW, borderH := m.styles.Border.GetFrameSize()
+	width := max(m.Width-borderW, 0)
+	code := m.category.CurrencyCode
+
+	var b strings.Builder
+	b.WriteString(m.styles.Title.Render(m.category.Name) +
+		m.styles.Desc.Render(fmt.Sprintf("  last %d months (esc to close)", len(m.history.Months))) + "\n\n")
+
+	b.WriteString(m.styles.Label.Render(fmt.Sprintf("%-*s", labelWidth, "Month")) +
+		m.styles.Spent.Render("Spent") + m.styles.Desc.Render(" / ") +
+		m.styles.Earned.Render("Earned") + m.styles.Desc.Render(" "+code) + "\n")
+
+	peak := 0.0
+	for _, month := range m.history.Months {
+		peak = max(peak, month.Spent, month.Earned)
+	}
+	barWidth := max((width-labelWidth-2*amountWidth-2)/2, 1)
+	for _, month := range m.history.Months {
+		b.WriteString(m.styles.Label.Render(fmt.Sprintf("%-*s", labelWidth, month.Month.Format("Jan 2006"))))
+		b.WriteString(m.bar(month.Spent, peak, barWidth, m.styles.Spent))
+		b.WriteString(" ")
+		b.WriteString(m.bar(month.Earned, peak, barWidth, m.styles.Earned))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n" + m.styles.Label.Render("Top expense accounts") + "\n")
+	if len(m.history.TopAccounts) == 0 {
+		b.WriteString(m.styles.Empty.Render("-") + "\n")
+	}
+	for _, account := range m.history.TopAccounts {
+		b.WriteString(m.styles.Value.Render(fmt.Sprintf("%-24s %14s", account.Name, m.amounts.Format(account.Spent, code))) + "\n")
+	}
+
+	return m.styles.Border.
+		Width(width).
+		Height(max(m.Height-borderH, 0)).
+		Render(strings.TrimSuffix(b.String(), "\n"))
+}
+
+// bar renders amount as a bar scaled to peak, followed by the amount.
+func (m Model) bar(amount, peak float64, width int, style lipgloss.Style) string {
+	n := 0
+	if peak > 0 {
+		n = int(amount / peak * float64(width))
+	}
+	if amount > 0 && n == 0 {
+		n = 1
+	}
+	bar := style.Render(strings.Repeat("█", n)) + strings.Repeat(" ", width-n)
+	if amount == 0 {
+		return bar + m.styles.Empty.Render(fmt.Sprintf("%*s", amountWidth, "-"))
+	}
+	return bar + m.styles.Value.Render(fmt.Sprintf("%*.2f", amountWidth, amount))
+}
+
+func (m *Model) Focus() {
+	m.focus = true
+}
+
+func (m *Model) Blur() {
+	m.focus = false
+}
+
+func (m *Model) Focused() bool {
+	return m.focus
+}
+
+func (m *Model) WithSize(width, height int) *Model {
+	m.Width = width
+	m.Height = height
+	return m
+}
+
+func (m *Model) WithStyles(styles Styles) *Model {
+	m.styles = styles
+	return m
+}
+
+func Open(category firefly.Category, history firefly.CategoryHistory) tea.Cmd {
+	return func() tea.Msg {
+		return OpenMsg{Category: category, History: history}
+	}
+}
+
+func Close() tea.Cmd {
+	return func() tea.Msg {
+		return CloseMsg{}
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package categorydetail
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/money"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func openModel(t *testing.T, history firefly.CategoryHistory) Model {
+	t.Helper()
+	m := New(money.Formatter{})
+	updated, _ := m.Update(OpenMsg{
+		Category: firefly.Category{ID: "c1", Name: "Groceries", CurrencyCode: "EUR"},
+		History:  history,
+	})
+	m = updated.(Model)
+	if !m.Focused() {
+		t.Fatal("Expected model to be focused after OpenMsg")
+	}
+	return m
+}
+
+func TestNew(t *testing.T) {
+	m := New(money.Formatter{})
+
+	if m.Focused() {
+		t.Error("Expected new model to be unfocused")
+	}
+	if m.View() != "" {
+		t.Error("Expected empty view when unfocused")
+	}
+}
+
+func TestView_History(t *testing.T) {
+	m := openModel(t, firefly.CategoryHistory{
+		Months: []firefly.CategoryMonth{
+			{Month: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Spent: 400, Earned: 0},
+			{Month: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), Spent: 200, Earned: 25},
+		},
+		TopAccounts: []firefly.CategoryAccount{
+			{Name: "Supermarket", Spent: 450},
+			{Name: "Bakery", Spent: 150},
+		},
+	})
+	view := m.View()
+
+	for _, want := range []string{
+		"Groceries",
+		"last 2 months",
+		"Jan 2026", "400.00",
+		"Feb 2026", "200.00", "25.00",
+		"Top expense accounts",
+		"Supermarket", "450.00 EUR",
+		"Bakery", "150.00 EUR",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected view to contain %q", want)
+		}
+	}
+
+	lines := strings.Split(view, "\n")
+	var jan, feb string
+	for _, line := range lines {
+		if strings.Contains(line, "Jan 2026") {
+			jan = line
+		}
+		if strings.Contains(line, "Feb 2026") {
+			feb = line
+		}
+	}
+	if got, want := strings.Count(jan, "█"), 2*strings.Count(feb, "█"); got < want-2 {
+		t.Errorf("Expected January's bars to be about twice February's, got %d and %d", got, strings.Count(feb, "█"))
+	}
+}
+
+func TestView_Empty(t *testing.T) {
+	m := openModel(t, firefly.CategoryHistory{
+		Months: []firefly.CategoryMonth{{Month: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}},
+	})
+	view := m.View()
+
+	if strings.Contains(view, "█") {
+		t.Error("Expected no bars without amounts")
+	}
+	if !strings.Contains(view, "Top expense accounts") {
+		t.Error("Expected top expense accounts section")
+	}
+}
+
+func TestUpdate_Close(t *testing.T) {
+	for _, k := range []string{"esc", "enter", "q", "v"} {
+		t.Run(k, func(t *testing.T) {
+			m := openModel(t, firefly.CategoryHistory{})
+			var msg tea.KeyMsg
+			switch k {
+			case "esc":
+				msg = tea.KeyMsg{Type: tea.KeyEsc}
+			case "enter":
+				msg = tea.KeyMsg{Type: tea.KeyEnter}
+			default:
+				msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+			}
+			updated, cmd := m.Update(msg)
+			m = updated.(Model)
+			if cmd == nil {
+				t.Fatal("Expected close command")
+			}
+			if _, ok := cmd().(CloseMsg); !ok {
+				t.Fatal("Expected CloseMsg")
+			}
+			updated, _ = m.Update(CloseMsg{})
+			m = updated.(Model)
+			if m.Focused() {
+				t.Error("Expected model to be blurred after CloseMsg")
+			}
+		})
+	}
+}
+
+func TestUpdate_IgnoresKeysWhenUnfocused(t *testing.T) {
+	m := New(money.Formatter{})
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc}); cmd != nil {
+		t.Error("Expected no command when unfocused")
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package cellview
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxWidth is the widest the popup gets, long values wrap within it.
+const maxWidth = 72
+
+// Field is a cell by its column title.
+type Field struct {
+	Name  string
+	Value string
+}
+
+type OpenMsg struct {
+	Title  string
+	Fields []Field
+}
+
+type CloseMsg struct{}
+
+// Model shows the full values of table cells cut short to fit their
+// columns, in a popup over the view.
+type Model struct {
+	title  string
+	fields []Field
+	focus  bool
+	styles Styles
+	Width  int
+	Height int
+}
+
+func New() Model {
+	return Model{
+		styles: DefaultStyles(),
+		Width:  80,
+		Height: 24,
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case OpenMsg:
+		m.title = msg.Title
+		m.fields = msg.Fields
+		m.Focus()
+		return m, nil
+	case CloseMsg:
+		m.Blur()
+		return m, nil
+	}
+
+	if !m.focus {
+		return m, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc", "q", "enter", "v":
+			return m, Close()
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) View() string {
+	if !m.focus {
+		return ""
+	}
+
+	borderW, _ := m.styles.Border.GetFrameSize()
+	width := max(min(m.Width-borderW, maxWidth), 1)
+
+	var b strings.Builder
+	b.WriteString(m.styles.Title.Render(m.title) + "\n")
+	for _, f := range m.fields {
+		value := f.Value
+		if value == "" {
+			value = "-"
+		}
+		b.WriteString("\n" + m.styles.Name.Render(f.Name) + "\n")
+		b.WriteString(m.styles.Value.Width(width).Render(value) + "\n")
+	}
+	b.WriteString("\n" + m.styles.Desc.Render("esc to close"))
+
+	box := m.styles.Border.Render(b.String())
+	return lipgloss.Place(m.Width, m.Height, lipgloss.Center, lipgloss.Center, box)
+}
+
+func (m *Model) Focus() {
+	m.focus = true
+}
+
+func (m *Model) Blur() {
+	m.focus = false
+}
+
+func (m *Model) Focused() bool {
+	return m.focus
+}
+
+func (m *Model) WithSize(width, height int) *Model {
+	m.Width = width
+	m.Height = height
+	return m
+}
+
+func (m *Model) WithStyles(styles Styles) *Model {
+	m.styles = styles
+	return m
+}
+
+func Open(title string, fields []Field) tea.Cmd {
+	return func() tea.Msg {
+		return OpenMsg{Title: title, Fields: fields}
+	}
+}
+
+func Close() tea.Cmd {
+	return func() tea.Msg {
+		return CloseMsg{}
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package cellview
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func openModel(t *testing.T) Model {
+	t.Helper()
+	m := New()
+	updated, _ := m.Update(OpenMsg{
+		Title: "Transaction #12",
+		Fields: []Field{
+			{Name: "Description", Value: strings.Repeat("groceries and household ", 5)},
+			{Name: "Category", Value: ""},
+		},
+	})
+	m = updated.(Model)
+	if !m.Focused() {
+		t.Fatal("Expected model to be focused after OpenMsg")
+	}
+	return m
+}
+
+func TestView_WrapsValues(t *testing.T) {
+	m := openModel(t)
+	view := m.View()
+
+	for _, want := range []string{"Transaction #12", "Description", "Category", "-", "esc to close"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected view to contain %q", want)
+		}
+	}
+	if got := strings.Count(view, "household"); got != 5 {
+		t.Errorf("Expected the whole value wrapped, got %d of 5 words in\n%s", got, view)
+	}
+	for _, line := range strings.Split(view, "\n") {
+		if len([]rune(line)) > m.Width {
+			t.Errorf("Expected lines within %d columns, got %q", m.Width, line)
+		}
+	}
+}
+
+func TestUpdate_Close(t *testing.T) {
+	for _, k := range []tea.KeyMsg{{Type: tea.KeyEsc}, {Type: tea.KeyRunes, Runes: []rune("v")}} {
+		m := openModel(t)
+		_, cmd := m.Update(k)
+		if cmd == nil {
+			t.Fatalf("Expected %s to close the popup", k)
+		}
+		if _, ok := cmd().(CloseMsg); !ok {
+			t.Errorf("Expected CloseMsg, got %T", cmd())
+		}
+		updated, _ := m.Update(CloseMsg{})
+		if m = updated.(Model); m.Focused() || m.View() != "" {
+			t.Error("Expected the popup closed")
+		}
+	}
+}
@@ -0,0 +1,39 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"ffiii-tui/internal/ui/prompt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/viper"
+)
+
+// Actions that require confirmation. Each one can be silenced with an
+// "always" answer, which is stored under confirm.always.<action> in config.
+const (
+	confirmDeleteTransaction = "delete_transaction"
+	confirmDeleteSplit       = "delete_split"
+	confirmDeleteTag         = "delete_tag"
+)
+
+// confirmAction asks the user to confirm a destructive action unless it was
+// previously answered with "always". onYes runs on yes/always, onNo otherwise.
+func confirmAction(action, question string, onYes, onNo tea.Cmd) tea.Cmd {
+	configKey := "confirm.always." + action
+	if viper.GetBool(configKey) {
+		return onYes
+	}
+	return prompt.Confirm(question, func(answer prompt.Answer) tea.Cmd {
+		switch answer {
+		case prompt.Always:
+			viper.Set(configKey, true)
+			return onYes
+		case prompt.Yes:
+			return onYes
+		}
+		return onNo
+	})
+}
@@ -0,0 +1,162 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/prompt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/viper"
+)
+
+type confirmedMsg struct{}
+type declinedMsg struct{}
+
+// answerConfirm opens the confirmation returned by cmd in a prompt model and
+// presses the given key, returning the messages produced by the answer.
+func answerConfirm(t *testing.T, cmd tea.Cmd, keyPress string) []tea.Msg {
+	t.Helper()
+
+	msg := cmd()
+	confirm, ok := msg.(prompt.ConfirmMsg)
+	if !ok {
+		t.Fatalf("expected prompt.ConfirmMsg, got %T", msg)
+	}
+
+	p := prompt.New()
+	updated, _ := p.Update(confirm)
+	p = updated.(prompt.Model)
+	if !p.Focused() {
+		t.Fatal("expected prompt to be focused after ConfirmMsg")
+	}
+
+	var key tea.KeyMsg
+	switch keyPress {
+	case "esc":
+		key = tea.KeyMsg{Type: tea.KeyEsc}
+	case "enter":
+		key = tea.KeyMsg{Type: tea.KeyEnter}
+	default:
+		key = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(keyPress)}
+	}
+	_, answerCmd := p.Update(key)
+	if answerCmd == nil {
+		return nil
+	}
+	return collectMsgsFromCmd(answerCmd)
+}
+
+func hasMsg[T any](msgs []tea.Msg) bool {
+	for _, msg := range msgs {
+		if _, ok := msg.(T); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func findMsg[T any](msgs []tea.Msg) (T, bool) {
+	for _, msg := range msgs {
+		if m, ok := msg.(T); ok {
+			return m, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+func TestConfirmAction_Answers(t *testing.T) {
+	tests := []struct {
+		key          string
+		wantYes      bool
+		wantRemember bool
+	}{
+		{"y", true, false},
+		{"n", false, false},
+		{"esc", false, false},
+		{"enter", false, false},
+		{"a", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			viper.Set("confirm.always.test_action", false)
+			defer viper.Set("confirm.always.test_action", false)
+
+			cmd := confirmAction("test_action", "Proceed?", Cmd(confirmedMsg{}), Cmd(declinedMsg{}))
+			msgs := answerConfirm(t, cmd, tt.key)
+
+			if got := hasMsg[confirmedMsg](msgs); got != tt.wantYes {
+				t.Errorf("expected confirmed=%v, got msgs %v", tt.wantYes, msgs)
+			}
+			if got := hasMsg[declinedMsg](msgs); got == tt.wantYes {
+				t.Errorf("expected declined=%v, got msgs %v", !tt.wantYes, msgs)
+			}
+			if got := viper.GetBool("confirm.always.test_action"); got != tt.wantRemember {
+				t.Errorf("expected remembered=%v, got %v", tt.wantRemember, got)
+			}
+		})
+	}
+}
+
+func TestConfirmAction_IgnoresOtherKeys(t *testing.T) {
+	cmd := confirmAction("test_action", "Proceed?", Cmd(confirmedMsg{}), Cmd(declinedMsg{}))
+	if msgs := answerConfirm(t, cmd, "x"); len(msgs) != 0 {
+		t.Errorf("expected no messages for unrelated key, got %v", msgs)
+	}
+}
+
+func TestConfirmAction_SkipsPromptWhenAlways(t *testing.T) {
+	viper.Set("confirm.always.test_action", true)
+	defer viper.Set("confirm.always.test_action", false)
+
+	cmd := confirmAction("test_action", "Proceed?", Cmd(confirmedMsg{}), Cmd(declinedMsg{}))
+	if _, ok := cmd().(confirmedMsg); !ok {
+		t.Error("expected confirmation to be skipped when always is set")
+	}
+}
+
+func TestTransactions_KeyDelete_AsksConfirmation(t *testing.T) {
+	tx := newTestTransaction(1, "42", "withdrawal", "2026-01-15T00:00:00Z", "Coffee")
+	m := newFocusedTransactionModel(t, []firefly.Transaction{tx})
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'D'}})
+	if cmd == nil {
+		t.Fatal("expected confirmation command")
+	}
+
+	msgs := answerConfirm(t, cmd, "y")
+	found := false
+	for _, msg := range msgs {
+		if del, ok := msg.(DeleteTransactionMsg); ok && del.Transaction.TransactionID == "42" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected DeleteTransactionMsg for transaction 42, got %v", msgs)
+	}
+}
+
+func TestTransaction_KeyDeleteSplit_AsksConfirmation(t *testing.T) {
+	m := newTestTransactionModel()
+	m.Focus()
+	m.splits = []*split{
+		{description: "Split 0"},
+		{description: "Split 1"},
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlD})
+	if cmd == nil {
+		t.Fatal("expected confirmation command")
+	}
+
+	msgs := answerConfirm(t, cmd, "n")
+	if hasMsg[DeleteSplitMsg](msgs) {
+		t.Error("expected split not to be deleted when declined")
+	}
+}
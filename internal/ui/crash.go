@@ -0,0 +1,149 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"go.uber.org/zap"
+)
+
+// crashMessages is how many of the latest messages a crash report lists.
+const crashMessages = 50
+
+// crashReporter writes a report of the state of the UI when it panics, so
+// a bug report says more than the stack. It is shared by the copies of the
+// root model.
+type crashReporter struct {
+	mu       sync.Mutex
+	messages []string
+	// path is where the report is written, empty to write none; written
+	// is set once it was
+	path    string
+	written string
+}
+
+func newCrashReporter() *crashReporter {
+	return &crashReporter{}
+}
+
+// crashPath returns the crash report file of the active profile.
+func crashPath() string {
+	return profileCachePath(".crash-" + time.Now().Format("20060102-150405") + ".txt")
+}
+
+// record keeps msg among the latest messages.
+func (c *crashReporter) record(msg tea.Msg) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.messages = append(c.messages, describeMsg(msg))
+	if len(c.messages) > crashMessages {
+		c.messages = c.messages[len(c.messages)-crashMessages:]
+	}
+}
+
+// describeMsg names a message without its content, which may hold amounts,
+// descriptions or an API token being typed. Only keys other than text and
+// window sizes are kept.
+func describeMsg(msg tea.Msg) string {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+			return "tea.KeyMsg <text>"
+		}
+		return "tea.KeyMsg " + msg.String()
+	case tea.WindowSizeMsg:
+		return fmt.Sprintf("tea.WindowSizeMsg %dx%d", msg.Width, msg.Height)
+	}
+	return fmt.Sprintf("%T", msg)
+}
+
+// recover writes the crash report of m when the UI panics and panics again,
+// for Bubble Tea to restore the terminal. It is deferred by Update and View;
+// a panic passing through nested updates is reported by the innermost.
+func (c *crashReporter) recover(m modelUI) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	if c != nil && c.path != "" && c.reportPath() == "" {
+		if err := c.write(c.report(m, r, debug.Stack())); err != nil {
+			zap.L().Error("Failed to write crash report", zap.Error(err))
+		}
+	}
+	panic(r)
+}
+
+// report returns the crash report of m.
+func (c *crashReporter) report(m modelUI, r any, stack []byte) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var s strings.Builder
+	fmt.Fprintf(&s, "ffiii-tui crash report\n\n")
+	fmt.Fprintf(&s, "Time:    %s\n", time.Now().Format(time.RFC3339))
+	fmt.Fprintf(&s, "Panic:   %v\n", r)
+	fmt.Fprintf(&s, "Profile: %s\n", activeProfile())
+	fmt.Fprintf(&s, "View:    %s\n", viewName(m.state))
+	if m.api != nil {
+		fmt.Fprintf(&s, "Period:  %s - %s\n",
+			m.api.PeriodStart().Format(time.DateOnly), m.api.PeriodEnd().Format(time.DateOnly))
+	}
+	if m.layout != nil {
+		l := m.layout
+		fmt.Fprintf(&s, "Layout:  %dx%d, top %d, left %d, summary %d, tab bar %d, full transaction view %t\n",
+			l.Width, l.Height, l.TopSize, l.LeftSize, l.SummarySize, l.TabBarSize, l.FullTransactionView)
+	}
+	fmt.Fprintf(&s, "\nLatest messages, oldest first:\n")
+	for _, msg := range c.messages {
+		fmt.Fprintf(&s, "  %s\n", msg)
+	}
+	fmt.Fprintf(&s, "\nStack:\n%s", stack)
+	return s.String()
+}
+
+func (c *crashReporter) write(report string) error {
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	if err := os.WriteFile(c.path, []byte(report), 0o600); err != nil {
+		return fmt.Errorf("failed to write crash report: %w", err)
+	}
+	c.mu.Lock()
+	c.written = c.path
+	c.mu.Unlock()
+	return nil
+}
+
+// reportPath returns where the crash report was written, empty when the UI
+// did not crash.
+func (c *crashReporter) reportPath() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.written
+}
+
+// viewName returns the name of the view s, as kept in the session.
+func viewName(s state) string {
+	for name, view := range sessionViews {
+		if view == s {
+			return name
+		}
+	}
+	if s == customView {
+		return "custom panel"
+	}
+	return fmt.Sprintf("view %d", s)
+}
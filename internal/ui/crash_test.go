@@ -0,0 +1,85 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// panicIn runs fn with the crash reporter of m deferred, as Update does,
+// and returns what it panicked with.
+func panicIn(m modelUI, fn func()) (r any) {
+	defer func() { r = recover() }()
+	defer m.crash.recover(m)
+	fn()
+	return nil
+}
+
+func TestCrashReporter_WritesReport(t *testing.T) {
+	m := newTestModelUI()
+	m.crash.path = filepath.Join(t.TempDir(), "default.crash.txt")
+	m.state = tagsView
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("secret")})
+	m = updated.(modelUI)
+	updated, cmd := m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	m = updated.(modelUI)
+	updated, _ = m.Update(cmd())
+	m = updated.(modelUI)
+
+	if r := panicIn(m, func() { panic("boom") }); r != "boom" {
+		t.Fatalf("expected the panic passed on, got %v", r)
+	}
+	if m.crash.reportPath() != m.crash.path {
+		t.Fatalf("expected the report written to %s, got %q", m.crash.path, m.crash.reportPath())
+	}
+	data, err := os.ReadFile(m.crash.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := string(data)
+	for _, want := range []string{"Panic:   boom", "View:    tags", "Layout:  100x30", "tea.KeyMsg <text>", "tea.WindowSizeMsg 100x30", "Stack:"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("expected %q in the report, got\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "secret") {
+		t.Errorf("expected typed text left out of the report, got\n%s", report)
+	}
+}
+
+func TestCrashReporter_NoPath(t *testing.T) {
+	m := newTestModelUI()
+
+	if r := panicIn(m, func() { panic("boom") }); r != "boom" {
+		t.Fatalf("expected the panic passed on, got %v", r)
+	}
+	if m.crash.reportPath() != "" {
+		t.Errorf("expected no report without a path, got %q", m.crash.reportPath())
+	}
+}
+
+func TestCrashReporter_KeepsLatestMessages(t *testing.T) {
+	c := newCrashReporter()
+	for range crashMessages + 10 {
+		c.record(RefreshTagsMsg{})
+	}
+	c.record(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if len(c.messages) != crashMessages {
+		t.Fatalf("expected %d messages kept, got %d", crashMessages, len(c.messages))
+	}
+	if last := c.messages[len(c.messages)-1]; last != "tea.KeyMsg enter" {
+		t.Errorf("expected the latest message last, got %q", last)
+	}
+	if c.messages[0] != "ui.RefreshTagsMsg" {
+		t.Errorf("expected messages named by type, got %q", c.messages[0])
+	}
+}
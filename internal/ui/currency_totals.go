@@ -0,0 +1,71 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"ffiii-tui/internal/ui/money"
+
+	"github.com/spf13/viper"
+)
+
+// currencyTotals sums amounts by currency code, for the Total rows of lists
+// spanning several currencies.
+type currencyTotals map[string]float64
+
+// spans reports whether the totals hold an amount in a currency other than
+// code, so a single figure in code would be wrong.
+func (t currencyTotals) spans(code string) bool {
+	for c, v := range t {
+		if v != 0 && !strings.EqualFold(c, code) {
+			return true
+		}
+	}
+	return false
+}
+
+// Format lists the non-zero totals sorted by currency, e.g.
+// "12.00 EUR, 3.50 USD".
+func (t currencyTotals) Format(amounts money.Formatter) string {
+	return t.join(amounts.Format)
+}
+
+// Short is Format with large totals abbreviated when configured, for lists
+// and the summary.
+func (t currencyTotals) Short(amounts money.Formatter) string {
+	return t.join(amounts.Short)
+}
+
+func (t currencyTotals) join(format func(amount float64, code string) string) string {
+	parts := []string{}
+	for _, code := range slices.Sorted(maps.Keys(t)) {
+		if t[code] != 0 {
+			parts = append(parts, format(t[code], code))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// inPrimary merges the totals in foreign currencies into the primary one
+// when ui.convert_balances is set. Currencies without a known rate are kept
+// apart.
+func (t currencyTotals) inPrimary(api ExchangeRateAPI) currencyTotals {
+	if !viper.GetBool("ui.convert_balances") || len(t) == 0 {
+		return t
+	}
+	primary := api.PrimaryCurrency().Code
+	merged := currencyTotals{}
+	for code, v := range t {
+		if converted, ok := api.ConvertToPrimary(v, code); ok {
+			merged[primary] += converted
+		} else {
+			merged[code] += v
+		}
+	}
+	return merged
+}
@@ -0,0 +1,120 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/money"
+
+	"github.com/spf13/viper"
+)
+
+func TestCurrencyTotals_Format(t *testing.T) {
+	totals := currencyTotals{"USD": 3.5, "EUR": 12, "GBP": 0}
+	if got := totals.Format(money.Formatter{}); got != "12.00 EUR, 3.50 USD" {
+		t.Errorf("Expected sorted non-zero totals, got %q", got)
+	}
+}
+
+type symbolsAPI map[string]string
+
+func (s symbolsAPI) GetCurrencyByCode(code string) firefly.Currency {
+	return firefly.Currency{Code: code, Symbol: s[code]}
+}
+
+func TestCurrencyTotals_FormatSymbols(t *testing.T) {
+	amounts := money.New(symbolsAPI{"EUR": "€", "USD": "$"})
+	viper.Set("ui.currency_display", "symbol")
+	t.Cleanup(func() { viper.Set("ui.currency_display", nil) })
+
+	totals := currencyTotals{"USD": -3.5, "EUR": 12, "JPY": 100}
+	if got := totals.Format(amounts); got != "€12.00, 100.00 JPY, -$3.50" {
+		t.Errorf("Expected the symbols, the code without one, got %q", got)
+	}
+}
+
+func TestCurrencyTotals_Spans(t *testing.T) {
+	tests := []struct {
+		name   string
+		totals currencyTotals
+		want   bool
+	}{
+		{"nil", nil, false},
+		{"primary only", currencyTotals{"EUR": 10}, false},
+		{"foreign zero", currencyTotals{"EUR": 10, "USD": 0}, false},
+		{"foreign only", currencyTotals{"USD": 10}, true},
+		{"mixed", currencyTotals{"EUR": 10, "USD": 5}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.totals.spans("EUR"); got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCurrencyTotals_InPrimary(t *testing.T) {
+	api := &mockExpenseAPI{
+		primaryCurrencyFunc: func() firefly.Currency { return firefly.Currency{Code: "EUR"} },
+		convertToPrimaryFunc: func(amount float64, currencyCode string) (float64, bool) {
+			if currencyCode != "USD" {
+				return amount, false
+			}
+			return amount * 0.5, true
+		},
+	}
+	totals := currencyTotals{"EUR": 10, "USD": 8, "JPY": 100}
+
+	if got := totals.inPrimary(api).Format(money.Formatter{}); got != "10.00 EUR, 100.00 JPY, 8.00 USD" {
+		t.Errorf("Expected totals unchanged by default, got %q", got)
+	}
+
+	viper.Set("ui.convert_balances", true)
+	defer viper.Set("ui.convert_balances", false)
+
+	if got := totals.inPrimary(api).Format(money.Formatter{}); got != "14.00 EUR, 100.00 JPY" {
+		t.Errorf("Expected USD merged into EUR, got %q", got)
+	}
+}
+
+func TestTotalRow_MultiCurrency(t *testing.T) {
+	api := &mockExpenseAPI{
+		accountsByTypeFunc: func(accountType string) []firefly.Account { return nil },
+		primaryCurrencyFunc: func() firefly.Currency {
+			return firefly.Currency{Code: "EUR"}
+		},
+		getTotalExpenseDiffFunc: func() float64 { return 130 },
+		expenseTotalsFunc: func() map[string]float64 {
+			return map[string]float64{"EUR": 100, "USD": 30}
+		},
+	}
+	m := newModelExpenses(api, newRequestScope())
+
+	total := m.createTotalEntity(api.GetTotalExpenseDiff(), api.ExpenseTotals()).(expenseItem)
+	if got := total.Description(); got != "Total: 100.00 EUR, 30.00 USD" {
+		t.Errorf("Expected per-currency subtotals, got %q", got)
+	}
+
+	single := m.createTotalEntity(130, currencyTotals{"EUR": 100}).(expenseItem)
+	if got := single.Description(); got != "Total: 100.00 EUR" {
+		t.Errorf("Expected single currency total, got %q", got)
+	}
+}
+
+func TestCategoryTotalRow_MultiCurrency(t *testing.T) {
+	item := categoryItem{
+		category:     firefly.Category{Name: "Total", CurrencyCode: "EUR"},
+		spent:        150,
+		earned:       20,
+		spentTotals:  currencyTotals{"EUR": 100, "USD": 50},
+		earnedTotals: currencyTotals{"EUR": 20},
+	}
+	if got := item.Description(); got != "Spent: 100.00 EUR, 50.00 USD | Earned: 20.00 EUR" {
+		t.Errorf("Unexpected description %q", got)
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"ffiii-tui/internal/firefly"
+)
+
+// dateRange narrows the transactions table to the days between from and to,
+// both included. Dates are YYYY-MM-DD, an empty one leaves that side open.
+type dateRange struct {
+	from string
+	to   string
+}
+
+type DateRangeMsg struct {
+	Range dateRange
+}
+
+func (r dateRange) isEmpty() bool {
+	return r.from == "" && r.to == ""
+}
+
+func (r dateRange) String() string {
+	if r.from != "" && r.from == r.to {
+		return r.from
+	}
+	return r.from + ".." + r.to
+}
+
+func (r dateRange) contains(date string) bool {
+	day := transactionDay(date)
+	if r.from != "" && day < r.from {
+		return false
+	}
+	if r.to != "" && day > r.to {
+		return false
+	}
+	return true
+}
+
+func transactionDay(date string) string {
+	if len(date) < len(time.DateOnly) {
+		return date
+	}
+	return date[:len(time.DateOnly)]
+}
+
+// newestDay returns the day of the latest transaction.
+func newestDay(transactions []firefly.Transaction) string {
+	newest := ""
+	for _, tx := range transactions {
+		newest = max(newest, transactionDay(tx.Date))
+	}
+	return newest
+}
+
+// parseDateRange reads a range typed into the prompt: "7d" for the last
+// seven days up to newest, "from..to" with either side optional, or a
+// single day.
+func parseDateRange(value, newest string) (dateRange, error) {
+	value = strings.TrimSpace(value)
+
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 1 {
+			return dateRange{}, fmt.Errorf("invalid number of days: %s", value)
+		}
+		end, err := time.Parse(time.DateOnly, newest)
+		if err != nil {
+			return dateRange{}, fmt.Errorf("no transactions to count days from")
+		}
+		return dateRange{
+			from: end.AddDate(0, 0, 1-n).Format(time.DateOnly),
+			to:   newest,
+		}, nil
+	}
+
+	from, to, found := strings.Cut(value, "..")
+	if !found {
+		to = from
+	}
+	for _, day := range []string{from, to} {
+		if day == "" {
+			continue
+		}
+		if _, err := time.Parse(time.DateOnly, day); err != nil {
+			return dateRange{}, fmt.Errorf("invalid date: %s", day)
+		}
+	}
+	r := dateRange{from: from, to: to}
+	if r.isEmpty() {
+		return dateRange{}, fmt.Errorf("invalid date range: %s", value)
+	}
+	if r.from != "" && r.to != "" && r.from > r.to {
+		return dateRange{}, fmt.Errorf("date range ends before it starts: %s", value)
+	}
+	return r, nil
+}
@@ -0,0 +1,71 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+)
+
+func TestParseDateRange(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    dateRange
+		wantErr bool
+	}{
+		{"7d", dateRange{from: "2026-01-25", to: "2026-01-31"}, false},
+		{"1d", dateRange{from: "2026-01-31", to: "2026-01-31"}, false},
+		{"2026-01-05..2026-01-12", dateRange{from: "2026-01-05", to: "2026-01-12"}, false},
+		{"2026-01-05..", dateRange{from: "2026-01-05"}, false},
+		{"..2026-01-12", dateRange{to: "2026-01-12"}, false},
+		{"2026-01-05", dateRange{from: "2026-01-05", to: "2026-01-05"}, false},
+		{"0d", dateRange{}, true},
+		{"xd", dateRange{}, true},
+		{"..", dateRange{}, true},
+		{"2026-13-01", dateRange{}, true},
+		{"2026-01-12..2026-01-05", dateRange{}, true},
+	}
+	for _, tt := range tests {
+		got, err := parseDateRange(tt.value, "2026-01-31")
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseDateRange(%q) = %+v, %v, want %+v, error %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+
+	if _, err := parseDateRange("7d", ""); err == nil {
+		t.Error("expected an error without transactions")
+	}
+}
+
+func TestTransactions_DateRange(t *testing.T) {
+	m := newFocusedTransactionModel(t, []firefly.Transaction{
+		newTestTransaction(0, "tx1", "withdrawal", "2024-01-10T10:00:00Z", "Early"),
+		newTestTransaction(1, "tx2", "withdrawal", "2024-01-15T23:30:00+01:00", "Middle"),
+		newTestTransaction(2, "tx3", "deposit", "2024-01-20T10:00:00Z", "Late"),
+	})
+
+	r, err := parseDateRange("6d", newestDay(m.transactions))
+	if err != nil {
+		t.Fatal(err)
+	}
+	updated, cmd := m.Update(DateRangeMsg{Range: r})
+	m = updated.(modelTransactions)
+	filter, ok := findMsg[FilterMsg](collectMsgsFromCmd(cmd))
+	if !ok {
+		t.Fatal("expected the list to be filtered again")
+	}
+	updated, _ = m.Update(filter)
+	m = updated.(modelTransactions)
+
+	ids := []string{}
+	for _, row := range m.table.Rows() {
+		ids = append(ids, row[11])
+	}
+	if strings.Join(ids, ",") != "tx2,tx3" {
+		t.Errorf("expected the transactions of the last 6 days, got %v", ids)
+	}
+}
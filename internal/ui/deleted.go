@@ -0,0 +1,131 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/deletedlog"
+	"ffiii-tui/internal/ui/notify"
+	"ffiii-tui/internal/ui/prompt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"go.uber.org/zap"
+)
+
+// deletedPath returns the log of the transactions deleted with the active
+// profile. It is only appended to, one JSON entry per line.
+func deletedPath() string {
+	return profileCachePath(".deleted.jsonl")
+}
+
+// recordDeleted appends the transaction to the deleted log, if it is kept.
+func recordDeleted(path string, tx firefly.Transaction, now time.Time) {
+	if path == "" {
+		return
+	}
+	if err := appendDeleted(path, deletedlog.Entry{DeletedAt: now, Transaction: tx}); err != nil {
+		zap.L().Warn("Failed to record deleted transaction",
+			zap.String("transaction_id", tx.TransactionID), zap.Error(err))
+	}
+}
+
+func appendDeleted(path string, entry deletedlog.Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode deleted transaction: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open deleted log: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write deleted log: %w", err)
+	}
+	return f.Close()
+}
+
+// loadDeleted reads the deleted log, newest first. Lines that cannot be
+// parsed are skipped, a missing log has no entries.
+func loadDeleted(path string) ([]deletedlog.Entry, error) {
+	entries := []deletedlog.Entry{}
+	if path == "" {
+		return entries, nil
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return entries, nil
+	}
+	if err != nil {
+		return entries, fmt.Errorf("failed to open deleted log: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var entry deletedlog.Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			zap.L().Warn("Skipped unreadable deleted log entry", zap.Error(err))
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return entries, fmt.Errorf("failed to read deleted log: %w", err)
+	}
+	slices.Reverse(entries)
+	return entries, nil
+}
+
+// openDeletedLog shows the deleted transactions.
+func openDeletedLog(path string) tea.Cmd {
+	entries, err := loadDeleted(path)
+	if err != nil {
+		return notify.NotifyWarn(err.Error())
+	}
+	return deletedlog.Open(entries)
+}
+
+func askDeletedExport(entries []deletedlog.Entry) tea.Cmd {
+	name := fmt.Sprintf("deleted-transactions-%s.json", time.Now().Format(time.DateOnly))
+	return prompt.Ask("Export deleted transactions to: ", name, func(value string) tea.Cmd {
+		path := strings.TrimSpace(value)
+		if path == "" || value == "None" {
+			return nil
+		}
+		return writeDeletedExport(entries, path)
+	})
+}
+
+// writeDeletedExport saves the entries as one JSON array.
+func writeDeletedExport(entries []deletedlog.Entry, path string) tea.Cmd {
+	return func() tea.Msg {
+		data, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return notify.NotifyWarn(fmt.Sprintf("Failed to encode deleted transactions: %v", err))()
+		}
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			return notify.NotifyWarn(fmt.Sprintf("Failed to export deleted transactions: %v", err))()
+		}
+		return notify.NotifyLog(fmt.Sprintf("%d deleted transactions saved to %s", len(entries), path))()
+	}
+}
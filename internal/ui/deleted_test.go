@@ -0,0 +1,107 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"ffiii-tui/internal/ui/deletedlog"
+)
+
+func TestDeletedLog_AppendAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", "default.deleted.jsonl")
+	at := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	entries, err := loadDeleted(path)
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("expected no entries without a log, got %v, %v", entries, err)
+	}
+
+	recordDeleted(path, newTestTransaction(0, "1", "withdrawal", "2026-01-10T00:00:00Z", "Coffee"), at)
+	recordDeleted(path, newTestTransaction(0, "2", "deposit", "2026-01-11T00:00:00Z", "Salary"), at.Add(time.Hour))
+
+	entries, err = loadDeleted(path)
+	if err != nil {
+		t.Fatalf("loadDeleted: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Transaction.TransactionID != "2" || entries[1].Transaction.TransactionID != "1" {
+		t.Fatalf("expected both entries newest first, got %+v", entries)
+	}
+	if !entries[1].DeletedAt.Equal(at) {
+		t.Errorf("expected the deletion time kept, got %v", entries[1].DeletedAt)
+	}
+	if entries[1].Transaction.Splits[0].Description != "Coffee" {
+		t.Errorf("expected the payload kept, got %+v", entries[1].Transaction)
+	}
+}
+
+func TestDeletedLog_SkipsUnreadableLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "default.deleted.jsonl")
+	recordDeleted(path, newTestTransaction(0, "1", "withdrawal", "2026-01-10T00:00:00Z", "Coffee"), time.Now())
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString("{broken\n")
+	_ = f.Close()
+
+	entries, err := loadDeleted(path)
+	if err != nil || len(entries) != 1 {
+		t.Errorf("expected the readable entry only, got %v, %v", entries, err)
+	}
+}
+
+func TestDeletedLog_NotKeptWithoutPath(t *testing.T) {
+	recordDeleted("", newTestTransaction(0, "1", "withdrawal", "2026-01-10T00:00:00Z", "Coffee"), time.Now())
+	entries, err := loadDeleted("")
+	if err != nil || len(entries) != 0 {
+		t.Errorf("expected no entries, got %v, %v", entries, err)
+	}
+}
+
+func TestDeleteTransactionMsg_RecordsDeleted(t *testing.T) {
+	tx := newTestTransaction(0, "tx-to-delete", "withdrawal", "2024-01-15T10:00:00Z", "Test")
+	api := &mockTransactionAPI{}
+	m := NewModelTransactions(api)
+	m.deletedFile = filepath.Join(t.TempDir(), "default.deleted.jsonl")
+
+	_, cmd := m.Update(DeleteTransactionMsg{Transaction: tx})
+	collectMsgsFromCmd(cmd)
+
+	entries, _ := loadDeleted(m.deletedFile)
+	if len(entries) != 1 || entries[0].Transaction.TransactionID != "tx-to-delete" {
+		t.Errorf("expected the deleted transaction logged, got %+v", entries)
+	}
+
+	api.deleteTransactionFunc = func(string) error { return errors.New("boom") }
+	_, cmd = m.Update(DeleteTransactionMsg{Transaction: newTestTransaction(0, "kept", "withdrawal", "2024-01-15T10:00:00Z", "Test")})
+	collectMsgsFromCmd(cmd)
+	if entries, _ := loadDeleted(m.deletedFile); len(entries) != 1 {
+		t.Errorf("expected failed deletions not logged, got %d entries", len(entries))
+	}
+}
+
+func TestWriteDeletedExport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "deleted.json")
+	entries := []deletedlog.Entry{
+		{DeletedAt: time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC), Transaction: newTestTransaction(0, "1", "withdrawal", "2026-01-10T00:00:00Z", "Coffee")},
+	}
+
+	collectMsgsFromCmd(writeDeletedExport(entries, path))
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected the export written: %v", err)
+	}
+	var exported []deletedlog.Entry
+	if err := json.Unmarshal(data, &exported); err != nil || len(exported) != 1 {
+		t.Errorf("expected one exported entry, got %v, %v", exported, err)
+	}
+}
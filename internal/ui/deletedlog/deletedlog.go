@@ -0,0 +1,203 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package deletedlog
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/money"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Entry is a transaction deleted through the TUI, as it was before the
+// deletion.
+type Entry struct {
+	DeletedAt   time.Time           `json:"deleted_at"`
+	Transaction firefly.Transaction `json:"transaction"`
+}
+
+type OpenMsg struct {
+	Entries []Entry
+}
+
+type CloseMsg struct{}
+
+// ExportMsg asks where to save the entries shown.
+type ExportMsg struct {
+	Entries []Entry
+}
+
+type Model struct {
+	entries []Entry
+	offset  int
+	focus   bool
+	styles  Styles
+	amounts money.Formatter
+	Width   int
+	Height  int
+}
+
+func New(amounts money.Formatter) Model {
+	return Model{
+		styles:  DefaultStyles(),
+		amounts: amounts,
+		Width:   80,
+		Height:  24,
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case OpenMsg:
+		m.entries = msg.Entries
+		m.offset = 0
+		m.Focus()
+		return m, nil
+	case CloseMsg:
+		m.Blur()
+		return m, nil
+	}
+
+	if !m.focus {
+		return m, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc", "enter", "q":
+			return m, Close()
+		case "e":
+			if len(m.entries) > 0 {
+				m.Blur()
+				entries := m.entries
+				return m, func() tea.Msg { return ExportMsg{Entries: entries} }
+			}
+		case "up", "k":
+			if m.offset > 0 {
+				m.offset--
+			}
+		case "down", "j":
+			if m.offset < len(m.entries)-1 {
+				m.offset++
+			}
+		case "home", "g":
+			m.offset = 0
+		case "end", "G":
+			m.offset = max(len(m.entries)-1, 0)
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) View() string {
+	if !m.focus {
+		return ""
+	}
+
+	borderW, borderH := m.styles.Border.GetFrameSize()
+	bodyHeight := max(m.Height-borderH-3, 1)
+
+	var b strings.Builder
+	b.WriteString(m.styles.Title.Render("Deleted transactions") +
+		m.styles.Desc.Render(fmt.Sprintf("  %d deleted, newest first (esc to close, e to export, ↑/↓ to scroll)", len(m.entries))) + "\n")
+	b.WriteString(m.styles.Header.Render(fmt.Sprintf("%-16s  %-10s  %12s  %s", "DELETED", "DATE", "AMOUNT", "TRANSACTION")) + "\n")
+
+	if len(m.entries) == 0 {
+		b.WriteString("\n" + m.styles.Empty.Render("No transactions deleted yet"))
+	} else {
+		offset := min(m.offset, len(m.entries)-1)
+		end := min(offset+bodyHeight, len(m.entries))
+		rows := make([]string, 0, end-offset)
+		for _, e := range m.entries[offset:end] {
+			rows = append(rows, m.row(e))
+		}
+		b.WriteString(strings.Join(rows, "\n"))
+	}
+
+	return m.styles.Border.
+		Width(max(m.Width-borderW, 0)).
+		Height(max(m.Height-borderH, 0)).
+		Render(b.String())
+}
+
+// row renders an entry with the total of its splits and where the money
+// went.
+func (m Model) row(e Entry) string {
+	tx := e.Transaction
+	amount := 0.0
+	currency := ""
+	description := tx.GroupTitle
+	for i, s := range tx.Splits {
+		amount += s.Amount
+		if i == 0 {
+			currency = s.Currency
+			if description == "" {
+				description = s.Description
+			}
+			description += fmt.Sprintf(" (%s → %s)", s.Source.Name, s.Destination.Name)
+		}
+	}
+	date := tx.Date
+	if len(date) > len(time.DateOnly) {
+		date = date[:len(time.DateOnly)]
+	}
+
+	line := fmt.Sprintf("%-16s  %-10s  %12s  #%s %s",
+		e.DeletedAt.Local().Format("2006-01-02 15:04"),
+		date,
+		m.amounts.Format(amount, currency),
+		tx.TransactionID,
+		description)
+	borderW, _ := m.styles.Border.GetFrameSize()
+	if width := m.Width - borderW; width > 0 && lipgloss.Width(line) > width {
+		line = string([]rune(line)[:max(width-1, 0)]) + "…"
+	}
+	return m.styles.Row.Render(line)
+}
+
+func (m *Model) Focus() {
+	m.focus = true
+}
+
+func (m *Model) Blur() {
+	m.focus = false
+}
+
+func (m *Model) Focused() bool {
+	return m.focus
+}
+
+func (m *Model) WithSize(width, height int) *Model {
+	m.Width = width
+	m.Height = height
+	return m
+}
+
+func (m *Model) WithStyles(styles Styles) *Model {
+	m.styles = styles
+	return m
+}
+
+func Open(entries []Entry) tea.Cmd {
+	return func() tea.Msg {
+		return OpenMsg{Entries: entries}
+	}
+}
+
+func Close() tea.Cmd {
+	return func() tea.Msg {
+		return CloseMsg{}
+	}
+}
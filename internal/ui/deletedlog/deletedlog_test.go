@@ -0,0 +1,97 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package deletedlog
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/money"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func testEntries() []Entry {
+	return []Entry{
+		{
+			DeletedAt: time.Date(2026, 1, 15, 10, 30, 0, 0, time.Local),
+			Transaction: firefly.Transaction{
+				TransactionID: "42",
+				Date:          "2026-01-14T00:00:00+01:00",
+				Splits: []firefly.Split{{
+					Description: "Groceries",
+					Amount:      23.5,
+					Currency:    "EUR",
+					Source:      firefly.Account{Name: "Checking"},
+					Destination: firefly.Account{Name: "Market"},
+				}},
+			},
+		},
+	}
+}
+
+func openModel(t *testing.T, entries []Entry) Model {
+	t.Helper()
+	m := New(money.Formatter{})
+	updated, _ := m.Update(OpenMsg{Entries: entries})
+	m = updated.(Model)
+	if !m.Focused() {
+		t.Fatal("Expected model to be focused after OpenMsg")
+	}
+	return m
+}
+
+func TestView_Entries(t *testing.T) {
+	m := openModel(t, testEntries())
+	m.Width = 120
+	view := m.View()
+
+	for _, want := range []string{"Deleted transactions", "2026-01-15 10:30", "2026-01-14", "23.50 EUR", "#42 Groceries (Checking → Market)"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected view to contain %q", want)
+		}
+	}
+}
+
+func TestView_Empty(t *testing.T) {
+	m := openModel(t, nil)
+	if !strings.Contains(m.View(), "No transactions deleted yet") {
+		t.Error("Expected the empty hint")
+	}
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}}); cmd != nil {
+		t.Error("Expected nothing to export")
+	}
+}
+
+func TestUpdate_Export(t *testing.T) {
+	m := openModel(t, testEntries())
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	if cmd == nil {
+		t.Fatal("Expected an export command")
+	}
+	export, ok := cmd().(ExportMsg)
+	if !ok || len(export.Entries) != 1 {
+		t.Errorf("Expected ExportMsg with the entries, got %v", cmd())
+	}
+	if m = updated.(Model); m.Focused() {
+		t.Error("Expected the log closed for the export prompt")
+	}
+}
+
+func TestUpdate_Close(t *testing.T) {
+	m := openModel(t, testEntries())
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if _, ok := cmd().(CloseMsg); !ok {
+		t.Error("Expected CloseMsg on esc")
+	}
+	updated, _ := m.Update(CloseMsg{})
+	if m = updated.(Model); m.Focused() {
+		t.Error("Expected model to be blurred after CloseMsg")
+	}
+}
@@ -0,0 +1,36 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package deletedlog
+
+import "github.com/charmbracelet/lipgloss"
+
+type Styles struct {
+	Border lipgloss.Style
+	Title  lipgloss.Style
+	Header lipgloss.Style
+	Row    lipgloss.Style
+	Desc   lipgloss.Style
+	Empty  lipgloss.Style
+}
+
+func DefaultStyles() Styles {
+	return Styles{
+		Border: lipgloss.NewStyle().
+			BorderStyle(lipgloss.ThickBorder()).
+			BorderForeground(lipgloss.Color("#D75F5F")),
+		Title: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#D75F5F")),
+		Header: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#D75F87")),
+		Row: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#DDDADA")),
+		Desc: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#8A8A8A")),
+		Empty: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#585858")),
+	}
+}
@@ -0,0 +1,289 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/notify"
+	"ffiii-tui/internal/ui/prompt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// draftSaveDelay is how long the form has to stay unchanged before the
+// draft is written, so typing does not write on every key.
+const draftSaveDelay = time.Second
+
+type ResumeDraftMsg struct{ Draft draft }
+
+// draftDueMsg saves the draft if the form did not change since edit.
+type draftDueMsg struct{ edit int }
+
+// draft is an unfinished transaction form kept on disk, so a crash or an
+// accidental quit does not lose it.
+type draft struct {
+	SavedAt       time.Time
+	New           bool
+	TransactionID string
+	Year          string
+	Month         string
+	Day           string
+	TypeOverride  string
+	GroupTitle    string
+	Splits        []draftSplit
+}
+
+type draftSplit struct {
+	Source               firefly.Account
+	Destination          firefly.Account
+	Category             firefly.Category
+	Amount               string
+	ForeignAmount        string
+	Description          string
+	Location             string
+	ZoomLevel            int
+	InternalReference    string
+	ExternalID           string
+	InvoiceDate          string
+	TransactionJournalID string
+}
+
+// profileCachePath returns a file of the active profile kept next to the
+// cached data, or an empty string when there is no cache directory.
+func profileCachePath(suffix string) string {
+	dir := viper.GetString("cache.dir")
+	if dir == "" {
+		cacheDir, err := os.UserCacheDir()
+		if err != nil {
+			zap.L().Warn("No cache directory", zap.Error(err))
+			return ""
+		}
+		dir = filepath.Join(cacheDir, "ffiii-tui")
+	}
+	return filepath.Join(dir, activeProfile()+suffix)
+}
+
+// draftPath returns the draft file of the active profile.
+func draftPath() string {
+	return profileCachePath(".draft.json")
+}
+
+func loadDraft(path string) (draft, error) {
+	var d draft
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return d, err
+	}
+	if err := json.Unmarshal(data, &d); err != nil {
+		return d, fmt.Errorf("failed to parse draft: %w", err)
+	}
+	return d, nil
+}
+
+func writeDraft(path string, d draft) error {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("failed to encode draft: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write draft: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// draft returns the current values of the form.
+func (m *modelTransaction) draft() draft {
+	d := draft{
+		SavedAt:       time.Now(),
+		New:           m.new,
+		TransactionID: m.attr.trxID,
+		Year:          m.attr.year,
+		Month:         m.attr.month,
+		Day:           m.attr.day,
+		TypeOverride:  m.attr.typeOverride,
+		GroupTitle:    m.attr.groupTitle,
+	}
+	for _, s := range m.splits {
+		d.Splits = append(d.Splits, draftSplit{
+			Source:               s.source,
+			Destination:          s.destination,
+			Category:             s.category,
+			Amount:               s.amount,
+			ForeignAmount:        s.foreignAmount,
+			Description:          s.description,
+			Location:             s.location,
+			ZoomLevel:            s.zoom,
+			InternalReference:    s.internalReference,
+			ExternalID:           s.externalID,
+			InvoiceDate:          s.invoiceDate,
+			TransactionJournalID: s.trxJID,
+		})
+	}
+	return d
+}
+
+// saveDraft schedules the form to be written to the draft file, if drafts
+// are enabled. Later changes push the write back.
+func (m *modelTransaction) saveDraft() tea.Cmd {
+	if m.draftFile == "" {
+		return nil
+	}
+	m.edits++
+	edit := m.edits
+	return tea.Tick(draftSaveDelay, func(time.Time) tea.Msg {
+		return draftDueMsg{edit: edit}
+	})
+}
+
+// writeDraftNow writes the form to the draft file in the background.
+func (m *modelTransaction) writeDraftNow() tea.Cmd {
+	if m.draftFile == "" {
+		return nil
+	}
+	return m.drafts.write(m.draftFile, m.draft())
+}
+
+// deleteDraft removes the draft file once the form was submitted or
+// discarded, dropping the writes still pending.
+func (m *modelTransaction) deleteDraft() {
+	m.edits++
+	m.drafts.remove(m.draftFile)
+}
+
+// draftWriter orders the draft writes running in the background with the
+// removals of the draft, so a late write cannot bring back a draft that
+// was submitted or discarded in the meantime.
+type draftWriter struct {
+	mu       sync.Mutex
+	removals int
+}
+
+func (w *draftWriter) write(path string, d draft) tea.Cmd {
+	w.mu.Lock()
+	removals := w.removals
+	w.mu.Unlock()
+	return func() tea.Msg {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		if w.removals != removals {
+			return nil
+		}
+		if err := writeDraft(path, d); err != nil {
+			zap.L().Warn("Failed to save draft", zap.Error(err))
+		}
+		return nil
+	}
+}
+
+func (w *draftWriter) remove(path string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.removals++
+	removeDraft(path)
+}
+
+func removeDraft(path string) {
+	if path == "" {
+		return
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		zap.L().Warn("Failed to delete draft", zap.Error(err))
+	}
+}
+
+// storedDraft returns the draft left by an earlier session.
+func (m *modelTransaction) storedDraft() (draft, bool) {
+	if m.draftFile == "" {
+		return draft{}, false
+	}
+	d, err := loadDraft(m.draftFile)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			zap.L().Warn("Ignoring draft", zap.Error(err))
+		}
+		return draft{}, false
+	}
+	return d, len(d.Splits) > 0
+}
+
+// askResumeDraft offers the stored draft before opening an empty form.
+func (m *modelTransaction) askResumeDraft(d draft, start tea.Cmd) tea.Cmd {
+	path := m.draftFile
+	what := "new transaction"
+	if !d.New {
+		what = "edit of transaction " + d.TransactionID
+	}
+	return prompt.Ask(
+		fmt.Sprintf("Resume draft %s from %s? (y - resume/ d - delete draft/ any key - start new): ",
+			what, d.SavedAt.Format("2006-01-02 15:04")),
+		"",
+		func(value string) tea.Cmd {
+			switch value {
+			case "y":
+				return Cmd(ResumeDraftMsg{Draft: d})
+			case "d":
+				removeDraft(path)
+				return start
+			}
+			return start
+		},
+	)
+}
+
+// resumeDraft loads a stored draft into the form.
+func (m *modelTransaction) resumeDraft(d draft) tea.Cmd {
+	m.new = d.New
+	m.submitted = false
+	m.groups.reset()
+	m.attr.trxID = d.TransactionID
+	m.attr.year, m.attr.month, m.attr.day = d.Year, d.Month, d.Day
+	m.attr.typeOverride = d.TypeOverride
+	m.attr.groupTitle = d.GroupTitle
+	m.splits = []*split{}
+	for _, s := range d.Splits {
+		m.splits = append(m.splits, &split{
+			source:        s.Source,
+			destination:   s.Destination,
+			category:      s.Category,
+			amount:        s.Amount,
+			foreignAmount: s.ForeignAmount,
+			description:   s.Description,
+			location:      s.Location,
+			zoom:          s.ZoomLevel,
+			trxJID:        s.TransactionJournalID,
+
+			internalReference: s.InternalReference,
+			externalID:        s.ExternalID,
+			invoiceDate:       s.InvoiceDate,
+
+			categoryPicked: s.Category.ID != "",
+
+			sharedSource:      d.Splits[0].Source,
+			sharedDestination: d.Splits[0].Destination,
+		})
+	}
+	m.created = true
+	m.dirty = true
+	return tea.Batch(
+		RedrawForm(),
+		SetView(newView),
+		notify.NotifyLog("Draft resumed"),
+	)
+}
@@ -0,0 +1,196 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/prompt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func newDraftTestModel(t *testing.T) modelTransaction {
+	t.Helper()
+	m := newTestTransactionModel()
+	m.draftFile = filepath.Join(t.TempDir(), "default.draft.json")
+	m.Focus()
+	return m
+}
+
+// saveDueDraft delivers the draft save scheduled by the last change.
+func saveDueDraft(m modelTransaction) modelTransaction {
+	updated, cmd := m.Update(draftDueMsg{edit: m.edits})
+	collectMsgsFromCmd(cmd)
+	return updated.(modelTransaction)
+}
+
+func TestDraft_SavedOnChangeAndDeletedOnSubmit(t *testing.T) {
+	m := newDraftTestModel(t)
+	m.SetTransaction(firefly.Transaction{}, true)
+	m.created = true
+	m.UpdateForm()
+	m = settleForm(m, m.form.Init())
+
+	if _, err := os.Stat(m.draftFile); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expected no draft before any input, got %v", err)
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = updated.(modelTransaction)
+	if _, err := os.Stat(m.draftFile); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expected the draft to wait for the form to settle, got %v", err)
+	}
+	m = saveDueDraft(m)
+	d, err := loadDraft(m.draftFile)
+	if err != nil {
+		t.Fatalf("expected draft after a change: %v", err)
+	}
+	if !d.New || len(d.Splits) != 1 || d.Splits[0].Source != testAssetSavings {
+		t.Errorf("expected draft with the changed source, got %+v", d)
+	}
+
+	m.splits[0].destination = testExpenseGroceries
+	m.splits[0].amount = "10"
+	m.attr.transactionType = "withdrawal"
+	m.CreateTransaction()
+	if _, err := os.Stat(m.draftFile); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected draft to be deleted after submitting, got %v", err)
+	}
+}
+
+func TestDraft_ResumeOnNewTransaction(t *testing.T) {
+	m := newDraftTestModel(t)
+	stored := draft{
+		New:        true,
+		Year:       "2026",
+		Month:      "02",
+		Day:        "03",
+		GroupTitle: "Weekly shopping",
+		Splits: []draftSplit{
+			{Source: testAssetChecking, Destination: testExpenseGroceries, Amount: "12.50", Description: "Bread"},
+			{Source: testAssetChecking, Destination: testExpenseUtilities, Amount: "3"},
+		},
+	}
+	if err := writeDraft(m.draftFile, stored); err != nil {
+		t.Fatal(err)
+	}
+
+	_, cmd := m.Update(NewTransactionMsg{})
+	msgs := collectMsgsFromCmd(cmd)
+	if len(msgs) != 1 {
+		t.Fatalf("expected a prompt, got %v", msgs)
+	}
+	ask, ok := msgs[0].(prompt.PromptMsg)
+	if !ok {
+		t.Fatalf("expected PromptMsg, got %T", msgs[0])
+	}
+
+	resume, ok := ask.Callback("y")().(ResumeDraftMsg)
+	if !ok {
+		t.Fatal("expected ResumeDraftMsg")
+	}
+	updated, cmd := m.Update(resume)
+	m = updated.(modelTransaction)
+	if !m.created || !m.Dirty() {
+		t.Error("expected resumed draft to be an unsaved form")
+	}
+	if len(m.splits) != 2 || m.splits[0].amount != "12.50" || m.splits[1].destination != testExpenseUtilities {
+		t.Errorf("expected splits of the draft, got %+v", m.splits)
+	}
+	if m.attr.day != "03" || m.GroupTitle() != "Weekly shopping" {
+		t.Errorf("expected attributes of the draft, got %+v", m.attr)
+	}
+	if view, ok := findMsg[SetFocusedViewMsg](collectMsgsFromCmd(cmd)); !ok || view.state != newView {
+		t.Error("expected the form to be shown")
+	}
+}
+
+func TestDraft_DeleteAndStartNew(t *testing.T) {
+	m := newDraftTestModel(t)
+	if err := writeDraft(m.draftFile, draft{New: true, Splits: []draftSplit{{Amount: "1"}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, cmd := m.Update(NewTransactionMsg{})
+	ask := collectMsgsFromCmd(cmd)[0].(prompt.PromptMsg)
+
+	if _, ok := ask.Callback("d")().(NewTransactionFromConfirmedMsg); !ok {
+		t.Error("expected an empty form to be opened")
+	}
+	if _, err := os.Stat(m.draftFile); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected draft to be deleted, got %v", err)
+	}
+}
+
+func TestDraft_SavedOnceChangesStop(t *testing.T) {
+	m := newDraftTestModel(t)
+	m.SetTransaction(firefly.Transaction{}, true)
+	m.created = true
+
+	m.splits[0].amount = "1"
+	first := m.edits
+	m.saveDraft()
+	m.splits[0].amount = "12"
+	m.saveDraft()
+
+	updated, cmd := m.Update(draftDueMsg{edit: first})
+	m = updated.(modelTransaction)
+	collectMsgsFromCmd(cmd)
+	if _, err := os.Stat(m.draftFile); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expected no write while the form still changes, got %v", err)
+	}
+
+	m = saveDueDraft(m)
+	if d, err := loadDraft(m.draftFile); err != nil || d.Splits[0].Amount != "12" {
+		t.Errorf("expected the latest values saved, got %+v, %v", d, err)
+	}
+}
+
+func TestDraft_LateWriteAfterSubmit(t *testing.T) {
+	m := newDraftTestModel(t)
+	m.SetTransaction(firefly.Transaction{}, true)
+	m.created = true
+	m.splits[0].amount = "1"
+	m.saveDraft()
+
+	_, write := m.Update(draftDueMsg{edit: m.edits})
+	m.deleteDraft()
+	collectMsgsFromCmd(write)
+
+	if _, err := os.Stat(m.draftFile); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected a write running late not to bring the draft back, got %v", err)
+	}
+}
+
+func TestDraft_SavedOnLeave(t *testing.T) {
+	m := newDraftTestModel(t)
+	m.SetTransaction(firefly.Transaction{}, true)
+	m.created = true
+	m.splits[0].amount = "7"
+
+	ask := m.confirmLeave()().(prompt.PromptMsg)
+	collectMsgsFromCmd(ask.Callback("s"))
+
+	if d, err := loadDraft(m.draftFile); err != nil || d.Splits[0].Amount != "7" {
+		t.Errorf("expected the draft saved right away, got %+v, %v", d, err)
+	}
+}
+
+func TestDraft_Disabled(t *testing.T) {
+	m := newTestTransactionModel()
+	m.splits = []*split{{amount: "1"}}
+	if cmd := m.saveDraft(); cmd != nil {
+		t.Error("expected no save scheduled without a draft file")
+	}
+	if _, ok := m.storedDraft(); ok {
+		t.Error("expected no draft without a draft file")
+	}
+}
@@ -0,0 +1,195 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// driverPoll is how often WaitFor looks at the latest frame.
+const driverPoll = 10 * time.Millisecond
+
+// Driver runs the UI as a Bubble Tea program without a terminal, for end
+// to end tests and scripted screenshots. Keys and messages are sent to the
+// program, commands run as they do in a terminal, and each update renders
+// a frame read with Frame. Colors follow the lipgloss color profile, set
+// it to render screenshots in color.
+type Driver struct {
+	program *tea.Program
+	done    chan struct{}
+	err     error
+
+	mu    sync.Mutex
+	frame string
+}
+
+// frameRecorder renders the UI after each update for the driver.
+type frameRecorder struct {
+	model  tea.Model
+	driver *Driver
+}
+
+func (r frameRecorder) Init() tea.Cmd {
+	r.driver.setFrame(r.model.View())
+	return r.model.Init()
+}
+
+func (r frameRecorder) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := r.model.Update(msg)
+	r.driver.setFrame(model.View())
+	return frameRecorder{model: model, driver: r.driver}, cmd
+}
+
+func (r frameRecorder) View() string {
+	return r.model.View()
+}
+
+// NewDriver starts the UI on api in a terminal of the given size. Nothing
+// is kept on disk: no drafts, session or crash reports. Stop it with Quit.
+func NewDriver(api UIAPI, width, height int) *Driver {
+	d := &Driver{done: make(chan struct{})}
+	d.program = tea.NewProgram(
+		frameRecorder{model: NewModelUI(api), driver: d},
+		tea.WithInput(nil),
+		tea.WithOutput(io.Discard),
+		tea.WithoutRenderer(),
+		tea.WithoutSignalHandler(),
+	)
+	go func() {
+		defer close(d.done)
+		_, d.err = d.program.Run()
+	}()
+	d.program.Send(tea.WindowSizeMsg{Width: width, Height: height})
+	return d
+}
+
+func (d *Driver) setFrame(frame string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.frame = frame
+}
+
+// Frame returns the latest rendered frame.
+func (d *Driver) Frame() string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.frame
+}
+
+// Send sends msg to the UI.
+func (d *Driver) Send(msg tea.Msg) {
+	d.program.Send(msg)
+}
+
+// Resize sends a new terminal size.
+func (d *Driver) Resize(width, height int) {
+	d.program.Send(tea.WindowSizeMsg{Width: width, Height: height})
+}
+
+// Press sends keys by the names used in the key bindings, e.g. "a",
+// "enter", "ctrl+n" or "alt+j".
+func (d *Driver) Press(keys ...string) error {
+	msgs := make([]tea.KeyMsg, 0, len(keys))
+	for _, k := range keys {
+		msg, err := parseKey(k)
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, msg)
+	}
+	for _, msg := range msgs {
+		d.program.Send(msg)
+	}
+	return nil
+}
+
+// Type sends text one key at a time, as if typed.
+func (d *Driver) Type(text string) {
+	for _, r := range text {
+		d.program.Send(runeKeyMsg(r))
+	}
+}
+
+// WaitFor waits until a frame satisfies cond and returns it, or fails with
+// the latest frame once timeout passes.
+func (d *Driver) WaitFor(cond func(frame string) bool, timeout time.Duration) (string, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		frame := d.Frame()
+		if cond(frame) {
+			return frame, nil
+		}
+		select {
+		case <-d.done:
+			return frame, errors.New("the UI exited")
+		default:
+		}
+		if time.Now().After(deadline) {
+			return frame, fmt.Errorf("timed out after %s, latest frame:\n%s", timeout, frame)
+		}
+		time.Sleep(driverPoll)
+	}
+}
+
+// WaitForText waits until a frame shows text.
+func (d *Driver) WaitForText(text string, timeout time.Duration) (string, error) {
+	return d.WaitFor(func(frame string) bool {
+		return strings.Contains(frame, text)
+	}, timeout)
+}
+
+// Quit stops the UI and returns the error it exited with.
+func (d *Driver) Quit() error {
+	d.program.Quit()
+	<-d.done
+	return d.err
+}
+
+// keyTypes are the keys by their names, except runes.
+var keyTypes = sync.OnceValue(func() map[string]tea.KeyType {
+	types := map[string]tea.KeyType{}
+	for t := tea.KeyType(-128); t <= 127; t++ {
+		if name := t.String(); name != "" && t != tea.KeyRunes {
+			if _, ok := types[name]; !ok {
+				types[name] = t
+			}
+		}
+	}
+	types["space"] = tea.KeySpace
+	return types
+})
+
+// parseKey returns the key msg of a key name.
+func parseKey(name string) (tea.KeyMsg, error) {
+	key, alt := strings.CutPrefix(name, "alt+")
+	if t, ok := keyTypes()[key]; ok {
+		msg := tea.KeyMsg{Type: t, Alt: alt}
+		if t == tea.KeySpace {
+			msg.Runes = []rune{' '}
+		}
+		return msg, nil
+	}
+	if r, size := utf8.DecodeRuneInString(key); r != utf8.RuneError && size == len(key) {
+		msg := runeKeyMsg(r)
+		msg.Alt = alt
+		return msg, nil
+	}
+	return tea.KeyMsg{}, fmt.Errorf("unknown key %q", name)
+}
+
+func runeKeyMsg(r rune) tea.KeyMsg {
+	if r == ' ' {
+		return tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+}
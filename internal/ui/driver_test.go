@@ -0,0 +1,103 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"ffiii-tui/internal/demo"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const driverTimeout = 5 * time.Second
+
+func newDemoDriver(t *testing.T, width, height int) *Driver {
+	t.Helper()
+	d := NewDriver(demo.New(1, time.Date(2026, time.March, 18, 12, 0, 0, 0, time.UTC)), width, height)
+	t.Cleanup(func() {
+		if err := d.Quit(); err != nil {
+			t.Errorf("expected the UI to exit cleanly, got %v", err)
+		}
+	})
+	return d
+}
+
+func TestDriver_EndToEnd(t *testing.T) {
+	d := newDemoDriver(t, 140, 40)
+
+	if _, err := d.WaitForText("Transactions loaded", driverTimeout); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := d.Press("c"); err != nil {
+		t.Fatal(err)
+	}
+	frame, err := d.WaitFor(func(frame string) bool {
+		return strings.Contains(frame, "Categories") && !strings.Contains(frame, "Asset accounts")
+	}, driverTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(frame, "\n"); len(lines) > 40 {
+		t.Errorf("expected the frame to fit 40 lines, got %d", len(lines))
+	}
+}
+
+func TestDriver_Resize(t *testing.T) {
+	d := newDemoDriver(t, 140, 40)
+
+	d.Resize(50, 8)
+	if _, err := d.WaitForText("Terminal too small", driverTimeout); err != nil {
+		t.Fatal(err)
+	}
+	d.Resize(140, 40)
+	if _, err := d.WaitFor(func(frame string) bool {
+		return !strings.Contains(frame, "Terminal too small")
+	}, driverTimeout); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDriver_WaitForTimeout(t *testing.T) {
+	d := newDemoDriver(t, 140, 40)
+
+	if _, err := d.WaitForText("no such text", 50*time.Millisecond); err == nil {
+		t.Error("expected a timeout")
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	tests := []struct {
+		name string
+		want tea.KeyMsg
+	}{
+		{"a", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}}},
+		{"€", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'€'}}},
+		{"enter", tea.KeyMsg{Type: tea.KeyEnter}},
+		{"esc", tea.KeyMsg{Type: tea.KeyEscape}},
+		{"ctrl+n", tea.KeyMsg{Type: tea.KeyCtrlN}},
+		{"shift+tab", tea.KeyMsg{Type: tea.KeyShiftTab}},
+		{"space", tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}},
+		{"alt+j", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}, Alt: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseKey(tt.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.String() != tt.want.String() || got.Type != tt.want.Type {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	if _, err := parseKey("hyper+x"); err == nil {
+		t.Error("expected an unknown key to fail")
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+
+	"ffiii-tui/internal/ui/money"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// envelopeLowShare is the share of the limit below which what is left of
+// an envelope is shown as running low.
+const envelopeLowShare = 0.25
+
+// envelopeDescription shows what is left of the budget of the category,
+// e.g. "Remaining: 120.50 of 450.00 EUR".
+func (i categoryItem) envelopeDescription() string {
+	if i.budget.Limit == 0 {
+		return "No budget limits in the period"
+	}
+	remaining := i.budget.Remaining()
+	if remaining < 0 {
+		return fmt.Sprintf("Over: %s of %s",
+			i.amounts.Amount(-remaining, i.budget.CurrencyCode), i.amounts.Format(i.budget.Limit, i.budget.CurrencyCode))
+	}
+	return fmt.Sprintf("Remaining: %s of %s",
+		i.amounts.Amount(remaining, i.budget.CurrencyCode), i.amounts.Format(i.budget.Limit, i.budget.CurrencyCode))
+}
+
+// envelopeStyle colors an envelope by what is left of it: green, amber
+// below envelopeLowShare of the limit and red once overspent.
+func envelopeStyle(styles Styles, i categoryItem) lipgloss.Style {
+	remaining := i.budget.Remaining()
+	switch {
+	case remaining < 0:
+		return styles.Withdrawal
+	case remaining < i.budget.Limit*envelopeLowShare:
+		return styles.NotifyWarn
+	default:
+		return styles.Deposit
+	}
+}
+
+// envelopeItems keeps the categories with a budget limit, the ones with the
+// least left of their limit first.
+func envelopeItems(items []list.Item) []list.Item {
+	envelopes := []list.Item{}
+	for _, item := range items {
+		i, ok := item.(categoryItem)
+		if !ok || i.budget.Limit == 0 {
+			continue
+		}
+		i.envelope = true
+		envelopes = append(envelopes, i)
+	}
+	slices.SortStableFunc(envelopes, func(a, b list.Item) int {
+		ea, eb := a.(categoryItem).budget, b.(categoryItem).budget
+		return cmp.Compare(ea.Remaining()/ea.Limit, eb.Remaining()/eb.Limit)
+	})
+	return envelopes
+}
+
+// envelopeTotal sums the envelopes in the primary currency, the others
+// are left out.
+func envelopeTotal(envelopes []list.Item, amounts money.Formatter) categoryItem {
+	total := categoryItem{category: totalCategory, envelope: true, amounts: amounts}
+	total.budget.CurrencyCode = totalCategory.CurrencyCode
+	for _, item := range envelopes {
+		i := item.(categoryItem)
+		if i.budget.CurrencyCode != total.budget.CurrencyCode {
+			continue
+		}
+		total.budget.Limit += i.budget.Limit
+		total.budget.Spent += i.budget.Spent
+	}
+	return total
+}
+
+// categoryDelegate colors the description of envelopes by what is left of
+// them, categories are rendered as usual.
+type categoryDelegate struct {
+	list.DefaultDelegate
+	styles Styles
+}
+
+func newCategoryDelegate(styles Styles) categoryDelegate {
+	return categoryDelegate{DefaultDelegate: list.NewDefaultDelegate(), styles: styles}
+}
+
+func (d categoryDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	if i, ok := item.(categoryItem); ok && i.envelope && i.budget.Limit != 0 {
+		fg := envelopeStyle(d.styles, i).GetForeground()
+		d.Styles.NormalDesc = d.Styles.NormalDesc.Foreground(fg)
+		d.Styles.SelectedDesc = d.Styles.SelectedDesc.Foreground(fg)
+	}
+	d.DefaultDelegate.Render(w, m, index, item)
+}
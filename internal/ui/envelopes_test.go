@@ -0,0 +1,127 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/money"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestEnvelopeDescriptionAndStyle(t *testing.T) {
+	styles := DefaultStyles()
+	tests := []struct {
+		name        string
+		budget      firefly.Budget
+		description string
+		style       string
+	}{
+		{"plenty left", firefly.Budget{Limit: 400, Spent: 100, CurrencyCode: "EUR"}, "Remaining: 300.00 of 400.00 EUR", "deposit"},
+		{"running low", firefly.Budget{Limit: 400, Spent: 350, CurrencyCode: "EUR"}, "Remaining: 50.00 of 400.00 EUR", "warn"},
+		{"overspent", firefly.Budget{Limit: 400, Spent: 420, CurrencyCode: "EUR"}, "Over: 20.00 of 400.00 EUR", "withdrawal"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := categoryItem{budget: tt.budget, envelope: true}
+			if got := i.Description(); got != tt.description {
+				t.Errorf("expected %q, got %q", tt.description, got)
+			}
+			want := map[string]any{
+				"deposit":    styles.Deposit.GetForeground(),
+				"warn":       styles.NotifyWarn.GetForeground(),
+				"withdrawal": styles.Withdrawal.GetForeground(),
+			}[tt.style]
+			if got := envelopeStyle(styles, i).GetForeground(); got != want {
+				t.Errorf("expected %s colored %v, got %v", tt.style, want, got)
+			}
+		})
+	}
+}
+
+func TestEnvelopeItems(t *testing.T) {
+	items := []list.Item{
+		categoryItem{category: firefly.Category{Name: "Groceries"}, budget: firefly.Budget{Limit: 400, Spent: 100, CurrencyCode: "USD"}},
+		categoryItem{category: firefly.Category{Name: "Transport"}},
+		categoryItem{category: firefly.Category{Name: "Dining out"}, budget: firefly.Budget{Limit: 100, Spent: 90, CurrencyCode: "USD"}},
+		categoryItem{category: firefly.Category{Name: "Travel"}, budget: firefly.Budget{Limit: 500, CurrencyCode: "EUR"}},
+	}
+
+	envelopes := envelopeItems(items)
+	names := []string{}
+	for _, item := range envelopes {
+		i := item.(categoryItem)
+		if !i.envelope {
+			t.Errorf("expected %s shown as an envelope", i.category.Name)
+		}
+		names = append(names, i.category.Name)
+	}
+	if strings.Join(names, ",") != "Dining out,Groceries,Travel" {
+		t.Errorf("expected the budgeted categories, least left first, got %v", names)
+	}
+
+	totalCategory.CurrencyCode = "USD"
+	total := envelopeTotal(envelopes, money.Formatter{})
+	if total.budget.Limit != 500 || total.budget.Spent != 190 {
+		t.Errorf("expected the USD envelopes summed, got %+v", total.budget)
+	}
+}
+
+func TestCategories_ToggleEnvelopes(t *testing.T) {
+	m := newFocusedCategoriesModelWithCategory(t, firefly.Category{ID: "c1", Name: "Groceries", CurrencyCode: "USD"})
+	api := m.api.(*mockCategoryAPI)
+	api.categoriesListFunc = func() []firefly.Category {
+		return []firefly.Category{
+			{ID: "c1", Name: "Groceries", CurrencyCode: "USD"},
+			{ID: "c2", Name: "Transport", CurrencyCode: "USD"},
+		}
+	}
+	api.categoryBudgetFunc = func(name string) (firefly.Budget, bool) {
+		if name != "Groceries" {
+			return firefly.Budget{}, false
+		}
+		return firefly.Budget{Name: name, Limit: 400, Spent: 380, CurrencyCode: "USD"}, true
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+	m = updated.(modelCategories)
+	if !m.envelopes || m.list.Title != "Envelopes" {
+		t.Fatalf("expected envelope mode, got %v %q", m.envelopes, m.list.Title)
+	}
+	if !hasMsg[CategoriesUpdateMsg](collectMsgsFromCmd(cmd)) {
+		t.Fatal("expected the list to be updated")
+	}
+
+	updated, _ = m.Update(CategoriesUpdateMsg{})
+	m = updated.(modelCategories)
+	items := m.list.Items()
+	if len(items) != 2 {
+		t.Fatalf("expected Total and Groceries, got %d items", len(items))
+	}
+	groceries := items[1].(categoryItem)
+	if groceries.Description() != "Remaining: 20.00 of 400.00 USD" {
+		t.Errorf("unexpected envelope %q", groceries.Description())
+	}
+
+	var b bytes.Buffer
+	m.list.SetSize(80, 20)
+	newCategoryDelegate(m.styles).Render(&b, m.list, 1, groceries)
+	if !strings.Contains(b.String(), "Remaining: 20.00") {
+		t.Errorf("expected the envelope rendered, got %q", b.String())
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+	m = updated.(modelCategories)
+	updated, _ = m.Update(CategoriesUpdateMsg{})
+	m = updated.(modelCategories)
+	if m.envelopes || m.list.Title != "Categories" || len(m.list.Items()) != 3 {
+		t.Errorf("expected the categories back, got %q with %d items", m.list.Title, len(m.list.Items()))
+	}
+}
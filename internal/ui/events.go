@@ -0,0 +1,103 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"reflect"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Event is a change of the data made through the TUI. Writes publish the
+// events of what they changed and the panels subscribe to the ones they
+// show, instead of each write listing the panels to refresh.
+type Event int
+
+const (
+	// TransactionsChanged follows creating, updating or deleting
+	// transactions, balances and insights change with them
+	TransactionsChanged Event = iota
+	// AccountsChanged follows changing accounts, e.g. an opening balance
+	AccountsChanged
+	// CategoriesChanged follows changing categories or their budgets
+	CategoriesChanged
+	// TagsChanged follows renaming, deleting or adding tags
+	TagsChanged
+)
+
+// EventMsg carries published events to the bus.
+type EventMsg struct {
+	Events []Event
+	// TrxID is the transaction to select once the list is reloaded
+	TrxID string
+}
+
+// Publish announces the events.
+func Publish(events ...Event) tea.Cmd {
+	return Cmd(EventMsg{Events: events})
+}
+
+// PublishTransaction announces a saved transaction, selected once the list
+// is reloaded.
+func PublishTransaction(id string) tea.Cmd {
+	return Cmd(EventMsg{Events: []Event{TransactionsChanged}, TrxID: id})
+}
+
+// eventBus turns events into the refresh messages of the subscribed panels.
+type eventBus struct {
+	subscribers map[Event][]func(EventMsg) tea.Msg
+}
+
+func newEventBus() *eventBus {
+	return &eventBus{subscribers: map[Event][]func(EventMsg) tea.Msg{}}
+}
+
+// Subscribe sends the message returned by refresh after each event.
+func (b *eventBus) Subscribe(event Event, refresh func(EventMsg) tea.Msg) {
+	b.subscribers[event] = append(b.subscribers[event], refresh)
+}
+
+// SubscribeAll sends msg after any of the events.
+func (b *eventBus) SubscribeAll(msg tea.Msg, events ...Event) {
+	for _, event := range events {
+		b.Subscribe(event, func(EventMsg) tea.Msg { return msg })
+	}
+}
+
+// dispatch returns the refreshes of the subscribers, each type of message
+// once even when several of the events ask for it: the first event wins.
+func (b *eventBus) dispatch(msg EventMsg) tea.Cmd {
+	var cmds []tea.Cmd
+	seen := map[reflect.Type]bool{}
+	for _, event := range msg.Events {
+		for _, refresh := range b.subscribers[event] {
+			refreshMsg := refresh(msg)
+			if seen[reflect.TypeOf(refreshMsg)] {
+				continue
+			}
+			seen[reflect.TypeOf(refreshMsg)] = true
+			cmds = append(cmds, Cmd(refreshMsg))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// subscribePanels registers what each panel shows.
+func subscribePanels(bus *eventBus) {
+	bus.Subscribe(TransactionsChanged, func(e EventMsg) tea.Msg {
+		return RefreshTransactionsMsg{TrxID: e.TrxID}
+	})
+	bus.SubscribeAll(RefreshTransactionsMsg{}, AccountsChanged, TagsChanged)
+
+	bus.SubscribeAll(RefreshAssetsMsg{}, TransactionsChanged, AccountsChanged)
+	bus.SubscribeAll(RefreshLiabilitiesMsg{}, TransactionsChanged, AccountsChanged)
+	bus.SubscribeAll(RefreshSummaryMsg{}, TransactionsChanged, AccountsChanged)
+
+	bus.SubscribeAll(RefreshExpenseInsightsMsg{}, TransactionsChanged)
+	bus.SubscribeAll(RefreshRevenueInsightsMsg{}, TransactionsChanged)
+	bus.SubscribeAll(RefreshCategoryInsightsMsg{}, TransactionsChanged)
+	bus.SubscribeAll(RefreshCategoriesMsg{}, CategoriesChanged)
+	bus.SubscribeAll(RefreshTagsMsg{}, TagsChanged)
+}
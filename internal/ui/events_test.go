@@ -0,0 +1,79 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// dispatched adds the refreshes of the events published in msgs, as the
+// bus of the UI sends them.
+func dispatched(msgs []tea.Msg) []tea.Msg {
+	bus := newEventBus()
+	subscribePanels(bus)
+	out := msgs
+	for _, msg := range msgs {
+		if event, ok := msg.(EventMsg); ok {
+			out = append(out, collectMsgsFromCmd(bus.dispatch(event))...)
+		}
+	}
+	return out
+}
+
+func TestEventBus_TransactionsChanged(t *testing.T) {
+	msgs := dispatched(collectMsgsFromCmd(PublishTransaction("42")))
+
+	refresh, ok := findMsg[RefreshTransactionsMsg](msgs)
+	if !ok || refresh.TrxID != "42" {
+		t.Errorf("expected the transactions reloaded with #42 selected, got %v", msgs)
+	}
+	if !hasMsg[RefreshAssetsMsg](msgs) || !hasMsg[RefreshLiabilitiesMsg](msgs) || !hasMsg[RefreshSummaryMsg](msgs) {
+		t.Errorf("expected the balances refreshed, got %v", msgs)
+	}
+	if !hasMsg[RefreshCategoryInsightsMsg](msgs) || !hasMsg[RefreshExpenseInsightsMsg](msgs) || !hasMsg[RefreshRevenueInsightsMsg](msgs) {
+		t.Errorf("expected the insights refreshed, got %v", msgs)
+	}
+	if hasMsg[RefreshTagsMsg](msgs) || hasMsg[RefreshCategoriesMsg](msgs) {
+		t.Errorf("expected no refresh of unsubscribed panels, got %v", msgs)
+	}
+}
+
+func TestEventBus_RefreshesOnce(t *testing.T) {
+	bus := newEventBus()
+	subscribePanels(bus)
+
+	msgs := collectMsgsFromCmd(bus.dispatch(EventMsg{Events: []Event{TransactionsChanged, AccountsChanged, TagsChanged}, TrxID: "7"}))
+	count := 0
+	for _, msg := range msgs {
+		if refresh, ok := msg.(RefreshTransactionsMsg); ok {
+			count++
+			if refresh.TrxID != "7" {
+				t.Errorf("expected the first event to win, got %+v", refresh)
+			}
+		}
+	}
+	if count != 1 {
+		t.Errorf("expected the transactions reloaded once, got %d times", count)
+	}
+}
+
+func TestEventBus_NoSubscribers(t *testing.T) {
+	bus := newEventBus()
+	if cmd := bus.dispatch(EventMsg{Events: []Event{TagsChanged}}); cmd != nil {
+		t.Errorf("expected nothing to refresh, got %v", collectMsgsFromCmd(cmd))
+	}
+}
+
+func TestModelUI_DispatchesEvents(t *testing.T) {
+	m := newTestModelUI()
+
+	_, cmd := m.Update(EventMsg{Events: []Event{TagsChanged}})
+	msgs := collectMsgsFromCmd(cmd)
+	if !hasMsg[RefreshTagsMsg](msgs) || !hasMsg[RefreshTransactionsMsg](msgs) {
+		t.Errorf("expected tags and transactions refreshed, got %v", msgs)
+	}
+}
@@ -5,10 +5,13 @@ SPDX-License-Identifier: Apache-2.0
 package ui
 
 import (
+	"context"
 	"fmt"
 	"slices"
 
 	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/loading"
+	"ffiii-tui/internal/ui/money"
 	"ffiii-tui/internal/ui/notify"
 	"ffiii-tui/internal/ui/prompt"
 
@@ -28,18 +31,16 @@ type (
 type expenseItem = accountListItem[firefly.Account]
 
 type modelExpenses struct {
-	AccountListModel[firefly.Account]
+	AccountListModel[firefly.Account, ExpenseAPI]
+	requests *requestScope // of the selected period
 }
 
-func newModelExpenses(api ExpenseAPI) modelExpenses {
-	config := &AccountListConfig[firefly.Account]{
+func newModelExpenses(api ExpenseAPI, requests *requestScope) modelExpenses {
+	config := &AccountListConfig[firefly.Account, ExpenseAPI]{
 		AccountType: "expense",
 		Title:       "Expense accounts",
-		GetItems: func(apiInterface any, sorted bool) []list.Item {
-			return getExpensesItems(apiInterface.(ExpenseAPI), sorted)
-		},
-		RefreshItems: func(apiInterface any, accountType string) error {
-			return apiInterface.(ExpenseAPI).UpdateAccounts(accountType)
+		GetItems: func(api ExpenseAPI, sorted bool) []list.Item {
+			return getExpensesItems(api, sorted)
 		},
 		RefreshMsgType: RefreshExpensesMsg{},
 		UpdateMsgType:  ExpensesUpdatedMsg{},
@@ -48,8 +49,11 @@ func newModelExpenses(api ExpenseAPI) modelExpenses {
 		},
 		HasSort:     true,
 		HasTotalRow: true,
-		GetTotalFunc: func(api any) float64 {
-			return api.(ExpenseAPI).GetTotalExpenseDiff()
+		GetTotalFunc: func(api ExpenseAPI) float64 {
+			return api.GetTotalExpenseDiff()
+		},
+		GetTotalsFunc: func(api ExpenseAPI) map[string]float64 {
+			return api.ExpenseTotals()
 		},
 		FilterFunc: func(item list.Item) tea.Cmd {
 			i, ok := item.(expenseItem)
@@ -70,6 +74,7 @@ func newModelExpenses(api ExpenseAPI) modelExpenses {
 	}
 	return modelExpenses{
 		AccountListModel: NewAccountListModel(api, config),
+		requests:         requests,
 	}
 }
 
@@ -79,10 +84,9 @@ func (m modelExpenses) Init() tea.Cmd {
 
 func (m modelExpenses) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if newMsg, ok := msg.(NewExpenseMsg); ok {
-		api := m.api.(ExpenseAPI)
-		err := api.CreateExpenseAccount(newMsg.Account)
+		err := m.api.CreateExpenseAccount(context.Background(), newMsg.Account)
 		if err != nil {
-			return m, notify.NotifyWarn(err.Error())
+			return m, notify.NotifyWarn(errorText(err))
 		}
 		return m, tea.Batch(
 			Cmd(RefreshExpensesMsg{}),
@@ -92,25 +96,26 @@ func (m modelExpenses) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg.(type) {
 	case RefreshExpenseInsightsMsg:
-		return m, func() tea.Msg {
-			opID := startLoading("Loading expense insights...")
-			defer stopLoading(opID)
-			err := m.api.(ExpenseAPI).UpdateExpenseInsights()
+		ctx := m.requests.Context()
+		return m, loading.Track("Loading expense insights...", func() tea.Msg {
+			err := m.api.UpdateExpenseInsights(ctx)
 			if err != nil {
-				return notify.NotifyWarn(err.Error())()
+				return dataLoadFailed("expense", err)
 			}
 			return ExpensesUpdatedMsg{}
-		}
+		})
 	}
 	updated, cmd := m.AccountListModel.Update(msg)
-	m.AccountListModel = updated.(AccountListModel[firefly.Account])
+	m.AccountListModel = updated.(AccountListModel[firefly.Account, ExpenseAPI])
 	return m, cmd
 }
 
 func getExpensesItems(api ExpenseAPI, sorted bool) []list.Item {
 	items := []list.Item{}
-	for _, account := range api.AccountsByType("expense") {
-		spent := api.GetExpenseDiff(account.ID)
+	data := fromStore[expensesReader](api)
+	amounts := money.New(api)
+	for _, account := range data.AccountsByType("expense") {
+		spent := data.GetExpenseDiff(account.ID)
 		if sorted && spent == 0 {
 			continue
 		}
@@ -118,6 +123,7 @@ func getExpensesItems(api ExpenseAPI, sorted bool) []list.Item {
 			account,
 			"Spent",
 			spent,
+			amounts,
 		))
 	}
 	if sorted {
@@ -6,6 +6,7 @@ SPDX-License-Identifier: Apache-2.0
 package ui
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -18,6 +19,8 @@ import (
 )
 
 type mockExpenseAPI struct {
+	expenseTotalsFunc           func() map[string]float64
+	convertToPrimaryFunc        func(amount float64, currencyCode string) (float64, bool)
 	updateAccountsFunc          func(accountType string) error
 	accountsByTypeFunc          func(accountType string) []firefly.Account
 	accountBalanceFunc          func(accountID string) float64
@@ -29,9 +32,10 @@ type mockExpenseAPI struct {
 	updateAccountsCalledWith    []string
 	createExpenseCalledWith     []string
 	updateExpenseInsightsCalled bool
+	forgetInsightsCalled        int
 }
 
-func (m *mockExpenseAPI) UpdateAccounts(accountType string) error {
+func (m *mockExpenseAPI) UpdateAccounts(_ context.Context, accountType string) error {
 	m.updateAccountsCalledWith = append(m.updateAccountsCalledWith, accountType)
 	if m.updateAccountsFunc != nil {
 		return m.updateAccountsFunc(accountType)
@@ -53,7 +57,7 @@ func (m *mockExpenseAPI) AccountBalance(accountID string) float64 {
 	return 0
 }
 
-func (m *mockExpenseAPI) CreateExpenseAccount(name string) error {
+func (m *mockExpenseAPI) CreateExpenseAccount(_ context.Context, name string) error {
 	m.createExpenseCalledWith = append(m.createExpenseCalledWith, name)
 	if m.createExpenseAccountFunc != nil {
 		return m.createExpenseAccountFunc(name)
@@ -61,7 +65,11 @@ func (m *mockExpenseAPI) CreateExpenseAccount(name string) error {
 	return nil
 }
 
-func (m *mockExpenseAPI) UpdateExpenseInsights() error {
+func (m *mockExpenseAPI) ForgetInsights() {
+	m.forgetInsightsCalled++
+}
+
+func (m *mockExpenseAPI) UpdateExpenseInsights(_ context.Context) error {
 	m.updateExpenseInsightsCalled = true
 	if m.updateExpenseInsightsFunc != nil {
 		return m.updateExpenseInsightsFunc()
@@ -90,6 +98,24 @@ func (m *mockExpenseAPI) PrimaryCurrency() firefly.Currency {
 	return firefly.Currency{Code: "USD", Symbol: "$"}
 }
 
+func (m *mockExpenseAPI) GetCurrencyByCode(code string) firefly.Currency {
+	return firefly.Currency{Code: code}
+}
+
+func (m *mockExpenseAPI) ConvertToPrimary(amount float64, currencyCode string) (float64, bool) {
+	if m.convertToPrimaryFunc != nil {
+		return m.convertToPrimaryFunc(amount, currencyCode)
+	}
+	return amount, false
+}
+
+func (m *mockExpenseAPI) ExpenseTotals() map[string]float64 {
+	if m.expenseTotalsFunc != nil {
+		return m.expenseTotalsFunc()
+	}
+	return nil
+}
+
 func newFocusedExpensesModelWithAccount(t *testing.T, acc firefly.Account) modelExpenses {
 	t.Helper()
 
@@ -106,7 +132,7 @@ func newFocusedExpensesModelWithAccount(t *testing.T, acc firefly.Account) model
 		},
 	}
 
-	m := newModelExpenses(api)
+	m := newModelExpenses(api, newRequestScope())
 	(&m).Focus()
 	return m
 }
@@ -214,14 +240,14 @@ func TestModelExpenses_RefreshExpenses_Success(t *testing.T) {
 			return firefly.Currency{Code: "USD", Symbol: "$"}
 		},
 	}
-	m := newModelExpenses(api)
+	m := newModelExpenses(api, newRequestScope())
 
 	_, cmd := m.Update(RefreshExpensesMsg{})
 	if cmd == nil {
 		t.Fatal("expected cmd")
 	}
 
-	msg := cmd()
+	msg := trackedMsg(cmd)
 	if _, ok := msg.(ExpensesUpdatedMsg); !ok {
 		t.Fatalf("expected ExpensesUpdatedMsg, got %T", msg)
 	}
@@ -244,17 +270,20 @@ func TestModelExpenses_RefreshExpenses_Error(t *testing.T) {
 			return expectedErr
 		},
 	}
-	m := newModelExpenses(api)
+	m := newModelExpenses(api, newRequestScope())
 
 	_, cmd := m.Update(RefreshExpensesMsg{})
 	if cmd == nil {
 		t.Fatal("expected cmd")
 	}
 
-	msg := cmd()
-	notifyMsg, ok := msg.(notify.NotifyMsg)
+	msgs := collectMsgsFromCmd(cmd)
+	if !hasMsg[DataLoadFailedMsg](msgs) {
+		t.Errorf("expected DataLoadFailedMsg, got %v", msgs)
+	}
+	notifyMsg, ok := msgs[0].(notify.NotifyMsg)
 	if !ok {
-		t.Fatalf("expected notify.NotifyMsg, got %T", msg)
+		t.Fatalf("expected notify.NotifyMsg, got %T", msgs[0])
 	}
 	if notifyMsg.Level != notify.Warn {
 		t.Fatalf("expected warn level, got %v", notifyMsg.Level)
@@ -273,14 +302,14 @@ func TestModelExpenses_RefreshExpenseInsights_Success(t *testing.T) {
 			return firefly.Currency{Code: "USD", Symbol: "$"}
 		},
 	}
-	m := newModelExpenses(api)
+	m := newModelExpenses(api, newRequestScope())
 
 	_, cmd := m.Update(RefreshExpenseInsightsMsg{})
 	if cmd == nil {
 		t.Fatal("expected cmd")
 	}
 
-	msg := cmd()
+	msg := trackedMsg(cmd)
 	if _, ok := msg.(ExpensesUpdatedMsg); !ok {
 		t.Fatalf("expected ExpensesUpdatedMsg, got %T", msg)
 	}
@@ -303,17 +332,21 @@ func TestModelExpenses_RefreshExpenseInsights_Error(t *testing.T) {
 			return expectedErr
 		},
 	}
-	m := newModelExpenses(api)
+	m := newModelExpenses(api, newRequestScope())
 
 	_, cmd := m.Update(RefreshExpenseInsightsMsg{})
 	if cmd == nil {
 		t.Fatal("expected cmd")
 	}
 
-	msg := cmd()
-	notifyMsg, ok := msg.(notify.NotifyMsg)
+	msgs := collectMsgsFromCmd(cmd)
+	failed, ok := findMsg[DataLoadFailedMsg](msgs)
+	if !ok || failed.DataType != "expense" {
+		t.Errorf("expected DataLoadFailedMsg for expense, got %v", msgs)
+	}
+	notifyMsg, ok := msgs[0].(notify.NotifyMsg)
 	if !ok {
-		t.Fatalf("expected notify.NotifyMsg, got %T", msg)
+		t.Fatalf("expected notify.NotifyMsg, got %T", msgs[0])
 	}
 	if notifyMsg.Message != expectedErr.Error() {
 		t.Fatalf("expected message %q, got %q", expectedErr.Error(), notifyMsg.Message)
@@ -329,7 +362,7 @@ func TestModelExpenses_NewExpense_Success(t *testing.T) {
 			return firefly.Currency{Code: "USD", Symbol: "$"}
 		},
 	}
-	m := newModelExpenses(api)
+	m := newModelExpenses(api, newRequestScope())
 
 	_, cmd := m.Update(NewExpenseMsg{Account: "New Expense"})
 	if cmd == nil {
@@ -374,7 +407,7 @@ func TestModelExpenses_NewExpense_Error(t *testing.T) {
 			return expectedErr
 		},
 	}
-	m := newModelExpenses(api)
+	m := newModelExpenses(api, newRequestScope())
 
 	_, cmd := m.Update(NewExpenseMsg{Account: "Bad Expense"})
 	if cmd == nil {
@@ -411,7 +444,7 @@ func TestModelExpenses_ExpensesUpdated_EmitsDataLoadCompleted(t *testing.T) {
 			return firefly.Currency{Code: "USD", Symbol: "$"}
 		},
 	}
-	m := newModelExpenses(api)
+	m := newModelExpenses(api, newRequestScope())
 
 	updated, cmd := m.Update(ExpensesUpdatedMsg{})
 	m2 := updated.(modelExpenses)
@@ -464,7 +497,7 @@ func TestModelExpenses_UpdatePositions_SetsListSize(t *testing.T) {
 			return firefly.Currency{Code: "USD", Symbol: "$"}
 		},
 	}
-	m := newModelExpenses(api)
+	m := newModelExpenses(api, newRequestScope())
 
 	updated, _ := m.Update(UpdatePositions{
 		layout: &LayoutConfig{
@@ -498,7 +531,7 @@ func TestModelExpenses_IgnoresKeysWhenNotFocused(t *testing.T) {
 			return firefly.Currency{Code: "USD", Symbol: "$"}
 		},
 	}
-	m := newModelExpenses(api) // focus is false by default
+	m := newModelExpenses(api, newRequestScope()) // focus is false by default
 
 	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
 	if cmd != nil {
@@ -538,7 +571,7 @@ func TestModelExpenses_KeyFilter_IgnoresTotalAccount(t *testing.T) {
 			return firefly.Currency{Code: "USD", Symbol: "$"}
 		},
 	}
-	m := newModelExpenses(api)
+	m := newModelExpenses(api, newRequestScope())
 	(&m).Focus()
 
 	// Trigger ExpensesUpdatedMsg to add total account
@@ -570,6 +603,9 @@ func TestModelExpenses_KeyRefresh_EmitsRefreshExpensesMsg(t *testing.T) {
 	if _, ok := msg.(RefreshExpensesMsg); !ok {
 		t.Fatalf("expected RefreshExpensesMsg, got %T", msg)
 	}
+	if got := m.api.(*mockExpenseAPI).forgetInsightsCalled; got != 1 {
+		t.Errorf("expected cached insights to be dropped once, got %d", got)
+	}
 }
 
 func TestModelExpenses_KeySort_TogglesSort(t *testing.T) {
@@ -590,7 +626,7 @@ func TestModelExpenses_KeySort_TogglesSort(t *testing.T) {
 			return firefly.Currency{Code: "USD", Symbol: "$"}
 		},
 	}
-	m := newModelExpenses(api)
+	m := newModelExpenses(api, newRequestScope())
 	(&m).Focus()
 
 	if m.sorted {
@@ -849,7 +885,7 @@ func TestModelExpenses_EmptyAccountList(t *testing.T) {
 			return firefly.Currency{Code: "USD", Symbol: "$"}
 		},
 	}
-	m := newModelExpenses(api)
+	m := newModelExpenses(api, newRequestScope())
 	(&m).Focus()
 
 	// Verify no panics when updating with empty list
@@ -873,7 +909,7 @@ func TestModelExpenses_NilAPI(t *testing.T) {
 		}
 	}()
 
-	_ = newModelExpenses(nil)
+	_ = newModelExpenses(nil, newRequestScope())
 }
 
 func TestModelExpenses_SpentBoundaryValues(t *testing.T) {
@@ -995,8 +1031,8 @@ func TestModelExpenses_MissingCurrencyCode(t *testing.T) {
 	}
 
 	desc := item.Description()
-	if desc != "Spent: 500.00 " {
-		t.Errorf("expected description %q, got %q", "Spent: 500.00 ", desc)
+	if desc != "Spent: 500.00" {
+		t.Errorf("expected description %q, got %q", "Spent: 500.00", desc)
 	}
 }
 
@@ -1013,7 +1049,7 @@ func TestModelExpenses_FocusToggle(t *testing.T) {
 		},
 	}
 
-	m := newModelExpenses(api)
+	m := newModelExpenses(api, newRequestScope())
 
 	if m.focus {
 		t.Fatal("expected focus to be false initially")
@@ -1065,7 +1101,7 @@ func TestModelExpenses_UpdatePositions_WithVariousDimensions(t *testing.T) {
 					return firefly.Currency{Code: "USD", Symbol: "$"}
 				},
 			}
-			m := newModelExpenses(api)
+			m := newModelExpenses(api, newRequestScope())
 
 			updated, _ := m.Update(UpdatePositions{
 				layout: &LayoutConfig{
@@ -0,0 +1,195 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"slices"
+	"strings"
+
+	"ffiii-tui/internal/firefly"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// tagPrefix marks a filter query as a tag, e.g. "#vacation".
+const tagPrefix = "#"
+
+type filterKind int
+
+const (
+	filterAccount filterKind = iota
+	filterCategory
+	filterQuery
+	filterTag
+	filterDates
+	filterType
+	filterUnreconciled
+	filterUncategorized
+)
+
+// transactionFilters is the whole filter set of the transactions, kept
+// aside while searching.
+type transactionFilters struct {
+	account          firefly.Account
+	category         firefly.Category
+	query            string
+	tag              string
+	dates            dateRange
+	typeFilter       string
+	unreconciledOnly bool
+	uncategorized    string
+	order            []filterKind
+}
+
+// filters returns the filters set.
+func (m modelTransactions) filters() transactionFilters {
+	return transactionFilters{
+		account:          m.currentAccount,
+		category:         m.currentCategory,
+		query:            m.currentFilter,
+		tag:              m.currentTag,
+		dates:            m.dates,
+		typeFilter:       m.typeFilter,
+		unreconciledOnly: m.unreconciledOnly,
+		uncategorized:    m.uncategorized,
+		order:            slices.Clone(m.filterOrder),
+	}
+}
+
+// setFilters replaces the filters set with f.
+func (m *modelTransactions) setFilters(f transactionFilters) {
+	m.currentAccount = f.account
+	m.currentCategory = f.category
+	m.currentFilter = f.query
+	m.currentTag = f.tag
+	m.dates = f.dates
+	m.typeFilter = f.typeFilter
+	m.unreconciledOnly = f.unreconciledOnly
+	m.uncategorized = f.uncategorized
+	m.filterOrder = slices.Clone(f.order)
+}
+
+// filterChip is an active transactions filter as shown in the header.
+type filterChip struct {
+	kind  filterKind
+	label string
+}
+
+// activeFilters returns the label of every filter set.
+func (m modelTransactions) activeFilters() map[filterKind]string {
+	active := map[filterKind]string{}
+	if !m.currentAccount.IsEmpty() {
+		active[filterAccount] = "Account: " + m.currentAccount.Name
+	}
+	if !m.currentCategory.IsEmpty() {
+		active[filterCategory] = "Category: " + m.currentCategory.Name
+	}
+	if m.currentFilter != "" {
+		active[filterQuery] = "Filter: " + m.currentFilter
+	}
+	if m.currentTag != "" {
+		active[filterTag] = "Tag: " + m.currentTag
+	}
+	if !m.dates.isEmpty() {
+		active[filterDates] = "Dates: " + m.dates.String()
+	}
+	if m.typeFilter != "" {
+		active[filterType] = "Type: " + m.typeFilter
+	}
+	if m.unreconciledOnly {
+		active[filterUnreconciled] = "Unreconciled"
+	}
+	if label, ok := uncategorizedLabels[m.uncategorized]; ok {
+		active[filterUncategorized] = label
+	}
+	return active
+}
+
+// syncFilterOrder keeps filterOrder in the order the filters were set,
+// dropping the ones cleared since.
+func (m *modelTransactions) syncFilterOrder() {
+	active := m.activeFilters()
+	m.filterOrder = slices.DeleteFunc(m.filterOrder, func(kind filterKind) bool {
+		_, ok := active[kind]
+		return !ok
+	})
+	for kind := filterAccount; kind <= filterUncategorized; kind++ {
+		if _, ok := active[kind]; ok && !slices.Contains(m.filterOrder, kind) {
+			m.filterOrder = append(m.filterOrder, kind)
+		}
+	}
+}
+
+// filterChips returns the active filters, the one set last at the end.
+func (m modelTransactions) filterChips() []filterChip {
+	m.filterOrder = slices.Clone(m.filterOrder)
+	m.syncFilterOrder()
+	active := m.activeFilters()
+	chips := []filterChip{}
+	for _, kind := range m.filterOrder {
+		chips = append(chips, filterChip{kind: kind, label: active[kind]})
+	}
+	return chips
+}
+
+// removeFilter clears a single filter, leaving the others in place.
+func (m *modelTransactions) removeFilter(kind filterKind) {
+	switch kind {
+	case filterAccount:
+		m.currentAccount = firefly.Account{}
+	case filterCategory:
+		m.currentCategory = firefly.Category{}
+	case filterQuery:
+		m.currentFilter = ""
+	case filterTag:
+		m.currentTag = ""
+	case filterDates:
+		m.dates = dateRange{}
+	case filterType:
+		m.typeFilter = ""
+	case filterUnreconciled:
+		m.unreconciledOnly = false
+	case filterUncategorized:
+		m.uncategorized = ""
+	}
+}
+
+// hasTag reports whether a split of tx carries tag, ignoring case.
+func hasTag(tx firefly.Transaction, tag string) bool {
+	for _, split := range tx.Splits {
+		for _, t := range split.Tags {
+			if strings.EqualFold(t, tag) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// renderFilterChips renders the active filters for the header, with a hint
+// on how to remove the last one.
+func renderFilterChips(chips []filterChip, styles Styles) string {
+	if len(chips) == 0 {
+		return ""
+	}
+	rendered := []string{}
+	for _, chip := range chips {
+		rendered = append(rendered, styles.FilterChip.Render(chip.label+" ×"))
+	}
+	return strings.Join(rendered, " ") + styles.FilterChipHint.Render(" backspace removes last")
+}
+
+// headerWithChips appends the chips to header, cut to width.
+func headerWithChips(header, chips string, width int) string {
+	if chips == "" {
+		return header
+	}
+	line := header + "  " + chips
+	if width > 0 && lipgloss.Width(line) > width {
+		line = ansi.Truncate(line, width-1, "…")
+	}
+	return line
+}
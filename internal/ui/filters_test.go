@@ -0,0 +1,153 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func newTestFilterTransactions() []firefly.Transaction {
+	food := firefly.Category{ID: "c1", Name: "Food"}
+	wallet := firefly.Account{ID: "a1", Name: "Wallet"}
+
+	lunch := newTestTransaction(0, "tx1", "withdrawal", "2024-01-15T10:00:00Z", "Lunch")
+	lunch.Splits[0].Source = wallet
+	lunch.Splits[0].Category = food
+	lunch.Splits[0].Tags = []string{"Work"}
+
+	dinner := newTestTransaction(1, "tx2", "withdrawal", "2024-01-16T10:00:00Z", "Dinner")
+	dinner.Splits[0].Source = wallet
+	dinner.Splits[0].Category = food
+
+	lunchByCard := newTestTransaction(2, "tx3", "withdrawal", "2024-01-17T10:00:00Z", "Lunch")
+	lunchByCard.Splits[0].Category = food
+	lunchByCard.Splits[0].Tags = []string{"work"}
+
+	return []firefly.Transaction{lunch, dinner, lunchByCard}
+}
+
+func applyFilter(t *testing.T, m modelTransactions, msg tea.Msg) modelTransactions {
+	t.Helper()
+	updated, cmd := m.Update(msg)
+	m = updated.(modelTransactions)
+	if filter, ok := findMsg[FilterMsg](collectMsgsFromCmd(cmd)); ok {
+		updated, _ = m.Update(filter)
+		m = updated.(modelTransactions)
+	}
+	return m
+}
+
+func shownIDs(m modelTransactions) string {
+	ids := []string{}
+	for _, row := range m.table.Rows() {
+		ids = append(ids, row[11])
+	}
+	return strings.Join(ids, ",")
+}
+
+func chipLabels(m modelTransactions) string {
+	labels := []string{}
+	for _, chip := range m.filterChips() {
+		labels = append(labels, chip.label)
+	}
+	return strings.Join(labels, ", ")
+}
+
+func TestFilters_Stack(t *testing.T) {
+	m := newFocusedTransactionModel(t, newTestFilterTransactions())
+
+	m = applyFilter(t, m, FilterMsg{Category: firefly.Category{ID: "c1", Name: "Food"}})
+	m = applyFilter(t, m, FilterMsg{Account: firefly.Account{ID: "a1", Name: "Wallet"}})
+	m = applyFilter(t, m, FilterMsg{Query: "lunch"})
+	if got := shownIDs(m); got != "tx1" {
+		t.Errorf("expected the filters to stack, got %s", got)
+	}
+
+	// Applying a filter again keeps the others
+	m = applyFilter(t, m, FilterMsg{Account: firefly.Account{ID: "a1", Name: "Wallet"}})
+	if got := shownIDs(m); got != "tx1" {
+		t.Errorf("expected the filters to be kept, got %s", got)
+	}
+
+	// A reload keeps them too
+	m = applyFilter(t, m, TransactionsUpdateMsg{Transactions: m.transactions})
+	if got := chipLabels(m); got != "Category: Food, Account: Wallet, Filter: lunch" {
+		t.Errorf("unexpected chips after reload: %s", got)
+	}
+	if got := shownIDs(m); got != "tx1" {
+		t.Errorf("expected the filters to survive a reload, got %s", got)
+	}
+}
+
+func TestFilters_Tag(t *testing.T) {
+	m := newFocusedTransactionModel(t, newTestFilterTransactions())
+
+	m = applyFilter(t, m, FilterMsg{Query: "#WORK"})
+	if m.currentTag != "WORK" || m.currentFilter != "" {
+		t.Fatalf("expected a tag filter, got tag %q and query %q", m.currentTag, m.currentFilter)
+	}
+	if got := shownIDs(m); got != "tx1,tx3" {
+		t.Errorf("expected the tagged transactions, got %s", got)
+	}
+
+	m = applyFilter(t, m, FilterMsg{Query: "lunch"})
+	if got := chipLabels(m); got != "Tag: WORK, Filter: lunch" {
+		t.Errorf("expected tag and query chips, got %s", got)
+	}
+}
+
+func TestFilters_RemoveLast(t *testing.T) {
+	m := newFocusedTransactionModel(t, newTestFilterTransactions())
+	backspace := tea.KeyMsg{Type: tea.KeyBackspace}
+
+	m = applyFilter(t, m, FilterMsg{Query: "lunch"})
+	m = applyFilter(t, m, FilterMsg{Account: firefly.Account{ID: "a1", Name: "Wallet"}})
+	m = applyFilter(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+
+	m = applyFilter(t, m, backspace)
+	if got := chipLabels(m); got != "Filter: lunch, Account: Wallet" {
+		t.Errorf("expected the unreconciled filter removed, got %s", got)
+	}
+	m = applyFilter(t, m, backspace)
+	if got := chipLabels(m); got != "Filter: lunch" {
+		t.Errorf("expected the account filter removed, got %s", got)
+	}
+	if got := shownIDs(m); got != "tx1,tx3" {
+		t.Errorf("expected the query filter to stay, got %s", got)
+	}
+	m = applyFilter(t, m, backspace)
+	m = applyFilter(t, m, backspace)
+	if got := shownIDs(m); got != "tx1,tx2,tx3" {
+		t.Errorf("expected all transactions without filters, got %s", got)
+	}
+}
+
+func TestFilters_HeaderChips(t *testing.T) {
+	m := newTestModelUI()
+	m.Width = 200
+	m.transactions.currentAccount = firefly.Account{ID: "a1", Name: "Wallet"}
+	m.transactions.currentTag = "work"
+
+	view := m.View()
+	for _, want := range []string{"Account: Wallet ×", "Tag: work ×", "backspace removes last"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected header to contain %q", want)
+		}
+	}
+
+	chips := renderFilterChips(m.transactions.filterChips(), m.styles)
+	if got := headerWithChips(" ffiii-tui", chips, 30); lipgloss.Width(got) > 30 || !strings.Contains(got, "…") {
+		t.Errorf("expected chips cut to the width, got %q", got)
+	}
+	if got := headerWithChips(" ffiii-tui", "", 30); got != " ffiii-tui" {
+		t.Errorf("expected the header alone without filters, got %q", got)
+	}
+}
@@ -0,0 +1,40 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package formkit holds what the small overlay forms share, like the
+// transfer, asset account and recurrence forms.
+package formkit
+
+import "github.com/charmbracelet/lipgloss"
+
+type Styles struct {
+	Border lipgloss.Style
+	Title  lipgloss.Style
+	Desc   lipgloss.Style
+}
+
+func DefaultStyles() Styles {
+	return Styles{
+		Border: lipgloss.NewStyle().
+			BorderStyle(lipgloss.ThickBorder()).
+			BorderForeground(lipgloss.Color("#5F5FD7")),
+		Title: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#5F5FD7")),
+		Desc: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#8A8A8A")),
+	}
+}
+
+// Frame renders a form under its title, bordered to fill width and height.
+func (s Styles) Frame(title, form string, width, height int) string {
+	borderW, borderH := s.Border.GetFrameSize()
+	header := s.Title.Render(title) + s.Desc.Render("  (esc to cancel)")
+
+	return s.Border.
+		Width(max(width-borderW, 0)).
+		Height(max(height-borderH, 0)).
+		Render(header + "\n\n" + form)
+}
@@ -0,0 +1,75 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package formtest drives the overlay forms in tests the way the program
+// would.
+package formtest
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// pending is how long a command may run before it counts as still pending,
+// like a cursor blink.
+const pending = 20 * time.Millisecond
+
+// Harness drives a form model M.
+type Harness[M tea.Model] struct {
+	// Out reports the messages meant for the parent of the form, like its
+	// submit and close messages; they are returned and not fed back.
+	Out func(tea.Msg) bool
+}
+
+// Send updates m with msg and feeds the resulting messages back, the way
+// the program would. Commands still pending after a short wait are dropped.
+func (h Harness[M]) Send(m M, msg tea.Msg) (M, []tea.Msg) {
+	updated, cmd := m.Update(msg)
+	m = updated.(M)
+	var out []tea.Msg
+	for _, next := range Run(cmd) {
+		if h.Out(next) {
+			out = append(out, next)
+			continue
+		}
+		var more []tea.Msg
+		m, more = h.Send(m, next)
+		out = append(out, more...)
+	}
+	return m, out
+}
+
+// TypeText sends text to m as typed keys.
+func (h Harness[M]) TypeText(m M, text string) M {
+	m, _ = h.Send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text)})
+	return m
+}
+
+// Run runs cmd and returns its messages, those of a batch one by one.
+func Run(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	done := make(chan tea.Msg, 1)
+	go func() { done <- cmd() }()
+	var msg tea.Msg
+	select {
+	case msg = <-done:
+	case <-time.After(pending):
+		return nil
+	}
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		var msgs []tea.Msg
+		for _, c := range batch {
+			msgs = append(msgs, Run(c)...)
+		}
+		return msgs
+	}
+	if msg == nil {
+		return nil
+	}
+	return []tea.Msg{msg}
+}
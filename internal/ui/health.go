@@ -0,0 +1,63 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"ffiii-tui/internal/firefly"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"go.uber.org/zap"
+)
+
+// HealthCheckedMsg carries the result of the /about health check.
+type HealthCheckedMsg struct {
+	About firefly.About
+	Err   error
+}
+
+// checkHealth asks the server for its version.
+func (m *modelUI) checkHealth() tea.Cmd {
+	api := m.api
+	return func() tea.Msg {
+		about, err := api.GetAbout(context.Background())
+		if err != nil {
+			zap.L().Warn("Health check failed", zap.Error(err))
+		} else if !about.Supported() {
+			zap.L().Warn("Unsupported Firefly III version",
+				zap.String("version", about.Version),
+				zap.String("min_version", firefly.MinVersion))
+		}
+		return HealthCheckedMsg{About: about, Err: err}
+	}
+}
+
+// healthBanner warns that the server is unreachable or too old, it is
+// empty while the server is healthy.
+func (m *modelUI) healthBanner() string {
+	retry := m.keymap.Retry.Help().Key
+	var text string
+	switch {
+	case errors.Is(m.health.Err, firefly.ErrOffline):
+		text = fmt.Sprintf("Cannot reach Firefly III at %s, showing cached data (%s to retry)",
+			serverHost(m.api.ServerURL()), retry)
+	case m.health.Err != nil:
+		text = fmt.Sprintf("Firefly III health check failed: %s (%s to retry)", m.health.Err, retry)
+	case !m.health.About.Supported():
+		text = fmt.Sprintf("Firefly III %s is not supported, %s or later is required; some panels may not work",
+			m.health.About.Version, firefly.MinVersion)
+	default:
+		return ""
+	}
+
+	style := m.styles.BannerWarn
+	if m.health.Err != nil {
+		style = m.styles.BannerError
+	}
+	return style.Width(m.Width).MaxWidth(m.Width).Render(" " + text)
+}
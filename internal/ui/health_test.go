@@ -0,0 +1,117 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestHealth_NoBannerWhenHealthy(t *testing.T) {
+	m := newTestModelUI()
+	m.Width = 200
+
+	updated, _ := m.Update(HealthCheckedMsg{About: firefly.About{Version: "6.1.0"}})
+	m = updated.(modelUI)
+
+	if banner := m.healthBanner(); banner != "" {
+		t.Errorf("Expected no banner, got %q", banner)
+	}
+}
+
+func TestHealth_Banner(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  HealthCheckedMsg
+		want []string
+	}{
+		{
+			name: "unreachable",
+			msg:  HealthCheckedMsg{Err: fmt.Errorf("failed to get about: %w: dial tcp", firefly.ErrOffline)},
+			want: []string{"Cannot reach Firefly III at firefly.example.com", "R to retry"},
+		},
+		{
+			name: "failed",
+			msg:  HealthCheckedMsg{Err: errors.New("HTTP error: 401")},
+			want: []string{"health check failed: HTTP error: 401", "R to retry"},
+		},
+		{
+			name: "too old",
+			msg:  HealthCheckedMsg{About: firefly.About{Version: "5.7.18"}},
+			want: []string{"Firefly III 5.7.18 is not supported", firefly.MinVersion + " or later"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModelUI()
+			m.Width = 200
+
+			updated, cmd := m.Update(tt.msg)
+			m = updated.(modelUI)
+			if cmd == nil {
+				t.Error("Expected the layout to be recomputed")
+			}
+
+			view := m.View()
+			for _, want := range tt.want {
+				if !strings.Contains(view, want) {
+					t.Errorf("Expected view to contain %q", want)
+				}
+			}
+		})
+	}
+}
+
+func TestHealth_CheckedOnRefreshAll(t *testing.T) {
+	api := newTestUIAPI()
+	api.aboutFunc = func() (firefly.About, error) {
+		return firefly.About{}, firefly.ErrOffline
+	}
+	m := NewModelUI(api)
+
+	_, cmd := m.Update(RefreshAllMsg{})
+	msgs := collectMsgsFromCmd(cmd)
+
+	checked, ok := findMsg[HealthCheckedMsg](msgs)
+	if !ok {
+		t.Fatalf("Expected HealthCheckedMsg, got %v", msgs)
+	}
+	if !errors.Is(checked.Err, firefly.ErrOffline) {
+		t.Errorf("Expected ErrOffline, got %v", checked.Err)
+	}
+}
+
+func TestHealth_RetryKeyChecksAgain(t *testing.T) {
+	api := newTestUIAPI()
+	m := NewModelUI(api)
+	m.Width = 200
+
+	updated, _ := m.Update(HealthCheckedMsg{Err: firefly.ErrOffline})
+	m = updated.(modelUI)
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'R'}})
+	m = updated.(modelUI)
+	msgs := collectMsgsFromCmd(cmd)
+	if api.aboutCalled != 1 {
+		t.Errorf("Expected health check to run once, got %d", api.aboutCalled)
+	}
+
+	checked, ok := findMsg[HealthCheckedMsg](msgs)
+	if !ok {
+		t.Fatalf("Expected HealthCheckedMsg, got %v", msgs)
+	}
+	updated, _ = m.Update(checked)
+	m = updated.(modelUI)
+	if banner := m.healthBanner(); banner != "" {
+		t.Errorf("Expected banner to clear once the server is back, got %q", banner)
+	}
+}
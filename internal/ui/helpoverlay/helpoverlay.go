@@ -0,0 +1,250 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package helpoverlay
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Group is a titled set of key bindings, usually one per view.
+type Group struct {
+	Title    string
+	Bindings []key.Binding
+}
+
+// NewGroup flattens the full help of a key map into a group, skipping
+// disabled bindings.
+func NewGroup(title string, keymap help.KeyMap) Group {
+	g := Group{Title: title}
+	for _, column := range keymap.FullHelp() {
+		for _, b := range column {
+			if b.Enabled() {
+				g.Bindings = append(g.Bindings, b)
+			}
+		}
+	}
+	return g
+}
+
+type OpenMsg struct {
+	Groups []Group
+}
+
+type CloseMsg struct{}
+
+type Model struct {
+	groups []Group
+	search textinput.Model
+	offset int
+	focus  bool
+	styles Styles
+	Width  int
+	Height int
+}
+
+func New() Model {
+	search := textinput.New()
+	search.Prompt = "Search: "
+	search.Placeholder = "type to filter actions"
+
+	return Model{
+		search: search,
+		styles: DefaultStyles(),
+		Width:  80,
+		Height: 24,
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case OpenMsg:
+		m.groups = msg.Groups
+		m.offset = 0
+		m.search.SetValue("")
+		m.Focus()
+		return m, nil
+	case CloseMsg:
+		m.Blur()
+		return m, nil
+	}
+
+	if !m.focus {
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			if m.search.Value() != "" {
+				m.search.SetValue("")
+				m.offset = 0
+				return m, nil
+			}
+			return m, Close()
+		case "enter":
+			return m, Close()
+		case "up", "ctrl+k":
+			if m.offset > 0 {
+				m.offset--
+			}
+			return m, nil
+		case "down", "ctrl+j":
+			if m.offset < len(m.lines())-1 {
+				m.offset++
+			}
+			return m, nil
+		}
+		query := m.search.Value()
+		m.search, cmd = m.search.Update(msg)
+		if m.search.Value() != query {
+			m.offset = 0
+		}
+	}
+
+	return m, cmd
+}
+
+func (m Model) View() string {
+	if !m.focus {
+		return ""
+	}
+
+	borderW, borderH := m.styles.Border.GetFrameSize()
+	bodyHeight := max(m.Height-borderH-3, 1)
+
+	lines := m.lines()
+	if len(lines) == 0 {
+		lines = []string{m.styles.Empty.Render("No matching actions")}
+	}
+	offset := min(m.offset, len(lines)-1)
+	end := min(offset+bodyHeight, len(lines))
+
+	var b strings.Builder
+	b.WriteString(m.styles.Title.Render("Key bindings") +
+		m.styles.Desc.Render("  (esc to close, ↑/↓ to scroll)") + "\n")
+	b.WriteString(m.search.View() + "\n\n")
+	b.WriteString(strings.Join(lines[offset:end], "\n"))
+
+	return m.styles.Border.
+		Width(max(m.Width-borderW, 0)).
+		Height(max(m.Height-borderH, 0)).
+		Render(b.String())
+}
+
+// lines renders the groups with bindings matching the current query.
+func (m Model) lines() []string {
+	query := m.search.Value()
+
+	keyWidth := 0
+	for _, g := range m.groups {
+		for _, b := range g.Bindings {
+			keyWidth = max(keyWidth, lipgloss.Width(bindingKeys(b)))
+		}
+	}
+
+	var lines []string
+	for _, g := range m.groups {
+		var rows []string
+		for _, b := range g.Bindings {
+			if !Match(query, b.Help().Desc) {
+				continue
+			}
+			keys := bindingKeys(b)
+			rows = append(rows, "  "+
+				m.styles.Key.Render(keys+strings.Repeat(" ", keyWidth-lipgloss.Width(keys)))+
+				"  "+m.styles.Desc.Render(b.Help().Desc))
+		}
+		if len(rows) == 0 {
+			continue
+		}
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, m.styles.Group.Render(g.Title))
+		lines = append(lines, rows...)
+	}
+	return lines
+}
+
+// bindingKeys returns the keys actually bound, so remapped keys are shown
+// instead of the static help text.
+func bindingKeys(b key.Binding) string {
+	keys := b.Keys()
+	if len(keys) == 0 {
+		return b.Help().Key
+	}
+	return strings.Join(keys, "/")
+}
+
+// Match reports whether all runes of query appear in s in order, ignoring
+// case and spaces in the query.
+func Match(query, s string) bool {
+	target := []rune(strings.ToLower(s))
+	i := 0
+	for _, r := range strings.ToLower(query) {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		for i < len(target) && target[i] != r {
+			i++
+		}
+		if i == len(target) {
+			return false
+		}
+		i++
+	}
+	return true
+}
+
+func (m *Model) Focus() {
+	m.search.Focus()
+	m.focus = true
+}
+
+func (m *Model) Blur() {
+	m.search.Blur()
+	m.focus = false
+}
+
+func (m *Model) Focused() bool {
+	return m.focus
+}
+
+func (m *Model) WithSize(width, height int) *Model {
+	m.Width = width
+	m.Height = height
+	return m
+}
+
+func (m *Model) WithStyles(styles Styles) *Model {
+	m.styles = styles
+	return m
+}
+
+func Open(groups []Group) tea.Cmd {
+	return func() tea.Msg {
+		return OpenMsg{Groups: groups}
+	}
+}
+
+func Close() tea.Cmd {
+	return func() tea.Msg {
+		return CloseMsg{}
+	}
+}
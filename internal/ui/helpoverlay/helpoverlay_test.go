@@ -0,0 +1,171 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package helpoverlay
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func testGroups() []Group {
+	return []Group{
+		{
+			Title: "Transactions",
+			Bindings: []key.Binding{
+				key.NewBinding(key.WithKeys("D"), key.WithHelp("D", "delete")),
+				key.NewBinding(key.WithKeys("ctrl+n"), key.WithHelp("n", "new transaction")),
+			},
+		},
+		{
+			Title: "Global",
+			Bindings: []key.Binding{
+				key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "select period")),
+			},
+		},
+	}
+}
+
+func openModel(t *testing.T) Model {
+	t.Helper()
+	m := New()
+	updated, _ := m.Update(OpenMsg{Groups: testGroups()})
+	m = updated.(Model)
+	if !m.Focused() {
+		t.Fatal("Expected model to be focused after OpenMsg")
+	}
+	return m
+}
+
+func typeQuery(m Model, query string) Model {
+	for _, r := range query {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = updated.(Model)
+	}
+	return m
+}
+
+func TestNew(t *testing.T) {
+	m := New()
+
+	if m.Focused() {
+		t.Error("Expected new model to be unfocused")
+	}
+	if m.View() != "" {
+		t.Error("Expected empty view when unfocused")
+	}
+}
+
+func TestView_GroupsAndActualKeys(t *testing.T) {
+	m := openModel(t)
+	view := m.View()
+
+	for _, want := range []string{"Transactions", "Global", "delete", "select period"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected view to contain %q", want)
+		}
+	}
+	// Keys come from the binding, not from the help text
+	if !strings.Contains(view, "ctrl+n") {
+		t.Error("Expected remapped key 'ctrl+n' in view")
+	}
+}
+
+func TestNewGroup_SkipsDisabled(t *testing.T) {
+	km := testKeyMap{
+		enabled:  key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "enabled")),
+		disabled: key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "disabled"), key.WithDisabled()),
+	}
+
+	g := NewGroup("Test", km)
+	if len(g.Bindings) != 1 || g.Bindings[0].Help().Desc != "enabled" {
+		t.Errorf("Expected only the enabled binding, got %v", g.Bindings)
+	}
+}
+
+func TestUpdate_SearchFiltersBindings(t *testing.T) {
+	m := typeQuery(openModel(t), "slprd")
+	view := m.View()
+
+	if !strings.Contains(view, "select period") {
+		t.Error("Expected fuzzy match for 'select period'")
+	}
+	if strings.Contains(view, "delete") || strings.Contains(view, "Transactions") {
+		t.Error("Expected non-matching bindings and empty groups to be hidden")
+	}
+
+	m = typeQuery(m, "zzz")
+	if !strings.Contains(m.View(), "No matching actions") {
+		t.Error("Expected empty state when nothing matches")
+	}
+}
+
+func TestUpdate_EscClearsQueryThenCloses(t *testing.T) {
+	m := typeQuery(openModel(t), "del")
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if cmd != nil {
+		t.Error("Expected no command when clearing the query")
+	}
+	if m.search.Value() != "" {
+		t.Errorf("Expected query to be cleared, got %q", m.search.Value())
+	}
+
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd == nil {
+		t.Fatal("Expected close command")
+	}
+	if _, ok := cmd().(CloseMsg); !ok {
+		t.Error("Expected CloseMsg")
+	}
+}
+
+func TestUpdate_CloseMsgBlurs(t *testing.T) {
+	m := openModel(t)
+
+	updated, _ := m.Update(CloseMsg{})
+	m = updated.(Model)
+	if m.Focused() {
+		t.Error("Expected model to be blurred after CloseMsg")
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		query string
+		s     string
+		want  bool
+	}{
+		{"", "anything", true},
+		{"del", "delete", true},
+		{"DEL", "delete", true},
+		{"nt", "new transaction", true},
+		{"new tr", "new transaction", true},
+		{"tn", "new", false},
+		{"x", "delete", false},
+	}
+
+	for _, tt := range tests {
+		if got := Match(tt.query, tt.s); got != tt.want {
+			t.Errorf("Match(%q, %q) = %v, want %v", tt.query, tt.s, got, tt.want)
+		}
+	}
+}
+
+type testKeyMap struct {
+	enabled  key.Binding
+	disabled key.Binding
+}
+
+func (k testKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.enabled}
+}
+
+func (k testKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.enabled, k.disabled}}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"context"
+	"time"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/hooks"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"go.uber.org/zap"
+)
+
+// hookTransaction is a transaction as passed to hooks.
+type hookTransaction struct {
+	ID         string      `json:"id"`
+	Type       string      `json:"type"`
+	Date       string      `json:"date"`
+	GroupTitle string      `json:"group_title,omitempty"`
+	Splits     []hookSplit `json:"splits"`
+}
+
+type hookSplit struct {
+	Source          string   `json:"source"`
+	Destination     string   `json:"destination"`
+	Category        string   `json:"category,omitempty"`
+	Amount          float64  `json:"amount"`
+	Currency        string   `json:"currency,omitempty"`
+	ForeignAmount   float64  `json:"foreign_amount,omitempty"`
+	ForeignCurrency string   `json:"foreign_currency,omitempty"`
+	Description     string   `json:"description"`
+	Tags            []string `json:"tags,omitempty"`
+}
+
+type hookPeriod struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+func newHookTransaction(tx firefly.Transaction) hookTransaction {
+	h := hookTransaction{
+		ID:         tx.TransactionID,
+		Type:       tx.Type,
+		Date:       transactionDay(tx.Date),
+		GroupTitle: tx.GroupTitle,
+	}
+	for _, s := range tx.Splits {
+		h.Splits = append(h.Splits, hookSplit{
+			Source:          s.Source.Name,
+			Destination:     s.Destination.Name,
+			Category:        s.Category.Name,
+			Amount:          s.Amount,
+			Currency:        s.Currency,
+			ForeignAmount:   s.ForeignAmount,
+			ForeignCurrency: s.ForeignCurrency,
+			Description:     s.Description,
+			Tags:            s.Tags,
+		})
+	}
+	return h
+}
+
+// runHook runs the hook configured for the event in the background, nil
+// when there is none. Failures are logged only, hooks are the user's
+// scripts and must not get in the way.
+func runHook(event string, data any) tea.Cmd {
+	if hooks.Command(event) == "" {
+		return nil
+	}
+	e := hooks.Event{
+		Name:    event,
+		Profile: activeProfile(),
+		Time:    time.Now(),
+		Data:    data,
+	}
+	return func() tea.Msg {
+		if err := hooks.Run(context.Background(), e); err != nil {
+			zap.L().Warn("Hook failed", zap.String("event", event), zap.Error(err))
+		}
+		return nil
+	}
+}
+
+// periodHook runs the hook of a period change.
+func periodHook(start, end time.Time) tea.Cmd {
+	return runHook(hooks.PeriodChanged, hookPeriod{
+		Start: start.Format(time.DateOnly),
+		End:   end.Format(time.DateOnly),
+	})
+}
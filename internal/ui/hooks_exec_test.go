@@ -0,0 +1,163 @@
+//go:build !windows
+
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/hooks"
+
+	"github.com/spf13/viper"
+)
+
+// recordHook configures the hook of event to append its payload to a file,
+// and returns the transactions of the payloads recorded so far.
+func recordHook(t *testing.T, event string) func() []hookTransaction {
+	t.Helper()
+	out := filepath.Join(t.TempDir(), event+".jsonl")
+	viper.Set("hooks.on_"+event, "cat >> "+out+" && echo >> "+out)
+	t.Cleanup(func() { viper.Set("hooks.on_"+event, "") })
+
+	return func() []hookTransaction {
+		t.Helper()
+		data, err := os.ReadFile(out)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			t.Fatalf("failed to read the hook payloads: %v", err)
+		}
+		var txs []hookTransaction
+		for line := range strings.Lines(strings.TrimSpace(string(data))) {
+			var e struct {
+				Event string          `json:"event"`
+				Data  hookTransaction `json:"data"`
+			}
+			if err := json.Unmarshal([]byte(line), &e); err != nil {
+				t.Fatalf("invalid payload %q: %v", line, err)
+			}
+			if e.Event != event {
+				t.Errorf("expected a %s event, got %s", event, e.Event)
+			}
+			txs = append(txs, e.Data)
+		}
+		return txs
+	}
+}
+
+func TestCreateTransactionsMsg_RunsHookPerCreatedTransaction(t *testing.T) {
+	created := recordHook(t, hooks.TransactionCreated)
+	txs := []firefly.RequestTransaction{
+		{Transactions: []firefly.RequestTransactionSplit{{Type: "withdrawal", Date: "2026-03-01", Amount: "4.50", Description: "Coffee"}}},
+		{Transactions: []firefly.RequestTransactionSplit{{Type: "withdrawal", Date: "2026-03-02", Amount: "900", Description: "Rent"}}},
+		{Transactions: []firefly.RequestTransactionSplit{{Type: "deposit", Date: "2026-03-03", Amount: "3000", Description: "Salary"}}},
+	}
+	api := &mockTransactionAPI{
+		createTransactionsFunc: func(txs []firefly.RequestTransaction) firefly.BatchResult {
+			return firefly.BatchResult{
+				Total:   len(txs),
+				Created: []string{"11", "13"},
+				Sent:    []int{0, 2},
+				Failed:  []firefly.BatchFailure{{Index: 1, Description: "Rent", Err: os.ErrInvalid}},
+			}
+		},
+	}
+	m := NewModelTransactions(api)
+
+	_, cmd := m.Update(CreateTransactionsMsg{Transactions: txs})
+	collectMsgsFromCmd(cmd)
+
+	got := created()
+	if len(got) != 2 {
+		t.Fatalf("expected a hook per created transaction, got %+v", got)
+	}
+	if got[0].ID != "11" || got[0].Date != "2026-03-01" || got[0].Splits[0].Description != "Coffee" {
+		t.Errorf("unexpected first transaction %+v", got[0])
+	}
+	if got[1].ID != "13" || got[1].Type != "deposit" || got[1].Splits[0].Amount != 3000 {
+		t.Errorf("unexpected second transaction %+v", got[1])
+	}
+}
+
+func TestTransactions_AssignCategoryRunsUpdatedHook(t *testing.T) {
+	updated := recordHook(t, hooks.TransactionUpdated)
+	m := newFocusedTransactionModel(t, newUncategorizedTransactions())
+	api := m.api.(*mockTransactionAPI)
+	api.updateTransactionFunc = func(id string, _ firefly.RequestTransaction) (string, error) {
+		return id, nil
+	}
+	dining := firefly.Category{ID: "cat2", Name: "Dining"}
+	coffee := newUncategorizedTransactions()[2]
+
+	collectMsgsFromCmd(assignCategory(api, coffee, dining, ""))
+
+	got := updated()
+	if len(got) != 1 || got[0].ID != "tx3" {
+		t.Fatalf("expected a hook for the updated transaction, got %+v", got)
+	}
+	if got[0].Splits[0].Category != "Dining" {
+		t.Errorf("expected the assigned category in the hook, got %+v", got[0].Splits)
+	}
+}
+
+func TestTransactions_AssignCategoryFailedRunsNoHook(t *testing.T) {
+	updated := recordHook(t, hooks.TransactionUpdated)
+	m := newFocusedTransactionModel(t, newUncategorizedTransactions())
+	api := m.api.(*mockTransactionAPI)
+	api.updateTransactionFunc = func(string, firefly.RequestTransaction) (string, error) {
+		return "", os.ErrInvalid
+	}
+
+	collectMsgsFromCmd(assignCategory(api, m.shown[0], firefly.Category{ID: "cat2"}, ""))
+
+	if got := updated(); len(got) != 0 {
+		t.Errorf("expected no hook for a failed update, got %+v", got)
+	}
+}
+
+func TestBulkTag_RunsUpdatedHookPerUpdatedTransaction(t *testing.T) {
+	updated := recordHook(t, hooks.TransactionUpdated)
+	txs := []firefly.Transaction{
+		newTestTransaction(1, "tx1", "withdrawal", "2024-01-17T10:00:00Z", "Fuel"),
+		newTestTransaction(2, "tx2", "withdrawal", "2024-01-16T10:00:00Z", "Parking"),
+		newTestTransaction(3, "tx3", "withdrawal", "2024-01-15T10:00:00Z", "Toll"),
+		newTestTransaction(4, "tx4", "withdrawal", "2024-01-14T10:00:00Z", "Car wash"),
+	}
+	txs[1].Splits[0].Tags = []string{"car"}
+	api := &mockTransactionAPI{
+		updateTransactionFunc: func(id string, _ firefly.RequestTransaction) (string, error) {
+			if id == "tx3" {
+				return "", os.ErrInvalid
+			}
+			return id, nil
+		},
+	}
+
+	collectMsgsFromCmd(bulkTag(api, txs, "car", false))
+
+	got := updated()
+	ids := []string{}
+	for _, tx := range got {
+		ids = append(ids, tx.ID)
+		if !slices.Contains(tx.Splits[0].Tags, "car") {
+			t.Errorf("expected the new tag in the hook of %s, got %v", tx.ID, tx.Splits[0].Tags)
+		}
+	}
+	slices.Sort(ids)
+	if !slices.Equal(ids, []string{"tx1", "tx4"}) {
+		t.Errorf("expected a hook per updated transaction, got %v", ids)
+	}
+	if txs[0].Splits[0].Tags != nil {
+		t.Errorf("expected the marked transactions left as they are, got %v", txs[0].Splits[0].Tags)
+	}
+}
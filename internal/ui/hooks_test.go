@@ -0,0 +1,33 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"testing"
+
+	"ffiii-tui/internal/hooks"
+)
+
+func TestRunHook_NotConfigured(t *testing.T) {
+	if cmd := runHook(hooks.TransactionCreated, nil); cmd != nil {
+		t.Error("expected no command without a configured hook")
+	}
+}
+
+func TestNewHookTransaction(t *testing.T) {
+	tx := newTestTransaction(0, "tx7", "withdrawal", "2024-01-15T10:00:00Z", "Coffee")
+
+	h := newHookTransaction(tx)
+
+	if h.ID != "tx7" || h.Type != "withdrawal" || h.Date != "2024-01-15" {
+		t.Errorf("unexpected transaction %+v", h)
+	}
+	if len(h.Splits) != len(tx.Splits) {
+		t.Fatalf("expected %d splits, got %d", len(tx.Splits), len(h.Splits))
+	}
+	if h.Splits[0].Description != "Coffee" || h.Splits[0].Amount != tx.Splits[0].Amount {
+		t.Errorf("unexpected split %+v", h.Splits[0])
+	}
+}
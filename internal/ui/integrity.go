@@ -0,0 +1,111 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/integrity"
+	"ffiii-tui/internal/ui/loading"
+	"ffiii-tui/internal/ui/notify"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"go.uber.org/zap"
+)
+
+// integrityTolerance is the difference left to rounding.
+const integrityTolerance = 0.005
+
+// checkIntegrity loads all accounts and transactions, whatever the period,
+// and lists the accounts whose balance does not add up.
+func checkIntegrity(api BackupAPI) tea.Cmd {
+	return loading.Track("Checking data integrity...", func() tea.Msg {
+		backup, err := api.Backup(context.Background(), time.Now())
+		if err != nil {
+			return notify.NotifyWarn(fmt.Sprintf("Failed to check data integrity: %s", errorText(err)))()
+		}
+		discrepancies, checked := findDiscrepancies(backup)
+		zap.L().Info("Data integrity checked",
+			zap.Int("accounts", checked),
+			zap.Int("discrepancies", len(discrepancies)))
+		return integrity.OpenMsg{Discrepancies: discrepancies, Checked: checked}
+	})
+}
+
+// findDiscrepancies compares the balance of each asset and liability
+// account with its opening balance plus the transactions moving money in
+// and out of it. Opening balance transactions are left out, the opening
+// balance of the account stands for them. It returns the accounts that
+// differ, largest difference first, and how many were compared.
+func findDiscrepancies(backup firefly.Backup) ([]integrity.Discrepancy, int) {
+	type flow struct {
+		amount float64
+		count  int
+	}
+	flows := map[string]*flow{}
+	accounts := []firefly.Account{}
+	for _, a := range backup.Accounts {
+		if a.Type != "asset" && a.Type != "liabilities" {
+			continue
+		}
+		if _, ok := backup.Balances[a.ID]; !ok {
+			continue
+		}
+		accounts = append(accounts, a)
+		flows[a.ID] = &flow{}
+	}
+	currencies := map[string]string{}
+	for _, a := range accounts {
+		currencies[a.ID] = a.CurrencyCode
+	}
+
+	for _, tx := range backup.Transactions {
+		if tx.Type == "opening balance" {
+			continue
+		}
+		for _, s := range tx.Splits {
+			if f, ok := flows[s.Source.ID]; ok {
+				f.amount -= splitAmountIn(s, currencies[s.Source.ID])
+				f.count++
+			}
+			if f, ok := flows[s.Destination.ID]; ok {
+				f.amount += splitAmountIn(s, currencies[s.Destination.ID])
+				f.count++
+			}
+		}
+	}
+
+	discrepancies := []integrity.Discrepancy{}
+	for _, a := range accounts {
+		d := integrity.Discrepancy{
+			Account:      a,
+			Balance:      backup.Balances[a.ID],
+			Expected:     a.OpeningBalance + flows[a.ID].amount,
+			Transactions: flows[a.ID].count,
+		}
+		if math.Abs(d.Difference()) >= integrityTolerance {
+			discrepancies = append(discrepancies, d)
+		}
+	}
+	slices.SortStableFunc(discrepancies, func(a, b integrity.Discrepancy) int {
+		return cmp.Compare(math.Abs(b.Difference()), math.Abs(a.Difference()))
+	})
+	return discrepancies, len(accounts)
+}
+
+// splitAmountIn returns the amount of the split in the currency of the
+// account, the foreign amount when the split is in another currency.
+func splitAmountIn(s firefly.Split, currency string) float64 {
+	if currency != "" && s.Currency != currency && s.ForeignCurrency == currency {
+		return s.ForeignAmount
+	}
+	return s.Amount
+}
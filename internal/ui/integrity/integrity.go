@@ -0,0 +1,200 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package integrity
+
+import (
+	"fmt"
+	"strings"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/money"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Discrepancy is an account whose balance differs from its opening balance
+// plus the sum of its transactions.
+type Discrepancy struct {
+	Account firefly.Account
+	// Balance is what the server reports, Expected what the transactions add
+	// up to
+	Balance      float64
+	Expected     float64
+	Transactions int
+}
+
+// Difference is the amount the transactions miss, negative when they add
+// up to more than the balance.
+func (d Discrepancy) Difference() float64 {
+	return d.Balance - d.Expected
+}
+
+type OpenMsg struct {
+	Discrepancies []Discrepancy
+	// Checked is the number of accounts compared
+	Checked int
+}
+
+type CloseMsg struct{}
+
+type Model struct {
+	discrepancies []Discrepancy
+	checked       int
+	offset        int
+	focus         bool
+	styles        Styles
+	amounts       money.Formatter
+	Width         int
+	Height        int
+}
+
+func New(amounts money.Formatter) Model {
+	return Model{
+		styles:  DefaultStyles(),
+		amounts: amounts,
+		Width:   80,
+		Height:  24,
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case OpenMsg:
+		m.discrepancies = msg.Discrepancies
+		m.checked = msg.Checked
+		m.offset = 0
+		m.Focus()
+		return m, nil
+	case CloseMsg:
+		m.Blur()
+		return m, nil
+	}
+
+	if !m.focus {
+		return m, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc", "enter", "q":
+			return m, Close()
+		case "up", "k":
+			if m.offset > 0 {
+				m.offset--
+			}
+		case "down", "j":
+			if m.offset < len(m.discrepancies)-1 {
+				m.offset++
+			}
+		case "home", "g":
+			m.offset = 0
+		case "end", "G":
+			m.offset = max(len(m.discrepancies)-1, 0)
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) View() string {
+	if !m.focus {
+		return ""
+	}
+
+	borderW, borderH := m.styles.Border.GetFrameSize()
+	bodyHeight := max(m.Height-borderH-3, 1)
+
+	var b strings.Builder
+	b.WriteString(m.styles.Title.Render("Data integrity") +
+		m.styles.Desc.Render(fmt.Sprintf("  %d of %d accounts differ from their opening balance plus transactions (esc to close, ↑/↓ to scroll)",
+			len(m.discrepancies), m.checked)) + "\n")
+	b.WriteString(m.styles.Header.Render(fmt.Sprintf("%-28s  %14s  %14s  %14s  %s", "ACCOUNT", "BALANCE", "EXPECTED", "DIFFERENCE", "TRANSACTIONS")) + "\n")
+
+	if len(m.discrepancies) == 0 {
+		b.WriteString("\n" + m.styles.Empty.Render(fmt.Sprintf("All %d accounts match their transactions", m.checked)))
+	} else {
+		offset := min(m.offset, len(m.discrepancies)-1)
+		end := min(offset+bodyHeight, len(m.discrepancies))
+		rows := make([]string, 0, end-offset)
+		for _, d := range m.discrepancies[offset:end] {
+			rows = append(rows, m.row(d))
+		}
+		b.WriteString(strings.Join(rows, "\n"))
+	}
+
+	return m.styles.Border.
+		Width(max(m.Width-borderW, 0)).
+		Height(max(m.Height-borderH, 0)).
+		Render(b.String())
+}
+
+// signed formats a difference with its sign, e.g. +12.00 EUR.
+func (m Model) signed(amount float64, currency string) string {
+	if amount >= 0 {
+		return "+" + m.amounts.Format(amount, currency)
+	}
+	return m.amounts.Format(amount, currency)
+}
+
+// row renders a discrepancy, the difference stands out.
+func (m Model) row(d Discrepancy) string {
+	name := d.Account.Name
+	if len([]rune(name)) > 28 {
+		name = string([]rune(name)[:27]) + "…"
+	}
+	currency := d.Account.CurrencyCode
+	line := m.styles.Row.Render(fmt.Sprintf("%-28s  %14s  %14s  ",
+		name,
+		m.amounts.Format(d.Balance, currency),
+		m.amounts.Format(d.Expected, currency))) +
+		m.styles.Diff.Render(fmt.Sprintf("%14s", m.signed(d.Difference(), currency))) +
+		m.styles.Row.Render(fmt.Sprintf("  %d", d.Transactions))
+
+	borderW, _ := m.styles.Border.GetFrameSize()
+	if width := m.Width - borderW; width > 0 && lipgloss.Width(line) > width {
+		line = lipgloss.NewStyle().MaxWidth(width).Render(line)
+	}
+	return line
+}
+
+func (m *Model) Focus() {
+	m.focus = true
+}
+
+func (m *Model) Blur() {
+	m.focus = false
+}
+
+func (m *Model) Focused() bool {
+	return m.focus
+}
+
+func (m *Model) WithSize(width, height int) *Model {
+	m.Width = width
+	m.Height = height
+	return m
+}
+
+func (m *Model) WithStyles(styles Styles) *Model {
+	m.styles = styles
+	return m
+}
+
+func Open(discrepancies []Discrepancy, checked int) tea.Cmd {
+	return func() tea.Msg {
+		return OpenMsg{Discrepancies: discrepancies, Checked: checked}
+	}
+}
+
+func Close() tea.Cmd {
+	return func() tea.Msg {
+		return CloseMsg{}
+	}
+}
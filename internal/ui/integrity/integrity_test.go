@@ -0,0 +1,62 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package integrity
+
+import (
+	"strings"
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/money"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func openModel(t *testing.T, discrepancies []Discrepancy, checked int) Model {
+	t.Helper()
+	m := New(money.Formatter{})
+	updated, _ := m.Update(OpenMsg{Discrepancies: discrepancies, Checked: checked})
+	m = updated.(Model)
+	if !m.Focused() {
+		t.Fatal("Expected model to be focused after OpenMsg")
+	}
+	return m
+}
+
+func TestView_Discrepancies(t *testing.T) {
+	m := openModel(t, []Discrepancy{{
+		Account:      firefly.Account{Name: "Checking", CurrencyCode: "EUR"},
+		Balance:      1000,
+		Expected:     1023.5,
+		Transactions: 12,
+	}}, 3)
+	m.Width = 120
+	view := m.View()
+
+	for _, want := range []string{"Data integrity", "1 of 3 accounts", "Checking", "1000.00 EUR", "1023.50 EUR", "-23.50 EUR", "12"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected view to contain %q", want)
+		}
+	}
+}
+
+func TestView_AllMatch(t *testing.T) {
+	m := openModel(t, nil, 4)
+	if !strings.Contains(m.View(), "All 4 accounts match their transactions") {
+		t.Error("Expected the all clear hint")
+	}
+}
+
+func TestUpdate_Close(t *testing.T) {
+	m := openModel(t, nil, 1)
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd == nil {
+		t.Fatal("Expected a close command")
+	}
+	if _, ok := cmd().(CloseMsg); !ok {
+		t.Errorf("Expected CloseMsg, got %T", cmd())
+	}
+}
@@ -0,0 +1,39 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package integrity
+
+import "github.com/charmbracelet/lipgloss"
+
+type Styles struct {
+	Border lipgloss.Style
+	Title  lipgloss.Style
+	Header lipgloss.Style
+	Row    lipgloss.Style
+	Diff   lipgloss.Style
+	Desc   lipgloss.Style
+	Empty  lipgloss.Style
+}
+
+func DefaultStyles() Styles {
+	return Styles{
+		Border: lipgloss.NewStyle().
+			BorderStyle(lipgloss.ThickBorder()).
+			BorderForeground(lipgloss.Color("#5FAFD7")),
+		Title: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#5FAFD7")),
+		Header: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#5F87D7")),
+		Row: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#DDDADA")),
+		Diff: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FF5F5F")),
+		Desc: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#8A8A8A")),
+		Empty: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#585858")),
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/integrity"
+	"ffiii-tui/internal/ui/notify"
+)
+
+type stubBackupAPI struct {
+	backup firefly.Backup
+	err    error
+}
+
+func (s stubBackupAPI) Backup(_ context.Context, _ time.Time) (firefly.Backup, error) {
+	return s.backup, s.err
+}
+
+func integrityBackup() firefly.Backup {
+	checking := firefly.Account{ID: "1", Name: "Checking", Type: "asset", CurrencyCode: "EUR", OpeningBalance: 100}
+	savings := firefly.Account{ID: "2", Name: "Savings", Type: "asset", CurrencyCode: "USD"}
+	market := firefly.Account{ID: "3", Name: "Market", Type: "expense"}
+	initial := firefly.Account{ID: "4", Name: "Checking initial balance", Type: "initial-balance"}
+
+	return firefly.Backup{
+		Accounts: []firefly.Account{checking, savings, market, initial},
+		Balances: map[string]float64{"1": 50, "2": 110, "3": 0},
+		Transactions: []firefly.Transaction{
+			{Type: "opening balance", Splits: []firefly.Split{{Source: initial, Destination: checking, Amount: 100, Currency: "EUR"}}},
+			{Type: "withdrawal", Splits: []firefly.Split{{Source: checking, Destination: market, Amount: 30, Currency: "EUR"}}},
+			{Type: "transfer", Splits: []firefly.Split{{
+				Source: checking, Destination: savings,
+				Amount: 100, Currency: "EUR",
+				ForeignAmount: 110, ForeignCurrency: "USD",
+			}}},
+		},
+	}
+}
+
+func TestFindDiscrepancies(t *testing.T) {
+	discrepancies, checked := findDiscrepancies(integrityBackup())
+
+	if checked != 2 {
+		t.Errorf("expected the 2 asset accounts checked, got %d", checked)
+	}
+	if len(discrepancies) != 1 {
+		t.Fatalf("expected 1 discrepancy, got %+v", discrepancies)
+	}
+	d := discrepancies[0]
+	if d.Account.Name != "Checking" || d.Expected != -30 || d.Balance != 50 || d.Transactions != 2 {
+		t.Errorf("unexpected discrepancy %+v", d)
+	}
+	if d.Difference() != 80 {
+		t.Errorf("expected a difference of 80, got %v", d.Difference())
+	}
+}
+
+func TestFindDiscrepancies_LargestFirst(t *testing.T) {
+	backup := integrityBackup()
+	backup.Balances["1"] = -30
+	backup.Balances["2"] = 100
+	backup.Balances["5"] = 0
+	backup.Accounts = append(backup.Accounts, firefly.Account{ID: "5", Name: "Loan", Type: "liabilities", OpeningBalance: -500})
+
+	discrepancies, checked := findDiscrepancies(backup)
+	if checked != 3 || len(discrepancies) != 2 {
+		t.Fatalf("expected 2 of 3 accounts to differ, got %d of %d", len(discrepancies), checked)
+	}
+	if discrepancies[0].Account.Name != "Loan" || discrepancies[1].Account.Name != "Savings" {
+		t.Errorf("expected the largest difference first, got %s, %s",
+			discrepancies[0].Account.Name, discrepancies[1].Account.Name)
+	}
+}
+
+func TestCheckIntegrity(t *testing.T) {
+	msg := trackedMsg(checkIntegrity(stubBackupAPI{backup: integrityBackup()}))
+
+	open, ok := msg.(integrity.OpenMsg)
+	if !ok {
+		t.Fatalf("expected integrity.OpenMsg, got %T", msg)
+	}
+	if open.Checked != 2 || len(open.Discrepancies) != 1 {
+		t.Errorf("unexpected result %+v", open)
+	}
+}
+
+func TestCheckIntegrity_Error(t *testing.T) {
+	msg := trackedMsg(checkIntegrity(stubBackupAPI{err: errors.New("boom")}))
+
+	note, ok := msg.(notify.NotifyMsg)
+	if !ok || note.Level != notify.Warn {
+		t.Errorf("expected a warning, got %#v", msg)
+	}
+}
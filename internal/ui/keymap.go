@@ -12,7 +12,34 @@ type UIKeyMap struct {
 	Quit          key.Binding
 	ShowShortHelp key.Binding
 
-	PeriodPicker key.Binding
+	PeriodPicker  key.Binding
+	SwitchProfile key.Binding
+	APILog        key.Binding
+	Retry         key.Binding
+	ExportReport  key.Binding
+	QuickTransfer key.Binding
+	PayBill       key.Binding
+	Deleted       key.Binding
+	Notifications key.Binding
+	Integrity     key.Binding
+	Zoom          key.Binding
+}
+
+// VimKeyMap is an alternate navigation layer enabled with ui.vim_mode.
+// Its bindings are translated into the default table/list keys and may be
+// prefixed with a numeric count (e.g. 5j).
+type VimKeyMap struct {
+	Down     key.Binding
+	Up       key.Binding
+	PageDown key.Binding
+	PageUp   key.Binding
+	Top      key.Binding
+	Bottom   key.Binding
+}
+
+// PanelKeyMap is shown for the panels added with the panel package.
+type PanelKeyMap struct {
+	Back key.Binding
 }
 
 type AccountKeyMap struct {
@@ -25,12 +52,16 @@ type AccountKeyMap struct {
 	ViewExpenses     key.Binding
 	ViewRevenues     key.Binding
 	ViewLiabilities  key.Binding
+	ViewTags         key.Binding
 	Filter           key.Binding
 	FilterBy         key.Binding
 	ResetFilter      key.Binding
 	Sort             key.Binding
 	New              key.Binding
 	Select           key.Binding
+	Details          key.Binding
+	PayCard          key.Binding
+	OpenInWeb        key.Binding
 }
 
 type CategoryKeyMap struct {
@@ -42,6 +73,31 @@ type CategoryKeyMap struct {
 	New          key.Binding
 	Refresh      key.Binding
 	Sort         key.Binding
+	Details      key.Binding
+	OpenInWeb    key.Binding
+	Budgets      key.Binding
+	Envelopes    key.Binding
+
+	ViewTransactions key.Binding
+	ViewAssets       key.Binding
+	ViewCategories   key.Binding
+	ViewExpenses     key.Binding
+	ViewRevenues     key.Binding
+	ViewLiabilities  key.Binding
+	ViewTags         key.Binding
+}
+
+type TagKeyMap struct {
+	ShowFullHelp key.Binding
+	Quit         key.Binding
+	Filter       key.Binding
+	FilterBy     key.Binding
+	ResetFilter  key.Binding
+	Rename       key.Binding
+	Delete       key.Binding
+	Refresh      key.Binding
+	Sort         key.Binding
+	OpenInWeb    key.Binding
 
 	ViewTransactions key.Binding
 	ViewAssets       key.Binding
@@ -60,6 +116,8 @@ type TransactionFormKeyMap struct {
 	AddSplit      key.Binding
 	DeleteSplit   key.Binding
 	ChangeLayout  key.Binding
+	Cash          key.Binding
+	CopyCategory  key.Binding
 }
 
 type TransactionsKeyMap struct {
@@ -68,18 +126,43 @@ type TransactionsKeyMap struct {
 	Refresh            key.Binding
 	Filter             key.Binding
 	ResetFilter        key.Binding
+	RemoveFilter       key.Binding
+	Unreconciled       key.Binding
+	Uncategorized      key.Binding
+	AssignCategory     key.Binding
+	TypeFilter         key.Binding
+	DateRange          key.Binding
 	Search             key.Binding
+	Open               key.Binding
 	NewView            key.Binding
 	Select             key.Binding
 	NewTransactionFrom key.Binding
 	Delete             key.Binding
+	OpenInWeb          key.Binding
+	ToggleSplits       key.Binding
 	ToggleFullView     key.Binding
+	Mark               key.Binding
+	ShowCells          key.Binding
+	ScrollLeft         key.Binding
+	ScrollRight        key.Binding
+	BulkTag            key.Binding
+	RepeatMonthly      key.Binding
 
 	ViewAssets      key.Binding
 	ViewCategories  key.Binding
 	ViewExpenses    key.Binding
 	ViewRevenues    key.Binding
 	ViewLiabilities key.Binding
+	ViewTags        key.Binding
+}
+
+func DefaultPanelKeyMap() PanelKeyMap {
+	return PanelKeyMap{
+		Back: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "go back"),
+		),
+	}
 }
 
 func DefaultUIKeyMap() UIKeyMap {
@@ -96,6 +179,75 @@ func DefaultUIKeyMap() UIKeyMap {
 			key.WithKeys("p"),
 			key.WithHelp("p", "period picker"),
 		),
+		SwitchProfile: key.NewBinding(
+			key.WithKeys("P"),
+			key.WithHelp("P", "switch profile"),
+		),
+		APILog: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("L", "API log"),
+		),
+		ExportReport: key.NewBinding(
+			key.WithKeys("E"),
+			key.WithHelp("E", "export report"),
+		),
+		Retry: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "retry failed loads"),
+		),
+		QuickTransfer: key.NewBinding(
+			key.WithKeys("M"),
+			key.WithHelp("M", "move money between own accounts"),
+		),
+		PayBill: key.NewBinding(
+			key.WithKeys("$"),
+			key.WithHelp("$", "pay a bill due soon"),
+		),
+		Deleted: key.NewBinding(
+			key.WithKeys("H"),
+			key.WithHelp("H", "deleted transactions"),
+		),
+		Notifications: key.NewBinding(
+			key.WithKeys("O"),
+			key.WithHelp("O", "notifications, open what they refer to"),
+		),
+		Integrity: key.NewBinding(
+			key.WithKeys("I"),
+			key.WithHelp("I", "check balances against transactions"),
+		),
+		Zoom: key.NewBinding(
+			key.WithKeys("z"),
+			key.WithHelp("z", "zoom the focused panel"),
+		),
+	}
+}
+
+func DefaultVimKeyMap() VimKeyMap {
+	return VimKeyMap{
+		Down: key.NewBinding(
+			key.WithKeys("j"),
+			key.WithHelp("[n]j", "down"),
+		),
+		Up: key.NewBinding(
+			key.WithKeys("k"),
+			key.WithHelp("[n]k", "up"),
+		),
+		PageDown: key.NewBinding(
+			key.WithKeys("ctrl+d"),
+			key.WithHelp("[n]ctrl+d", "page down"),
+		),
+		PageUp: key.NewBinding(
+			key.WithKeys("ctrl+u"),
+			key.WithHelp("[n]ctrl+u", "page up"),
+		),
+		Top: key.NewBinding(
+			key.WithKeys("g"),
+			key.WithHelp("gg", "go to top"),
+		),
+		Bottom: key.NewBinding(
+			key.WithKeys("G"),
+			key.WithHelp("[n]G", "go to bottom/line n"),
+		),
 	}
 }
 
@@ -137,6 +289,10 @@ func DefaultAccountKeyMap() AccountKeyMap {
 			key.WithKeys("o"),
 			key.WithHelp("o", "view liabilities"),
 		),
+		ViewTags: key.NewBinding(
+			key.WithKeys("#"),
+			key.WithHelp("#", "view tags"),
+		),
 		ResetFilter: key.NewBinding(
 			key.WithKeys("ctrl+a"),
 			key.WithHelp("ctrl+a", "reset filter"),
@@ -155,12 +311,24 @@ func DefaultAccountKeyMap() AccountKeyMap {
 		),
 		FilterBy: key.NewBinding(
 			key.WithKeys("f"),
-			key.WithHelp("f", "filter by account (press twice for exclusive)"),
+			key.WithHelp("f", "filter by account"),
 		),
 		Select: key.NewBinding(
 			key.WithKeys("enter"),
 			key.WithHelp("enter", "select asset"),
 		),
+		Details: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "view details"),
+		),
+		PayCard: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "pay credit card"),
+		),
+		OpenInWeb: key.NewBinding(
+			key.WithKeys("w"),
+			key.WithHelp("w", "open in web UI"),
+		),
 	}
 }
 
@@ -180,7 +348,7 @@ func DefaultCategoryKeyMap() CategoryKeyMap {
 		),
 		FilterBy: key.NewBinding(
 			key.WithKeys("f"),
-			key.WithHelp("f", "filter by category (press twice for exclusive)"),
+			key.WithHelp("f", "filter by category"),
 		),
 		ResetFilter: key.NewBinding(
 			key.WithKeys("ctrl+a"),
@@ -198,6 +366,22 @@ func DefaultCategoryKeyMap() CategoryKeyMap {
 			key.WithKeys("s"),
 			key.WithHelp("s", "sort categories"),
 		),
+		Details: key.NewBinding(
+			key.WithKeys("enter", "v"),
+			key.WithHelp("enter/v", "view monthly history"),
+		),
+		OpenInWeb: key.NewBinding(
+			key.WithKeys("w"),
+			key.WithHelp("w", "open in web UI"),
+		),
+		Budgets: key.NewBinding(
+			key.WithKeys("b"),
+			key.WithHelp("b", "propose next budget limits"),
+		),
+		Envelopes: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "toggle envelopes: left per budget"),
+		),
 		ViewTransactions: key.NewBinding(
 			key.WithKeys("t"),
 			key.WithHelp("t", "view transactions"),
@@ -223,6 +407,79 @@ func DefaultCategoryKeyMap() CategoryKeyMap {
 			key.WithKeys("o"),
 			key.WithHelp("o", "view liabilities"),
 		),
+		ViewTags: key.NewBinding(
+			key.WithKeys("#"),
+			key.WithHelp("#", "view tags"),
+		),
+	}
+}
+
+func DefaultTagKeyMap() TagKeyMap {
+	return TagKeyMap{
+		ShowFullHelp: key.NewBinding(
+			key.WithKeys("?"),
+			key.WithHelp("?", "toggle help"),
+		),
+		Quit: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "go back"),
+		),
+		Filter: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "filter tag"),
+		),
+		FilterBy: key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("f", "filter by tag"),
+		),
+		ResetFilter: key.NewBinding(
+			key.WithKeys("ctrl+a"),
+			key.WithHelp("ctrl+a", "reset filter"),
+		),
+		Rename: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "rename tag"),
+		),
+		Delete: key.NewBinding(
+			key.WithKeys("D"),
+			key.WithHelp("D", "delete tag"),
+		),
+		Refresh: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "refresh tags"),
+		),
+		Sort: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "rank by spent"),
+		),
+		OpenInWeb: key.NewBinding(
+			key.WithKeys("w"),
+			key.WithHelp("w", "open in web UI"),
+		),
+		ViewTransactions: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "view transactions"),
+		),
+		ViewAssets: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "view assets"),
+		),
+		ViewCategories: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "view categories"),
+		),
+		ViewExpenses: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "view expenses"),
+		),
+		ViewRevenues: key.NewBinding(
+			key.WithKeys("i"),
+			key.WithHelp("i", "view revenues"),
+		),
+		ViewLiabilities: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "view liabilities"),
+		),
 	}
 }
 
@@ -260,6 +517,14 @@ func DefaultTransactionFormKeyMap() TransactionFormKeyMap {
 			key.WithKeys("ctrl+f"),
 			key.WithHelp("ctrl+f", "toggle layout (for many splits)"),
 		),
+		Cash: key.NewBinding(
+			key.WithKeys("ctrl+t"),
+			key.WithHelp("ctrl+t", "pay in cash"),
+		),
+		CopyCategory: key.NewBinding(
+			key.WithKeys("ctrl+g"),
+			key.WithHelp("ctrl+g", "first split's category to all"),
+		),
 	}
 }
 
@@ -279,16 +544,44 @@ func DefaultTransactionsKeyMap() TransactionsKeyMap {
 		),
 		Filter: key.NewBinding(
 			key.WithKeys("/"),
-			key.WithHelp("/", "filter transactions (press twice for exclusive)"),
+			key.WithHelp("/", "filter transactions (#tag for tags)"),
 		),
 		ResetFilter: key.NewBinding(
 			key.WithKeys("ctrl+a"),
 			key.WithHelp("ctrl+a", "reset filter"),
 		),
+		RemoveFilter: key.NewBinding(
+			key.WithKeys("backspace"),
+			key.WithHelp("backspace", "remove last filter"),
+		),
+		Unreconciled: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "only unreconciled"),
+		),
+		Uncategorized: key.NewBinding(
+			key.WithKeys("U"),
+			key.WithHelp("U", "cycle uncategorized filter"),
+		),
+		AssignCategory: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", "assign category"),
+		),
+		TypeFilter: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", "cycle type filter"),
+		),
+		DateRange: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", "narrow date range"),
+		),
 		Search: key.NewBinding(
 			key.WithKeys("s"),
 			key.WithHelp("s", "search transactions"),
 		),
+		Open: key.NewBinding(
+			key.WithKeys("J"),
+			key.WithHelp("J", "jump to transaction by ID or URL"),
+		),
 		NewView: key.NewBinding(
 			key.WithKeys("n"),
 			key.WithHelp("n", "new transaction"),
@@ -305,6 +598,38 @@ func DefaultTransactionsKeyMap() TransactionsKeyMap {
 			key.WithKeys("D"),
 			key.WithHelp("D", "delete transaction"),
 		),
+		OpenInWeb: key.NewBinding(
+			key.WithKeys("w"),
+			key.WithHelp("w", "open in web UI"),
+		),
+		ToggleSplits: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "expand/collapse splits"),
+		),
+		Mark: key.NewBinding(
+			key.WithKeys(" "),
+			key.WithHelp("space", "mark transaction"),
+		),
+		ShowCells: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "show full values"),
+		),
+		ScrollLeft: key.NewBinding(
+			key.WithKeys("left", "h"),
+			key.WithHelp("←/h", "scroll left"),
+		),
+		ScrollRight: key.NewBinding(
+			key.WithKeys("right", "l"),
+			key.WithHelp("→/l", "scroll right"),
+		),
+		BulkTag: key.NewBinding(
+			key.WithKeys("B"),
+			key.WithHelp("B", "tag marked transactions"),
+		),
+		RepeatMonthly: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "repeat monthly…"),
+		),
 		ToggleFullView: key.NewBinding(
 			key.WithKeys("t"),
 			key.WithHelp("t", "toggle full view"),
@@ -329,6 +654,10 @@ func DefaultTransactionsKeyMap() TransactionsKeyMap {
 			key.WithKeys("o"),
 			key.WithHelp("o", "view liabilities"),
 		),
+		ViewTags: key.NewBinding(
+			key.WithKeys("#"),
+			key.WithHelp("#", "view tags"),
+		),
 	}
 }
 
@@ -340,6 +669,23 @@ func (k UIKeyMap) ShortHelp() []key.Binding {
 	}
 }
 
+func (k VimKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{
+		k.Down,
+		k.Up,
+		k.PageDown,
+		k.PageUp,
+		k.Top,
+		k.Bottom,
+	}
+}
+
+func (k PanelKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{
+		k.Back,
+	}
+}
+
 func (k AccountKeyMap) ShortHelp() []key.Binding {
 	return []key.Binding{
 		k.ShowFullHelp,
@@ -349,8 +695,11 @@ func (k AccountKeyMap) ShortHelp() []key.Binding {
 		k.Sort,
 		k.ResetFilter,
 		k.Select,
+		k.Details,
+		k.PayCard,
 		k.New,
 		k.Refresh,
+		k.OpenInWeb,
 	}
 }
 
@@ -364,6 +713,25 @@ func (k CategoryKeyMap) ShortHelp() []key.Binding {
 		k.New,
 		k.Refresh,
 		k.Sort,
+		k.Details,
+		k.OpenInWeb,
+		k.Budgets,
+		k.Envelopes,
+	}
+}
+
+func (k TagKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{
+		k.ShowFullHelp,
+		k.Quit,
+		k.Filter,
+		k.FilterBy,
+		k.ResetFilter,
+		k.Rename,
+		k.Delete,
+		k.Refresh,
+		k.Sort,
+		k.OpenInWeb,
 	}
 }
 
@@ -375,10 +743,25 @@ func (k TransactionsKeyMap) ShortHelp() []key.Binding {
 		k.Search,
 		k.Filter,
 		k.ResetFilter,
+		k.RemoveFilter,
+		k.Unreconciled,
+		k.Uncategorized,
+		k.AssignCategory,
+		k.TypeFilter,
+		k.DateRange,
+		k.Open,
 		k.NewView,
 		k.NewTransactionFrom,
 		k.Select,
 		k.Delete,
+		k.OpenInWeb,
+		k.ToggleSplits,
+		k.Mark,
+		k.ShowCells,
+		k.ScrollLeft,
+		k.ScrollRight,
+		k.BulkTag,
+		k.RepeatMonthly,
 		k.Refresh,
 	}
 }
@@ -387,6 +770,8 @@ func (k TransactionFormKeyMap) ShortHelp() []key.Binding {
 	return []key.Binding{
 		k.AddSplit,
 		k.DeleteSplit,
+		k.Cash,
+		k.CopyCategory,
 		k.Submit,
 		k.Cancel,
 		k.Reset,
@@ -400,10 +785,32 @@ func (k UIKeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{
 			k.PeriodPicker,
+			k.SwitchProfile,
+			k.APILog,
+			k.Retry,
+			k.ExportReport,
+			k.QuickTransfer,
+			k.PayBill,
+			k.Deleted,
+			k.Notifications,
+			k.Integrity,
+			k.Zoom,
 		},
 	}
 }
 
+func (k VimKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		k.ShortHelp(),
+	}
+}
+
+func (k PanelKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		k.ShortHelp(),
+	}
+}
+
 func (k AccountKeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		k.ShortHelp(),
@@ -416,6 +823,12 @@ func (k CategoryKeyMap) FullHelp() [][]key.Binding {
 	}
 }
 
+func (k TagKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		k.ShortHelp(),
+	}
+}
+
 func (k TransactionsKeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		k.ShortHelp(),
@@ -4,6 +4,22 @@ SPDX-License-Identifier: Apache-2.0
 */
 package ui
 
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// The smallest terminal the panels fit in. Below it the UI shows a notice
+// until the terminal is resized.
+const (
+	minWidth  = 60
+	minHeight = 16
+)
+
+// LayoutConfig holds the sizes the panels are laid out with. The root
+// model owns it and computes it on UpdatePositions, the panels get a copy
+// with the message and only read it.
 type LayoutConfig struct {
 	TopSize             int
 	LeftSize            int
@@ -125,3 +141,80 @@ func (lc *LayoutConfig) ToggleFullTransactionView() bool {
 	lc.FullTransactionView = !lc.FullTransactionView
 	return lc.FullTransactionView
 }
+
+// updateLayout computes the layout for the size and full view setting of
+// the message, or the current ones when it carries none, and returns the
+// message to pass to the panels.
+func (m *modelUI) updateLayout(msg UpdatePositions) UpdatePositions {
+	layout := *m.layout
+	if msg.layout != nil {
+		layout.FullTransactionView = msg.layout.FullTransactionView
+		if msg.layout.Width != 0 {
+			layout.Width, layout.Height = msg.layout.Width, msg.layout.Height
+		}
+	}
+
+	h, _ := m.styles.Base.GetFrameSize()
+	m.Width = layout.Width - h
+
+	// Header (3), status bar, notification and help lines
+	layout.TopSize = 6
+	if m.help.ShowAll {
+		layout.TopSize += lipgloss.Height(m.HelpView())
+	}
+	if banner := m.healthBanner(); banner != "" {
+		layout.TopSize += lipgloss.Height(banner)
+	}
+	layout.SummarySize = m.summary.height()
+
+	layout.LeftSize = 0
+	layout.TabBarSize = 2
+	tabBarWidth := lipgloss.Width(m.tabBar())
+	switch m.state {
+	case transactionsView, assetsView:
+		if !layout.FullTransactionView {
+			layout.LeftSize = max(
+				lipgloss.Width(m.assets.View()),
+				lipgloss.Width(m.summary.View()),
+				tabBarWidth,
+			) + h
+		} else {
+			layout.TabBarSize = 0
+		}
+	case categoriesView:
+		layout.LeftSize = max(lipgloss.Width(m.categories.View()), tabBarWidth) + h
+	case tagsView:
+		layout.LeftSize = max(lipgloss.Width(m.tags.View()), tabBarWidth) + h
+	case expensesView:
+		layout.LeftSize = max(lipgloss.Width(m.expenses.View()), tabBarWidth) + h
+	case revenuesView:
+		layout.LeftSize = max(lipgloss.Width(m.revenues.View()), tabBarWidth) + h
+	case liabilitiesView:
+		layout.LeftSize = max(lipgloss.Width(m.liabilities.View()), tabBarWidth) + h
+	}
+	if m.isZoomed() {
+		layout.TabBarSize = 0
+	}
+	m.layout = &layout
+
+	// Too small a terminal shows a notice, the panels keep the smallest
+	// size they fit in so none ends up with a negative one
+	panels := layout
+	panels.Width = max(panels.Width, minWidth)
+	panels.Height = max(panels.Height, minHeight)
+	return UpdatePositions{layout: &panels}
+}
+
+// tooSmall reports whether the terminal is below the smallest size.
+func (m modelUI) tooSmall() bool {
+	return m.layout.GetWidth() < minWidth || m.layout.GetHeight() < minHeight
+}
+
+// tooSmallView asks for a larger terminal, in place of every view.
+func (m modelUI) tooSmallView() string {
+	width, height := m.layout.GetWidth(), m.layout.GetHeight()
+	notice := m.styles.NotifyWarn.Render(
+		fmt.Sprintf("Terminal too small (need at least %dx%d, have %dx%d)", minWidth, minHeight, width, height))
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center,
+		lipgloss.NewStyle().Width(width).Align(lipgloss.Center).Render(notice))
+}
@@ -5,11 +5,13 @@ SPDX-License-Identifier: Apache-2.0
 package ui
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"strings"
 
 	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/money"
 	"ffiii-tui/internal/ui/notify"
 	"ffiii-tui/internal/ui/prompt"
 
@@ -33,18 +35,15 @@ type (
 type liabilityItem = accountListItem[firefly.Account]
 
 type modelLiabilities struct {
-	AccountListModel[firefly.Account]
+	AccountListModel[firefly.Account, LiabilityAPI]
 }
 
 func newModelLiabilities(api LiabilityAPI) modelLiabilities {
-	config := &AccountListConfig[firefly.Account]{
-		AccountType: "liability",
+	config := &AccountListConfig[firefly.Account, LiabilityAPI]{
+		AccountType: "liabilities",
 		Title:       "Liabilities",
-		GetItems: func(apiInterface any, sorted bool) []list.Item {
-			return getLiabilitiesItems(apiInterface.(LiabilityAPI))
-		},
-		RefreshItems: func(apiInterface any, accountType string) error {
-			return apiInterface.(LiabilityAPI).UpdateAccounts(accountType)
+		GetItems: func(api LiabilityAPI, sorted bool) []list.Item {
+			return getLiabilitiesItems(api)
 		},
 		RefreshMsgType: RefreshLiabilitiesMsg{},
 		UpdateMsgType:  LiabilitiesUpdateMsg{},
@@ -69,6 +68,7 @@ func newModelLiabilities(api LiabilityAPI) modelLiabilities {
 			cmds = append(cmds, SetView(transactionsView))
 			return tea.Sequence(cmds...)
 		},
+		DetailsFunc: liabilityDetails(api),
 	}
 	return modelLiabilities{
 		AccountListModel: NewAccountListModel(api, config),
@@ -81,8 +81,7 @@ func (m modelLiabilities) Init() tea.Cmd {
 
 func (m modelLiabilities) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if newMsg, ok := msg.(NewLiabilityMsg); ok {
-		api := m.api.(LiabilityAPI)
-		err := api.CreateLiabilityAccount(
+		err := m.api.CreateLiabilityAccount(context.Background(),
 			firefly.NewLiability{
 				Name:         newMsg.Account,
 				CurrencyCode: newMsg.Currency,
@@ -90,7 +89,7 @@ func (m modelLiabilities) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				Direction:    newMsg.Direction,
 			})
 		if err != nil {
-			return m, notify.NotifyWarn(err.Error())
+			return m, notify.NotifyWarn(errorText(err))
 		}
 		// Reset prompt on accaunt creation
 		promptValue = ""
@@ -100,24 +99,29 @@ func (m modelLiabilities) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		)
 	}
 	updated, cmd := m.AccountListModel.Update(msg)
-	m.AccountListModel = updated.(AccountListModel[firefly.Account])
+	m.AccountListModel = updated.(AccountListModel[firefly.Account, LiabilityAPI])
 	return m, cmd
 }
 
-func getLiabilitiesItems(api AccountsAPI) []list.Item {
+func getLiabilitiesItems(api LiabilityAPI) []list.Item {
 	items := []list.Item{}
-	for _, account := range api.AccountsByType("liabilities") {
+	data := fromStore[accountsReader](api)
+	amounts := money.New(api)
+	for _, account := range data.AccountsByType("liabilities") {
 		label := "They owe us"
-		balance := api.AccountBalance(account.ID)
+		balance := data.AccountBalance(account.ID)
 		if account.LiabilityDirection == "debit" {
 			label = "We owe"
 			balance = (-1) * balance
 		}
-		items = append(items, newAccountListItem(
+		item := convertedToPrimary(newAccountListItem(
 			account,
 			label,
 			balance,
-		))
+			amounts,
+		), api)
+		item.extra = interestSummary(account)
+		items = append(items, item)
 	}
 	return items
 }
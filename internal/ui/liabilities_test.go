@@ -6,8 +6,11 @@ SPDX-License-Identifier: Apache-2.0
 package ui
 
 import (
+	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"ffiii-tui/internal/firefly"
 	"ffiii-tui/internal/ui/notify"
@@ -21,12 +24,15 @@ type mockLiabilityAPI struct {
 	updateAccountsFunc         func(accountType string) error
 	accountsByTypeFunc         func(accountType string) []firefly.Account
 	accountBalanceFunc         func(accountID string) float64
+	accountActivityFunc        func(accountID string) string
+	convertToPrimaryFunc       func(amount float64, currencyCode string) (float64, bool)
 	createLiabilityAccountFunc func(nl firefly.NewLiability) error
+	accountTransactionsFunc    func(accountID string) ([]firefly.Transaction, error)
 	updateAccountsCalledWith   []string
 	createLiabilityCalledWith  []firefly.NewLiability
 }
 
-func (m *mockLiabilityAPI) UpdateAccounts(accountType string) error {
+func (m *mockLiabilityAPI) UpdateAccounts(_ context.Context, accountType string) error {
 	m.updateAccountsCalledWith = append(m.updateAccountsCalledWith, accountType)
 	if m.updateAccountsFunc != nil {
 		return m.updateAccountsFunc(accountType)
@@ -48,7 +54,29 @@ func (m *mockLiabilityAPI) AccountBalance(accountID string) float64 {
 	return 0
 }
 
-func (m *mockLiabilityAPI) CreateLiabilityAccount(nl firefly.NewLiability) error {
+func (m *mockLiabilityAPI) AccountLastActivity(accountID string) string {
+	if m.accountActivityFunc != nil {
+		return m.accountActivityFunc(accountID)
+	}
+	return ""
+}
+
+func (m *mockLiabilityAPI) ConvertToPrimary(amount float64, currencyCode string) (float64, bool) {
+	if m.convertToPrimaryFunc != nil {
+		return m.convertToPrimaryFunc(amount, currencyCode)
+	}
+	return amount, false
+}
+
+func (m *mockLiabilityAPI) PrimaryCurrency() firefly.Currency {
+	return firefly.Currency{Code: "EUR", Symbol: "€"}
+}
+
+func (m *mockLiabilityAPI) GetCurrencyByCode(code string) firefly.Currency {
+	return firefly.Currency{Code: code}
+}
+
+func (m *mockLiabilityAPI) CreateLiabilityAccount(_ context.Context, nl firefly.NewLiability) error {
 	m.createLiabilityCalledWith = append(m.createLiabilityCalledWith, nl)
 	if m.createLiabilityAccountFunc != nil {
 		return m.createLiabilityAccountFunc(nl)
@@ -56,6 +84,13 @@ func (m *mockLiabilityAPI) CreateLiabilityAccount(nl firefly.NewLiability) error
 	return nil
 }
 
+func (m *mockLiabilityAPI) AccountTransactions(_ context.Context, accountID string, _, _ time.Time) ([]firefly.Transaction, error) {
+	if m.accountTransactionsFunc != nil {
+		return m.accountTransactionsFunc(accountID)
+	}
+	return nil, nil
+}
+
 func newFocusedLiabilitiesModelWithAccount(t *testing.T, acc firefly.Account) modelLiabilities {
 	t.Helper()
 
@@ -165,7 +200,7 @@ func TestRefreshLiabilitiesMsg_Success(t *testing.T) {
 		t.Fatal("expected a command, got nil")
 	}
 
-	msg := cmd()
+	msg := trackedMsg(cmd)
 	if _, ok := msg.(LiabilitiesUpdateMsg); !ok {
 		t.Errorf("expected LiabilitiesUpdateMsg, got %T", msg)
 	}
@@ -173,7 +208,7 @@ func TestRefreshLiabilitiesMsg_Success(t *testing.T) {
 	if len(api.updateAccountsCalledWith) != 1 {
 		t.Fatalf("expected UpdateAccounts to be called once, got %d", len(api.updateAccountsCalledWith))
 	}
-	if api.updateAccountsCalledWith[0] != "liability" {
+	if api.updateAccountsCalledWith[0] != "liabilities" {
 		t.Errorf("expected UpdateAccounts called with 'liabilities', got %q", api.updateAccountsCalledWith[0])
 	}
 }
@@ -197,10 +232,13 @@ func TestRefreshLiabilitiesMsg_Error(t *testing.T) {
 		t.Fatal("expected a command, got nil")
 	}
 
-	msg := cmd()
-	notifyMsg, ok := msg.(notify.NotifyMsg)
+	msgs := collectMsgsFromCmd(cmd)
+	if !hasMsg[DataLoadFailedMsg](msgs) {
+		t.Errorf("expected DataLoadFailedMsg, got %v", msgs)
+	}
+	notifyMsg, ok := msgs[0].(notify.NotifyMsg)
 	if !ok {
-		t.Fatalf("expected notify.NotifyMsg, got %T", msg)
+		t.Fatalf("expected notify.NotifyMsg, got %T", msgs[0])
 	}
 	if notifyMsg.Level != notify.Warn {
 		t.Errorf("expected notify level Warn, got %v", notifyMsg.Level)
@@ -234,7 +272,7 @@ func TestLiabilitiesUpdateMsg_SetsItems(t *testing.T) {
 	foundDataLoadMsg := false
 	for _, msg := range msgs {
 		if dlMsg, ok := msg.(DataLoadCompletedMsg); ok {
-			if dlMsg.DataType != "liability" {
+			if dlMsg.DataType != "liabilities" {
 				t.Errorf("expected DataType 'liabilities', got %q", dlMsg.DataType)
 			}
 			foundDataLoadMsg = true
@@ -754,6 +792,25 @@ func TestCmdPromptNewLiability_CancelWithNone(t *testing.T) {
 
 // Edge case tests
 
+func TestGetLiabilitiesItems_ShowsInterest(t *testing.T) {
+	api := &mockLiabilityAPI{
+		accountsByTypeFunc: func(accountType string) []firefly.Account {
+			return []firefly.Account{
+				{ID: "l1", Name: "Car loan", CurrencyCode: "EUR", Type: "liabilities", Interest: "4.9", InterestPeriod: "yearly"},
+				{ID: "l2", Name: "Loan to a friend", CurrencyCode: "EUR", Type: "liabilities"},
+			}
+		},
+	}
+
+	items := getLiabilitiesItems(api)
+	if got := items[0].(liabilityItem).Description(); !strings.Contains(got, "4.9% yearly") {
+		t.Errorf("expected interest in description, got %q", got)
+	}
+	if got := items[1].(liabilityItem).Description(); strings.Contains(got, "%") {
+		t.Errorf("expected no interest in description, got %q", got)
+	}
+}
+
 func TestGetLiabilitiesItems_EmptyList(t *testing.T) {
 	api := &mockLiabilityAPI{
 		accountsByTypeFunc: func(accountType string) []firefly.Account {
@@ -0,0 +1,168 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/accountdetail"
+	"ffiii-tui/internal/ui/money"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"go.uber.org/zap"
+)
+
+const (
+	// payoffHistoryMonths is how far back payments are averaged to
+	// project the payoff of a liability.
+	payoffHistoryMonths = 12
+	// maxPayoffMonths caps the projection, anything later is reported as
+	// never paid off.
+	maxPayoffMonths = 600
+)
+
+// periodsPerYear maps Firefly III interest periods to their count in a year.
+var periodsPerYear = map[string]float64{
+	"daily":     365,
+	"weekly":    52,
+	"monthly":   12,
+	"quarterly": 4,
+	"half-year": 2,
+	"yearly":    1,
+}
+
+// interestSummary returns the interest of a liability, e.g. "4.9% yearly".
+func interestSummary(account firefly.Account) string {
+	rate, err := strconv.ParseFloat(account.Interest, 64)
+	if err != nil || rate == 0 {
+		return ""
+	}
+	summary := strconv.FormatFloat(rate, 'f', -1, 64) + "%"
+	if account.InterestPeriod != "" {
+		summary += " " + account.InterestPeriod
+	}
+	return summary
+}
+
+// monthlyInterestRate converts the interest of a liability, in percent per
+// interest period, to a monthly rate. Unknown periods are taken as yearly.
+func monthlyInterestRate(account firefly.Account) float64 {
+	rate, err := strconv.ParseFloat(account.Interest, 64)
+	if err != nil || rate <= 0 {
+		return 0
+	}
+	perYear, ok := periodsPerYear[account.InterestPeriod]
+	if !ok {
+		perYear = 1
+	}
+	return rate / 100 * perYear / 12
+}
+
+// averageMonthlyPayment averages what was paid toward a liability per month
+// since start, or since the liability was opened if that is later.
+func averageMonthlyPayment(account firefly.Account, txs []firefly.Transaction, start, now time.Time) float64 {
+	if opened, err := time.Parse(time.DateOnly, transactionDay(account.OpeningBalanceDate)); err == nil && opened.After(start) {
+		start = opened
+	}
+
+	paid := 0.0
+	for _, tx := range txs {
+		switch tx.Type {
+		case "withdrawal", "deposit", "transfer":
+		default:
+			// Opening balances and liability credits are not payments
+			continue
+		}
+		for _, s := range tx.Splits {
+			if account.LiabilityDirection == "credit" {
+				if s.Source.ID == account.ID {
+					paid += s.Amount
+				}
+			} else if s.Destination.ID == account.ID {
+				paid += s.Amount
+			}
+		}
+	}
+
+	months := max(now.Sub(start).Hours()/24/(365.0/12), 1)
+	return paid / months
+}
+
+// projectPayoff simulates monthly payments against balance, accruing rate
+// every month, and returns the month the balance is paid off. It reports
+// false when the payments do not outrun the interest or the payoff is more
+// than maxPayoffMonths away.
+func projectPayoff(balance, rate, payment float64, from time.Time) (time.Time, bool) {
+	if balance <= 0 {
+		return from, true
+	}
+	if payment <= balance*rate {
+		return time.Time{}, false
+	}
+	for month := 1; month <= maxPayoffMonths; month++ {
+		balance += balance*rate - payment
+		if balance <= 0 {
+			return from.AddDate(0, month, 0), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// payoffSummary projects when the owed amount of a liability is paid off at
+// the average payment of txs, e.g. "Mar 2028 at 320.00 EUR a month".
+func payoffSummary(account firefly.Account, owed float64, txs []firefly.Transaction, start, now time.Time, amounts money.Formatter) string {
+	if owed <= 0 {
+		return "paid off"
+	}
+	payment := averageMonthlyPayment(account, txs, start, now)
+	if payment <= 0 {
+		return fmt.Sprintf("no payments in the last %d months", payoffHistoryMonths)
+	}
+	date, ok := projectPayoff(owed, monthlyInterestRate(account), payment, now)
+	if !ok {
+		return fmt.Sprintf("never at %s a month", amounts.Format(payment, account.CurrencyCode))
+	}
+	return fmt.Sprintf("%s at %s a month", date.Format("Jan 2006"), amounts.Format(payment, account.CurrencyCode))
+}
+
+// liabilityDetails opens the detail pane of a liability once its payment
+// history is fetched to project the payoff.
+func liabilityDetails(api LiabilityAPI) func(item list.Item) tea.Cmd {
+	return func(item list.Item) tea.Cmd {
+		i, ok := item.(liabilityItem)
+		if !ok || i.Entity.ID == "" {
+			return nil
+		}
+		account := i.Entity
+		balance := api.AccountBalance(account.ID)
+		lastActivity := api.AccountLastActivity(account.ID)
+		return func() tea.Msg {
+			owed := balance
+			if account.LiabilityDirection == "debit" {
+				owed = -balance
+			}
+			now := time.Now()
+			start := now.AddDate(0, -payoffHistoryMonths, 0)
+			payoff := ""
+			txs, err := api.AccountTransactions(context.Background(), account.ID, start, now)
+			if err != nil {
+				zap.L().Warn("Failed to fetch liability payments", zap.String("account", account.Name), zap.Error(err))
+			} else {
+				payoff = payoffSummary(account, owed, txs, start, now, money.New(api))
+			}
+			return accountdetail.OpenMsg{
+				Account:      account,
+				Balance:      balance,
+				LastActivity: lastActivity,
+				Payoff:       payoff,
+			}
+		}
+	}
+}
@@ -0,0 +1,173 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/accountdetail"
+	"ffiii-tui/internal/ui/money"
+)
+
+func TestInterestSummary(t *testing.T) {
+	tests := []struct {
+		interest, period, want string
+	}{
+		{"4.9", "yearly", "4.9% yearly"},
+		{"4.900000", "monthly", "4.9% monthly"},
+		{"2", "", "2%"},
+		{"0", "yearly", ""},
+		{"", "yearly", ""},
+	}
+	for _, tt := range tests {
+		got := interestSummary(firefly.Account{Interest: tt.interest, InterestPeriod: tt.period})
+		if got != tt.want {
+			t.Errorf("interestSummary(%q, %q) = %q, want %q", tt.interest, tt.period, got, tt.want)
+		}
+	}
+}
+
+func TestMonthlyInterestRate(t *testing.T) {
+	tests := []struct {
+		interest, period string
+		want             float64
+	}{
+		{"12", "yearly", 0.01},
+		{"1", "monthly", 0.01},
+		{"3", "quarterly", 0.01},
+		{"6", "half-year", 0.01},
+		{"12", "unknown", 0.01},
+		{"", "yearly", 0},
+		{"-1", "yearly", 0},
+	}
+	for _, tt := range tests {
+		got := monthlyInterestRate(firefly.Account{Interest: tt.interest, InterestPeriod: tt.period})
+		if math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("monthlyInterestRate(%q, %q) = %f, want %f", tt.interest, tt.period, got, tt.want)
+		}
+	}
+}
+
+func TestProjectPayoff(t *testing.T) {
+	from := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	date, ok := projectPayoff(1000, 0, 100, from)
+	if !ok || !date.Equal(from.AddDate(0, 10, 0)) {
+		t.Errorf("expected payoff in 10 months, got %v, %v", date, ok)
+	}
+
+	date, ok = projectPayoff(1000, 0.01, 100, from)
+	if !ok || !date.Equal(from.AddDate(0, 11, 0)) {
+		t.Errorf("expected interest to add a month, got %v, %v", date, ok)
+	}
+
+	if _, ok := projectPayoff(1000, 0.1, 100, from); ok {
+		t.Error("expected no payoff when payments only cover the interest")
+	}
+	if _, ok := projectPayoff(1e9, 0, 1, from); ok {
+		t.Error("expected no payoff beyond the projection cap")
+	}
+	if date, ok := projectPayoff(0, 0.01, 0, from); !ok || !date.Equal(from) {
+		t.Errorf("expected settled balance to be paid off now, got %v, %v", date, ok)
+	}
+}
+
+func payoffTransactions(liability firefly.Account) []firefly.Transaction {
+	checking := firefly.Account{ID: "a1", Name: "Checking", Type: "asset"}
+	return []firefly.Transaction{
+		{Type: "opening balance", Splits: []firefly.Split{{Source: checking, Destination: liability, Amount: 9600}}},
+		{Type: "transfer", Splits: []firefly.Split{{Source: checking, Destination: liability, Amount: 300}}},
+		{Type: "transfer", Splits: []firefly.Split{{Source: checking, Destination: liability, Amount: 300}}},
+		{Type: "transfer", Splits: []firefly.Split{{Source: liability, Destination: checking, Amount: 50}}},
+	}
+}
+
+func TestAverageMonthlyPayment(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	start := now.AddDate(0, -payoffHistoryMonths, 0)
+
+	debit := firefly.Account{ID: "l1", LiabilityDirection: "debit"}
+	got := averageMonthlyPayment(debit, payoffTransactions(debit), start, now)
+	if want := 600.0 / (now.Sub(start).Hours() / 24 / (365.0 / 12)); math.Abs(got-want) > 1e-9 {
+		t.Errorf("expected %f over the whole history, got %f", want, got)
+	}
+
+	debit.OpeningBalanceDate = now.AddDate(0, 0, -61).Format(time.DateOnly)
+	got = averageMonthlyPayment(debit, payoffTransactions(debit), start, now)
+	if math.Abs(got-300) > 5 {
+		t.Errorf("expected about 300 a month since opening, got %f", got)
+	}
+
+	credit := firefly.Account{ID: "l1", LiabilityDirection: "credit"}
+	got = averageMonthlyPayment(credit, payoffTransactions(credit), now.AddDate(0, 0, -1), now)
+	if got != 50 {
+		t.Errorf("expected repayments to a credit liability to count, got %f", got)
+	}
+}
+
+func TestPayoffSummary(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	start := now.AddDate(0, 0, -30)
+	loan := firefly.Account{ID: "l1", LiabilityDirection: "debit", CurrencyCode: "EUR"}
+
+	if got := payoffSummary(loan, 0, nil, start, now, money.Formatter{}); got != "paid off" {
+		t.Errorf("expected 'paid off', got %q", got)
+	}
+	if got := payoffSummary(loan, 1000, nil, start, now, money.Formatter{}); !strings.Contains(got, "no payments") {
+		t.Errorf("expected no payments, got %q", got)
+	}
+
+	got := payoffSummary(loan, 6000, payoffTransactions(loan), start, now, money.Formatter{})
+	if got != "Apr 2027 at 600.00 EUR a month" {
+		t.Errorf("unexpected summary %q", got)
+	}
+
+	loan.Interest, loan.InterestPeriod = "200", "yearly"
+	if got := payoffSummary(loan, 6000, payoffTransactions(loan), start, now, money.Formatter{}); !strings.HasPrefix(got, "never at ") {
+		t.Errorf("expected never paid off, got %q", got)
+	}
+}
+
+func TestLiabilityDetails_OpensWithPayoff(t *testing.T) {
+	loan := firefly.Account{ID: "l1", Name: "Car loan", Type: "liabilities", LiabilityDirection: "debit", CurrencyCode: "EUR"}
+	api := &mockLiabilityAPI{
+		accountBalanceFunc: func(string) float64 { return -6000 },
+		accountTransactionsFunc: func(accountID string) ([]firefly.Transaction, error) {
+			if accountID != "l1" {
+				t.Errorf("expected transactions of l1, got %q", accountID)
+			}
+			return payoffTransactions(loan), nil
+		},
+	}
+
+	cmd := liabilityDetails(api)(newAccountListItem(loan, "We owe", 6000, money.Formatter{}))
+	if cmd == nil {
+		t.Fatal("expected details command")
+	}
+	msg, ok := cmd().(accountdetail.OpenMsg)
+	if !ok {
+		t.Fatal("expected accountdetail.OpenMsg")
+	}
+	if msg.Balance != -6000 || !strings.Contains(msg.Payoff, "EUR a month") {
+		t.Errorf("unexpected details %+v", msg)
+	}
+
+	api.accountTransactionsFunc = func(string) ([]firefly.Transaction, error) {
+		return nil, errors.New("offline")
+	}
+	msg = liabilityDetails(api)(newAccountListItem(loan, "We owe", 6000, money.Formatter{}))().(accountdetail.OpenMsg)
+	if msg.Payoff != "" {
+		t.Errorf("expected no payoff when payments can't be fetched, got %q", msg.Payoff)
+	}
+
+	if cmd := liabilityDetails(api)(newAccountListItem(firefly.Account{}, "", 0, money.Formatter{})); cmd != nil {
+		t.Error("expected no command for an item without account")
+	}
+}
@@ -0,0 +1,52 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"ffiii-tui/internal/firefly"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"go.uber.org/zap"
+)
+
+// liveUpdateMsg reports changes made outside of the program, e.g. in the
+// web interface; the next ones arrive on events.
+type liveUpdateMsg struct {
+	events <-chan firefly.WebhookEvent
+}
+
+// waitForLiveUpdate waits for the next change reported by the webhooks,
+// nil when live updates are off.
+func waitForLiveUpdate(events <-chan firefly.WebhookEvent) tea.Cmd {
+	if events == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		event := <-events
+		zap.L().Debug("Live update", zap.String("trigger", event.Trigger))
+		// A burst of changes, e.g. an import, reloads once
+		for {
+			select {
+			case <-events:
+			default:
+				return liveUpdateMsg{events: events}
+			}
+		}
+	}
+}
+
+// liveUpdate reloads the transactions, keeping the selected one, and
+// forgets the insights the change made stale.
+func (m *modelUI) liveUpdate(msg liveUpdateMsg) tea.Cmd {
+	if m.api.Unauthorized() {
+		return waitForLiveUpdate(msg.events)
+	}
+	m.api.ForgetInsights()
+	selected, _ := m.transactions.GetCurrentTransaction()
+	return tea.Batch(
+		Cmd(RefreshTransactionsMsg{TrxID: selected.TransactionID}),
+		waitForLiveUpdate(msg.events),
+	)
+}
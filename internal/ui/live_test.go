@@ -0,0 +1,59 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+)
+
+func TestWaitForLiveUpdate_Off(t *testing.T) {
+	if cmd := waitForLiveUpdate(nil); cmd != nil {
+		t.Error("expected no command without live updates")
+	}
+}
+
+func TestWaitForLiveUpdate_CoalescesBurst(t *testing.T) {
+	events := make(chan firefly.WebhookEvent, 4)
+	for range 3 {
+		events <- firefly.WebhookEvent{Trigger: "STORE_TRANSACTION"}
+	}
+
+	msg := waitForLiveUpdate(events)()
+	if _, ok := msg.(liveUpdateMsg); !ok {
+		t.Fatalf("expected liveUpdateMsg, got %T", msg)
+	}
+	if len(events) != 0 {
+		t.Errorf("expected the burst to be reported once, %d events left", len(events))
+	}
+}
+
+func TestUI_LiveUpdateReloadsTransactions(t *testing.T) {
+	api := newTestUIAPI()
+	api.liveUpdates = make(chan firefly.WebhookEvent, 1)
+	m := NewModelUI(api)
+	m.transactions.transactions = []firefly.Transaction{
+		newTestTransaction(0, "tx7", "withdrawal", "2024-01-15T10:00:00Z", "Coffee"),
+	}
+	m.transactions.shown = m.transactions.transactions
+	m.transactions.updateRows("tx7")
+
+	_, cmd := m.Update(liveUpdateMsg{events: api.liveUpdates})
+	// The next change unblocks the renewed wait
+	api.liveUpdates <- firefly.WebhookEvent{Trigger: "UPDATE_TRANSACTION"}
+	msgs := collectMsgsFromCmd(cmd)
+
+	refresh, ok := findMsg[RefreshTransactionsMsg](msgs)
+	if !ok || refresh.TrxID != "tx7" {
+		t.Errorf("expected the transactions to reload keeping tx7 selected, got %v", msgs)
+	}
+	if !hasMsg[liveUpdateMsg](msgs) {
+		t.Error("expected to keep waiting for changes")
+	}
+	if api.forgetInsightsCalled != 1 {
+		t.Errorf("expected stale insights to be forgotten, got %d calls", api.forgetInsightsCalled)
+	}
+}
@@ -0,0 +1,190 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"slices"
+	"strings"
+
+	"ffiii-tui/internal/ui/notify"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type loadState int
+
+const (
+	loadPending loadState = iota // waiting for dependencies
+	loadRunning
+	loadDone
+	loadFailed
+)
+
+// resourceLoad is the state of one resource during a full refresh.
+type resourceLoad struct {
+	state loadState
+	err   error
+}
+
+type DataLoadFailedMsg struct {
+	DataType string
+	Err      error
+}
+
+// loadGraph lists the resources loaded by a full refresh and the resources
+// each of them waits for. Transactions resolve account and category IDs, so
+// they start once those lists settled. Everything else loads concurrently;
+// insights are loaded with their lists.
+var loadGraph = map[string][]string{
+	"asset":        nil,
+	"expense":      nil,
+	"revenue":      nil,
+	"liabilities":  nil,
+	"categories":   nil,
+	"tags":         nil,
+	"summary":      nil,
+	"transactions": {"asset", "expense", "revenue", "liabilities", "categories"},
+}
+
+// loadMsgs are the messages starting the load of each resource.
+var loadMsgs = map[string]tea.Msg{
+	"asset":        RefreshAssetsMsg{},
+	"expense":      RefreshExpensesMsg{},
+	"revenue":      RefreshRevenuesMsg{},
+	"liabilities":  RefreshLiabilitiesMsg{},
+	"categories":   RefreshCategoriesMsg{},
+	"tags":         RefreshTagsMsg{},
+	"summary":      RefreshSummaryMsg{},
+	"transactions": RefreshTransactionsMsg{},
+}
+
+func newLoadStatus() map[string]resourceLoad {
+	status := make(map[string]resourceLoad, len(loadGraph))
+	for resource := range loadGraph {
+		status[resource] = resourceLoad{state: loadPending}
+	}
+	return status
+}
+
+// dataLoadFailed reports a failed refresh of a resource: a warning for the user
+// and the failure for the load state. Canceled loads report nothing, the
+// request superseding them updates the state.
+func dataLoadFailed(dataType string, err error) tea.Msg {
+	if canceled(err) {
+		return nil
+	}
+	return tea.BatchMsg{
+		notify.NotifyWarn(err.Error()),
+		Cmd(DataLoadFailedMsg{DataType: dataType, Err: err}),
+	}
+}
+
+// startReadyLoads starts the pending resources whose dependencies settled,
+// loaded or failed. A failed dependency does not block its dependents, they
+// load with what is available.
+func (m *modelUI) startReadyLoads() tea.Cmd {
+	var cmds []tea.Cmd
+	for _, resource := range sortedResources() {
+		if m.loadStatus[resource].state != loadPending {
+			continue
+		}
+		ready := true
+		for _, dep := range loadGraph[resource] {
+			if state := m.loadStatus[dep].state; state != loadDone && state != loadFailed {
+				ready = false
+				break
+			}
+		}
+		if ready {
+			m.loadStatus[resource] = resourceLoad{state: loadRunning}
+			cmds = append(cmds, Cmd(loadMsgs[resource]))
+		}
+	}
+	if len(cmds) == 0 {
+		return nil
+	}
+	return tea.Batch(cmds...)
+}
+
+// setLoadState records the outcome of a resource load and starts the
+// resources waiting for it. Results of resources still waiting for their
+// dependencies are ignored, they come from cached data and the resource is
+// loaded once it is ready. Later manual refreshes update the state, so a
+// successful retry clears an earlier failure.
+func (m *modelUI) setLoadState(resource string, state loadState, err error) tea.Cmd {
+	current, ok := m.loadStatus[resource]
+	if !ok || current.state == loadPending {
+		return nil
+	}
+	m.loadStatus[resource] = resourceLoad{state: state, err: err}
+	return m.startReadyLoads()
+}
+
+// retryFailedLoads loads the resources that failed again.
+func (m *modelUI) retryFailedLoads() tea.Cmd {
+	var cmds []tea.Cmd
+	for _, resource := range m.failedResources() {
+		m.loadStatus[resource] = resourceLoad{state: loadRunning}
+		cmds = append(cmds, Cmd(loadMsgs[resource]))
+	}
+	if len(cmds) == 0 {
+		return nil
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m *modelUI) failedResources() []string {
+	var failed []string
+	for _, resource := range sortedResources() {
+		if m.loadStatus[resource].state == loadFailed {
+			failed = append(failed, resource)
+		}
+	}
+	return failed
+}
+
+// loadSegment lists the resources that failed to load.
+func (m *modelUI) loadSegment() string {
+	failed := m.failedResources()
+	if len(failed) == 0 {
+		return ""
+	}
+	return m.styles.StatusBarError.Render("not loaded: " + strings.Join(failed, ", ") +
+		" (" + m.keymap.Retry.Help().Key + " to retry)")
+}
+
+// panelView returns the view of the panel showing resource, or an error
+// state of the same size when the resource failed to load, so the layout
+// does not shift.
+func (m *modelUI) panelView(resource, title, view string) string {
+	load := m.loadStatus[resource]
+	if load.state != loadFailed {
+		return view
+	}
+
+	width, height := lipgloss.Width(view), lipgloss.Height(view)
+	reason := "failed to load"
+	if load.err != nil {
+		reason = load.err.Error()
+	}
+	body := lipgloss.NewStyle().PaddingLeft(2).Width(max(width, 1)).Render(
+		m.styles.PanelError.Render(reason) + "\n\n" +
+			m.help.Styles.ShortKey.Render(m.keymap.Retry.Help().Key+" "+m.keymap.Retry.Help().Desc))
+	content := list.DefaultStyles().TitleBar.Render(list.DefaultStyles().Title.Render(title)) + "\n" + body
+
+	return lipgloss.NewStyle().MaxWidth(width).MaxHeight(height).Render(
+		lipgloss.Place(width, height, lipgloss.Left, lipgloss.Top, content))
+}
+
+func sortedResources() []string {
+	resources := make([]string, 0, len(loadGraph))
+	for resource := range loadGraph {
+		resources = append(resources, resource)
+	}
+	slices.Sort(resources)
+	return resources
+}
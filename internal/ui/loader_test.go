@@ -0,0 +1,179 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestLoader_StartsRootsConcurrently(t *testing.T) {
+	m := newTestModelUI()
+	m.loadStatus = newLoadStatus()
+
+	msgs := collectMsgsFromCmd(m.startReadyLoads())
+
+	for _, want := range []any{
+		RefreshAssetsMsg{}, RefreshExpensesMsg{}, RefreshRevenuesMsg{},
+		RefreshLiabilitiesMsg{}, RefreshCategoriesMsg{}, RefreshSummaryMsg{},
+	} {
+		found := false
+		for _, msg := range msgs {
+			if msg == want {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected %T to start", want)
+		}
+	}
+	if hasMsg[RefreshTransactionsMsg](msgs) {
+		t.Error("Expected transactions to wait for accounts and categories")
+	}
+}
+
+func TestLoader_TransactionsStartWhenDependenciesSettle(t *testing.T) {
+	m := newTestModelUI()
+	m.loadStatus = newLoadStatus()
+	m.startReadyLoads()
+
+	for _, resource := range []string{"asset", "expense", "revenue", "liabilities"} {
+		if cmd := m.setLoadState(resource, loadDone, nil); cmd != nil {
+			t.Fatalf("Expected nothing to start after %s", resource)
+		}
+	}
+
+	// A failed dependency does not block transactions
+	msgs := collectMsgsFromCmd(m.setLoadState("categories", loadFailed, errors.New("boom")))
+	if !hasMsg[RefreshTransactionsMsg](msgs) {
+		t.Error("Expected transactions to start once dependencies settled")
+	}
+	if m.loadStatus["transactions"].state != loadRunning {
+		t.Errorf("Expected transactions running, got %d", m.loadStatus["transactions"].state)
+	}
+}
+
+func TestLoader_IgnoresResultsOfWaitingResources(t *testing.T) {
+	m := newTestModelUI()
+	m.loadStatus = newLoadStatus()
+	m.startReadyLoads()
+
+	updated, _ := m.Update(DataLoadCompletedMsg{DataType: "transactions"})
+	m = updated.(modelUI)
+
+	if m.loadStatus["transactions"].state != loadPending {
+		t.Error("Expected cached results not to complete a waiting resource")
+	}
+}
+
+func TestLoader_FailureShownUntilRetried(t *testing.T) {
+	m := newTestModelUI()
+	m.Width = 200
+	m.loadStatus = newLoadStatus()
+	m.startReadyLoads()
+
+	updated, _ := m.Update(DataLoadFailedMsg{DataType: "revenue", Err: errors.New("HTTP error: 500")})
+	m = updated.(modelUI)
+
+	if bar := m.statusBar(); !strings.Contains(bar, "not loaded: revenue") {
+		t.Errorf("Expected failed resource in status bar, got %q", bar)
+	}
+
+	updated, _ = m.Update(DataLoadCompletedMsg{DataType: "revenue"})
+	m = updated.(modelUI)
+
+	if bar := m.statusBar(); strings.Contains(bar, "not loaded") {
+		t.Errorf("Expected failure to clear after a successful retry, got %q", bar)
+	}
+}
+
+func TestLoader_DataLoadFailedReportsWarning(t *testing.T) {
+	msgs := collectMsgsFromMsg(dataLoadFailed("summary", errors.New("boom")))
+
+	if len(msgs) != 2 || !hasMsg[DataLoadFailedMsg](msgs) {
+		t.Errorf("Expected warning and failure messages, got %v", msgs)
+	}
+}
+
+func TestLoader_FailedPanelShowsErrorState(t *testing.T) {
+	m := newTestModelUI()
+	m.loadStatus = newLoadStatus()
+	m.startReadyLoads()
+	m.state = revenuesView
+	m.revenues.list.SetSize(40, 20)
+
+	updated, _ := m.Update(DataLoadFailedMsg{DataType: "revenue", Err: errors.New("HTTP error: 500")})
+	m = updated.(modelUI)
+
+	view := m.panelView("revenue", "Revenue accounts", m.revenues.View())
+	for _, want := range []string{"Revenue accounts", "HTTP error: 500", "R retry failed loads"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected error state to contain %q, got %q", want, view)
+		}
+	}
+	if w, h := lipgloss.Size(view); w != lipgloss.Width(m.revenues.View()) || h != lipgloss.Height(m.revenues.View()) {
+		t.Errorf("Expected error state to keep the panel size, got %dx%d", w, h)
+	}
+	if !strings.Contains(m.View(), "HTTP error: 500") {
+		t.Error("Expected error state in the revenues view")
+	}
+
+	// Panels of other resources are unaffected
+	if view := m.panelView("asset", "Asset accounts", m.assets.View()); view != m.assets.View() {
+		t.Error("Expected loaded panel to render as is")
+	}
+}
+
+func TestLoader_RetryKeyReloadsFailedResources(t *testing.T) {
+	m := newTestModelUI()
+	m.loadStatus = newLoadStatus()
+	m.startReadyLoads()
+	m.setLoadState("revenue", loadFailed, errors.New("boom"))
+	m.setLoadState("summary", loadFailed, errors.New("boom"))
+	m.setLoadState("asset", loadDone, nil)
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'R'}})
+	m = updated.(modelUI)
+
+	msgs := collectMsgsFromCmd(cmd)
+	if !hasMsg[RefreshRevenuesMsg](msgs) || !hasMsg[RefreshSummaryMsg](msgs) {
+		t.Errorf("Expected failed resources to reload, got %v", msgs)
+	}
+	if hasMsg[RefreshAssetsMsg](msgs) {
+		t.Error("Expected loaded resources not to reload")
+	}
+	for _, resource := range []string{"revenue", "summary"} {
+		if m.loadStatus[resource].state != loadRunning {
+			t.Errorf("Expected %s running, got %d", resource, m.loadStatus[resource].state)
+		}
+	}
+	if bar := m.statusBar(); strings.Contains(bar, "not loaded") {
+		t.Errorf("Expected retried resources to leave the status bar, got %q", bar)
+	}
+}
+
+func TestLoader_InsightsFailureTracked(t *testing.T) {
+	m := newTestModelUI()
+	m.loadStatus = newLoadStatus()
+	for resource := range loadGraph {
+		m.loadStatus[resource] = resourceLoad{state: loadDone}
+	}
+
+	msgs := collectMsgsFromMsg(dataLoadFailed("expense", errors.New("HTTP error: 500")))
+	failed, ok := findMsg[DataLoadFailedMsg](msgs)
+	if !ok {
+		t.Fatalf("Expected DataLoadFailedMsg, got %v", msgs)
+	}
+	updated, _ := m.Update(failed)
+	m = updated.(modelUI)
+
+	if m.loadStatus["expense"].state != loadFailed {
+		t.Error("Expected a failed refresh after the initial load to be tracked")
+	}
+}
@@ -0,0 +1,174 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package loading
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// MaxOperations is how many running operations are tracked, more run
+	// without being shown
+	MaxOperations = 100
+	// MaxShown is how many operation messages are listed
+	MaxShown = 5
+	// MaxMessageLength is the length messages are cut to
+	MaxMessageLength = 25
+)
+
+// Operation is a running operation shown by the indicator from its
+// StartMsg until it is stopped.
+type Operation struct {
+	mu      sync.Mutex
+	message string
+	stopped bool
+}
+
+// NewOperation returns an operation showing message once started.
+func NewOperation(message string) *Operation {
+	return &Operation{message: message}
+}
+
+// Start returns the command showing the operation.
+func (o *Operation) Start() tea.Cmd {
+	return func() tea.Msg { return StartMsg{Op: o} }
+}
+
+// Stop hides the operation. It is safe to call from the command running
+// the operation, and more than once.
+func (o *Operation) Stop() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.stopped = true
+}
+
+func (o *Operation) running() bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return !o.stopped
+}
+
+// SetMessage replaces the message of the operation, e.g. to report its
+// progress. It is safe to call from the command running the operation.
+func (o *Operation) SetMessage(message string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.message = message
+}
+
+// Message returns the current message of the operation.
+func (o *Operation) Message() string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.message
+}
+
+// StartMsg shows the operation until it is stopped.
+type StartMsg struct{ Op *Operation }
+
+// Track shows message while cmd runs.
+func Track(message string, cmd tea.Cmd) tea.Cmd {
+	return TrackProgress(message, func(*Operation) tea.Msg {
+		return cmd()
+	})
+}
+
+// TrackProgress shows message while cmd runs, cmd may replace the message
+// of its operation to report progress.
+func TrackProgress(message string, cmd func(op *Operation) tea.Msg) tea.Cmd {
+	op := NewOperation(message)
+	return tea.Sequence(op.Start(), func() tea.Msg {
+		defer op.Stop()
+		return cmd(op)
+	})
+}
+
+// Model is the loading indicator: a spinner followed by the messages of the
+// running operations, oldest first.
+type Model struct {
+	spinner spinner.Model
+	ops     []*Operation
+}
+
+func New() Model {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	return Model{spinner: s}
+}
+
+func (m Model) Init() tea.Cmd {
+	return m.spinner.Tick
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	// Operations stop in their commands, they are dropped on the next
+	// message
+	m.ops = m.running()
+
+	switch msg := msg.(type) {
+	case StartMsg:
+		if len(m.ops) < MaxOperations && msg.Op.running() {
+			m.ops = append(m.ops, msg.Op)
+		}
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// running returns a new list of the operations not stopped yet.
+func (m Model) running() []*Operation {
+	ops := make([]*Operation, 0, len(m.ops))
+	for _, op := range m.ops {
+		if op.running() {
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+// Active reports whether any operation is running.
+func (m Model) Active() bool {
+	return len(m.running()) > 0
+}
+
+// View returns the spinner with the running operations, empty when none is
+// running.
+func (m Model) View() string {
+	if !m.Active() {
+		return ""
+	}
+	return m.spinner.View() + m.Message()
+}
+
+// Message lists the running operations, their messages cut short.
+func (m Model) Message() string {
+	var messages []string
+	for _, op := range m.running() {
+		message := op.Message()
+		if len(message) > MaxMessageLength {
+			message = message[:MaxMessageLength-3] + "..."
+		}
+		messages = append(messages, message)
+	}
+
+	switch count := len(messages); {
+	case count == 0:
+		return "..."
+	case count == 1:
+		return messages[0]
+	case count <= MaxShown:
+		return fmt.Sprintf("(%d) %s", count, strings.Join(messages, " "))
+	default:
+		return fmt.Sprintf("(%d) %s | +%d more", count, strings.Join(messages[:MaxShown], " "), count-MaxShown)
+	}
+}
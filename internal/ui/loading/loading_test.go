@@ -0,0 +1,121 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package loading
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func start(m Model, message string) (Model, *Operation) {
+	op := NewOperation(message)
+	m, _ = m.Update(op.Start()())
+	return m, op
+}
+
+func TestModel_StartStop(t *testing.T) {
+	m, op := start(New(), "Loading test...")
+	if !m.Active() {
+		t.Fatal("Expected the operation to be running")
+	}
+	if !strings.Contains(m.View(), "Loading test...") {
+		t.Errorf("Expected the view to contain the message, got %q", m.View())
+	}
+
+	op.Stop()
+	if m.Active() || m.View() != "" {
+		t.Errorf("Expected nothing shown once stopped, got %q", m.View())
+	}
+	m, _ = m.Update(nil)
+	if len(m.ops) != 0 {
+		t.Errorf("Expected the stopped operation dropped, got %d", len(m.ops))
+	}
+	if msg := m.Message(); msg != "..." {
+		t.Errorf("Expected fallback '...', got %q", msg)
+	}
+}
+
+func TestModel_StoppedBeforeStart(t *testing.T) {
+	op := NewOperation("Fast")
+	op.Stop()
+	m, _ := New().Update(StartMsg{Op: op})
+	if m.Active() {
+		t.Error("Expected an operation stopped before its start to stay hidden")
+	}
+}
+
+func TestModel_MaxOperations(t *testing.T) {
+	m := New()
+	for range MaxOperations + 1 {
+		m, _ = start(m, "Test")
+	}
+	if len(m.ops) != MaxOperations {
+		t.Errorf("Expected %d operations tracked, got %d", MaxOperations, len(m.ops))
+	}
+}
+
+func TestModel_NestedOperations(t *testing.T) {
+	m, op1 := start(New(), "Operation 1")
+	m, op2 := start(m, "Operation 2")
+	m, op3 := start(m, "Operation 3")
+
+	if msg := m.Message(); msg != "(3) Operation 1 Operation 2 Operation 3" {
+		t.Errorf("Expected the operations listed in order, got %q", msg)
+	}
+	op2.Stop()
+	if msg := m.Message(); !strings.HasPrefix(msg, "(2)") {
+		t.Errorf("Expected message to show count (2), got %q", msg)
+	}
+	op1.Stop()
+	op3.Stop()
+	if m.Active() {
+		t.Error("Expected no operation left")
+	}
+}
+
+func TestModel_MessageCutShort(t *testing.T) {
+	m, _ := start(New(), strings.Repeat("x", 40))
+	for range MaxShown {
+		m, _ = start(m, "Op")
+	}
+
+	msg := m.Message()
+	if !strings.Contains(msg, strings.Repeat("x", MaxMessageLength-3)+"...") {
+		t.Errorf("Expected the long message cut, got %q", msg)
+	}
+	if !strings.HasSuffix(msg, "| +1 more") {
+		t.Errorf("Expected the operations past %d counted, got %q", MaxShown, msg)
+	}
+}
+
+func TestTrackProgress(t *testing.T) {
+	var m Model
+	cmd := TrackProgress("Working...", func(op *Operation) tea.Msg {
+		op.SetMessage("Working 1/2...")
+		if msg := m.Message(); msg != "Working 1/2..." {
+			t.Errorf("Expected the progress shown while running, got %q", msg)
+		}
+		return "done"
+	})
+
+	// tea.Sequence returns its commands, run one after the other
+	cmds := reflect.ValueOf(cmd())
+	if cmds.Kind() != reflect.Slice || cmds.Len() != 2 {
+		t.Fatalf("Expected a sequence of 2 commands, got %T", cmd())
+	}
+	m, _ = New().Update(cmds.Index(0).Interface().(tea.Cmd)())
+	if !m.Active() {
+		t.Fatal("Expected the operation started first")
+	}
+	if msg := cmds.Index(1).Interface().(tea.Cmd)(); msg != "done" {
+		t.Errorf("Expected the message of the command, got %v", msg)
+	}
+	if m.Active() {
+		t.Error("Expected the operation stopped with the command")
+	}
+}
@@ -0,0 +1,58 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"ffiii-tui/internal/firefly"
+)
+
+// locationMark is shown next to the type of transactions with a location.
+const locationMark = "⌖"
+
+var errInvalidLocation = errors.New("enter the location as latitude, longitude, e.g. 52.52, 13.405")
+
+// parseLocation reads coordinates as typed or copied from a map, e.g.
+// "52.52, 13.405", "52.52 13.405" or "geo:52.52,13.405". An empty value is
+// no location.
+func parseLocation(value string) (firefly.Location, error) {
+	value = strings.TrimSpace(value)
+	value = strings.TrimPrefix(value, "geo:")
+	if value == "" {
+		return firefly.Location{}, nil
+	}
+	// geo URIs may carry parameters after the coordinates
+	value, _, _ = strings.Cut(value, ";")
+
+	fields := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	if len(fields) != 2 {
+		return firefly.Location{}, errInvalidLocation
+	}
+	lat, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil || lat < -90 || lat > 90 {
+		return firefly.Location{}, errInvalidLocation
+	}
+	lon, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil || lon < -180 || lon > 180 {
+		return firefly.Location{}, errInvalidLocation
+	}
+	return firefly.Location{Latitude: lat, Longitude: lon}, nil
+}
+
+// Location returns the location entered for the split. The zoom level of a
+// loaded location is kept while its coordinates are.
+func (s *split) Location() firefly.Location {
+	location, err := parseLocation(s.location)
+	if err != nil || location.IsEmpty() {
+		return firefly.Location{}
+	}
+	location.ZoomLevel = s.zoom
+	return location
+}
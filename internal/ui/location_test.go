@@ -0,0 +1,125 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ui
+
+import (
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+)
+
+func TestParseLocation(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    firefly.Location
+		wantErr bool
+	}{
+		{value: "", want: firefly.Location{}},
+		{value: "  ", want: firefly.Location{}},
+		{value: "52.52, 13.405", want: firefly.Location{Latitude: 52.52, Longitude: 13.405}},
+		{value: "52.52 13.405", want: firefly.Location{Latitude: 52.52, Longitude: 13.405}},
+		{value: "-33.8688,151.2093", want: firefly.Location{Latitude: -33.8688, Longitude: 151.2093}},
+		{value: "geo:52.52,13.405;u=35", want: firefly.Location{Latitude: 52.52, Longitude: 13.405}},
+		{value: "Berlin", wantErr: true},
+		{value: "52.52", wantErr: true},
+		{value: "91, 13", wantErr: true},
+		{value: "52, 181", wantErr: true},
+		{value: "1, 2, 3", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseLocation(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSplitLocation_KeepsZoomLevel(t *testing.T) {
+	s := &split{location: "52.520000, 13.405000", zoom: 15}
+	if got := s.Location(); got.ZoomLevel != 15 || got.Latitude != 52.52 {
+		t.Errorf("expected the loaded zoom level, got %+v", got)
+	}
+
+	s = &split{location: "not a place", zoom: 15}
+	if got := s.Location(); !got.IsEmpty() {
+		t.Errorf("expected no location for invalid input, got %+v", got)
+	}
+}
+
+func TestRequestSplit_SetLocation(t *testing.T) {
+	var req firefly.RequestTransactionSplit
+	req.SetLocation(firefly.Location{})
+	if req.Latitude != nil || req.Longitude != nil || req.ZoomLevel != nil {
+		t.Fatal("expected an empty location to be left out")
+	}
+
+	req.SetLocation(firefly.Location{Latitude: 52.52, Longitude: 13.405})
+	if req.Latitude == nil || *req.Latitude != 52.52 || *req.Longitude != 13.405 {
+		t.Fatalf("expected coordinates to be sent, got %+v", req)
+	}
+	if *req.ZoomLevel != firefly.DefaultZoomLevel {
+		t.Errorf("expected default zoom level, got %d", *req.ZoomLevel)
+	}
+}
+
+func TestCreateTransaction_SendsLocation(t *testing.T) {
+	api := &mockTransactionFormAPI{
+		createTransactionFunc: func(tx firefly.RequestTransaction) (string, error) {
+			return "1", nil
+		},
+	}
+	m := newModelTransaction(api)
+	m.created = true
+	m.new = true
+	m.splits = []*split{{
+		source:      testAssetChecking,
+		destination: testExpenseGroceries,
+		amount:      "12.00",
+		location:    "48.8566, 2.3522",
+	}}
+	m.attr.year, m.attr.month, m.attr.day = "2026", "01", "15"
+
+	m.CreateTransaction()
+
+	if len(api.createTransactionCalls) != 1 {
+		t.Fatalf("expected one create, got %d", len(api.createTransactionCalls))
+	}
+	req := api.createTransactionCalls[0].Transactions[0]
+	if req.Latitude == nil || *req.Latitude != 48.8566 || *req.Longitude != 2.3522 {
+		t.Errorf("expected the location in the request, got %+v", req)
+	}
+}
+
+func TestSetTransaction_LoadsLocation(t *testing.T) {
+	m := newTestTransactionModel()
+	tx := newTestTransaction(1, "7", "withdrawal", "2026-01-15T10:00:00Z", "Lunch")
+	tx.Splits[0].Location = firefly.Location{Latitude: 52.52, Longitude: 13.405, ZoomLevel: 14}
+
+	m.SetTransaction(tx, false)
+
+	if got := m.splits[0].location; got != "52.520000, 13.405000" {
+		t.Errorf("expected the coordinates in the form, got %q", got)
+	}
+	if m.splits[0].zoom != 14 {
+		t.Errorf("expected zoom level 14, got %d", m.splits[0].zoom)
+	}
+}
+
+func TestGetRows_LocationMark(t *testing.T) {
+	tx := newTestTransaction(0, "tx1", "withdrawal", "2024-01-15T10:00:00Z", "Lunch")
+	tx.Splits[0].Location = firefly.Location{Latitude: 52.52, Longitude: 13.405}
+
+	rows, _ := getRows([]firefly.Transaction{tx}, nil, DefaultStyles())
+
+	if rows[0][1] != "WD"+locationMark {
+		t.Errorf("expected the location mark after the badge, got %q", rows[0][1])
+	}
+}
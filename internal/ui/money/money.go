@@ -0,0 +1,184 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package money
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"ffiii-tui/internal/firefly"
+
+	"github.com/spf13/viper"
+)
+
+// Display is how amounts show their currency, set with ui.currency_display.
+type Display string
+
+const (
+	// Code shows the ISO code after the amount, e.g. 12.50 EUR
+	Code Display = "code"
+	// Symbol shows the symbol before the amount, e.g. €12.50, and the code
+	// for currencies without one
+	Symbol Display = "symbol"
+	// Both shows the symbol and the code, e.g. €12.50 EUR
+	Both Display = "both"
+)
+
+// Currencies looks up the currencies amounts are in.
+type Currencies interface {
+	GetCurrencyByCode(code string) firefly.Currency
+}
+
+// Formatter formats amounts with the symbols of the currencies of the
+// server in use. The zero Formatter knows no symbols and shows codes.
+type Formatter struct {
+	currencies Currencies
+}
+
+// New returns a Formatter looking up the symbols in c.
+func New(c Currencies) Formatter {
+	return Formatter{currencies: c}
+}
+
+// symbol returns the symbol of the currency, empty when unknown.
+func (f Formatter) symbol(code string) string {
+	if f.currencies == nil {
+		return ""
+	}
+	return f.currencies.GetCurrencyByCode(code).Symbol
+}
+
+// CurrentDisplay returns the configured display, Code when unset or unknown.
+func CurrentDisplay() Display {
+	switch d := Display(viper.GetString("ui.currency_display")); d {
+	case Symbol, Both:
+		return d
+	}
+	return Code
+}
+
+// Format returns amount with two decimals and its currency as configured.
+// Without a currency code only the amount is returned.
+func (f Formatter) Format(amount float64, code string) string {
+	return f.FormatAs(CurrentDisplay(), amount, code)
+}
+
+// Amount returns amount with the symbol of its currency when configured
+// but without the code, for an amount followed by another one in the same
+// currency, e.g. "Over: 12.00 of 450.00 EUR".
+func (f Formatter) Amount(amount float64, code string) string {
+	return signed(amount, fixed(amount), f.displayedSymbol(CurrentDisplay(), code))
+}
+
+// FormatAs returns amount with two decimals and its currency shown as d.
+func (f Formatter) FormatAs(d Display, amount float64, code string) string {
+	return f.render(d, amount, fixed(amount), code)
+}
+
+// Abbreviated reports whether large amounts are abbreviated in lists and
+// the summary, set with ui.abbreviate_amounts.
+func Abbreviated() bool {
+	return viper.GetBool("ui.abbreviate_amounts")
+}
+
+// Short is Format with amounts from 1000 on abbreviated when configured,
+// e.g. 12.3k EUR, to keep narrow panels readable. Detail panes show the
+// exact amount with Format.
+func (f Formatter) Short(amount float64, code string) string {
+	if short, ok := abbreviated(amount); ok {
+		return f.render(CurrentDisplay(), amount, short, code)
+	}
+	return f.Format(amount, code)
+}
+
+// ShortNumber is Short for an amount shown without its currency.
+func ShortNumber(amount float64) string {
+	if short, ok := abbreviated(amount); ok {
+		return signed(amount, short, "")
+	}
+	return fmt.Sprintf("%.2f", amount)
+}
+
+func abbreviated(amount float64) (string, bool) {
+	if !Abbreviated() {
+		return "", false
+	}
+	return Abbreviate(amount)
+}
+
+// abbreviations are the units large amounts are abbreviated with, smallest
+// first.
+var abbreviations = []struct {
+	unit   float64
+	suffix string
+}{
+	{1e3, "k"},
+	{1e6, "M"},
+	{1e9, "B"},
+}
+
+// Abbreviate returns the absolute amount in thousands, millions or billions
+// with up to three digits, e.g. 1.2k, 12.3k, 123k or 1.2M. ok is false
+// below 1000.
+func Abbreviate(amount float64) (short string, ok bool) {
+	abs := math.Abs(amount)
+	if abs < abbreviations[0].unit {
+		return "", false
+	}
+	for i, a := range abbreviations {
+		v := abs / a.unit
+		if v >= 999.5 && i < len(abbreviations)-1 {
+			continue
+		}
+		if v >= 99.95 {
+			return fmt.Sprintf("%.0f%s", v, a.suffix), true
+		}
+		return strings.TrimSuffix(fmt.Sprintf("%.1f", v), ".0") + a.suffix, true
+	}
+	return "", false
+}
+
+// fixed returns the absolute amount with two decimals.
+func fixed(amount float64) string {
+	return fmt.Sprintf("%.2f", math.Abs(amount))
+}
+
+// render shows number, the absolute amount, with the sign of amount and
+// its currency shown as d.
+func (f Formatter) render(d Display, amount float64, number, code string) string {
+	if code == "" {
+		return signed(amount, number, "")
+	}
+	sym := f.displayedSymbol(d, code)
+	switch {
+	case sym == "":
+		return signed(amount, number, "") + " " + code
+	case d == Both:
+		return signed(amount, number, sym) + " " + code
+	}
+	return signed(amount, number, sym)
+}
+
+// displayedSymbol returns the symbol shown for the currency, empty when the
+// code is shown instead.
+func (f Formatter) displayedSymbol(d Display, code string) string {
+	if d == Code || code == "" {
+		return ""
+	}
+	if sym := f.symbol(code); sym != code {
+		return sym
+	}
+	return ""
+}
+
+// signed puts the sign before the symbol, e.g. -€12.50. Amounts rounding
+// to zero have none.
+func signed(amount float64, number, sym string) string {
+	if amount < 0 && strings.Trim(number, "0.") != "" {
+		return "-" + sym + number
+	}
+	return sym + number
+}
@@ -0,0 +1,137 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package money
+
+import (
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+
+	"github.com/spf13/viper"
+)
+
+type stubCurrencies map[string]string
+
+func (s stubCurrencies) GetCurrencyByCode(code string) firefly.Currency {
+	return firefly.Currency{Code: code, Symbol: s[code]}
+}
+
+func TestFormatAs(t *testing.T) {
+	f := New(stubCurrencies{"EUR": "€", "USD": "$", "CHF": "CHF"})
+
+	tests := []struct {
+		display Display
+		amount  float64
+		code    string
+		want    string
+	}{
+		{Code, 12.5, "EUR", "12.50 EUR"},
+		{Symbol, 12.5, "EUR", "€12.50"},
+		{Symbol, -1234.5, "USD", "-$1234.50"},
+		{Symbol, -0.001, "USD", "$0.00"},
+		{Both, 12.5, "EUR", "€12.50 EUR"},
+		{Symbol, 3, "CHF", "3.00 CHF"},
+		{Symbol, 3, "JPY", "3.00 JPY"},
+		{Symbol, 3, "", "3.00"},
+	}
+	for _, tt := range tests {
+		if got := f.FormatAs(tt.display, tt.amount, tt.code); got != tt.want {
+			t.Errorf("FormatAs(%s, %v, %q) = %q, want %q", tt.display, tt.amount, tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestFormat_Configured(t *testing.T) {
+	f := New(stubCurrencies{"EUR": "€"})
+	t.Cleanup(func() { viper.Set("ui.currency_display", nil) })
+
+	if got := f.Format(5, "EUR"); got != "5.00 EUR" {
+		t.Errorf("expected the code by default, got %q", got)
+	}
+	viper.Set("ui.currency_display", "symbol")
+	if got := f.Format(5, "EUR"); got != "€5.00" {
+		t.Errorf("expected the symbol, got %q", got)
+	}
+	viper.Set("ui.currency_display", "emoji")
+	if got := f.Format(5, "EUR"); got != "5.00 EUR" {
+		t.Errorf("expected the code for an unknown display, got %q", got)
+	}
+}
+
+func TestAmount(t *testing.T) {
+	f := New(stubCurrencies{"EUR": "€"})
+	t.Cleanup(func() { viper.Set("ui.currency_display", nil) })
+
+	if got := f.Amount(5, "EUR"); got != "5.00" {
+		t.Errorf("expected the amount alone by default, got %q", got)
+	}
+	viper.Set("ui.currency_display", "both")
+	if got := f.Amount(-5, "EUR"); got != "-€5.00" {
+		t.Errorf("expected the symbol without the code, got %q", got)
+	}
+	if got := f.Amount(5, "JPY"); got != "5.00" {
+		t.Errorf("expected the amount alone without a symbol, got %q", got)
+	}
+}
+
+func TestFormat_NoCurrencies(t *testing.T) {
+	var f Formatter
+	if got := f.FormatAs(Symbol, 5, "EUR"); got != "5.00 EUR" {
+		t.Errorf("expected the code without currencies, got %q", got)
+	}
+}
+
+func TestAbbreviate(t *testing.T) {
+	tests := []struct {
+		amount float64
+		want   string
+		ok     bool
+	}{
+		{999.99, "", false},
+		{1000, "1k", true},
+		{1234.56, "1.2k", true},
+		{-12345, "12.3k", true},
+		{123456, "123k", true},
+		{999600, "1M", true},
+		{1260000, "1.3M", true},
+		{3.2e9, "3.2B", true},
+		{4.5e12, "4500B", true},
+	}
+	for _, tt := range tests {
+		got, ok := Abbreviate(tt.amount)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Abbreviate(%v) = %q, %v, want %q, %v", tt.amount, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestShort(t *testing.T) {
+	f := New(stubCurrencies{"EUR": "€"})
+	t.Cleanup(func() {
+		viper.Set("ui.currency_display", nil)
+		viper.Set("ui.abbreviate_amounts", nil)
+	})
+
+	if got := f.Short(12345.67, "EUR"); got != "12345.67 EUR" {
+		t.Errorf("expected the exact amount by default, got %q", got)
+	}
+	viper.Set("ui.abbreviate_amounts", true)
+	if got := f.Short(-12345.67, "EUR"); got != "-12.3k EUR" {
+		t.Errorf("expected the amount abbreviated, got %q", got)
+	}
+	if got := f.Short(12.5, "EUR"); got != "12.50 EUR" {
+		t.Errorf("expected small amounts exact, got %q", got)
+	}
+	if got := ShortNumber(-2500); got != "-2.5k" {
+		t.Errorf("expected the number abbreviated, got %q", got)
+	}
+	viper.Set("ui.currency_display", "symbol")
+	if got := f.Short(-2500, "EUR"); got != "-€2.5k" {
+		t.Errorf("expected the symbol before the abbreviation, got %q", got)
+	}
+	if got := f.Format(2500, "EUR"); got != "€2500.00" {
+		t.Errorf("expected Format to stay exact, got %q", got)
+	}
+}
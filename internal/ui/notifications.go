@@ -0,0 +1,43 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"ffiii-tui/internal/ui/notify"
+	"ffiii-tui/internal/ui/notifylog"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	transactionLinkKind = "transaction"
+	recurrenceLinkKind  = "recurrence"
+)
+
+func transactionLink(id string) notify.Link {
+	return notify.Link{Kind: transactionLinkKind, ID: id, WebPath: "transactions/show/" + id}
+}
+
+func recurrenceLink(id string) notify.Link {
+	return notify.Link{Kind: recurrenceLinkKind, ID: id, WebPath: "recurring/show/" + id}
+}
+
+// notifySaved notifies about a saved object, with a link to it when the
+// server returned its ID.
+func notifySaved(message string, link notify.Link) tea.Cmd {
+	if link.ID == "" {
+		return notify.NotifyLog(message)
+	}
+	return notify.NotifyLink(message, link)
+}
+
+// openLink opens the object of a notification: transactions in the form,
+// everything else in the web interface.
+func (m modelUI) openLink(msg notifylog.OpenLinkMsg) tea.Cmd {
+	if !msg.Web && msg.Link.Kind == transactionLinkKind {
+		return Cmd(OpenTransactionMsg{TransactionID: msg.Link.ID})
+	}
+	return m.openInWeb(msg.Link.WebPath)
+}
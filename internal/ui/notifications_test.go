@@ -0,0 +1,61 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"testing"
+
+	"ffiii-tui/internal/ui/notify"
+	"ffiii-tui/internal/ui/notifylog"
+)
+
+func TestNotifySaved(t *testing.T) {
+	n, ok := notifySaved("Transaction #12 created", transactionLink("12"))().(notify.NotifyMsg)
+	if !ok || n.Link == nil || n.Link.ID != "12" || n.Link.WebPath != "transactions/show/12" {
+		t.Errorf("expected a link to transaction 12, got %+v", n)
+	}
+
+	n, _ = notifySaved("Saved offline", transactionLink(""))().(notify.NotifyMsg)
+	if n.Link != nil {
+		t.Errorf("expected no link without an ID, got %+v", n.Link)
+	}
+}
+
+func TestUI_OpenLink(t *testing.T) {
+	opened := stubBrowser(t, nil)
+	m := newTestModelUI()
+	m.api.(*mockUIAPI).serverURL = "https://firefly.example.com/api/v1"
+
+	_, cmd := m.Update(notifylog.OpenLinkMsg{Link: transactionLink("12")})
+	open, ok := findMsg[OpenTransactionMsg](collectMsgsFromCmd(cmd))
+	if !ok || open.TransactionID != "12" {
+		t.Errorf("expected the transaction opened in the form, got %v", open)
+	}
+
+	_, cmd = m.Update(notifylog.OpenLinkMsg{Link: transactionLink("12"), Web: true})
+	collectMsgsFromCmd(cmd)
+	_, cmd = m.Update(notifylog.OpenLinkMsg{Link: recurrenceLink("3")})
+	collectMsgsFromCmd(cmd)
+	want := []string{
+		"https://firefly.example.com/transactions/show/12",
+		"https://firefly.example.com/recurring/show/3",
+	}
+	if len(*opened) != 2 || (*opened)[0] != want[0] || (*opened)[1] != want[1] {
+		t.Errorf("expected %v opened, got %v", want, *opened)
+	}
+}
+
+func TestUI_NotificationHistory(t *testing.T) {
+	m := newTestModelUI()
+
+	m, _ = updateModel(m, notify.NotifyMsg{Message: "Transaction #12 created", Link: &notify.Link{Kind: transactionLinkKind, ID: "12"}})
+	m, _ = updateModel(m, notify.NotifyMsg{Message: "Later"})
+
+	_, cmd := m.Update(runeKey('O'))
+	open, ok := cmd().(notifylog.OpenMsg)
+	if !ok || len(open.Messages) != 2 || open.Messages[0].Message != "Later" {
+		t.Errorf("expected the notifications newest first, got %+v", open)
+	}
+}
@@ -14,6 +14,8 @@ import (
 
 const (
 	MaxQueueSize = 20
+	// MaxHistorySize is how many notifications the history keeps
+	MaxHistorySize = 50
 
 	LogDuration   = 5 * time.Second
 	WarnDuration  = 7 * time.Second
@@ -27,6 +29,19 @@ type NotifyMsg struct {
 	Message  string
 	Level    NotifyLevel
 	Duration *time.Duration
+	// Link is the object the notification is about, if any
+	Link *Link
+}
+
+// Link points to an object created or updated on the server, so it can be
+// opened from the notification history.
+type Link struct {
+	// Kind is the type of object, e.g. "transaction" or "recurrence"
+	Kind string
+	ID   string
+	// WebPath is the page of the object in the Firefly III web interface,
+	// relative to its base URL
+	WebPath string
 }
 
 type NotifyLevel uint
@@ -58,6 +73,7 @@ type QueuedMessage struct {
 	Duration  time.Duration
 	Timestamp time.Time
 	State     MessageState
+	Link      *Link
 }
 
 type notifyQueue struct {
@@ -69,6 +85,7 @@ type notifyQueue struct {
 
 type Model struct {
 	queue        *notifyQueue
+	history      *[]QueuedMessage
 	styles       Styles
 	Width        int
 	isDisplaying bool
@@ -96,6 +113,18 @@ func NotifyWithDuration(message string, level NotifyLevel, duration time.Duratio
 	})
 }
 
+// NotifyLink notifies about the object at link, which can be opened from
+// the notification history.
+func NotifyLink(message string, link Link) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		return NotifyMsg{
+			Message: message,
+			Level:   Log,
+			Link:    &link,
+		}
+	})
+}
+
 func ShowNextNotification() tea.Cmd {
 	return tea.Cmd(func() tea.Msg {
 		return NotifyShowNextMsg{}
@@ -116,8 +145,10 @@ func NotifyError(message string) tea.Cmd {
 
 func New() Model {
 	queue := newNotifyQueue()
+	history := make([]QueuedMessage, 0, MaxHistorySize)
 	return Model{
 		queue:        &queue,
+		history:      &history,
 		styles:       DefaultStyles(),
 		isDisplaying: false,
 	}
@@ -172,7 +203,11 @@ func (m Model) View() string {
 // Private methods for queue management and state machine
 
 func (m Model) enqueueMessage(msg NotifyMsg) (tea.Model, tea.Cmd) {
-	m.queue.Enqueue(msg)
+	queued := m.queue.Enqueue(msg)
+	if len(*m.history) >= MaxHistorySize {
+		*m.history = (*m.history)[1:]
+	}
+	*m.history = append(*m.history, queued)
 
 	if m.queue.current == nil && !m.isDisplaying {
 		return m.startDisplaying()
@@ -224,6 +259,15 @@ func (m Model) styleMessage(text string, level NotifyLevel) string {
 	return style.Width(m.Width).Render(text)
 }
 
+// History returns the notifications received, newest first.
+func (m Model) History() []QueuedMessage {
+	history := make([]QueuedMessage, 0, len(*m.history))
+	for i := len(*m.history) - 1; i >= 0; i-- {
+		history = append(history, (*m.history)[i])
+	}
+	return history
+}
+
 func (m *Model) WithWidth(width int) *Model {
 	m.Width = width
 	return m
@@ -256,6 +300,7 @@ func (q *notifyQueue) Enqueue(msg NotifyMsg) QueuedMessage {
 		Duration:  duration,
 		Timestamp: time.Now(),
 		State:     Queued,
+		Link:      msg.Link,
 	}
 
 	// Handle queue overflow - remove oldest messages
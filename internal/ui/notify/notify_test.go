@@ -0,0 +1,39 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package notify
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestHistory_NewestFirstAndBounded(t *testing.T) {
+	m := New()
+	for i := range MaxHistorySize + 5 {
+		updated, _ := m.Update(NotifyMsg{Message: fmt.Sprint(i)})
+		m = updated.(Model)
+	}
+
+	history := m.History()
+	if len(history) != MaxHistorySize {
+		t.Fatalf("Expected %d notifications kept, got %d", MaxHistorySize, len(history))
+	}
+	if history[0].Message != fmt.Sprint(MaxHistorySize+4) || history[len(history)-1].Message != "5" {
+		t.Errorf("Expected the newest first, got %q .. %q", history[0].Message, history[len(history)-1].Message)
+	}
+}
+
+func TestNotifyLink(t *testing.T) {
+	msg, ok := NotifyLink("Transaction #12 created", Link{Kind: "transaction", ID: "12"})().(NotifyMsg)
+	if !ok || msg.Level != Log || msg.Link == nil || msg.Link.ID != "12" {
+		t.Fatalf("Expected a log notification with a link, got %+v", msg)
+	}
+
+	m := New()
+	updated, _ := m.Update(msg)
+	if h := updated.(Model).History(); len(h) != 1 || h[0].Link == nil {
+		t.Errorf("Expected the link kept in the history, got %+v", h)
+	}
+}
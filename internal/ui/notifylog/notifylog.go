@@ -0,0 +1,181 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package notifylog
+
+import (
+	"fmt"
+	"strings"
+
+	"ffiii-tui/internal/ui/notify"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type OpenMsg struct {
+	Messages []notify.QueuedMessage
+}
+
+type CloseMsg struct{}
+
+// OpenLinkMsg opens the object of a notification, in the web interface when
+// Web is set, otherwise in the TUI.
+type OpenLinkMsg struct {
+	Link notify.Link
+	Web  bool
+}
+
+type Model struct {
+	messages []notify.QueuedMessage
+	cursor   int
+	focus    bool
+	styles   Styles
+	Width    int
+	Height   int
+}
+
+func New() Model {
+	return Model{
+		styles: DefaultStyles(),
+		Width:  80,
+		Height: 24,
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case OpenMsg:
+		m.messages = msg.Messages
+		m.cursor = 0
+		m.Focus()
+		return m, nil
+	case CloseMsg:
+		m.Blur()
+		return m, nil
+	}
+
+	if !m.focus {
+		return m, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc", "q":
+			return m, Close()
+		case "enter", "w":
+			if m.cursor < len(m.messages) && m.messages[m.cursor].Link != nil {
+				m.Blur()
+				link := OpenLinkMsg{Link: *m.messages[m.cursor].Link, Web: msg.String() == "w"}
+				return m, func() tea.Msg { return link }
+			}
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.messages)-1 {
+				m.cursor++
+			}
+		case "home", "g":
+			m.cursor = 0
+		case "end", "G":
+			m.cursor = max(len(m.messages)-1, 0)
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) View() string {
+	if !m.focus {
+		return ""
+	}
+
+	borderW, borderH := m.styles.Border.GetFrameSize()
+	bodyHeight := max(m.Height-borderH-3, 1)
+
+	var b strings.Builder
+	b.WriteString(m.styles.Title.Render("Notifications") +
+		m.styles.Desc.Render(fmt.Sprintf("  %d recent, newest first (esc to close, enter to open ↗ in the TUI, w in the web interface)", len(m.messages))) + "\n")
+	b.WriteString(m.styles.Header.Render(fmt.Sprintf("%-8s  %s", "TIME", "MESSAGE")) + "\n")
+
+	if len(m.messages) == 0 {
+		b.WriteString("\n" + m.styles.Empty.Render("No notifications yet"))
+	} else {
+		offset := max(m.cursor-bodyHeight+1, 0)
+		end := min(offset+bodyHeight, len(m.messages))
+		rows := make([]string, 0, end-offset)
+		for i := offset; i < end; i++ {
+			rows = append(rows, m.row(m.messages[i], i == m.cursor))
+		}
+		b.WriteString(strings.Join(rows, "\n"))
+	}
+
+	return m.styles.Border.
+		Width(max(m.Width-borderW, 0)).
+		Height(max(m.Height-borderH, 0)).
+		Render(b.String())
+}
+
+// row renders a notification, the ones with an object to open are marked.
+func (m Model) row(n notify.QueuedMessage, selected bool) string {
+	line := fmt.Sprintf("%-8s  %s", n.Timestamp.Format("15:04:05"), n.Message)
+	if n.Link != nil {
+		line += " ↗"
+	}
+	borderW, _ := m.styles.Border.GetFrameSize()
+	if width := m.Width - borderW; width > 0 && lipgloss.Width(line) > width {
+		line = string([]rune(line)[:max(width-1, 0)]) + "…"
+	}
+
+	switch {
+	case selected:
+		return m.styles.Selected.Render(line)
+	case n.Level == notify.Err:
+		return m.styles.Err.Render(line)
+	case n.Level == notify.Warn:
+		return m.styles.Warn.Render(line)
+	}
+	return m.styles.Row.Render(line)
+}
+
+func (m *Model) Focus() {
+	m.focus = true
+}
+
+func (m *Model) Blur() {
+	m.focus = false
+}
+
+func (m *Model) Focused() bool {
+	return m.focus
+}
+
+func (m *Model) WithSize(width, height int) *Model {
+	m.Width = width
+	m.Height = height
+	return m
+}
+
+func (m *Model) WithStyles(styles Styles) *Model {
+	m.styles = styles
+	return m
+}
+
+func Open(messages []notify.QueuedMessage) tea.Cmd {
+	return func() tea.Msg {
+		return OpenMsg{Messages: messages}
+	}
+}
+
+func Close() tea.Cmd {
+	return func() tea.Msg {
+		return CloseMsg{}
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package notifylog
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"ffiii-tui/internal/ui/notify"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func testMessages() []notify.QueuedMessage {
+	at := time.Date(2026, 1, 15, 10, 30, 0, 0, time.Local)
+	return []notify.QueuedMessage{
+		{Message: "Tag renamed", Level: notify.Log, Timestamp: at},
+		{
+			Message:   "Transaction #12 created",
+			Level:     notify.Log,
+			Timestamp: at,
+			Link:      &notify.Link{Kind: "transaction", ID: "12", WebPath: "transactions/show/12"},
+		},
+	}
+}
+
+func openModel(t *testing.T, messages []notify.QueuedMessage) Model {
+	t.Helper()
+	m := New()
+	updated, _ := m.Update(OpenMsg{Messages: messages})
+	m = updated.(Model)
+	if !m.Focused() {
+		t.Fatal("Expected model to be focused after OpenMsg")
+	}
+	return m
+}
+
+func key(s string) tea.KeyMsg {
+	switch s {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestView_Messages(t *testing.T) {
+	m := openModel(t, testMessages())
+	view := m.View()
+
+	for _, want := range []string{"Notifications", "10:30:00", "Tag renamed", "Transaction #12 created ↗"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected view to contain %q", want)
+		}
+	}
+}
+
+func TestUpdate_OpenLink(t *testing.T) {
+	m := openModel(t, testMessages())
+
+	if _, cmd := m.Update(key("enter")); cmd != nil {
+		t.Error("Expected nothing to open without a link")
+	}
+
+	updated, _ := m.Update(key("j"))
+	m = updated.(Model)
+	for _, k := range []string{"enter", "w"} {
+		_, cmd := m.Update(key(k))
+		if cmd == nil {
+			t.Fatalf("Expected %s to open the link", k)
+		}
+		open, ok := cmd().(OpenLinkMsg)
+		if !ok || open.Link.ID != "12" || open.Web != (k == "w") {
+			t.Errorf("Expected the link opened by %s, got %+v", k, cmd())
+		}
+	}
+}
+
+func TestUpdate_Close(t *testing.T) {
+	m := openModel(t, nil)
+	if !strings.Contains(m.View(), "No notifications yet") {
+		t.Error("Expected the empty hint")
+	}
+
+	_, cmd := m.Update(key("esc"))
+	if _, ok := cmd().(CloseMsg); !ok {
+		t.Error("Expected CloseMsg on esc")
+	}
+}
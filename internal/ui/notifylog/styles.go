@@ -0,0 +1,47 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package notifylog
+
+import "github.com/charmbracelet/lipgloss"
+
+type Styles struct {
+	Border   lipgloss.Style
+	Title    lipgloss.Style
+	Header   lipgloss.Style
+	Row      lipgloss.Style
+	Selected lipgloss.Style
+	Warn     lipgloss.Style
+	Err      lipgloss.Style
+	Desc     lipgloss.Style
+	Empty    lipgloss.Style
+}
+
+func DefaultStyles() Styles {
+	return Styles{
+		Border: lipgloss.NewStyle().
+			BorderStyle(lipgloss.ThickBorder()).
+			BorderForeground(lipgloss.Color("#5FAFAF")),
+		Title: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#5FAFAF")),
+		Header: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#D75F87")),
+		Row: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#DDDADA")),
+		Selected: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#FFFFFF")).
+			Background(lipgloss.Color("#3A3A3A")),
+		Warn: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#D7AF5F")),
+		Err: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#D75F5F")),
+		Desc: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#8A8A8A")),
+		Empty: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#585858")),
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/notify"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// reconnectInterval is how often an unreachable server is probed and queued
+// writes are retried.
+const reconnectInterval = 15 * time.Second
+
+type (
+	reconnectTickMsg struct{}
+	ReconnectedMsg   struct {
+		Replayed  int
+		Conflicts []firefly.Conflict
+		Err       error
+	}
+)
+
+func reconnectTick() tea.Cmd {
+	return tea.Tick(reconnectInterval, func(time.Time) tea.Msg {
+		return reconnectTickMsg{}
+	})
+}
+
+// reconnect probes the server and replays queued writes. It returns nil
+// when online with nothing queued.
+func (m *modelUI) reconnect() tea.Cmd {
+	if !m.api.Offline() && m.api.QueuedWrites() == 0 {
+		return nil
+	}
+
+	api := m.api
+	return func() tea.Msg {
+		replayed, conflicts, err := api.Reconnect(context.Background())
+		return ReconnectedMsg{Replayed: replayed, Conflicts: conflicts, Err: err}
+	}
+}
+
+// reconnected reports the outcome of a replay and reloads the data once the
+// server is reachable again.
+func (m *modelUI) reconnected(msg ReconnectedMsg) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, c := range msg.Conflicts {
+		cmds = append(cmds, notify.NotifyError(fmt.Sprintf("Not synced, %s: %s", c.Write.Summary, c.Reason)))
+	}
+	if msg.Replayed > 0 {
+		cmds = append(cmds, notify.NotifyLog(fmt.Sprintf("Synced %d queued change(s)", msg.Replayed)))
+	}
+	if msg.Err == nil {
+		cmds = append(cmds, Cmd(RefreshAllMsg{}))
+	}
+	return tea.Batch(cmds...)
+}
+
+// offlineSegment shows that the server is unreachable and how many changes
+// are waiting to be sent.
+func (m *modelUI) offlineSegment() string {
+	queued := m.api.QueuedWrites()
+	switch {
+	case m.api.Offline() && queued > 0:
+		return m.styles.StatusBarOffline.Render(fmt.Sprintf("offline, %d queued", queued))
+	case m.api.Offline():
+		return m.styles.StatusBarOffline.Render("offline")
+	case queued > 0:
+		return m.styles.StatusBarOffline.Render(fmt.Sprintf("%d queued", queued))
+	}
+	return ""
+}
@@ -0,0 +1,125 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/notify"
+)
+
+func TestOffline_OnlineDoesNotReconnect(t *testing.T) {
+	m := newTestModelUI()
+
+	if cmd := m.reconnect(); cmd != nil {
+		t.Error("Expected no reconnect while online with an empty queue")
+	}
+}
+
+func TestOffline_ReconnectReplaysQueue(t *testing.T) {
+	api := newTestUIAPI()
+	api.offline = true
+	api.queuedWrites = 2
+	api.reconnectFunc = func() (int, []firefly.Conflict, error) {
+		return 1, []firefly.Conflict{{
+			Write:  firefly.QueuedWrite{Summary: "delete transaction #7"},
+			Reason: "deleted on the server",
+		}}, nil
+	}
+	m := NewModelUI(api)
+
+	cmd := m.reconnect()
+	if cmd == nil {
+		t.Fatal("Expected reconnect command while offline")
+	}
+	msg, ok := cmd().(ReconnectedMsg)
+	if !ok {
+		t.Fatal("Expected ReconnectedMsg")
+	}
+	if api.reconnectCalled != 1 || msg.Replayed != 1 || len(msg.Conflicts) != 1 {
+		t.Errorf("Unexpected reconnect result: %+v", msg)
+	}
+}
+
+func TestOffline_ReconnectedReportsConflictsAndRefreshes(t *testing.T) {
+	m := newTestModelUI()
+
+	msgs := collectMsgsFromCmd(m.reconnected(ReconnectedMsg{
+		Replayed: 1,
+		Conflicts: []firefly.Conflict{{
+			Write:  firefly.QueuedWrite{Summary: "update transaction Rent"},
+			Reason: "changed on the server since 10:00",
+		}},
+	}))
+
+	conflict, synced := false, false
+	for _, msg := range msgs {
+		if n, ok := msg.(notify.NotifyMsg); ok {
+			conflict = conflict || (n.Level == notify.Err && strings.Contains(n.Message, "Rent"))
+			synced = synced || strings.Contains(n.Message, "Synced 1")
+		}
+	}
+	if !conflict || !synced {
+		t.Errorf("Expected conflict and sync notifications, got %v", msgs)
+	}
+	if !hasMsg[RefreshAllMsg](msgs) {
+		t.Error("Expected data refresh once back online")
+	}
+}
+
+func TestOffline_StillOfflineDoesNotRefresh(t *testing.T) {
+	m := newTestModelUI()
+
+	msgs := collectMsgsFromCmd(m.reconnected(ReconnectedMsg{Err: firefly.ErrOffline}))
+	if hasMsg[RefreshAllMsg](msgs) {
+		t.Error("Expected no refresh while the server is unreachable")
+	}
+}
+
+func TestOffline_StatusBarShowsQueue(t *testing.T) {
+	api := newTestUIAPI()
+	m := NewModelUI(api)
+	m.Width = 200
+
+	if bar := m.statusBar(); strings.Contains(bar, "offline") {
+		t.Errorf("Expected no offline marker while online, got %q", bar)
+	}
+
+	api.offline = true
+	api.queuedWrites = 3
+	if bar := m.statusBar(); !strings.Contains(bar, "offline, 3 queued") {
+		t.Errorf("Expected offline marker with queue size, got %q", bar)
+	}
+}
+
+func TestOffline_QueuedCreateClosesForm(t *testing.T) {
+	api := &mockTransactionFormAPI{
+		createTransactionFunc: func(tx firefly.RequestTransaction) (string, error) {
+			return "", firefly.ErrQueued
+		},
+	}
+	m := newModelTransaction(api)
+	m.created = true
+	m.new = true
+	m.splits = []*split{{
+		source:      testAssetChecking,
+		destination: testExpenseGroceries,
+		amount:      "50.00",
+		description: "Groceries",
+	}}
+
+	msgs := collectMsgsFromCmd(m.CreateTransaction())
+
+	if m.created {
+		t.Error("Expected form to be reset after queueing")
+	}
+	for _, msg := range msgs {
+		if n, ok := msg.(notify.NotifyMsg); ok && n.Level == notify.Err {
+			t.Errorf("Expected no error notification, got %q", n.Message)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/loading"
+	"ffiii-tui/internal/ui/notify"
+	"ffiii-tui/internal/ui/prompt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// askOpeningBalance prompts for the opening balance of an asset account as
+// "amount date", e.g. "1500 2026-01-01"; the date defaults to the current
+// opening date, or today.
+func askOpeningBalance(api OpeningBalanceAPI, account firefly.Account) tea.Cmd {
+	current := fmt.Sprintf("%.2f", account.OpeningBalance)
+	if date := openingDate(account); date != "" {
+		current += " " + date
+	}
+	return prompt.Ask(
+		fmt.Sprintf("Opening balance of %s (amount date): ", account.Name),
+		current,
+		func(value string) tea.Cmd {
+			if strings.TrimSpace(value) == "" || value == "None" {
+				return nil
+			}
+			amount, date, err := parseOpeningBalance(value, account, time.Now())
+			if err != nil {
+				return notify.NotifyWarn(err.Error())
+			}
+			if amount == account.OpeningBalance && date == openingDate(account) {
+				return nil
+			}
+			return setOpeningBalance(api, account, amount, date)
+		},
+	)
+}
+
+func parseOpeningBalance(value string, account firefly.Account, now time.Time) (float64, string, error) {
+	fields := strings.Fields(value)
+	if len(fields) == 0 || len(fields) > 2 {
+		return 0, "", fmt.Errorf("expected an amount and a date, e.g. 1500 %s", now.Format(time.DateOnly))
+	}
+	amount, err := strconv.ParseFloat(strings.ReplaceAll(fields[0], ",", "."), 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid amount: %s", fields[0])
+	}
+	date := openingDate(account)
+	if len(fields) == 2 {
+		date = fields[1]
+	}
+	if date == "" {
+		date = now.Format(time.DateOnly)
+	}
+	if _, err := time.Parse(time.DateOnly, date); err != nil {
+		return 0, "", fmt.Errorf("invalid date: %s, expected YYYY-MM-DD", date)
+	}
+	return amount, date, nil
+}
+
+// openingDate cuts the opening balance timestamp of the API to its date.
+func openingDate(account firefly.Account) string {
+	date := account.OpeningBalanceDate
+	if len(date) > len(time.DateOnly) {
+		date = date[:len(time.DateOnly)]
+	}
+	return date
+}
+
+// setOpeningBalance saves the opening balance and reloads what depends on
+// it: the balances and the opening balance transaction.
+func setOpeningBalance(api OpeningBalanceAPI, account firefly.Account, amount float64, date string) tea.Cmd {
+	return loading.Track("Saving opening balance...", func() tea.Msg {
+		err := api.UpdateOpeningBalance(context.Background(), account, amount, date)
+		if err != nil && !errors.Is(err, firefly.ErrQueued) {
+			return notify.NotifyWarn(fmt.Sprintf("Failed to set the opening balance of %s: %s", account.Name, errorText(err)))()
+		}
+		note := notify.NotifyLog(fmt.Sprintf("Opening balance of %s set to %.2f on %s", account.Name, amount, date))
+		if err != nil {
+			note = notify.NotifyWarn(err.Error())
+		}
+		return tea.BatchMsg{
+			note,
+			Publish(AccountsChanged),
+		}
+	})
+}
@@ -0,0 +1,104 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/notify"
+	"ffiii-tui/internal/ui/prompt"
+)
+
+type mockOpeningBalanceAPI struct {
+	err    error
+	amount float64
+	date   string
+	calls  int
+}
+
+func (m *mockOpeningBalanceAPI) UpdateOpeningBalance(_ context.Context, _ firefly.Account, amount float64, date string) error {
+	m.calls++
+	m.amount = amount
+	m.date = date
+	return m.err
+}
+
+func TestParseOpeningBalance(t *testing.T) {
+	now := time.Date(2026, time.March, 18, 0, 0, 0, 0, time.UTC)
+	account := firefly.Account{OpeningBalanceDate: "2025-01-01T00:00:00+01:00"}
+
+	tests := []struct {
+		value   string
+		account firefly.Account
+		amount  float64
+		date    string
+		wantErr bool
+	}{
+		{"1500 2026-01-01", account, 1500, "2026-01-01", false},
+		{"12,50", account, 12.5, "2025-01-01", false},
+		{"100", firefly.Account{}, 100, "2026-03-18", false},
+		{"0", account, 0, "2025-01-01", false},
+		{"abc", account, 0, "", true},
+		{"100 01/01/2026", account, 0, "", true},
+		{"100 2026-01-01 extra", account, 0, "", true},
+	}
+	for _, tt := range tests {
+		amount, date, err := parseOpeningBalance(tt.value, tt.account, now)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%q: expected error %v, got %v", tt.value, tt.wantErr, err)
+			continue
+		}
+		if amount != tt.amount || date != tt.date {
+			t.Errorf("%q: expected %.2f on %s, got %.2f on %s", tt.value, tt.amount, tt.date, amount, date)
+		}
+	}
+}
+
+func TestAskOpeningBalance(t *testing.T) {
+	api := &mockOpeningBalanceAPI{}
+	account := firefly.Account{ID: "1", Name: "Checking", Type: "asset", OpeningBalance: 100, OpeningBalanceDate: "2025-01-01"}
+
+	ask, ok := askOpeningBalance(api, account)().(prompt.PromptMsg)
+	if !ok {
+		t.Fatal("expected a prompt")
+	}
+	if ask.Value != "100.00 2025-01-01" {
+		t.Errorf("expected the current opening balance as default, got %q", ask.Value)
+	}
+
+	for _, value := range []string{"None", "", "100 2025-01-01"} {
+		if cmd := ask.Callback(value); cmd != nil {
+			collectMsgsFromCmd(cmd)
+		}
+	}
+	if api.calls != 0 {
+		t.Errorf("expected no update for an unchanged value, got %d", api.calls)
+	}
+
+	msgs := dispatched(collectMsgsFromCmd(ask.Callback("250 2025-02-01")))
+	if api.amount != 250 || api.date != "2025-02-01" {
+		t.Errorf("expected 250 on 2025-02-01, got %.2f on %s", api.amount, api.date)
+	}
+	if !hasMsg[RefreshAssetsMsg](msgs) || !hasMsg[RefreshSummaryMsg](msgs) || !hasMsg[RefreshTransactionsMsg](msgs) {
+		t.Errorf("expected assets, summary and transactions refresh, got %v", msgs)
+	}
+}
+
+func TestSetOpeningBalance_Error(t *testing.T) {
+	api := &mockOpeningBalanceAPI{err: errors.New("boom")}
+
+	msgs := dispatched(collectMsgsFromCmd(setOpeningBalance(api, firefly.Account{Name: "Checking"}, 10, "2026-01-01")))
+
+	if hasMsg[RefreshAssetsMsg](msgs) {
+		t.Error("expected no refresh after a failed update")
+	}
+	if !hasMsg[notify.NotifyMsg](msgs) {
+		t.Errorf("expected a notification, got %v", msgs)
+	}
+}
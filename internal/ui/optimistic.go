@@ -0,0 +1,104 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"ffiii-tui/internal/firefly"
+)
+
+// transactionSavedMsg shows a created or updated transaction in the table
+// right away. The refresh sent along replaces it with the server's copy.
+type transactionSavedMsg struct {
+	Transaction firefly.Transaction
+}
+
+// savedTransaction builds the transaction the form was saved as, id is the
+// ID the server returned, if any.
+func (m *modelTransaction) savedTransaction(id string) firefly.Transaction {
+	if id == "" {
+		id = m.attr.trxID
+	}
+	splits := make([]firefly.Split, 0, len(m.splits))
+	for _, s := range m.splits {
+		amount, _ := strconv.ParseFloat(s.amount, 64)
+		foreignAmount, _ := strconv.ParseFloat(s.foreignAmount, 64)
+		splits = append(splits, firefly.Split{
+			TransactionJournalID: s.trxJID,
+			Source:               s.source,
+			Destination:          s.destination,
+			Category:             s.category,
+			Currency:             s.CurrencyCode(),
+			ForeignCurrency:      s.ForeignCurrencyCode(),
+			Amount:               amount,
+			ForeignAmount:        foreignAmount,
+			Description:          s.Description(),
+			Location:             s.Location(),
+			InternalReference:    s.internalReference,
+			ExternalID:           s.externalID,
+			InvoiceDate:          s.invoiceDate,
+		})
+	}
+	return firefly.Transaction{
+		TransactionID: id,
+		Type:          m.TransactionType(),
+		Date:          fmt.Sprintf("%s-%s-%sT00:00:00Z", m.attr.year, m.attr.month, m.attr.day),
+		GroupTitle:    m.GroupTitle(),
+		Splits:        splits,
+		UpdatedAt:     time.Now(),
+	}
+}
+
+// putTransaction replaces the loaded transaction with the ID of tx, or
+// inserts tx before the first older one. A replaced transaction keeps its
+// time of day and the reconciliation, tags and budget of its splits, the
+// form does not change them, and the location of splits saved without one.
+func (m *modelTransactions) putTransaction(tx firefly.Transaction) {
+	i := slices.IndexFunc(m.transactions, func(t firefly.Transaction) bool {
+		return t.TransactionID == tx.TransactionID
+	})
+	if i < 0 {
+		at := slices.IndexFunc(m.transactions, func(t firefly.Transaction) bool {
+			return t.Date < tx.Date
+		})
+		if at < 0 {
+			at = len(m.transactions)
+		}
+		m.transactions = slices.Insert(m.transactions, at, tx)
+		return
+	}
+
+	old := m.transactions[i]
+	if len(old.Date) >= 10 && old.Date[:10] == tx.Date[:10] {
+		tx.Date = old.Date
+	}
+	tx.ID = old.ID
+	for j, s := range tx.Splits {
+		k := slices.IndexFunc(old.Splits, func(o firefly.Split) bool {
+			return o.TransactionJournalID == s.TransactionJournalID
+		})
+		if s.TransactionJournalID != "" && k >= 0 {
+			tx.Splits[j].Reconciled = old.Splits[k].Reconciled
+			tx.Splits[j].Tags = old.Splits[k].Tags
+			tx.Splits[j].Budget = old.Splits[k].Budget
+			// A cleared location field leaves the stored one
+			if s.Location.IsEmpty() {
+				tx.Splits[j].Location = old.Splits[k].Location
+			}
+		}
+	}
+	m.transactions[i] = tx
+}
+
+// removeTransaction drops a deleted transaction from the loaded ones.
+func (m *modelTransactions) removeTransaction(id string) {
+	m.transactions = slices.DeleteFunc(m.transactions, func(t firefly.Transaction) bool {
+		return t.TransactionID == id
+	})
+}
@@ -0,0 +1,120 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+)
+
+func TestPutTransaction_InsertsByDate(t *testing.T) {
+	m := NewModelTransactions(&mockTransactionAPI{})
+	m.transactions = []firefly.Transaction{
+		newTestTransaction(0, "tx3", "withdrawal", "2024-01-20T10:00:00Z", "Newest"),
+		newTestTransaction(1, "tx1", "withdrawal", "2024-01-10T10:00:00Z", "Oldest"),
+	}
+
+	m.putTransaction(newTestTransaction(0, "tx2", "withdrawal", "2024-01-15T00:00:00Z", "Created"))
+
+	ids := []string{}
+	for _, tx := range m.transactions {
+		ids = append(ids, tx.TransactionID)
+	}
+	if len(ids) != 3 || ids[1] != "tx2" {
+		t.Errorf("expected the new transaction between the others, got %v", ids)
+	}
+}
+
+func TestPutTransaction_ReplaceKeepsServerFields(t *testing.T) {
+	m := NewModelTransactions(&mockTransactionAPI{})
+	old := newTestTransaction(4, "tx1", "withdrawal", "2024-01-15T10:30:00+01:00", "Coffee")
+	old.Splits[0].TransactionJournalID = "j1"
+	old.Splits[0].Reconciled = true
+	old.Splits[0].Tags = []string{"work"}
+	m.transactions = []firefly.Transaction{old}
+
+	updated := newTestTransaction(0, "tx1", "withdrawal", "2024-01-15T00:00:00Z", "Tea")
+	updated.Splits[0].TransactionJournalID = "j1"
+	m.putTransaction(updated)
+
+	got := m.transactions[0]
+	if len(m.transactions) != 1 || got.Splits[0].Description != "Tea" {
+		t.Fatalf("expected the transaction to be replaced, got %+v", m.transactions)
+	}
+	if got.Date != old.Date || got.ID != 4 {
+		t.Errorf("expected the time of day and row ID to be kept, got %q, %d", got.Date, got.ID)
+	}
+	if !got.Splits[0].Reconciled || len(got.Splits[0].Tags) != 1 {
+		t.Errorf("expected reconciliation and tags to be kept, got %+v", got.Splits[0])
+	}
+}
+
+func TestTransactionSavedMsg_ShowsRow(t *testing.T) {
+	m := NewModelTransactions(&mockTransactionAPI{})
+	tx := newTestTransaction(0, "tx9", "withdrawal", "2024-01-15T00:00:00Z", "Created")
+
+	model, cmd := m.Update(transactionSavedMsg{Transaction: tx})
+	m = model.(modelTransactions)
+	filter, ok := findMsg[FilterMsg](collectMsgsFromCmd(cmd))
+	if !ok || filter.TrxID != "tx9" {
+		t.Fatalf("expected the table to show the transaction, got %v", filter)
+	}
+	m, _ = updateModel(m, filter)
+	if row := m.table.SelectedRow(); row == nil || row[11] != "tx9" {
+		t.Errorf("expected the cursor on the saved transaction, got %v", row)
+	}
+}
+
+func TestTransaction_CreateShowsRowBeforeRefresh(t *testing.T) {
+	api := &mockTransactionFormAPI{
+		createTransactionFunc: func(tx firefly.RequestTransaction) (string, error) {
+			return "42", nil
+		},
+	}
+	m := newCompletedTransactionModel(api)
+
+	msgs := dispatched(collectMsgsFromCmd(m.CreateTransaction()))
+	saved, ok := findMsg[transactionSavedMsg](msgs)
+	if !ok {
+		t.Fatalf("expected the created transaction to be shown, got %v", msgs)
+	}
+	tx := saved.Transaction
+	if tx.TransactionID != "42" || tx.Date != "2026-01-15T00:00:00Z" || tx.Type != "withdrawal" {
+		t.Errorf("unexpected transaction %+v", tx)
+	}
+	if len(tx.Splits) != 1 || tx.Splits[0].Amount != 50 || tx.Splits[0].Description != "Test" {
+		t.Errorf("unexpected splits %+v", tx.Splits)
+	}
+	if !hasMsg[RefreshTransactionsMsg](msgs) {
+		t.Error("expected a refresh to reconcile with the server")
+	}
+}
+
+func TestDeleteTransactionMsg_RemovesRow(t *testing.T) {
+	m := NewModelTransactions(&mockTransactionAPI{})
+	m.transactions = []firefly.Transaction{
+		newTestTransaction(0, "tx1", "withdrawal", "2024-01-15T10:00:00Z", "Keep"),
+		newTestTransaction(1, "tx2", "withdrawal", "2024-01-14T10:00:00Z", "Delete"),
+	}
+
+	_, cmd := m.Update(DeleteTransactionMsg{Transaction: m.transactions[1]})
+	if len(m.transactions) != 2 {
+		t.Error("expected the row kept until the transaction is deleted")
+	}
+	deleted, ok := findMsg[transactionDeletedMsg](collectMsgsFromCmd(cmd))
+	if !ok {
+		t.Fatal("expected the transaction to be deleted")
+	}
+	model, cmd := m.Update(deleted)
+	m = model.(modelTransactions)
+
+	if len(m.transactions) != 1 || m.transactions[0].TransactionID != "tx1" {
+		t.Errorf("expected the deleted transaction to be removed, got %+v", m.transactions)
+	}
+	if !hasMsg[FilterMsg](collectMsgsFromCmd(cmd)) {
+		t.Error("expected the table to be updated")
+	}
+}
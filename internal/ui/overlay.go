@@ -0,0 +1,72 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"go.uber.org/zap"
+)
+
+// overlay is a full-screen model shown instead of the views while focused.
+// It takes the keys then, other messages keep flowing to the views below.
+type overlay interface {
+	Focused() bool
+	update(msg tea.Msg) tea.Cmd
+	view(width, height int) string
+}
+
+// overlays returns the overlays in the order they get messages, the first
+// focused one is shown. A new overlay only needs to be added here.
+func (m *modelUI) overlays() []overlay {
+	return []overlay{
+		overlayOf(&m.helpOverlay),
+		overlayOf(&m.apiLog),
+		overlayOf(&m.notifyLog),
+		overlayOf(&m.cells),
+		overlayOf(&m.integrity),
+		overlayOf(&m.deletedLog),
+		overlayOf(&m.details),
+		overlayOf(&m.category),
+		overlayOf(&m.assetForm),
+		overlayOf(&m.transferForm),
+		overlayOf(&m.repeatForm),
+	}
+}
+
+// overlayModel is implemented by the overlay models: updated by value like
+// any tea.Model, focused and sized through a pointer.
+type overlayModel[M any] interface {
+	*M
+	tea.Model
+	Focused() bool
+	WithSize(width, height int) *M
+}
+
+// modelOverlay updates and renders an overlay model in place.
+type modelOverlay[M any, P overlayModel[M]] struct {
+	model P
+}
+
+func overlayOf[M any, P overlayModel[M]](model P) overlay {
+	return modelOverlay[M, P]{model: model}
+}
+
+func (o modelOverlay[M, P]) Focused() bool {
+	return o.model.Focused()
+}
+
+func (o modelOverlay[M, P]) update(msg tea.Msg) tea.Cmd {
+	updated, cmd := o.model.Update(msg)
+	if model, ok := updated.(M); ok {
+		*o.model = model
+	} else {
+		zap.S().Errorf("Failed to update overlay: type assertion failed for %T", updated)
+	}
+	return cmd
+}
+
+func (o modelOverlay[M, P]) view(width, height int) string {
+	return P(o.model.WithSize(width, height)).View()
+}
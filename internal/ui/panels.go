@@ -0,0 +1,89 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"ffiii-tui/panel"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// panelConfig is a command panel configured under "panels".
+type panelConfig struct {
+	Title   string `mapstructure:"title"`
+	Key     string `mapstructure:"key"`
+	Command string `mapstructure:"command"`
+}
+
+// loadPanels returns the registered panels followed by the command panels
+// of the config. A panel whose key is already taken is skipped.
+func loadPanels() []panel.Panel {
+	var configured []panelConfig
+	if err := viper.UnmarshalKey("panels", &configured); err != nil {
+		zap.L().Warn("Invalid panels config", zap.Error(err))
+	}
+
+	candidates := panel.Registered()
+	for _, c := range configured {
+		if c.Title == "" || c.Key == "" || c.Command == "" {
+			zap.L().Warn("Panel needs a title, key and command", zap.String("title", c.Title))
+			continue
+		}
+		candidates = append(candidates, panel.NewCommand(c.Title, c.Key, c.Command))
+	}
+
+	var panels []panel.Panel
+	keys := make(map[string]bool)
+	for _, p := range candidates {
+		if keys[p.Key()] {
+			zap.L().Warn("Panel key already taken",
+				zap.String("panel", p.Title()), zap.String("key", p.Key()))
+			continue
+		}
+		keys[p.Key()] = true
+		panels = append(panels, p)
+	}
+	return panels
+}
+
+// panelByKey returns the index of the panel opened with key, -1 when there
+// is none.
+func (m *modelUI) panelByKey(key string) int {
+	for i, p := range m.panels {
+		if p.Key() == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func (m *modelUI) openPanel(i int) tea.Cmd {
+	m.activePanel = i
+	return tea.Batch(SetView(customView), m.panels[i].Init())
+}
+
+// updatePanels passes the message to the panels, keys only to the open one.
+func (m *modelUI) updatePanels(msg tea.Msg) tea.Cmd {
+	_, isKey := msg.(tea.KeyMsg)
+	var cmds []tea.Cmd
+	for i, p := range m.panels {
+		if isKey && (m.state != customView || i != m.activePanel) {
+			continue
+		}
+		var cmd tea.Cmd
+		m.panels[i], cmd = p.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m *modelUI) customPanelView() string {
+	h, v := m.styles.BaseFocused.GetFrameSize()
+	width := m.layout.GetWidth() - h
+	height := m.layout.GetHeight() - m.layout.GetTopSize() - m.layout.GetTabBarSize() - v
+	return m.panels[m.activePanel].View(max(width, 1), max(height, 1))
+}
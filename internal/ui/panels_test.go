@@ -0,0 +1,103 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"ffiii-tui/panel"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/viper"
+)
+
+type testPanel struct {
+	key  string
+	keys []string
+	msgs int
+}
+
+type testPanelOpenedMsg struct{}
+
+func (p *testPanel) Title() string { return "Test" }
+func (p *testPanel) Key() string   { return p.key }
+func (p *testPanel) Init() tea.Cmd { return Cmd(testPanelOpenedMsg{}) }
+func (p *testPanel) Update(msg tea.Msg) (panel.Panel, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		p.keys = append(p.keys, msg.String())
+	} else {
+		p.msgs++
+	}
+	return p, nil
+}
+func (p *testPanel) View(width, height int) string { return "test panel content" }
+
+func TestLoadPanels_FromConfig(t *testing.T) {
+	viper.Set("panels", []map[string]any{
+		{"title": "Budgets", "key": "B", "command": "echo '[]'"},
+		{"title": "Goals", "key": "B", "command": "echo '[]'"},
+		{"title": "Broken", "key": "X"},
+	})
+	defer viper.Set("panels", nil)
+
+	panels := loadPanels()
+
+	if len(panels) != 1 || panels[0].Title() != "Budgets" {
+		t.Errorf("expected only the first complete panel with a free key, got %v", panels)
+	}
+}
+
+func TestUI_OpenPanel(t *testing.T) {
+	p := &testPanel{key: "B"}
+	m := NewModelUI(newTestUIAPI())
+	m.panels = []panel.Panel{p}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("B")})
+	m = updated.(modelUI)
+	msgs := collectMsgsFromCmd(cmd)
+
+	view, ok := findMsg[SetFocusedViewMsg](msgs)
+	if !ok || view.state != customView {
+		t.Fatalf("expected the panel view, got %v", msgs)
+	}
+	if !hasMsg[testPanelOpenedMsg](msgs) {
+		t.Error("expected the panel to be initialized")
+	}
+
+	updated, _ = m.Update(view)
+	m = updated.(modelUI)
+	if !strings.Contains(m.View(), "test panel content") {
+		t.Error("expected the panel to be rendered")
+	}
+	if !strings.Contains(m.tabBar(), "B Test") {
+		t.Error("expected the panel in the tab bar")
+	}
+}
+
+func TestUI_PanelKeys(t *testing.T) {
+	p := &testPanel{key: "B"}
+	m := NewModelUI(newTestUIAPI())
+	m.panels = []panel.Panel{p}
+
+	// Closed panels get no keys but all other messages
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	m.Update(testPanelOpenedMsg{})
+	if len(p.keys) != 0 || p.msgs != 1 {
+		t.Fatalf("expected only the message, got keys %v and %d messages", p.keys, p.msgs)
+	}
+
+	m.SetState(customView)
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	if len(p.keys) != 1 || p.keys[0] != "j" {
+		t.Errorf("expected the open panel to get the key, got %v", p.keys)
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	view, ok := findMsg[SetFocusedViewMsg](collectMsgsFromCmd(cmd))
+	if !ok || view.state != transactionsView {
+		t.Error("expected esc to go back to the transactions")
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"ffiii-tui/internal/ui/loading"
+	"ffiii-tui/internal/ui/notify"
+	"ffiii-tui/internal/ui/prompt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/viper"
+)
+
+const defaultProfile = "default"
+
+// ConnectFunc creates a new API client for the named profile.
+type ConnectFunc func(profile string) (UIAPI, error)
+
+type ProfileSwitchedMsg struct {
+	Profile string
+	API     UIAPI
+}
+
+// profileNames returns the default profile followed by the profiles
+// configured under "profiles", sorted by name.
+func profileNames() []string {
+	names := []string{defaultProfile}
+	for name := range viper.GetStringMap("profiles") {
+		if name != defaultProfile {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names[1:])
+	return names
+}
+
+func activeProfile() string {
+	if profile := viper.GetString("profile"); profile != "" {
+		return profile
+	}
+	return defaultProfile
+}
+
+func (m *modelUI) askProfile() tea.Cmd {
+	names := profileNames()
+	connect := m.connect
+	return prompt.Ask(
+		fmt.Sprintf("Switch profile (%s): ", strings.Join(names, ", ")),
+		"",
+		func(value string) tea.Cmd {
+			name := strings.TrimSpace(value)
+			if name == "" {
+				return nil
+			}
+			if !slices.Contains(names, name) {
+				return notify.NotifyWarn(fmt.Sprintf("Unknown profile: %s", name))
+			}
+			if name == activeProfile() {
+				return nil
+			}
+			return switchProfile(connect, name)
+		},
+	)
+}
+
+// switchProfile connects to the profile in the background and reports the
+// new client with ProfileSwitchedMsg.
+func switchProfile(connect ConnectFunc, name string) tea.Cmd {
+	return loading.Track(fmt.Sprintf("Connecting to profile %s...", name), func() tea.Msg {
+		api, err := connect(name)
+		if err != nil {
+			return notify.NotifyWarn(fmt.Sprintf("Failed to switch to profile %s: %v", name, err))()
+		}
+		return ProfileSwitchedMsg{Profile: name, API: api}
+	})
+}
+
+// withAPI rebuilds the UI around a new API client, keeping the terminal
+// layout and running components.
+func (m modelUI) withAPI(api UIAPI) modelUI {
+	n := NewModelUI(api)
+	n.connect = m.connect
+	n.saveToken = m.saveToken
+	n.layout = m.layout
+	n.Width = m.Width
+	n.loading = m.loading
+	n.notify = m.notify
+	n.panels = m.panels
+	n.crash = m.crash
+	n.zoomed = m.zoomed
+	if m.new.draftFile != "" {
+		n.new.draftFile = draftPath()
+	}
+	if m.transactions.deletedFile != "" {
+		n.transactions.deletedFile = deletedPath()
+	}
+	if m.new.usage != nil {
+		n.new.usage = loadUsage(usagePath())
+	}
+	if m.sessionFile != "" {
+		n.sessionFile = sessionPath()
+		n.restoreSession()
+	}
+	return n
+}
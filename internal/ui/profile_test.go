@@ -0,0 +1,117 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"ffiii-tui/internal/ui/notify"
+	"ffiii-tui/internal/ui/prompt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/viper"
+)
+
+func setTestProfiles(t *testing.T) {
+	t.Helper()
+	viper.Set("profiles", map[string]any{
+		"work":     map[string]any{"api_url": "https://work.example.com/api/v1"},
+		"personal": map[string]any{"api_url": "https://home.example.com/api/v1"},
+	})
+	t.Cleanup(func() {
+		viper.Set("profiles", nil)
+		viper.Set("profile", "")
+	})
+}
+
+func TestProfileNames(t *testing.T) {
+	setTestProfiles(t)
+
+	got := profileNames()
+	want := []string{"default", "personal", "work"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestUI_KeySwitchProfile_RequiresConnect(t *testing.T) {
+	m := newTestModelUI()
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'P'}})
+	for _, msg := range collectMsgsFromCmd(cmd) {
+		if _, ok := msg.(prompt.PromptMsg); ok {
+			t.Error("Expected no profile prompt without a connect function")
+		}
+	}
+}
+
+func TestUI_KeySwitchProfile_Connects(t *testing.T) {
+	setTestProfiles(t)
+
+	workAPI := newTestUIAPI()
+	workAPI.serverURL = "https://work.example.com/api/v1"
+
+	var connected string
+	m := newTestModelUI()
+	m.connect = func(profile string) (UIAPI, error) {
+		connected = profile
+		return workAPI, nil
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'P'}})
+	if cmd == nil {
+		t.Fatal("Expected profile prompt command")
+	}
+	ask, ok := cmd().(prompt.PromptMsg)
+	if !ok {
+		t.Fatal("Expected prompt.PromptMsg")
+	}
+	if !strings.Contains(ask.Prompt, "work") {
+		t.Errorf("Expected prompt to list profiles, got %q", ask.Prompt)
+	}
+
+	msg := trackedMsg(ask.Callback("work"))
+	switched, ok := msg.(ProfileSwitchedMsg)
+	if !ok {
+		t.Fatalf("Expected ProfileSwitchedMsg, got %T", msg)
+	}
+	if connected != "work" {
+		t.Errorf("Expected connect for 'work', got %q", connected)
+	}
+
+	updated, cmd := m.Update(switched)
+	m = updated.(modelUI)
+	if !hasMsg[RefreshAllMsg](collectMsgsFromCmd(cmd)) {
+		t.Error("Expected RefreshAllMsg after switching profile")
+	}
+	if m.api != UIAPI(workAPI) || m.connect == nil {
+		t.Error("Expected UI to use the new client and keep the connect function")
+	}
+	if !strings.Contains(m.statusBar(), "work.example.com") || !strings.Contains(m.statusBar(), "profile: work") {
+		t.Error("Expected status bar to show the new profile and server")
+	}
+}
+
+func TestUI_SwitchProfile_Errors(t *testing.T) {
+	setTestProfiles(t)
+
+	m := newTestModelUI()
+	m.connect = func(profile string) (UIAPI, error) {
+		return nil, errors.New("unauthorized")
+	}
+
+	ask := m.askProfile()().(prompt.PromptMsg)
+
+	if _, ok := ask.Callback("unknown")().(notify.NotifyMsg); !ok {
+		t.Error("Expected warning for unknown profile")
+	}
+	msg := trackedMsg(ask.Callback("personal"))
+	if n, ok := msg.(notify.NotifyMsg); !ok || !strings.Contains(n.Message, "unauthorized") {
+		t.Errorf("Expected connection error notification, got %v", msg)
+	}
+}
@@ -15,13 +15,30 @@ type PromptMsg struct {
 	Prompt   string
 	Value    string
 	Callback func(value string) tea.Cmd
+	// Secret hides the typed value, e.g. for tokens
+	Secret bool
 }
 
 type PromptBlur struct{}
 
+// Answer is the user's choice in a confirmation prompt.
+type Answer uint
+
+const (
+	No Answer = iota
+	Yes
+	Always
+)
+
+type ConfirmMsg struct {
+	Prompt   string
+	Callback func(answer Answer) tea.Cmd
+}
+
 type Model struct {
 	input    textinput.Model
 	callback func(value string) tea.Cmd
+	confirm  func(answer Answer) tea.Cmd
 	focus    bool
 	styles   Styles
 	Width    int
@@ -50,7 +67,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case PromptMsg:
 		m.input.Prompt = msg.Prompt
 		m.input.SetValue(msg.Value)
+		m.input.EchoMode = textinput.EchoNormal
+		if msg.Secret {
+			m.input.EchoMode = textinput.EchoPassword
+		}
 		m.callback = msg.Callback
+		m.confirm = nil
+		m.Focus()
+		return m, nil
+	case ConfirmMsg:
+		m.input.Prompt = msg.Prompt + " [y]es/[n]o/[a]lways: "
+		m.input.SetValue("")
+		m.input.EchoMode = textinput.EchoNormal
+		m.callback = nil
+		m.confirm = msg.Callback
 		m.Focus()
 		return m, nil
 	case PromptBlur:
@@ -62,6 +92,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	if m.confirm != nil {
+		return m.updateConfirm(msg)
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
@@ -90,6 +124,34 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+func (m Model) updateConfirm(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	var answer Answer
+	switch keyMsg.String() {
+	case "y", "Y":
+		answer = Yes
+	case "a", "A":
+		answer = Always
+	case "n", "N", "esc", "enter":
+		answer = No
+	default:
+		return m, nil
+	}
+
+	confirm := m.confirm
+	m.confirm = nil
+	return m, tea.Sequence(
+		tea.Cmd(func() tea.Msg {
+			return PromptBlur{}
+		}),
+		confirm(answer),
+	)
+}
+
 func (m Model) View() string {
 	return m.styles.PromptFocused.Width(m.Width).Render(" " + m.input.View())
 }
@@ -127,3 +189,25 @@ func Ask(prompt, value string, callback func(value string) tea.Cmd) tea.Cmd {
 		}
 	})
 }
+
+// AskSecret asks for a value that is not shown while typed.
+func AskSecret(prompt string, callback func(value string) tea.Cmd) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		return PromptMsg{
+			Prompt:   prompt,
+			Callback: callback,
+			Secret:   true,
+		}
+	})
+}
+
+// Confirm asks a yes/no/always question. Any answer other than y or a,
+// including esc and enter, is treated as No.
+func Confirm(prompt string, callback func(answer Answer) tea.Cmd) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		return ConfirmMsg{
+			Prompt:   prompt,
+			Callback: callback,
+		}
+	})
+}
@@ -0,0 +1,82 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/hooks"
+	"ffiii-tui/internal/ui/loading"
+	"ffiii-tui/internal/ui/notify"
+	"ffiii-tui/internal/ui/transferform"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/viper"
+)
+
+// ownAccounts are the accounts a quick transfer moves money between.
+func ownAccounts(api AccountsAPI) []firefly.Account {
+	return append(api.AccountsByType("asset"), api.AccountsByType("liabilities")...)
+}
+
+// transferRequest is the request creating t, described by its destination
+// like the web interface does.
+func transferRequest(t transferform.Transfer) firefly.RequestTransaction {
+	return firefly.RequestTransaction{
+		ApplyRules:           true,
+		ErrorIfDuplicateHash: viper.GetBool("transactions.reject_duplicates"),
+		FireWebhooks:         true,
+		Transactions: []firefly.RequestTransactionSplit{{
+			Type:          "transfer",
+			Date:          t.Date,
+			SourceID:      t.Source.ID,
+			DestinationID: t.Destination.ID,
+			CurrencyCode:  t.Source.CurrencyCode,
+			Amount:        fmt.Sprintf("%.2f", t.Amount),
+			Description:   "Transfer to " + t.Destination.Name,
+		}},
+	}
+}
+
+// createTransfer creates the transfer of the quick transfer form. It shows
+// up in the table right away, like one saved with the full form.
+func createTransfer(api TransactionWriteAPI, t transferform.Transfer) tea.Cmd {
+	return loading.Track("Creating transfer...", func() tea.Msg {
+		request := transferRequest(t)
+		id, err := api.CreateTransaction(context.Background(), request)
+		if errors.Is(err, firefly.ErrQueued) {
+			return notify.NotifyWarn(err.Error())()
+		}
+		if err != nil {
+			return notify.NotifyWarn(fmt.Sprintf("Failed to create transfer: %s", errorText(err)))()
+		}
+
+		split := request.Transactions[0]
+		saved := firefly.Transaction{
+			TransactionID: id,
+			Type:          "transfer",
+			Date:          t.Date + "T00:00:00Z",
+			UpdatedAt:     time.Now(),
+			Splits: []firefly.Split{{
+				Source:      t.Source,
+				Destination: t.Destination,
+				Currency:    split.CurrencyCode,
+				Amount:      t.Amount,
+				Description: split.Description,
+			}},
+		}
+		return tea.BatchMsg{
+			notifySaved(fmt.Sprintf("Moved %s %s from %s to %s as #%s",
+				split.Amount, split.CurrencyCode, t.Source.Name, t.Destination.Name, id), transactionLink(id)),
+			Cmd(transactionSavedMsg{Transaction: saved}),
+			runHook(hooks.TransactionCreated, newHookTransaction(saved)),
+			PublishTransaction(id),
+		}
+	})
+}
@@ -0,0 +1,67 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"errors"
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/notify"
+	"ffiii-tui/internal/ui/transferform"
+)
+
+func TestOwnAccounts(t *testing.T) {
+	m := newTestTransactionModel()
+	got := ownAccounts(m.api)
+	want := append(m.api.AccountsByType("asset"), m.api.AccountsByType("liabilities")...)
+	if len(got) != len(want) || len(got) == 0 {
+		t.Fatalf("expected asset and liability accounts, got %v", got)
+	}
+	for _, account := range got {
+		if account.Type != "asset" && account.Type != "liabilities" {
+			t.Errorf("unexpected %s account %s", account.Type, account.Name)
+		}
+	}
+}
+
+func TestCreateTransfer(t *testing.T) {
+	api := newTestTransactionModel().api.(*mockTransactionFormAPI)
+	api.createTransactionFunc = func(firefly.RequestTransaction) (string, error) {
+		return "77", nil
+	}
+	transfer := transferform.Transfer{
+		Source:      testAssetChecking,
+		Destination: testLiabilityLoan,
+		Amount:      150,
+		Date:        "2026-02-03",
+	}
+
+	msgs := dispatched(collectMsgsFromCmd(createTransfer(api, transfer)))
+	if len(api.createTransactionCalls) != 1 {
+		t.Fatalf("expected one transaction created, got %d", len(api.createTransactionCalls))
+	}
+	split := api.createTransactionCalls[0].Transactions[0]
+	if split.Type != "transfer" || split.SourceID != testAssetChecking.ID || split.DestinationID != testLiabilityLoan.ID ||
+		split.Amount != "150.00" || split.Date != "2026-02-03" || split.Description != "Transfer to Loan" {
+		t.Errorf("unexpected request %+v", split)
+	}
+
+	saved, ok := findMsg[transactionSavedMsg](msgs)
+	if !ok || saved.Transaction.TransactionID != "77" || saved.Transaction.Splits[0].Destination != testLiabilityLoan {
+		t.Errorf("expected the transfer shown right away, got %+v", saved)
+	}
+	if !hasMsg[RefreshAssetsMsg](msgs) || !hasMsg[RefreshLiabilitiesMsg](msgs) {
+		t.Error("expected the account balances to be refreshed")
+	}
+
+	api.createTransactionFunc = func(firefly.RequestTransaction) (string, error) {
+		return "", errors.New("boom")
+	}
+	msgs = collectMsgsFromCmd(createTransfer(api, transfer))
+	if n, ok := findMsg[notify.NotifyMsg](msgs); !ok || n.Level != notify.Warn || hasMsg[transactionSavedMsg](msgs) {
+		t.Errorf("expected a warning only, got %#v", msgs)
+	}
+}
@@ -0,0 +1,31 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"time"
+
+	"ffiii-tui/internal/firefly"
+)
+
+// recentMark is appended to the type icon of transactions created or
+// changed within recentAge, so what a sync or an import brought in stands
+// out.
+const recentMark = "*"
+
+// recentAge is how long a transaction counts as new.
+const recentAge = 24 * time.Hour
+
+// recentTransactions returns the IDs of the transactions created or
+// changed within recentAge of now.
+func recentTransactions(transactions []firefly.Transaction, now time.Time) map[string]bool {
+	recent := map[string]bool{}
+	for _, tx := range transactions {
+		if !tx.UpdatedAt.IsZero() && now.Sub(tx.UpdatedAt) < recentAge {
+			recent[tx.TransactionID] = true
+		}
+	}
+	return recent
+}
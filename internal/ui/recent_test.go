@@ -0,0 +1,48 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"ffiii-tui/internal/firefly"
+)
+
+func TestRecentTransactions(t *testing.T) {
+	now := time.Date(2026, time.March, 18, 12, 0, 0, 0, time.UTC)
+	txs := []firefly.Transaction{
+		{TransactionID: "1", UpdatedAt: now.Add(-time.Hour)},
+		{TransactionID: "2", UpdatedAt: now.Add(-25 * time.Hour)},
+		{TransactionID: "3"},
+	}
+
+	recent := recentTransactions(txs, now)
+	if !recent["1"] || recent["2"] || recent["3"] || len(recent) != 1 {
+		t.Errorf("expected only the transaction changed an hour ago, got %v", recent)
+	}
+}
+
+func TestTransactionRows_RecentMark(t *testing.T) {
+	txs := []firefly.Transaction{
+		newTestTransaction(1, "tx1", "withdrawal", "2024-01-17T10:00:00Z", "Fuel"),
+		newTestTransaction(2, "tx2", "withdrawal", "2024-01-16T10:00:00Z", "Parking"),
+		newTestTransaction(3, "tx3", "withdrawal", "2024-01-15T10:00:00Z", "Coffee"),
+	}
+	txs[0].UpdatedAt = time.Now().Add(-time.Minute)
+	txs[1].UpdatedAt = time.Now().Add(-48 * time.Hour)
+	m := newFocusedTransactionModel(t, txs)
+
+	rows := m.table.Rows()
+	if !strings.HasSuffix(rows[0][1], recentMark) {
+		t.Errorf("expected the new transaction marked, got %q", rows[0][1])
+	}
+	for _, row := range rows[1:] {
+		if strings.HasSuffix(row[1], recentMark) {
+			t.Errorf("expected older transactions without mark, got %q", row[1])
+		}
+	}
+}
@@ -0,0 +1,226 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package recurrenceform
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/formkit"
+	"ffiii-tui/internal/ui/money"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+)
+
+// OpenMsg opens the form to repeat Transaction monthly.
+type OpenMsg struct {
+	Transaction firefly.Transaction
+}
+
+// SubmitMsg carries the confirmed schedule of the transaction.
+type SubmitMsg struct {
+	Transaction firefly.Transaction
+	Schedule    Schedule
+}
+
+type CloseMsg struct{}
+
+// Schedule repeats a transaction every month from FirstDate, on its day of
+// the month, until Until. Dates are YYYY-MM-DD, Until is empty to repeat
+// forever.
+type Schedule struct {
+	Title     string
+	FirstDate string
+	Until     string
+}
+
+// Day is the day of the month the schedule repeats on.
+func (s Schedule) Day() int {
+	date, err := time.Parse(time.DateOnly, s.FirstDate)
+	if err != nil {
+		return 0
+	}
+	return date.Day()
+}
+
+type Model struct {
+	form        *huh.Form
+	schedule    *Schedule
+	transaction firefly.Transaction
+	focus       bool
+	styles      formkit.Styles
+	amounts     money.Formatter
+	Width       int
+	Height      int
+}
+
+func New(amounts money.Formatter) Model {
+	return Model{
+		styles:  formkit.DefaultStyles(),
+		amounts: amounts,
+		Width:   80,
+		Height:  24,
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case OpenMsg:
+		m.transaction = msg.Transaction
+		m.schedule = &Schedule{
+			Title:     msg.Transaction.Description(),
+			FirstDate: nextMonth(msg.Transaction.Date),
+		}
+		m.form = newForm(m.schedule, msg.Transaction, m.amounts)
+		m.Focus()
+		return m, m.form.Init()
+	case CloseMsg:
+		m.Blur()
+		return m, nil
+	}
+
+	if !m.focus {
+		return m, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok && msg.String() == "esc" {
+		return m, Close()
+	}
+
+	form, cmd := m.form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.form = f
+	}
+	if m.form.State == huh.StateCompleted {
+		m.Blur()
+		submit := SubmitMsg{
+			Transaction: m.transaction,
+			Schedule: Schedule{
+				Title:     strings.TrimSpace(m.schedule.Title),
+				FirstDate: strings.TrimSpace(m.schedule.FirstDate),
+				Until:     strings.TrimSpace(m.schedule.Until),
+			},
+		}
+		return m, func() tea.Msg {
+			return submit
+		}
+	}
+	return m, cmd
+}
+
+func (m Model) View() string {
+	if !m.focus {
+		return ""
+	}
+
+	return m.styles.Frame("Repeat monthly", m.form.View(), m.Width, m.Height)
+}
+
+// nextMonth returns the same day a month after date, the last day of the
+// month when it is shorter.
+func nextMonth(date string) string {
+	t, err := time.Parse(time.DateOnly, date[:min(len(date), 10)])
+	if err != nil {
+		t = time.Now()
+	}
+	first := time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(t.Day(), last)-1).Format(time.DateOnly)
+}
+
+func validateDate(s string) error {
+	if _, err := time.Parse(time.DateOnly, strings.TrimSpace(s)); err != nil {
+		return errors.New("please enter a date as YYYY-MM-DD")
+	}
+	return nil
+}
+
+func newForm(s *Schedule, tx firefly.Transaction, amounts money.Formatter) *huh.Form {
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewNote().
+				Title(fmt.Sprintf("%s, %s -> %s", amounts.Format(tx.Amount(), tx.Currency()), tx.Source().Name, tx.Destination().Name)).
+				DescriptionFunc(func() string {
+					if s.Day() == 0 {
+						return ""
+					}
+					return fmt.Sprintf("Every month on day %d", s.Day())
+				}, &s.FirstDate),
+			huh.NewInput().
+				Title("Title").
+				Value(&s.Title).
+				Validate(func(v string) error {
+					if strings.TrimSpace(v) == "" {
+						return errors.New("title is required")
+					}
+					return nil
+				}),
+			huh.NewInput().
+				Title("First date").
+				Placeholder(time.DateOnly).
+				Value(&s.FirstDate).
+				Validate(validateDate),
+			huh.NewInput().
+				Title("Repeat until").
+				Placeholder("empty to repeat forever").
+				Value(&s.Until).
+				Validate(func(v string) error {
+					if strings.TrimSpace(v) == "" {
+						return nil
+					}
+					if err := validateDate(v); err != nil {
+						return err
+					}
+					if strings.TrimSpace(v) <= strings.TrimSpace(s.FirstDate) {
+						return errors.New("please enter a date after the first date")
+					}
+					return nil
+				}),
+		),
+	).WithShowHelp(false)
+}
+
+func (m *Model) Focus() {
+	m.focus = true
+}
+
+func (m *Model) Blur() {
+	m.focus = false
+}
+
+func (m *Model) Focused() bool {
+	return m.focus
+}
+
+func (m *Model) WithSize(width, height int) *Model {
+	m.Width = width
+	m.Height = height
+	return m
+}
+
+func (m *Model) WithStyles(styles formkit.Styles) *Model {
+	m.styles = styles
+	return m
+}
+
+func Open(tx firefly.Transaction) tea.Cmd {
+	return func() tea.Msg {
+		return OpenMsg{Transaction: tx}
+	}
+}
+
+func Close() tea.Cmd {
+	return func() tea.Msg {
+		return CloseMsg{}
+	}
+}
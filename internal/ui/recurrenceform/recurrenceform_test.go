@@ -0,0 +1,133 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package recurrenceform
+
+import (
+	"strings"
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/formkit/formtest"
+	"ffiii-tui/internal/ui/money"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var form = formtest.Harness[Model]{Out: func(msg tea.Msg) bool {
+	switch msg.(type) {
+	case SubmitMsg, CloseMsg:
+		return true
+	}
+	return false
+}}
+
+var rent = firefly.Transaction{
+	TransactionID: "7",
+	Type:          "withdrawal",
+	Date:          "2026-01-31T00:00:00+01:00",
+	Splits: []firefly.Split{{
+		Source:      firefly.Account{ID: "1", Name: "Checking"},
+		Destination: firefly.Account{ID: "2", Name: "Landlord"},
+		Currency:    "EUR",
+		Amount:      900,
+		Description: "Rent",
+	}},
+}
+
+func openForm(t *testing.T) Model {
+	t.Helper()
+	m, _ := form.Send(New(money.Formatter{}), OpenMsg{Transaction: rent})
+	if !m.Focused() {
+		t.Fatal("Expected form to be focused after OpenMsg")
+	}
+	return m
+}
+
+func TestNextMonth(t *testing.T) {
+	tests := map[string]string{
+		"2026-01-31T00:00:00+01:00": "2026-02-28",
+		"2026-03-15":                "2026-04-15",
+		"2026-12-05":                "2027-01-05",
+	}
+	for date, want := range tests {
+		if got := nextMonth(date); got != want {
+			t.Errorf("nextMonth(%q) = %q, want %q", date, got, want)
+		}
+	}
+}
+
+func TestView(t *testing.T) {
+	m := openForm(t)
+	view := m.View()
+
+	for _, want := range []string{"Repeat monthly", "900.00 EUR, Checking -> Landlord", "Every month on day 28", "Title", "First date", "Repeat until"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected view to contain %q", want)
+		}
+	}
+}
+
+func TestUpdate_Submit(t *testing.T) {
+	m := openForm(t)
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+
+	m, _ = form.Send(m, enter) // keep the title
+	m, _ = form.Send(m, tea.KeyMsg{Type: tea.KeyCtrlU})
+	m = form.TypeText(m, "2026-03-01")
+	m, _ = form.Send(m, enter)
+	m = form.TypeText(m, "2026-12-31")
+	m, msgs := form.Send(m, enter)
+
+	if len(msgs) != 1 {
+		t.Fatalf("Expected a single SubmitMsg, got %v", msgs)
+	}
+	submit, ok := msgs[0].(SubmitMsg)
+	if !ok {
+		t.Fatalf("Expected SubmitMsg, got %T", msgs[0])
+	}
+	want := Schedule{Title: "Rent", FirstDate: "2026-03-01", Until: "2026-12-31"}
+	if submit.Schedule != want || submit.Transaction.TransactionID != "7" {
+		t.Errorf("Unexpected submit: %+v", submit)
+	}
+	if submit.Schedule.Day() != 1 {
+		t.Errorf("Expected day 1, got %d", submit.Schedule.Day())
+	}
+	if m.Focused() {
+		t.Error("Expected form to close after submit")
+	}
+}
+
+func TestUpdate_UntilBeforeFirstDate(t *testing.T) {
+	m := openForm(t)
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+
+	m, _ = form.Send(m, enter)
+	m, _ = form.Send(m, enter)
+	m = form.TypeText(m, "2026-01-01")
+	m, msgs := form.Send(m, enter)
+	if len(msgs) != 0 {
+		t.Fatalf("Expected no messages, got %v", msgs)
+	}
+	if !strings.Contains(m.View(), "after the first date") {
+		t.Error("Expected validation error in view")
+	}
+}
+
+func TestUpdate_Close(t *testing.T) {
+	m := openForm(t)
+
+	m, msgs := form.Send(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if len(msgs) != 1 {
+		t.Fatalf("Expected CloseMsg, got %v", msgs)
+	}
+	if _, ok := msgs[0].(CloseMsg); !ok {
+		t.Fatalf("Expected CloseMsg, got %T", msgs[0])
+	}
+	updated, _ := m.Update(CloseMsg{})
+	m = updated.(Model)
+	if m.Focused() {
+		t.Error("Expected model to be unfocused after CloseMsg")
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/loading"
+	"ffiii-tui/internal/ui/notify"
+	"ffiii-tui/internal/ui/recurrenceform"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// recurrenceRequest is the request repeating tx every month on schedule,
+// with the accounts, amounts, categories, budgets and tags of its splits.
+func recurrenceRequest(tx firefly.Transaction, schedule recurrenceform.Schedule) firefly.RequestRecurrence {
+	request := firefly.RequestRecurrence{
+		Type:        tx.Type,
+		Title:       schedule.Title,
+		FirstDate:   schedule.FirstDate,
+		RepeatUntil: schedule.Until,
+		ApplyRules:  true,
+		Active:      true,
+		Repetitions: []firefly.RequestRecurrenceRepetition{firefly.MonthlyOn(schedule.Day())},
+	}
+	for _, split := range tx.Splits {
+		s := firefly.RequestRecurrenceTransaction{
+			Description:         split.Description,
+			Amount:              fmt.Sprintf("%.2f", split.Amount),
+			CurrencyCode:        split.Currency,
+			ForeignCurrencyCode: split.ForeignCurrency,
+			SourceID:            split.Source.ID,
+			DestinationID:       split.Destination.ID,
+			CategoryID:          split.Category.ID,
+			BudgetID:            split.Budget.ID,
+			Tags:                split.Tags,
+		}
+		if split.ForeignCurrency != "" && split.ForeignAmount != 0 {
+			s.ForeignAmount = fmt.Sprintf("%.2f", split.ForeignAmount)
+		}
+		request.Transactions = append(request.Transactions, s)
+	}
+	return request
+}
+
+// createRecurrence repeats tx every month on schedule, Firefly III creates
+// the transactions from then on.
+func createRecurrence(api RecurrenceAPI, tx firefly.Transaction, schedule recurrenceform.Schedule) tea.Cmd {
+	return loading.Track("Creating recurrence...", func() tea.Msg {
+		id, err := api.CreateRecurrence(context.Background(), recurrenceRequest(tx, schedule))
+		if errors.Is(err, firefly.ErrQueued) {
+			return notify.NotifyWarn(err.Error())()
+		}
+		if err != nil {
+			return notify.NotifyWarn(fmt.Sprintf("Failed to create recurrence: %s", errorText(err)))()
+		}
+		return notifySaved(fmt.Sprintf("%s repeats every month on day %d from %s",
+			schedule.Title, schedule.Day(), schedule.FirstDate), recurrenceLink(id))()
+	})
+}
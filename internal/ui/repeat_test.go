@@ -0,0 +1,90 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/notify"
+	"ffiii-tui/internal/ui/recurrenceform"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type mockRecurrenceAPI struct {
+	created []firefly.RequestRecurrence
+	err     error
+}
+
+func (m *mockRecurrenceAPI) CreateRecurrence(_ context.Context, recurrence firefly.RequestRecurrence) (string, error) {
+	m.created = append(m.created, recurrence)
+	return "5", m.err
+}
+
+func TestRecurrenceRequest(t *testing.T) {
+	tx := newTestTransaction(1, "tx1", "withdrawal", "2026-01-31T10:00:00Z", "Rent")
+	tx.Splits[0].Budget = firefly.Budget{ID: "b1", Name: "Home"}
+	tx.Splits[0].Tags = []string{"home"}
+	schedule := recurrenceform.Schedule{Title: "Monthly rent", FirstDate: "2026-02-28", Until: "2026-12-31"}
+
+	request := recurrenceRequest(tx, schedule)
+	if request.Type != "withdrawal" || request.Title != "Monthly rent" || request.FirstDate != "2026-02-28" ||
+		request.RepeatUntil != "2026-12-31" || !request.Active {
+		t.Errorf("unexpected recurrence %+v", request)
+	}
+	if len(request.Repetitions) != 1 || request.Repetitions[0].Type != "monthly" || request.Repetitions[0].Moment != "28" {
+		t.Errorf("expected monthly on day 28, got %+v", request.Repetitions)
+	}
+	split := request.Transactions[0]
+	if split.Amount != "100.00" || split.SourceID != "src1" || split.DestinationID != "dst1" ||
+		split.CategoryID != "cat1" || split.BudgetID != "b1" || !slices.Equal(split.Tags, []string{"home"}) {
+		t.Errorf("unexpected transaction %+v", split)
+	}
+	if split.ForeignAmount != "" {
+		t.Errorf("expected no foreign amount, got %q", split.ForeignAmount)
+	}
+}
+
+func TestCreateRecurrence(t *testing.T) {
+	api := &mockRecurrenceAPI{}
+	tx := newTestTransaction(1, "tx1", "withdrawal", "2026-01-31T10:00:00Z", "Rent")
+	schedule := recurrenceform.Schedule{Title: "Rent", FirstDate: "2026-02-28"}
+
+	msgs := collectMsgsFromCmd(createRecurrence(api, tx, schedule))
+	if n, ok := findMsg[notify.NotifyMsg](msgs); !ok || n.Level != notify.Log || len(api.created) != 1 {
+		t.Errorf("expected the recurrence created, got %#v", msgs)
+	}
+
+	api.err = errors.New("boom")
+	msgs = collectMsgsFromCmd(createRecurrence(api, tx, schedule))
+	if n, ok := findMsg[notify.NotifyMsg](msgs); !ok || n.Level != notify.Warn {
+		t.Errorf("expected a warning, got %#v", msgs)
+	}
+}
+
+func TestTransactions_RepeatMonthly(t *testing.T) {
+	deposit := newTestTransaction(1, "tx1", "deposit", "2026-01-17T10:00:00Z", "Salary")
+	rent := newTestTransaction(2, "tx2", "withdrawal", "2026-01-16T10:00:00Z", "Rent")
+	m := newFocusedTransactionModel(t, []firefly.Transaction{deposit, rent})
+	repeat := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}}
+
+	_, cmd := m.Update(repeat)
+	msgs := collectMsgsFromCmd(cmd)
+	if n, ok := findMsg[notify.NotifyMsg](msgs); !ok || n.Level != notify.Warn || hasMsg[recurrenceform.OpenMsg](msgs) {
+		t.Errorf("expected deposits not to be repeated, got %#v", msgs)
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = updated.(modelTransactions)
+	_, cmd = m.Update(repeat)
+	open, ok := findMsg[recurrenceform.OpenMsg](collectMsgsFromCmd(cmd))
+	if !ok || open.Transaction.TransactionID != "tx2" {
+		t.Errorf("expected the form for the withdrawal, got %+v", open)
+	}
+}
@@ -0,0 +1,220 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"ffiii-tui/internal/ui/money"
+	"ffiii-tui/internal/ui/notify"
+	"ffiii-tui/internal/ui/prompt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// reportTopExpenses is the number of expense accounts listed in reports.
+const reportTopExpenses = 10
+
+// report is the review of a period: the summary, the categories and the
+// expense accounts with the most spent.
+type report struct {
+	Start, End  string
+	Summary     []reportRow
+	Categories  []reportCategory
+	Spent       string
+	Earned      string
+	TopExpenses []reportRow
+	Expenses    string
+}
+
+type reportRow struct {
+	Name  string
+	Value string
+}
+
+type reportCategory struct {
+	Name   string
+	Spent  string
+	Earned string
+}
+
+func newReport(api ReportAPI) report {
+	r := report{
+		Start: api.PeriodStart().Format(time.DateOnly),
+		End:   api.PeriodEnd().Format(time.DateOnly),
+	}
+	amounts := money.New(api)
+
+	for _, si := range api.SummaryItems() {
+		r.Summary = append(r.Summary, reportRow{Name: si.Title, Value: si.ValueParsed})
+	}
+	slices.SortFunc(r.Summary, func(a, b reportRow) int { return cmp.Compare(a.Name, b.Name) })
+
+	type category struct {
+		name, currency string
+		spent, earned  float64
+	}
+	var categories []category
+	for _, c := range api.CategoriesList() {
+		spent, earned := api.CategorySpent(c.ID), api.CategoryEarned(c.ID)
+		if spent != 0 || earned != 0 {
+			categories = append(categories, category{c.Name, c.CurrencyCode, spent, earned})
+		}
+	}
+	slices.SortStableFunc(categories, func(a, b category) int { return cmp.Compare(b.spent, a.spent) })
+	for _, c := range categories {
+		r.Categories = append(r.Categories, reportCategory{
+			Name:   c.name,
+			Spent:  reportAmount(amounts, c.spent, c.currency),
+			Earned: reportAmount(amounts, c.earned, c.currency),
+		})
+	}
+	spent, earned := api.CategoryTotals()
+	r.Spent = currencyTotals(spent).Format(amounts)
+	r.Earned = currencyTotals(earned).Format(amounts)
+
+	type expense struct {
+		name, currency string
+		spent          float64
+	}
+	var expenses []expense
+	for _, account := range api.AccountsByType("expense") {
+		if spent := api.GetExpenseDiff(account.ID); spent != 0 {
+			expenses = append(expenses, expense{account.Name, account.CurrencyCode, spent})
+		}
+	}
+	slices.SortStableFunc(expenses, func(a, b expense) int { return cmp.Compare(b.spent, a.spent) })
+	for _, e := range expenses[:min(len(expenses), reportTopExpenses)] {
+		r.TopExpenses = append(r.TopExpenses, reportRow{Name: e.name, Value: reportAmount(amounts, e.spent, e.currency)})
+	}
+	r.Expenses = currencyTotals(api.ExpenseTotals()).Format(amounts)
+
+	return r
+}
+
+func reportAmount(amounts money.Formatter, amount float64, currency string) string {
+	if amount == 0 {
+		return ""
+	}
+	return amounts.Format(amount, currency)
+}
+
+func (r report) Markdown() string {
+	var b strings.Builder
+	cell := strings.NewReplacer("|", `\|`, "\n", " ").Replace
+
+	fmt.Fprintf(&b, "# Report %s – %s\n", r.Start, r.End)
+
+	b.WriteString("\n## Summary\n\n")
+	if len(r.Summary) == 0 {
+		b.WriteString("No data.\n")
+	}
+	for _, row := range r.Summary {
+		fmt.Fprintf(&b, "- **%s**: %s\n", cell(row.Name), cell(row.Value))
+	}
+
+	b.WriteString("\n## Categories\n\n")
+	if len(r.Categories) == 0 {
+		b.WriteString("No transactions.\n")
+	} else {
+		b.WriteString("| Category | Spent | Earned |\n|---|---:|---:|\n")
+		for _, c := range r.Categories {
+			fmt.Fprintf(&b, "| %s | %s | %s |\n", cell(c.Name), c.Spent, c.Earned)
+		}
+		fmt.Fprintf(&b, "| **Total** | %s | %s |\n", r.Spent, r.Earned)
+	}
+
+	b.WriteString("\n## Top expenses\n\n")
+	if len(r.TopExpenses) == 0 {
+		b.WriteString("No expenses.\n")
+	} else {
+		b.WriteString("| Expense account | Spent |\n|---|---:|\n")
+		for _, row := range r.TopExpenses {
+			fmt.Fprintf(&b, "| %s | %s |\n", cell(row.Name), row.Value)
+		}
+		fmt.Fprintf(&b, "| **Total** | %s |\n", r.Expenses)
+	}
+	return b.String()
+}
+
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Report {{.Start}} – {{.End}}</title>
+<style>
+body { font-family: sans-serif; max-width: 48em; margin: 2em auto; }
+table { border-collapse: collapse; width: 100%; }
+th, td { padding: 0.3em 0.6em; border-bottom: 1px solid #ddd; text-align: left; }
+td.amount, th.amount { text-align: right; }
+tr.total td { font-weight: bold; }
+</style>
+</head>
+<body>
+<h1>Report {{.Start}} – {{.End}}</h1>
+<h2>Summary</h2>
+{{if .Summary}}<ul>
+{{range .Summary}}<li><strong>{{.Name}}</strong>: {{.Value}}</li>
+{{end}}</ul>{{else}}<p>No data.</p>{{end}}
+<h2>Categories</h2>
+{{if .Categories}}<table>
+<tr><th>Category</th><th class="amount">Spent</th><th class="amount">Earned</th></tr>
+{{range .Categories}}<tr><td>{{.Name}}</td><td class="amount">{{.Spent}}</td><td class="amount">{{.Earned}}</td></tr>
+{{end}}<tr class="total"><td>Total</td><td class="amount">{{.Spent}}</td><td class="amount">{{.Earned}}</td></tr>
+</table>{{else}}<p>No transactions.</p>{{end}}
+<h2>Top expenses</h2>
+{{if .TopExpenses}}<table>
+<tr><th>Expense account</th><th class="amount">Spent</th></tr>
+{{range .TopExpenses}}<tr><td>{{.Name}}</td><td class="amount">{{.Value}}</td></tr>
+{{end}}<tr class="total"><td>Total</td><td class="amount">{{.Expenses}}</td></tr>
+</table>{{else}}<p>No expenses.</p>{{end}}
+</body>
+</html>
+`))
+
+func (r report) HTML() (string, error) {
+	var b strings.Builder
+	if err := reportTemplate.Execute(&b, r); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// askReport asks where to save the report of the period; an .html file
+// gets HTML, any other Markdown.
+func askReport(api ReportAPI) tea.Cmd {
+	r := newReport(api)
+	name := fmt.Sprintf("report-%s.md", api.PeriodStart().Format("2006-01"))
+	return prompt.Ask("Export report to (.md or .html): ", name, func(value string) tea.Cmd {
+		path := strings.TrimSpace(value)
+		if path == "" {
+			return nil
+		}
+		return writeReport(r, path)
+	})
+}
+
+func writeReport(r report, path string) tea.Cmd {
+	return func() tea.Msg {
+		content := r.Markdown()
+		if ext := strings.ToLower(filepath.Ext(path)); ext == ".html" || ext == ".htm" {
+			var err error
+			if content, err = r.HTML(); err != nil {
+				return notify.NotifyWarn(fmt.Sprintf("Failed to render report: %v", err))()
+			}
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			return notify.NotifyWarn(fmt.Sprintf("Failed to export report: %v", err))()
+		}
+		return notify.NotifyLog(fmt.Sprintf("Report saved to %s", path))()
+	}
+}
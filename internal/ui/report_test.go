@@ -0,0 +1,127 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/notify"
+)
+
+func newTestReportAPI() *mockUIAPI {
+	api := newTestUIAPI()
+	api.summaryItemsFunc = func() map[string]firefly.SummaryItem {
+		return map[string]firefly.SummaryItem{
+			"balance": {Title: "Balance", ValueParsed: "$1,200.00"},
+		}
+	}
+	api.categoriesListFunc = func() []firefly.Category {
+		return []firefly.Category{
+			{ID: "1", Name: "Rent | Flat", CurrencyCode: "USD"},
+			{ID: "2", Name: "Groceries", CurrencyCode: "USD"},
+			{ID: "3", Name: "Unused", CurrencyCode: "USD"},
+		}
+	}
+	api.categorySpentFunc = func(id string) float64 {
+		return map[string]float64{"1": 900, "2": 150.5}[id]
+	}
+	api.accountsByTypeFunc = func(accountType string) []firefly.Account {
+		if accountType != "expense" {
+			return nil
+		}
+		var accounts []firefly.Account
+		for i := range reportTopExpenses + 2 {
+			accounts = append(accounts, firefly.Account{
+				ID: fmt.Sprint(i), Name: fmt.Sprintf("Shop %d", i), CurrencyCode: "USD",
+			})
+		}
+		return accounts
+	}
+	api.getExpenseDiffFunc = func(id string) float64 {
+		var i int
+		fmt.Sscan(id, &i)
+		return float64(i * 10)
+	}
+	return api
+}
+
+func TestNewReport(t *testing.T) {
+	r := newReport(newTestReportAPI())
+
+	if len(r.Summary) != 1 || r.Summary[0].Value != "$1,200.00" {
+		t.Errorf("unexpected summary %v", r.Summary)
+	}
+	if len(r.Categories) != 2 || r.Categories[0].Name != "Rent | Flat" || r.Categories[1].Spent != "150.50 USD" {
+		t.Errorf("expected the categories with transactions by spent, got %v", r.Categories)
+	}
+	if len(r.TopExpenses) != reportTopExpenses {
+		t.Fatalf("expected %d top expenses, got %d", reportTopExpenses, len(r.TopExpenses))
+	}
+	if r.TopExpenses[0].Name != "Shop 11" || r.TopExpenses[0].Value != "110.00 USD" {
+		t.Errorf("expected the largest expense first, got %v", r.TopExpenses[0])
+	}
+}
+
+func TestReport_Markdown(t *testing.T) {
+	md := newReport(newTestReportAPI()).Markdown()
+
+	for _, want := range []string{
+		"## Summary", "- **Balance**: $1,200.00",
+		"| Rent \\| Flat | 900.00 USD |  |",
+		"## Top expenses", "| Shop 11 | 110.00 USD |",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("expected %q in the report:\n%s", want, md)
+		}
+	}
+}
+
+func TestReport_HTML(t *testing.T) {
+	api := newTestReportAPI()
+	api.categoriesListFunc = func() []firefly.Category {
+		return []firefly.Category{{ID: "1", Name: "<b>Rent</b>", CurrencyCode: "USD"}}
+	}
+
+	html, err := newReport(api).HTML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(html, "&lt;b&gt;Rent&lt;/b&gt;") {
+		t.Error("expected names to be escaped")
+	}
+	if !strings.Contains(html, "<td>Shop 11</td>") {
+		t.Errorf("expected the top expenses in the report:\n%s", html)
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	dir := t.TempDir()
+	r := newReport(newTestReportAPI())
+
+	for _, name := range []string{"report.md", "report.html"} {
+		path := filepath.Join(dir, name)
+		msg := writeReport(r, path)()
+		if n, ok := msg.(notify.NotifyMsg); !ok || n.Level != notify.Log {
+			t.Fatalf("expected a success notification, got %v", msg)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if isHTML := strings.HasPrefix(string(data), "<!DOCTYPE html>"); isHTML != (name == "report.html") {
+			t.Errorf("unexpected format of %s:\n%s", name, data)
+		}
+	}
+
+	msg := writeReport(r, filepath.Join(dir, "missing", "report.md"))()
+	if n, ok := msg.(notify.NotifyMsg); !ok || n.Level != notify.Warn {
+		t.Errorf("expected a warning, got %v", msg)
+	}
+}
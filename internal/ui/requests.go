@@ -0,0 +1,45 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// requestScope is the context of requests for one data set, such as the
+// selected period. Renewing it cancels the requests still in flight, so
+// their responses cannot overwrite the data of the new set.
+type requestScope struct {
+	mu     sync.Mutex
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func newRequestScope() *requestScope {
+	s := &requestScope{}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	return s
+}
+
+func (s *requestScope) Context() context.Context {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ctx
+}
+
+func (s *requestScope) Renew() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cancel()
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+}
+
+// canceled reports whether err comes from a request of a superseded data
+// set. Such errors are expected and not shown.
+func canceled(err error) bool {
+	return errors.Is(err, context.Canceled)
+}
@@ -0,0 +1,85 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"ffiii-tui/internal/ui/period"
+)
+
+func TestRequestScope_RenewCancelsInFlight(t *testing.T) {
+	s := newRequestScope()
+	ctx := s.Context()
+
+	s.Renew()
+
+	if ctx.Err() == nil {
+		t.Error("Expected previous context to be canceled")
+	}
+	if s.Context().Err() != nil {
+		t.Error("Expected new context to be active")
+	}
+}
+
+func TestRequests_PeriodChangeCancelsObsoleteRequests(t *testing.T) {
+	m := newTestModelUI()
+	periodCtx := m.periodRequests.Context()
+	listCtx := m.transactions.requests.Context()
+
+	m.Update(period.SelectedMsg{Year: 2025, Month: time.March})
+
+	if periodCtx.Err() == nil || listCtx.Err() == nil {
+		t.Error("Expected requests of the previous period to be canceled")
+	}
+}
+
+func TestRequests_SearchCancelsListing(t *testing.T) {
+	m := newTestModelUI()
+	listCtx := m.transactions.requests.Context()
+	periodCtx := m.periodRequests.Context()
+
+	m.transactions.Update(SearchMsg{Query: "coffee"})
+
+	if listCtx.Err() == nil {
+		t.Error("Expected previous listing to be canceled")
+	}
+	if periodCtx.Err() != nil {
+		t.Error("Expected period requests to keep running")
+	}
+}
+
+func TestRequests_ScopesBelongToTheModel(t *testing.T) {
+	m := newTestModelUI()
+	other := newTestModelUI()
+	otherCtx := other.periodRequests.Context()
+	otherListCtx := other.transactions.requests.Context()
+
+	m.Update(period.SelectedMsg{Year: 2025, Month: time.March})
+
+	if otherCtx.Err() != nil || otherListCtx.Err() != nil {
+		t.Error("Expected requests of another model to keep running")
+	}
+	if m.summary.requests != m.periodRequests || m.expenses.requests != m.periodRequests {
+		t.Error("Expected the period views to share the period scope")
+	}
+}
+
+func TestRequests_CanceledRefreshIsNotReported(t *testing.T) {
+	api := &mockSummaryAPI{
+		updateSummaryFunc: func() error {
+			return fmt.Errorf("failed to get summary: %w", context.Canceled)
+		},
+	}
+	m := newModelSummary(api, newRequestScope())
+
+	_, cmd := m.Update(RefreshSummaryMsg{})
+	if msg := trackedMsg(cmd); msg != nil {
+		t.Errorf("Expected no message for a canceled refresh, got %T", msg)
+	}
+}
@@ -5,10 +5,13 @@ SPDX-License-Identifier: Apache-2.0
 package ui
 
 import (
+	"context"
 	"fmt"
 	"slices"
 
 	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/loading"
+	"ffiii-tui/internal/ui/money"
 	"ffiii-tui/internal/ui/notify"
 	"ffiii-tui/internal/ui/prompt"
 
@@ -28,18 +31,16 @@ type (
 type revenueItem = accountListItem[firefly.Account]
 
 type modelRevenues struct {
-	AccountListModel[firefly.Account]
+	AccountListModel[firefly.Account, RevenueAPI]
+	requests *requestScope // of the selected period
 }
 
-func newModelRevenues(api RevenueAPI) modelRevenues {
-	config := &AccountListConfig[firefly.Account]{
+func newModelRevenues(api RevenueAPI, requests *requestScope) modelRevenues {
+	config := &AccountListConfig[firefly.Account, RevenueAPI]{
 		AccountType: "revenue",
 		Title:       "Revenue accounts",
-		GetItems: func(apiInterface any, sorted bool) []list.Item {
-			return getRevenuesItems(apiInterface.(RevenueAPI), sorted)
-		},
-		RefreshItems: func(apiInterface any, accountType string) error {
-			return apiInterface.(RevenueAPI).UpdateAccounts(accountType)
+		GetItems: func(api RevenueAPI, sorted bool) []list.Item {
+			return getRevenuesItems(api, sorted)
 		},
 		RefreshMsgType: RefreshRevenuesMsg{},
 		UpdateMsgType:  RevenuesUpdateMsg{},
@@ -48,8 +49,11 @@ func newModelRevenues(api RevenueAPI) modelRevenues {
 		},
 		HasSort:     true,
 		HasTotalRow: true,
-		GetTotalFunc: func(api any) float64 {
-			return api.(RevenueAPI).GetTotalRevenueDiff()
+		GetTotalFunc: func(api RevenueAPI) float64 {
+			return api.GetTotalRevenueDiff()
+		},
+		GetTotalsFunc: func(api RevenueAPI) map[string]float64 {
+			return api.RevenueTotals()
 		},
 		FilterFunc: func(item list.Item) tea.Cmd {
 			i, ok := item.(revenueItem)
@@ -70,6 +74,7 @@ func newModelRevenues(api RevenueAPI) modelRevenues {
 	}
 	return modelRevenues{
 		AccountListModel: NewAccountListModel(api, config),
+		requests:         requests,
 	}
 }
 
@@ -79,10 +84,9 @@ func (m modelRevenues) Init() tea.Cmd {
 
 func (m modelRevenues) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if newMsg, ok := msg.(NewRevenueMsg); ok {
-		api := m.api.(RevenueAPI)
-		err := api.CreateRevenueAccount(newMsg.Account)
+		err := m.api.CreateRevenueAccount(context.Background(), newMsg.Account)
 		if err != nil {
-			return m, notify.NotifyWarn(err.Error())
+			return m, notify.NotifyWarn(errorText(err))
 		}
 		return m, tea.Batch(
 			Cmd(RefreshRevenuesMsg{}),
@@ -92,25 +96,26 @@ func (m modelRevenues) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg.(type) {
 	case RefreshRevenueInsightsMsg:
-		return m, func() tea.Msg {
-			opID := startLoading("Loading revenue insights...")
-			defer stopLoading(opID)
-			err := m.api.(RevenueAPI).UpdateRevenueInsights()
+		ctx := m.requests.Context()
+		return m, loading.Track("Loading revenue insights...", func() tea.Msg {
+			err := m.api.UpdateRevenueInsights(ctx)
 			if err != nil {
-				return notify.NotifyWarn(err.Error())()
+				return dataLoadFailed("revenue", err)
 			}
 			return RevenuesUpdateMsg{}
-		}
+		})
 	}
 	updated, cmd := m.AccountListModel.Update(msg)
-	m.AccountListModel = updated.(AccountListModel[firefly.Account])
+	m.AccountListModel = updated.(AccountListModel[firefly.Account, RevenueAPI])
 	return m, cmd
 }
 
 func getRevenuesItems(api RevenueAPI, sorted bool) []list.Item {
 	items := []list.Item{}
-	for _, account := range api.AccountsByType("revenue") {
-		earned := api.GetRevenueDiff(account.ID)
+	data := fromStore[revenuesReader](api)
+	amounts := money.New(api)
+	for _, account := range data.AccountsByType("revenue") {
+		earned := data.GetRevenueDiff(account.ID)
 		if sorted && earned == 0 {
 			continue
 		}
@@ -118,6 +123,7 @@ func getRevenuesItems(api RevenueAPI, sorted bool) []list.Item {
 			account,
 			"Earned",
 			earned,
+			amounts,
 		))
 	}
 	if sorted {
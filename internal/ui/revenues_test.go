@@ -6,6 +6,7 @@ SPDX-License-Identifier: Apache-2.0
 package ui
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -18,6 +19,8 @@ import (
 )
 
 type mockRevenueAPI struct {
+	revenueTotalsFunc           func() map[string]float64
+	convertToPrimaryFunc        func(amount float64, currencyCode string) (float64, bool)
 	updateAccountsFunc          func(accountType string) error
 	accountsByTypeFunc          func(accountType string) []firefly.Account
 	accountBalanceFunc          func(accountID string) float64
@@ -29,9 +32,10 @@ type mockRevenueAPI struct {
 	updateAccountsCalledWith    []string
 	createRevenueCalledWith     []string
 	updateRevenueInsightsCalled bool
+	forgetInsightsCalled        int
 }
 
-func (m *mockRevenueAPI) UpdateAccounts(accountType string) error {
+func (m *mockRevenueAPI) UpdateAccounts(_ context.Context, accountType string) error {
 	m.updateAccountsCalledWith = append(m.updateAccountsCalledWith, accountType)
 	if m.updateAccountsFunc != nil {
 		return m.updateAccountsFunc(accountType)
@@ -53,7 +57,7 @@ func (m *mockRevenueAPI) AccountBalance(accountID string) float64 {
 	return 0
 }
 
-func (m *mockRevenueAPI) CreateRevenueAccount(name string) error {
+func (m *mockRevenueAPI) CreateRevenueAccount(_ context.Context, name string) error {
 	m.createRevenueCalledWith = append(m.createRevenueCalledWith, name)
 	if m.createRevenueAccountFunc != nil {
 		return m.createRevenueAccountFunc(name)
@@ -61,7 +65,11 @@ func (m *mockRevenueAPI) CreateRevenueAccount(name string) error {
 	return nil
 }
 
-func (m *mockRevenueAPI) UpdateRevenueInsights() error {
+func (m *mockRevenueAPI) ForgetInsights() {
+	m.forgetInsightsCalled++
+}
+
+func (m *mockRevenueAPI) UpdateRevenueInsights(_ context.Context) error {
 	m.updateRevenueInsightsCalled = true
 	if m.updateRevenueInsightsFunc != nil {
 		return m.updateRevenueInsightsFunc()
@@ -90,6 +98,24 @@ func (m *mockRevenueAPI) PrimaryCurrency() firefly.Currency {
 	return firefly.Currency{Code: "USD", Symbol: "$"}
 }
 
+func (m *mockRevenueAPI) GetCurrencyByCode(code string) firefly.Currency {
+	return firefly.Currency{Code: code}
+}
+
+func (m *mockRevenueAPI) ConvertToPrimary(amount float64, currencyCode string) (float64, bool) {
+	if m.convertToPrimaryFunc != nil {
+		return m.convertToPrimaryFunc(amount, currencyCode)
+	}
+	return amount, false
+}
+
+func (m *mockRevenueAPI) RevenueTotals() map[string]float64 {
+	if m.revenueTotalsFunc != nil {
+		return m.revenueTotalsFunc()
+	}
+	return nil
+}
+
 func newFocusedRevenuesModelWithAccount(t *testing.T, acc firefly.Account) modelRevenues {
 	t.Helper()
 
@@ -106,7 +132,7 @@ func newFocusedRevenuesModelWithAccount(t *testing.T, acc firefly.Account) model
 		},
 	}
 
-	m := newModelRevenues(api)
+	m := newModelRevenues(api, newRequestScope())
 	(&m).Focus()
 	return m
 }
@@ -214,14 +240,14 @@ func TestModelRevenues_RefreshRevenues_Success(t *testing.T) {
 			return firefly.Currency{Code: "USD", Symbol: "$"}
 		},
 	}
-	m := newModelRevenues(api)
+	m := newModelRevenues(api, newRequestScope())
 
 	_, cmd := m.Update(RefreshRevenuesMsg{})
 	if cmd == nil {
 		t.Fatal("expected cmd")
 	}
 
-	msg := cmd()
+	msg := trackedMsg(cmd)
 	if _, ok := msg.(RevenuesUpdateMsg); !ok {
 		t.Fatalf("expected RevenuesUpdateMsg, got %T", msg)
 	}
@@ -244,17 +270,20 @@ func TestModelRevenues_RefreshRevenues_Error(t *testing.T) {
 			return expectedErr
 		},
 	}
-	m := newModelRevenues(api)
+	m := newModelRevenues(api, newRequestScope())
 
 	_, cmd := m.Update(RefreshRevenuesMsg{})
 	if cmd == nil {
 		t.Fatal("expected cmd")
 	}
 
-	msg := cmd()
-	notifyMsg, ok := msg.(notify.NotifyMsg)
+	msgs := collectMsgsFromCmd(cmd)
+	if !hasMsg[DataLoadFailedMsg](msgs) {
+		t.Errorf("expected DataLoadFailedMsg, got %v", msgs)
+	}
+	notifyMsg, ok := msgs[0].(notify.NotifyMsg)
 	if !ok {
-		t.Fatalf("expected notify.NotifyMsg, got %T", msg)
+		t.Fatalf("expected notify.NotifyMsg, got %T", msgs[0])
 	}
 	if notifyMsg.Level != notify.Warn {
 		t.Fatalf("expected warn level, got %v", notifyMsg.Level)
@@ -273,14 +302,14 @@ func TestModelRevenues_RefreshRevenueInsights_Success(t *testing.T) {
 			return firefly.Currency{Code: "USD", Symbol: "$"}
 		},
 	}
-	m := newModelRevenues(api)
+	m := newModelRevenues(api, newRequestScope())
 
 	_, cmd := m.Update(RefreshRevenueInsightsMsg{})
 	if cmd == nil {
 		t.Fatal("expected cmd")
 	}
 
-	msg := cmd()
+	msg := trackedMsg(cmd)
 	if _, ok := msg.(RevenuesUpdateMsg); !ok {
 		t.Fatalf("expected RevenuesUpdateMsg, got %T", msg)
 	}
@@ -303,17 +332,21 @@ func TestModelRevenues_RefreshRevenueInsights_Error(t *testing.T) {
 			return expectedErr
 		},
 	}
-	m := newModelRevenues(api)
+	m := newModelRevenues(api, newRequestScope())
 
 	_, cmd := m.Update(RefreshRevenueInsightsMsg{})
 	if cmd == nil {
 		t.Fatal("expected cmd")
 	}
 
-	msg := cmd()
-	notifyMsg, ok := msg.(notify.NotifyMsg)
+	msgs := collectMsgsFromCmd(cmd)
+	failed, ok := findMsg[DataLoadFailedMsg](msgs)
+	if !ok || failed.DataType != "revenue" {
+		t.Errorf("expected DataLoadFailedMsg for revenue, got %v", msgs)
+	}
+	notifyMsg, ok := msgs[0].(notify.NotifyMsg)
 	if !ok {
-		t.Fatalf("expected notify.NotifyMsg, got %T", msg)
+		t.Fatalf("expected notify.NotifyMsg, got %T", msgs[0])
 	}
 	if notifyMsg.Message != expectedErr.Error() {
 		t.Fatalf("expected message %q, got %q", expectedErr.Error(), notifyMsg.Message)
@@ -329,7 +362,7 @@ func TestModelRevenues_NewRevenue_Success(t *testing.T) {
 			return firefly.Currency{Code: "USD", Symbol: "$"}
 		},
 	}
-	m := newModelRevenues(api)
+	m := newModelRevenues(api, newRequestScope())
 
 	_, cmd := m.Update(NewRevenueMsg{Account: "New Revenue"})
 	if cmd == nil {
@@ -374,7 +407,7 @@ func TestModelRevenues_NewRevenue_Error(t *testing.T) {
 			return expectedErr
 		},
 	}
-	m := newModelRevenues(api)
+	m := newModelRevenues(api, newRequestScope())
 
 	_, cmd := m.Update(NewRevenueMsg{Account: "Bad Revenue"})
 	if cmd == nil {
@@ -411,7 +444,7 @@ func TestModelRevenues_RevenuesUpdate_EmitsDataLoadCompleted(t *testing.T) {
 			return firefly.Currency{Code: "USD", Symbol: "$"}
 		},
 	}
-	m := newModelRevenues(api)
+	m := newModelRevenues(api, newRequestScope())
 
 	updated, cmd := m.Update(RevenuesUpdateMsg{})
 	m2 := updated.(modelRevenues)
@@ -466,7 +499,7 @@ func TestModelRevenues_UpdatePositions_SetsListSize(t *testing.T) {
 			return firefly.Currency{Code: "USD", Symbol: "$"}
 		},
 	}
-	m := newModelRevenues(api)
+	m := newModelRevenues(api, newRequestScope())
 
 	updated, _ := m.Update(UpdatePositions{
 		layout: &LayoutConfig{
@@ -500,7 +533,7 @@ func TestModelRevenues_IgnoresKeysWhenNotFocused(t *testing.T) {
 			return firefly.Currency{Code: "USD", Symbol: "$"}
 		},
 	}
-	m := newModelRevenues(api) // focus is false by default
+	m := newModelRevenues(api, newRequestScope()) // focus is false by default
 
 	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
 	if cmd != nil {
@@ -540,7 +573,7 @@ func TestModelRevenues_KeyFilter_IgnoresTotalAccount(t *testing.T) {
 			return firefly.Currency{Code: "USD", Symbol: "$"}
 		},
 	}
-	m := newModelRevenues(api)
+	m := newModelRevenues(api, newRequestScope())
 	(&m).Focus()
 
 	// Trigger RevenuesUpdateMsg to add total account
@@ -572,6 +605,9 @@ func TestModelRevenues_KeyRefresh_EmitsRefreshRevenuesMsg(t *testing.T) {
 	if _, ok := msg.(RefreshRevenuesMsg); !ok {
 		t.Fatalf("expected RefreshRevenuesMsg, got %T", msg)
 	}
+	if got := m.api.(*mockRevenueAPI).forgetInsightsCalled; got != 1 {
+		t.Errorf("expected cached insights to be dropped once, got %d", got)
+	}
 }
 
 func TestModelRevenues_KeySort_TogglesSort(t *testing.T) {
@@ -592,7 +628,7 @@ func TestModelRevenues_KeySort_TogglesSort(t *testing.T) {
 			return firefly.Currency{Code: "USD", Symbol: "$"}
 		},
 	}
-	m := newModelRevenues(api)
+	m := newModelRevenues(api, newRequestScope())
 	(&m).Focus()
 
 	if m.sorted {
@@ -851,7 +887,7 @@ func TestModelRevenues_EmptyAccountList(t *testing.T) {
 			return firefly.Currency{Code: "USD", Symbol: "$"}
 		},
 	}
-	m := newModelRevenues(api)
+	m := newModelRevenues(api, newRequestScope())
 	(&m).Focus()
 
 	// Verify no panics when updating with empty list
@@ -875,7 +911,7 @@ func TestModelRevenues_NilAPI(t *testing.T) {
 		}
 	}()
 
-	_ = newModelRevenues(nil)
+	_ = newModelRevenues(nil, newRequestScope())
 }
 
 func TestModelRevenues_EarnedBoundaryValues(t *testing.T) {
@@ -997,8 +1033,8 @@ func TestModelRevenues_MissingCurrencyCode(t *testing.T) {
 	}
 
 	desc := item.Description()
-	if desc != "Earned: 500.00 " {
-		t.Errorf("expected description %q, got %q", "Earned: 500.00 ", desc)
+	if desc != "Earned: 500.00" {
+		t.Errorf("expected description %q, got %q", "Earned: 500.00", desc)
 	}
 }
 
@@ -1015,7 +1051,7 @@ func TestModelRevenues_FocusToggle(t *testing.T) {
 		},
 	}
 
-	m := newModelRevenues(api)
+	m := newModelRevenues(api, newRequestScope())
 
 	if m.focus {
 		t.Fatal("expected focus to be false initially")
@@ -1067,7 +1103,7 @@ func TestModelRevenues_UpdatePositions_WithVariousDimensions(t *testing.T) {
 					return firefly.Currency{Code: "USD", Symbol: "$"}
 				},
 			}
-			m := newModelRevenues(api)
+			m := newModelRevenues(api, newRequestScope())
 
 			updated, _ := m.Update(UpdatePositions{
 				layout: &LayoutConfig{
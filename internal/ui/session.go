@@ -0,0 +1,168 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"ffiii-tui/internal/firefly"
+
+	"go.uber.org/zap"
+)
+
+// sessionViews are the views restored on the next start by name, the
+// others fall back to the transactions.
+var sessionViews = map[string]state{
+	"transactions": transactionsView,
+	"assets":       assetsView,
+	"categories":   categoriesView,
+	"tags":         tagsView,
+	"expenses":     expensesView,
+	"revenues":     revenuesView,
+	"liabilities":  liabilitiesView,
+}
+
+// session is where the UI was left, kept on disk so the next start puts
+// the user back there.
+type session struct {
+	View          string
+	Year          int
+	Month         time.Month
+	Search        string
+	Account       firefly.Account
+	Category      firefly.Category
+	Filter        string
+	Tag           string
+	Type          string
+	Unreconciled  bool
+	Uncategorized string
+	From          string
+	To            string
+	FilterOrder   []filterKind
+	TransactionID string // under the table cursor
+}
+
+// sessionPath returns the session file of the active profile.
+func sessionPath() string {
+	return profileCachePath(".session.json")
+}
+
+// loadSession reads the session file at path. ok is false when there is
+// none or it cannot be read.
+func loadSession(path string) (s session, ok bool) {
+	if path == "" {
+		return s, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			zap.L().Warn("Ignoring session", zap.Error(err))
+		}
+		return s, false
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		zap.L().Warn("Ignoring session", zap.Error(err))
+		return s, false
+	}
+	return s, true
+}
+
+func writeSession(path string, s session) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to encode session: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// session returns where the UI is now.
+func (m *modelUI) session() session {
+	view := "transactions"
+	for name, s := range sessionViews {
+		if s == m.state {
+			view = name
+		}
+	}
+	t := &m.transactions
+	selected, _ := t.GetCurrentTransaction()
+	if t.resumeTrxID != "" {
+		// Not loaded yet, kept for the next start
+		selected.TransactionID = t.resumeTrxID
+	}
+	start := m.api.PeriodStart()
+	return session{
+		View:          view,
+		Year:          start.Year(),
+		Month:         start.Month(),
+		Search:        t.currentSearch,
+		Account:       t.currentAccount,
+		Category:      t.currentCategory,
+		Filter:        t.currentFilter,
+		Tag:           t.currentTag,
+		Type:          t.typeFilter,
+		Unreconciled:  t.unreconciledOnly,
+		Uncategorized: t.uncategorized,
+		From:          t.dates.from,
+		To:            t.dates.to,
+		FilterOrder:   t.filterOrder,
+		TransactionID: selected.TransactionID,
+	}
+}
+
+// saveSession keeps where the UI is in sessionFile, if set. Failures are
+// logged, the next start shows the default view then.
+func (m *modelUI) saveSession() {
+	if m.sessionFile == "" {
+		return
+	}
+	if err := writeSession(m.sessionFile, m.session()); err != nil {
+		zap.L().Warn("Failed to save session", zap.Error(err))
+	}
+}
+
+// restoreSession puts the UI back where sessionFile says it was left. The
+// view and the cursor are applied once the data is loaded.
+func (m *modelUI) restoreSession() {
+	s, ok := loadSession(m.sessionFile)
+	if !ok {
+		return
+	}
+	if s.Year > 0 && s.Month >= time.January && s.Month <= time.December {
+		m.api.SetPeriod(s.Year, s.Month)
+	}
+	if view, ok := sessionViews[s.View]; ok {
+		m.resumeView = view
+	}
+
+	t := &m.transactions
+	t.currentSearch = s.Search
+	t.currentAccount = s.Account
+	t.currentCategory = s.Category
+	t.currentFilter = s.Filter
+	t.currentTag = s.Tag
+	t.typeFilter = s.Type
+	t.unreconciledOnly = s.Unreconciled
+	if slices.Contains(uncategorizedCycle, s.Uncategorized) {
+		t.uncategorized = s.Uncategorized
+	}
+	t.dates = dateRange{from: s.From, to: s.To}
+	t.filterOrder = s.FilterOrder
+	t.syncFilterOrder()
+	t.resumeTrxID = s.TransactionID
+}
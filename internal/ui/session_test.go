@@ -0,0 +1,108 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"ffiii-tui/internal/firefly"
+)
+
+func TestSession_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "default.session.json")
+
+	api := newTestUIAPI()
+	api.SetPeriod(2025, time.March)
+	m := NewModelUI(api)
+	m.sessionFile = path
+	m.state = categoriesView
+	m.transactions.currentSearch = "coffee"
+	m.transactions.currentAccount = testAssetChecking
+	m.transactions.currentTag = "work"
+	m.transactions.typeFilter = "withdrawal"
+	m.transactions.dates = dateRange{from: "2025-03-01", to: "2025-03-10"}
+	m.transactions.syncFilterOrder()
+	m.transactions.transactions = []firefly.Transaction{
+		newTestTransaction(0, "tx1", "withdrawal", "2025-03-05T10:00:00Z", "Coffee"),
+		newTestTransaction(1, "tx2", "withdrawal", "2025-03-04T10:00:00Z", "Lunch"),
+	}
+	m.transactions.shown = m.transactions.transactions
+	m.transactions.updateRows("tx2")
+	m.saveSession()
+
+	restoredAPI := newTestUIAPI()
+	restored := NewModelUI(restoredAPI)
+	restored.sessionFile = path
+	restored.restoreSession()
+
+	if restoredAPI.setPeriodYear != 2025 || restoredAPI.setPeriodMonth != time.March {
+		t.Errorf("expected the period to be restored, got %d-%d", restoredAPI.setPeriodYear, restoredAPI.setPeriodMonth)
+	}
+	if restored.resumeView != categoriesView {
+		t.Errorf("expected the categories view to be resumed, got %d", restored.resumeView)
+	}
+	tr := restored.transactions
+	if tr.currentSearch != "coffee" || tr.currentAccount != testAssetChecking || tr.currentTag != "work" ||
+		tr.typeFilter != "withdrawal" || tr.dates.String() != "2025-03-01..2025-03-10" {
+		t.Errorf("expected the filters to be restored, got %+v", tr)
+	}
+	if len(tr.filterChips()) != 4 {
+		t.Errorf("expected 4 filter chips, got %v", tr.filterChips())
+	}
+	if tr.resumeTrxID != "tx2" {
+		t.Errorf("expected the cursor to be resumed on tx2, got %q", tr.resumeTrxID)
+	}
+}
+
+func TestSession_Missing(t *testing.T) {
+	api := newTestUIAPI()
+	m := NewModelUI(api)
+	m.sessionFile = filepath.Join(t.TempDir(), "none.session.json")
+	m.restoreSession()
+
+	if api.setPeriodCalled != 0 || m.resumeView != transactionsView {
+		t.Error("expected nothing to be restored without a session")
+	}
+}
+
+func TestSession_ResumeViewAfterLoad(t *testing.T) {
+	m := NewModelUI(newTestUIAPI())
+	m.resumeView = expensesView
+
+	model, cmd := m.Update(RefreshAllMsg{})
+	m = model.(modelUI)
+	msgs := collectMsgsFromCmd(cmd)
+
+	view, ok := findMsg[SetFocusedViewMsg](msgs)
+	if !ok || view.state != expensesView {
+		t.Errorf("expected the resumed view to be shown, got %v", msgs)
+	}
+	if m.resumeView != transactionsView {
+		t.Error("expected later refreshes to show the transactions")
+	}
+}
+
+func TestTransactionsUpdateMsg_ResumesCursor(t *testing.T) {
+	m := NewModelTransactions(&mockTransactionAPI{})
+	m.resumeTrxID = "tx2"
+	txs := []firefly.Transaction{
+		newTestTransaction(0, "tx1", "withdrawal", "2025-03-05T10:00:00Z", "Coffee"),
+		newTestTransaction(1, "tx2", "withdrawal", "2025-03-04T10:00:00Z", "Lunch"),
+	}
+
+	model, cmd := m.Update(TransactionsUpdateMsg{Transactions: txs[:1]})
+	m = model.(modelTransactions)
+	if filter, _ := findMsg[FilterMsg](collectMsgsFromCmd(cmd)); filter.TrxID != "" || m.resumeTrxID != "tx2" {
+		t.Error("expected the cursor to wait until the transaction is loaded")
+	}
+
+	model, cmd = m.Update(TransactionsUpdateMsg{Transactions: txs})
+	m = model.(modelTransactions)
+	if filter, _ := findMsg[FilterMsg](collectMsgsFromCmd(cmd)); filter.TrxID != "tx2" || m.resumeTrxID != "" {
+		t.Errorf("expected the cursor on tx2, got %q", filter.TrxID)
+	}
+}
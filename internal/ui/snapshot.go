@@ -0,0 +1,70 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/loading"
+	"ffiii-tui/internal/ui/notify"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// showCachedData renders the data restored from the on-disk cache before
+// the first refresh. It returns nil when nothing was restored.
+func (m *modelUI) showCachedData() tea.Cmd {
+	stale, _ := m.api.Stale()
+	if len(stale) == 0 {
+		return nil
+	}
+
+	cmds := []tea.Cmd{
+		Cmd(AssetsUpdateMsg{}),
+		Cmd(ExpensesUpdatedMsg{}),
+		Cmd(RevenuesUpdateMsg{}),
+		Cmd(LiabilitiesUpdateMsg{}),
+		Cmd(CategoriesUpdateMsg{}),
+	}
+	if txs := m.api.CachedTransactions(); txs != nil {
+		cmds = append(cmds, Cmd(TransactionsUpdateMsg{Transactions: txs}))
+	}
+	return tea.Batch(cmds...)
+}
+
+// refreshBaseData reloads the user, special accounts and currencies of an
+// API restored from cache. It returns nil when they are up to date.
+func (m *modelUI) refreshBaseData() tea.Cmd {
+	stale, _ := m.api.Stale()
+	if !slices.Contains(stale, firefly.StaleBase) {
+		return nil
+	}
+
+	api := m.api
+	return loading.Track("Connecting...", func() tea.Msg {
+		if err := api.RefreshBaseData(context.Background()); err != nil {
+			return notify.NotifyWarn(err.Error())()
+		}
+		return nil
+	})
+}
+
+// staleSegment describes cached data that was not refreshed yet.
+func (m *modelUI) staleSegment() string {
+	stale, savedAt := m.api.Stale()
+	if len(stale) == 0 {
+		return ""
+	}
+
+	when := savedAt.Format("Jan 2 15:04")
+	if savedAt.Format(time.DateOnly) == time.Now().Format(time.DateOnly) {
+		when = savedAt.Format("15:04")
+	}
+	return m.styles.StatusBarStale.Render("stale since " + when + ": " + strings.Join(stale, ", "))
+}
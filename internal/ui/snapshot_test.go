@@ -0,0 +1,82 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"ffiii-tui/internal/firefly"
+)
+
+func newStaleTestModelUI() (modelUI, *mockUIAPI) {
+	api := newTestUIAPI()
+	api.stale = []string{"asset", firefly.StaleBase, firefly.StaleTransactions}
+	api.savedAt = time.Now().Add(-time.Hour)
+	api.cachedTransactions = []firefly.Transaction{
+		newTestTransaction(0, "7", "withdrawal", "2026-01-15T00:00:00Z", "Cached"),
+	}
+	m := NewModelUI(api)
+	m.Width = 200
+	return m, api
+}
+
+func TestSnapshot_ShowCachedData(t *testing.T) {
+	m, _ := newStaleTestModelUI()
+
+	msgs := collectMsgsFromCmd(m.showCachedData())
+	if !hasMsg[AssetsUpdateMsg](msgs) || !hasMsg[CategoriesUpdateMsg](msgs) {
+		t.Error("Expected cached lists to be rendered")
+	}
+	found := false
+	for _, msg := range msgs {
+		if upd, ok := msg.(TransactionsUpdateMsg); ok && len(upd.Transactions) == 1 {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected cached transactions to be rendered")
+	}
+}
+
+func TestSnapshot_NothingCached(t *testing.T) {
+	m := newTestModelUI()
+
+	if cmd := m.showCachedData(); cmd != nil {
+		t.Error("Expected no cached data command without a snapshot")
+	}
+	if cmd := m.refreshBaseData(); cmd != nil {
+		t.Error("Expected no base data refresh without a snapshot")
+	}
+	if seg := m.staleSegment(); seg != "" {
+		t.Errorf("Expected no stale segment, got %q", seg)
+	}
+}
+
+func TestSnapshot_RefreshAllLoadsBaseData(t *testing.T) {
+	m, api := newStaleTestModelUI()
+
+	_, cmd := m.Update(RefreshAllMsg{})
+	collectMsgsFromCmd(cmd)
+
+	if api.refreshBaseDataCalled != 1 {
+		t.Errorf("Expected base data refresh, got %d calls", api.refreshBaseDataCalled)
+	}
+}
+
+func TestSnapshot_StatusBarShowsStale(t *testing.T) {
+	m, api := newStaleTestModelUI()
+
+	bar := m.statusBar()
+	if !strings.Contains(bar, "stale since") || !strings.Contains(bar, "transactions") {
+		t.Errorf("Expected stale marker in status bar, got %q", bar)
+	}
+
+	api.stale = nil
+	if bar := m.statusBar(); strings.Contains(bar, "stale") {
+		t.Errorf("Expected stale marker to disappear after refresh, got %q", bar)
+	}
+}
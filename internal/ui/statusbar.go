@@ -0,0 +1,77 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const statusBarSeparator = " | "
+
+// statusBar renders the persistent bottom line. The period or search and
+// loading operations are shown on the left, the active filters are left to
+// the chips in the header; the connected server and profile are
+// right-aligned and dropped first when space runs out.
+func (m *modelUI) statusBar() string {
+	var segments []string
+	if m.transactions.currentSearch != "" {
+		segments = append(segments, "Search: "+m.transactions.currentSearch)
+	} else {
+		segments = append(segments, fmt.Sprintf("p %s %d",
+			m.api.PeriodStart().Month(),
+			m.api.PeriodStart().Year()))
+	}
+
+	if pending := m.vim.pending(); pending != "" {
+		segments = append(segments, pending)
+	}
+
+	if m.loading.Active() {
+		segments = append(segments, m.loading.View())
+	}
+
+	if failed := m.loadSegment(); failed != "" {
+		segments = append(segments, failed)
+	}
+
+	if offline := m.offlineSegment(); offline != "" {
+		segments = append(segments, offline)
+	}
+
+	if stale := m.staleSegment(); stale != "" {
+		segments = append(segments, stale)
+	}
+
+	if attempt, maxRetries := m.api.RetryStatus(); attempt > 0 {
+		segments = append(segments, fmt.Sprintf("retry %d/%d", attempt, maxRetries))
+	}
+
+	left := " " + strings.Join(segments, statusBarSeparator)
+	right := m.styles.StatusBarAccent.Render(serverHost(m.api.ServerURL())) +
+		statusBarSeparator + "profile: " + activeProfile() + " "
+
+	line := left
+	if gap := m.Width - lipgloss.Width(left) - lipgloss.Width(right); gap >= 1 {
+		line = left + strings.Repeat(" ", gap) + right
+	}
+	line = lipgloss.NewStyle().Inline(true).MaxWidth(m.Width).Render(line)
+
+	return m.styles.StatusBar.Width(m.Width).Render(line)
+}
+
+func serverHost(apiURL string) string {
+	if apiURL == "" {
+		return "not connected"
+	}
+	u, err := url.Parse(apiURL)
+	if err != nil || u.Host == "" {
+		return apiURL
+	}
+	return u.Host
+}
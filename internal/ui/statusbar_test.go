@@ -0,0 +1,160 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"strings"
+
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/loading"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/viper"
+)
+
+func TestStatusBar_ShowsServerProfileAndPeriod(t *testing.T) {
+	m := newTestModelUI()
+	m.Width = 120
+
+	bar := m.statusBar()
+
+	if !strings.Contains(bar, "firefly.example.com") {
+		t.Errorf("Expected status bar to contain server host, got %q", bar)
+	}
+	if !strings.Contains(bar, "profile: default") {
+		t.Errorf("Expected status bar to contain default profile, got %q", bar)
+	}
+	period := m.api.PeriodStart().Month().String()
+	if !strings.Contains(bar, period) {
+		t.Errorf("Expected status bar to contain period %q, got %q", period, bar)
+	}
+}
+
+func TestStatusBar_ShowsConfiguredProfile(t *testing.T) {
+	viper.Set("profile", "work")
+	defer viper.Set("profile", "")
+
+	m := newTestModelUI()
+	m.Width = 120
+
+	if bar := m.statusBar(); !strings.Contains(bar, "profile: work") {
+		t.Errorf("Expected status bar to contain configured profile, got %q", bar)
+	}
+}
+
+func TestStatusBar_SearchReplacesPeriod(t *testing.T) {
+	m := newTestModelUI()
+	m.Width = 120
+	m.transactions.currentSearch = "coffee"
+
+	bar := m.statusBar()
+
+	if !strings.Contains(bar, "Search: coffee") {
+		t.Errorf("Expected status bar to contain search, got %q", bar)
+	}
+	if strings.Contains(bar, "p "+m.api.PeriodStart().Month().String()) {
+		t.Errorf("Expected period to be hidden while searching, got %q", bar)
+	}
+}
+
+func TestStatusBar_LeavesFiltersToHeader(t *testing.T) {
+	m := newTestModelUI()
+	m.Width = 200
+	m.transactions.currentAccount = firefly.Account{ID: "1", Name: "Wallet"}
+	m.transactions.currentCategory = firefly.Category{ID: "2", Name: "Food"}
+	m.transactions.currentFilter = "lunch"
+	m.transactions.unreconciledOnly = true
+	m.transactions.typeFilter = "deposit"
+	m.transactions.dates = dateRange{from: "2026-01-05", to: "2026-01-12"}
+
+	bar := m.statusBar()
+	header := renderFilterChips(m.transactions.filterChips(), m.styles)
+
+	for _, want := range []string{"Account: Wallet", "Category: Food", "Filter: lunch", "Dates: 2026-01-05..2026-01-12", "Type: deposit", "Unreconciled"} {
+		if strings.Contains(bar, want) {
+			t.Errorf("Expected %q shown once, in the header, got status bar %q", want, bar)
+		}
+		if !strings.Contains(header, want) {
+			t.Errorf("Expected header chips to contain %q, got %q", want, header)
+		}
+	}
+}
+
+func TestStatusBar_ShowsLoadingOperations(t *testing.T) {
+	m := newTestModelUI()
+	m.Width = 120
+
+	op := loading.NewOperation("Loading summary...")
+	updated, _ := m.Update(loading.StartMsg{Op: op})
+	m = updated.(modelUI)
+	if bar := m.statusBar(); !strings.Contains(bar, "Loading summary...") {
+		t.Errorf("Expected status bar to contain loading message, got %q", bar)
+	}
+	op.Stop()
+	if bar := m.statusBar(); strings.Contains(bar, "Loading summary...") {
+		t.Errorf("Expected loading message to be gone, got %q", bar)
+	}
+}
+
+func TestStatusBar_ShowsRetryCount(t *testing.T) {
+	api := newTestUIAPI()
+	m := NewModelUI(api)
+	m.Width = 120
+
+	if bar := m.statusBar(); strings.Contains(bar, "retry") {
+		t.Errorf("Expected no retry segment, got %q", bar)
+	}
+
+	api.retryAttempt, api.maxRetries = 2, 3
+	if bar := m.statusBar(); !strings.Contains(bar, "retry 2/3") {
+		t.Errorf("Expected retry count in status bar, got %q", bar)
+	}
+}
+
+func TestStatusBar_FitsSingleLine(t *testing.T) {
+	m := newTestModelUI()
+	m.Width = 30
+	m.transactions.currentFilter = strings.Repeat("x", 100)
+
+	bar := m.statusBar()
+
+	if h := lipgloss.Height(bar); h != 1 {
+		t.Errorf("Expected status bar height 1, got %d", h)
+	}
+	if w := lipgloss.Width(bar); w != 30 {
+		t.Errorf("Expected status bar width 30, got %d", w)
+	}
+}
+
+func TestStatusBar_RenderedInView(t *testing.T) {
+	m := newTestModelUI()
+	m.Width = 120
+
+	view := m.View()
+
+	if !strings.Contains(view, "firefly.example.com") {
+		t.Error("Expected view to contain status bar")
+	}
+}
+
+func TestServerHost(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"https://firefly.example.com/api/v1", "firefly.example.com"},
+		{"http://localhost:8080/api/v1", "localhost:8080"},
+		{"", "not connected"},
+		{"not a url", "not a url"},
+	}
+
+	for _, tt := range tests {
+		if got := serverHost(tt.in); got != tt.want {
+			t.Errorf("serverHost(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
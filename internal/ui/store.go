@@ -0,0 +1,46 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import "ffiii-tui/internal/firefly"
+
+// Readers of the loaded data, satisfied by both the APIs and the data of
+// their store.
+type (
+	accountsReader interface {
+		AccountsByType(accountType string) []firefly.Account
+		AccountBalance(accountID string) float64
+	}
+	expensesReader interface {
+		accountsReader
+		GetExpenseDiff(accountID string) float64
+	}
+	revenuesReader interface {
+		accountsReader
+		GetRevenueDiff(accountID string) float64
+	}
+	categoriesReader interface {
+		CategoriesList() []firefly.Category
+		CategorySpent(categoryID string) float64
+		CategoryEarned(categoryID string) float64
+	}
+	tagsReader interface {
+		TagsList() []firefly.Tag
+		TagSpent(tagID string) float64
+		TagEarned(tagID string) float64
+	}
+)
+
+// fromStore returns the data of api as one consistent state when the api
+// keeps it in a store, so items built from several reads agree even when
+// a load lands in between. Other APIs are read as they are.
+func fromStore[R any](api R) R {
+	if store, ok := any(api).(StoreAPI); ok {
+		if data, ok := any(store.Data()).(R); ok {
+			return data
+		}
+	}
+	return api
+}
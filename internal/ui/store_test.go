@@ -0,0 +1,52 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+)
+
+type storeAccountsAPI struct {
+	accountsReader
+	data firefly.StoreData
+}
+
+func (s storeAccountsAPI) Data() firefly.StoreData {
+	return s.data
+}
+
+func assetsMock() *mockAssetAPI {
+	return &mockAssetAPI{
+		accountsByTypeFunc: func(string) []firefly.Account {
+			return []firefly.Account{{ID: "1", Name: "Checking"}}
+		},
+	}
+}
+
+func TestFromStore_ReadsStoreData(t *testing.T) {
+	api := storeAccountsAPI{accountsReader: assetsMock()}
+
+	data := fromStore[accountsReader](api)
+	if _, ok := data.(firefly.StoreData); !ok {
+		t.Fatalf("expected the data of the store, got %T", data)
+	}
+	if accounts := data.AccountsByType("asset"); len(accounts) != 0 {
+		t.Errorf("expected the accounts of the empty store, got %v", accounts)
+	}
+}
+
+func TestFromStore_WithoutStore(t *testing.T) {
+	api := assetsMock()
+
+	data := fromStore[accountsReader](api)
+	if data != accountsReader(api) {
+		t.Errorf("expected the api read as is, got %T", data)
+	}
+	if accounts := data.AccountsByType("asset"); len(accounts) != 1 {
+		t.Errorf("expected the accounts of the api, got %v", accounts)
+	}
+}
@@ -4,7 +4,10 @@ SPDX-License-Identifier: Apache-2.0
 */
 package ui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/viper"
+)
 
 type Styles struct {
 	ListItem         lipgloss.Style
@@ -29,10 +32,35 @@ type Styles struct {
 
 	Withdrawal lipgloss.Style
 	Deposit    lipgloss.Style
+	Transfer   lipgloss.Style
 	Normal     lipgloss.Style
 
+	// Text matching the filter or search query in the transactions table
+	SearchMatch lipgloss.Style
+
+	// Short labels of the transaction types in the transactions table
+	WithdrawalBadge string
+	DepositBadge    string
+	TransferBadge   string
+
 	TabActive   lipgloss.Style
 	TabInactive lipgloss.Style
+
+	TableFooter lipgloss.Style
+
+	FilterChip     lipgloss.Style
+	FilterChipHint lipgloss.Style
+
+	StatusBar        lipgloss.Style
+	StatusBarAccent  lipgloss.Style
+	StatusBarStale   lipgloss.Style
+	StatusBarOffline lipgloss.Style
+	StatusBarError   lipgloss.Style
+
+	PanelError lipgloss.Style
+
+	BannerError lipgloss.Style
+	BannerWarn  lipgloss.Style
 }
 
 func DefaultStyles() Styles {
@@ -45,7 +73,7 @@ func DefaultStyles() Styles {
 		BorderStyle(lipgloss.ThickBorder()).
 		BorderForeground(lipgloss.Color("#5F5FD7"))
 
-	return Styles{
+	return applyTheme(Styles{
 		// List styles
 		ListItem: lipgloss.NewStyle().
 			PaddingLeft(2).
@@ -77,10 +105,95 @@ func DefaultStyles() Styles {
 		// Transaction type styles
 		Withdrawal: lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5555")),
 		Deposit:    lipgloss.NewStyle().Foreground(lipgloss.Color("#00AF00")),
+		Transfer:   lipgloss.NewStyle().Foreground(lipgloss.Color("#DDDADA")),
 		Normal:     lipgloss.NewStyle().Foreground(lipgloss.Color("#DDDADA")),
 
+		SearchMatch: lipgloss.NewStyle().Foreground(lipgloss.Color("#FFAF00")),
+
+		WithdrawalBadge: "WD",
+		DepositBadge:    "DEP",
+		TransferBadge:   "TRF",
+
 		// Tab bar styles
 		TabActive:   lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#5F5FD7")),
 		TabInactive: lipgloss.NewStyle().Foreground(lipgloss.Color("#585858")),
+
+		TableFooter: lipgloss.NewStyle().Foreground(lipgloss.Color("#8A8A8A")),
+
+		// Filter chip styles
+		FilterChip: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#DDDADA")).
+			Background(lipgloss.Color("#5F5FD7")).
+			Padding(0, 1),
+		FilterChipHint: lipgloss.NewStyle().Foreground(lipgloss.Color("#585858")),
+
+		// Status bar styles
+		StatusBar: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#DDDADA")).
+			Background(lipgloss.Color("#303030")),
+		StatusBarAccent: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#5F5FD7")).
+			Background(lipgloss.Color("#303030")),
+		StatusBarStale: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#D7AF5F")).
+			Background(lipgloss.Color("#303030")),
+		StatusBarOffline: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#D75F5F")).
+			Background(lipgloss.Color("#303030")),
+		StatusBarError: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#D75F5F")).
+			Background(lipgloss.Color("#303030")),
+
+		PanelError: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#D75F5F")),
+
+		BannerError: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#FFFFFF")).
+			Background(lipgloss.Color("#D75F5F")),
+		BannerWarn: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#1C1C1C")).
+			Background(lipgloss.Color("#D7AF5F")),
+	})
+}
+
+// applyTheme overrides the transaction type colors and badges with the ones
+// set in the ui.theme config section.
+func applyTheme(s Styles) Styles {
+	colors := map[string]*lipgloss.Style{
+		"withdrawal": &s.Withdrawal,
+		"deposit":    &s.Deposit,
+		"transfer":   &s.Transfer,
+	}
+	for name, style := range colors {
+		if color := viper.GetString("ui.theme." + name); color != "" {
+			*style = style.Foreground(lipgloss.Color(color))
+		}
+	}
+
+	badges := map[string]*string{
+		"withdrawal_badge": &s.WithdrawalBadge,
+		"deposit_badge":    &s.DepositBadge,
+		"transfer_badge":   &s.TransferBadge,
+	}
+	for name, badge := range badges {
+		if label := viper.GetString("ui.theme." + name); label != "" {
+			*badge = label
+		}
+	}
+	return s
+}
+
+// typeStyle returns the style of the amounts and badges of a transaction
+// type.
+func (s Styles) typeStyle(transactionType string) lipgloss.Style {
+	switch transactionType {
+	case "withdrawal":
+		return s.Withdrawal
+	case "deposit":
+		return s.Deposit
 	}
+	return s.Transfer
 }
@@ -0,0 +1,53 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/viper"
+)
+
+func TestDefaultStyles_Theme(t *testing.T) {
+	defaults := DefaultStyles()
+	if defaults.WithdrawalBadge != "WD" || defaults.DepositBadge != "DEP" || defaults.TransferBadge != "TRF" {
+		t.Errorf("unexpected default badges %q %q %q", defaults.WithdrawalBadge, defaults.DepositBadge, defaults.TransferBadge)
+	}
+
+	viper.Set("ui.theme.withdrawal", "#123456")
+	viper.Set("ui.theme.transfer_badge", "XFER")
+	defer viper.Set("ui.theme.withdrawal", "")
+	defer viper.Set("ui.theme.transfer_badge", "")
+
+	styles := DefaultStyles()
+	if got := styles.Withdrawal.GetForeground(); got != lipgloss.Color("#123456") {
+		t.Errorf("expected themed withdrawal color, got %v", got)
+	}
+	if got := styles.Deposit.GetForeground(); got != defaults.Deposit.GetForeground() {
+		t.Errorf("expected default deposit color, got %v", got)
+	}
+	if styles.TransferBadge != "XFER" || styles.WithdrawalBadge != "WD" {
+		t.Errorf("expected themed transfer badge only, got %q %q", styles.TransferBadge, styles.WithdrawalBadge)
+	}
+}
+
+func TestStyles_TypeStyle(t *testing.T) {
+	styles := DefaultStyles()
+	tests := []struct {
+		transactionType string
+		want            lipgloss.Style
+	}{
+		{"withdrawal", styles.Withdrawal},
+		{"deposit", styles.Deposit},
+		{"transfer", styles.Transfer},
+		{"opening balance", styles.Transfer},
+	}
+	for _, tt := range tests {
+		if got := styles.typeStyle(tt.transactionType); got.GetForeground() != tt.want.GetForeground() {
+			t.Errorf("typeStyle(%q) = %v, want %v", tt.transactionType, got.GetForeground(), tt.want.GetForeground())
+		}
+	}
+}
@@ -9,9 +9,12 @@ import (
 	"io"
 	"slices"
 	"strings"
+	"time"
 	"unicode/utf8"
 
-	"ffiii-tui/internal/ui/notify"
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/loading"
+	"ffiii-tui/internal/ui/money"
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
@@ -27,7 +30,12 @@ type (
 type summaryItem struct {
 	title, value  string
 	monetaryValue float64
-	style         lipgloss.Style
+	// currency groups the summary items when they span several currencies
+	currency string
+	style    lipgloss.Style
+	// widget is set on the rows of the bills due, the savings goals and the
+	// account groups
+	widget bool
 }
 
 func (i summaryItem) FilterValue() string { return i.title }
@@ -67,18 +75,20 @@ func (d summaryDelegate) Render(w io.Writer, m list.Model, index int, listItem l
 }
 
 type modelSummary struct {
-	list   list.Model
-	api    SummaryAPI
-	styles Styles
+	list     list.Model
+	api      SummaryAPI
+	styles   Styles
+	requests *requestScope // of the selected period
 }
 
-func newModelSummary(api SummaryAPI) modelSummary {
+func newModelSummary(api SummaryAPI, requests *requestScope) modelSummary {
 	styles := DefaultStyles()
 	items := getSummaryItems(api, styles)
 	m := modelSummary{
-		list:   list.New(items, summaryDelegate{}, 0, 0),
-		api:    api,
-		styles: styles,
+		list:     list.New(items, summaryDelegate{}, 0, 0),
+		api:      api,
+		styles:   styles,
+		requests: requests,
 	}
 	m.list.Title = "Summary"
 	m.list.SetShowStatusBar(false)
@@ -86,7 +96,7 @@ func newModelSummary(api SummaryAPI) modelSummary {
 	m.list.SetShowHelp(false)
 	m.list.DisableQuitKeybindings()
 	m.list.SetShowPagination(false)
-	m.list.SetWidth(api.GetMaxWidth())
+	m.list.SetWidth(max(api.GetMaxWidth(), widgetsWidth(items)))
 	return m
 }
 
@@ -95,32 +105,43 @@ func (m modelSummary) Init() tea.Cmd {
 }
 
 func (m modelSummary) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch msg := msg.(type) {
+	switch msg.(type) {
 	case RefreshSummaryMsg:
-		return m, func() tea.Msg {
-			opID := startLoading("Loading summary...")
-			defer stopLoading(opID)
-			err := m.api.UpdateSummary()
+		ctx := m.requests.Context()
+		return m, loading.Track("Loading summary...", func() tea.Msg {
+			err := m.api.UpdateSummary(ctx)
 			if err != nil {
-				return notify.NotifyWarn(err.Error())()
+				return dataLoadFailed("summary", err)
 			}
 			return SummaryUpdateMsg{}
-		}
+		})
 	case SummaryUpdateMsg:
-		return m, tea.Sequence(
-			m.list.SetItems(getSummaryItems(m.api, m.styles)),
-			tea.WindowSize())
-	case UpdatePositions:
-		if msg.layout != nil {
-			_, v := m.styles.Base.GetFrameSize()
-			l := len(m.list.Items())
-			m.list.SetHeight(l + v + 1)
-			msg.layout.SummarySize = m.list.Height()
+		items := getSummaryItems(m.api, m.styles)
+		m.list.SetWidth(max(m.list.Width(), widgetsWidth(items)))
+		return m, tea.Batch(
+			tea.Sequence(
+				m.list.SetItems(items),
+				tea.WindowSize()),
+			Cmd(DataLoadCompletedMsg{DataType: "summary"}))
+	case AssetsUpdateMsg, LiabilitiesUpdateMsg:
+		// The account groups sum the balances loaded with the accounts
+		if len(loadAccountGroups()) > 0 {
+			items := getSummaryItems(m.api, m.styles)
+			m.list.SetWidth(max(m.list.Width(), widgetsWidth(items)))
+			return m, tea.Sequence(m.list.SetItems(items), tea.WindowSize())
 		}
+	case UpdatePositions:
+		m.list.SetHeight(m.height())
 	}
 	return m, nil
 }
 
+// height is the height of the summary showing all its items.
+func (m modelSummary) height() int {
+	_, v := m.styles.Base.GetFrameSize()
+	return len(m.list.Items()) + v + 1
+}
+
 func (m modelSummary) View() string {
 	return m.styles.LeftPanel.Render(m.list.View())
 }
@@ -128,6 +149,7 @@ func (m modelSummary) View() string {
 func getSummaryItems(api SummaryAPI, styles Styles) []list.Item {
 	var style lipgloss.Style
 	items := []list.Item{}
+	amounts := money.New(api)
 	for _, si := range api.SummaryItems() {
 		switch {
 		case si.MonetaryValue < 0:
@@ -137,10 +159,15 @@ func getSummaryItems(api SummaryAPI, styles Styles) []list.Item {
 		default:
 			style = styles.Normal
 		}
+		value := si.ValueParsed
+		if _, large := money.Abbreviate(si.MonetaryValue); large && money.Abbreviated() {
+			value = amounts.Short(si.MonetaryValue, si.CurrencyCode)
+		}
 		item := summaryItem{
 			title:         si.Title,
-			value:         si.ValueParsed,
+			value:         value,
 			monetaryValue: si.MonetaryValue,
+			currency:      si.CurrencyCode,
 			style:         style,
 		}
 		items = append(items, item)
@@ -149,6 +176,9 @@ func getSummaryItems(api SummaryAPI, styles Styles) []list.Item {
 	slices.SortFunc(items, func(a, b list.Item) int {
 		sa := a.(summaryItem)
 		sb := b.(summaryItem)
+		if c := strings.Compare(sa.currency, sb.currency); c != 0 {
+			return c
+		}
 		if sa.monetaryValue != sb.monetaryValue {
 			if sa.monetaryValue < sb.monetaryValue {
 				return 1
@@ -157,5 +187,77 @@ func getSummaryItems(api SummaryAPI, styles Styles) []list.Item {
 		}
 		return 0
 	})
+	items = groupByCurrency(items, styles)
+	items = append(items, getGroupItems(api, loadAccountGroups(), styles, amounts)...)
+	now := time.Now()
+	items = append(items, getBurnItems(api.BurnRate(), styles, amounts)...)
+	items = append(items, getDueItems(api.SubscriptionsDue(), now, styles, amounts)...)
+	return append(items, getGoalItems(api.PiggyBanks(), now, styles, amounts)...)
+}
+
+// groupByCurrency puts a header row above the items of each currency when
+// the summary spans several, so the balance, spent and earned lines of EUR
+// and USD read as separate subtotals. The items are sorted by currency.
+func groupByCurrency(items []list.Item, styles Styles) []list.Item {
+	currencies := map[string]bool{}
+	for _, item := range items {
+		currencies[item.(summaryItem).currency] = true
+	}
+	if len(currencies) < 2 {
+		return items
+	}
+	grouped := make([]list.Item, 0, len(items)+len(currencies))
+	current := ""
+	for i, item := range items {
+		code := item.(summaryItem).currency
+		if i == 0 || code != current {
+			current = code
+			title := code
+			if title == "" {
+				title = "Other"
+			}
+			grouped = append(grouped, summaryItem{
+				title: title,
+				style: styles.Normal,
+			})
+		}
+		grouped = append(grouped, item)
+	}
+	return grouped
+}
+
+// getGoalItems lists the piggy banks with a target date after the summary,
+// each with the contribution per month it still needs: green while the
+// savings keep pace with the target date, red when behind.
+func getGoalItems(goals []firefly.PiggyBank, now time.Time, styles Styles, amounts money.Formatter) []list.Item {
+	items := []list.Item{}
+	for _, goal := range goals {
+		item := summaryItem{
+			title:  "Goal " + goal.Name,
+			value:  amounts.Short(goal.MonthlyNeeded(now), goal.CurrencyCode) + "/mo",
+			style:  styles.Deposit,
+			widget: true,
+		}
+		switch {
+		case goal.Left() == 0:
+			item.value = "reached"
+		case !goal.OnTrack(now):
+			item.value += " behind"
+			item.style = styles.Withdrawal
+		}
+		items = append(items, item)
+	}
 	return items
 }
+
+// widgetsWidth is the width the widget rows need, like GetMaxWidth does
+// for the summary items.
+func widgetsWidth(items []list.Item) int {
+	width := 0
+	for _, item := range items {
+		if i, ok := item.(summaryItem); ok && i.widget {
+			width = max(width, utf8.RuneCountInString(i.title)+utf8.RuneCountInString(i.value)+1)
+		}
+	}
+	return width
+}
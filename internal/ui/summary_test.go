@@ -5,11 +5,14 @@ SPDX-License-Identifier: Apache-2.0
 package ui
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/money"
 	"ffiii-tui/internal/ui/notify"
 
 	"github.com/charmbracelet/lipgloss"
@@ -25,13 +28,17 @@ type mockSummaryAPI struct {
 	updateSummaryFunc func() error
 	getMaxWidthFunc   func() int
 	summaryItemsFunc  func() map[string]firefly.SummaryItem
+	piggyBanks        []firefly.PiggyBank
+	burnRate          firefly.BurnRate
+	accounts          map[string][]firefly.Account
+	balances          map[string]float64
 
 	updateSummaryCalled int
 	getMaxWidthCalled   int
 	summaryItemsCalled  int
 }
 
-func (m *mockSummaryAPI) UpdateSummary() error {
+func (m *mockSummaryAPI) UpdateSummary(_ context.Context) error {
 	m.updateSummaryCalled++
 	if m.updateSummaryFunc != nil {
 		return m.updateSummaryFunc()
@@ -61,6 +68,34 @@ func (m *mockSummaryAPI) SummaryItems() map[string]firefly.SummaryItem {
 	}
 }
 
+func (m *mockSummaryAPI) PiggyBanks() []firefly.PiggyBank {
+	return m.piggyBanks
+}
+
+func (m *mockSummaryAPI) BurnRate() firefly.BurnRate {
+	return m.burnRate
+}
+
+func (m *mockSummaryAPI) SubscriptionsDue() []firefly.Subscription {
+	return nil
+}
+
+func (m *mockSummaryAPI) UpdateAccounts(_ context.Context, _ string) error {
+	return nil
+}
+
+func (m *mockSummaryAPI) AccountsByType(accountType string) []firefly.Account {
+	return m.accounts[accountType]
+}
+
+func (m *mockSummaryAPI) AccountBalance(accountID string) float64 {
+	return m.balances[accountID]
+}
+
+func (m *mockSummaryAPI) GetCurrencyByCode(code string) firefly.Currency {
+	return firefly.Currency{Code: code}
+}
+
 func newTestSummaryAPI() *mockSummaryAPI {
 	return &mockSummaryAPI{}
 }
@@ -86,7 +121,7 @@ func TestSummary_NewModelSummary(t *testing.T) {
 		}
 	}
 
-	m := newModelSummary(api)
+	m := newModelSummary(api, newRequestScope())
 
 	if m.api == nil {
 		t.Error("Expected api to be set")
@@ -112,7 +147,7 @@ func TestSummary_NewModelSummary(t *testing.T) {
 
 func TestSummary_Init(t *testing.T) {
 	api := newTestSummaryAPI()
-	m := newModelSummary(api)
+	m := newModelSummary(api, newRequestScope())
 
 	cmd := m.Init()
 
@@ -174,6 +209,45 @@ func TestSummary_GetSummaryItems_Sorting(t *testing.T) {
 	}
 }
 
+func TestSummary_GetSummaryItems_GroupedByCurrency(t *testing.T) {
+	api := newTestSummaryAPI()
+	api.summaryItemsFunc = func() map[string]firefly.SummaryItem {
+		return map[string]firefly.SummaryItem{
+			"balance-in-USD": {Title: "Balance (USD)", ValueParsed: "$50.00", MonetaryValue: 50, CurrencyCode: "USD"},
+			"spent-in-EUR":   {Title: "Spent (EUR)", ValueParsed: "-€20.00", MonetaryValue: -20, CurrencyCode: "EUR"},
+			"balance-in-EUR": {Title: "Balance (EUR)", ValueParsed: "€80.00", MonetaryValue: 80, CurrencyCode: "EUR"},
+			"spent-in-USD":   {Title: "Spent (USD)", ValueParsed: "-$10.00", MonetaryValue: -10, CurrencyCode: "USD"},
+		}
+	}
+
+	items := getSummaryItems(api, DefaultStyles())
+
+	expected := []string{"EUR", "Balance (EUR)", "Spent (EUR)", "USD", "Balance (USD)", "Spent (USD)"}
+	if len(items) != len(expected) {
+		t.Fatalf("Expected %d items, got %d", len(expected), len(items))
+	}
+	for i, item := range items {
+		if title := item.(summaryItem).title; title != expected[i] {
+			t.Errorf("Expected item %d to be %q, got %q", i, expected[i], title)
+		}
+	}
+}
+
+func TestSummary_GetSummaryItems_SingleCurrencyWithoutHeader(t *testing.T) {
+	api := newTestSummaryAPI()
+	api.summaryItemsFunc = func() map[string]firefly.SummaryItem {
+		return map[string]firefly.SummaryItem{
+			"balance-in-EUR": {Title: "Balance (EUR)", ValueParsed: "€80.00", MonetaryValue: 80, CurrencyCode: "EUR"},
+			"spent-in-EUR":   {Title: "Spent (EUR)", ValueParsed: "-€20.00", MonetaryValue: -20, CurrencyCode: "EUR"},
+		}
+	}
+
+	items := getSummaryItems(api, DefaultStyles())
+	if len(items) != 2 || items[0].(summaryItem).title != "Balance (EUR)" {
+		t.Errorf("Expected the items without a currency header, got %+v", items)
+	}
+}
+
 func TestSummary_GetSummaryItems_Styling(t *testing.T) {
 	api := newTestSummaryAPI()
 	styles := DefaultStyles()
@@ -227,6 +301,58 @@ func TestSummary_GetSummaryItems_Styling(t *testing.T) {
 	}
 }
 
+func TestSummary_GetGoalItems(t *testing.T) {
+	styles := DefaultStyles()
+	now := time.Date(2026, time.March, 18, 12, 0, 0, 0, time.UTC)
+	goals := []firefly.PiggyBank{
+		// 10 months in, 10 to go with half saved
+		{Name: "Holiday", CurrencyCode: "EUR", Target: 2000, Saved: 1000, StartDate: "2025-05-18", TargetDate: "2027-01-18"},
+		// half the time gone with a fifth saved
+		{Name: "Laptop", CurrencyCode: "EUR", Target: 1000, Saved: 200, StartDate: "2026-01-18", TargetDate: "2026-05-18"},
+		{Name: "Bike", CurrencyCode: "EUR", Target: 500, Saved: 500, StartDate: "2026-01-01", TargetDate: "2026-06-30"},
+		{Name: "Sofa", CurrencyCode: "EUR", Target: 800, Saved: 100, TargetDate: "2026-02-28"},
+	}
+
+	items := getGoalItems(goals, now, styles, money.Formatter{})
+	if len(items) != 4 {
+		t.Fatalf("Expected 4 goal items, got %d", len(items))
+	}
+	tests := []struct {
+		title, value string
+		style        lipgloss.Style
+	}{
+		{"Goal Holiday", "90.91 EUR/mo", styles.Deposit},
+		{"Goal Laptop", "266.67 EUR/mo behind", styles.Withdrawal},
+		{"Goal Bike", "reached", styles.Deposit},
+		{"Goal Sofa", "700.00 EUR/mo behind", styles.Withdrawal},
+	}
+	for i, tt := range tests {
+		si := items[i].(summaryItem)
+		if si.title != tt.title || si.value != tt.value {
+			t.Errorf("Expected %q %q, got %q %q", tt.title, tt.value, si.title, si.value)
+		}
+		if si.style.GetForeground() != tt.style.GetForeground() {
+			t.Errorf("Expected %s styled %v, got %v", tt.title, tt.style.GetForeground(), si.style.GetForeground())
+		}
+	}
+}
+
+func TestSummary_GoalsAfterSummaryItems(t *testing.T) {
+	api := newTestSummaryAPI()
+	api.piggyBanks = []firefly.PiggyBank{
+		{Name: "A very long savings goal name", CurrencyCode: "EUR", Target: 100, Saved: 100, TargetDate: "2030-01-01"},
+	}
+
+	m := newModelSummary(api, newRequestScope())
+	items := m.list.Items()
+	if len(items) != 2 || items[1].(summaryItem).title != "Goal A very long savings goal name" {
+		t.Fatalf("Expected the goal after the summary items, got %+v", items)
+	}
+	if m.list.Width() < widgetsWidth(items) {
+		t.Errorf("Expected the panel wide enough for the goal, got %d", m.list.Width())
+	}
+}
+
 func TestSummary_SummaryItem_FilterValue(t *testing.T) {
 	item := summaryItem{
 		title:         "Test Title",
@@ -248,7 +374,7 @@ func TestSummary_Update_RefreshSummaryMsg_Success(t *testing.T) {
 	api.updateSummaryFunc = func() error {
 		return nil
 	}
-	m := newModelSummary(api)
+	m := newModelSummary(api, newRequestScope())
 
 	_, cmd := m.Update(RefreshSummaryMsg{})
 
@@ -256,7 +382,7 @@ func TestSummary_Update_RefreshSummaryMsg_Success(t *testing.T) {
 		t.Fatal("Expected command to be returned")
 	}
 
-	msg := cmd()
+	msg := trackedMsg(cmd)
 	if _, ok := msg.(SummaryUpdateMsg); !ok {
 		t.Errorf("Expected SummaryUpdateMsg, got %T", msg)
 	}
@@ -271,7 +397,7 @@ func TestSummary_Update_RefreshSummaryMsg_Error(t *testing.T) {
 	api.updateSummaryFunc = func() error {
 		return errors.New("update failed")
 	}
-	m := newModelSummary(api)
+	m := newModelSummary(api, newRequestScope())
 
 	_, cmd := m.Update(RefreshSummaryMsg{})
 
@@ -279,10 +405,13 @@ func TestSummary_Update_RefreshSummaryMsg_Error(t *testing.T) {
 		t.Fatal("Expected command to be returned")
 	}
 
-	msg := cmd()
-	notifyMsg, ok := msg.(notify.NotifyMsg)
+	msgs := collectMsgsFromCmd(cmd)
+	if !hasMsg[DataLoadFailedMsg](msgs) {
+		t.Errorf("Expected DataLoadFailedMsg, got %v", msgs)
+	}
+	notifyMsg, ok := msgs[0].(notify.NotifyMsg)
 	if !ok {
-		t.Fatalf("Expected notify.NotifyMsg, got %T", msg)
+		t.Fatalf("Expected notify.NotifyMsg, got %T", msgs[0])
 	}
 
 	if notifyMsg.Level != notify.Warn {
@@ -307,7 +436,7 @@ func TestSummary_Update_SummaryUpdateMsg(t *testing.T) {
 		return initialItems
 	}
 
-	m := newModelSummary(api)
+	m := newModelSummary(api, newRequestScope())
 
 	// Update the items
 	newItems := map[string]firefly.SummaryItem{
@@ -347,7 +476,7 @@ func TestSummary_Update_UpdatePositions(t *testing.T) {
 		}
 	}
 
-	m := newModelSummary(api)
+	m := newModelSummary(api, newRequestScope())
 	initialHeight := m.list.Height()
 
 	updatedModel, cmd := m.Update(UpdatePositions{
@@ -376,7 +505,7 @@ func TestSummary_Update_UpdatePositions(t *testing.T) {
 
 func TestSummary_Update_UnknownMessage(t *testing.T) {
 	api := newTestSummaryAPI()
-	m := newModelSummary(api)
+	m := newModelSummary(api, newRequestScope())
 
 	type unknownMsg struct{}
 
@@ -407,7 +536,7 @@ func TestSummary_View(t *testing.T) {
 		}
 	}
 
-	m := newModelSummary(api)
+	m := newModelSummary(api, newRequestScope())
 	view := m.View()
 
 	if view == "" {
@@ -430,7 +559,7 @@ func TestSummary_EmptyItems(t *testing.T) {
 		return map[string]firefly.SummaryItem{}
 	}
 
-	m := newModelSummary(api)
+	m := newModelSummary(api, newRequestScope())
 
 	if len(m.list.Items()) != 0 {
 		t.Errorf("Expected 0 items, got %d", len(m.list.Items()))
@@ -464,7 +593,7 @@ func TestSummary_ManyItems(t *testing.T) {
 		return items
 	}
 
-	m := newModelSummary(api)
+	m := newModelSummary(api, newRequestScope())
 
 	if len(m.list.Items()) != 100 {
 		t.Errorf("Expected 100 items, got %d", len(m.list.Items()))
@@ -532,7 +661,7 @@ func TestSummary_LargeValues(t *testing.T) {
 		}
 	}
 
-	m := newModelSummary(api)
+	m := newModelSummary(api, newRequestScope())
 
 	if len(m.list.Items()) != 2 {
 		t.Errorf("Expected 2 items, got %d", len(m.list.Items()))
@@ -557,7 +686,7 @@ func TestSummary_SpecialCharactersInTitle(t *testing.T) {
 		}
 	}
 
-	m := newModelSummary(api)
+	m := newModelSummary(api, newRequestScope())
 
 	if len(m.list.Items()) != 1 {
 		t.Errorf("Expected 1 item, got %d", len(m.list.Items()))
@@ -588,7 +717,7 @@ func TestSummary_VeryLongTitles(t *testing.T) {
 		}
 	}
 
-	m := newModelSummary(api)
+	m := newModelSummary(api, newRequestScope())
 
 	if len(m.list.Items()) != 1 {
 		t.Errorf("Expected 1 item, got %d", len(m.list.Items()))
@@ -629,7 +758,7 @@ func TestSummary_DifferentWidths(t *testing.T) {
 				}
 			}
 
-			m := newModelSummary(api)
+			m := newModelSummary(api, newRequestScope())
 
 			if m.list.Width() != tt.width {
 				t.Errorf("Expected width %d, got %d", tt.width, m.list.Width())
@@ -669,7 +798,7 @@ func TestSummary_SummaryDelegate_Update(t *testing.T) {
 func TestSummary_SummaryDelegate_Render_InvalidItem(t *testing.T) {
 	delegate := summaryDelegate{}
 	api := newTestSummaryAPI()
-	m := newModelSummary(api)
+	m := newModelSummary(api, newRequestScope())
 
 	// Create a buffer to capture output
 	var buf strings.Builder
@@ -700,7 +829,7 @@ func TestSummary_IntegrationSequence(t *testing.T) {
 	}
 
 	// 1. Create model
-	m := newModelSummary(api)
+	m := newModelSummary(api, newRequestScope())
 	if len(m.list.Items()) != 1 {
 		t.Fatalf("Expected 1 initial item, got %d", len(m.list.Items()))
 	}
@@ -713,7 +842,7 @@ func TestSummary_IntegrationSequence(t *testing.T) {
 	m = m2.(modelSummary)
 
 	// 3. Execute refresh command
-	msg := cmd()
+	msg := trackedMsg(cmd)
 	if _, ok := msg.(SummaryUpdateMsg); !ok {
 		t.Fatalf("Expected SummaryUpdateMsg, got %T", msg)
 	}
@@ -0,0 +1,128 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/table"
+)
+
+// firstScrolledColumn is the first column of the transactions table that
+// scrolls horizontally, the type and the date before it stay in place.
+const firstScrolledColumn = 3
+
+// flexibleColumn is a column of the transactions table whose width follows
+// the space available: accounts, category and description. Longer values
+// are cut short with an ellipsis by the table, the full values show with
+// the ShowCells key.
+type flexibleColumn struct {
+	index    int
+	min, max int
+}
+
+// flexibleColumns are in the order they get spare space, the description
+// first.
+var flexibleColumns = []flexibleColumn{
+	{index: 10, min: 16, max: 50}, // Description
+	{index: 3, min: 10, max: 30},  // Source
+	{index: 4, min: 10, max: 30},  // Destination
+	{index: 5, min: 8, max: 30},   // Category
+}
+
+// fitColumns returns columns, as wide as their content, fitted to width:
+// the flexible ones are capped at their max, then grow back to their
+// content with the space left or shrink down to their min until the table
+// fits. What still does not fit scrolls. Without a width the columns are
+// only capped.
+func fitColumns(columns []table.Column, width int) []table.Column {
+	fitted := make([]table.Column, len(columns))
+	copy(fitted, columns)
+	flexible := []flexibleColumn{}
+	for _, f := range flexibleColumns {
+		if f.index < len(fitted) {
+			fitted[f.index].Width = min(fitted[f.index].Width, f.max)
+			flexible = append(flexible, f)
+		}
+	}
+	if width <= 0 {
+		return fitted
+	}
+
+	spare := width - tableWidth(fitted)
+	for _, f := range flexible {
+		if spare <= 0 {
+			break
+		}
+		grow := min(columns[f.index].Width-fitted[f.index].Width, spare)
+		fitted[f.index].Width += grow
+		spare -= grow
+	}
+
+	// The widest column above its min gives up a cell at a time, so the
+	// columns shrink evenly
+	for spare < 0 {
+		widest := -1
+		for _, f := range flexible {
+			if w := fitted[f.index].Width; w > f.min && (widest < 0 || w > fitted[widest].Width) {
+				widest = f.index
+			}
+		}
+		if widest < 0 {
+			break
+		}
+		fitted[widest].Width--
+		spare++
+	}
+	return fitted
+}
+
+// tableWidth returns the width columns take rendered, with the padding of
+// the cells.
+func tableWidth(columns []table.Column) int {
+	padding := table.DefaultStyles().Cell.GetHorizontalFrameSize()
+	total := 0
+	for _, column := range columns {
+		if column.Width > 0 {
+			total += column.Width + padding
+		}
+	}
+	return total
+}
+
+// scrolledColumns returns columns with scroll columns from
+// firstScrolledColumn on hidden, the table leaves out columns without width.
+func scrolledColumns(columns []table.Column, scroll int) []table.Column {
+	scrolled := make([]table.Column, len(columns))
+	copy(scrolled, columns)
+	for i := firstScrolledColumn; i < min(firstScrolledColumn+scroll, len(scrolled)); i++ {
+		scrolled[i].Width = 0
+	}
+	return scrolled
+}
+
+// maxScroll returns how many columns have to be scrolled out to show the
+// last one in width, none when all fit or the width is not known yet.
+func maxScroll(columns []table.Column, width int) int {
+	padding := table.DefaultStyles().Cell.GetHorizontalFrameSize()
+	total := tableWidth(columns)
+
+	scroll := 0
+	for i := firstScrolledColumn; width > 0 && total > width && i < len(columns)-1; i++ {
+		if columns[i].Width > 0 {
+			total -= columns[i].Width + padding
+		}
+		scroll++
+	}
+	return scroll
+}
+
+// arrangeColumns fits the columns to the width of the table and scrolls it
+// to scroll columns, within the columns that do not fit. It runs on every
+// resize, so the space is shared out again.
+func (m *modelTransactions) arrangeColumns(scroll int) {
+	width := m.table.Width()
+	fitted := fitColumns(m.columns, width)
+	m.scroll = max(0, min(scroll, maxScroll(fitted, width)))
+	m.table.SetColumns(scrolledColumns(fitted, m.scroll))
+}
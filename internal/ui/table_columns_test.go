@@ -0,0 +1,187 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestMaxScroll(t *testing.T) {
+	columns := []table.Column{
+		{Title: "ID", Width: 0},
+		{Title: "Type", Width: 2},
+		{Title: "Date", Width: 10},
+		{Title: "Source", Width: 20},
+		{Title: "Destination", Width: 20},
+		{Title: "Description", Width: 30},
+	}
+	// 4 + 12 + 22 + 22 + 32 = 92 with the padding of the cells
+	tests := []struct {
+		width int
+		want  int
+	}{
+		{0, 0},
+		{92, 0},
+		{80, 1},
+		{40, 2},
+		{10, 2},
+	}
+	for _, tt := range tests {
+		if got := maxScroll(columns, tt.width); got != tt.want {
+			t.Errorf("maxScroll(%d) = %d, want %d", tt.width, got, tt.want)
+		}
+	}
+
+	scrolled := scrolledColumns(columns, 2)
+	if scrolled[3].Width != 0 || scrolled[4].Width != 0 || scrolled[5].Width != 30 || scrolled[2].Width != 10 {
+		t.Errorf("expected source and destination hidden, got %v", scrolled)
+	}
+	if columns[3].Width != 20 {
+		t.Error("expected the full columns left as they are")
+	}
+}
+
+// transactionColumns returns the columns of the transactions table with
+// the widths of their content.
+func transactionColumns(source, destination, category, description int) []table.Column {
+	_, columns := getRows(nil, nil, DefaultStyles())
+	columns[3].Width = source
+	columns[4].Width = destination
+	columns[5].Width = category
+	columns[10].Width = description
+	return columns
+}
+
+func TestFitColumns(t *testing.T) {
+	columns := transactionColumns(40, 12, 9, 80)
+
+	capped := fitColumns(columns, 0)
+	if capped[3].Width != 30 || capped[4].Width != 12 || capped[5].Width != 9 || capped[10].Width != 50 {
+		t.Errorf("expected long columns capped without a width, got %v", capped)
+	}
+	if columns[10].Width != 80 {
+		t.Error("expected the content widths left as they are")
+	}
+
+	wide := fitColumns(columns, tableWidth(capped)+35)
+	if wide[10].Width != 80 || wide[3].Width != 35 {
+		t.Errorf("expected the spare space to the description first, got %d and %d", wide[10].Width, wide[3].Width)
+	}
+	if got := tableWidth(fitColumns(columns, 1000)); got != tableWidth(columns) {
+		t.Errorf("expected the columns no wider than their content, got %d", got)
+	}
+
+	narrow := fitColumns(columns, tableWidth(capped)-20)
+	if got := tableWidth(narrow); got != tableWidth(capped)-20 {
+		t.Errorf("expected the table shrunk to the width, got %d", got)
+	}
+	if narrow[10].Width != 30 || narrow[3].Width != 30 {
+		t.Errorf("expected the widest column shrunk first, got %d and %d", narrow[10].Width, narrow[3].Width)
+	}
+	narrow = fitColumns(columns, tableWidth(capped)-30)
+	if narrow[10].Width != 25 || narrow[3].Width != 25 || narrow[4].Width != 12 {
+		t.Errorf("expected the widest columns shrunk evenly, got %v", narrow)
+	}
+
+	tiny := fitColumns(columns, 20)
+	if tiny[10].Width != 16 || tiny[3].Width != 10 || tiny[4].Width != 10 || tiny[5].Width != 8 {
+		t.Errorf("expected the columns no narrower than their min, got %v", tiny)
+	}
+}
+
+func TestTransactions_ColumnsFollowResize(t *testing.T) {
+	txs := []firefly.Transaction{
+		newTestTransaction(1, "tx1", "withdrawal", "2024-01-17T10:00:00Z", strings.Repeat("long ", 16)),
+	}
+	m := newFocusedTransactionModel(t, txs)
+	resize := func(width int) int {
+		updated, _ := m.Update(UpdatePositions{layout: &LayoutConfig{Width: width, Height: 20}})
+		m = updated.(modelTransactions)
+		return m.table.Columns()[10].Width
+	}
+
+	if got := resize(300); got != 80 {
+		t.Errorf("expected the whole description on a wide terminal, got %d", got)
+	}
+	if got := resize(150); got >= 80 || got < 16 {
+		t.Errorf("expected the description shrunk on a narrower one, got %d", got)
+	}
+	if got := resize(300); got != 80 {
+		t.Errorf("expected the description to grow back, got %d", got)
+	}
+}
+
+func TestTransactions_ScrollColumns(t *testing.T) {
+	txs := []firefly.Transaction{
+		newTestTransaction(1, "tx1", "withdrawal", "2024-01-17T10:00:00Z", "A rather long description of a fuel stop"),
+	}
+	m := newFocusedTransactionModel(t, txs)
+	updated, _ := m.Update(UpdatePositions{layout: &LayoutConfig{Width: 60, Height: 20}})
+	m = updated.(modelTransactions)
+
+	press := func(keys string) {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(keys)})
+		m = updated.(modelTransactions)
+	}
+
+	if strings.Contains(m.table.View(), "A rather long") {
+		t.Fatal("expected the description out of view")
+	}
+	press("h")
+	if m.scroll != 0 {
+		t.Errorf("expected no scrolling left of the first column, got %d", m.scroll)
+	}
+	for range 20 {
+		press("l")
+	}
+	if m.scroll == 0 || m.scroll != maxScroll(fitColumns(m.columns, m.table.Width()), m.table.Width()) {
+		t.Errorf("expected scrolling to stop at the last column, got %d", m.scroll)
+	}
+	if !strings.Contains(m.table.View(), "tx1") {
+		t.Errorf("expected the last columns scrolled into view, got\n%s", m.table.View())
+	}
+	press("h")
+	if !strings.Contains(m.table.View(), "A rather long") {
+		t.Errorf("expected the description scrolled into view, got\n%s", m.table.View())
+	}
+
+	updated, _ = m.Update(UpdatePositions{layout: &LayoutConfig{Width: 300, Height: 20}})
+	m = updated.(modelTransactions)
+	if m.scroll != 0 {
+		t.Errorf("expected no scrolling once everything fits, got %d", m.scroll)
+	}
+}
+
+func TestUI_VimMode_ScrollsColumnsWithHL(t *testing.T) {
+	txs := []firefly.Transaction{
+		newTestTransaction(1, "tx1", "withdrawal", "2024-01-17T10:00:00Z", "A rather long description of a fuel stop"),
+	}
+	m := newTestModelUI()
+	m.vim = newVimLayer(true)
+	m.transactions = newFocusedTransactionModel(t, txs)
+	updated, _ := m.transactions.Update(UpdatePositions{layout: &LayoutConfig{Width: 60, Height: 20}})
+	m.transactions = updated.(modelTransactions)
+	m.state = transactionsView
+
+	press := func(r rune) {
+		updated, _ := m.Update(runeKey(r))
+		m = updated.(modelUI)
+	}
+
+	press('l')
+	if m.transactions.scroll != 1 {
+		t.Errorf("expected l to scroll right in vim mode, got %d", m.transactions.scroll)
+	}
+	press('h')
+	if m.transactions.scroll != 0 {
+		t.Errorf("expected h to scroll left in vim mode, got %d", m.transactions.scroll)
+	}
+}
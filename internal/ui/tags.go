@@ -0,0 +1,360 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/loading"
+	"ffiii-tui/internal/ui/money"
+	"ffiii-tui/internal/ui/notify"
+	"ffiii-tui/internal/ui/prompt"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type (
+	RefreshTagsMsg        struct{}
+	RefreshTagInsightsMsg struct{}
+	TagsUpdateMsg         struct{}
+	RenameTagMsg          struct {
+		Tag  firefly.Tag
+		Name string
+	}
+	DeleteTagMsg struct {
+		Tag firefly.Tag
+	}
+)
+
+// tagBarWidth is the width of the bar of the tag spent most on when the
+// tags are ranked by spent.
+const tagBarWidth = 20
+
+type tagItem struct {
+	tag      firefly.Tag
+	used     int
+	spent    float64
+	earned   float64
+	currency string
+	amounts  money.Formatter
+
+	// Set when ranked by spent: the part of the spent of all tags and the
+	// spent relative to the tag spent most on
+	share  float64
+	weight float64
+}
+
+func (i tagItem) Title() string {
+	if i.weight == 0 {
+		return tagPrefix + i.tag.Name
+	}
+	bar := strings.Repeat("█", max(1, int(math.Round(i.weight*tagBarWidth))))
+	return fmt.Sprintf("%s%s %s %.0f%%", tagPrefix, i.tag.Name, bar, i.share*100)
+}
+func (i tagItem) Description() string {
+	parts := []string{fmt.Sprintf("Used %d times", i.used)}
+	if i.used == 1 {
+		parts[0] = "Used once"
+	}
+	if i.spent != 0 {
+		parts = append(parts, "Spent: "+i.amounts.Short(i.spent, i.currency))
+	}
+	if i.earned != 0 {
+		parts = append(parts, "Earned: "+i.amounts.Short(i.earned, i.currency))
+	}
+	return strings.Join(parts, " | ")
+}
+func (i tagItem) FilterValue() string { return i.tag.Name }
+
+type modelTags struct {
+	list   list.Model
+	api    TagAPI
+	focus  bool
+	keymap TagKeyMap
+	styles Styles
+	// bySpent ranks the tags spent on in the period, heaviest first
+	bySpent  bool
+	requests *requestScope // of the selected period
+}
+
+func newModelTags(api TagAPI, requests *requestScope) modelTags {
+	m := modelTags{
+		list:     list.New(getTagsItems(api, false), list.NewDefaultDelegate(), 0, 0),
+		api:      api,
+		keymap:   DefaultTagKeyMap(),
+		styles:   DefaultStyles(),
+		requests: requests,
+	}
+	m.list.Title = "Tags"
+	m.list.Styles.HelpStyle = list.DefaultStyles().HelpStyle.PaddingLeft(4).PaddingBottom(1)
+	m.list.SetFilteringEnabled(true)
+	m.list.FilterInput.Blur()
+	m.list.SetShowStatusBar(false)
+	m.list.SetShowHelp(false)
+	m.list.DisableQuitKeybindings()
+
+	return m
+}
+
+func (m modelTags) Init() tea.Cmd {
+	return nil
+}
+
+func (m modelTags) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case RefreshTagInsightsMsg:
+		ctx := m.requests.Context()
+		return m, loading.Track("Loading tag insights...", func() tea.Msg {
+			err := m.api.UpdateTagsInsights(ctx)
+			if err != nil {
+				return dataLoadFailed("tags", err)
+			}
+			return TagsUpdateMsg{}
+		})
+	case RefreshTagsMsg:
+		return m, loading.Track("Loading tags...", func() tea.Msg {
+			err := m.api.UpdateTags(context.Background())
+			if err != nil {
+				return dataLoadFailed("tags", err)
+			}
+			return TagsUpdateMsg{}
+		})
+	case TagsUpdateMsg:
+		return m, tea.Batch(
+			m.list.SetItems(getTagsItems(m.api, m.bySpent)),
+			Cmd(DataLoadCompletedMsg{DataType: "tags"}),
+		)
+	case TransactionsUpdateMsg:
+		// Usage counts come from the transactions of the period
+		return m, m.list.SetItems(getTagsItems(m.api, m.bySpent))
+	case RenameTagMsg:
+		return m, renameTag(m.api, msg.Tag, msg.Name)
+	case DeleteTagMsg:
+		return m, deleteTag(m.api, msg.Tag)
+	case UpdatePositions:
+		if msg.layout != nil {
+			h, v := m.styles.Base.GetFrameSize()
+			m.list.SetSize(
+				msg.layout.Width-h,
+				msg.layout.Height-v-msg.layout.TopSize-msg.layout.TabBarSize,
+			)
+		}
+		m.list.FilterInput.Width = 20
+	}
+
+	if !m.focus {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keymap.Filter):
+			m.list.FilterInput.Focus()
+		case key.Matches(msg, m.keymap.Quit):
+			if m.list.FilterInput.Focused() {
+				m.list.FilterInput.Blur()
+			} else {
+				return m, SetView(transactionsView)
+			}
+		}
+	}
+
+	if m.list.FilterInput.Focused() {
+		m.list, cmd = m.list.Update(msg)
+		return m, cmd
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keymap.FilterBy):
+			i, ok := m.list.SelectedItem().(tagItem)
+			if !ok {
+				return m, nil
+			}
+			return m, Cmd(FilterMsg{Query: tagPrefix + i.tag.Name})
+		case key.Matches(msg, m.keymap.ResetFilter):
+			return m, Cmd(FilterMsg{Reset: true})
+		case key.Matches(msg, m.keymap.Rename):
+			i, ok := m.list.SelectedItem().(tagItem)
+			if !ok {
+				return m, nil
+			}
+			return m, CmdPromptRenameTag(i.tag, SetView(tagsView))
+		case key.Matches(msg, m.keymap.Delete):
+			i, ok := m.list.SelectedItem().(tagItem)
+			if !ok {
+				return m, nil
+			}
+			return m, confirmAction(
+				confirmDeleteTag,
+				fmt.Sprintf("Delete tag %s? Transactions keep everything else.", i.tag.Name),
+				tea.Sequence(SetView(tagsView), Cmd(DeleteTagMsg{Tag: i.tag})),
+				SetView(tagsView),
+			)
+		case key.Matches(msg, m.keymap.OpenInWeb):
+			i, ok := m.list.SelectedItem().(tagItem)
+			if !ok || i.tag.ID == "" {
+				return m, nil
+			}
+			return m, Cmd(OpenInWebMsg{Path: "tags/show/" + i.tag.ID})
+		case key.Matches(msg, m.keymap.Refresh):
+			m.api.ForgetInsights()
+			return m, Cmd(RefreshTagsMsg{})
+		case key.Matches(msg, m.keymap.Sort):
+			m.bySpent = !m.bySpent
+			m.list.Title = "Tags"
+			if m.bySpent {
+				m.list.Title = "Tags by spent"
+			}
+			m.list.ResetSelected()
+			return m, m.list.SetItems(getTagsItems(m.api, m.bySpent))
+		case key.Matches(msg, m.keymap.ViewTransactions):
+			return m, SetView(transactionsView)
+		case key.Matches(msg, m.keymap.ViewAssets):
+			return m, SetView(assetsView)
+		case key.Matches(msg, m.keymap.ViewCategories):
+			return m, SetView(categoriesView)
+		case key.Matches(msg, m.keymap.ViewExpenses):
+			return m, SetView(expensesView)
+		case key.Matches(msg, m.keymap.ViewRevenues):
+			return m, SetView(revenuesView)
+		case key.Matches(msg, m.keymap.ViewLiabilities):
+			return m, SetView(liabilitiesView)
+		}
+	}
+
+	m.list, cmd = m.list.Update(msg)
+
+	return m, cmd
+}
+
+func (m modelTags) View() string {
+	return m.styles.LeftPanel.Render(m.list.View())
+}
+
+func (m *modelTags) Focus() {
+	m.focus = true
+}
+
+func (m *modelTags) Blur() {
+	m.focus = false
+}
+
+// getTagsItems lists the tags used most in the period first. Ranked by
+// spent, it lists only the tags spent on, with their share of the spent.
+func getTagsItems(api TagAPI, bySpent bool) []list.Item {
+	usage := api.TagUsage()
+	currency := api.PrimaryCurrency().Code
+
+	tags := []tagItem{}
+	var total, heaviest float64
+	data := fromStore[tagsReader](api)
+	amounts := money.New(api)
+	for _, tag := range data.TagsList() {
+		item := tagItem{
+			tag:      tag,
+			used:     usage[tag.Name],
+			spent:    data.TagSpent(tag.ID),
+			earned:   data.TagEarned(tag.ID),
+			currency: currency,
+			amounts:  amounts,
+		}
+		if bySpent && item.spent <= 0 {
+			continue
+		}
+		total += max(item.spent, 0)
+		heaviest = max(heaviest, item.spent)
+		tags = append(tags, item)
+	}
+	if bySpent {
+		for i := range tags {
+			tags[i].share = tags[i].spent / total
+			tags[i].weight = tags[i].spent / heaviest
+		}
+	}
+	slices.SortStableFunc(tags, func(a, b tagItem) int {
+		if bySpent {
+			if c := cmp.Compare(b.spent, a.spent); c != 0 {
+				return c
+			}
+		}
+		if c := cmp.Compare(b.used, a.used); c != 0 {
+			return c
+		}
+		return strings.Compare(strings.ToLower(a.tag.Name), strings.ToLower(b.tag.Name))
+	})
+
+	items := make([]list.Item, 0, len(tags))
+	for _, tag := range tags {
+		items = append(items, tag)
+	}
+	return items
+}
+
+func CmdPromptRenameTag(tag firefly.Tag, backCmd tea.Cmd) tea.Cmd {
+	return prompt.Ask(
+		fmt.Sprintf("Rename tag %s to: ", tag.Name),
+		tag.Name,
+		func(value string) tea.Cmd {
+			var cmds []tea.Cmd
+			value = strings.TrimSpace(strings.TrimPrefix(value, tagPrefix))
+			if value != "None" && value != "" && value != tag.Name {
+				cmds = append(cmds, Cmd(RenameTagMsg{Tag: tag, Name: value}))
+			}
+			cmds = append(cmds, backCmd)
+			return tea.Sequence(cmds...)
+		},
+	)
+}
+
+// renameTag renames the tag and reloads the transactions, which show the
+// new name.
+func renameTag(api TagAPI, tag firefly.Tag, name string) tea.Cmd {
+	return loading.Track("Renaming tag...", func() tea.Msg {
+		err := api.RenameTag(context.Background(), tag, name)
+		if err != nil && !errors.Is(err, firefly.ErrQueued) {
+			return notify.NotifyWarn(fmt.Sprintf("Failed to rename tag %s: %s", tag.Name, errorText(err)))()
+		}
+		note := notify.NotifyLog(fmt.Sprintf("Tag '%s' renamed to '%s'", tag.Name, name))
+		if err != nil {
+			note = notify.NotifyWarn(err.Error())
+		}
+		return tea.BatchMsg{
+			note,
+			Publish(TagsChanged),
+		}
+	})
+}
+
+// deleteTag deletes the tag, the transactions using it lose only the tag.
+func deleteTag(api TagAPI, tag firefly.Tag) tea.Cmd {
+	return loading.Track("Deleting tag...", func() tea.Msg {
+		err := api.DeleteTag(context.Background(), tag)
+		if err != nil && !errors.Is(err, firefly.ErrQueued) {
+			return notify.NotifyWarn(fmt.Sprintf("Failed to delete tag %s: %s", tag.Name, errorText(err)))()
+		}
+		note := notify.NotifyLog(fmt.Sprintf("Tag '%s' deleted", tag.Name))
+		if err != nil {
+			note = notify.NotifyWarn(err.Error())
+		}
+		return tea.BatchMsg{
+			note,
+			Publish(TagsChanged),
+		}
+	})
+}
@@ -0,0 +1,333 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ui
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/notify"
+	"ffiii-tui/internal/ui/prompt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/viper"
+)
+
+type mockTagAPI struct {
+	tags        []firefly.Tag
+	spent       map[string]float64
+	earned      map[string]float64
+	usage       map[string]int
+	renameErr   error
+	deleteErr   error
+	updateErr   error
+	renamed     map[string]string
+	deleted     []string
+	forgotten   int
+	updateCalls int
+}
+
+func (m *mockTagAPI) UpdateTags(_ context.Context) error {
+	m.updateCalls++
+	return m.updateErr
+}
+
+func (m *mockTagAPI) UpdateTagsInsights(_ context.Context) error {
+	m.updateCalls++
+	return m.updateErr
+}
+
+func (m *mockTagAPI) TagsList() []firefly.Tag           { return m.tags }
+func (m *mockTagAPI) TagSpent(tagID string) float64     { return m.spent[tagID] }
+func (m *mockTagAPI) TagEarned(tagID string) float64    { return m.earned[tagID] }
+func (m *mockTagAPI) TagUsage() map[string]int          { return m.usage }
+func (m *mockTagAPI) PrimaryCurrency() firefly.Currency { return firefly.Currency{Code: "EUR"} }
+func (m *mockTagAPI) ForgetInsights()                   { m.forgotten++ }
+
+func (m *mockTagAPI) RenameTag(_ context.Context, tag firefly.Tag, name string) error {
+	if m.renamed == nil {
+		m.renamed = map[string]string{}
+	}
+	m.renamed[tag.ID] = name
+	return m.renameErr
+}
+
+func (m *mockTagAPI) GetCurrencyByCode(code string) firefly.Currency {
+	return firefly.Currency{Code: code}
+}
+
+func (m *mockTagAPI) DeleteTag(_ context.Context, tag firefly.Tag) error {
+	m.deleted = append(m.deleted, tag.ID)
+	return m.deleteErr
+}
+
+func newTestTagAPI() *mockTagAPI {
+	return &mockTagAPI{
+		tags: []firefly.Tag{
+			{ID: "1", Name: "vacation"},
+			{ID: "2", Name: "car"},
+			{ID: "3", Name: "Gifts"},
+		},
+		spent:  map[string]float64{"1": 420.5, "2": 80},
+		earned: map[string]float64{"3": 25},
+		usage:  map[string]int{"car": 4, "vacation": 1},
+	}
+}
+
+func newFocusedTagsModel(api *mockTagAPI) modelTags {
+	m := newModelTags(api, newRequestScope())
+	m.Focus()
+	return m
+}
+
+func TestGetTagsItems_SortsByUsageThenName(t *testing.T) {
+	items := getTagsItems(newTestTagAPI(), false)
+
+	want := []string{"car", "vacation", "Gifts"}
+	if len(items) != len(want) {
+		t.Fatalf("expected %d items, got %d", len(want), len(items))
+	}
+	for i, name := range want {
+		if got := items[i].(tagItem).tag.Name; got != name {
+			t.Errorf("item %d: expected %q, got %q", i, name, got)
+		}
+	}
+
+	car := items[0].(tagItem)
+	if car.used != 4 || car.spent != 80 || car.currency != "EUR" {
+		t.Errorf("unexpected car item: %+v", car)
+	}
+}
+
+func TestGetTagsItems_BySpent(t *testing.T) {
+	items := getTagsItems(newTestTagAPI(), true)
+
+	if len(items) != 2 {
+		t.Fatalf("expected only the tags spent on, got %d items", len(items))
+	}
+	vacation, car := items[0].(tagItem), items[1].(tagItem)
+	if vacation.tag.Name != "vacation" || car.tag.Name != "car" {
+		t.Fatalf("expected vacation before car, got %s, %s", vacation.tag.Name, car.tag.Name)
+	}
+	if vacation.weight != 1 {
+		t.Errorf("expected the heaviest tag at full weight, got %v", vacation.weight)
+	}
+	if math.Abs(vacation.share+car.share-1) > 1e-9 {
+		t.Errorf("expected shares to add up to 1, got %v and %v", vacation.share, car.share)
+	}
+}
+
+func TestTagItem_TitleWithBar(t *testing.T) {
+	item := tagItem{tag: firefly.Tag{Name: "car"}, weight: 0.5, share: 0.25}
+	want := "#car " + strings.Repeat("█", tagBarWidth/2) + " 25%"
+	if got := item.Title(); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	item = tagItem{tag: firefly.Tag{Name: "tiny"}, weight: 0.001, share: 0.001}
+	if got := item.Title(); !strings.Contains(got, "█") {
+		t.Errorf("expected at least one bar block, got %q", got)
+	}
+}
+
+func TestModelTags_SortTogglesRanking(t *testing.T) {
+	m := newFocusedTagsModel(newTestTagAPI())
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	m = updated.(modelTags)
+	if cmd != nil {
+		cmd()
+	}
+	if !m.bySpent || m.list.Title != "Tags by spent" {
+		t.Fatalf("expected ranking by spent, got bySpent=%v title=%q", m.bySpent, m.list.Title)
+	}
+	if got := m.list.SelectedItem().(tagItem).tag.Name; got != "vacation" {
+		t.Errorf("expected vacation selected first, got %q", got)
+	}
+
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	filter, ok := cmd().(FilterMsg)
+	if !ok || filter.Query != "#vacation" {
+		t.Errorf("expected to drill into #vacation, got %+v", filter)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	if updated.(modelTags).bySpent {
+		t.Error("expected a second press to go back to usage order")
+	}
+}
+
+func TestTagItem_Description(t *testing.T) {
+	tests := []struct {
+		name string
+		item tagItem
+		want string
+	}{
+		{"unused", tagItem{currency: "EUR"}, "Used 0 times"},
+		{"once", tagItem{used: 1, spent: 12.5, currency: "EUR"}, "Used once | Spent: 12.50 EUR"},
+		{"both", tagItem{used: 3, spent: 10, earned: 20, currency: "EUR"}, "Used 3 times | Spent: 10.00 EUR | Earned: 20.00 EUR"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.item.Description(); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTagItem_TitleAndFilterValue(t *testing.T) {
+	item := tagItem{tag: firefly.Tag{Name: "car"}}
+	if item.Title() != "#car" {
+		t.Errorf("expected title #car, got %q", item.Title())
+	}
+	if item.FilterValue() != "car" {
+		t.Errorf("expected filter value car, got %q", item.FilterValue())
+	}
+}
+
+func TestModelTags_FilterBySendsTagQuery(t *testing.T) {
+	m := newFocusedTagsModel(newTestTagAPI())
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	if cmd == nil {
+		t.Fatal("expected a command, got nil")
+	}
+	msg, ok := cmd().(FilterMsg)
+	if !ok {
+		t.Fatalf("expected FilterMsg, got %T", cmd())
+	}
+	if msg.Query != "#car" {
+		t.Errorf("expected query #car, got %q", msg.Query)
+	}
+}
+
+func TestModelTags_RenamePrompt(t *testing.T) {
+	m := newFocusedTagsModel(newTestTagAPI())
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+	if cmd == nil {
+		t.Fatal("expected a command, got nil")
+	}
+	ask, ok := cmd().(prompt.PromptMsg)
+	if !ok {
+		t.Fatalf("expected prompt.PromptMsg, got %T", cmd())
+	}
+	if ask.Value != "car" {
+		t.Errorf("expected the current name as default, got %q", ask.Value)
+	}
+
+	msgs := collectMsgsFromCmd(ask.Callback("#auto "))
+	rename, ok := findMsg[RenameTagMsg](msgs)
+	if !ok {
+		t.Fatalf("expected RenameTagMsg, got %v", msgs)
+	}
+	if rename.Tag.ID != "2" || rename.Name != "auto" {
+		t.Errorf("unexpected rename: %+v", rename)
+	}
+
+	for _, value := range []string{"None", "car", ""} {
+		if msgs := collectMsgsFromCmd(ask.Callback(value)); hasMsg[RenameTagMsg](msgs) {
+			t.Errorf("expected no rename for %q", value)
+		}
+	}
+}
+
+func TestRenameTag_RefreshesTagsAndTransactions(t *testing.T) {
+	api := newTestTagAPI()
+
+	msgs := dispatched(collectMsgsFromCmd(renameTag(api, api.tags[1], "auto")))
+
+	if api.renamed["2"] != "auto" {
+		t.Errorf("expected tag 2 renamed to auto, got %v", api.renamed)
+	}
+	if !hasMsg[RefreshTagsMsg](msgs) || !hasMsg[RefreshTransactionsMsg](msgs) {
+		t.Errorf("expected tags and transactions refresh, got %v", msgs)
+	}
+}
+
+func TestRenameTag_Error(t *testing.T) {
+	api := newTestTagAPI()
+	api.renameErr = errors.New("boom")
+
+	msgs := dispatched(collectMsgsFromCmd(renameTag(api, api.tags[1], "auto")))
+
+	if hasMsg[RefreshTagsMsg](msgs) {
+		t.Error("expected no refresh after a failed rename")
+	}
+	if !hasMsg[notify.NotifyMsg](msgs) {
+		t.Errorf("expected a notification, got %v", msgs)
+	}
+}
+
+func TestModelTags_DeleteAsksForConfirmation(t *testing.T) {
+	viper.Set("confirm.always."+confirmDeleteTag, false)
+	defer viper.Set("confirm.always."+confirmDeleteTag, false)
+
+	api := newTestTagAPI()
+	m := newFocusedTagsModel(api)
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'D'}})
+	msgs := answerConfirm(t, cmd, "y")
+
+	del, ok := findMsg[DeleteTagMsg](msgs)
+	if !ok {
+		t.Fatalf("expected DeleteTagMsg, got %v", msgs)
+	}
+	if del.Tag.ID != "2" {
+		t.Errorf("expected tag 2, got %+v", del.Tag)
+	}
+
+	_, cmd = m.Update(del)
+	msgs = dispatched(collectMsgsFromCmd(cmd))
+	if len(api.deleted) != 1 || api.deleted[0] != "2" {
+		t.Errorf("expected tag 2 deleted, got %v", api.deleted)
+	}
+	if !hasMsg[RefreshTransactionsMsg](msgs) {
+		t.Errorf("expected transactions refresh, got %v", msgs)
+	}
+}
+
+func TestModelTags_RefreshForgetsInsights(t *testing.T) {
+	api := newTestTagAPI()
+	m := newFocusedTagsModel(api)
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+
+	if api.forgotten != 1 {
+		t.Errorf("expected insights forgotten once, got %d", api.forgotten)
+	}
+	if !hasMsg[RefreshTagsMsg](collectMsgsFromCmd(cmd)) {
+		t.Error("expected RefreshTagsMsg")
+	}
+}
+
+func TestRefreshTagsMsg_Error(t *testing.T) {
+	api := newTestTagAPI()
+	api.updateErr = errors.New("boom")
+	m := newModelTags(api, newRequestScope())
+
+	_, cmd := m.Update(RefreshTagsMsg{})
+	msgs := collectMsgsFromCmd(cmd)
+
+	failed, ok := findMsg[DataLoadFailedMsg](msgs)
+	if !ok || failed.DataType != "tags" {
+		t.Errorf("expected DataLoadFailedMsg for tags, got %v", msgs)
+	}
+}
+
+func TestModelTags_UnfocusedIgnoresKeys(t *testing.T) {
+	m := newModelTags(newTestTagAPI(), newRequestScope())
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	if cmd != nil {
+		t.Errorf("expected no command, got %T", cmd())
+	}
+}
@@ -7,28 +7,27 @@ package ui
 // TODO: Use last date as input, and key for resetting to today.
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/hooks"
 	"ffiii-tui/internal/ui/notify"
 	"ffiii-tui/internal/ui/prompt"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/huh"
+	"github.com/spf13/viper"
 	"go.uber.org/zap"
 )
 
-var (
-	triggerCategoryCounter    byte
-	triggerSourceCounter      byte
-	triggerDestinationCounter byte
-	fullNewForm               bool
-)
-
 type (
 	RedrawFormMsg                  struct{}
 	DeleteSplitMsg                 struct{ Index int }
@@ -38,6 +37,7 @@ type (
 	EditTransactionMsg             struct{ Transaction firefly.Transaction }
 	EditTransactionConfirmedMsg    struct{ Transaction firefly.Transaction }
 	ResetTransactionMsg            struct{}
+	DiscardTransactionMsg          struct{}
 )
 
 type modelTransaction struct {
@@ -48,9 +48,24 @@ type modelTransaction struct {
 
 	new     bool
 	created bool
+	// submitted is set once the completed form was sent, further submits
+	// are ignored unless the save fails or another transaction is loaded
+	submitted bool
 
 	splits []*split
 	attr   *transactionAttr
+	groups *formGroups
+
+	dirty bool // changed by the user since the transaction was loaded
+	// fullLayout lays the form out in one column instead of a grid, for
+	// transactions with many splits
+	fullLayout bool
+
+	draftFile   string               // where unfinished forms are kept, empty to keep none
+	drafts      *draftWriter         // writes the draft file in the background
+	edits       int                  // changes of the form, a draft is saved once they stop
+	usage       *usageStats          // accounts and categories picked before, nil to keep none
+	suggestions *categorySuggestions // categories of the loaded transactions
 }
 
 type split struct {
@@ -60,20 +75,79 @@ type split struct {
 	amount        string
 	foreignAmount string
 	description   string
+	location      string // latitude, longitude
+	zoom          int    // zoom level of the loaded location
+
+	internalReference string
+	externalID        string
+	invoiceDate       string // YYYY-MM-DD
 
 	trxJID string // For editing existing transactions
+
+	categoryPicked bool // by the user, so no category is suggested for it
+
+	// Shared account of the first split this split last followed. The split
+	// keeps following it until another account is picked.
+	sharedSource      firefly.Account
+	sharedDestination firefly.Account
 }
 
 type transactionAttr struct {
 	year            string
 	month           string
 	day             string
-	transactionType string
+	transactionType string // detected from the accounts of the first split
+	typeOverride    string // chosen in the form, empty to use the detected type
 	source          firefly.Account
 	destination     firefly.Account
 	groupTitle      string
 
 	trxID string // For editing existing transactions
+
+	// fieldErrors are the messages of the fields the server rejected, by
+	// the key of the form field
+	fieldErrors map[string][]string
+}
+
+// formGroups keeps the groups of the form between redraws. A redraw builds
+// the groups of new or moved splits only, the others keep their values,
+// filters and focused field, so adding a split does not reset the cursor.
+type formGroups struct {
+	splits      map[*split]splitGroup
+	date        *huh.Group
+	title       *huh.Group
+	titleSplits int // number of splits the title group was built for
+
+	focusSplit *split // split focused by the next redraw, nil to keep the focus
+
+	// Bumped by the refresh key, so the account and category fields load
+	// their options again.
+	categoryTrigger    byte
+	sourceTrigger      byte
+	destinationTrigger byte
+}
+
+// splitGroup is the group of a split, built for its position in the form.
+type splitGroup struct {
+	index int
+	group *huh.Group
+}
+
+func newFormGroups() *formGroups {
+	return &formGroups{splits: map[*split]splitGroup{}}
+}
+
+// reset drops all groups, the next redraw builds the form from scratch.
+func (g *formGroups) reset() {
+	g.splits = map[*split]splitGroup{}
+	g.date = nil
+	g.title = nil
+	g.focusSplit = nil
+}
+
+// forget drops the group of a split changed outside of the form.
+func (g *formGroups) forget(s *split) {
+	delete(g.splits, s)
 }
 
 func newModelTransaction(api TransactionFormAPI) modelTransaction {
@@ -81,12 +155,15 @@ func newModelTransaction(api TransactionFormAPI) modelTransaction {
 		api:    api,
 		keymap: DefaultTransactionFormKeyMap(),
 		attr:   &transactionAttr{},
+		groups: newFormGroups(),
 		form: huh.NewForm(
 			huh.NewGroup(
 				huh.NewNote().Title("Loading..."),
 			),
 		).WithLayout(huh.LayoutDefault),
-		splits: []*split{},
+		splits:      []*split{},
+		drafts:      &draftWriter{},
+		suggestions: &categorySuggestions{},
 	}
 }
 
@@ -98,6 +175,10 @@ func (m modelTransaction) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case NewTransactionMsg:
 		if !m.created {
+			if d, ok := m.storedDraft(); ok {
+				trx := msg.Transaction
+				return m, m.askResumeDraft(d, Cmd(NewTransactionFromConfirmedMsg{Transaction: trx}))
+			}
 			m.SetTransaction(msg.Transaction, true)
 			m.created = true
 		}
@@ -105,6 +186,8 @@ func (m modelTransaction) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			RedrawForm(),
 			SetView(newView),
 		)
+	case ResumeDraftMsg:
+		return m, m.resumeDraft(msg.Draft)
 	case NewTransactionFromMsg:
 		if m.created {
 			trx := msg.Transaction
@@ -163,12 +246,28 @@ func (m modelTransaction) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		trx := firefly.Transaction{}
 		m.SetTransaction(trx, true)
 		m.created = true
+		m.deleteDraft()
 		return m, RedrawForm()
+	case DiscardTransactionMsg:
+		m.SetTransaction(firefly.Transaction{}, true)
+		m.created = false
+		m.deleteDraft()
+		return m, tea.Batch(
+			SetView(transactionsView),
+			notify.NotifyLog("Changes discarded"),
+		)
+	case draftDueMsg:
+		if msg.edit != m.edits || !m.created {
+			return m, nil
+		}
+		return m, m.writeDraftNow()
 	case RedrawFormMsg:
-		m.UpdateForm()
-		return m, tea.WindowSize()
+		return m, tea.Batch(m.UpdateForm(), tea.WindowSize())
 	case DeleteSplitMsg:
 		return m, m.DeleteSplit(msg.Index)
+	case TransactionsUpdateMsg:
+		m.suggestions.learn(msg.Transactions)
+		return m, nil
 	}
 
 	if !m.focus {
@@ -181,12 +280,10 @@ func (m modelTransaction) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		switch {
 		case key.Matches(msg, m.keymap.Cancel):
-			if m.created {
-				return m, tea.Batch(
-					SetView(transactionsView),
-					notify.NotifyLog("Form saved. Press n to continue editing."),
-				)
+			if m.created && m.Dirty() {
+				return m, m.confirmLeave()
 			}
+			m.created = false
 			return m, SetView(transactionsView)
 		case key.Matches(msg, m.keymap.Reset):
 			return m, tea.Batch(
@@ -194,9 +291,9 @@ func (m modelTransaction) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				Cmd(ResetTransactionMsg{}),
 			)
 		case key.Matches(msg, m.keymap.Refresh):
-			triggerCategoryCounter++
-			triggerSourceCounter++
-			triggerDestinationCounter++
+			m.groups.categoryTrigger++
+			m.groups.sourceTrigger++
+			m.groups.destinationTrigger++
 			return m, RedrawForm()
 		case key.Matches(msg, m.keymap.EditFormAgain):
 			return m, RedrawForm()
@@ -204,25 +301,24 @@ func (m modelTransaction) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if len(m.splits) >= 5 {
 				return m, notify.NotifyWarn("Maximum of 5 splits allowed")
 			}
-			m.splits = append(m.splits, &split{})
-			return m, RedrawForm()
+			s := &split{}
+			m.splits = append(m.splits, s)
+			m.groups.focusSplit = s
+			m.dirty = true
+			save := m.saveDraft()
+			return m, tea.Batch(RedrawForm(), save)
 		case key.Matches(msg, m.keymap.DeleteSplit):
 			if len(m.splits) <= 1 {
 				return m, notify.NotifyWarn("Cannot delete the only split")
 			}
 			if len(m.splits) == 2 {
-				// Only one deletable split (index 1), delete it directly
-				return m, Cmd(DeleteSplitMsg{Index: 1})
+				// Only one deletable split (index 1), confirm it directly
+				return m, m.confirmDeleteSplit(1)
 			}
 			// Build a descriptive prompt listing deletable splits
 			options := ""
 			for i := 1; i < len(m.splits); i++ {
-				s := m.splits[i]
-				desc := s.Description()
-				if s.amount != "" {
-					desc += " (" + s.amount + ")"
-				}
-				options += fmt.Sprintf(" %d: %s,", i, desc)
+				options += fmt.Sprintf(" %d: %s,", i, m.splits[i].Summary())
 			}
 			return m, prompt.Ask(
 				fmt.Sprintf("Delete split [%s ]: ", options),
@@ -231,16 +327,23 @@ func (m modelTransaction) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					if value != "None" {
 						index, err := strconv.Atoi(value)
 						if err == nil {
-							return Cmd(DeleteSplitMsg{Index: index})
+							return m.confirmDeleteSplit(index)
 						}
 					}
 					return SetView(newView)
 				},
 			)
+		case key.Matches(msg, m.keymap.Cash):
+			return m, m.SetCashDestination(m.focusedSplit())
+		case key.Matches(msg, m.keymap.CopyCategory):
+			return m, m.CopyFirstCategory()
 		case key.Matches(msg, m.keymap.ChangeLayout):
-			fullNewForm = !fullNewForm
+			m.fullLayout = !m.fullLayout
 			return m, RedrawForm()
 		case key.Matches(msg, m.keymap.Submit):
+			if m.submitted {
+				return m, notify.NotifyLog("Transaction already submitted")
+			}
 			if m.form.State == huh.StateCompleted {
 				if m.new {
 					return m, m.CreateTransaction()
@@ -251,20 +354,108 @@ func (m modelTransaction) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	_, isKey := msg.(tea.KeyMsg)
+	before := m.values()
+
 	form, cmd := m.form.Update(msg)
 	if f, ok := form.(*huh.Form); ok {
 		m.form = f
 	}
+	m.followDestinations(before, isKey)
+	if isKey && !before.equal(m.values()) {
+		m.dirty = true
+		save := m.saveDraft()
+		return m, tea.Batch(cmd, save)
+	}
 	return m, cmd
 }
 
 func (m modelTransaction) View() string {
 	if m.form.State == huh.StateCompleted {
-		return "Press Ctrl+S to submit, Ctrl+N to reset current form, Ctrl+E to edit current form again, or Esc to go back."
+		help := "Press Ctrl+S to submit, Ctrl+N to reset current form, Ctrl+E to edit current form again, or Esc to go back."
+		for _, note := range []string{m.categoryMixHint(), m.trxTypeWarning(0)()} {
+			if note != "" {
+				help = note + "\n" + help
+			}
+		}
+		return help
 	}
 	return m.form.View()
 }
 
+// formValues are the values of the form the user can change.
+type formValues struct {
+	year, month, day string
+	typeOverride     string
+	groupTitle       string
+	splits           []split
+}
+
+func (m *modelTransaction) values() formValues {
+	v := formValues{
+		year:         m.attr.year,
+		month:        m.attr.month,
+		day:          m.attr.day,
+		typeOverride: m.attr.typeOverride,
+		groupTitle:   m.attr.groupTitle,
+	}
+	for _, s := range m.splits {
+		v.splits = append(v.splits, split{
+			source:        s.source,
+			destination:   s.destination,
+			category:      s.category,
+			amount:        s.amount,
+			foreignAmount: s.foreignAmount,
+			description:   s.description,
+			location:      s.location,
+
+			internalReference: s.internalReference,
+			externalID:        s.externalID,
+			invoiceDate:       s.invoiceDate,
+		})
+	}
+	return v
+}
+
+func (v formValues) equal(other formValues) bool {
+	return v.year == other.year &&
+		v.month == other.month &&
+		v.day == other.day &&
+		v.typeOverride == other.typeOverride &&
+		v.groupTitle == other.groupTitle &&
+		slices.Equal(v.splits, other.splits)
+}
+
+// Dirty reports whether the user changed the form since the transaction was
+// loaded into it. Values filled in by the form itself, like the first option
+// of a list, do not count.
+func (m *modelTransaction) Dirty() bool {
+	return m.dirty
+}
+
+// confirmLeave asks what to do with the changes before leaving the form.
+// A saved draft stays in the form until it is submitted or discarded.
+func (m *modelTransaction) confirmLeave() tea.Cmd {
+	save := m.writeDraftNow()
+	return prompt.Ask(
+		"Unsaved changes. Discard? (d - discard/ s - save draft/ any key - keep editing): ",
+		"",
+		func(value string) tea.Cmd {
+			switch value {
+			case "d":
+				return Cmd(DiscardTransactionMsg{})
+			case "s":
+				return tea.Batch(
+					save,
+					SetView(transactionsView),
+					notify.NotifyLog("Draft saved. Press n to continue editing."),
+				)
+			}
+			return SetView(newView)
+		},
+	)
+}
+
 func (m *modelTransaction) Focus() {
 	m.focus = true
 }
@@ -277,109 +468,256 @@ func (m *modelTransaction) Focused() bool {
 	return m.focus
 }
 
-func (m *modelTransaction) UpdateForm() {
-	var allGroups []*huh.Group
+func (m *modelTransaction) UpdateForm() tea.Cmd {
+	focused, focusedGroup := m.focusedGroup()
+	var focusedField huh.Field
+	if focusedGroup != nil {
+		focusedField = m.form.GetFocusedField()
+	}
 
+	var allGroups []*huh.Group
+	var keys []any // identify the groups across redraws
+	splits := make(map[*split]splitGroup, len(m.splits))
 	for i, s := range m.splits {
-		allGroups = append(allGroups, huh.NewGroup(
-			huh.NewNote().
-				Title(fmt.Sprint("Split: ", i)).
-				TitleFunc(m.trxTitle(i, s)),
-			huh.NewSelect[firefly.Account]().
-				Title("Source").
-				Value(&s.source).
-				Options(huh.NewOption(s.source.Name, s.source)).
-				OptionsFunc(m.trxSourceOptions(i, s)).WithHeight(5),
-			huh.NewSelect[firefly.Account]().
-				Title("Destination").
-				Value(&s.destination).
-				Options(huh.NewOption(s.destination.Name, s.destination)).
-				OptionsFunc(m.trxDestinationOptions(i, s)).WithHeight(4),
-			huh.NewSelect[firefly.Category]().
-				Title("Category").
-				Value(&s.category).
-				Options(huh.NewOption(s.category.Name, s.category)).
-				OptionsFunc(func() []huh.Option[firefly.Category] {
-					options := []huh.Option[firefly.Category]{}
-					for _, category := range m.api.CategoriesList() {
-						options = append(options, huh.NewOption(category.Name, category))
+		sg, ok := m.groups.splits[s]
+		if !ok || sg.index != i {
+			sg = splitGroup{index: i, group: m.splitGroup(i, s)}
+		}
+		splits[s] = sg
+		allGroups = append(allGroups, sg.group)
+		keys = append(keys, s)
+	}
+	m.groups.splits = splits
+
+	if m.groups.date == nil {
+		m.groups.date = m.dateGroup()
+	}
+	allGroups = append(allGroups, m.groups.date)
+	keys = append(keys, "date")
+
+	if len(m.splits) > 1 {
+		// The placeholder counts the splits, so the group is built again
+		// when they change.
+		if m.groups.title == nil || m.groups.titleSplits != len(m.splits) {
+			hintBindings := []any{&m.attr.groupTitle}
+			for _, s := range m.splits {
+				hintBindings = append(hintBindings, &s.category)
+			}
+			m.groups.title = huh.NewGroup(
+				huh.NewInput().
+					Key("group_title").
+					Title("Group Title").
+					Value(&m.attr.groupTitle).
+					PlaceholderFunc(m.GroupTitle, &m.splits).
+					DescriptionFunc(m.categoryMixHint, hintBindings).
+					WithWidth(30),
+			)
+			m.groups.titleSplits = len(m.splits)
+		}
+		allGroups = append(allGroups, m.groups.title)
+		keys = append(keys, "title")
+	}
+
+	if m.fullLayout {
+		m.form = huh.NewForm(allGroups...).WithLayout(huh.LayoutDefault)
+	} else {
+		m.form = huh.NewForm(allGroups...).WithLayout(huh.LayoutGrid(2, len(m.splits)+1))
+	}
+
+	if m.groups.focusSplit != nil {
+		focused = m.groups.focusSplit
+		m.groups.focusSplit = nil
+	}
+	index := slices.Index(keys, focused)
+	if focusedField != nil && (index != 0 || allGroups[0] != focusedGroup) {
+		focusedField.Blur()
+	}
+	return m.focusGroup(index)
+}
+
+// focusedGroup returns the group of the focused field with the split, or
+// "date" or "title", identifying it. Both are nil when the form is not
+// being filled in.
+func (m *modelTransaction) focusedGroup() (any, *huh.Group) {
+	if m.form.State != huh.StateNormal {
+		return nil, nil
+	}
+	field := m.form.GetFocusedField()
+	if field == nil {
+		return nil, nil
+	}
+	switch key := field.GetKey(); key {
+	case "year", "month", "day", "type":
+		if m.groups.date != nil {
+			return "date", m.groups.date
+		}
+	case "group_title":
+		if m.groups.title != nil {
+			return "title", m.groups.title
+		}
+	default:
+		var i int
+		if _, err := fmt.Sscanf(key, "split%d.", &i); err != nil {
+			return nil, nil
+		}
+		for s, sg := range m.groups.splits {
+			if sg.index == i {
+				return s, sg.group
+			}
+		}
+	}
+	return nil, nil
+}
+
+// focusGroup moves the focus of a new form from the first group to the
+// group at index, like tabbing through the groups would. It stops at a
+// group with invalid fields.
+func (m *modelTransaction) focusGroup(index int) tea.Cmd {
+	var cmds []tea.Cmd
+	for range index {
+		field := m.form.GetFocusedField()
+		cmds = append(cmds, m.form.NextGroup())
+		if m.form.GetFocusedField() == field {
+			break
+		}
+		cmds = append(cmds, field.Blur())
+	}
+	return tea.Batch(cmds...)
+}
+
+// splitGroup builds the group with the fields of the split at index i.
+func (m *modelTransaction) splitGroup(i int, s *split) *huh.Group {
+	return huh.NewGroup(
+		huh.NewNote().
+			Title(fmt.Sprint("Split: ", i)).
+			TitleFunc(m.trxTitle(i, s)).
+			DescriptionFunc(m.splitNote(i), []any{&s.source, &s.destination, &m.attr.typeOverride, &m.attr.fieldErrors}),
+		newTypeAheadSelect[firefly.Account]().
+			Key(splitFieldKey(i, "source")).
+			Title("Source").
+			Value(&s.source).
+			Options(huh.NewOption(s.source.Name, s.source)).
+			OptionsFunc(m.trxSourceOptions(i, s)).WithHeight(5),
+		newTypeAheadSelect[firefly.Account]().
+			Key(splitFieldKey(i, "destination")).
+			Title("Destination").
+			Value(&s.destination).
+			Options(huh.NewOption(s.destination.Name, s.destination)).
+			OptionsFunc(m.trxDestinationOptions(i, s)).
+			Validate(func(firefly.Account) error { return m.validateSplit(i, s) }).
+			WithHeight(4),
+		newTypeAheadSelect[firefly.Category]().
+			Key(splitFieldKey(i, "category")).
+			Title("Category").
+			Value(&s.category).
+			Options(huh.NewOption(s.category.Name, s.category)).
+			OptionsFunc(m.categoryOptions, &m.groups.categoryTrigger).WithHeight(4),
+		huh.NewInput().
+			Key(splitFieldKey(i, "amount")).
+			Title("Amount").
+			Value(&s.amount).
+			TitleFunc(func() string {
+				title := "Amount "
+				switch s.source.Type {
+				case "asset", "liabilities":
+					return title + s.source.CurrencyCode
+				case "revenue":
+					return title + s.destination.CurrencyCode
+				}
+				return title
+			}, []any{&s.source, &s.destination}).
+			Validate(func(str string) error {
+				var amount float64
+				amount, err := strconv.ParseFloat(str, 64)
+				if err != nil || amount < 0 {
+					return errors.New("please enter a valid positive number for amount")
+				}
+				return nil
+			}),
+		huh.NewInput().
+			Key(splitFieldKey(i, "foreign_amount")).
+			Title("Foreign Amount").
+			Value(&s.foreignAmount).
+			TitleFunc(func() string {
+				title := "Foreign Amount "
+				sType := s.source.Type
+				dType := s.destination.Type
+				if (sType == "asset" || sType == "liabilities") && (dType == "asset" || dType == "liabilities") {
+					if s.source.CurrencyCode == s.destination.CurrencyCode {
+						return title + "N/A"
 					}
-					return options
-				}, &triggerCategoryCounter).WithHeight(4),
-			huh.NewInput().
-				Title("Amount").
-				Value(&s.amount).
-				TitleFunc(func() string {
-					title := "Amount "
-					switch s.source.Type {
-					case "asset", "liabilities":
-						return title + s.source.CurrencyCode
-					case "revenue":
-						return title + s.destination.CurrencyCode
+					return title + s.destination.CurrencyCode
+				}
+				return title + "N/A"
+			}, []any{&s.source, &s.destination}).
+			Validate(func(str string) error {
+				sType := s.source.Type
+				dType := s.destination.Type
+				if (sType == "asset" || sType == "liabilities") && (dType == "asset" || dType == "liabilities") {
+					if s.source.CurrencyCode == s.destination.CurrencyCode {
+						if str != "" {
+							return errors.New("for transfers between same currency accounts, foreign amount should be empty")
+						}
+						return nil
 					}
-					return title
-				}, []any{&s.source, &s.destination}).
-				Validate(func(str string) error {
 					var amount float64
 					amount, err := strconv.ParseFloat(str, 64)
 					if err != nil || amount < 0 {
 						return errors.New("please enter a valid positive number for amount")
 					}
 					return nil
-				}),
-			huh.NewInput().
-				Title("Foreign Amount").
-				Value(&s.foreignAmount).
-				TitleFunc(func() string {
-					title := "Foreign Amount "
-					sType := s.source.Type
-					dType := s.destination.Type
-					if (sType == "asset" || sType == "liabilities") && (dType == "asset" || dType == "liabilities") {
-						if s.source.CurrencyCode == s.destination.CurrencyCode {
-							return title + "N/A"
-						}
-						return title + s.destination.CurrencyCode
-					}
-					return title + "N/A"
-				}, []any{&s.source, &s.destination}).
-				Validate(func(str string) error {
-					sType := s.source.Type
-					dType := s.destination.Type
-					if (sType == "asset" || sType == "liabilities") && (dType == "asset" || dType == "liabilities") {
-						if s.source.CurrencyCode == s.destination.CurrencyCode {
-							if str != "" {
-								return errors.New("for transfers between same currency accounts, foreign amount should be empty")
-							}
-							return nil
-						}
-						var amount float64
-						amount, err := strconv.ParseFloat(str, 64)
-						if err != nil || amount < 0 {
-							return errors.New("please enter a valid positive number for amount")
-						}
-						return nil
-					}
-					if str != "" {
-						return errors.New("foreign amount is only applicable for transactions between asset/liability accounts")
-					}
-					return nil
-				},
-				),
-			huh.NewInput().
-				Title("Description").
-				Value(&s.description).
-				PlaceholderFunc(s.Description, []any{&s.category, &s.source, &s.destination}).
-				WithWidth(30),
-		))
-	}
+				}
+				if str != "" {
+					return errors.New("foreign amount is only applicable for transactions between asset/liability accounts")
+				}
+				return nil
+			},
+			),
+		huh.NewInput().
+			Key(splitFieldKey(i, "description")).
+			Title("Description").
+			Value(&s.description).
+			PlaceholderFunc(s.Description, []any{&s.category, &s.source, &s.destination}).
+			WithWidth(30),
+		huh.NewInput().
+			Key(splitFieldKey(i, "location")).
+			Title("Location").
+			Value(&s.location).
+			Placeholder("latitude, longitude").
+			Validate(func(str string) error {
+				_, err := parseLocation(str)
+				return err
+			}).
+			WithWidth(30),
+		huh.NewInput().
+			Key(splitFieldKey(i, "internal_reference")).
+			Title("Internal Reference").
+			Value(&s.internalReference).
+			WithWidth(30),
+		huh.NewInput().
+			Key(splitFieldKey(i, "external_id")).
+			Title("External ID").
+			Value(&s.externalID).
+			WithWidth(30),
+		huh.NewInput().
+			Key(splitFieldKey(i, "invoice_date")).
+			Title("Invoice Date").
+			Value(&s.invoiceDate).
+			Placeholder("YYYY-MM-DD").
+			Validate(validateInvoiceDate).
+			WithWidth(30),
+	)
+}
 
+// dateGroup builds the group with the date and type of the transaction.
+func (m *modelTransaction) dateGroup() *huh.Group {
 	now := time.Now()
 	years := []string{}
 	startYear := now.Year() - 9
 	for y := range 10 {
 		years = append(years, fmt.Sprintf("%d", startYear+y))
 	}
-	allGroups = append(allGroups, huh.NewGroup(
+	return huh.NewGroup(
 		huh.NewSelect[string]().
 			Key("year").
 			Title("Year").
@@ -408,40 +746,156 @@ func (m *modelTransaction) UpdateForm() {
 				}
 				return huh.NewOptions(days...)
 			}, []any{&m.attr.month, &m.attr.year}).WithHeight(4),
-	))
+		huh.NewSelect[string]().
+			Key("type").
+			Title("Type").
+			Value(&m.attr.typeOverride).
+			Options(huh.NewOption("auto", "")).
+			OptionsFunc(func() []huh.Option[string] {
+				options := []huh.Option[string]{huh.NewOption("auto: "+m.attr.transactionType, "")}
+				for _, t := range transactionTypes {
+					options = append(options, huh.NewOption(t, t))
+				}
+				return options
+			}, &m.attr.transactionType).WithHeight(3),
+	)
+}
 
-	if len(m.splits) > 1 {
-		allGroups = append(allGroups, huh.NewGroup(
-			huh.NewInput().
-				Title("Group Title").
-				Value(&m.attr.groupTitle).
-				PlaceholderFunc(m.GroupTitle, &m.splits).
-				WithWidth(30),
-		))
+func splitFieldKey(i int, field string) string {
+	return fmt.Sprintf("split%d.%s", i, field)
+}
+
+// focusedSplit returns the index of the split the focused field belongs to,
+// the first split when the focus is outside of the splits.
+func (m *modelTransaction) focusedSplit() int {
+	field := m.form.GetFocusedField()
+	if field == nil {
+		return 0
+	}
+	var i int
+	if _, err := fmt.Sscanf(field.GetKey(), "split%d.", &i); err != nil || i >= len(m.splits) {
+		return 0
 	}
+	return i
+}
 
-	if fullNewForm {
-		m.form = huh.NewForm(allGroups...).WithLayout(huh.LayoutDefault)
-	} else {
-		m.form = huh.NewForm(allGroups...).WithLayout(huh.LayoutGrid(2, len(m.splits)+1))
+// cashAccount returns the cash account of Firefly III, listed with the
+// expense accounts.
+func cashAccount(api TransactionFormAPI) (firefly.Account, bool) {
+	for _, account := range api.AccountsByType("expense") {
+		if isCash(account) {
+			return account, true
+		}
 	}
+	return firefly.Account{}, false
+}
+
+func isCash(account firefly.Account) bool {
+	return account.Type == "cash"
+}
+
+// SetCashDestination pays a split in cash: the destination becomes the
+// cash account of Firefly III instead of an expense account.
+func (m *modelTransaction) SetCashDestination(index int) tea.Cmd {
+	if index < 0 || index >= len(m.splits) {
+		return nil
+	}
+	cash, ok := cashAccount(m.api)
+	if !ok {
+		return notify.NotifyWarn("No cash account found, refresh expense accounts and try again")
+	}
+	s := m.splits[index]
+	if s.source.Type != "asset" {
+		return notify.NotifyWarn("Cash payments need an asset source account, pick one first")
+	}
+	s.destination = cash
+	m.groups.forget(s)
+	m.dirty = true
+	return tea.Batch(RedrawForm(), m.saveDraft())
+}
+
+// categoryMixHint points out splits in different categories under one
+// group title, often a group that should have been separate transactions
+// or a category picked for the first split only.
+func (m *modelTransaction) categoryMixHint() string {
+	if len(m.splits) < 2 || m.attr.groupTitle == "" {
+		return ""
+	}
+	categories := map[string]bool{}
+	for _, s := range m.splits {
+		if !s.category.IsEmpty() {
+			categories[s.category.ID] = true
+		}
+	}
+	if len(categories) < 2 {
+		return ""
+	}
+	hint := fmt.Sprintf("Splits are in %d categories under one title", len(categories))
+	if first := m.splits[0].category; !first.IsEmpty() {
+		hint += fmt.Sprintf(", %s puts them all in %s", m.keymap.CopyCategory.Help().Key, first.Name)
+	}
+	return hint
+}
+
+// CopyFirstCategory sets the category of the first split on all splits.
+func (m *modelTransaction) CopyFirstCategory() tea.Cmd {
+	if len(m.splits) < 2 {
+		return notify.NotifyWarn("Only one split, nothing to copy the category to")
+	}
+	first := m.splits[0].category
+	if first.IsEmpty() {
+		return notify.NotifyWarn("The first split has no category, pick one first")
+	}
+	changed := false
+	for _, s := range m.splits[1:] {
+		if s.category == first {
+			continue
+		}
+		s.category = first
+		s.categoryPicked = true
+		m.groups.forget(s)
+		changed = true
+	}
+	if !changed {
+		return notify.NotifyLog(fmt.Sprintf("All splits are already in %s", first.Name))
+	}
+	m.dirty = true
+	return tea.Batch(RedrawForm(), m.saveDraft())
 }
 
 func (m *modelTransaction) DeleteSplit(index int) tea.Cmd {
 	if index >= 1 && index < len(m.splits) {
 		m.splits = append(m.splits[:index], m.splits[index+1:]...)
-		return tea.Sequence(RedrawForm(), SetView(newView))
+		m.groups.focusSplit = m.splits[index-1]
+		m.dirty = true
+		return tea.Batch(tea.Sequence(RedrawForm(), SetView(newView)), m.saveDraft())
 	}
 	return tea.Sequence(notify.NotifyWarn("Invalid split index"), SetView(newView))
 }
 
+func (m *modelTransaction) confirmDeleteSplit(index int) tea.Cmd {
+	question := fmt.Sprintf("Delete split %d?", index)
+	if index >= 1 && index < len(m.splits) {
+		question = fmt.Sprintf("Delete split %d: %s?", index, m.splits[index].Summary())
+	}
+	return confirmAction(
+		confirmDeleteSplit,
+		question,
+		Cmd(DeleteSplitMsg{Index: index}),
+		SetView(newView),
+	)
+}
+
 func (m *modelTransaction) CreateTransaction() tea.Cmd {
-	opID := startLoading("Creating transaction...")
-	defer stopLoading(opID)
+	if err := m.validateSplits(); err != nil {
+		return notify.NotifyWarn(err.Error())
+	}
+	m.attr.fieldErrors = nil
+
 	trx := []firefly.RequestTransactionSplit{}
 	for _, s := range m.splits {
 		trx = append(trx, firefly.RequestTransactionSplit{
-			Type:                m.attr.transactionType,
+			Type:                m.TransactionType(),
 			Date:                fmt.Sprintf("%s-%s-%s", m.attr.year, m.attr.month, m.attr.day),
 			SourceID:            s.source.ID,
 			DestinationID:       s.destination.ID,
@@ -451,44 +905,66 @@ func (m *modelTransaction) CreateTransaction() tea.Cmd {
 			Amount:              s.amount,
 			ForeignAmount:       s.foreignAmount,
 			Description:         s.Description(),
+			InternalReference:   s.internalReference,
+			ExternalID:          s.externalID,
+			InvoiceDate:         s.invoiceDate,
 		})
+		trx[len(trx)-1].SetLocation(s.Location())
 	}
 
-	id, err := m.api.CreateTransaction(firefly.RequestTransaction{
+	m.submitted = true
+	id, err := m.api.CreateTransaction(context.Background(), firefly.RequestTransaction{
 		ApplyRules:           true,
-		ErrorIfDuplicateHash: false,
+		ErrorIfDuplicateHash: viper.GetBool("transactions.reject_duplicates"),
 		FireWebhooks:         true,
 		GroupTitle:           m.GroupTitle(),
 		Transactions:         trx,
 	})
+	if errors.Is(err, firefly.ErrQueued) {
+		m.created = false
+		m.deleteDraft()
+		m.recordUsage()
+		return tea.Batch(
+			SetView(transactionsView),
+			notify.NotifyWarn(err.Error()))
+	}
 	if err != nil {
+		if cmd := m.duplicate(err); cmd != nil {
+			return cmd
+		}
+		m.submitted = false
+		if cmd := m.rejected(err); cmd != nil {
+			return cmd
+		}
 		return tea.Sequence(
 			notify.NotifyError(err.Error()),
 			SetView(transactionsView))
 	}
 
 	m.created = false
+	m.deleteDraft()
+	m.recordUsage()
+	saved := m.savedTransaction(id)
 
 	return tea.Batch(
 		SetView(transactionsView),
-		notify.NotifyLog("Transaction created successfully"),
-		Cmd(RefreshAssetsMsg{}),
-		Cmd(RefreshLiabilitiesMsg{}),
-		Cmd(RefreshSummaryMsg{}),
-		Cmd(RefreshTransactionsMsg{TrxID: id}),
-		Cmd(RefreshExpenseInsightsMsg{}),
-		Cmd(RefreshRevenueInsightsMsg{}),
-		Cmd(RefreshCategoryInsightsMsg{}))
+		notifySaved(fmt.Sprintf("Transaction #%s created", id), transactionLink(id)),
+		Cmd(transactionSavedMsg{Transaction: saved}),
+		runHook(hooks.TransactionCreated, newHookTransaction(saved)),
+		PublishTransaction(id))
 }
 
 func (m *modelTransaction) UpdateTransaction() tea.Cmd {
-	opID := startLoading("Updating transaction...")
-	defer stopLoading(opID)
+	if err := m.validateSplits(); err != nil {
+		return notify.NotifyWarn(err.Error())
+	}
+	m.attr.fieldErrors = nil
+
 	trx := []firefly.RequestTransactionSplit{}
 	for _, s := range m.splits {
 		trx = append(trx, firefly.RequestTransactionSplit{
 			TransactionJournalID: s.trxJID,
-			Type:                 m.attr.transactionType,
+			Type:                 m.TransactionType(),
 			Date:                 fmt.Sprintf("%s-%s-%s", m.attr.year, m.attr.month, m.attr.day),
 			SourceID:             s.source.ID,
 			DestinationID:        s.destination.ID,
@@ -498,44 +974,67 @@ func (m *modelTransaction) UpdateTransaction() tea.Cmd {
 			Amount:               s.amount,
 			ForeignAmount:        s.foreignAmount,
 			Description:          s.Description(),
+			InternalReference:    s.internalReference,
+			ExternalID:           s.externalID,
+			InvoiceDate:          s.invoiceDate,
 		})
+		trx[len(trx)-1].SetLocation(s.Location())
 	}
 
-	id, err := m.api.UpdateTransaction(m.attr.trxID, firefly.RequestTransaction{
+	m.submitted = true
+	id, err := m.api.UpdateTransaction(context.Background(), m.attr.trxID, firefly.RequestTransaction{
 		ApplyRules:   true,
 		FireWebhooks: true,
 		GroupTitle:   m.GroupTitle(),
 		Transactions: trx,
 	})
+	if errors.Is(err, firefly.ErrQueued) {
+		m.created = false
+		m.deleteDraft()
+		m.recordUsage()
+		return tea.Batch(
+			SetView(transactionsView),
+			notify.NotifyWarn(err.Error()))
+	}
 	if err != nil {
+		m.submitted = false
+		if cmd := m.rejected(err); cmd != nil {
+			return cmd
+		}
 		return tea.Sequence(
 			notify.NotifyError(err.Error()),
 			SetView(transactionsView))
 	}
 
 	m.created = false
+	m.deleteDraft()
+	m.recordUsage()
+	saved := m.savedTransaction(id)
 
 	return tea.Batch(
 		SetView(transactionsView),
-		notify.NotifyLog("Transaction updated successfully"),
-		Cmd(RefreshAssetsMsg{}),
-		Cmd(RefreshLiabilitiesMsg{}),
-		Cmd(RefreshSummaryMsg{}),
-		Cmd(RefreshTransactionsMsg{TrxID: id}),
-		Cmd(RefreshExpenseInsightsMsg{}),
-		Cmd(RefreshRevenueInsightsMsg{}),
-		Cmd(RefreshCategoryInsightsMsg{}))
+		notifySaved(fmt.Sprintf("Transaction #%s updated", id), transactionLink(id)),
+		Cmd(transactionSavedMsg{Transaction: saved}),
+		runHook(hooks.TransactionUpdated, newHookTransaction(saved)),
+		PublishTransaction(id))
 }
 
 func (m *modelTransaction) SetTransaction(trx firefly.Transaction, newT bool) {
 	zap.L().Debug("newModelTransaction", zap.Any("trx", trx))
 
 	m.new = newT
+	m.submitted = false
+	m.groups.reset()
+	m.attr.fieldErrors = nil
 
 	now := time.Now()
 
 	if trx.TransactionID != "" {
 		m.attr.transactionType = trx.Type
+		m.attr.typeOverride = ""
+		if len(trx.Splits) > 0 && trx.Type != detectTransactionType(trx.Splits[0].Source, trx.Splits[0].Destination) {
+			m.attr.typeOverride = trx.Type
+		}
 		m.attr.year = trx.Date[0:4]
 		m.attr.month = trx.Date[5:7]
 		m.attr.day = trx.Date[8:10]
@@ -543,6 +1042,10 @@ func (m *modelTransaction) SetTransaction(trx firefly.Transaction, newT bool) {
 		m.attr.trxID = trx.TransactionID
 
 		m.splits = []*split{}
+		var first firefly.Split
+		if len(trx.Splits) > 0 {
+			first = trx.Splits[0]
+		}
 		for _, s := range trx.Splits {
 			amount := ""
 			if s.Amount != 0 {
@@ -559,11 +1062,23 @@ func (m *modelTransaction) SetTransaction(trx firefly.Transaction, newT bool) {
 				amount:        amount,
 				foreignAmount: foreignAmount,
 				description:   s.Description,
+				location:      s.Location.String(),
+				zoom:          s.Location.ZoomLevel,
 				trxJID:        s.TransactionJournalID,
+
+				internalReference: s.InternalReference,
+				externalID:        s.ExternalID,
+				invoiceDate:       s.InvoiceDate,
+
+				categoryPicked: s.Category.ID != "",
+
+				sharedSource:      first.Source,
+				sharedDestination: first.Destination,
 			})
 		}
 	} else {
 		m.attr.transactionType = "withdrawal"
+		m.attr.typeOverride = ""
 		m.attr.year = fmt.Sprintf("%d", now.Year())
 		m.attr.month = fmt.Sprintf("%02d", now.Month())
 		m.attr.day = fmt.Sprintf("%02d", now.Day())
@@ -571,24 +1086,33 @@ func (m *modelTransaction) SetTransaction(trx firefly.Transaction, newT bool) {
 		source := firefly.Account{}
 		destination := firefly.Account{}
 		category := firefly.Category{}
+		amount := ""
+		description := ""
 		if len(trx.Splits) > 0 {
 			source = trx.Splits[0].Source
 			destination = trx.Splits[0].Destination
 			category = trx.Splits[0].Category
+			if trx.Splits[0].Amount != 0 {
+				amount = fmt.Sprintf("%.2f", trx.Splits[0].Amount)
+			}
+			description = trx.Splits[0].Description
 		}
 		m.splits = []*split{
 			{
 				source:        source,
 				destination:   destination,
 				category:      category,
-				amount:        "",
+				amount:        amount,
 				foreignAmount: "",
-				description:   "",
+				description:   description,
 				trxJID:        "",
+
+				categoryPicked: category.ID != "",
 			},
 		}
 		m.new = true
 	}
+	m.dirty = false
 }
 
 func RedrawForm() tea.Cmd {
@@ -596,33 +1120,200 @@ func RedrawForm() tea.Cmd {
 }
 
 // Helpers
+
+// detectTransactionType derives the transaction type from the account types
+// of a split.
+func detectTransactionType(source, destination firefly.Account) string {
+	stx := source.Type
+	dtx := destination.Type
+	switch {
+	case stx == "asset" && (dtx == "expense" || dtx == "liabilities" || dtx == "cash"):
+		return "withdrawal"
+	case stx == "asset" && dtx == "asset":
+		return "transfer"
+	case stx == "revenue":
+		return "deposit"
+	case stx == "liabilities" && dtx == "expense":
+		return "withdrawal"
+	case stx == "liabilities" && dtx == "asset":
+		return "deposit"
+	case stx == "liabilities" && dtx == "liabilities":
+		return "transfer"
+	default:
+		return "unknown"
+	}
+}
+
+// transactionTypes are the types that can be chosen in the form instead of
+// the detected one.
+var transactionTypes = []string{"withdrawal", "deposit", "transfer", "opening balance", "reconciliation"}
+
+// TransactionType returns the type chosen in the form, or the type detected
+// from the accounts of the first split.
+func (m *modelTransaction) TransactionType() string {
+	if m.attr.typeOverride != "" {
+		return m.attr.typeOverride
+	}
+	return m.attr.transactionType
+}
+
+// accountsMatchType reports whether Firefly III accepts a split between
+// source and destination for the transaction type. Opening balances and
+// reconciliations book against an account of the server that is not listed
+// in the form, so one side has to be such an account.
+func accountsMatchType(transactionType string, source, destination firefly.Account) bool {
+	listed := func(a firefly.Account) bool {
+		switch a.Type {
+		case "asset", "liabilities", "expense", "revenue":
+			return true
+		}
+		return false
+	}
+	switch transactionType {
+	case "opening balance":
+		return (source.Type == "asset" || source.Type == "liabilities") && !listed(destination) ||
+			(destination.Type == "asset" || destination.Type == "liabilities") && !listed(source)
+	case "reconciliation":
+		return source.Type == "asset" && !listed(destination) ||
+			destination.Type == "asset" && !listed(source)
+	}
+	return detectTransactionType(source, destination) == transactionType
+}
+
+// trxTypeWarning warns when the accounts of the first split do not fit the
+// type chosen in the form. The transaction can still be submitted, Firefly
+// III has the final word.
+func (m *modelTransaction) trxTypeWarning(i int) func() string {
+	return func() string {
+		if i != 0 || m.attr.typeOverride == "" || len(m.splits) == 0 {
+			return ""
+		}
+		s := m.splits[0]
+		if s.source.ID == "" || s.destination.ID == "" || accountsMatchType(m.attr.typeOverride, s.source, s.destination) {
+			return ""
+		}
+		return fmt.Sprintf("Warning: %s -> %s does not look like a %s", s.source.Name, s.destination.Name, m.attr.typeOverride)
+	}
+}
+
+// splitNote warns about the type of the split and lists the messages of
+// its fields the server rejected.
+func (m *modelTransaction) splitNote(i int) func() string {
+	warning := m.trxTypeWarning(i)
+	return func() string {
+		lines := []string{}
+		if w := warning(); w != "" {
+			lines = append(lines, w)
+		}
+		prefix := splitFieldKey(i, "")
+		for _, key := range slices.Sorted(maps.Keys(m.attr.fieldErrors)) {
+			if strings.HasPrefix(key, prefix) {
+				lines = append(lines, m.attr.fieldErrors[key]...)
+			}
+		}
+		return strings.Join(lines, "\n")
+	}
+}
+
+// rejected keeps the form open when the server rejected its values, with
+// the messages shown at the rejected splits. It returns nil for other
+// errors.
+func (m *modelTransaction) rejected(err error) tea.Cmd {
+	var invalid *firefly.ValidationError
+	if !errors.As(err, &invalid) {
+		return nil
+	}
+	fields, first := formFieldErrors(invalid)
+	m.attr.fieldErrors = fields
+	if first >= 0 && first < len(m.splits) {
+		m.groups.focusSplit = m.splits[first]
+	}
+	return tea.Batch(notify.NotifyWarn(errorText(err)), RedrawForm())
+}
+
+// duplicate closes the form when the server rejected the transaction as a
+// duplicate of one created before, e.g. by a double submit. It returns nil
+// for other errors.
+func (m *modelTransaction) duplicate(err error) tea.Cmd {
+	var invalid *firefly.ValidationError
+	if !errors.As(err, &invalid) {
+		return nil
+	}
+	id, ok := invalid.Duplicate()
+	if !ok {
+		return nil
+	}
+	m.created = false
+	m.deleteDraft()
+	return tea.Batch(
+		SetView(transactionsView),
+		notify.NotifyLog(fmt.Sprintf("Transaction already created as #%s", id)),
+		Cmd(RefreshTransactionsMsg{TrxID: id}))
+}
+
+// validateSplit checks that the accounts of a split give the type of the
+// group, Firefly III rejects groups mixing types.
+func (m *modelTransaction) validateSplit(i int, s *split) error {
+	if i == 0 || s.source.ID == "" || s.destination.ID == "" {
+		return nil
+	}
+	if t := detectTransactionType(s.source, s.destination); t != m.attr.transactionType {
+		return fmt.Errorf("split %d is a %s, %s -> %s, but the transaction is a %s",
+			i, t, s.source.Name, s.destination.Name, m.attr.transactionType)
+	}
+	return nil
+}
+
+// validateInvoiceDate accepts an empty invoice date or a day as YYYY-MM-DD.
+func validateInvoiceDate(str string) error {
+	if str == "" {
+		return nil
+	}
+	if _, err := time.Parse(time.DateOnly, str); err != nil {
+		return errors.New("please enter the invoice date as YYYY-MM-DD")
+	}
+	return nil
+}
+
+func (m *modelTransaction) validateSplits() error {
+	for i, s := range m.splits {
+		if err := m.validateSplit(i, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sharedAccountOptions lists the accounts of the given types with the
+// account shared with the first split on top. The split follows changes of
+// the shared account unless another account was picked for it.
+func (m *modelTransaction) sharedAccountOptions(account, followed *firefly.Account, shared firefly.Account, types ...string) []huh.Option[firefly.Account] {
+	if account.ID == "" || account.ID == followed.ID {
+		*account = shared
+	}
+	*followed = shared
+
+	options := []huh.Option[firefly.Account]{huh.NewOption(shared.Name, shared)}
+	for _, option := range m.accountOptions(types...) {
+		if option.Value.ID != shared.ID {
+			options = append(options, option)
+		}
+	}
+	return options
+}
+
 func (m *modelTransaction) trxTitle(i int, s *split) (func() string, any) {
 	bindings := []any{&s.source, &s.destination}
 
 	if i == 0 {
+		bindings = append(bindings, &m.attr.typeOverride)
 		return func() string {
 			m.attr.source = s.source
 			m.attr.destination = s.destination
 
-			stx := s.source.Type
-			dtx := s.destination.Type
-			m.attr.transactionType = ""
-
-			switch {
-			case stx == "asset" && (dtx == "expense" || dtx == "liabilities" || dtx == "cash"):
-				m.attr.transactionType = "withdrawal"
-			case stx == "asset" && dtx == "asset":
-				m.attr.transactionType = "transfer"
-			case stx == "revenue":
-				m.attr.transactionType = "deposit"
-			case stx == "liabilities" && dtx == "expense":
-				m.attr.transactionType = "withdrawal"
-			case stx == "liabilities" && dtx == "asset":
-				m.attr.transactionType = "deposit"
-			case stx == "liabilities" && dtx == "liabilities":
-				m.attr.transactionType = "transfer"
-			default:
-				m.attr.transactionType = "unknown"
+			m.attr.transactionType = detectTransactionType(s.source, s.destination)
+			if m.attr.typeOverride != "" {
+				return fmt.Sprintf("Current Type: %s (set manually)", m.attr.typeOverride)
 			}
 			return fmt.Sprintf("Current Type: %s", m.attr.transactionType)
 		}, bindings
@@ -632,113 +1323,54 @@ func (m *modelTransaction) trxTitle(i int, s *split) (func() string, any) {
 }
 
 func (m *modelTransaction) trxSourceOptions(i int, s *split) (func() []huh.Option[firefly.Account], any) {
-	bindings := []any{&triggerSourceCounter}
+	bindings := []any{&m.groups.sourceTrigger}
 
 	if i > 0 {
 		bindings = append(bindings, &m.attr.source)
 		return func() []huh.Option[firefly.Account] {
-			options := []huh.Option[firefly.Account]{}
 			if m.attr.transactionType == "withdrawal" || m.attr.transactionType == "transfer" {
-				options = append(options, huh.NewOption(m.attr.source.Name, m.attr.source))
-				s.source = m.attr.source
-			} else {
-				for _, account := range m.api.AccountsByType("revenue") {
-					options = append(options, huh.NewOption(account.Name, account))
-				}
-				for _, account := range m.api.AccountsByType("liabilities") {
-					options = append(options, huh.NewOption(account.Name, account))
-				}
+				return m.sharedAccountOptions(&s.source, &s.sharedSource, m.attr.source, "asset", "liabilities")
 			}
-			return options
+			return m.accountOptions("revenue", "liabilities")
 		}, bindings
 	}
 
 	return func() []huh.Option[firefly.Account] {
-		options := []huh.Option[firefly.Account]{}
-		for _, account := range m.api.AccountsByType("asset") {
-			options = append(options, huh.NewOption(account.Name, account))
-		}
-		for _, account := range m.api.AccountsByType("revenue") {
-			options = append(options, huh.NewOption(account.Name, account))
-		}
-		for _, account := range m.api.AccountsByType("liabilities") {
-			options = append(options, huh.NewOption(account.Name, account))
-		}
-		return options
+		return m.accountOptions("asset", "revenue", "liabilities")
 	}, bindings
 }
 
 func (m *modelTransaction) trxDestinationOptions(i int, s *split) (func() []huh.Option[firefly.Account], any) {
-	bindings := []any{&s.source.Type, &triggerDestinationCounter}
+	bindings := []any{&s.source.Type, &m.groups.destinationTrigger}
 
 	if i > 0 {
 		bindings = append(bindings, &m.attr.destination)
 		return func() []huh.Option[firefly.Account] {
-			options := []huh.Option[firefly.Account]{}
 			if m.attr.transactionType == "deposit" || m.attr.transactionType == "transfer" {
-				options = append(options, huh.NewOption(m.attr.destination.Name, m.attr.destination))
-				s.destination = m.attr.destination
-			} else {
-				switch s.source.Type {
-				case "asset":
-					for _, account := range m.api.AccountsByType("expense") {
-						options = append(options, huh.NewOption(account.Name, account))
-					}
-					for _, account := range m.api.AccountsByType("liabilities") {
-						options = append(options, huh.NewOption(account.Name, account))
-					}
-				case "revenue":
-					for _, account := range m.api.AccountsByType("asset") {
-						options = append(options, huh.NewOption(account.Name, account))
-					}
-					for _, account := range m.api.AccountsByType("liabilities") {
-						options = append(options, huh.NewOption(account.Name, account))
-					}
-				case "liabilities":
-					for _, account := range m.api.AccountsByType("asset") {
-						options = append(options, huh.NewOption(account.Name, account))
-					}
-					for _, account := range m.api.AccountsByType("expense") {
-						options = append(options, huh.NewOption(account.Name, account))
-					}
-				}
+				return m.sharedAccountOptions(&s.destination, &s.sharedDestination, m.attr.destination, "asset", "liabilities")
+			}
+			switch s.source.Type {
+			case "asset":
+				return m.accountOptions("expense", "liabilities")
+			case "revenue":
+				return m.accountOptions("asset", "liabilities")
+			case "liabilities":
+				return m.accountOptions("asset", "expense")
 			}
-			return options
+			return []huh.Option[firefly.Account]{}
 		}, bindings
 	}
 
 	return func() []huh.Option[firefly.Account] {
-		options := []huh.Option[firefly.Account]{}
 		switch s.source.Type {
 		case "asset":
-			for _, account := range m.api.AccountsByType("expense") {
-				options = append(options, huh.NewOption(account.Name, account))
-			}
-			for _, account := range m.api.AccountsByType("asset") {
-				options = append(options, huh.NewOption(account.Name, account))
-			}
-			for _, account := range m.api.AccountsByType("liabilities") {
-				options = append(options, huh.NewOption(account.Name, account))
-			}
+			return m.accountOptions("expense", "asset", "liabilities")
 		case "revenue":
-			for _, account := range m.api.AccountsByType("asset") {
-				options = append(options, huh.NewOption(account.Name, account))
-			}
-			for _, account := range m.api.AccountsByType("liabilities") {
-				options = append(options, huh.NewOption(account.Name, account))
-			}
+			return m.accountOptions("asset", "liabilities")
 		case "liabilities":
-			for _, account := range m.api.AccountsByType("asset") {
-				options = append(options, huh.NewOption(account.Name, account))
-			}
-			for _, account := range m.api.AccountsByType("expense") {
-				options = append(options, huh.NewOption(account.Name, account))
-			}
-			for _, account := range m.api.AccountsByType("liabilities") {
-				options = append(options, huh.NewOption(account.Name, account))
-			}
+			return m.accountOptions("asset", "expense", "liabilities")
 		}
-		return options
+		return []huh.Option[firefly.Account]{}
 	}, bindings
 }
 
@@ -756,7 +1388,7 @@ func (m *modelTransaction) GroupTitle() string {
 		case "transfer":
 			acc = fmt.Sprintf("%s -> %s", m.attr.source.Name, m.attr.destination.Name)
 		}
-		return fmt.Sprintf("%s, splits: %d, %s", m.attr.transactionType, len(m.splits), acc)
+		return fmt.Sprintf("%s, splits: %d, %s", m.TransactionType(), len(m.splits), acc)
 	}
 	return ""
 }
@@ -768,6 +1400,15 @@ func (s *split) Description() string {
 	return s.description
 }
 
+// Summary returns the split description followed by its amount, if set.
+func (s *split) Summary() string {
+	desc := s.Description()
+	if s.amount != "" {
+		desc += " (" + s.amount + ")"
+	}
+	return desc
+}
+
 func (s *split) CurrencyCode() string {
 	switch s.source.Type {
 	case "asset", "liabilities":
@@ -5,24 +5,40 @@ SPDX-License-Identifier: Apache-2.0
 package ui
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"maps"
 	"net/url"
+	"slices"
+	"strings"
 	"time"
+	"unicode"
 
 	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/hooks"
+	"ffiii-tui/internal/ui/cellview"
+	"ffiii-tui/internal/ui/loading"
 	"ffiii-tui/internal/ui/notify"
 	"ffiii-tui/internal/ui/prompt"
+	"ffiii-tui/internal/ui/recurrenceform"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
 )
 
-var (
-	filterPromptAccount  firefly.Account
-	filterPromptCategory firefly.Category
-	filterPromptFilter   string
+// Resets the foreground color only, keeping the background of the cursor row.
+const defaultForeground = "\x1b[39m"
+
+// Matches of the query are underlined as well as colored, so they stay
+// visible on the cursor row.
+const (
+	underline   = "\x1b[4m"
+	noUnderline = "\x1b[24m"
 )
 
 type (
@@ -46,6 +62,27 @@ type (
 	DeleteTransactionMsg struct {
 		Transaction firefly.Transaction
 	}
+	// OpenTransactionMsg fetches a transaction by ID to edit it, even if
+	// it is not in the loaded period.
+	OpenTransactionMsg struct {
+		TransactionID string
+	}
+	transactionFetchedMsg struct {
+		Transaction firefly.Transaction
+	}
+	// transactionDeletedMsg removes a transaction deleted on the server.
+	transactionDeletedMsg struct {
+		Transaction firefly.Transaction
+	}
+	// transactionsPageMsg is a page of a transaction load still in
+	// progress; the next messages of the load arrive on stream.
+	transactionsPageMsg struct {
+		ctx    context.Context
+		page   firefly.TransactionsPage
+		stream <-chan tea.Msg
+	}
+	// transactionsLoadEndedMsg clears the progress of a failed load.
+	transactionsLoadEndedMsg struct{}
 )
 
 type modelTransactions struct {
@@ -59,12 +96,49 @@ type modelTransactions struct {
 	focus           bool
 	keymap          TransactionsKeyMap
 	styles          Styles
+
+	shown            []firefly.Transaction // after filtering
+	expanded         map[string]bool       // split groups shown split by split
+	marked           map[string]bool       // transactions picked for a bulk action
+	unreconciledOnly bool
+	uncategorized    string    // one of uncategorizedCycle
+	typeFilter       string    // only transactions of this type when set
+	dates            dateRange // within the loaded period
+	currentTag       string
+	filterOrder      []filterKind       // active filters, the one set last at the end
+	beforeSearch     transactionFilters // restored when the search ends
+
+	columns []table.Column // as wide as their content, before fitting
+	scroll  int            // columns scrolled out to the left
+
+	// Progress of a load streamed page by page, zero when not loading
+	loaded    int
+	loadTotal int
+
+	// resumeTrxID is the transaction the cursor was left on in the last
+	// session, it is selected once loaded
+	resumeTrxID string
+
+	deletedFile string // where deleted transactions are logged, empty to log none
+
+	// requests load the list, of the period or a search
+	requests *requestScope
+}
+
+// typeFilterCycle is the order the type filter key steps through, back to
+// all transactions.
+var typeFilterCycle = []string{"", "withdrawal", "deposit", "transfer"}
+
+func nextTypeFilter(current string) string {
+	i := slices.Index(typeFilterCycle, current)
+	return typeFilterCycle[(i+1)%len(typeFilterCycle)]
 }
 
 func NewModelTransactions(api TransactionAPI) modelTransactions {
 	transactions := []firefly.Transaction{}
 
-	rows, columns := getRows(transactions)
+	styles := DefaultStyles()
+	rows, columns := getRows(transactions, nil, styles)
 	t := table.New(
 		table.WithColumns(columns),
 		table.WithRows(rows),
@@ -88,7 +162,11 @@ func NewModelTransactions(api TransactionAPI) modelTransactions {
 		transactions: transactions,
 		api:          api,
 		keymap:       DefaultTransactionsKeyMap(),
-		styles:       DefaultStyles(),
+		styles:       styles,
+		expanded:     map[string]bool{},
+		marked:       map[string]bool{},
+		requests:     newRequestScope(),
+		columns:      columns,
 	}
 	return m
 }
@@ -106,32 +184,24 @@ func (m modelTransactions) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			m.currentSearch = ""
 
-			// Restoring values
-			m.currentAccount = filterPromptAccount
-			m.currentCategory = filterPromptCategory
-			m.currentFilter = filterPromptFilter
+			// Restoring the filters set before searching
+			m.setFilters(m.beforeSearch)
+			m.beforeSearch = transactionFilters{}
 		} else { // Searching for something
 			if m.currentSearch == "" {
-				// Saving values in global state for future restoration
-				filterPromptAccount = m.currentAccount
-				filterPromptCategory = m.currentCategory
-				filterPromptFilter = m.currentFilter
-
-				// Resetting
-				m.currentAccount = firefly.Account{}
-				m.currentCategory = firefly.Category{}
-				m.currentFilter = ""
+				// Searching starts without filters, they are back after
+				m.beforeSearch = m.filters()
+				m.setFilters(transactionFilters{})
 			}
 			m.currentSearch = msg.Query
 		}
+		m.requests.Renew()
 		return m, Cmd(RefreshTransactionsMsg{})
 
 	case FilterMsg:
 		// Reset flag
 		if msg.Reset {
-			m.currentAccount = firefly.Account{}
-			m.currentCategory = firefly.Category{}
-			m.currentFilter = ""
+			m.setFilters(transactionFilters{})
 		}
 
 		// if msg.Account == "None" {
@@ -146,28 +216,21 @@ func (m modelTransactions) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.currentFilter = ""
 		}
 
-		// Reset other filters if the same filter is applied again
+		// Filters stack, each one replaces only the filter of its kind
 		if !msg.Account.IsEmpty() {
-			if msg.Account == m.currentAccount {
-				m.currentCategory = firefly.Category{}
-				m.currentFilter = ""
-			}
 			m.currentAccount = msg.Account
 		}
 		if !msg.Category.IsEmpty() {
-			if msg.Category == m.currentCategory {
-				m.currentAccount = firefly.Account{}
-				m.currentFilter = ""
-			}
 			m.currentCategory = msg.Category
 		}
 		if msg.Query != "" && msg.Query != "None" {
-			if msg.Query == m.currentFilter {
-				m.currentAccount = firefly.Account{}
-				m.currentCategory = firefly.Category{}
+			if tag, ok := strings.CutPrefix(msg.Query, tagPrefix); ok {
+				m.currentTag = strings.TrimSpace(tag)
+			} else {
+				m.currentFilter = msg.Query
 			}
-			m.currentFilter = msg.Query
 		}
+		m.syncFilterOrder()
 
 		transactions := m.transactions
 
@@ -214,7 +277,9 @@ func (m modelTransactions) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							split.Currency+
 							split.ForeignCurrency+
 							fmt.Sprintf("%.2f", split.Amount)+
-							fmt.Sprintf("%.2f", split.ForeignAmount),
+							fmt.Sprintf("%.2f", split.ForeignAmount)+
+							split.InternalReference+
+							split.ExternalID,
 						value,
 					) {
 						txs = append(txs, tx)
@@ -225,73 +290,154 @@ func (m modelTransactions) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			transactions = txs
 		}
 
-		rows, columns := getRows(transactions)
-		m.table.SetRows(rows)
-		m.table.SetColumns(columns)
+		if m.currentTag != "" {
+			txs := []firefly.Transaction{}
+			for _, tx := range transactions {
+				if hasTag(tx, m.currentTag) {
+					txs = append(txs, tx)
+				}
+			}
+			transactions = txs
+		}
+
+		if !m.dates.isEmpty() {
+			txs := []firefly.Transaction{}
+			for _, tx := range transactions {
+				if m.dates.contains(tx.Date) {
+					txs = append(txs, tx)
+				}
+			}
+			transactions = txs
+		}
 
-		if msg.TrxID != "" {
-			for i, trx := range m.table.Rows() {
-				if trx[11] == msg.TrxID { // That is TxID column
-					m.table.SetCursor(i)
+		if m.typeFilter != "" {
+			txs := []firefly.Transaction{}
+			for _, tx := range transactions {
+				if tx.Type == m.typeFilter {
+					txs = append(txs, tx)
 				}
 			}
+			transactions = txs
 		}
 
-	case RefreshTransactionsMsg:
-		return m, func() tea.Msg {
-			var err error
-			searchQuery := ""
-			if m.currentSearch != "" {
-				searchQuery = url.QueryEscape(m.currentSearch)
-			}
-			opID := startLoading("Loading transactions...")
-			defer stopLoading(opID)
-			transactions, err := m.api.ListTransactions(searchQuery)
-			if err != nil {
-				return notify.NotifyWarn(err.Error())()
+		if m.unreconciledOnly {
+			txs := []firefly.Transaction{}
+			for _, tx := range transactions {
+				if !tx.Reconciled() {
+					txs = append(txs, tx)
+				}
 			}
-			return TransactionsUpdateMsg{
-				TrxID:        msg.TrxID,
-				Transactions: transactions,
+			transactions = txs
+		}
+
+		if m.uncategorized != "" {
+			txs := []firefly.Transaction{}
+			for _, tx := range transactions {
+				if needsCategory(tx, m.uncategorized == uncategorizedBudget) {
+					txs = append(txs, tx)
+				}
 			}
+			transactions = txs
 		}
 
+		m.shown = transactions
+		m.updateRows(msg.TrxID)
+
+	case DateRangeMsg:
+		m.dates = msg.Range
+		return m, Cmd(FilterMsg{})
+
+	case RefreshTransactionsMsg:
+		ctx := m.requests.Context()
+		searchQuery := ""
+		if m.currentSearch != "" {
+			searchQuery = url.QueryEscape(m.currentSearch)
+		}
+		op := loading.NewOperation("Loading transactions...")
+		return m, tea.Sequence(op.Start(), func() tea.Msg {
+			stream := make(chan tea.Msg)
+			go streamTransactions(ctx, m.api, searchQuery, msg.TrxID, op, stream)
+			return <-stream
+		})
+
+	case transactionsPageMsg:
+		// Pages of a superseded load are dropped
+		if msg.ctx.Err() != nil {
+			return m, nil
+		}
+		if msg.page.Loaded == len(msg.page.Transactions) {
+			m.transactions = nil
+		}
+		m.transactions = append(m.transactions, msg.page.Transactions...)
+		m.loaded, m.loadTotal = msg.page.Loaded, msg.page.Total
+		return m, tea.Batch(Cmd(FilterMsg{}), waitForTransactions(msg.stream))
+
+	case transactionsLoadEndedMsg:
+		m.loaded, m.loadTotal = 0, 0
+
 	case TransactionsUpdateMsg:
+		m.loaded, m.loadTotal = 0, 0
 		m.transactions = msg.Transactions
-		return m, tea.Batch(Cmd(FilterMsg{
-			TrxID:    msg.TrxID,
-			Account:  m.currentAccount,
-			Category: m.currentCategory,
-			Query:    m.currentFilter,
-		}), notify.NotifyLog("Transactions loaded"))
+		trxID := msg.TrxID
+		if trxID == "" && m.resumeTrxID != "" && slices.ContainsFunc(m.transactions, func(tx firefly.Transaction) bool {
+			return tx.TransactionID == m.resumeTrxID
+		}) {
+			trxID, m.resumeTrxID = m.resumeTrxID, ""
+		}
+		return m, tea.Batch(Cmd(FilterMsg{TrxID: trxID}),
+			notify.NotifyLog("Transactions loaded"),
+			Cmd(DataLoadCompletedMsg{DataType: "transactions"}))
 
-	case DeleteTransactionMsg:
-		id := msg.Transaction.TransactionID
-		if id != "" {
-			opID := startLoading("Deleting transaction...")
-			defer stopLoading(opID)
-			err := m.api.DeleteTransaction(id)
+	case OpenTransactionMsg:
+		id := msg.TransactionID
+		return m, loading.Track("Loading transaction...", func() tea.Msg {
+			trx, err := m.api.GetTransaction(context.Background(), id)
 			if err != nil {
-				return m, tea.Batch(
-					notify.NotifyError(fmt.Sprint("Error deleting transaction, ", err.Error())),
-					SetView(transactionsView))
+				return notify.NotifyWarn(fmt.Sprintf("Transaction #%s not found: %v", id, err))()
 			}
-			return m, tea.Batch(
-				notify.NotifyLog("Transaction deleted successfully."),
-				SetView(transactionsView),
-				Cmd(RefreshAssetsMsg{}),
-				Cmd(RefreshLiabilitiesMsg{}),
-				Cmd(RefreshSummaryMsg{}),
-				Cmd(RefreshTransactionsMsg{}),
-				Cmd(RefreshExpenseInsightsMsg{}),
-				Cmd(RefreshRevenueInsightsMsg{}))
-		}
-		return m, SetView(transactionsView)
+			return transactionFetchedMsg{Transaction: trx}
+		})
+
+	case transactionFetchedMsg:
+		return m, tea.Sequence(
+			Cmd(EditTransactionMsg{Transaction: msg.Transaction}),
+			SetView(newView))
+
+	case categoryAssignedMsg:
+		return m, m.categoryAssigned(msg)
+
+	case bulkTaggedMsg:
+		clear(m.marked)
+		trx, _ := m.GetCurrentTransaction()
+		m.updateRows(trx.TransactionID)
+		return m, nil
+
+	case transactionSavedMsg:
+		if msg.Transaction.TransactionID == "" {
+			return m, nil
+		}
+		m.putTransaction(msg.Transaction)
+		return m, Cmd(FilterMsg{TrxID: msg.Transaction.TransactionID})
+	case CreateTransactionsMsg:
+		return m, createTransactions(m.api, msg.Transactions)
+	case DeleteTransactionMsg:
+		if msg.Transaction.TransactionID == "" {
+			return m, SetView(transactionsView)
+		}
+		return m, deleteTransaction(m.api, msg.Transaction, m.deletedFile)
+	case transactionDeletedMsg:
+		m.removeTransaction(msg.Transaction.TransactionID)
+		return m, tea.Batch(
+			notify.NotifyLog("Transaction deleted successfully."),
+			runHook(hooks.TransactionDeleted, newHookTransaction(msg.Transaction)),
+			Cmd(FilterMsg{}),
+			Publish(TransactionsChanged))
 	case UpdatePositions:
 		if msg.layout != nil {
 			h, v := m.styles.Base.GetFrameSize()
 			m.table.SetWidth(msg.layout.Width - msg.layout.LeftSize - h)
-			m.table.SetHeight(msg.layout.Height - msg.layout.TopSize - v)
+			m.table.SetHeight(msg.layout.Height - msg.layout.TopSize - v - footerHeight)
+			m.arrangeColumns(m.scroll)
 		}
 	}
 
@@ -303,6 +449,8 @@ func (m modelTransactions) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		// The cursor is the user's from now on
+		m.resumeTrxID = ""
 		switch {
 		case key.Matches(msg, m.keymap.Quit):
 			return m, tea.Quit
@@ -310,7 +458,7 @@ func (m modelTransactions) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, Cmd(RefreshAllMsg{})
 		case key.Matches(msg, m.keymap.Filter):
 			return m, prompt.Ask(
-				"Filter query (ESC to reset): ",
+				"Filter query, #tag for tags (ESC to reset): ",
 				m.currentFilter,
 				func(value string) tea.Cmd {
 					var cmds []tea.Cmd
@@ -320,6 +468,25 @@ func (m modelTransactions) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return tea.Sequence(cmds...)
 				},
 			)
+		case key.Matches(msg, m.keymap.Open):
+			return m, prompt.Ask(
+				"Transaction ID or URL: ",
+				"",
+				func(value string) tea.Cmd {
+					if value == "None" {
+						return SetView(transactionsView)
+					}
+					id, err := parseTransactionRef(value)
+					if err != nil {
+						return tea.Sequence(
+							notify.NotifyWarn(err.Error()),
+							SetView(transactionsView))
+					}
+					return tea.Sequence(
+						SetView(transactionsView),
+						Cmd(OpenTransactionMsg{TransactionID: id}))
+				},
+			)
 		case key.Matches(msg, m.keymap.Search):
 			return m, prompt.Ask(
 				"Search query (ESC to exit search mode): ",
@@ -373,19 +540,101 @@ func (m modelTransactions) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, notify.NotifyError("Transaction not found.")
 			}
 
+			return m, confirmAction(
+				confirmDeleteTransaction,
+				fmt.Sprintf("Delete transaction %s - %s?", trx.TransactionID, trx.Description()),
+				tea.Sequence(SetView(transactionsView), Cmd(DeleteTransactionMsg{Transaction: trx})),
+				SetView(transactionsView),
+			)
+		case key.Matches(msg, m.keymap.Unreconciled):
+			m.unreconciledOnly = !m.unreconciledOnly
+			return m, Cmd(FilterMsg{})
+		case key.Matches(msg, m.keymap.Uncategorized):
+			m.uncategorized = nextUncategorized(m.uncategorized)
+			return m, Cmd(FilterMsg{})
+		case key.Matches(msg, m.keymap.AssignCategory):
+			trx, err := m.GetCurrentTransaction()
+			if err != nil {
+				return m, notify.NotifyWarn(err.Error())
+			}
+			return m, m.askCategory(trx)
+		case key.Matches(msg, m.keymap.TypeFilter):
+			m.typeFilter = nextTypeFilter(m.typeFilter)
+			return m, Cmd(FilterMsg{})
+		case key.Matches(msg, m.keymap.DateRange):
+			current := ""
+			if !m.dates.isEmpty() {
+				current = m.dates.String()
+			}
+			newest := newestDay(m.transactions)
 			return m, prompt.Ask(
-				fmt.Sprintf("Are you sure you want to delete the transaction? Type 'yes!' to confirm. Transaction: %s - %s: ", trx.TransactionID, trx.Description()),
-				"no",
+				"Date range, e.g. 7d or 2026-01-05..2026-01-12 (ESC to reset): ",
+				current,
 				func(value string) tea.Cmd {
-					var cmd tea.Cmd
-					if value == "yes!" {
-						cmd = Cmd(DeleteTransactionMsg{Transaction: trx})
+					if value == "None" {
+						return tea.Sequence(
+							Cmd(DateRangeMsg{}),
+							SetView(transactionsView))
+					}
+					r, err := parseDateRange(value, newest)
+					if err != nil {
+						return tea.Sequence(
+							notify.NotifyWarn(err.Error()),
+							SetView(transactionsView))
 					}
-					return tea.Sequence(SetView(transactionsView), cmd)
+					return tea.Sequence(
+						Cmd(DateRangeMsg{Range: r}),
+						SetView(transactionsView))
 				},
 			)
 		case key.Matches(msg, m.keymap.ResetFilter):
 			return m, Cmd(FilterMsg{Reset: true})
+		case key.Matches(msg, m.keymap.RemoveFilter):
+			if len(m.filterOrder) == 0 {
+				return m, nil
+			}
+			m.removeFilter(m.filterOrder[len(m.filterOrder)-1])
+			return m, Cmd(FilterMsg{})
+		case key.Matches(msg, m.keymap.OpenInWeb):
+			row := m.table.SelectedRow()
+			if row == nil {
+				return m, notify.NotifyWarn("Transaction not selected.")
+			}
+			return m, Cmd(OpenInWebMsg{Path: "transactions/show/" + row[11]})
+		case key.Matches(msg, m.keymap.ToggleSplits):
+			row := m.table.SelectedRow()
+			if row == nil {
+				return m, nil
+			}
+			trx, err := m.findTransactionByID(row[11])
+			if err != nil || len(trx.Splits) < 2 {
+				return m, nil
+			}
+			m.expanded[trx.TransactionID] = !m.expanded[trx.TransactionID]
+			m.updateRows(trx.TransactionID)
+			return m, nil
+		case key.Matches(msg, m.keymap.Mark):
+			m.toggleMark()
+			return m, nil
+		case key.Matches(msg, m.keymap.ShowCells):
+			return m, m.showCells()
+		case key.Matches(msg, m.keymap.ScrollLeft):
+			m.arrangeColumns(m.scroll - 1)
+			return m, nil
+		case key.Matches(msg, m.keymap.ScrollRight):
+			m.arrangeColumns(m.scroll + 1)
+			return m, nil
+		case key.Matches(msg, m.keymap.BulkTag):
+			return m, m.askBulkTag()
+		case key.Matches(msg, m.keymap.RepeatMonthly):
+			trx, err := m.GetCurrentTransaction()
+			if err != nil {
+				return m, notify.NotifyWarn(err.Error())
+			}
+			if trx.Type != "withdrawal" && trx.Type != "transfer" {
+				return m, notify.NotifyWarn("Only withdrawals and transfers can be repeated")
+			}
+			return m, recurrenceform.Open(trx)
 		case key.Matches(msg, m.keymap.ToggleFullView):
 			return m, Cmd(ViewFullTransactionViewMsg{})
 		case key.Matches(msg, m.keymap.ViewAssets):
@@ -398,6 +647,8 @@ func (m modelTransactions) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, SetView(revenuesView)
 		case key.Matches(msg, m.keymap.ViewLiabilities):
 			return m, SetView(liabilitiesView)
+		case key.Matches(msg, m.keymap.ViewTags):
+			return m, SetView(tagsView)
 		}
 	}
 
@@ -405,8 +656,245 @@ func (m modelTransactions) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// deleteTransaction deletes trx in the background. The deletion is not tied
+// to the requests of the list, renewing them must not cancel it. Deletions
+// are logged to deletedFile, also the ones queued offline.
+func deleteTransaction(api TransactionAPI, trx firefly.Transaction, deletedFile string) tea.Cmd {
+	return loading.Track("Deleting transaction...", func() tea.Msg {
+		err := api.DeleteTransaction(context.Background(), trx.TransactionID)
+		if err == nil || errors.Is(err, firefly.ErrQueued) {
+			recordDeleted(deletedFile, trx, time.Now())
+		}
+		switch {
+		case errors.Is(err, firefly.ErrQueued):
+			return notify.NotifyWarn(err.Error())()
+		case err != nil:
+			return notify.NotifyError(fmt.Sprint("Error deleting transaction, ", err.Error()))()
+		}
+		return transactionDeletedMsg{Transaction: trx}
+	})
+}
+
+// streamTransactions loads the transactions, sending a page message for
+// every page but the last and then the whole list, or the failure, on
+// stream. It gives up once ctx is canceled and stops op when done.
+func streamTransactions(ctx context.Context, api TransactionAPI, query, trxID string, op *loading.Operation, stream chan tea.Msg) {
+	defer close(stream)
+	defer op.Stop()
+
+	send := func(msg tea.Msg) {
+		select {
+		case stream <- msg:
+		case <-ctx.Done():
+		}
+	}
+	transactions, err := api.StreamTransactions(ctx, query, func(page firefly.TransactionsPage) {
+		// The last page arrives with the whole list
+		if page.Loaded < page.Total {
+			send(transactionsPageMsg{ctx: ctx, page: page, stream: stream})
+		}
+	})
+	if err != nil {
+		send(tea.BatchMsg{
+			func() tea.Msg { return dataLoadFailed("transactions", err) },
+			Cmd(transactionsLoadEndedMsg{}),
+		})
+		return
+	}
+	send(TransactionsUpdateMsg{TrxID: trxID, Transactions: transactions})
+}
+
+// waitForTransactions waits for the next message of a streamed load.
+func waitForTransactions(stream <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-stream
+	}
+}
+
 func (m modelTransactions) View() string {
-	return m.table.View()
+	return m.colorRows(m.table.View()) + "\n" + m.footer()
+}
+
+// Lines under the table taken by the footer
+const footerHeight = 1
+
+// footer counts the visible transactions and sums them per currency.
+// Withdrawals count negative and deposits positive; transfers stay between
+// own accounts and are left out. While a large load is streamed in, it
+// shows how many transactions arrived so far.
+func (m modelTransactions) footer() string {
+	totals := map[string]float64{}
+	for _, tx := range m.shown {
+		for _, split := range tx.Splits {
+			switch tx.Type {
+			case "withdrawal":
+				totals[split.Currency] -= split.Amount
+			case "deposit":
+				totals[split.Currency] += split.Amount
+			}
+		}
+	}
+	currencies := slices.Sorted(maps.Keys(totals))
+
+	noun := "transactions"
+	if len(m.shown) == 1 {
+		noun = "transaction"
+	}
+	parts := []string{fmt.Sprintf(" %d %s", len(m.shown), noun)}
+	if m.loadTotal > 0 {
+		parts = append(parts, fmt.Sprintf("loading %d/%d", m.loaded, m.loadTotal))
+	}
+	for _, currency := range currencies {
+		parts = append(parts, fmt.Sprintf("%s %.2f", currency, totals[currency]))
+	}
+	line := strings.Join(parts, statusBarSeparator)
+	if width := m.table.Width(); width > 0 {
+		line = ansi.Truncate(line, width, "…")
+	}
+	return m.styles.TableFooter.Render(line)
+}
+
+// colorRows colors the type badges and amounts in the rendered table by
+// transaction type, and highlights the text matching the filter or search
+// query. The table truncates cells without regard to escape codes, so the
+// colors are put into its output instead of the rows. Only the foreground
+// is set, the cursor row overrides it with its own colors.
+func (m modelTransactions) colorRows(view string) string {
+	types := map[string]string{}
+	for _, tx := range m.shown {
+		types[tx.TransactionID] = tx.Type
+	}
+
+	// Columns of the rendered table, hidden ones are not rendered
+	type span struct{ start, end int }
+	padding := table.DefaultStyles().Cell.GetHorizontalFrameSize()
+	spans := []span{}
+	pos := 0
+	for _, column := range m.table.Columns() {
+		if column.Width <= 0 {
+			spans = append(spans, span{})
+			continue
+		}
+		spans = append(spans, span{pos, pos + column.Width + padding})
+		pos += column.Width + padding
+	}
+	if len(spans) < 12 {
+		return view
+	}
+
+	highlight := foregroundSequence(m.styles.SearchMatch)
+	queries := m.highlightQueries()
+
+	// segment is a part of a line put between prefix and suffix
+	type segment struct {
+		span
+		prefix, suffix string
+	}
+
+	lines := strings.Split(view, "\n")
+	for i, line := range lines {
+		width := ansi.StringWidth(line)
+		id := strings.TrimSpace(ansi.Strip(ansi.Cut(line, spans[11].start, spans[11].end)))
+		trxType, ok := types[id]
+		if !ok {
+			continue
+		}
+
+		segments := []segment{}
+		color := foregroundSequence(m.styles.typeStyle(trxType))
+		for column := range spans {
+			cell := spans[column]
+			switch column {
+			case 1, 7, 9: // Type, Amount, Foreign Amount
+				if color != "" {
+					segments = append(segments, segment{cell, color, defaultForeground})
+				}
+			case 3, 4, 10: // Source, Destination, Description
+				if highlight == "" {
+					continue
+				}
+				text := ansi.Strip(ansi.Cut(line, cell.start, cell.end))
+				for _, match := range matchColumns(text, queries) {
+					segments = append(segments, segment{
+						span{cell.start + match[0], cell.start + match[1]},
+						highlight + underline, noUnderline + defaultForeground,
+					})
+				}
+			}
+		}
+		if len(segments) == 0 {
+			continue
+		}
+
+		var b strings.Builder
+		start := 0
+		for _, seg := range segments {
+			b.WriteString(ansi.Cut(line, start, seg.start))
+			b.WriteString(seg.prefix + ansi.Cut(line, seg.start, seg.end) + seg.suffix)
+			start = seg.end
+		}
+		b.WriteString(ansi.Cut(line, start, width))
+		lines[i] = b.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// highlightQueries returns the filter query and the search query, unless
+// the search uses Firefly III operators such as "amount_more:10".
+func (m modelTransactions) highlightQueries() []string {
+	queries := []string{}
+	if q := strings.TrimSpace(m.currentFilter); q != "" {
+		queries = append(queries, q)
+	}
+	if q := strings.TrimSpace(m.currentSearch); q != "" && !strings.Contains(q, ":") {
+		queries = append(queries, q)
+	}
+	return queries
+}
+
+// matchColumns returns the start and end columns of the parts of text
+// matching any of queries, ignoring case, in order and not overlapping.
+func matchColumns(text string, queries []string) [][2]int {
+	runes := []rune(text)
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+	needles := [][]rune{}
+	for _, query := range queries {
+		needles = append(needles, []rune(strings.ToLower(query)))
+	}
+
+	matches := [][2]int{}
+	column := 0
+	for i := 0; i < len(runes); {
+		length := 0
+		for _, needle := range needles {
+			if len(needle) > length && slices.Equal(lower[i:min(i+len(needle), len(lower))], needle) {
+				length = len(needle)
+			}
+		}
+		if length == 0 {
+			column += ansi.StringWidth(string(runes[i]))
+			i++
+			continue
+		}
+		width := ansi.StringWidth(string(runes[i : i+length]))
+		matches = append(matches, [2]int{column, column + width})
+		column += width
+		i += length
+	}
+	return matches
+}
+
+// foregroundSequence returns the escape code setting the foreground color
+// of style, empty when the terminal has no colors.
+func foregroundSequence(style lipgloss.Style) string {
+	color := lipgloss.ColorProfile().FromColor(style.GetForeground())
+	if seq := color.Sequence(false); seq != "" {
+		return termenv.CSI + seq + "m"
+	}
+	return ""
 }
 
 func (m *modelTransactions) Blur() {
@@ -419,7 +907,65 @@ func (m *modelTransactions) Focus() {
 	m.focus = true
 }
 
-func getRows(transactions []firefly.Transaction) ([]table.Row, []table.Column) {
+// showCells opens the full accounts, category and description of the
+// selected row, the table cuts long ones short.
+func (m modelTransactions) showCells() tea.Cmd {
+	row := m.table.SelectedRow()
+	if row == nil {
+		return nil
+	}
+	return cellview.Open(fmt.Sprintf("Transaction #%s", row[11]), []cellview.Field{
+		{Name: "Description", Value: row[10]},
+		{Name: "Source", Value: row[3]},
+		{Name: "Destination", Value: row[4]},
+		{Name: "Category", Value: row[5]},
+	})
+}
+
+// accountName returns the name shown for an account in the table, the cash
+// account stands out from expense and revenue accounts.
+func accountName(account firefly.Account) string {
+	if isCash(account) {
+		return "(cash)"
+	}
+	return account.Name
+}
+
+// updateRows shows the filtered transactions, with the cursor on the first
+// row of trxID if set.
+func (m *modelTransactions) updateRows(trxID string) {
+	rows, columns := getRows(m.shown, m.expanded, m.styles)
+	recent := recentTransactions(m.shown, time.Now())
+	for _, row := range rows {
+		if strings.HasPrefix(row[1], " ↳") {
+			continue
+		}
+		if recent[row[11]] {
+			row[1] += recentMark
+		}
+		if m.marked[row[11]] {
+			row[1] += markedMark
+		}
+		columns[1].Width = max(columns[1].Width, lipgloss.Width(row[1]))
+	}
+	m.table.SetRows(rows)
+	m.columns = columns
+	m.arrangeColumns(m.scroll)
+
+	if trxID != "" {
+		for i, trx := range m.table.Rows() {
+			if trx[11] == trxID { // That is TxID column
+				m.table.SetCursor(i)
+				break
+			}
+		}
+	}
+}
+
+// getRows lists transactions in table rows. A transaction with several
+// splits is summarized in one row unless expanded, then each split gets its
+// own row.
+func getRows(transactions []firefly.Transaction, expanded map[string]bool, styles Styles) ([]table.Row, []table.Column) {
 	sourceWidth := 5
 	destinationWidth := 5
 	categoryWidth := 5
@@ -429,6 +975,7 @@ func getRows(transactions []firefly.Transaction) ([]table.Row, []table.Column) {
 	currencyWidth := 3
 	foreignCurrencyWidth := 4
 	transactionIDWidth := 4
+	typeWidth := 2
 
 	rows := []table.Row{}
 
@@ -438,16 +985,32 @@ func getRows(transactions []firefly.Transaction) ([]table.Row, []table.Column) {
 		Type := ""
 		switch tx.Type {
 		case "withdrawal":
-			Type = "←"
+			Type = styles.WithdrawalBadge
 		case "deposit":
-			Type = "→"
+			Type = styles.DepositBadge
 		case "transfer":
-			Type = "⇄"
+			Type = styles.TransferBadge
+		}
+
+		splits := tx.Splits
+		collapsed := len(splits) > 1 && !expanded[tx.TransactionID]
+		if collapsed {
+			splits = []firefly.Split{summarizeSplits(tx)}
 		}
 
-		for idx, split := range tx.Splits {
+		for idx, split := range splits {
 			icon := Type
-			if len(tx.Splits) > 1 && idx > 0 {
+			if isCash(split.Source) || isCash(split.Destination) {
+				// Cash withdrawals and deposits
+				icon += "$"
+			}
+			if !split.Location.IsEmpty() {
+				icon += locationMark
+			}
+			if collapsed {
+				icon = Type + "+"
+			}
+			if len(splits) > 1 && idx > 0 {
 				icon = " ↳"
 			}
 			amount := fmt.Sprintf("%.2f", split.Amount)
@@ -457,8 +1020,8 @@ func getRows(transactions []firefly.Transaction) ([]table.Row, []table.Column) {
 				fmt.Sprintf("%d", tx.ID),
 				icon,
 				date.Format("2006-01-02"),
-				split.Source.Name,
-				split.Destination.Name,
+				accountName(split.Source),
+				accountName(split.Destination),
 				split.Category.Name,
 				split.Currency,
 				amount,
@@ -466,14 +1029,19 @@ func getRows(transactions []firefly.Transaction) ([]table.Row, []table.Column) {
 				foreignAmount,
 				split.Description,
 				tx.TransactionID,
+				reconciledMark(split.Reconciled),
 			}
 			rows = append(rows, row)
 
-			sourceLen := len(split.Source.Name)
+			typeLen := lipgloss.Width(icon)
+			if typeLen > typeWidth {
+				typeWidth = typeLen
+			}
+			sourceLen := len(accountName(split.Source))
 			if sourceLen > sourceWidth {
 				sourceWidth = sourceLen
 			}
-			destinationLen := len(split.Destination.Name)
+			destinationLen := len(accountName(split.Destination))
 			if destinationLen > destinationWidth {
 				destinationWidth = destinationLen
 			}
@@ -510,7 +1078,7 @@ func getRows(transactions []firefly.Transaction) ([]table.Row, []table.Column) {
 
 	return rows, []table.Column{
 		{Title: "ID", Width: 0},
-		{Title: "Type", Width: 2},
+		{Title: "Type", Width: typeWidth},
 		{Title: "Date", Width: 10},
 		{Title: "Source", Width: sourceWidth},
 		{Title: "Destination", Width: destinationWidth},
@@ -521,7 +1089,51 @@ func getRows(transactions []firefly.Transaction) ([]table.Row, []table.Column) {
 		{Title: "Foreign Amount", Width: foreignAmountWidth},
 		{Title: "Description", Width: descriptionWidth},
 		{Title: "TxID", Width: transactionIDWidth},
+		{Title: "Rec", Width: 3},
+	}
+}
+
+func reconciledMark(reconciled bool) string {
+	if reconciled {
+		return "✓"
+	}
+	return ""
+}
+
+// summarizeSplits returns a split standing for all splits of tx. Values the
+// splits do not share are shown as "(multiple)".
+func summarizeSplits(tx firefly.Transaction) firefly.Split {
+	multipleAccount := firefly.Account{Name: "(multiple)"}
+	first := tx.Splits[0]
+	summary := firefly.Split{
+		Source:          first.Source,
+		Destination:     first.Destination,
+		Category:        first.Category,
+		Currency:        first.Currency,
+		ForeignCurrency: first.ForeignCurrency,
+		Description:     fmt.Sprintf("%s (%d splits)", tx.Description(), len(tx.Splits)),
+		Reconciled:      tx.Reconciled(),
+	}
+	for _, split := range tx.Splits {
+		if split.Source != first.Source {
+			summary.Source = multipleAccount
+		}
+		if split.Destination != first.Destination {
+			summary.Destination = multipleAccount
+		}
+		if split.Category != first.Category {
+			summary.Category = firefly.Category{Name: "(multiple)"}
+		}
+		if split.Currency != first.Currency {
+			summary.Currency = ""
+		}
+		if split.ForeignCurrency != first.ForeignCurrency {
+			summary.ForeignCurrency = ""
+		}
+		summary.Amount += split.Amount
+		summary.ForeignAmount += split.ForeignAmount
 	}
+	return summary
 }
 
 func (m *modelTransactions) GetCurrentTransaction() (firefly.Transaction, error) {
@@ -6,33 +6,85 @@ SPDX-License-Identifier: Apache-2.0
 package ui
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
 
 	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/cellview"
 	"ffiii-tui/internal/ui/notify"
 
+	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
 )
 
 type mockTransactionAPI struct {
 	listTransactionsFunc        func(query string) ([]firefly.Transaction, error)
+	getTransactionFunc          func(transactionID string) (firefly.Transaction, error)
 	deleteTransactionFunc       func(transactionID string) error
 	listTransactionsCalledWith  []string
 	deleteTransactionCalledWith []string
+	deleteTransactionCtx        context.Context
+	// pageSize streams the listed transactions in pages when set
+	pageSize int
+
+	createTransactionsFunc func(txs []firefly.RequestTransaction) firefly.BatchResult
+
+	updateTransactionFunc func(transactionID string, tx firefly.RequestTransaction) (string, error)
+	categories            []firefly.Category
+}
+
+func (m *mockTransactionAPI) UpdateTransaction(_ context.Context, transactionID string, tx firefly.RequestTransaction) (string, error) {
+	if m.updateTransactionFunc != nil {
+		return m.updateTransactionFunc(transactionID, tx)
+	}
+	return transactionID, nil
+}
+
+func (m *mockTransactionAPI) CategoriesList() []firefly.Category {
+	return m.categories
 }
 
-func (m *mockTransactionAPI) ListTransactions(query string) ([]firefly.Transaction, error) {
+func (m *mockTransactionAPI) CreateTransactions(_ context.Context, txs []firefly.RequestTransaction) firefly.BatchResult {
+	if m.createTransactionsFunc != nil {
+		return m.createTransactionsFunc(txs)
+	}
+	return firefly.BatchResult{Total: len(txs)}
+}
+
+func (m *mockTransactionAPI) StreamTransactions(_ context.Context, query string, onPage func(firefly.TransactionsPage)) ([]firefly.Transaction, error) {
 	m.listTransactionsCalledWith = append(m.listTransactionsCalledWith, query)
-	if m.listTransactionsFunc != nil {
-		return m.listTransactionsFunc(query)
+	if m.listTransactionsFunc == nil {
+		return nil, nil
+	}
+	txs, err := m.listTransactionsFunc(query)
+	if err != nil || m.pageSize == 0 {
+		return txs, err
+	}
+	for start := 0; start < len(txs); start += m.pageSize {
+		end := min(start+m.pageSize, len(txs))
+		onPage(firefly.TransactionsPage{Transactions: txs[start:end], Loaded: end, Total: len(txs)})
+	}
+	return txs, nil
+}
+
+func (m *mockTransactionAPI) GetTransaction(_ context.Context, transactionID string) (firefly.Transaction, error) {
+	if m.getTransactionFunc != nil {
+		return m.getTransactionFunc(transactionID)
 	}
-	return nil, nil
+	return firefly.Transaction{}, nil
 }
 
-func (m *mockTransactionAPI) DeleteTransaction(transactionID string) error {
+func (m *mockTransactionAPI) DeleteTransaction(ctx context.Context, transactionID string) error {
+	m.deleteTransactionCtx = ctx
 	m.deleteTransactionCalledWith = append(m.deleteTransactionCalledWith, transactionID)
 	if m.deleteTransactionFunc != nil {
 		return m.deleteTransactionFunc(transactionID)
@@ -72,9 +124,8 @@ func newFocusedTransactionModel(t *testing.T, transactions []firefly.Transaction
 
 	m := NewModelTransactions(api)
 	m.transactions = transactions
-	rows, columns := getRows(transactions)
-	m.table.SetRows(rows)
-	m.table.SetColumns(columns)
+	m.shown = transactions
+	m.updateRows("")
 	(&m).Focus()
 	return m
 }
@@ -102,34 +153,34 @@ func TestNewModelTransactions_Initializes(t *testing.T) {
 }
 
 func TestGetRows_EmptyTransactions(t *testing.T) {
-	rows, columns := getRows([]firefly.Transaction{})
+	rows, columns := getRows([]firefly.Transaction{}, nil, DefaultStyles())
 
 	if len(rows) != 0 {
 		t.Errorf("expected 0 rows, got %d", len(rows))
 	}
-	if len(columns) != 12 {
-		t.Errorf("expected 12 columns, got %d", len(columns))
+	if len(columns) != 13 {
+		t.Errorf("expected 13 columns, got %d", len(columns))
 	}
 }
 
 func TestGetRows_SingleTransaction(t *testing.T) {
 	tx := newTestTransaction(0, "tx1", "withdrawal", "2024-01-15T10:00:00Z", "Test transaction")
 
-	rows, columns := getRows([]firefly.Transaction{tx})
+	rows, columns := getRows([]firefly.Transaction{tx}, nil, DefaultStyles())
 
 	if len(rows) != 1 {
 		t.Fatalf("expected 1 row, got %d", len(rows))
 	}
-	if len(columns) != 12 {
-		t.Errorf("expected 12 columns, got %d", len(columns))
+	if len(columns) != 13 {
+		t.Errorf("expected 13 columns, got %d", len(columns))
 	}
 
 	row := rows[0]
 	if row[0] != "0" {
 		t.Errorf("expected ID '0', got %q", row[0])
 	}
-	if row[1] != "←" {
-		t.Errorf("expected withdrawal icon '←', got %q", row[1])
+	if row[1] != "WD" {
+		t.Errorf("expected withdrawal badge 'WD', got %q", row[1])
 	}
 	if row[2] != "2024-01-15" {
 		t.Errorf("expected date '2024-01-15', got %q", row[2])
@@ -144,15 +195,15 @@ func TestGetRows_TransactionTypes(t *testing.T) {
 		txType       string
 		expectedIcon string
 	}{
-		{"withdrawal", "←"},
-		{"deposit", "→"},
-		{"transfer", "⇄"},
+		{"withdrawal", "WD"},
+		{"deposit", "DEP"},
+		{"transfer", "TRF"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.txType, func(t *testing.T) {
 			tx := newTestTransaction(0, "tx1", tt.txType, "2024-01-15T10:00:00Z", "Test")
-			rows, _ := getRows([]firefly.Transaction{tx})
+			rows, _ := getRows([]firefly.Transaction{tx}, nil, DefaultStyles())
 
 			if len(rows) != 1 {
 				t.Fatalf("expected 1 row, got %d", len(rows))
@@ -164,6 +215,20 @@ func TestGetRows_TransactionTypes(t *testing.T) {
 	}
 }
 
+func TestGetRows_CashTransaction(t *testing.T) {
+	tx := newTestTransaction(0, "tx1", "withdrawal", "2024-01-15T10:00:00Z", "ATM")
+	tx.Splits[0].Destination = firefly.Account{ID: "cash1", Name: "Cash account", Type: "cash"}
+
+	rows, _ := getRows([]firefly.Transaction{tx}, nil, DefaultStyles())
+
+	if rows[0][1] != "WD$" {
+		t.Errorf("expected cash badge 'WD$', got %q", rows[0][1])
+	}
+	if rows[0][4] != "(cash)" {
+		t.Errorf("expected cash destination '(cash)', got %q", rows[0][4])
+	}
+}
+
 func TestGetRows_MultiSplitTransaction(t *testing.T) {
 	tx := firefly.Transaction{
 		ID:            0,
@@ -177,14 +242,14 @@ func TestGetRows_MultiSplitTransaction(t *testing.T) {
 		},
 	}
 
-	rows, _ := getRows([]firefly.Transaction{tx})
+	rows, _ := getRows([]firefly.Transaction{tx}, map[string]bool{"tx1": true}, DefaultStyles())
 
 	if len(rows) != 3 {
 		t.Fatalf("expected 3 rows (one per split), got %d", len(rows))
 	}
 
-	if rows[0][1] != "←" {
-		t.Errorf("expected first row icon '←', got %q", rows[0][1])
+	if rows[0][1] != "WD" {
+		t.Errorf("expected first row badge 'WD', got %q", rows[0][1])
 	}
 	if rows[1][1] != " ↳" {
 		t.Errorf("expected second row icon ' ↳', got %q", rows[1][1])
@@ -194,6 +259,31 @@ func TestGetRows_MultiSplitTransaction(t *testing.T) {
 	}
 }
 
+func TestGetRows_CollapsedSplitGroup(t *testing.T) {
+	tx := firefly.Transaction{
+		TransactionID: "tx1",
+		Type:          "withdrawal",
+		Date:          "2024-01-15T10:00:00Z",
+		GroupTitle:    "Weekly shopping",
+		Splits: []firefly.Split{
+			{Source: testAssetChecking, Destination: testExpenseGroceries, Category: testCategoryFood, Amount: 50.00, Currency: "USD"},
+			{Source: testAssetChecking, Destination: testExpenseUtilities, Category: testCategoryFood, Amount: 30.25, Currency: "USD"},
+		},
+	}
+
+	rows, _ := getRows([]firefly.Transaction{tx}, nil, DefaultStyles())
+
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 summary row, got %d", len(rows))
+	}
+	want := table.Row{"0", "WD+", "2024-01-15", "Checking", "(multiple)", "Food", "USD", "80.25", "", "0.00", "Weekly shopping (2 splits)", "tx1"}
+	for i := range want {
+		if rows[0][i] != want[i] {
+			t.Errorf("column %d: expected %q, got %q", i, want[i], rows[0][i])
+		}
+	}
+}
+
 // Message handler tests
 
 func TestRefreshTransactionsMsg_Success(t *testing.T) {
@@ -217,7 +307,7 @@ func TestRefreshTransactionsMsg_Success(t *testing.T) {
 		t.Fatal("expected a command, got nil")
 	}
 
-	msg := cmd()
+	msg := trackedMsg(cmd)
 	txUpdateMsg, ok := msg.(TransactionsUpdateMsg)
 	if !ok {
 		t.Fatalf("expected TransactionsUpdateMsg, got %T", msg)
@@ -252,7 +342,7 @@ func TestRefreshTransactionsMsg_WithSearch(t *testing.T) {
 		t.Fatal("expected a command, got nil")
 	}
 
-	_ = cmd()
+	_ = trackedMsg(cmd)
 
 	if len(api.listTransactionsCalledWith) != 1 {
 		t.Fatalf("expected ListTransactions to be called once, got %d", len(api.listTransactionsCalledWith))
@@ -280,10 +370,13 @@ func TestRefreshTransactionsMsg_Error(t *testing.T) {
 		t.Fatal("expected a command, got nil")
 	}
 
-	msg := cmd()
-	notifyMsg, ok := msg.(notify.NotifyMsg)
+	msgs := collectMsgsFromCmd(cmd)
+	if !hasMsg[DataLoadFailedMsg](msgs) {
+		t.Errorf("expected DataLoadFailedMsg, got %v", msgs)
+	}
+	notifyMsg, ok := msgs[0].(notify.NotifyMsg)
 	if !ok {
-		t.Fatalf("expected notify.NotifyMsg, got %T", msg)
+		t.Fatalf("expected notify.NotifyMsg, got %T", msgs[0])
 	}
 	if notifyMsg.Level != notify.Warn {
 		t.Errorf("expected notify level Warn, got %v", notifyMsg.Level)
@@ -293,6 +386,96 @@ func TestRefreshTransactionsMsg_Error(t *testing.T) {
 	}
 }
 
+func TestRefreshTransactionsMsg_StreamsPages(t *testing.T) {
+	var transactions []firefly.Transaction
+	for i := range 5 {
+		transactions = append(transactions,
+			newTestTransaction(uint(i), fmt.Sprintf("tx%d", i), "withdrawal", "2024-01-15T10:00:00Z", "Test"))
+	}
+	api := &mockTransactionAPI{
+		listTransactionsFunc: func(query string) ([]firefly.Transaction, error) {
+			return transactions, nil
+		},
+		pageSize: 2,
+	}
+	m := NewModelTransactions(api)
+	(&m).Focus()
+
+	_, cmd := m.Update(RefreshTransactionsMsg{TrxID: "tx3"})
+	msgs := []tea.Msg{trackedMsg(cmd)}
+	for _, loaded := range []int{2, 4} {
+		page, ok := findMsg[transactionsPageMsg](msgs)
+		if !ok {
+			t.Fatalf("expected a page with %d transactions loaded, got %v", loaded, msgs)
+		}
+		updated, cmd := m.Update(page)
+		m = updated.(modelTransactions)
+		if len(m.transactions) != loaded {
+			t.Errorf("expected %d transactions after the page, got %d", loaded, len(m.transactions))
+		}
+		if want := fmt.Sprintf("loading %d/5", loaded); !strings.Contains(ansi.Strip(m.footer()), want) {
+			t.Errorf("expected footer to show %q, got %q", want, ansi.Strip(m.footer()))
+		}
+		msgs = collectMsgsFromCmd(cmd)
+		if !hasMsg[FilterMsg](msgs) {
+			t.Error("expected the shown rows to be filtered again")
+		}
+	}
+
+	final, ok := findMsg[TransactionsUpdateMsg](msgs)
+	if !ok {
+		t.Fatalf("expected the whole list once the last page arrived, got %v", msgs)
+	}
+	if len(final.Transactions) != 5 || final.TrxID != "tx3" {
+		t.Errorf("unexpected final update %+v", final)
+	}
+	updated, _ := m.Update(final)
+	m = updated.(modelTransactions)
+	if strings.Contains(ansi.Strip(m.footer()), "loading") {
+		t.Error("expected no progress once loaded")
+	}
+}
+
+func TestTransactionsPageMsg_SupersededLoadIsDropped(t *testing.T) {
+	m := NewModelTransactions(&mockTransactionAPI{})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	updated, cmd := m.Update(transactionsPageMsg{
+		ctx: ctx,
+		page: firefly.TransactionsPage{
+			Transactions: []firefly.Transaction{newTestTransaction(0, "tx1", "withdrawal", "2024-01-15T10:00:00Z", "Stale")},
+			Loaded:       1,
+			Total:        3,
+		},
+	})
+	m = updated.(modelTransactions)
+	if cmd != nil || len(m.transactions) != 0 || m.loadTotal != 0 {
+		t.Error("expected pages of a canceled load to be ignored")
+	}
+}
+
+func TestRefreshTransactionsMsg_ErrorClearsProgress(t *testing.T) {
+	api := &mockTransactionAPI{
+		listTransactionsFunc: func(query string) ([]firefly.Transaction, error) {
+			return nil, errors.New("timeout")
+		},
+	}
+	m := NewModelTransactions(api)
+	m.loaded, m.loadTotal = 50, 500
+
+	_, cmd := m.Update(RefreshTransactionsMsg{})
+	ended, ok := findMsg[transactionsLoadEndedMsg](collectMsgsFromCmd(cmd))
+	if !ok {
+		t.Fatal("expected the failed load to end")
+	}
+	updated, _ := m.Update(ended)
+	m = updated.(modelTransactions)
+	if m.loaded != 0 || m.loadTotal != 0 {
+		t.Errorf("expected progress to be cleared, got %d/%d", m.loaded, m.loadTotal)
+	}
+}
+
 func TestTransactionsUpdateMsg_UpdatesTransactionsAndFilters(t *testing.T) {
 	transactions := []firefly.Transaction{
 		newTestTransaction(0, "tx1", "withdrawal", "2024-01-15T10:00:00Z", "Test 1"),
@@ -346,7 +529,14 @@ func TestDeleteTransactionMsg_Success(t *testing.T) {
 	if cmd == nil {
 		t.Fatal("expected a command, got nil")
 	}
+	if len(api.deleteTransactionCalledWith) != 0 {
+		t.Error("expected the deletion to wait for the command")
+	}
 
+	deleted, ok := findMsg[transactionDeletedMsg](collectMsgsFromCmd(cmd))
+	if !ok {
+		t.Fatal("expected the transaction to be deleted")
+	}
 	if len(api.deleteTransactionCalledWith) != 1 {
 		t.Fatalf("expected DeleteTransaction to be called once, got %d", len(api.deleteTransactionCalledWith))
 	}
@@ -354,7 +544,8 @@ func TestDeleteTransactionMsg_Success(t *testing.T) {
 		t.Errorf("expected transaction ID 'tx-to-delete', got %q", api.deleteTransactionCalledWith[0])
 	}
 
-	msgs := collectMsgsFromCmd(cmd)
+	_, cmd = m.Update(deleted)
+	msgs := dispatched(collectMsgsFromCmd(cmd))
 	foundRefreshTransactions := false
 	foundRefreshSummary := false
 	for _, msg := range msgs {
@@ -405,6 +596,39 @@ func TestDeleteTransactionMsg_Error(t *testing.T) {
 	}
 }
 
+func TestDeleteTransactionMsg_Tracked(t *testing.T) {
+	tx := newTestTransaction(0, "tx-to-delete", "withdrawal", "2024-01-15T10:00:00Z", "Test")
+	m := NewModelTransactions(&mockTransactionAPI{})
+
+	_, cmd := m.Update(DeleteTransactionMsg{Transaction: tx})
+
+	if _, ok := trackedMsg(cmd).(transactionDeletedMsg); !ok {
+		t.Error("expected the deletion shown by the loading indicator")
+	}
+}
+
+func TestDeleteTransactionMsg_OutlivesListRequests(t *testing.T) {
+	tx := newTestTransaction(0, "tx-to-delete", "withdrawal", "2024-01-15T10:00:00Z", "Test")
+	api := &mockTransactionAPI{}
+	m := NewModelTransactions(api)
+	m.deletedFile = filepath.Join(t.TempDir(), "default.deleted.jsonl")
+
+	_, cmd := m.Update(DeleteTransactionMsg{Transaction: tx})
+	// A search started right after confirming renews the list's requests
+	m.requests.Renew()
+	msg := trackedMsg(cmd)
+
+	if err := api.deleteTransactionCtx.Err(); err != nil {
+		t.Errorf("expected the deletion not canceled with the list's requests, got %v", err)
+	}
+	if _, ok := msg.(transactionDeletedMsg); !ok {
+		t.Errorf("expected the transaction deleted, got %#v", msg)
+	}
+	if entries, _ := loadDeleted(m.deletedFile); len(entries) != 1 {
+		t.Errorf("expected the deletion logged, got %+v", entries)
+	}
+}
+
 // FilterMsg tests
 
 func TestFilterMsg_ByAccount(t *testing.T) {
@@ -521,6 +745,23 @@ func TestFilterMsg_ByQuery(t *testing.T) {
 	}
 }
 
+func TestFilterMsg_ByReference(t *testing.T) {
+	invoice := newTestTransaction(0, "tx1", "withdrawal", "2024-01-15T10:00:00Z", "Web hosting")
+	invoice.Splits[0].InternalReference = "INV-2024-017"
+	payment := newTestTransaction(1, "tx2", "withdrawal", "2024-01-16T10:00:00Z", "Domain")
+	payment.Splits[0].ExternalID = "PAY-88311"
+
+	m := newFocusedTransactionModel(t, []firefly.Transaction{invoice, payment})
+
+	for query, want := range map[string]string{"inv-2024": "tx1", "PAY-883": "tx2"} {
+		updated, _ := m.Update(FilterMsg{Query: query})
+		rows := updated.(modelTransactions).table.Rows()
+		if len(rows) != 1 || rows[0][11] != want {
+			t.Errorf("filter %q: expected only %s, got %v", query, want, rows)
+		}
+	}
+}
+
 func TestFilterMsg_Reset(t *testing.T) {
 	srcAccount := firefly.Account{ID: "src1", Name: "Source"}
 	catGroceries := firefly.Category{ID: "cat1", Name: "Groceries"}
@@ -533,6 +774,9 @@ func TestFilterMsg_Reset(t *testing.T) {
 	m.currentAccount = srcAccount
 	m.currentCategory = catGroceries
 	m.currentFilter = "test"
+	m.unreconciledOnly = true
+	m.typeFilter = "transfer"
+	m.dates = dateRange{from: "2024-01-01"}
 
 	updated, _ := m.Update(FilterMsg{Reset: true})
 	m2 := updated.(modelTransactions)
@@ -546,6 +790,128 @@ func TestFilterMsg_Reset(t *testing.T) {
 	if m2.currentFilter != "" {
 		t.Errorf("expected currentFilter to be empty after reset, got %q", m2.currentFilter)
 	}
+	if m2.unreconciledOnly {
+		t.Error("expected unreconciled filter to be off after reset")
+	}
+	if m2.typeFilter != "" {
+		t.Errorf("expected type filter to be off after reset, got %q", m2.typeFilter)
+	}
+	if !m2.dates.isEmpty() {
+		t.Errorf("expected date range to be off after reset, got %s", m2.dates)
+	}
+}
+
+func TestTransactions_TypeFilter(t *testing.T) {
+	groceries := newTestTransaction(0, "tx1", "withdrawal", "2024-01-15T10:00:00Z", "Groceries")
+	groceries.Splits[0].Category = firefly.Category{ID: "c1", Name: "Food"}
+	salary := newTestTransaction(1, "tx2", "deposit", "2024-01-16T10:00:00Z", "Salary")
+	saving := newTestTransaction(2, "tx3", "transfer", "2024-01-17T10:00:00Z", "Saving")
+	rent := newTestTransaction(3, "tx4", "withdrawal", "2024-01-18T10:00:00Z", "Rent")
+
+	m := newFocusedTransactionModel(t, []firefly.Transaction{groceries, salary, saving, rent})
+
+	press := func() []string {
+		t.Helper()
+		updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'T'}})
+		m = updated.(modelTransactions)
+		filter, ok := findMsg[FilterMsg](collectMsgsFromCmd(cmd))
+		if !ok {
+			t.Fatal("expected the list to be filtered again")
+		}
+		updated, _ = m.Update(filter)
+		m = updated.(modelTransactions)
+		ids := []string{}
+		for _, row := range m.table.Rows() {
+			ids = append(ids, row[11])
+		}
+		return ids
+	}
+
+	tests := []struct {
+		typeFilter string
+		want       []string
+	}{
+		{"withdrawal", []string{"tx1", "tx4"}},
+		{"deposit", []string{"tx2"}},
+		{"transfer", []string{"tx3"}},
+		{"", []string{"tx1", "tx2", "tx3", "tx4"}},
+	}
+	for _, tt := range tests {
+		got := press()
+		if m.typeFilter != tt.typeFilter {
+			t.Fatalf("expected type filter %q, got %q", tt.typeFilter, m.typeFilter)
+		}
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("type filter %q: expected %v, got %v", tt.typeFilter, tt.want, got)
+		}
+	}
+
+	// Combined with the category filter
+	updated, _ := m.Update(FilterMsg{Category: firefly.Category{ID: "c1", Name: "Food"}})
+	m = updated.(modelTransactions)
+	if got := press(); strings.Join(got, ",") != "tx1" {
+		t.Errorf("expected the withdrawals of the category, got %v", got)
+	}
+}
+
+func TestTransactions_UnreconciledFilter(t *testing.T) {
+	reconciled := newTestTransaction(0, "tx1", "withdrawal", "2024-01-15T10:00:00Z", "Matched")
+	reconciled.Splits[0].Reconciled = true
+	partly := firefly.Transaction{
+		TransactionID: "tx2",
+		Type:          "withdrawal",
+		Date:          "2024-01-16T10:00:00Z",
+		Splits: []firefly.Split{
+			{Description: "Split 1", Reconciled: true},
+			{Description: "Split 2"},
+		},
+	}
+	open := newTestTransaction(2, "tx3", "deposit", "2024-01-17T10:00:00Z", "Open")
+
+	m := newFocusedTransactionModel(t, []firefly.Transaction{reconciled, partly, open})
+	rows := m.table.Rows()
+	if rows[0][12] != "✓" || rows[1][12] != "" || rows[2][12] != "" {
+		t.Errorf("expected only the reconciled transaction marked, got %q %q %q", rows[0][12], rows[1][12], rows[2][12])
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	m = updated.(modelTransactions)
+	if !m.unreconciledOnly {
+		t.Fatal("expected unreconciled filter on")
+	}
+	filter, ok := findMsg[FilterMsg](collectMsgsFromCmd(cmd))
+	if !ok {
+		t.Fatal("expected the list to be filtered again")
+	}
+	updated, _ = m.Update(filter)
+	m = updated.(modelTransactions)
+	rows = m.table.Rows()
+	if len(rows) != 2 || rows[0][11] != "tx2" || rows[1][11] != "tx3" {
+		t.Errorf("expected the unreconciled transactions, got %v", rows)
+	}
+}
+
+func TestGetRows_ReconciledSplits(t *testing.T) {
+	tx := firefly.Transaction{
+		TransactionID: "tx1",
+		Type:          "withdrawal",
+		Date:          "2024-01-15T10:00:00Z",
+		Splits: []firefly.Split{
+			{Description: "Split 1", Reconciled: true},
+			{Description: "Split 2"},
+		},
+	}
+
+	rows, _ := getRows([]firefly.Transaction{tx}, map[string]bool{"tx1": true}, DefaultStyles())
+	if rows[0][12] != "✓" || rows[1][12] != "" {
+		t.Errorf("expected the mark per split, got %q %q", rows[0][12], rows[1][12])
+	}
+
+	tx.Splits[1].Reconciled = true
+	rows, _ = getRows([]firefly.Transaction{tx}, nil, DefaultStyles())
+	if rows[0][12] != "✓" {
+		t.Errorf("expected the summary marked once all splits are, got %q", rows[0][12])
+	}
 }
 
 func TestFilterMsg_ComplexFiltering(t *testing.T) {
@@ -648,6 +1014,53 @@ func TestSearchMsg_ClearSearch(t *testing.T) {
 	}
 }
 
+func TestSearchMsg_RestoresAllFilters(t *testing.T) {
+	m := NewModelTransactions(&mockTransactionAPI{})
+	(&m).Focus()
+	m.currentAccount = firefly.Account{ID: "a1", Name: "Checking"}
+	m.currentCategory = firefly.Category{ID: "c1", Name: "Food"}
+	m.currentFilter = "coffee"
+	m.currentTag = "trip"
+	m.dates = dateRange{from: "2024-01-05", to: "2024-01-10"}
+	m.typeFilter = "withdrawal"
+	m.unreconciledOnly = true
+	m.uncategorized = uncategorizedCategory
+	m.syncFilterOrder()
+	before := m.filters()
+
+	updated, _ := m.Update(SearchMsg{Query: "rent"})
+	m = updated.(modelTransactions)
+	if !reflect.DeepEqual(m.filters(), transactionFilters{}) {
+		t.Errorf("expected the search without filters, got %+v", m.filters())
+	}
+	updated, _ = m.Update(SearchMsg{Query: "rent 2024"})
+	m = updated.(modelTransactions)
+
+	updated, _ = m.Update(SearchMsg{Query: "None"})
+	m = updated.(modelTransactions)
+	if !reflect.DeepEqual(m.filters(), before) {
+		t.Errorf("expected the filters back after the search, got %+v, want %+v", m.filters(), before)
+	}
+}
+
+func TestSearchMsg_FiltersBelongToTheModel(t *testing.T) {
+	a := NewModelTransactions(&mockTransactionAPI{})
+	a.currentTag = "trip"
+	b := NewModelTransactions(&mockTransactionAPI{})
+	b.currentTag = "car"
+
+	updated, _ := a.Update(SearchMsg{Query: "rent"})
+	a = updated.(modelTransactions)
+	updated, _ = b.Update(SearchMsg{Query: "fuel"})
+	b = updated.(modelTransactions)
+
+	updated, _ = a.Update(SearchMsg{Query: "None"})
+	a = updated.(modelTransactions)
+	if a.currentTag != "trip" {
+		t.Errorf("expected the tag of the model restored, got %q", a.currentTag)
+	}
+}
+
 func TestSearchMsg_ClearSearchNoOp(t *testing.T) {
 	api := &mockTransactionAPI{}
 	m := NewModelTransactions(api)
@@ -794,7 +1207,7 @@ func TestGetRows_CalculatesColumnWidths(t *testing.T) {
 		},
 	}
 
-	_, columns := getRows([]firefly.Transaction{tx})
+	_, columns := getRows([]firefly.Transaction{tx}, nil, DefaultStyles())
 
 	sourceCol := columns[3]
 	if sourceCol.Width < len("Very Long Source Account Name Here") {
@@ -812,6 +1225,36 @@ func TestGetRows_CalculatesColumnWidths(t *testing.T) {
 	}
 }
 
+func TestTransactions_ShowCells(t *testing.T) {
+	desc := strings.Repeat("long ", 20)
+	m := newFocusedTransactionModel(t, []firefly.Transaction{
+		newTestTransaction(1, "tx1", "withdrawal", "2024-01-15T10:00:00Z", desc),
+	})
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	if cmd == nil {
+		t.Fatal("expected the full values opened")
+	}
+	open, ok := cmd().(cellview.OpenMsg)
+	if !ok {
+		t.Fatalf("expected cellview.OpenMsg, got %T", cmd())
+	}
+	want := []cellview.Field{
+		{Name: "Description", Value: desc},
+		{Name: "Source", Value: "Source Account"},
+		{Name: "Destination", Value: "Destination Account"},
+		{Name: "Category", Value: "Groceries"},
+	}
+	if open.Title != "Transaction #tx1" || !slices.Equal(open.Fields, want) {
+		t.Errorf("expected the cells of the selected row, got %+v", open)
+	}
+
+	empty := newFocusedTransactionModel(t, []firefly.Transaction{})
+	if _, cmd := empty.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}}); cmd != nil {
+		t.Error("expected nothing to open without a row")
+	}
+}
+
 func TestGetRows_HandlesMultipleTransactions(t *testing.T) {
 	transactions := []firefly.Transaction{
 		newTestTransaction(0, "tx1", "withdrawal", "2024-01-15T10:00:00Z", "Test 1"),
@@ -819,7 +1262,7 @@ func TestGetRows_HandlesMultipleTransactions(t *testing.T) {
 		newTestTransaction(2, "tx3", "transfer", "2024-01-17T10:00:00Z", "Test 3"),
 	}
 
-	rows, _ := getRows(transactions)
+	rows, _ := getRows(transactions, nil, DefaultStyles())
 
 	if len(rows) != 3 {
 		t.Errorf("expected 3 rows, got %d", len(rows))
@@ -836,6 +1279,183 @@ func TestGetRows_HandlesMultipleTransactions(t *testing.T) {
 	}
 }
 
+func TestTransactions_ToggleSplits(t *testing.T) {
+	group := firefly.Transaction{
+		TransactionID: "tx2",
+		Type:          "withdrawal",
+		Date:          "2024-01-16T10:00:00Z",
+		Splits: []firefly.Split{
+			{Description: "Split 1", Amount: 50.00},
+			{Description: "Split 2", Amount: 30.00},
+		},
+	}
+	m := newFocusedTransactionModel(t, []firefly.Transaction{
+		newTestTransaction(1, "tx1", "withdrawal", "2024-01-15T10:00:00Z", "Single"),
+		group,
+	})
+	m.table.SetCursor(1)
+
+	toggle := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}}
+	updated, _ := m.Update(toggle)
+	m = updated.(modelTransactions)
+	if len(m.table.Rows()) != 3 {
+		t.Fatalf("expected the group expanded to 3 rows, got %d", len(m.table.Rows()))
+	}
+	if m.table.Cursor() != 1 {
+		t.Errorf("expected the cursor on the first split, got %d", m.table.Cursor())
+	}
+
+	// Collapsing works from any split of the group
+	m.table.SetCursor(2)
+	updated, _ = m.Update(toggle)
+	m = updated.(modelTransactions)
+	if len(m.table.Rows()) != 2 || m.table.Cursor() != 1 {
+		t.Errorf("expected the group collapsed with the cursor on it, got %d rows, cursor %d", len(m.table.Rows()), m.table.Cursor())
+	}
+
+	// Single split transactions have nothing to expand
+	m.table.SetCursor(0)
+	updated, _ = m.Update(toggle)
+	m = updated.(modelTransactions)
+	if len(m.table.Rows()) != 2 {
+		t.Errorf("expected rows unchanged, got %d", len(m.table.Rows()))
+	}
+}
+
+func TestTransactions_ColoredAmounts(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	defer lipgloss.SetColorProfile(profile)
+
+	withdrawal := newTestTransaction(1, "tx1", "withdrawal", "2024-01-15T10:00:00Z", "Bread")
+	deposit := newTestTransaction(2, "tx2", "deposit", "2024-01-16T10:00:00Z", "Salary")
+	deposit.Splits[0].Amount = 2500
+	m := newFocusedTransactionModel(t, []firefly.Transaction{withdrawal, deposit})
+	m.table.SetHeight(10)
+	m.table.SetCursor(1)
+
+	view := m.View()
+	if got, want := ansi.Strip(view), ansi.Strip(m.table.View()+"\n"+m.footer()); got != want {
+		t.Fatalf("expected the layout of the table, got\n%s\nwant\n%s", got, want)
+	}
+
+	red := foregroundSequence(m.styles.Withdrawal)
+	green := foregroundSequence(m.styles.Deposit)
+	if red == "" || green == "" {
+		t.Fatal("expected color sequences")
+	}
+	var withdrawalLine, depositLine string
+	for _, line := range strings.Split(view, "\n") {
+		plain := ansi.Strip(line)
+		switch {
+		case strings.Contains(plain, "Bread"):
+			withdrawalLine = line
+		case strings.Contains(plain, "Salary"):
+			depositLine = line
+		}
+	}
+	if !strings.Contains(withdrawalLine, red+" WD ") || !strings.Contains(withdrawalLine, red+" 100.00 ") {
+		t.Errorf("expected withdrawal badge and amount in the withdrawal color, got %q", withdrawalLine)
+	}
+	if strings.Contains(withdrawalLine, red+" Source Account") {
+		t.Errorf("expected other columns uncolored, got %q", withdrawalLine)
+	}
+	// The cursor row is colored by the table after the type color
+	if !strings.Contains(depositLine, green) {
+		t.Errorf("expected deposit colors on the cursor row, got %q", depositLine)
+	}
+}
+
+func TestTransactions_HighlightsMatches(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	defer lipgloss.SetColorProfile(profile)
+
+	bread := newTestTransaction(1, "tx1", "withdrawal", "2024-01-15T10:00:00Z", "Bread and butter")
+	bread.Splits[0].Destination = firefly.Account{ID: "d1", Name: "Bakery"}
+	rent := newTestTransaction(2, "tx2", "withdrawal", "2024-01-16T10:00:00Z", "Rent")
+	m := newFocusedTransactionModel(t, []firefly.Transaction{bread, rent})
+	m.table.SetHeight(10)
+	m.table.SetCursor(1)
+	m.currentFilter = "BREAD"
+
+	view := m.View()
+	if got, want := ansi.Strip(view), ansi.Strip(m.table.View()+"\n"+m.footer()); got != want {
+		t.Fatalf("expected the layout of the table, got\n%s\nwant\n%s", got, want)
+	}
+	highlight := foregroundSequence(m.styles.SearchMatch) + underline
+	if !strings.Contains(view, highlight+"Bread"+noUnderline) {
+		t.Errorf("expected the match in the description highlighted, got %q", view)
+	}
+	if strings.Contains(view, highlight+"Bakery") {
+		t.Errorf("expected only matches highlighted, got %q", view)
+	}
+
+	m.currentFilter = ""
+	m.currentSearch = "description_contains:bread"
+	if view := m.View(); strings.Contains(view, underline) {
+		t.Errorf("expected searches with operators not highlighted, got %q", view)
+	}
+}
+
+func TestTransactions_Footer(t *testing.T) {
+	salary := newTestTransaction(0, "tx1", "deposit", "2024-01-15T10:00:00Z", "Salary")
+	salary.Splits[0].Amount = 2500
+	rent := newTestTransaction(1, "tx2", "withdrawal", "2024-01-16T10:00:00Z", "Rent")
+	rent.Splits[0].Amount = 900.5
+	saving := newTestTransaction(2, "tx3", "transfer", "2024-01-17T10:00:00Z", "Saving")
+	trip := newTestTransaction(3, "tx4", "withdrawal", "2024-01-18T10:00:00Z", "Trip")
+	trip.Splits[0].Currency = "EUR"
+	trip.Splits[0].Amount = 40
+
+	m := newFocusedTransactionModel(t, []firefly.Transaction{salary, rent, saving, trip})
+	if got, want := ansi.Strip(m.footer()), " 4 transactions | EUR -40.00 | USD 1599.50"; got != want {
+		t.Errorf("expected footer %q, got %q", want, got)
+	}
+	if !strings.HasSuffix(ansi.Strip(m.View()), "USD 1599.50") {
+		t.Error("expected the footer under the table")
+	}
+
+	updated, _ := m.Update(FilterMsg{Query: "rent"})
+	m = updated.(modelTransactions)
+	if got, want := ansi.Strip(m.footer()), " 1 transaction | USD -900.50"; got != want {
+		t.Errorf("expected the footer to follow the filter, got %q", got)
+	}
+}
+
+func TestMatchColumns(t *testing.T) {
+	tests := []struct {
+		text    string
+		queries []string
+		want    [][2]int
+	}{
+		{"Bread and bread", []string{"bread"}, [][2]int{{0, 5}, {10, 15}}},
+		{"Café crème", []string{"CRÈME"}, [][2]int{{5, 10}}},
+		{"寿司 sushi", []string{"sushi"}, [][2]int{{5, 10}}},
+		{"Groceries", []string{"gro", "groceries"}, [][2]int{{0, 9}}},
+		{"Rent", []string{"bread"}, [][2]int{}},
+	}
+	for _, tt := range tests {
+		got := matchColumns(tt.text, tt.queries)
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("matchColumns(%q, %v) = %v, want %v", tt.text, tt.queries, got, tt.want)
+		}
+	}
+}
+
+func TestTransactions_NoColors(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.Ascii)
+	defer lipgloss.SetColorProfile(profile)
+
+	m := newFocusedTransactionModel(t, []firefly.Transaction{
+		newTestTransaction(1, "tx1", "withdrawal", "2024-01-15T10:00:00Z", "Bread"),
+	})
+	if m.View() != m.table.View()+"\n"+m.footer() {
+		t.Error("expected the table unchanged without colors")
+	}
+}
+
 func TestFilterMsg_CaseInsensitiveSearch(t *testing.T) {
 	transactions := []firefly.Transaction{
 		{
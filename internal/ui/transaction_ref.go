@@ -0,0 +1,43 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// parseTransactionRef returns the transaction ID in value, either the ID
+// itself ("#123" or "123") or a Firefly III web URL such as
+// https://firefly.example.com/transactions/show/123.
+func parseTransactionRef(value string) (string, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "#")
+	if isTransactionID(value) {
+		return value, nil
+	}
+
+	u, err := url.Parse(value)
+	if err == nil && u.Host != "" {
+		segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+		for i, segment := range segments {
+			if segment != "transactions" {
+				continue
+			}
+			for _, next := range segments[i+1:] {
+				if isTransactionID(next) {
+					return next, nil
+				}
+			}
+		}
+	}
+	return "", fmt.Errorf("not a transaction ID or URL: %s", value)
+}
+
+func isTransactionID(value string) bool {
+	id, err := strconv.ParseUint(value, 10, 64)
+	return err == nil && id > 0
+}
@@ -0,0 +1,94 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"errors"
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/prompt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestParseTransactionRef(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"123", "123", false},
+		{" #42 ", "42", false},
+		{"https://firefly.example.com/transactions/show/123", "123", false},
+		{"https://firefly.example.com/transactions/edit/7?foo=bar", "7", false},
+		{"http://localhost:8080/ff/transactions/show/9#split-2", "9", false},
+		{"https://firefly.example.com/accounts/show/123", "", true},
+		{"firefly.example.com/transactions/show/123", "", true},
+		{"0", "", true},
+		{"abc", "", true},
+	}
+	for _, tt := range tests {
+		got, err := parseTransactionRef(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseTransactionRef(%q) = %q, %v, want %q, error %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestTransactions_OpenTransaction(t *testing.T) {
+	old := newTestTransaction(0, "77", "withdrawal", "2023-05-01T10:00:00Z", "Old purchase")
+	api := &mockTransactionAPI{
+		getTransactionFunc: func(transactionID string) (firefly.Transaction, error) {
+			if transactionID != "77" {
+				return firefly.Transaction{}, errors.New("not found")
+			}
+			return old, nil
+		},
+	}
+	m := NewModelTransactions(api)
+
+	_, cmd := m.Update(OpenTransactionMsg{TransactionID: "77"})
+	fetched, ok := findMsg[transactionFetchedMsg](collectMsgsFromCmd(cmd))
+	if !ok {
+		t.Fatal("expected the transaction to be fetched")
+	}
+	_, cmd = m.Update(fetched)
+	msgs := collectMsgsFromCmd(cmd)
+	edit, ok := findMsg[EditTransactionMsg](msgs)
+	if !ok || edit.Transaction.TransactionID != "77" {
+		t.Errorf("expected the fetched transaction in the form, got %+v", edit)
+	}
+	if view, ok := findMsg[SetFocusedViewMsg](msgs); !ok || view.state != newView {
+		t.Errorf("expected the form view, got %+v", view)
+	}
+
+	_, cmd = m.Update(OpenTransactionMsg{TransactionID: "78"})
+	if _, ok := findMsg[transactionFetchedMsg](collectMsgsFromCmd(cmd)); ok {
+		t.Error("expected no transaction for an unknown ID")
+	}
+}
+
+func TestTransactions_OpenKeyAsks(t *testing.T) {
+	m := newFocusedTransactionModel(t, nil)
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'J'}})
+	if cmd == nil {
+		t.Fatal("expected a prompt")
+	}
+	p, ok := cmd().(prompt.PromptMsg)
+	if !ok {
+		t.Fatal("expected a prompt message")
+	}
+
+	msgs := collectMsgsFromCmd(p.Callback("https://firefly.example.com/transactions/show/77"))
+	if open, ok := findMsg[OpenTransactionMsg](msgs); !ok || open.TransactionID != "77" {
+		t.Errorf("expected transaction 77 to be opened, got %+v", open)
+	}
+
+	msgs = collectMsgsFromCmd(p.Callback("not an id"))
+	if _, ok := findMsg[OpenTransactionMsg](msgs); ok {
+		t.Error("expected nothing opened for an invalid reference")
+	}
+}
@@ -6,15 +6,20 @@ SPDX-License-Identifier: Apache-2.0
 package ui
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/notify"
+	"ffiii-tui/internal/ui/prompt"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/huh"
+	"github.com/spf13/viper"
 )
 
 // mockTransactionFormAPI implements all methods from AccountsAPI, CategoriesAPI, and TransactionWriteAPI
@@ -49,7 +54,7 @@ type mockTransactionFormAPI struct {
 }
 
 // AccountsAPI methods
-func (m *mockTransactionFormAPI) UpdateAccounts(accountType string) error {
+func (m *mockTransactionFormAPI) UpdateAccounts(_ context.Context, accountType string) error {
 	m.updateAccountsCalledWith = append(m.updateAccountsCalledWith, accountType)
 	if m.updateAccountsFunc != nil {
 		return m.updateAccountsFunc(accountType)
@@ -72,7 +77,7 @@ func (m *mockTransactionFormAPI) AccountBalance(accountID string) float64 {
 }
 
 // CategoriesAPI methods
-func (m *mockTransactionFormAPI) UpdateCategories() error {
+func (m *mockTransactionFormAPI) UpdateCategories(_ context.Context) error {
 	m.updateCategoriesCalledCount++
 	if m.updateCategoriesFunc != nil {
 		return m.updateCategoriesFunc()
@@ -80,7 +85,7 @@ func (m *mockTransactionFormAPI) UpdateCategories() error {
 	return nil
 }
 
-func (m *mockTransactionFormAPI) UpdateCategoriesInsights() error {
+func (m *mockTransactionFormAPI) UpdateCategoriesInsights(_ context.Context) error {
 	if m.updateCategoriesInsightsFunc != nil {
 		return m.updateCategoriesInsightsFunc()
 	}
@@ -101,6 +106,10 @@ func (m *mockTransactionFormAPI) GetTotalSpentEarnedCategories() (spent, earned
 	return 0, 0
 }
 
+func (m *mockTransactionFormAPI) CategoryTotals() (spent, earned map[string]float64) {
+	return nil, nil
+}
+
 func (m *mockTransactionFormAPI) CategorySpent(categoryID string) float64 {
 	if m.categorySpentFunc != nil {
 		return m.categorySpentFunc(categoryID)
@@ -115,7 +124,7 @@ func (m *mockTransactionFormAPI) CategoryEarned(categoryID string) float64 {
 	return 0
 }
 
-func (m *mockTransactionFormAPI) CreateCategory(name, notes string) error {
+func (m *mockTransactionFormAPI) CreateCategory(_ context.Context, name, notes string) error {
 	m.createCategoryCalledWith = append(m.createCategoryCalledWith, struct {
 		name, notes string
 	}{name: name, notes: notes})
@@ -126,7 +135,7 @@ func (m *mockTransactionFormAPI) CreateCategory(name, notes string) error {
 }
 
 // TransactionWriteAPI methods
-func (m *mockTransactionFormAPI) CreateTransaction(tx firefly.RequestTransaction) (string, error) {
+func (m *mockTransactionFormAPI) CreateTransaction(_ context.Context, tx firefly.RequestTransaction) (string, error) {
 	m.createTransactionCalls = append(m.createTransactionCalls, tx)
 	if m.createTransactionFunc != nil {
 		return m.createTransactionFunc(tx)
@@ -134,7 +143,7 @@ func (m *mockTransactionFormAPI) CreateTransaction(tx firefly.RequestTransaction
 	return "", nil
 }
 
-func (m *mockTransactionFormAPI) UpdateTransaction(transactionID string, tx firefly.RequestTransaction) (string, error) {
+func (m *mockTransactionFormAPI) UpdateTransaction(_ context.Context, transactionID string, tx firefly.RequestTransaction) (string, error) {
 	m.updateTransactionCalls = append(m.updateTransactionCalls, struct {
 		id string
 		tx firefly.RequestTransaction
@@ -562,10 +571,6 @@ func TestTransaction_DeleteSplitMsg(t *testing.T) {
 // Part 3: Key binding and transaction operation tests
 
 func TestTransaction_KeyBindings(t *testing.T) {
-	// Save and restore fullNewForm global
-	origFullNewForm := fullNewForm
-	defer func() { fullNewForm = origFullNewForm }()
-
 	t.Run("Cancel returns SetView(transactionsView)", func(t *testing.T) {
 		m := newTestTransactionModel()
 		m.Focus()
@@ -627,34 +632,24 @@ func TestTransaction_KeyBindings(t *testing.T) {
 	})
 
 	t.Run("Refresh increments all 3 counters and returns RedrawForm", func(t *testing.T) {
-		// Save and restore global counters
-		origCategory := triggerCategoryCounter
-		origSource := triggerSourceCounter
-		origDest := triggerDestinationCounter
-		defer func() {
-			triggerCategoryCounter = origCategory
-			triggerSourceCounter = origSource
-			triggerDestinationCounter = origDest
-		}()
-
 		m := newTestTransactionModel()
 		m.Focus()
 
-		initialCategory := triggerCategoryCounter
-		initialSource := triggerSourceCounter
-		initialDest := triggerDestinationCounter
+		initialCategory := m.groups.categoryTrigger
+		initialSource := m.groups.sourceTrigger
+		initialDest := m.groups.destinationTrigger
 
 		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
 
 		// Verify all counters incremented
-		if triggerCategoryCounter != initialCategory+1 {
-			t.Errorf("expected category counter to be %d, got %d", initialCategory+1, triggerCategoryCounter)
+		if m.groups.categoryTrigger != initialCategory+1 {
+			t.Errorf("expected category counter to be %d, got %d", initialCategory+1, m.groups.categoryTrigger)
 		}
-		if triggerSourceCounter != initialSource+1 {
-			t.Errorf("expected source counter to be %d, got %d", initialSource+1, triggerSourceCounter)
+		if m.groups.sourceTrigger != initialSource+1 {
+			t.Errorf("expected source counter to be %d, got %d", initialSource+1, m.groups.sourceTrigger)
 		}
-		if triggerDestinationCounter != initialDest+1 {
-			t.Errorf("expected destination counter to be %d, got %d", initialDest+1, triggerDestinationCounter)
+		if m.groups.destinationTrigger != initialDest+1 {
+			t.Errorf("expected destination counter to be %d, got %d", initialDest+1, m.groups.destinationTrigger)
 		}
 
 		// Verify RedrawForm was returned
@@ -707,17 +702,17 @@ func TestTransaction_KeyBindings(t *testing.T) {
 		}
 	})
 
-	t.Run("ChangeLayout toggles fullNewForm and returns RedrawForm", func(t *testing.T) {
+	t.Run("ChangeLayout toggles the full layout and returns RedrawForm", func(t *testing.T) {
 		m := newTestTransactionModel()
 		m.Focus()
 
-		initialFullNewForm := fullNewForm
-
-		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlF})
+		updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlF})
 
-		// Verify global was toggled
-		if fullNewForm == initialFullNewForm {
-			t.Error("expected fullNewForm to be toggled")
+		if !updated.(modelTransaction).fullLayout {
+			t.Error("expected the full layout to be toggled on")
+		}
+		if m.fullLayout {
+			t.Error("expected the layout of other forms left alone")
 		}
 
 		// Verify RedrawForm was returned
@@ -824,6 +819,151 @@ func TestTransaction_KeyBindings(t *testing.T) {
 	})
 }
 
+func newCompletedTransactionModel(api *mockTransactionFormAPI) modelTransaction {
+	m := newModelTransaction(api)
+	m.Focus()
+	m.new = true
+	m.created = true
+	m.splits = []*split{
+		{
+			source:      testAssetChecking,
+			destination: testExpenseGroceries,
+			amount:      "50.00",
+			description: "Test",
+		},
+	}
+	m.attr.year = "2026"
+	m.attr.month = "01"
+	m.attr.day = "15"
+	m.attr.transactionType = "withdrawal"
+	m.UpdateForm()
+	m.form.State = huh.StateCompleted
+	return m
+}
+
+func TestTransaction_DoubleSubmitCreatesOnce(t *testing.T) {
+	api := &mockTransactionFormAPI{
+		createTransactionFunc: func(tx firefly.RequestTransaction) (string, error) {
+			return "42", nil
+		},
+	}
+	m := newCompletedTransactionModel(api)
+
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	m = model.(modelTransaction)
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+
+	if len(api.createTransactionCalls) != 1 {
+		t.Errorf("expected CreateTransaction to be called once, got %d calls", len(api.createTransactionCalls))
+	}
+	msg, ok := findMsg[notify.NotifyMsg](collectMsgsFromCmd(cmd))
+	if !ok || msg.Message != "Transaction already submitted" {
+		t.Errorf("expected an already submitted notice, got %v", msg)
+	}
+
+	// Another transaction in the form can be submitted
+	m.SetTransaction(firefly.Transaction{}, true)
+	m.created = true
+	m.splits[0].source = testAssetChecking
+	m.splits[0].destination = testExpenseGroceries
+	m.splits[0].amount = "5"
+	m.attr.transactionType = "withdrawal"
+	m.UpdateForm()
+	m.form.State = huh.StateCompleted
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if len(api.createTransactionCalls) != 2 {
+		t.Errorf("expected a submit of the next transaction, got %d calls", len(api.createTransactionCalls))
+	}
+}
+
+func TestTransaction_RedrawAfterSubmitKeepsGuard(t *testing.T) {
+	api := &mockTransactionFormAPI{
+		createTransactionFunc: func(tx firefly.RequestTransaction) (string, error) {
+			return "42", nil
+		},
+	}
+	m := newCompletedTransactionModel(api)
+
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	m = model.(modelTransaction)
+	// A redraw queued before the submit lands in between
+	model, _ = m.Update(RedrawFormMsg{})
+	m = model.(modelTransaction)
+	m.form.State = huh.StateCompleted
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+
+	if len(api.createTransactionCalls) != 1 {
+		t.Errorf("expected the redraw not to allow a second submit, got %d calls", len(api.createTransactionCalls))
+	}
+	if msg, ok := findMsg[notify.NotifyMsg](collectMsgsFromCmd(cmd)); !ok || msg.Message != "Transaction already submitted" {
+		t.Errorf("expected an already submitted notice, got %v", msg)
+	}
+}
+
+func TestTransaction_FailedSubmitCanBeRetried(t *testing.T) {
+	fail := true
+	api := &mockTransactionFormAPI{
+		createTransactionFunc: func(tx firefly.RequestTransaction) (string, error) {
+			if fail {
+				return "", errors.New("server unavailable")
+			}
+			return "42", nil
+		},
+	}
+	m := newCompletedTransactionModel(api)
+
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	m = model.(modelTransaction)
+	if m.submitted {
+		t.Fatal("expected the guard cleared once the save failed")
+	}
+
+	fail = false
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if len(api.createTransactionCalls) != 2 {
+		t.Errorf("expected a retry after the failure, got %d calls", len(api.createTransactionCalls))
+	}
+}
+
+func TestTransaction_DuplicateIsAlreadyCreated(t *testing.T) {
+	viper.Set("transactions.reject_duplicates", true)
+	defer viper.Set("transactions.reject_duplicates", false)
+
+	api := &mockTransactionFormAPI{
+		createTransactionFunc: func(tx firefly.RequestTransaction) (string, error) {
+			if !tx.ErrorIfDuplicateHash {
+				t.Error("expected duplicates to be rejected")
+			}
+			return "", fmt.Errorf("failed to send request: %w", &firefly.HTTPError{
+				StatusCode: 422,
+				Validation: &firefly.ValidationError{
+					Message: "Duplicate of transaction #17.",
+					Fields: map[string][]string{
+						"transactions.0.description": {"Duplicate of transaction #17."},
+					},
+				},
+			})
+		},
+	}
+	m := newCompletedTransactionModel(api)
+
+	model, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	m = model.(modelTransaction)
+	msgs := collectMsgsFromCmd(cmd)
+
+	if m.created {
+		t.Error("expected the form to be closed")
+	}
+	notice, ok := findMsg[notify.NotifyMsg](msgs)
+	if !ok || notice.Level != notify.Log || notice.Message != "Transaction already created as #17" {
+		t.Errorf("expected an already created notice, got %v", msgs)
+	}
+	refresh, ok := findMsg[RefreshTransactionsMsg](msgs)
+	if !ok || refresh.TrxID != "17" {
+		t.Errorf("expected the existing transaction to be selected, got %v", msgs)
+	}
+}
+
 func TestTransaction_KeyBindings_NotFocused(t *testing.T) {
 	m := newTestTransactionModel()
 	// Don't call Focus()
@@ -900,6 +1040,21 @@ func TestTransaction_SetTransaction_New(t *testing.T) {
 	}
 }
 
+func TestTransaction_SetTransaction_NewPrefilled(t *testing.T) {
+	m := newTestTransactionModel()
+
+	m.SetTransaction(firefly.Transaction{
+		Splits: []firefly.Split{{Amount: 340, Description: "Credit card payment"}},
+	}, true)
+
+	if m.splits[0].amount != "340.00" {
+		t.Errorf("expected amount 340.00, got %s", m.splits[0].amount)
+	}
+	if m.splits[0].description != "Credit card payment" {
+		t.Errorf("expected description to be kept, got %s", m.splits[0].description)
+	}
+}
+
 func TestTransaction_SetTransaction_Edit(t *testing.T) {
 	m := newTestTransactionModel()
 
@@ -1647,6 +1802,362 @@ func TestTransaction_TransactionTypeDetection(t *testing.T) {
 	}
 }
 
+func TestTransaction_TypeOverride(t *testing.T) {
+	m := newTestTransactionModel()
+	api := m.api.(*mockTransactionFormAPI)
+	m.splits = []*split{{source: testAssetChecking, destination: testLiabilityLoan, amount: "100"}}
+	m.attr.year, m.attr.month, m.attr.day = "2026", "01", "15"
+
+	title, _ := m.trxTitle(0, m.splits[0])
+	if got := title(); got != "Current Type: withdrawal" {
+		t.Errorf("expected detected type, got %q", got)
+	}
+	if warning := m.trxTypeWarning(0)(); warning != "" {
+		t.Errorf("expected no warning without override, got %q", warning)
+	}
+
+	m.attr.typeOverride = "transfer"
+	if got := title(); got != "Current Type: transfer (set manually)" {
+		t.Errorf("expected chosen type, got %q", got)
+	}
+	if warning := m.trxTypeWarning(0)(); !strings.Contains(warning, "does not look like a transfer") {
+		t.Errorf("expected mismatch warning, got %q", warning)
+	}
+
+	m.CreateTransaction()
+	if len(api.createTransactionCalls) != 1 {
+		t.Fatalf("expected a warning not to block submitting, got %d calls", len(api.createTransactionCalls))
+	}
+	if got := api.createTransactionCalls[0].Transactions[0].Type; got != "transfer" {
+		t.Errorf("expected chosen type in the request, got %q", got)
+	}
+}
+
+func TestTransaction_AccountsMatchType(t *testing.T) {
+	initial := firefly.Account{ID: "ib1", Name: "Initial balance", Type: "initial-balance"}
+	tests := []struct {
+		transactionType     string
+		source, destination firefly.Account
+		want                bool
+	}{
+		{"withdrawal", testAssetChecking, testExpenseGroceries, true},
+		{"withdrawal", testRevenueSalary, testAssetChecking, false},
+		{"deposit", testLiabilityLoan, testAssetChecking, true},
+		{"opening balance", initial, testAssetChecking, true},
+		{"opening balance", testAssetChecking, testLiabilityLoan, false},
+		{"reconciliation", testAssetChecking, initial, true},
+		{"reconciliation", testLiabilityLoan, initial, false},
+	}
+	for _, tt := range tests {
+		if got := accountsMatchType(tt.transactionType, tt.source, tt.destination); got != tt.want {
+			t.Errorf("accountsMatchType(%s, %s, %s) = %v, want %v",
+				tt.transactionType, tt.source.Name, tt.destination.Name, got, tt.want)
+		}
+	}
+}
+
+func TestTransaction_SetTransaction_KeepsServerType(t *testing.T) {
+	m := newTestTransactionModel()
+	m.SetTransaction(firefly.Transaction{
+		TransactionID: "7",
+		Type:          "opening balance",
+		Date:          "2026-01-01T00:00:00Z",
+		Splits:        []firefly.Split{{Source: firefly.Account{ID: "ib1", Type: "initial-balance"}, Destination: testAssetChecking, Amount: 100}},
+	}, false)
+	if m.attr.typeOverride != "opening balance" {
+		t.Errorf("expected the server type to be kept, got %q", m.attr.typeOverride)
+	}
+
+	m.SetTransaction(firefly.Transaction{
+		TransactionID: "8",
+		Type:          "withdrawal",
+		Date:          "2026-01-01T00:00:00Z",
+		Splits:        []firefly.Split{{Source: testAssetChecking, Destination: testExpenseGroceries, Amount: 10}},
+	}, false)
+	if m.attr.typeOverride != "" {
+		t.Errorf("expected detected type for a regular transaction, got %q", m.attr.typeOverride)
+	}
+}
+
+func TestTransaction_SetCashDestination(t *testing.T) {
+	cash := firefly.Account{ID: "cash1", Name: "Cash account", Type: "cash"}
+	m := newTestTransactionModel()
+	api := m.api.(*mockTransactionFormAPI)
+
+	m.splits = []*split{
+		{source: testAssetChecking, destination: testExpenseGroceries},
+		{source: testRevenueSalary},
+	}
+	msgs := collectMsgsFromCmd(m.SetCashDestination(0))
+	if n, ok := msgs[0].(notify.NotifyMsg); !ok || n.Level != notify.Warn {
+		t.Errorf("expected warning without a cash account, got %#v", msgs)
+	}
+
+	accounts := api.accountsByTypeFunc
+	api.accountsByTypeFunc = func(accountType string) []firefly.Account {
+		if accountType == "expense" {
+			return append(accounts(accountType), cash)
+		}
+		return accounts(accountType)
+	}
+
+	msgs = collectMsgsFromCmd(m.SetCashDestination(0))
+	if _, ok := msgs[0].(RedrawFormMsg); !ok {
+		t.Errorf("expected form redraw, got %#v", msgs)
+	}
+	if m.splits[0].destination != cash {
+		t.Errorf("expected cash destination, got %q", m.splits[0].destination.Name)
+	}
+
+	msgs = collectMsgsFromCmd(m.SetCashDestination(1))
+	if n, ok := msgs[0].(notify.NotifyMsg); !ok || n.Level != notify.Warn {
+		t.Errorf("expected warning for a revenue source, got %#v", msgs)
+	}
+	if m.splits[1].destination == cash {
+		t.Error("expected deposit destination to be kept")
+	}
+}
+
+func TestTransaction_CategoryMixHint(t *testing.T) {
+	m := newTestTransactionModel()
+	m.splits = []*split{
+		{category: testCategoryFood},
+		{category: testCategoryBills},
+		{},
+	}
+	if hint := m.categoryMixHint(); hint != "" {
+		t.Errorf("expected no hint without a group title, got %q", hint)
+	}
+
+	m.attr.groupTitle = "Weekly shopping"
+	if hint := m.categoryMixHint(); !strings.Contains(hint, "2 categories") || !strings.Contains(hint, "ctrl+g puts them all in Food") {
+		t.Errorf("unexpected hint %q", hint)
+	}
+
+	m.splits[1].category = testCategoryFood
+	if hint := m.categoryMixHint(); hint != "" {
+		t.Errorf("expected no hint with one category, got %q", hint)
+	}
+}
+
+func TestTransaction_CopyFirstCategory(t *testing.T) {
+	m := newTestTransactionModel()
+	m.splits = []*split{{}, {category: testCategoryBills}}
+	msgs := collectMsgsFromCmd(m.CopyFirstCategory())
+	if n, ok := msgs[0].(notify.NotifyMsg); !ok || n.Level != notify.Warn {
+		t.Errorf("expected warning without a first category, got %#v", msgs)
+	}
+
+	m.splits[0].category = testCategoryFood
+	m.splits = append(m.splits, &split{})
+	msgs = collectMsgsFromCmd(m.CopyFirstCategory())
+	if _, ok := msgs[0].(RedrawFormMsg); !ok {
+		t.Errorf("expected form redraw, got %#v", msgs)
+	}
+	for i, s := range m.splits {
+		if s.category != testCategoryFood {
+			t.Errorf("expected split %d in Food, got %q", i, s.category.Name)
+		}
+	}
+	if !m.splits[1].categoryPicked || !m.dirty {
+		t.Error("expected the copied categories kept as picked and the form changed")
+	}
+
+	msgs = collectMsgsFromCmd(m.CopyFirstCategory())
+	if n, ok := msgs[0].(notify.NotifyMsg); !ok || n.Level != notify.Log {
+		t.Errorf("expected a note that nothing changed, got %#v", msgs)
+	}
+}
+
+func TestTransaction_FocusedSplit(t *testing.T) {
+	m := newTestTransactionModel()
+	m.splits = []*split{{}, {}}
+	m.UpdateForm()
+	m.form.Init()
+	if got := m.focusedSplit(); got != 0 {
+		t.Errorf("expected first split focused, got %d", got)
+	}
+
+	m.fullLayout = true
+	m.UpdateForm()
+	m.form.Init()
+	m.form.NextGroup()
+	if got := m.focusedSplit(); got != 1 {
+		t.Errorf("expected second split focused, got %d", got)
+	}
+}
+
+func TestTransaction_RedrawKeepsGroups(t *testing.T) {
+	m := newTestTransactionModel()
+	m.Focus()
+	m.SetTransaction(firefly.Transaction{}, true)
+	m.created = true
+	m.UpdateForm()
+	m = settleForm(m, m.form.Init())
+	first := m.groups.splits[m.splits[0]].group
+	date := m.groups.date
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlA})
+	m = settleForm(updated.(modelTransaction), cmd)
+	if len(m.splits) != 2 {
+		t.Fatalf("expected 2 splits, got %d", len(m.splits))
+	}
+	if m.groups.splits[m.splits[0]].group != first || m.groups.date != date {
+		t.Error("expected adding a split to keep the groups of the other fields")
+	}
+	if got := m.focusedSplit(); got != 1 {
+		t.Errorf("expected the new split focused, got %d", got)
+	}
+
+	m.splits = append(m.splits, &split{})
+	m.UpdateForm()
+	second := m.groups.splits[m.splits[1]].group
+	third := m.groups.splits[m.splits[2]].group
+
+	updated, cmd = m.Update(DeleteSplitMsg{Index: 1})
+	m = settleForm(updated.(modelTransaction), cmd)
+	if len(m.splits) != 2 {
+		t.Fatalf("expected 2 splits, got %d", len(m.splits))
+	}
+	if m.groups.splits[m.splits[0]].group != first {
+		t.Error("expected the first split to keep its group")
+	}
+	if got := m.groups.splits[m.splits[1]].group; got == third || got == second {
+		t.Error("expected the moved split to get a group for its new position")
+	}
+	if got := m.focusedSplit(); got != 0 {
+		t.Errorf("expected the split before the deleted one focused, got %d", got)
+	}
+
+	m.SetTransaction(firefly.Transaction{}, true)
+	m.UpdateForm()
+	if m.groups.splits[m.splits[0]].group == first || m.groups.date == date {
+		t.Error("expected a new transaction to build the form from scratch")
+	}
+}
+
+func TestTransaction_RedrawKeepsFocusedGroup(t *testing.T) {
+	m := newTestTransactionModel()
+	m.Focus()
+	m.SetTransaction(firefly.Transaction{}, true)
+	m.splits = append(m.splits, &split{})
+	m.created = true
+	m.UpdateForm()
+	m = settleForm(m, m.form.Init())
+	m = settleForm(m, m.form.NextGroup())
+	if got := m.focusedSplit(); got != 1 {
+		t.Fatalf("expected second split focused, got %d", got)
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlF})
+	m = settleForm(updated.(modelTransaction), cmd)
+	if got := m.focusedSplit(); got != 1 {
+		t.Errorf("expected second split still focused after changing the layout, got %d", got)
+	}
+}
+
+// settleForm runs the commands of the form until it stops producing
+// messages, loading the options of its selects.
+func settleForm(m modelTransaction, cmd tea.Cmd) modelTransaction {
+	for range 5 {
+		msgs := collectMsgsFromCmd(cmd)
+		var cmds []tea.Cmd
+		for _, msg := range msgs {
+			if _, ok := msg.(tea.WindowSizeMsg); ok {
+				continue
+			}
+			updated, c := m.Update(msg)
+			m = updated.(modelTransaction)
+			cmds = append(cmds, c)
+		}
+		cmd = tea.Batch(cmds...)
+	}
+	return m
+}
+
+func TestTransaction_DirtyForm(t *testing.T) {
+	newForm := func() modelTransaction {
+		m := newTestTransactionModel()
+		m.Focus()
+		m.SetTransaction(firefly.Transaction{}, true)
+		m.created = true
+		m.UpdateForm()
+		return settleForm(m, m.form.Init())
+	}
+
+	t.Run("options filled in by the form do not count", func(t *testing.T) {
+		m := newForm()
+		if m.splits[0].source != testAssetChecking {
+			t.Fatalf("expected first asset selected after loading options, got %q", m.splits[0].source.Name)
+		}
+		if m.Dirty() {
+			t.Error("expected form not to be dirty before any input")
+		}
+
+		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		msgs := collectMsgsFromCmd(cmd)
+		if len(msgs) != 1 {
+			t.Fatalf("expected to leave without asking, got %v", msgs)
+		}
+		if v, ok := msgs[0].(SetFocusedViewMsg); !ok || v.state != transactionsView {
+			t.Errorf("expected SetView(transactionsView), got %#v", msgs[0])
+		}
+	})
+
+	t.Run("changed form asks before leaving", func(t *testing.T) {
+		m := newForm()
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+		m = updated.(modelTransaction)
+		if m.splits[0].source != testAssetSavings {
+			t.Fatalf("expected second asset selected, got %q", m.splits[0].source.Name)
+		}
+		if !m.Dirty() {
+			t.Fatal("expected form to be dirty after changing the source")
+		}
+
+		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		msgs := collectMsgsFromCmd(cmd)
+		if len(msgs) != 1 {
+			t.Fatalf("expected a prompt, got %v", msgs)
+		}
+		ask, ok := msgs[0].(prompt.PromptMsg)
+		if !ok {
+			t.Fatalf("expected PromptMsg, got %T", msgs[0])
+		}
+
+		if v, ok := ask.Callback("k")().(SetFocusedViewMsg); !ok || v.state != newView {
+			t.Errorf("expected to keep editing, got %#v", v)
+		}
+
+		draft := collectMsgsFromCmd(ask.Callback("s"))
+		if v, ok := draft[0].(SetFocusedViewMsg); !ok || v.state != transactionsView {
+			t.Errorf("expected draft to leave the form, got %#v", draft)
+		}
+
+		discard, ok := ask.Callback("d")().(DiscardTransactionMsg)
+		if !ok {
+			t.Fatal("expected DiscardTransactionMsg")
+		}
+		updated, _ = m.Update(discard)
+		m = updated.(modelTransaction)
+		if m.created || m.Dirty() {
+			t.Error("expected discarded form to be empty and clean")
+		}
+		if m.splits[0].source != (firefly.Account{}) {
+			t.Errorf("expected discarded changes to be dropped, got %q", m.splits[0].source.Name)
+		}
+	})
+
+	t.Run("added split counts as a change", func(t *testing.T) {
+		m := newForm()
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlA})
+		m = updated.(modelTransaction)
+		if !m.Dirty() {
+			t.Error("expected form to be dirty after adding a split")
+		}
+	})
+}
+
 func TestTransaction_View(t *testing.T) {
 	t.Run("form not completed returns form view", func(t *testing.T) {
 		m := newTestTransactionModel()
@@ -1806,6 +2317,74 @@ func TestTransaction_MultipleSplits(t *testing.T) {
 	}
 }
 
+func TestTransaction_SplitAccountOverride(t *testing.T) {
+	m := newTestTransactionModel()
+	first := &split{source: testAssetChecking, destination: testExpenseGroceries}
+	following := &split{destination: testExpenseUtilities}
+	overridden := &split{destination: testExpenseUtilities}
+	m.splits = []*split{first, following, overridden}
+
+	title, _ := m.trxTitle(0, first)
+	title()
+	for i, s := range m.splits[1:] {
+		options, _ := m.trxSourceOptions(i+1, s)
+		opts := options()
+		if s.source != testAssetChecking {
+			t.Errorf("split %d: expected shared source Checking, got %q", i+1, s.source.Name)
+		}
+		if len(opts) == 0 || opts[0].Value != testAssetChecking {
+			t.Errorf("split %d: expected shared source as first option", i+1)
+		}
+		if len(opts) != 4 {
+			t.Errorf("split %d: expected shared source plus other assets and liabilities, got %d options", i+1, len(opts))
+		}
+	}
+
+	overridden.source = testLiabilityCreditCard
+	first.source = testAssetSavings
+	title()
+	for _, s := range m.splits[1:] {
+		options, _ := m.trxSourceOptions(1, s)
+		options()
+	}
+	if following.source != testAssetSavings {
+		t.Errorf("expected split to follow the new shared source, got %q", following.source.Name)
+	}
+	if overridden.source != testLiabilityCreditCard {
+		t.Errorf("expected overridden source to be kept, got %q", overridden.source.Name)
+	}
+	if err := m.validateSplits(); err != nil {
+		t.Errorf("expected mixed sources to be valid for a withdrawal, got %v", err)
+	}
+}
+
+func TestTransaction_SplitTypeValidation(t *testing.T) {
+	m := newTestTransactionModel()
+	api := m.api.(*mockTransactionFormAPI)
+	m.splits = []*split{
+		{source: testAssetChecking, destination: testExpenseGroceries, amount: "10"},
+		{source: testAssetChecking, destination: testAssetSavings, amount: "5"},
+	}
+	m.attr.transactionType = "withdrawal"
+	m.attr.year, m.attr.month, m.attr.day = "2026", "01", "15"
+
+	err := m.validateSplits()
+	if err == nil || !strings.Contains(err.Error(), "split 1 is a transfer") {
+		t.Fatalf("expected split type error, got %v", err)
+	}
+
+	msgs := collectMsgsFromCmd(m.CreateTransaction())
+	if len(api.createTransactionCalls) != 0 {
+		t.Error("expected invalid group not to be created")
+	}
+	if len(msgs) != 1 {
+		t.Fatalf("expected a single warning, got %v", msgs)
+	}
+	if n, ok := msgs[0].(notify.NotifyMsg); !ok || n.Level != notify.Warn {
+		t.Errorf("expected warning, got %#v", msgs[0])
+	}
+}
+
 func TestTransaction_ForeignCurrency(t *testing.T) {
 	testAssetEUR := firefly.Account{
 		ID:           "asset_eur",
@@ -1877,3 +2456,48 @@ func indexString(s, substr string) int {
 	}
 	return -1
 }
+
+func TestTransaction_References(t *testing.T) {
+	api := &mockTransactionFormAPI{
+		updateTransactionFunc: func(transactionID string, tx firefly.RequestTransaction) (string, error) {
+			return transactionID, nil
+		},
+	}
+	m := newModelTransaction(api)
+
+	trx := newTestTransaction(1, "7", "withdrawal", "2026-01-15T10:00:00Z", "Web hosting")
+	trx.Splits[0].InternalReference = "INV-017"
+	trx.Splits[0].ExternalID = "ext-42"
+	trx.Splits[0].InvoiceDate = "2026-01-02"
+	m.SetTransaction(trx, false)
+
+	s := m.splits[0]
+	if s.internalReference != "INV-017" || s.externalID != "ext-42" || s.invoiceDate != "2026-01-02" {
+		t.Fatalf("expected the references in the form, got %q %q %q", s.internalReference, s.externalID, s.invoiceDate)
+	}
+
+	s.invoiceDate = "2026-01-03"
+	m.created = true
+	m.UpdateTransaction()
+
+	if len(api.updateTransactionCalls) != 1 {
+		t.Fatalf("expected one update, got %d", len(api.updateTransactionCalls))
+	}
+	req := api.updateTransactionCalls[0].tx.Transactions[0]
+	if req.InternalReference != "INV-017" || req.ExternalID != "ext-42" || req.InvoiceDate != "2026-01-03" {
+		t.Errorf("expected the references in the request, got %+v", req)
+	}
+}
+
+func TestValidateInvoiceDate(t *testing.T) {
+	for value, valid := range map[string]bool{
+		"":           true,
+		"2026-01-15": true,
+		"2026-13-01": false,
+		"15.01.2026": false,
+	} {
+		if err := validateInvoiceDate(value); (err == nil) != valid {
+			t.Errorf("%q: expected valid=%v, got %v", value, valid, err)
+		}
+	}
+}
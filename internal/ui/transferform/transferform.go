@@ -0,0 +1,213 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package transferform
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/formkit"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+)
+
+// OpenMsg opens the form, Accounts are the asset and liability accounts
+// money can be moved between.
+type OpenMsg struct {
+	Accounts []firefly.Account
+}
+
+// SubmitMsg carries the transfer to create once the form is completed.
+type SubmitMsg struct {
+	Transfer Transfer
+}
+
+type CloseMsg struct{}
+
+// Transfer moves Amount from Source to Destination on Date, YYYY-MM-DD.
+type Transfer struct {
+	Source      firefly.Account
+	Destination firefly.Account
+	Amount      float64
+	Date        string
+}
+
+type values struct {
+	source      firefly.Account
+	destination firefly.Account
+	amount      string
+	date        string
+}
+
+type Model struct {
+	form   *huh.Form
+	values *values
+	focus  bool
+	styles formkit.Styles
+	Width  int
+	Height int
+}
+
+func New() Model {
+	return Model{
+		styles: formkit.DefaultStyles(),
+		Width:  80,
+		Height: 24,
+	}
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case OpenMsg:
+		m.values = &values{date: time.Now().Format(time.DateOnly)}
+		m.form = newForm(m.values, msg.Accounts)
+		m.Focus()
+		return m, m.form.Init()
+	case CloseMsg:
+		m.Blur()
+		return m, nil
+	}
+
+	if !m.focus {
+		return m, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok && msg.String() == "esc" {
+		return m, Close()
+	}
+
+	form, cmd := m.form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.form = f
+	}
+	if m.form.State == huh.StateCompleted {
+		m.Blur()
+		transfer := m.values.transfer()
+		return m, func() tea.Msg {
+			return SubmitMsg{Transfer: transfer}
+		}
+	}
+	return m, cmd
+}
+
+func (m Model) View() string {
+	if !m.focus {
+		return ""
+	}
+
+	return m.styles.Frame("Quick transfer", m.form.View(), m.Width, m.Height)
+}
+
+func accountOptions(accounts []firefly.Account, except firefly.Account) []huh.Option[firefly.Account] {
+	options := []huh.Option[firefly.Account]{}
+	for _, account := range accounts {
+		if account.ID == except.ID {
+			continue
+		}
+		options = append(options, huh.NewOption(account.Name, account))
+	}
+	return options
+}
+
+func newForm(v *values, accounts []firefly.Account) *huh.Form {
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[firefly.Account]().
+				Title("From").
+				Options(accountOptions(accounts, firefly.Account{})...).
+				Value(&v.source).
+				WithHeight(6),
+			huh.NewSelect[firefly.Account]().
+				Title("To").
+				OptionsFunc(func() []huh.Option[firefly.Account] {
+					return accountOptions(accounts, v.source)
+				}, &v.source).
+				Value(&v.destination).
+				Validate(func(destination firefly.Account) error {
+					if destination.ID == "" || destination.ID == v.source.ID {
+						return errors.New("pick another account to move the money to")
+					}
+					if destination.CurrencyCode != v.source.CurrencyCode {
+						return errors.New("accounts in different currencies need the full form")
+					}
+					return nil
+				}).
+				WithHeight(6),
+			huh.NewInput().
+				Title("Amount").
+				Value(&v.amount).
+				Validate(func(s string) error {
+					amount, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+					if err != nil || amount <= 0 {
+						return errors.New("please enter a positive number")
+					}
+					return nil
+				}),
+			huh.NewInput().
+				Title("Date").
+				Placeholder(time.DateOnly).
+				Value(&v.date).
+				Validate(func(s string) error {
+					if _, err := time.Parse(time.DateOnly, strings.TrimSpace(s)); err != nil {
+						return errors.New("please enter a date as YYYY-MM-DD")
+					}
+					return nil
+				}),
+		),
+	).WithShowHelp(false)
+}
+
+func (v *values) transfer() Transfer {
+	amount, _ := strconv.ParseFloat(strings.TrimSpace(v.amount), 64)
+	return Transfer{
+		Source:      v.source,
+		Destination: v.destination,
+		Amount:      amount,
+		Date:        strings.TrimSpace(v.date),
+	}
+}
+
+func (m *Model) Focus() {
+	m.focus = true
+}
+
+func (m *Model) Blur() {
+	m.focus = false
+}
+
+func (m *Model) Focused() bool {
+	return m.focus
+}
+
+func (m *Model) WithSize(width, height int) *Model {
+	m.Width = width
+	m.Height = height
+	return m
+}
+
+func (m *Model) WithStyles(styles formkit.Styles) *Model {
+	m.styles = styles
+	return m
+}
+
+func Open(accounts []firefly.Account) tea.Cmd {
+	return func() tea.Msg {
+		return OpenMsg{Accounts: accounts}
+	}
+}
+
+func Close() tea.Cmd {
+	return func() tea.Msg {
+		return CloseMsg{}
+	}
+}
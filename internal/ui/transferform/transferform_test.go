@@ -0,0 +1,108 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package transferform
+
+import (
+	"strings"
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/formkit/formtest"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var form = formtest.Harness[Model]{Out: func(msg tea.Msg) bool {
+	switch msg.(type) {
+	case SubmitMsg, CloseMsg:
+		return true
+	}
+	return false
+}}
+
+var (
+	checking = firefly.Account{ID: "1", Name: "Checking", Type: "asset", CurrencyCode: "EUR"}
+	savings  = firefly.Account{ID: "2", Name: "Savings", Type: "asset", CurrencyCode: "EUR"}
+	card     = firefly.Account{ID: "3", Name: "Dollar card", Type: "liabilities", CurrencyCode: "USD"}
+)
+
+func openForm(t *testing.T) Model {
+	t.Helper()
+	m, _ := form.Send(New(), OpenMsg{Accounts: []firefly.Account{checking, savings, card}})
+	if !m.Focused() {
+		t.Fatal("Expected form to be focused after OpenMsg")
+	}
+	return m
+}
+
+func TestView(t *testing.T) {
+	m := openForm(t)
+	view := m.View()
+
+	for _, want := range []string{"Quick transfer", "From", "To", "Amount", "Date", "Checking"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected view to contain %q", want)
+		}
+	}
+}
+
+func TestUpdate_Submit(t *testing.T) {
+	m := openForm(t)
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+
+	m, _ = form.Send(m, enter) // from checking
+	m, _ = form.Send(m, enter) // to savings, checking is not offered
+	m = form.TypeText(m, "120.5")
+	m, _ = form.Send(m, enter)
+	m, _ = form.Send(m, tea.KeyMsg{Type: tea.KeyCtrlU})
+	m = form.TypeText(m, "2026-01-01")
+	m, msgs := form.Send(m, enter)
+
+	if len(msgs) != 1 {
+		t.Fatalf("Expected a single SubmitMsg, got %v", msgs)
+	}
+	submit, ok := msgs[0].(SubmitMsg)
+	if !ok {
+		t.Fatalf("Expected SubmitMsg, got %T", msgs[0])
+	}
+	want := Transfer{Source: checking, Destination: savings, Amount: 120.5, Date: "2026-01-01"}
+	if submit.Transfer != want {
+		t.Errorf("Unexpected transfer: %+v", submit.Transfer)
+	}
+	if m.Focused() {
+		t.Error("Expected form to close after submit")
+	}
+}
+
+func TestUpdate_CurrenciesDiffer(t *testing.T) {
+	m := openForm(t)
+
+	m, _ = form.Send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = form.Send(m, tea.KeyMsg{Type: tea.KeyDown})
+	m, msgs := form.Send(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if len(msgs) != 0 {
+		t.Fatalf("Expected no messages, got %v", msgs)
+	}
+	if !strings.Contains(m.View(), "different currencies") {
+		t.Error("Expected validation error in view")
+	}
+}
+
+func TestUpdate_Close(t *testing.T) {
+	m := openForm(t)
+
+	m, msgs := form.Send(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if len(msgs) != 1 {
+		t.Fatalf("Expected CloseMsg, got %v", msgs)
+	}
+	if _, ok := msgs[0].(CloseMsg); !ok {
+		t.Fatalf("Expected CloseMsg, got %T", msgs[0])
+	}
+	updated, _ := m.Update(CloseMsg{})
+	m = updated.(Model)
+	if m.Focused() {
+		t.Error("Expected model to be unfocused after CloseMsg")
+	}
+}
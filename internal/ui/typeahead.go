@@ -0,0 +1,244 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/sahilm/fuzzy"
+)
+
+// Underline on and off only, so the matched characters keep the colors of
+// the option they are rendered in.
+const (
+	underlineOn  = "\x1b[4m"
+	underlineOff = "\x1b[24m"
+)
+
+// typeAheadSelect is a select field filtered by typing. The options fuzzy
+// matching the typed text are listed best match first, with the matched
+// characters underlined, and the best match is selected. Backspace removes
+// the last typed character, leaving the field clears the filter.
+type typeAheadSelect[T comparable] struct {
+	*huh.Select[T]
+	title string
+	value *T
+	query string
+
+	// Options before filtering. huh is bound to revision instead of the
+	// bindings of OptionsFunc, so it loads the options again on every change
+	// of the bindings and the last loaded options are the ones it shows.
+	// OptionsFunc runs in a command, hence mu.
+	mu       sync.Mutex
+	static   []huh.Option[T]
+	bindings any
+	seen     any // values of bindings at the last revision
+	revision int
+	loaded   []huh.Option[T]
+	loadedAt int // revision of loaded
+	shown    int // revision of the filtered options
+}
+
+func newTypeAheadSelect[T comparable]() *typeAheadSelect[T] {
+	return &typeAheadSelect[T]{
+		Select:   huh.NewSelect[T](),
+		loadedAt: -1,
+	}
+}
+
+func (s *typeAheadSelect[T]) Key(key string) *typeAheadSelect[T] {
+	s.Select.Key(key)
+	return s
+}
+
+func (s *typeAheadSelect[T]) Title(title string) *typeAheadSelect[T] {
+	s.title = title
+	s.Select.Title(title)
+	return s
+}
+
+func (s *typeAheadSelect[T]) Value(value *T) *typeAheadSelect[T] {
+	s.value = value
+	s.Select.Value(value)
+	return s
+}
+
+func (s *typeAheadSelect[T]) Options(options ...huh.Option[T]) *typeAheadSelect[T] {
+	s.mu.Lock()
+	s.static = options
+	s.mu.Unlock()
+	s.Select.Options(options...)
+	return s
+}
+
+func (s *typeAheadSelect[T]) OptionsFunc(f func() []huh.Option[T], bindings any) *typeAheadSelect[T] {
+	s.bindings = bindings
+	s.seen = bindingValues(bindings)
+	s.Select.OptionsFunc(func() []huh.Option[T] {
+		s.mu.Lock()
+		revision := s.revision
+		s.mu.Unlock()
+		options := f()
+		s.mu.Lock()
+		if revision == s.revision {
+			s.loaded, s.loadedAt = options, revision
+		}
+		s.mu.Unlock()
+		return options
+	}, &s.revision)
+	return s
+}
+
+func (s *typeAheadSelect[T]) Validate(validate func(T) error) *typeAheadSelect[T] {
+	s.Select.Validate(validate)
+	return s
+}
+
+func (s *typeAheadSelect[T]) WithHeight(height int) huh.Field {
+	s.Select.WithHeight(height)
+	return s
+}
+
+// bindingValues returns the values the bindings of OptionsFunc point to,
+// a pointer or a slice of pointers.
+func bindingValues(bindings any) any {
+	if list, ok := bindings.([]any); ok {
+		values := make([]any, len(list))
+		for i, b := range list {
+			values[i] = bindingValues(b)
+		}
+		return values
+	}
+	if v := reflect.ValueOf(bindings); v.Kind() == reflect.Pointer && !v.IsNil() {
+		return v.Elem().Interface()
+	}
+	return bindings
+}
+
+// syncBindings moves to the next revision when the bindings changed since
+// the last one, for huh to load the options again.
+func (s *typeAheadSelect[T]) syncBindings() {
+	if s.bindings == nil {
+		return
+	}
+	values := bindingValues(s.bindings)
+	if reflect.DeepEqual(values, s.seen) {
+		return
+	}
+	s.seen = values
+	s.mu.Lock()
+	s.revision++
+	s.mu.Unlock()
+}
+
+// options returns the unfiltered options the select shows for the current
+// bindings, and their revision.
+func (s *typeAheadSelect[T]) options() ([]huh.Option[T], int) {
+	s.syncBindings()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.bindings != nil && s.loadedAt == s.revision {
+		return s.loaded, s.revision
+	}
+	return s.static, s.revision
+}
+
+func (s *typeAheadSelect[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case msg.Type == tea.KeyRunes && !msg.Alt, msg.Type == tea.KeySpace:
+			s.filter(s.query + string(msg.Runes))
+			return s, nil
+		case msg.Type == tea.KeyBackspace && s.query != "":
+			runes := []rune(s.query)
+			s.filter(string(runes[:len(runes)-1]))
+			return s, nil
+		}
+	}
+
+	s.syncBindings()
+	_, cmd := s.Select.Update(msg)
+
+	// New options replace the filtered ones
+	if s.query != "" && s.bindings != nil && s.revision != s.shown {
+		s.query = ""
+		s.Select.Title(s.title)
+	}
+	return s, cmd
+}
+
+// Focus moves the cursor to the current value, which may have been set
+// outside the field.
+func (s *typeAheadSelect[T]) Focus() tea.Cmd {
+	if options, _ := s.options(); len(options) > 0 {
+		s.Select.Options(options...)
+	}
+	return s.Select.Focus()
+}
+
+func (s *typeAheadSelect[T]) Blur() tea.Cmd {
+	if s.query != "" {
+		s.filter("")
+	}
+	return s.Select.Blur()
+}
+
+// filter lists the options matching query.
+func (s *typeAheadSelect[T]) filter(query string) {
+	all, shown := s.options()
+	s.shown = shown
+	s.query = query
+	if query == "" {
+		s.Select.Title(s.title)
+		s.Select.Options(all...)
+		return
+	}
+
+	keys := make([]string, len(all))
+	for i, option := range all {
+		keys[i] = option.Key
+	}
+	matches := fuzzy.Find(query, keys)
+	if len(matches) == 0 {
+		s.Select.Title(s.title + " " + query + " (no matches)")
+		return
+	}
+
+	options := make([]huh.Option[T], 0, len(matches))
+	for _, match := range matches {
+		option := all[match.Index]
+		options = append(options, huh.NewOption(underlineMatches(option.Key, match.MatchedIndexes), option.Value))
+	}
+	if s.value != nil {
+		*s.value = options[0].Value
+	}
+	s.Select.Title(s.title + " " + query)
+	s.Select.Options(options...)
+}
+
+// underlineMatches underlines the characters of str at the given byte
+// offsets.
+func underlineMatches(str string, offsets []int) string {
+	matched := make(map[int]bool, len(offsets))
+	for _, offset := range offsets {
+		matched[offset] = true
+	}
+
+	var b strings.Builder
+	for i, r := range str {
+		if matched[i] {
+			b.WriteString(underlineOn)
+			b.WriteRune(r)
+			b.WriteString(underlineOff)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,183 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func newTestTypeAheadSelect(value *firefly.Account) *typeAheadSelect[firefly.Account] {
+	options := []huh.Option[firefly.Account]{}
+	for _, a := range []firefly.Account{testExpenseUtilities, testAssetChecking, testExpenseGroceries, testAssetSavings} {
+		options = append(options, huh.NewOption(a.Name, a))
+	}
+	s := newTypeAheadSelect[firefly.Account]().
+		Title("Destination").
+		Value(value).
+		Options(options...)
+	s.Focus()
+	return s
+}
+
+func typeKeys(s *typeAheadSelect[firefly.Account], text string) {
+	for _, r := range text {
+		s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func TestTypeAheadSelect_FiltersAndSelectsBestMatch(t *testing.T) {
+	var value firefly.Account
+	s := newTestTypeAheadSelect(&value)
+
+	typeKeys(s, "gro")
+	if value != testExpenseGroceries {
+		t.Errorf("expected Groceries selected, got %q", value.Name)
+	}
+
+	view := s.View()
+	plain := ansi.Strip(view)
+	if !strings.Contains(plain, "Destination gro") {
+		t.Errorf("expected typed text in the title, got %q", plain)
+	}
+	if strings.Contains(plain, "Checking") || strings.Contains(plain, "Utilities") {
+		t.Errorf("expected other options to be filtered out, got %q", plain)
+	}
+	if !strings.Contains(view, underlineOn+"G"+underlineOff+underlineOn+"r"+underlineOff+underlineOn+"o"+underlineOff) {
+		t.Errorf("expected matched characters underlined, got %q", view)
+	}
+}
+
+func TestTypeAheadSelect_FuzzyMatch(t *testing.T) {
+	var value firefly.Account
+	s := newTestTypeAheadSelect(&value)
+
+	typeKeys(s, "svg")
+	if value != testAssetSavings {
+		t.Errorf("expected Savings selected for a fuzzy match, got %q", value.Name)
+	}
+
+	typeKeys(s, "x")
+	if plain := ansi.Strip(s.View()); !strings.Contains(plain, "no matches") {
+		t.Errorf("expected no matches note, got %q", plain)
+	}
+	if value != testAssetSavings {
+		t.Errorf("expected selection to be kept without matches, got %q", value.Name)
+	}
+}
+
+func TestTypeAheadSelect_BackspaceAndBlur(t *testing.T) {
+	var value firefly.Account
+	s := newTestTypeAheadSelect(&value)
+
+	typeKeys(s, "chx")
+	s.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	if s.query != "ch" {
+		t.Errorf("expected backspace to remove the last character, got %q", s.query)
+	}
+	if value != testAssetChecking {
+		t.Errorf("expected Checking selected, got %q", value.Name)
+	}
+
+	s.Blur()
+	if s.query != "" {
+		t.Errorf("expected filter cleared on blur, got %q", s.query)
+	}
+	plain := ansi.Strip(s.View())
+	if strings.Contains(plain, "Destination ch") || !strings.Contains(plain, "Groceries") {
+		t.Errorf("expected all options listed again after blur, got %q", plain)
+	}
+	if value != testAssetChecking {
+		t.Errorf("expected selection to be kept after blur, got %q", value.Name)
+	}
+}
+
+func TestUnderlineMatches_MultiByte(t *testing.T) {
+	got := underlineMatches("Café bar", []int{3, 6})
+	want := "Caf" + underlineOn + "é" + underlineOff + " " + underlineOn + "b" + underlineOff + "ar"
+	if got != want {
+		t.Errorf("underlineMatches = %q, want %q", got, want)
+	}
+}
+
+func settleHuhForm(form *huh.Form, cmd tea.Cmd) *huh.Form {
+	for range 5 {
+		var cmds []tea.Cmd
+		for _, msg := range collectMsgsFromCmd(cmd) {
+			if _, ok := msg.(tea.WindowSizeMsg); ok {
+				continue
+			}
+			updated, c := form.Update(msg)
+			form = updated.(*huh.Form)
+			cmds = append(cmds, c)
+		}
+		cmd = tea.Batch(cmds...)
+	}
+	return form
+}
+
+func TestTypeAheadSelect_FiltersOptionsOfCurrentBindings(t *testing.T) {
+	var value firefly.Account
+	accountType := "asset"
+	s := newTypeAheadSelect[firefly.Account]().
+		Title("Destination").
+		Value(&value).
+		OptionsFunc(func() []huh.Option[firefly.Account] {
+			if accountType == "asset" {
+				return huh.NewOptions(testAssetChecking, testAssetSavings)
+			}
+			return huh.NewOptions(testExpenseGroceries, testExpenseUtilities)
+		}, &accountType)
+	form := huh.NewForm(huh.NewGroup(s))
+	form = settleHuhForm(form, form.Init())
+
+	accountType = "expense"
+	form = settleHuhForm(form, func() tea.Msg { return RedrawFormMsg{} })
+	typeKeys(s, "gro")
+	if value != testExpenseGroceries {
+		t.Errorf("expected Groceries selected from the expense options, got %q", value.Name)
+	}
+	s.filter("")
+
+	accountType = "asset"
+	settleHuhForm(form, func() tea.Msg { return RedrawFormMsg{} })
+	typeKeys(s, "sav")
+	if value != testAssetSavings {
+		t.Errorf("expected Savings selected from the shown options, got %q", value.Name)
+	}
+}
+
+func TestTypeAheadSelect_NewOptionsClearFilter(t *testing.T) {
+	var value firefly.Account
+	accountType := "asset"
+	s := newTypeAheadSelect[firefly.Account]().
+		Title("Destination").
+		Value(&value).
+		OptionsFunc(func() []huh.Option[firefly.Account] {
+			if accountType == "asset" {
+				return huh.NewOptions(testAssetChecking, testAssetSavings)
+			}
+			return huh.NewOptions(testExpenseGroceries, testExpenseUtilities)
+		}, &accountType)
+	form := huh.NewForm(huh.NewGroup(s))
+	form = settleHuhForm(form, form.Init())
+
+	typeKeys(s, "sav")
+	accountType = "expense"
+	settleHuhForm(form, func() tea.Msg { return RedrawFormMsg{} })
+
+	if s.query != "" {
+		t.Errorf("expected the filter cleared by the new options, got %q", s.query)
+	}
+	if all, _ := s.options(); len(all) != 2 || all[0].Value != testExpenseGroceries {
+		t.Errorf("expected the expense options, got %v", all)
+	}
+}
@@ -8,17 +8,28 @@ import (
 	"fmt"
 	"os"
 	"strings"
-	"sync"
-	"sync/atomic"
 	"time"
 
+	"ffiii-tui/internal/ui/accountdetail"
+	"ffiii-tui/internal/ui/apilog"
+	"ffiii-tui/internal/ui/assetform"
+	"ffiii-tui/internal/ui/categorydetail"
+	"ffiii-tui/internal/ui/cellview"
+	"ffiii-tui/internal/ui/deletedlog"
+	"ffiii-tui/internal/ui/helpoverlay"
+	"ffiii-tui/internal/ui/integrity"
+	"ffiii-tui/internal/ui/loading"
+	"ffiii-tui/internal/ui/money"
 	"ffiii-tui/internal/ui/notify"
+	"ffiii-tui/internal/ui/notifylog"
 	"ffiii-tui/internal/ui/period"
 	"ffiii-tui/internal/ui/prompt"
+	"ffiii-tui/internal/ui/recurrenceform"
+	"ffiii-tui/internal/ui/transferform"
+	"ffiii-tui/panel"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
-	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/viper"
@@ -27,12 +38,6 @@ import (
 
 type state uint
 
-var (
-	loading        atomic.Int32
-	loadingOps     sync.Map
-	operationIDSeq atomic.Uint64 // for generating unique operation IDs
-)
-
 const (
 	transactionsView state = iota
 	periodView
@@ -42,6 +47,9 @@ const (
 	expensesView
 	revenuesView
 	liabilitiesView
+	tagsView
+	// customView shows one of the panels added with the panel package
+	customView
 	// promptView
 )
 
@@ -53,13 +61,8 @@ type (
 	DataLoadCompletedMsg struct {
 		DataType string
 	}
-	LazyLoadMsg struct {
-		t time.Time
-		c int
-	}
-	AllBaseDataLoadedMsg struct{}
-	RefreshAllMsg        struct{}
-	UpdatePositions      struct {
+	RefreshAllMsg   struct{}
+	UpdatePositions struct {
 		layout *LayoutConfig
 	}
 )
@@ -68,71 +71,153 @@ type modelUI struct {
 	state        state
 	transactions modelTransactions
 	api          UIAPI
+	connect      ConnectFunc
+	saveToken    SaveTokenFunc
 	new          modelTransaction
 	assets       modelAssets
 	categories   modelCategories
+	tags         modelTags
 	expenses     modelExpenses
 	revenues     modelRevenues
 	liabilities  modelLiabilities
 	prompt       prompt.Model
 	periodPicker period.Model
+	helpOverlay  helpoverlay.Model
+	apiLog       apilog.Model
+	deletedLog   deletedlog.Model
+	notifyLog    notifylog.Model
+	cells        cellview.Model
+	integrity    integrity.Model
+	details      accountdetail.Model
+	category     categorydetail.Model
+	assetForm    assetform.Model
+	transferForm transferform.Model
+	repeatForm   recurrenceform.Model
 	notify       notify.Model
 	summary      modelSummary
-	spinner      spinner.Model
+	loading      loading.Model
 
-	keymap UIKeyMap
-	help   help.Model
-	styles Styles
+	panels      []panel.Panel
+	activePanel int
+
+	keymap      UIKeyMap
+	panelKeymap PanelKeyMap
+	vim         vimLayer
+	help        help.Model
+	styles      Styles
 
 	Width  int
 	layout *LayoutConfig
 
-	loadStatus map[string]bool
+	loadStatus map[string]resourceLoad
+	health     HealthCheckedMsg
+	// events refreshes the panels after the changes published
+	events *eventBus
+	// periodRequests load the summary and insights of the selected period
+	periodRequests *requestScope
+
+	// askingToken is set while the re-authentication prompt is open,
+	// tokenDismissed once it was closed without a token
+	askingToken    bool
+	tokenDismissed bool
+
+	// sessionFile keeps where the UI was left, empty to keep nothing;
+	// resumeView is the view shown once the data is loaded
+	sessionFile string
+	resumeView  state
+
+	// backingUp is set while a scheduled backup runs
+	backingUp bool
+	// alertsNotified is set once the balance alerts were checked
+	alertsNotified bool
+
+	// crash keeps the latest messages for the report written on a panic
+	crash *crashReporter
+
+	// zoomed are the views whose focused panel takes the whole screen, the
+	// transactions view zooms with its full view instead
+	zoomed map[state]bool
 }
 
-func Show(api UIAPI) {
+// Show runs the UI. connect is used by the profile switcher to create a
+// client for another profile, saveToken stores an API token entered after
+// the server rejected the previous one. The client in use when the UI
+// exits is returned.
+func Show(api UIAPI, connect ConnectFunc, saveToken SaveTokenFunc) UIAPI {
 	m := NewModelUI(api)
-
-	if _, err := tea.NewProgram(m).Run(); err != nil {
+	m.connect = connect
+	m.saveToken = saveToken
+	m.new.draftFile = draftPath()
+	m.transactions.deletedFile = deletedPath()
+	m.new.usage = loadUsage(usagePath())
+	m.sessionFile = sessionPath()
+	m.crash.path = crashPath()
+	m.restoreSession()
+
+	final, err := tea.NewProgram(m).Run()
+	if err != nil {
+		if path := m.crash.reportPath(); path != "" {
+			fmt.Println("Crash report written to", path)
+		}
 		fmt.Println("Error running program:", err)
 		os.Exit(1)
 	}
+	if fm, ok := final.(modelUI); ok {
+		fm.saveSession()
+		return fm.api
+	}
+	return api
 }
 
 func NewModelUI(api UIAPI) modelUI {
 	lc := NewDefaultLayout()
 	lc = lc.WithFullTransactionView(viper.GetBool("ui.full_view"))
 
-	sp := spinner.New()
-	sp.Spinner = spinner.Dot
+	// The summary and insights of the selected period share one scope
+	periodRequests := newRequestScope()
+	amounts := money.New(api)
 
 	m := modelUI{
-		api:          api,
-		transactions: NewModelTransactions(api),
-		new:          newModelTransaction(api),
-		assets:       newModelAssets(api),
-		categories:   newModelCategories(api),
-		expenses:     newModelExpenses(api),
-		revenues:     newModelRevenues(api),
-		liabilities:  newModelLiabilities(api),
-		prompt:       prompt.New(),
-		periodPicker: period.New(),
-		notify:       notify.New(),
-		summary:      newModelSummary(api),
-		spinner:      sp,
-		keymap:       DefaultUIKeyMap(),
-		help:         help.New(),
-		styles:       DefaultStyles(),
-		Width:        80,
-		layout:       lc,
-		loadStatus: map[string]bool{
-			"asset":      false,
-			"expense":    false,
-			"revenue":    false,
-			"liability":  false,
-			"categories": false,
-		},
+		api:            api,
+		periodRequests: periodRequests,
+		transactions:   NewModelTransactions(api),
+		new:            newModelTransaction(api),
+		assets:         newModelAssets(api),
+		categories:     newModelCategories(api, periodRequests),
+		tags:           newModelTags(api, periodRequests),
+		expenses:       newModelExpenses(api, periodRequests),
+		revenues:       newModelRevenues(api, periodRequests),
+		liabilities:    newModelLiabilities(api),
+		prompt:         prompt.New(),
+		periodPicker:   period.New(),
+		helpOverlay:    helpoverlay.New(),
+		apiLog:         apilog.New(),
+		deletedLog:     deletedlog.New(amounts),
+		notifyLog:      notifylog.New(),
+		cells:          cellview.New(),
+		integrity:      integrity.New(amounts),
+		details:        accountdetail.New(amounts),
+		category:       categorydetail.New(amounts),
+		assetForm:      assetform.New(),
+		transferForm:   transferform.New(),
+		repeatForm:     recurrenceform.New(amounts),
+		notify:         notify.New(),
+		summary:        newModelSummary(api, periodRequests),
+		loading:        loading.New(),
+		panels:         loadPanels(),
+		keymap:         DefaultUIKeyMap(),
+		panelKeymap:    DefaultPanelKeyMap(),
+		vim:            newVimLayer(viper.GetBool("ui.vim_mode")),
+		help:           help.New(),
+		styles:         DefaultStyles(),
+		Width:          80,
+		layout:         lc,
+		loadStatus:     newLoadStatus(),
+		events:         newEventBus(),
+		crash:          newCrashReporter(),
+		zoomed:         map[state]bool{},
 	}
+	subscribePanels(m.events)
 
 	m.help.Styles.FullKey = m.styles.HelpFullKey
 	m.help.Styles.ShortKey = m.styles.HelpShortKey
@@ -142,8 +227,11 @@ func NewModelUI(api UIAPI) modelUI {
 
 func (m modelUI) Init() tea.Cmd {
 	return tea.Batch(
-		Cmd(RefreshAllMsg{}),
-		m.spinner.Tick)
+		tea.Sequence(m.showCachedData(), Cmd(RefreshAllMsg{})),
+		m.loading.Init(),
+		reconnectTick(),
+		backupTick(backupStartDelay),
+		waitForLiveUpdate(m.api.LiveUpdates()))
 }
 
 func updateModel[T tea.Model](current T, msg tea.Msg) (T, tea.Cmd) {
@@ -157,25 +245,93 @@ func updateModel[T tea.Model](current T, msg tea.Msg) (T, tea.Cmd) {
 
 func (m modelUI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// zap.S().Debugf("UI Update: %+v", msg)
+	defer m.crash.recover(m)
+	m.crash.record(msg)
+
+	if positions, ok := msg.(UpdatePositions); ok {
+		msg = m.updateLayout(positions)
+	}
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.isAnyInputFocused() || m.periodPicker.Focused() {
+			m.vim.reset()
+		} else if keys, ok := m.vim.translate(msg); ok {
+			return m.replayKeys(keys)
+		}
 		switch {
 		case key.Matches(msg, m.keymap.Quit):
 			return m, tea.Quit
 		case key.Matches(msg, m.keymap.ShowShortHelp):
 			if !m.isAnyInputFocused() {
+				if viper.GetBool("ui.help_overlay") {
+					return m, helpoverlay.Open(m.helpGroups())
+				}
 				m.help.ShowAll = !m.help.ShowAll
 				m.assets.list.Help.ShowAll = m.help.ShowAll
 				m.categories.list.Help.ShowAll = m.help.ShowAll
+				m.tags.list.Help.ShowAll = m.help.ShowAll
 				m.expenses.list.Help.ShowAll = m.help.ShowAll
 				m.revenues.list.Help.ShowAll = m.help.ShowAll
 				m.assets.list.SetShowHelp(m.help.ShowAll)
 				m.categories.list.SetShowHelp(m.help.ShowAll)
+				m.tags.list.SetShowHelp(m.help.ShowAll)
 				m.expenses.list.SetShowHelp(m.help.ShowAll)
 				m.revenues.list.SetShowHelp(m.help.ShowAll)
 				return m, tea.WindowSize()
 			}
+		case key.Matches(msg, m.keymap.SwitchProfile):
+			if !m.isAnyInputFocused() && m.connect != nil {
+				return m, m.askProfile()
+			}
+		case key.Matches(msg, m.keymap.Retry):
+			if !m.isAnyInputFocused() && m.api.Unauthorized() {
+				return m, m.askToken("Paste a new API token: ")
+			}
+			if !m.isAnyInputFocused() {
+				var cmds []tea.Cmd
+				if m.health.Err != nil {
+					cmds = append(cmds, m.checkHealth())
+				}
+				if cmd := m.retryFailedLoads(); cmd != nil {
+					cmds = append(cmds, cmd)
+				}
+				if len(cmds) > 0 {
+					return m, tea.Batch(cmds...)
+				}
+			}
+		case key.Matches(msg, m.keymap.APILog):
+			if !m.isAnyInputFocused() {
+				return m, apilog.Open(m.api.RecentRequests(), m.api.SessionStats())
+			}
+		case key.Matches(msg, m.keymap.Deleted):
+			if !m.isAnyInputFocused() {
+				return m, openDeletedLog(m.transactions.deletedFile)
+			}
+		case key.Matches(msg, m.keymap.Notifications):
+			if !m.isAnyInputFocused() {
+				return m, notifylog.Open(m.notify.History())
+			}
+		case key.Matches(msg, m.keymap.Integrity):
+			if !m.isAnyInputFocused() {
+				return m, checkIntegrity(m.api)
+			}
+		case key.Matches(msg, m.keymap.ExportReport):
+			if !m.isAnyInputFocused() {
+				return m, askReport(m.api)
+			}
+		case key.Matches(msg, m.keymap.QuickTransfer):
+			if !m.isAnyInputFocused() && !m.periodPicker.Focused() {
+				return m, transferform.Open(ownAccounts(m.api))
+			}
+		case key.Matches(msg, m.keymap.PayBill):
+			if !m.isAnyInputFocused() && !m.periodPicker.Focused() {
+				return m, paySubscription(m.api, m.api.SubscriptionsDue())
+			}
+		case key.Matches(msg, m.keymap.Zoom):
+			if !m.isAnyInputFocused() && !m.periodPicker.Focused() {
+				return m, m.toggleZoom()
+			}
 		case key.Matches(msg, m.keymap.PeriodPicker):
 			if !m.isAnyInputFocused() {
 				return m, period.Open(
@@ -184,66 +340,57 @@ func (m modelUI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				)
 			}
 		}
+		if !m.isAnyInputFocused() && !m.periodPicker.Focused() {
+			if i := m.panelByKey(msg.String()); i >= 0 {
+				return m, m.openPanel(i)
+			}
+			if m.state == customView && key.Matches(msg, m.panelKeymap.Back) {
+				return m, SetView(transactionsView)
+			}
+		}
 	case period.SelectedMsg:
 		m.transactions.currentSearch = ""
+		m.transactions.dates = dateRange{}
+		m.periodRequests.Renew()
+		m.transactions.requests.Renew()
 		m.api.SetPeriod(msg.Year, msg.Month)
 		return m, tea.Batch(
+			periodHook(m.api.PeriodStart(), m.api.PeriodEnd()),
 			Cmd(RefreshTransactionsMsg{}),
 			Cmd(RefreshSummaryMsg{}),
 			Cmd(RefreshCategoryInsightsMsg{}),
+			Cmd(RefreshTagInsightsMsg{}),
 			Cmd(RefreshRevenueInsightsMsg{}),
 			Cmd(RefreshExpenseInsightsMsg{}),
 		)
 	case period.CloseMsg:
-	case UpdatePositions:
-		// TODO: Refactor, bad design
-		// Use current layout
-		globalWidth := m.layout.Width
-		fullView := msg.layout.GetFullTransactionView()
-		if msg.layout.Width != 0 {
-			globalWidth = msg.layout.Width
-		}
-
-		h, _ := m.styles.Base.GetFrameSize()
-		m.Width = globalWidth - h
-
-		topSize := 5
-		if m.help.ShowAll {
-			topSize += lipgloss.Height(m.HelpView())
-		}
-
-		leftSize := 0
-		tabBarSize := 2
-		tabBarWidth := lipgloss.Width(m.tabBar())
-		switch m.state {
-		case transactionsView, assetsView:
-			if !fullView {
-				leftSize = max(
-					lipgloss.Width(m.assets.View()),
-					lipgloss.Width(m.summary.View()),
-					tabBarWidth,
-				) + h
-			} else {
-				tabBarSize = 0
-			}
-		case categoriesView:
-			leftSize = max(lipgloss.Width(m.categories.View()), tabBarWidth) + h
-		case expensesView:
-			leftSize = max(lipgloss.Width(m.expenses.View()), tabBarWidth) + h
-		case revenuesView:
-			leftSize = max(lipgloss.Width(m.revenues.View()), tabBarWidth) + h
-		case liabilitiesView:
-			leftSize = max(lipgloss.Width(m.liabilities.View()), tabBarWidth) + h
-		}
-		m.layout = m.layout.
-			WithTopSize(topSize).
-			WithLeftSize(leftSize)
-		m.layout.TabBarSize = tabBarSize
-
+	case accountdetail.EditOpeningBalanceMsg:
+		return m, askOpeningBalance(m.api, msg.Account)
+	case notifylog.OpenLinkMsg:
+		return m, m.openLink(msg)
+	case deletedlog.ExportMsg:
+		return m, askDeletedExport(msg.Entries)
+	case transferform.SubmitMsg:
+		return m, createTransfer(m.api, msg.Transfer)
+	case recurrenceform.SubmitMsg:
+		return m, createRecurrence(m.api, msg.Transaction, msg.Schedule)
+	case OpenInWebMsg:
+		return m, m.openInWeb(msg.Path)
+	case ProfileSwitchedMsg:
+		m.saveSession()
+		viper.Set("profile", msg.Profile)
+		m.periodRequests.Renew()
+		m.transactions.requests.Renew()
+		m = m.withAPI(msg.API)
+		return m, tea.Batch(
+			Cmd(RefreshAllMsg{}),
+			notify.NotifyLog(fmt.Sprintf("Switched to profile %s", msg.Profile)),
+		)
 	case tea.WindowSizeMsg:
-		return m, Cmd(UpdatePositions{
-			layout: m.layout.WithSize(msg.Width, msg.Height),
-		})
+		// The positions follow the latest size, a late update of an earlier
+		// resize must not bring its size back
+		m.layout = m.layout.WithSize(msg.Width, msg.Height)
+		return m, Cmd(UpdatePositions{})
 
 	case SetFocusedViewMsg:
 		if msg.state == transactionsView {
@@ -261,6 +408,11 @@ func (m modelUI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else {
 			m.categories.Blur()
 		}
+		if msg.state == tagsView {
+			m.tags.Focus()
+		} else {
+			m.tags.Blur()
+		}
 		if msg.state == expensesView {
 			m.expenses.Focus()
 		} else {
@@ -289,45 +441,57 @@ func (m modelUI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		viper.Set("ui.full_view", m.layout.ToggleFullTransactionView())
 		return m, Cmd(UpdatePositions{layout: m.layout})
 	case DataLoadCompletedMsg:
-		m.loadStatus[msg.DataType] = true
-	case LazyLoadMsg:
-		c := msg.c - 1
-		for _, loaded := range m.loadStatus {
-			if !loaded {
-				if c <= 0 {
-					return m, notify.NotifyWarn("Could not load all resources")
-				}
-				return m, tea.Tick(time.Second*1, func(t time.Time) tea.Msg {
-					return LazyLoadMsg{t: t, c: c}
-				})
-			}
+		return m, tea.Batch(m.setLoadState(msg.DataType, loadDone, nil), m.notifyBalanceAlerts(msg.DataType))
+	case DataLoadFailedMsg:
+		return m, tea.Batch(m.setLoadState(msg.DataType, loadFailed, msg.Err), m.checkAuth())
+	case notify.NotifyMsg:
+		if msg.Level != notify.Log && m.api.Unauthorized() {
+			zap.L().Debug("Dropped notification while API token is rejected",
+				zap.String("message", msg.Message))
+			return m, m.checkAuth()
 		}
-		return m, tea.Batch(
-			Cmd(RefreshTransactionsMsg{}),
-			Cmd(RefreshSummaryMsg{}))
-	case RefreshAllMsg:
-		m.loadStatus = map[string]bool{
-			"asset":      false,
-			"expense":    false,
-			"revenue":    false,
-			"liability":  false,
-			"categories": false,
+	case tokenCheckedMsg:
+		return m, m.tokenChecked(msg)
+	case tokenCanceledMsg:
+		return m, m.tokenCanceled()
+	case reconnectTickMsg:
+		// Background refreshes are paused until the token is replaced
+		if m.api.Unauthorized() {
+			return m, reconnectTick()
+		}
+		if cmd := m.reconnect(); cmd != nil {
+			return m, cmd
 		}
+		return m, reconnectTick()
+	case liveUpdateMsg:
+		return m, m.liveUpdate(msg)
+	case backupTickMsg:
+		return m, tea.Batch(m.backupIfDue(time.Now()), backupTick(backupCheckInterval))
+	case backupDoneMsg:
+		return m, m.backupDone(msg)
+	case ReconnectedMsg:
+		return m, tea.Batch(m.reconnected(msg), reconnectTick())
+	case loading.StartMsg:
+		// Shown even while an overlay takes the messages
+		m.loading, _ = m.loading.Update(msg)
+		return m, nil
+	case EventMsg:
+		return m, m.events.dispatch(msg)
+	case HealthCheckedMsg:
+		m.health = msg
+		return m, tea.WindowSize()
+	case RefreshAllMsg:
+		m.api.ForgetInsights()
+		m.loadStatus = newLoadStatus()
+		view := m.resumeView
+		m.resumeView = transactionsView
 		return m, tea.Batch(
-			SetView(transactionsView),
-			tea.WindowSize(),
-			Cmd(RefreshAssetsMsg{}),
-			Cmd(RefreshLiabilitiesMsg{}),
-			Cmd(RefreshExpensesMsg{}),
-			Cmd(RefreshRevenuesMsg{}),
-			Cmd(RefreshCategoriesMsg{}),
-			tea.Tick(time.Millisecond*100, func(t time.Time) tea.Msg {
-				return LazyLoadMsg{
-					t: t,
-					c: m.api.TimeoutSeconds(),
-				}
-			}),
-		)
+			m.checkHealth(),
+			tea.Sequence(m.refreshBaseData(), tea.Batch(
+				SetView(view),
+				tea.WindowSize(),
+				m.startReadyLoads(),
+			)))
 	}
 
 	var cmds []tea.Cmd
@@ -339,6 +503,15 @@ func (m modelUI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, tea.Batch(cmds...)
 	}
 
+	// Keep data messages flowing while an overlay is open, only keys stop here
+	for _, o := range m.overlays() {
+		wasFocused := o.Focused()
+		cmds = append(cmds, o.update(msg))
+		if _, isKey := msg.(tea.KeyMsg); isKey && wasFocused {
+			return m, tea.Batch(cmds...)
+		}
+	}
+
 	periodPickerWasFocused := m.periodPicker.Focused()
 	m.periodPicker, cmd = updateModel(m.periodPicker, msg)
 	cmds = append(cmds, cmd)
@@ -361,6 +534,9 @@ func (m modelUI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	m.categories, cmd = updateModel(m.categories, msg)
 	cmds = append(cmds, cmd)
 
+	m.tags, cmd = updateModel(m.tags, msg)
+	cmds = append(cmds, cmd)
+
 	m.expenses, cmd = updateModel(m.expenses, msg)
 	cmds = append(cmds, cmd)
 
@@ -373,16 +549,28 @@ func (m modelUI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	m.new, cmd = updateModel(m.new, msg)
 	cmds = append(cmds, cmd)
 
-	m.spinner, cmd = m.spinner.Update(msg)
+	cmds = append(cmds, m.updatePanels(msg))
+
+	m.loading, cmd = m.loading.Update(msg)
 	cmds = append(cmds, cmd)
 
 	return m, tea.Batch(cmds...)
 }
 
 func (m modelUI) View() string {
+	defer m.crash.recover(m)
 	// TODO: Refactor, too complicated
 	var s strings.Builder
 
+	if m.tooSmall() {
+		return m.tooSmallView()
+	}
+	for _, o := range m.overlays() {
+		if o.Focused() {
+			return o.view(m.layout.GetWidth(), m.layout.GetHeight())
+		}
+	}
+
 	// TODO: Move to model
 	if m.prompt.Focused() {
 		s.WriteString(m.prompt.WithWidth(m.layout.GetWidth()).View() + "\n")
@@ -402,80 +590,79 @@ func (m modelUI) View() string {
 				headerRenderer = m.styles.PromptEditTr
 			}
 		} else {
-			if m.transactions.currentSearch != "" {
-				header = header + " | Search: " + m.transactions.currentSearch
-			} else {
-				header = header + fmt.Sprintf(" | p %s %d",
-					m.api.PeriodStart().Month(),
-					m.api.PeriodStart().Year())
-			}
-			if !m.transactions.currentAccount.IsEmpty() {
-				header = header + " | Account: " + m.transactions.currentAccount.Name
-			}
-			if !m.transactions.currentCategory.IsEmpty() {
-				header = header + " | Category: " + m.transactions.currentCategory.Name
-			}
-			if m.transactions.currentFilter != "" {
-				header = header + " | Filter: " + m.transactions.currentFilter
-			}
+			header = headerWithChips(header, renderFilterChips(m.transactions.filterChips(), m.styles), m.Width)
 		}
 
-		if loading.Load() > 0 {
-			msg := buildLoadingMessage()
-			header += " | " + m.spinner.View() + msg
-		}
 		s.WriteString(headerRenderer.Width(m.Width).Render(header) + "\n")
 	}
 
-	switch m.state {
-	case transactionsView:
-		if m.layout.GetFullTransactionView() {
-			s.WriteString(m.styles.BaseFocused.Render(m.transactions.View()))
-		} else {
-			s.WriteString(lipgloss.JoinHorizontal(lipgloss.Top,
+	if banner := m.healthBanner(); banner != "" {
+		s.WriteString(banner + "\n")
+	}
+
+	if view, ok := m.zoomedView(); ok {
+		s.WriteString(view)
+	} else {
+		switch m.state {
+		case transactionsView:
+			if m.layout.GetFullTransactionView() {
+				s.WriteString(m.styles.BaseFocused.Render(m.panelView("transactions", "Transactions", m.transactions.View())))
+			} else {
+				s.WriteString(lipgloss.JoinHorizontal(lipgloss.Top,
+					m.styles.Base.Render(
+						lipgloss.JoinVertical(lipgloss.Left, m.tabBar(), m.panelView("summary", m.summary.list.Title, m.summary.View()), m.panelView("asset", m.assets.list.Title, m.assets.View()))),
+					m.styles.BaseFocused.Render(m.panelView("transactions", "Transactions", m.transactions.View()))))
+			}
+		case assetsView:
+			s.WriteString(lipgloss.JoinHorizontal(
+				lipgloss.Top,
+				m.styles.BaseFocused.Render(
+					lipgloss.JoinVertical(lipgloss.Left, m.tabBar(), m.panelView("summary", m.summary.list.Title, m.summary.View()), m.panelView("asset", m.assets.list.Title, m.assets.View()))),
+				m.styles.Base.Render(m.panelView("transactions", "Transactions", m.transactions.View()))))
+		case categoriesView:
+			s.WriteString(lipgloss.JoinHorizontal(
+				lipgloss.Top,
+				m.styles.BaseFocused.Render(
+					lipgloss.JoinVertical(lipgloss.Left, m.tabBar(), m.panelView("categories", m.categories.list.Title, m.categories.View()))),
+				m.styles.Base.Render(m.panelView("transactions", "Transactions", m.transactions.View()))))
+		case tagsView:
+			s.WriteString(lipgloss.JoinHorizontal(
+				lipgloss.Top,
+				m.styles.BaseFocused.Render(
+					lipgloss.JoinVertical(lipgloss.Left, m.tabBar(), m.panelView("tags", m.tags.list.Title, m.tags.View()))),
+				m.styles.Base.Render(m.panelView("transactions", "Transactions", m.transactions.View()))))
+		case expensesView:
+			s.WriteString(lipgloss.JoinHorizontal(
+				lipgloss.Top,
+				m.styles.BaseFocused.Render(
+					lipgloss.JoinVertical(lipgloss.Left, m.tabBar(), m.panelView("expense", m.expenses.list.Title, m.expenses.View()))),
+				m.styles.Base.Render(m.panelView("transactions", "Transactions", m.transactions.View()))))
+		case revenuesView:
+			s.WriteString(lipgloss.JoinHorizontal(
+				lipgloss.Top,
+				m.styles.BaseFocused.Render(
+					lipgloss.JoinVertical(lipgloss.Left, m.tabBar(), m.panelView("revenue", m.revenues.list.Title, m.revenues.View()))),
+				m.styles.Base.Render(m.panelView("transactions", "Transactions", m.transactions.View()))))
+		case liabilitiesView:
+			s.WriteString(lipgloss.JoinHorizontal(
+				lipgloss.Top,
+				m.styles.BaseFocused.Render(
+					lipgloss.JoinVertical(lipgloss.Left, m.tabBar(), m.panelView("liabilities", m.liabilities.list.Title, m.liabilities.View()))),
+				m.styles.Base.Render(m.panelView("transactions", "Transactions", m.transactions.View()))))
+		case newView:
+			s.WriteString(lipgloss.JoinHorizontal(
+				lipgloss.Top,
 				m.styles.Base.Render(
-					lipgloss.JoinVertical(lipgloss.Left, m.tabBar(), m.summary.View(), m.assets.View())),
-				m.styles.BaseFocused.Render(m.transactions.View())))
+					lipgloss.JoinVertical(lipgloss.Left, m.panelView("summary", m.summary.list.Title, m.summary.View()), m.panelView("asset", m.assets.list.Title, m.assets.View()))),
+				m.styles.BaseFocused.Render(m.new.View())))
+		case customView:
+			s.WriteString(m.styles.BaseFocused.Render(
+				lipgloss.JoinVertical(lipgloss.Left, m.tabBar(), m.customPanelView())))
 		}
-	case assetsView:
-		s.WriteString(lipgloss.JoinHorizontal(
-			lipgloss.Top,
-			m.styles.BaseFocused.Render(
-				lipgloss.JoinVertical(lipgloss.Left, m.tabBar(), m.summary.View(), m.assets.View())),
-			m.styles.Base.Render(m.transactions.View())))
-	case categoriesView:
-		s.WriteString(lipgloss.JoinHorizontal(
-			lipgloss.Top,
-			m.styles.BaseFocused.Render(
-				lipgloss.JoinVertical(lipgloss.Left, m.tabBar(), m.categories.View())),
-			m.styles.Base.Render(m.transactions.View())))
-	case expensesView:
-		s.WriteString(lipgloss.JoinHorizontal(
-			lipgloss.Top,
-			m.styles.BaseFocused.Render(
-				lipgloss.JoinVertical(lipgloss.Left, m.tabBar(), m.expenses.View())),
-			m.styles.Base.Render(m.transactions.View())))
-	case revenuesView:
-		s.WriteString(lipgloss.JoinHorizontal(
-			lipgloss.Top,
-			m.styles.BaseFocused.Render(
-				lipgloss.JoinVertical(lipgloss.Left, m.tabBar(), m.revenues.View())),
-			m.styles.Base.Render(m.transactions.View())))
-	case liabilitiesView:
-		s.WriteString(lipgloss.JoinHorizontal(
-			lipgloss.Top,
-			m.styles.BaseFocused.Render(
-				lipgloss.JoinVertical(lipgloss.Left, m.tabBar(), m.liabilities.View())),
-			m.styles.Base.Render(m.transactions.View())))
-	case newView:
-		s.WriteString(lipgloss.JoinHorizontal(
-			lipgloss.Top,
-			m.styles.Base.Render(
-				lipgloss.JoinVertical(lipgloss.Left, m.summary.View(), m.assets.View())),
-			m.styles.BaseFocused.Render(m.new.View())))
 	}
 	s.WriteString("\n")
 
+	s.WriteString(m.statusBar() + "\n")
 	s.WriteString(m.notify.WithWidth(m.layout.GetWidth()).View() + "\n")
 	s.WriteString(m.help.Styles.ShortKey.Render(m.HelpView()))
 
@@ -497,15 +684,73 @@ func (m *modelUI) HelpView() string {
 		help += m.help.View(m.liabilities.keymap)
 	case categoriesView:
 		help += m.help.View(m.categories.keymap)
+	case tagsView:
+		help += m.help.View(m.tags.keymap)
 	case newView:
 		help += m.help.View(m.new.keymap)
+	case customView:
+		help += m.help.View(m.panelKeymap)
 	}
 	if m.help.ShowAll {
 		help = lipgloss.JoinHorizontal(lipgloss.Left, help, m.help.View(m.keymap))
+		if m.vim.enabled {
+			help = lipgloss.JoinHorizontal(lipgloss.Left, help, m.help.View(m.vim.keymap))
+		}
 	}
 	return help
 }
 
+// helpGroups returns the key bindings for the help overlay, starting with
+// the current view.
+func (m *modelUI) helpGroups() []helpoverlay.Group {
+	views := []struct {
+		state  state
+		title  string
+		keymap help.KeyMap
+	}{
+		{transactionsView, "Transactions", m.transactions.keymap},
+		{assetsView, "Assets", m.assets.keymap},
+		{expensesView, "Expenses", m.expenses.keymap},
+		{revenuesView, "Revenues", m.revenues.keymap},
+		{liabilitiesView, "Liabilities", m.liabilities.keymap},
+		{categoriesView, "Categories", m.categories.keymap},
+		{tagsView, "Tags", m.tags.keymap},
+		{newView, "Transaction form", m.new.keymap},
+		{customView, "Panels", m.panelKeymap},
+	}
+
+	var current []helpoverlay.Group
+	var others []helpoverlay.Group
+	for _, v := range views {
+		g := helpoverlay.NewGroup(v.title, v.keymap)
+		if v.state == m.state {
+			current = append(current, g)
+		} else {
+			others = append(others, g)
+		}
+	}
+
+	groups := append(current, helpoverlay.NewGroup("Global", m.keymap))
+	if m.vim.enabled {
+		groups = append(groups, helpoverlay.NewGroup("Vim", m.vim.keymap))
+	}
+	return append(groups, others...)
+}
+
+// replayKeys feeds keys produced by the vim layer through Update.
+func (m modelUI) replayKeys(keys []tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var (
+		model tea.Model = m
+		cmds  []tea.Cmd
+	)
+	for _, k := range keys {
+		var cmd tea.Cmd
+		model, cmd = model.Update(k)
+		cmds = append(cmds, cmd)
+	}
+	return model, tea.Batch(cmds...)
+}
+
 func (m *modelUI) tabBar() string {
 	type tab struct {
 		key   string
@@ -519,19 +764,24 @@ func (m *modelUI) tabBar() string {
 		{"e", "Expns.", expensesView},
 		{"i", "Revnu.", revenuesView},
 		{"o", "Liab.", liabilitiesView},
+		{"#", "Tags", tagsView},
 	}
 
 	var parts []string
-	for _, t := range tabs {
-		active := m.state == t.state ||
-			(m.state == transactionsView && t.state == assetsView)
-		label := t.key + " " + t.label
+	render := func(label string, active bool) {
 		if active {
 			parts = append(parts, m.styles.TabActive.Render(label))
 		} else {
 			parts = append(parts, m.styles.TabInactive.Render(label))
 		}
 	}
+	for _, t := range tabs {
+		render(t.key+" "+t.label, m.state == t.state ||
+			(m.state == transactionsView && t.state == assetsView))
+	}
+	for i, p := range m.panels {
+		render(p.Key()+" "+p.Title(), m.state == customView && i == m.activePanel)
+	}
 
 	return strings.Join(parts, m.styles.TabInactive.Render(" ")) + "\n"
 }
@@ -541,89 +791,21 @@ func (m *modelUI) SetState(s state) {
 }
 
 func (m *modelUI) isAnyInputFocused() bool {
+	for _, o := range m.overlays() {
+		if o.Focused() {
+			return true
+		}
+	}
 	return m.prompt.Focused() ||
 		m.new.Focused() ||
 		m.assets.list.FilterInput.Focused() ||
 		m.expenses.list.FilterInput.Focused() ||
 		m.revenues.list.FilterInput.Focused() ||
 		m.categories.list.FilterInput.Focused() ||
+		m.tags.list.FilterInput.Focused() ||
 		m.liabilities.list.FilterInput.Focused()
 }
 
 func SetView(state state) tea.Cmd {
 	return Cmd(SetFocusedViewMsg{state: state})
 }
-
-func startLoading(message string) string {
-	for {
-		current := loading.Load()
-		if current >= 100 {
-			return "" // Max operations reached
-		}
-		if loading.CompareAndSwap(current, current+1) {
-			break
-		}
-	}
-
-	// Generate unique operation ID
-	opID := fmt.Sprintf("op_%d", operationIDSeq.Add(1))
-
-	loadingOps.Store(opID, message)
-
-	return opID
-}
-
-func stopLoading(opID string) {
-	if opID == "" {
-		return // Invalid operation ID
-	}
-
-	loadingOps.Delete(opID)
-
-	for {
-		current := loading.Load()
-		if current <= 0 {
-			return
-		}
-		if loading.CompareAndSwap(current, current-1) {
-			return
-		}
-	}
-}
-
-func buildLoadingMessage() string {
-	var messages []string
-
-	loadingOps.Range(func(key, value any) bool {
-		if msg, ok := value.(string); ok {
-			abbrev := msg
-
-			if len(abbrev) > 25 {
-				abbrev = abbrev[:22] + "..."
-			}
-
-			messages = append(messages, abbrev)
-		}
-		return true
-	})
-
-	if len(messages) == 0 {
-		return "..."
-	}
-
-	const maxDisplay = 5
-	count := len(messages)
-
-	if count == 1 {
-		return messages[0]
-	}
-
-	if count <= maxDisplay {
-		return fmt.Sprintf("(%d) %s", count, strings.Join(messages, " "))
-	}
-
-	// Show first messages + remaining count
-	shown := messages[:maxDisplay]
-	remaining := count - maxDisplay
-	return fmt.Sprintf("(%d) %s | +%d more", count, strings.Join(shown, " "), remaining)
-}
@@ -5,16 +5,25 @@ SPDX-License-Identifier: Apache-2.0
 package ui
 
 import (
+	"context"
+	"slices"
 	"strings"
-	"sync"
 	"testing"
 	"time"
 
 	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/accountdetail"
+	"ffiii-tui/internal/ui/apilog"
+	"ffiii-tui/internal/ui/assetform"
+	"ffiii-tui/internal/ui/categorydetail"
+	"ffiii-tui/internal/ui/helpoverlay"
+	"ffiii-tui/internal/ui/loading"
 	"ffiii-tui/internal/ui/period"
 	"ffiii-tui/internal/ui/prompt"
+	"ffiii-tui/internal/ui/transferform"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/viper"
 )
 
 // Mock UIAPI implementation for testing
@@ -26,6 +35,13 @@ type mockUIAPI struct {
 	setPeriodYear        int
 	setPeriodMonth       time.Month
 
+	// InsightsCacheAPI
+	forgetInsightsCalled int
+	liveUpdates          chan firefly.WebhookEvent
+	sessionStats         firefly.SessionStats
+	unauthorized         bool
+	apiKey               string
+
 	// SummaryAPI
 	updateSummaryCalled int
 	getMaxWidthFunc     func() int
@@ -35,6 +51,8 @@ type mockUIAPI struct {
 	updateAccountsCalled int
 	accountsByTypeFunc   func(accountType string) []firefly.Account
 	accountBalanceFunc   func(accountID string) float64
+	accountActivityFunc  func(accountID string) string
+	convertToPrimaryFunc func(amount float64, currencyCode string) (float64, bool)
 
 	// CategoriesAPI
 	updateCategoriesCalled         int
@@ -54,6 +72,7 @@ type mockUIAPI struct {
 
 	// TransactionAPI
 	listTransactionsFunc  func(query string) ([]firefly.Transaction, error)
+	getTransactionFunc    func(transactionID string) (firefly.Transaction, error)
 	deleteTransactionFunc func(transactionID string) error
 
 	// TransactionWriteAPI
@@ -61,7 +80,7 @@ type mockUIAPI struct {
 	updateTransactionFunc func(transactionID string, tx firefly.RequestTransaction) (string, error)
 
 	// Account creation
-	createAssetAccountFunc     func(name, currencyCode string) error
+	createAssetAccountFunc     func(na firefly.NewAsset) error
 	createExpenseAccountFunc   func(name string) error
 	createRevenueAccountFunc   func(name string) error
 	createLiabilityAccountFunc func(nl firefly.NewLiability) error
@@ -72,12 +91,44 @@ type mockUIAPI struct {
 	periodStart     time.Time
 	periodEnd       time.Time
 	primaryCurrency firefly.Currency
+
+	// SummaryAPI
+	subscriptionsDue []firefly.Subscription
+
+	// StatusAPI
+	serverURL    string
+	retryAttempt int
+	maxRetries   int
+
+	// SnapshotAPI
+	stale                 []string
+	savedAt               time.Time
+	cachedTransactions    []firefly.Transaction
+	refreshBaseDataCalled int
+
+	// OfflineAPI
+	offline         bool
+	queuedWrites    int
+	reconnectFunc   func() (int, []firefly.Conflict, error)
+	reconnectCalled int
+
+	// TraceAPI
+	recentRequests []firefly.RequestTrace
+
+	// HealthAPI
+	aboutFunc   func() (firefly.About, error)
+	aboutCalled int
+
+	// BackupAPI
+	backupTransactions []firefly.Transaction
+	backupErr          error
 }
 
 func newTestUIAPI() *mockUIAPI {
 	now := time.Now()
 	return &mockUIAPI{
 		timeoutSeconds: 10,
+		serverURL:      "https://firefly.example.com/api/v1",
 		periodStart:    time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC),
 		periodEnd:      time.Date(now.Year(), now.Month()+1, 0, 23, 59, 59, 0, time.UTC),
 		primaryCurrency: firefly.Currency{
@@ -108,15 +159,60 @@ func (m *mockUIAPI) SetPeriod(year int, month time.Month) {
 	m.periodEnd = m.periodStart.AddDate(0, 1, 0).Add(-time.Nanosecond)
 }
 
-func (m *mockUIAPI) PeriodStart() time.Time { return m.periodStart }
-func (m *mockUIAPI) PeriodEnd() time.Time   { return m.periodEnd }
-func (m *mockUIAPI) TimeoutSeconds() int    { return m.timeoutSeconds }
+func (m *mockUIAPI) PeriodStart() time.Time  { return m.periodStart }
+func (m *mockUIAPI) PeriodEnd() time.Time    { return m.periodEnd }
+func (m *mockUIAPI) TimeoutSeconds() int     { return m.timeoutSeconds }
+func (m *mockUIAPI) ServerURL() string       { return m.serverURL }
+func (m *mockUIAPI) RetryStatus() (int, int) { return m.retryAttempt, m.maxRetries }
+
+// TraceAPI methods
+func (m *mockUIAPI) RecentRequests() []firefly.RequestTrace { return m.recentRequests }
+func (m *mockUIAPI) SessionStats() firefly.SessionStats     { return m.sessionStats }
+
+// HealthAPI methods
+func (m *mockUIAPI) GetAbout(_ context.Context) (firefly.About, error) {
+	m.aboutCalled++
+	if m.aboutFunc != nil {
+		return m.aboutFunc()
+	}
+	return firefly.About{Version: firefly.MinVersion}, nil
+}
+
+// SnapshotAPI methods
+func (m *mockUIAPI) Stale() ([]string, time.Time) { return m.stale, m.savedAt }
+func (m *mockUIAPI) CachedTransactions() []firefly.Transaction {
+	return m.cachedTransactions
+}
+
+func (m *mockUIAPI) RefreshBaseData(_ context.Context) error {
+	m.refreshBaseDataCalled++
+	m.stale = slices.DeleteFunc(m.stale, func(s string) bool { return s == firefly.StaleBase })
+	return nil
+}
+
+// OfflineAPI methods
+func (m *mockUIAPI) Offline() bool     { return m.offline }
+func (m *mockUIAPI) QueuedWrites() int { return m.queuedWrites }
+
+func (m *mockUIAPI) Reconnect(_ context.Context) (int, []firefly.Conflict, error) {
+	m.reconnectCalled++
+	if m.reconnectFunc != nil {
+		return m.reconnectFunc()
+	}
+	return 0, nil, nil
+}
 
 // CurrencyAPI methods
 func (m *mockUIAPI) PrimaryCurrency() firefly.Currency { return m.primaryCurrency }
+func (m *mockUIAPI) GetCurrencyByCode(code string) firefly.Currency {
+	if strings.EqualFold(code, m.primaryCurrency.Code) {
+		return m.primaryCurrency
+	}
+	return firefly.Currency{}
+}
 
 // SummaryAPI methods
-func (m *mockUIAPI) UpdateSummary() error {
+func (m *mockUIAPI) UpdateSummary(_ context.Context) error {
 	m.updateSummaryCalled++
 	return nil
 }
@@ -135,8 +231,20 @@ func (m *mockUIAPI) SummaryItems() map[string]firefly.SummaryItem {
 	return map[string]firefly.SummaryItem{}
 }
 
+func (m *mockUIAPI) PiggyBanks() []firefly.PiggyBank {
+	return nil
+}
+
+func (m *mockUIAPI) BurnRate() firefly.BurnRate {
+	return firefly.BurnRate{}
+}
+
+func (m *mockUIAPI) SubscriptionsDue() []firefly.Subscription {
+	return m.subscriptionsDue
+}
+
 // AccountsAPI methods
-func (m *mockUIAPI) UpdateAccounts(accountType string) error {
+func (m *mockUIAPI) UpdateAccounts(_ context.Context, accountType string) error {
 	m.updateAccountsCalled++
 	return nil
 }
@@ -155,42 +263,81 @@ func (m *mockUIAPI) AccountBalance(accountID string) float64 {
 	return 0
 }
 
+func (m *mockUIAPI) AccountLastActivity(accountID string) string {
+	if m.accountActivityFunc != nil {
+		return m.accountActivityFunc(accountID)
+	}
+	return ""
+}
+
+func (m *mockUIAPI) ConvertToPrimary(amount float64, currencyCode string) (float64, bool) {
+	if m.convertToPrimaryFunc != nil {
+		return m.convertToPrimaryFunc(amount, currencyCode)
+	}
+	return amount, false
+}
+
 // Account creation methods
-func (m *mockUIAPI) CreateAssetAccount(name, currencyCode string) error {
+func (m *mockUIAPI) CreateAssetAccount(_ context.Context, na firefly.NewAsset) error {
 	if m.createAssetAccountFunc != nil {
-		return m.createAssetAccountFunc(name, currencyCode)
+		return m.createAssetAccountFunc(na)
 	}
 	return nil
 }
 
-func (m *mockUIAPI) CreateExpenseAccount(name string) error {
+func (m *mockUIAPI) CreateExpenseAccount(_ context.Context, name string) error {
 	if m.createExpenseAccountFunc != nil {
 		return m.createExpenseAccountFunc(name)
 	}
 	return nil
 }
 
-func (m *mockUIAPI) CreateRevenueAccount(name string) error {
+func (m *mockUIAPI) CreateRevenueAccount(_ context.Context, name string) error {
 	if m.createRevenueAccountFunc != nil {
 		return m.createRevenueAccountFunc(name)
 	}
 	return nil
 }
 
-func (m *mockUIAPI) CreateLiabilityAccount(nl firefly.NewLiability) error {
+func (m *mockUIAPI) CreateLiabilityAccount(_ context.Context, nl firefly.NewLiability) error {
 	if m.createLiabilityAccountFunc != nil {
 		return m.createLiabilityAccountFunc(nl)
 	}
 	return nil
 }
 
+func (m *mockUIAPI) AccountTransactions(_ context.Context, _ string, _, _ time.Time) ([]firefly.Transaction, error) {
+	return nil, nil
+}
+
+func (m *mockUIAPI) CategoryHistory(_ context.Context, _ string, _ int, _ time.Time) (firefly.CategoryHistory, error) {
+	return firefly.CategoryHistory{}, nil
+}
+
+func (m *mockUIAPI) CategoryBudget(_ string) (firefly.Budget, bool) {
+	return firefly.Budget{}, false
+}
+
+// BudgetAPI methods
+func (m *mockUIAPI) Budgets() []firefly.Budget {
+	return nil
+}
+
+func (m *mockUIAPI) BudgetAverages(_ context.Context, _ int, _ time.Time) (map[string]float64, error) {
+	return nil, nil
+}
+
+func (m *mockUIAPI) SetBudgetLimit(_ context.Context, _ firefly.Budget, _ float64, _, _ time.Time) error {
+	return nil
+}
+
 // CategoriesAPI methods
-func (m *mockUIAPI) UpdateCategories() error {
+func (m *mockUIAPI) UpdateCategories(_ context.Context) error {
 	m.updateCategoriesCalled++
 	return nil
 }
 
-func (m *mockUIAPI) UpdateCategoriesInsights() error {
+func (m *mockUIAPI) UpdateCategoriesInsights(_ context.Context) error {
 	m.updateCategoriesInsightsCalled++
 	return nil
 }
@@ -209,6 +356,10 @@ func (m *mockUIAPI) GetTotalSpentEarnedCategories() (float64, float64) {
 	return 0, 0
 }
 
+func (m *mockUIAPI) CategoryTotals() (spent, earned map[string]float64) {
+	return nil, nil
+}
+
 func (m *mockUIAPI) CategorySpent(categoryID string) float64 {
 	if m.categorySpentFunc != nil {
 		return m.categorySpentFunc(categoryID)
@@ -223,15 +374,69 @@ func (m *mockUIAPI) CategoryEarned(categoryID string) float64 {
 	return 0
 }
 
-func (m *mockUIAPI) CreateCategory(name, notes string) error {
+func (m *mockUIAPI) CreateCategory(_ context.Context, name, notes string) error {
 	if m.createCategoryFunc != nil {
 		return m.createCategoryFunc(name, notes)
 	}
 	return nil
 }
 
+// TagsAPI methods
+func (m *mockUIAPI) UpdateTags(_ context.Context) error         { return nil }
+func (m *mockUIAPI) UpdateTagsInsights(_ context.Context) error { return nil }
+func (m *mockUIAPI) TagsList() []firefly.Tag                    { return nil }
+func (m *mockUIAPI) TagSpent(_ string) float64                  { return 0 }
+func (m *mockUIAPI) TagEarned(_ string) float64                 { return 0 }
+func (m *mockUIAPI) TagUsage() map[string]int                   { return nil }
+
+func (m *mockUIAPI) Backup(_ context.Context, now time.Time) (firefly.Backup, error) {
+	if m.backupErr != nil {
+		return firefly.Backup{}, m.backupErr
+	}
+	return firefly.Backup{CreatedAt: now, Transactions: m.backupTransactions}, nil
+}
+
+func (m *mockUIAPI) UpdateOpeningBalance(_ context.Context, _ firefly.Account, _ float64, _ string) error {
+	return nil
+}
+
+func (m *mockUIAPI) RenameTag(_ context.Context, _ firefly.Tag, _ string) error {
+	return nil
+}
+
+func (m *mockUIAPI) DeleteTag(_ context.Context, _ firefly.Tag) error {
+	return nil
+}
+
+// RecurrenceAPI methods
+func (m *mockUIAPI) CreateRecurrence(_ context.Context, _ firefly.RequestRecurrence) (string, error) {
+	return "1", nil
+}
+
 // InsightsAPI methods
-func (m *mockUIAPI) UpdateExpenseInsights() error {
+func (m *mockUIAPI) ForgetInsights() {
+	m.forgetInsightsCalled++
+}
+
+// LiveAPI methods
+func (m *mockUIAPI) LiveUpdates() <-chan firefly.WebhookEvent {
+	if m.liveUpdates == nil {
+		return nil
+	}
+	return m.liveUpdates
+}
+
+// AuthAPI methods
+func (m *mockUIAPI) Unauthorized() bool {
+	return m.unauthorized
+}
+
+func (m *mockUIAPI) SetAPIKey(key string) {
+	m.apiKey = key
+	m.unauthorized = false
+}
+
+func (m *mockUIAPI) UpdateExpenseInsights(_ context.Context) error {
 	m.updateExpenseInsightsCalled++
 	return nil
 }
@@ -250,7 +455,11 @@ func (m *mockUIAPI) GetTotalExpenseDiff() float64 {
 	return 0
 }
 
-func (m *mockUIAPI) UpdateRevenueInsights() error {
+func (m *mockUIAPI) ExpenseTotals() map[string]float64 {
+	return nil
+}
+
+func (m *mockUIAPI) UpdateRevenueInsights(_ context.Context) error {
 	m.updateRevenueInsightsCalled++
 	return nil
 }
@@ -269,15 +478,26 @@ func (m *mockUIAPI) GetTotalRevenueDiff() float64 {
 	return 0
 }
 
+func (m *mockUIAPI) RevenueTotals() map[string]float64 {
+	return nil
+}
+
 // TransactionAPI methods
-func (m *mockUIAPI) ListTransactions(query string) ([]firefly.Transaction, error) {
+func (m *mockUIAPI) StreamTransactions(_ context.Context, query string, _ func(firefly.TransactionsPage)) ([]firefly.Transaction, error) {
 	if m.listTransactionsFunc != nil {
 		return m.listTransactionsFunc(query)
 	}
 	return []firefly.Transaction{}, nil
 }
 
-func (m *mockUIAPI) DeleteTransaction(transactionID string) error {
+func (m *mockUIAPI) GetTransaction(_ context.Context, transactionID string) (firefly.Transaction, error) {
+	if m.getTransactionFunc != nil {
+		return m.getTransactionFunc(transactionID)
+	}
+	return firefly.Transaction{}, nil
+}
+
+func (m *mockUIAPI) DeleteTransaction(_ context.Context, transactionID string) error {
 	if m.deleteTransactionFunc != nil {
 		return m.deleteTransactionFunc(transactionID)
 	}
@@ -285,14 +505,18 @@ func (m *mockUIAPI) DeleteTransaction(transactionID string) error {
 }
 
 // TransactionWriteAPI methods
-func (m *mockUIAPI) CreateTransaction(tx firefly.RequestTransaction) (string, error) {
+func (m *mockUIAPI) CreateTransactions(ctx context.Context, txs []firefly.RequestTransaction) firefly.BatchResult {
+	return firefly.CreateBatch(ctx, txs, 1, m.CreateTransaction)
+}
+
+func (m *mockUIAPI) CreateTransaction(_ context.Context, tx firefly.RequestTransaction) (string, error) {
 	if m.createTransactionFunc != nil {
 		return m.createTransactionFunc(tx)
 	}
 	return "", nil
 }
 
-func (m *mockUIAPI) UpdateTransaction(transactionID string, tx firefly.RequestTransaction) (string, error) {
+func (m *mockUIAPI) UpdateTransaction(_ context.Context, transactionID string, tx firefly.RequestTransaction) (string, error) {
 	if m.updateTransactionFunc != nil {
 		return m.updateTransactionFunc(transactionID, tx)
 	}
@@ -417,11 +641,11 @@ func TestUI_KeyPeriodPicker(t *testing.T) {
 		transactions: NewModelTransactions(api),
 		new:          newModelTransaction(api),
 		assets:       newModelAssets(api),
-		categories:   newModelCategories(api),
-		expenses:     newModelExpenses(api),
-		revenues:     newModelRevenues(api),
+		categories:   newModelCategories(api, newRequestScope()),
+		expenses:     newModelExpenses(api, newRequestScope()),
+		revenues:     newModelRevenues(api, newRequestScope()),
 		liabilities:  newModelLiabilities(api),
-		summary:      newModelSummary(api),
+		summary:      newModelSummary(api, newRequestScope()),
 		keymap:       DefaultUIKeyMap(),
 		styles:       DefaultStyles(),
 	}
@@ -447,18 +671,20 @@ func TestUI_KeyPeriodPicker(t *testing.T) {
 
 func TestUI_PeriodSelectedMsg(t *testing.T) {
 	api := newTestUIAPI()
+	requests := newRequestScope()
 	m := modelUI{
-		api:          api,
-		transactions: NewModelTransactions(api),
-		new:          newModelTransaction(api),
-		assets:       newModelAssets(api),
-		categories:   newModelCategories(api),
-		expenses:     newModelExpenses(api),
-		revenues:     newModelRevenues(api),
-		liabilities:  newModelLiabilities(api),
-		summary:      newModelSummary(api),
-		keymap:       DefaultUIKeyMap(),
-		styles:       DefaultStyles(),
+		api:            api,
+		transactions:   NewModelTransactions(api),
+		new:            newModelTransaction(api),
+		assets:         newModelAssets(api),
+		categories:     newModelCategories(api, requests),
+		expenses:       newModelExpenses(api, requests),
+		revenues:       newModelRevenues(api, requests),
+		liabilities:    newModelLiabilities(api),
+		summary:        newModelSummary(api, requests),
+		keymap:         DefaultUIKeyMap(),
+		styles:         DefaultStyles(),
+		periodRequests: requests,
 	}
 	m.transactions.currentSearch = "test"
 
@@ -478,6 +704,9 @@ func TestUI_PeriodSelectedMsg(t *testing.T) {
 	if m2.transactions.currentSearch != "" {
 		t.Error("Expected search to be cleared")
 	}
+	if api.forgetInsightsCalled != 0 {
+		t.Error("Expected insights of other periods to stay cached")
+	}
 
 	if cmd == nil {
 		t.Fatal("Expected refresh commands")
@@ -567,6 +796,61 @@ func TestUI_UpdatePositions_TransactionsView(t *testing.T) {
 	_ = m3
 }
 
+func TestUI_UpdateLayout_PanelsGetCopy(t *testing.T) {
+	m := newTestModelUI()
+	m.state = assetsView
+
+	positions := m.updateLayout(UpdatePositions{layout: &LayoutConfig{Width: 120, Height: 40}})
+
+	if positions.layout == m.layout {
+		t.Fatal("Expected the panels to get a copy of the layout")
+	}
+	if *positions.layout != *m.layout {
+		t.Errorf("Expected the copy to match the layout, got %+v and %+v", *positions.layout, *m.layout)
+	}
+	if m.layout.SummarySize != m.summary.height() {
+		t.Errorf("Expected the summary size %d, got %d", m.summary.height(), m.layout.SummarySize)
+	}
+	if m.layout.Width != 120 || m.layout.Height != 40 || m.layout.LeftSize == 0 {
+		t.Errorf("Expected the layout computed for 120x40, got %+v", *m.layout)
+	}
+}
+
+func TestUI_View_TooSmall(t *testing.T) {
+	m := newTestModelUI()
+	m.state = assetsView
+	m.helpOverlay.Focus()
+
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 40, Height: 10})
+	m = updated.(modelUI)
+	if !m.tooSmall() {
+		t.Fatal("Expected a 40x10 terminal to be too small")
+	}
+	if view := m.View(); !strings.Contains(view, "Terminal too small") || !strings.Contains(view, "have 40x10") {
+		t.Errorf("Expected the too small notice over every view, got %q", view)
+	}
+
+	updated, _ = m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = updated.(modelUI)
+	if m.tooSmall() || strings.Contains(m.View(), "Terminal too small") {
+		t.Error("Expected the views back once resized")
+	}
+}
+
+func TestUI_UpdateLayout_TooSmallKeepsPanelsSized(t *testing.T) {
+	m := newTestModelUI()
+
+	positions := m.updateLayout(UpdatePositions{layout: &LayoutConfig{Width: 10, Height: 3}})
+
+	if m.layout.Width != 10 || m.layout.Height != 3 {
+		t.Errorf("Expected the terminal size kept, got %dx%d", m.layout.Width, m.layout.Height)
+	}
+	if positions.layout.Width != minWidth || positions.layout.Height != minHeight {
+		t.Errorf("Expected the panels sized %dx%d, got %dx%d",
+			minWidth, minHeight, positions.layout.Width, positions.layout.Height)
+	}
+}
+
 func TestUI_UpdatePositions_DifferentViews(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -678,193 +962,10 @@ func TestUI_ViewFullTransactionViewMsg(t *testing.T) {
 	}
 }
 
-func TestUI_DataLoadCompletedMsg(t *testing.T) {
-	m := newTestModelUI()
-	m.loadStatus["assets"] = false
-
-	updated, _ := m.Update(DataLoadCompletedMsg{DataType: "assets"})
-	m2 := updated.(modelUI)
-
-	if !m2.loadStatus["assets"] {
-		t.Error("Expected assets to be marked as loaded")
-	}
-}
-
-func TestUI_LazyLoadMsg_AllResourcesLoaded(t *testing.T) {
-	m := newTestModelUI()
-	m.loadStatus = map[string]bool{
-		"assets":      true,
-		"expenses":    true,
-		"revenues":    true,
-		"liabilities": true,
-		"categories":  true,
-	}
-
-	updated, cmd := m.Update(LazyLoadMsg{c: 5})
-
-	if cmd == nil {
-		t.Fatal("Expected command from LazyLoadMsg")
-	}
-
-	msgs := collectMsgsFromCmd(cmd)
-	foundRefreshTransactions := false
-	foundRefreshSummary := false
-
-	for _, msg := range msgs {
-		switch msg.(type) {
-		case RefreshTransactionsMsg:
-			foundRefreshTransactions = true
-		case RefreshSummaryMsg:
-			foundRefreshSummary = true
-		}
-	}
-
-	if !foundRefreshTransactions {
-		t.Error("Expected RefreshTransactionsMsg in batch")
-	}
-	if !foundRefreshSummary {
-		t.Error("Expected RefreshSummaryMsg in batch")
-	}
-
-	_ = updated
-}
-
-func TestUI_LazyLoadMsg_ResourcesStillLoading_Retry(t *testing.T) {
-	m := newTestModelUI()
-	m.loadStatus = map[string]bool{
-		"assets":      true,
-		"expenses":    false, // Still loading
-		"revenues":    true,
-		"liabilities": true,
-		"categories":  true,
-	}
-
-	updated, cmd := m.Update(LazyLoadMsg{c: 3})
-
-	if cmd == nil {
-		t.Fatal("Expected command from LazyLoadMsg")
-	}
-
-	// Execute the command to get the message
-	msg := cmd()
-	if msg == nil {
-		t.Fatal("Expected message from command")
-	}
-
-	// The command should produce another LazyLoadMsg after the tick
-	// We can't easily test the timing, but we verify a command was returned
-	// which would be the tea.Tick that schedules the next check
-
-	_ = updated
-}
-
-func TestUI_LazyLoadMsg_Timeout_ShowsWarning(t *testing.T) {
-	m := newTestModelUI()
-	m.loadStatus = map[string]bool{
-		"assets":      true,
-		"expenses":    false, // Still loading
-		"revenues":    true,
-		"liabilities": true,
-		"categories":  true,
-	}
-
-	updated, cmd := m.Update(LazyLoadMsg{c: 1})
-
-	if cmd == nil {
-		t.Fatal("Expected warning command from LazyLoadMsg")
-	}
-
-	// The command should be a notify.NotifyWarn
-	// We can't easily inspect the notification content in unit tests,
-	// but we verify that a command was returned (the warning)
-
-	_ = updated
-}
-
-func TestUI_LazyLoadMsg_FullLifecycle(t *testing.T) {
-	m := newTestModelUI()
-	m.loadStatus = map[string]bool{
-		"assets":      false,
-		"expenses":    false,
-		"revenues":    false,
-		"liabilities": false,
-		"categories":  false,
-	}
-
-	// Step 1: First LazyLoadMsg with resources unloaded - should retry
-	updated, cmd := m.Update(LazyLoadMsg{c: 3})
-	m = updated.(modelUI)
-
-	if cmd == nil {
-		t.Fatal("Expected retry command from LazyLoadMsg")
-	}
-
-	// Verify it's a retry (not a refresh batch)
-	msgs := collectMsgsFromCmd(cmd)
-	hasRefreshTransactions := false
-	for _, msg := range msgs {
-		if _, ok := msg.(RefreshTransactionsMsg); ok {
-			hasRefreshTransactions = true
-		}
-	}
-	if hasRefreshTransactions {
-		t.Error("Expected retry, not refresh commands on first attempt")
-	}
-
-	// Step 2: Second LazyLoadMsg, still loading - should retry
-	updated, cmd = m.Update(LazyLoadMsg{c: 2})
-	m = updated.(modelUI)
-
-	if cmd == nil {
-		t.Fatal("Expected retry command from second LazyLoadMsg")
-	}
-
-	// Step 3: Mark all resources as loaded
-	m.loadStatus = map[string]bool{
-		"assets":      true,
-		"expenses":    true,
-		"revenues":    true,
-		"liabilities": true,
-		"categories":  true,
-	}
-
-	// Step 4: Third LazyLoadMsg, now all loaded - should trigger refresh
-	updated, cmd = m.Update(LazyLoadMsg{c: 1})
-	m = updated.(modelUI)
-
-	if cmd == nil {
-		t.Fatal("Expected refresh command from LazyLoadMsg after loading complete")
-	}
-
-	msgs = collectMsgsFromCmd(cmd)
-	foundRefreshTransactions := false
-	foundRefreshSummary := false
-
-	for _, msg := range msgs {
-		switch msg.(type) {
-		case RefreshTransactionsMsg:
-			foundRefreshTransactions = true
-		case RefreshSummaryMsg:
-			foundRefreshSummary = true
-		}
-	}
-
-	if !foundRefreshTransactions {
-		t.Error("Expected RefreshTransactionsMsg after all resources loaded")
-	}
-	if !foundRefreshSummary {
-		t.Error("Expected RefreshSummaryMsg after all resources loaded")
-	}
-}
-
 func TestUI_RefreshAllMsg(t *testing.T) {
 	m := newTestModelUI()
-	m.loadStatus = map[string]bool{
-		"assets":      true,
-		"expenses":    true,
-		"revenues":    true,
-		"liabilities": true,
-		"categories":  true,
+	for resource := range m.loadStatus {
+		m.loadStatus[resource] = resourceLoad{state: loadDone}
 	}
 
 	updated, cmd := m.Update(RefreshAllMsg{})
@@ -875,16 +976,20 @@ func TestUI_RefreshAllMsg(t *testing.T) {
 
 	m2 := updated.(modelUI)
 
-	// All load statuses should be reset to false
-	for key, loaded := range m2.loadStatus {
-		if loaded {
-			t.Errorf("Expected %s to be marked as not loaded", key)
-		}
+	if got := m2.api.(*mockUIAPI).forgetInsightsCalled; got != 1 {
+		t.Errorf("Expected cached insights to be dropped once, got %d", got)
 	}
 
-	// Command should be a batch - we can verify it's not nil
-	// The actual execution would require the tea runtime
-	// Just verify that important refresh messages would be sent
+	// Everything without dependencies starts, transactions wait
+	for resource, load := range m2.loadStatus {
+		want := loadRunning
+		if resource == "transactions" {
+			want = loadPending
+		}
+		if load.state != want {
+			t.Errorf("Expected %s in state %d, got %d", resource, want, load.state)
+		}
+	}
 }
 
 // =============================================================================
@@ -1130,210 +1235,253 @@ func TestUI_HelpView_ShowAll(t *testing.T) {
 	}
 }
 
-// =============================================================================
-// Loading Indicator Tests
-// =============================================================================
+func TestUI_KeyHelp_OpensOverlay(t *testing.T) {
+	viper.Set("ui.help_overlay", true)
+	defer viper.Set("ui.help_overlay", false)
 
-func TestLoading_BasicOperations(t *testing.T) {
-	// Reset
-	loading.Store(0)
-	loadingOps = sync.Map{}
+	m := newTestModelUI()
+	m.state = categoriesView
 
-	// Test start increments counter and stores message
-	opID := startLoading("Loading test...")
-	if opID == "" {
-		t.Fatal("Expected non-empty operation ID")
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'?'}})
+	if cmd == nil {
+		t.Fatal("Expected command from help key")
+	}
+	msg := cmd()
+	open, ok := msg.(helpoverlay.OpenMsg)
+	if !ok {
+		t.Fatalf("Expected helpoverlay.OpenMsg, got %T", msg)
 	}
-	if loading.Load() != 1 {
-		t.Errorf("Expected counter to be 1, got %d", loading.Load())
+	if len(open.Groups) == 0 || open.Groups[0].Title != "Categories" {
+		t.Error("Expected current view bindings to be listed first")
 	}
 
-	// Verify message is stored
-	msg := buildLoadingMessage()
-	if !strings.Contains(msg, "test...") {
-		t.Errorf("Expected message to contain 'test...', got '%s'", msg)
+	updated, _ := m.Update(open)
+	m = updated.(modelUI)
+	if !m.helpOverlay.Focused() {
+		t.Fatal("Expected help overlay to be focused")
+	}
+	if m.help.ShowAll {
+		t.Error("Expected inline help not to be toggled")
+	}
+	if !strings.Contains(m.View(), "Key bindings") {
+		t.Error("Expected overlay to replace the view")
 	}
 
-	// Test stop decrements counter and removes operation
-	stopLoading(opID)
-	if loading.Load() != 0 {
-		t.Errorf("Expected counter to be 0, got %d", loading.Load())
+	// Keys go to the overlay search, not to the views
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	m = updated.(modelUI)
+	if m.periodPicker.Focused() {
+		t.Error("Expected period picker key to be captured by the overlay")
 	}
 
-	// Verify message is cleared
-	msg = buildLoadingMessage()
-	if msg != "..." {
-		t.Errorf("Expected fallback '...', got '%s'", msg)
+	updated, _ = m.Update(helpoverlay.CloseMsg{})
+	m = updated.(modelUI)
+	if m.helpOverlay.Focused() {
+		t.Error("Expected help overlay to be closed")
 	}
 }
 
-func TestLoading_OverflowUnderflowProtection(t *testing.T) {
-	// Test overflow protection
-	loading.Store(99)
-	loadingOps = sync.Map{}
-
-	op1 := startLoading("Test")
-	if loading.Load() != 100 {
-		t.Errorf("Expected counter to be 100, got %d", loading.Load())
-	}
+func TestUI_AccountDetails(t *testing.T) {
+	m := NewModelUI(newTestUIAPI())
 
-	op2 := startLoading("Test")
-	if op2 != "" {
-		t.Error("Expected empty operation ID when max reached")
+	updated, _ := m.Update(accountdetail.OpenMsg{
+		Account: firefly.Account{ID: "a1", Name: "Savings account", Notes: "Emergency fund"},
+	})
+	m = updated.(modelUI)
+	if !m.details.Focused() {
+		t.Fatal("Expected account details to be focused")
 	}
-	if loading.Load() != 100 {
-		t.Errorf("Expected counter to stay at 100, got %d", loading.Load())
+	if !strings.Contains(m.View(), "Emergency fund") {
+		t.Error("Expected account details to replace the view")
 	}
 
-	// Cleanup
-	stopLoading(op1)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	m = updated.(modelUI)
+	if m.periodPicker.Focused() {
+		t.Error("Expected period picker key to be captured by the details")
+	}
 
-	// Test underflow protection
-	loading.Store(0)
-	loadingOps = sync.Map{}
-	stopLoading("")
-	stopLoading("invalid_id")
-	if loading.Load() != 0 {
-		t.Errorf("Expected counter to stay at 0, got %d", loading.Load())
+	updated, _ = m.Update(accountdetail.CloseMsg{})
+	m = updated.(modelUI)
+	if m.details.Focused() {
+		t.Error("Expected account details to be closed")
 	}
 }
 
-func TestLoading_NestedOperations(t *testing.T) {
-	// Reset
-	loading.Store(0)
-	loadingOps = sync.Map{}
-
-	// Start multiple operations
-	op1 := startLoading("Operation 1")
-	op2 := startLoading("Operation 2")
-	op3 := startLoading("Operation 3")
-
-	if loading.Load() != 3 {
-		t.Errorf("Expected counter to be 3, got %d", loading.Load())
+func TestUI_AssetForm(t *testing.T) {
+	api := newTestUIAPI()
+	var created []firefly.NewAsset
+	api.createAssetAccountFunc = func(na firefly.NewAsset) error {
+		created = append(created, na)
+		return nil
 	}
+	m := NewModelUI(api)
 
-	// Message should show all operations
-	msg := buildLoadingMessage()
-	if !strings.Contains(msg, "(3)") {
-		t.Errorf("Expected message to show count (3), got '%s'", msg)
+	updated, _ := m.Update(assetform.OpenMsg{Currency: "EUR"})
+	m = updated.(modelUI)
+	if !m.assetForm.Focused() {
+		t.Fatal("Expected asset form to be focused")
+	}
+	if !strings.Contains(m.View(), "New asset account") {
+		t.Error("Expected asset form to replace the view")
 	}
 
-	// Stop one operation - should show 2 operations
-	stopLoading(op2)
-	if loading.Load() != 2 {
-		t.Errorf("Expected counter to be 2, got %d", loading.Load())
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	m = updated.(modelUI)
+	if m.periodPicker.Focused() {
+		t.Error("Expected typed keys to be captured by the form")
 	}
 
-	msg = buildLoadingMessage()
-	if !strings.Contains(msg, "(2)") {
-		t.Errorf("Expected message to show count (2), got '%s'", msg)
+	_, _ = m.Update(assetform.SubmitMsg{Asset: firefly.NewAsset{Name: "Travel", CurrencyCode: "EUR"}})
+	if len(created) != 1 || created[0].Name != "Travel" {
+		t.Errorf("Expected the asset to be created, got %+v", created)
 	}
+}
 
-	// Stop remaining operations
-	stopLoading(op1)
-	stopLoading(op3)
+func TestUI_QuickTransfer(t *testing.T) {
+	m := NewModelUI(newTestUIAPI())
 
-	if loading.Load() != 0 {
-		t.Errorf("Expected counter to be 0, got %d", loading.Load())
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'M'}})
+	open, ok := findMsg[transferform.OpenMsg](collectMsgsFromCmd(cmd))
+	if !ok {
+		t.Fatal("expected the quick transfer form to open")
+	}
+	updated, _ := m.Update(open)
+	m = updated.(modelUI)
+	if !m.transferForm.Focused() || !strings.Contains(m.View(), "Quick transfer") {
+		t.Fatal("expected the quick transfer form to replace the view")
 	}
 
-	msg = buildLoadingMessage()
-	if msg != "..." {
-		t.Errorf("Expected fallback '...', got '%s'", msg)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	m = updated.(modelUI)
+	if m.periodPicker.Focused() {
+		t.Error("expected typed keys to be captured by the form")
 	}
 }
 
-func TestLoading_ViewIntegration(t *testing.T) {
-	// Reset
-	loading.Store(0)
-	loadingOps = sync.Map{}
-
-	m := newTestModelUI()
-	m.Width = 100
+func TestUI_PayBill(t *testing.T) {
+	api := newTestUIAPI()
+	api.subscriptionsDue = []firefly.Subscription{{Name: "Rent", AmountMin: 900, AmountMax: 900, DueDates: []string{"2026-03-20"}}}
+	m := NewModelUI(api)
 
-	// Initially no spinner
-	view := m.View()
-	if strings.Contains(view, "Loading") {
-		t.Error("Expected no loading indicator when counter is 0")
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'$'}})
+	open, ok := findMsg[NewTransactionFromMsg](collectMsgsFromCmd(cmd))
+	if !ok || open.Transaction.Splits[0].Description != "Rent" || open.Transaction.Splits[0].Amount != 900 {
+		t.Errorf("expected the rent payment opened in the form, got %+v", open)
 	}
+}
 
-	// Start loading
-	opID := startLoading("Loading transactions...")
-	view = m.View()
-	if !strings.Contains(view, "transactions...") {
-		t.Error("Expected to see loading message in view")
+func TestUI_CategoryDetail(t *testing.T) {
+	m := NewModelUI(newTestUIAPI())
+
+	updated, _ := m.Update(categorydetail.OpenMsg{Category: firefly.Category{ID: "c1", Name: "Groceries"}})
+	m = updated.(modelUI)
+	if !m.category.Focused() {
+		t.Fatal("Expected category detail to be focused")
+	}
+	if !strings.Contains(m.View(), "Top expense accounts") {
+		t.Error("Expected category detail to replace the view")
 	}
 
-	// Stop loading
-	stopLoading(opID)
-	view = m.View()
-	if strings.Contains(view, "transactions...") {
-		t.Error("Expected loading indicator to be gone when counter is 0")
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(modelUI)
+	if msg, ok := findMsg[categorydetail.CloseMsg](collectMsgsFromCmd(cmd)); ok {
+		updated, _ = m.Update(msg)
+		m = updated.(modelUI)
+	}
+	if m.category.Focused() {
+		t.Error("Expected esc to close the category detail")
 	}
 }
 
-func TestLoading_FallbackMessage(t *testing.T) {
-	// Test with no operations
-	loading.Store(0)
-	loadingOps = sync.Map{}
-
-	msg := buildLoadingMessage()
-	if msg != "..." {
-		t.Errorf("Expected fallback '...', got '%s'", msg)
+func TestUI_KeyAPILog_OpensLog(t *testing.T) {
+	api := newTestUIAPI()
+	api.recentRequests = []firefly.RequestTrace{
+		{Method: "GET", URL: "https://firefly.example.com/api/v1/about", Status: 200},
 	}
+	m := NewModelUI(api)
 
-	// Cleanup
-	loading.Store(0)
-	loadingOps = sync.Map{}
-}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'L'}})
+	if cmd == nil {
+		t.Fatal("Expected command from API log key")
+	}
+	msg := cmd()
+	open, ok := msg.(apilog.OpenMsg)
+	if !ok {
+		t.Fatalf("Expected apilog.OpenMsg, got %T", msg)
+	}
+	if len(open.Requests) != 1 {
+		t.Fatalf("Expected 1 request, got %d", len(open.Requests))
+	}
 
-func TestLoading_MultipleOperationsDisplay(t *testing.T) {
-	// Reset
-	loading.Store(0)
-	loadingOps = sync.Map{}
+	updated, _ := m.Update(open)
+	m = updated.(modelUI)
+	if !m.apiLog.Focused() {
+		t.Fatal("Expected API log to be focused")
+	}
+	if !strings.Contains(m.View(), "/api/v1/about") {
+		t.Error("Expected API log to replace the view")
+	}
 
-	// Start multiple operations
-	op1 := startLoading("Loading transactions...")
-	op2 := startLoading("Loading categories...")
-	op3 := startLoading("Creating category...")
+	// Keys go to the log, not to the views
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	m = updated.(modelUI)
+	if m.periodPicker.Focused() {
+		t.Error("Expected period picker key to be captured by the API log")
+	}
 
-	// Verify all tracked
-	if loading.Load() != 3 {
-		t.Errorf("Expected 3 operations, got %d", loading.Load())
+	updated, _ = m.Update(apilog.CloseMsg{})
+	m = updated.(modelUI)
+	if m.apiLog.Focused() {
+		t.Error("Expected API log to be closed")
 	}
+}
 
-	// Check display message contains count
-	msg := buildLoadingMessage()
-	if !strings.Contains(msg, "(3)") {
-		t.Errorf("Expected message to contain '(3)', got '%s'", msg)
+func TestUI_HelpGroups_Vim(t *testing.T) {
+	m := newTestModelUI()
+
+	hasVim := func(groups []helpoverlay.Group) bool {
+		for _, g := range groups {
+			if g.Title == "Vim" {
+				return true
+			}
+		}
+		return false
 	}
 
-	// Should show abbreviated messages
-	if !strings.Contains(msg, " ") {
-		t.Error("Expected separator ' ' between messages")
+	if hasVim(m.helpGroups()) {
+		t.Error("Expected no vim group when vim mode is off")
+	}
+	m.vim = newVimLayer(true)
+	if !hasVim(m.helpGroups()) {
+		t.Error("Expected vim group when vim mode is on")
 	}
+}
+
+func TestLoading_ViewIntegration(t *testing.T) {
+	m := newTestModelUI()
+	m.Width = 100
 
-	// Stop one operation
-	stopLoading(op2)
-	if loading.Load() != 2 {
-		t.Errorf("Expected 2 operations, got %d", loading.Load())
+	view := m.View()
+	if strings.Contains(view, "transactions...") {
+		t.Error("Expected no loading indicator without operations")
 	}
 
-	// Check message updated
-	msg = buildLoadingMessage()
-	if !strings.Contains(msg, "(2)") {
-		t.Errorf("Expected message to contain '(2)', got '%s'", msg)
+	op := loading.NewOperation("Loading transactions...")
+	updated, _ := m.Update(loading.StartMsg{Op: op})
+	m = updated.(modelUI)
+	view = m.View()
+	if !strings.Contains(view, "transactions...") {
+		t.Error("Expected to see loading message in view")
 	}
 
-	// Cleanup
-	stopLoading(op1)
-	stopLoading(op3)
+	op.Stop()
+	view = m.View()
+	if strings.Contains(view, "transactions...") {
+		t.Error("Expected loading indicator to be gone once stopped")
+	}
 }
 
-// =============================================================================
-// Edge Cases
-// =============================================================================
-
 func TestUI_UnknownMessage(t *testing.T) {
 	m := newTestModelUI()
 
@@ -1423,3 +1571,23 @@ func TestUI_IntegrationSequence(t *testing.T) {
 		t.Error("Expected non-empty view")
 	}
 }
+
+func TestUI_Overlays_TakeKeysAndReplaceView(t *testing.T) {
+	m := newTestModelUI()
+	m.state = transactionsView
+
+	updated, _ := m.Update(apilog.OpenMsg{})
+	m = updated.(modelUI)
+	if !m.isAnyInputFocused() {
+		t.Fatal("Expected the open API log to count as focused input")
+	}
+	if !strings.Contains(m.View(), "API log") {
+		t.Error("Expected the API log to replace the view")
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	m = updated.(modelUI)
+	if m.periodPicker.Focused() {
+		t.Error("Expected the key captured by the API log")
+	}
+}
@@ -0,0 +1,169 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/hooks"
+	"ffiii-tui/internal/ui/loading"
+	"ffiii-tui/internal/ui/notify"
+	"ffiii-tui/internal/ui/prompt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Modes of the uncategorized filter: transactions without a category, or
+// also withdrawals without a budget.
+const (
+	uncategorizedCategory = "category"
+	uncategorizedBudget   = "budget"
+)
+
+// uncategorizedCycle is the order the uncategorized filter key steps
+// through, back to all transactions.
+var uncategorizedCycle = []string{"", uncategorizedCategory, uncategorizedBudget}
+
+var uncategorizedLabels = map[string]string{
+	uncategorizedCategory: "Uncategorized",
+	uncategorizedBudget:   "No category or budget",
+}
+
+func nextUncategorized(current string) string {
+	i := slices.Index(uncategorizedCycle, current)
+	return uncategorizedCycle[(i+1)%len(uncategorizedCycle)]
+}
+
+// needsCategory reports whether a split of tx has no category, or with
+// budgets also whether a withdrawal has a split without a budget.
+func needsCategory(tx firefly.Transaction, budgets bool) bool {
+	for _, split := range tx.Splits {
+		if split.Category.IsEmpty() {
+			return true
+		}
+		if budgets && tx.Type == "withdrawal" && split.Budget.ID == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// categoryAssignedMsg reports a category assigned with the quick-assign
+// prompt; Next is the uncategorized transaction to continue with.
+type categoryAssignedMsg struct {
+	Transaction firefly.Transaction
+	Next        string
+}
+
+// askCategory asks for the category of the splits of trx without one,
+// suggesting the category used most with its destination.
+func (m *modelTransactions) askCategory(trx firefly.Transaction) tea.Cmd {
+	if !slices.ContainsFunc(trx.Splits, func(s firefly.Split) bool { return s.Category.IsEmpty() }) {
+		return notify.NotifyLog(fmt.Sprintf("Transaction #%s already has a category", trx.TransactionID))
+	}
+
+	var suggestions categorySuggestions
+	suggestions.learn(m.transactions)
+	suggested, _ := suggestions.forDestination(trx.Destination())
+
+	api := m.api
+	next := m.nextUncategorized(trx.TransactionID)
+	return prompt.Ask(
+		fmt.Sprintf("Category for %s (ESC to stop): ", trx.Description()),
+		suggested.Name,
+		func(value string) tea.Cmd {
+			name := strings.TrimSpace(value)
+			if name == "" || name == "None" {
+				return SetView(transactionsView)
+			}
+			i := slices.IndexFunc(api.CategoriesList(), func(c firefly.Category) bool {
+				return strings.EqualFold(c.Name, name)
+			})
+			if i < 0 {
+				return tea.Sequence(
+					notify.NotifyWarn(fmt.Sprintf("Unknown category: %s", name)),
+					SetView(transactionsView))
+			}
+			return tea.Sequence(
+				SetView(transactionsView),
+				assignCategory(api, trx, api.CategoriesList()[i], next))
+		},
+	)
+}
+
+// nextUncategorized returns the ID of the first transaction below id that
+// needs a category, empty when there is none.
+func (m *modelTransactions) nextUncategorized(id string) string {
+	i := slices.IndexFunc(m.shown, func(tx firefly.Transaction) bool {
+		return tx.TransactionID == id
+	})
+	for _, tx := range m.shown[i+1:] {
+		if needsCategory(tx, m.uncategorized == uncategorizedBudget) {
+			return tx.TransactionID
+		}
+	}
+	return ""
+}
+
+// assignCategory sets category on the splits of trx without one. The
+// other splits are sent with their journal ID only, so they stay as they
+// are.
+func assignCategory(api TransactionAPI, trx firefly.Transaction, category firefly.Category, next string) tea.Cmd {
+	return loading.Track("Assigning category...", func() tea.Msg {
+		request := firefly.RequestTransaction{}
+		trx.Splits = slices.Clone(trx.Splits)
+		for i, split := range trx.Splits {
+			s := firefly.RequestTransactionSplit{TransactionJournalID: split.TransactionJournalID}
+			if split.Category.IsEmpty() {
+				s.CategoryID = category.ID
+				trx.Splits[i].Category = category
+			}
+			request.Transactions = append(request.Transactions, s)
+		}
+
+		_, err := api.UpdateTransaction(context.Background(), trx.TransactionID, request)
+		if errors.Is(err, firefly.ErrQueued) {
+			return tea.BatchMsg{
+				notify.NotifyWarn(err.Error()),
+				Cmd(categoryAssignedMsg{Transaction: trx, Next: next}),
+			}
+		}
+		if err != nil {
+			return notify.NotifyWarn(fmt.Sprintf("Failed to assign category: %s", errorText(err)))()
+		}
+		return tea.BatchMsg{
+			Cmd(categoryAssignedMsg{Transaction: trx, Next: next}),
+			runHook(hooks.TransactionUpdated, newHookTransaction(trx)),
+		}
+	})
+}
+
+// categoryAssigned shows the new category and moves on to the next
+// transaction that needs one, asking for its category while the
+// uncategorized filter is on.
+func (m *modelTransactions) categoryAssigned(msg categoryAssignedMsg) tea.Cmd {
+	m.putTransaction(msg.Transaction)
+	cursor := msg.Next
+	if cursor == "" {
+		cursor = msg.Transaction.TransactionID
+	}
+	cmds := []tea.Cmd{
+		Cmd(FilterMsg{TrxID: cursor}),
+		notify.NotifyLog(fmt.Sprintf("Category %s assigned to #%s",
+			msg.Transaction.Category().Name, msg.Transaction.TransactionID)),
+	}
+	if next, err := m.findTransactionByID(msg.Next); err == nil && m.uncategorized != "" {
+		cmds = append(cmds, m.askCategory(next))
+	}
+	return tea.Batch(
+		tea.Sequence(cmds...),
+		Cmd(RefreshCategoryInsightsMsg{}),
+		Cmd(RefreshExpenseInsightsMsg{}))
+}
@@ -0,0 +1,143 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/prompt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func newUncategorizedTransactions() []firefly.Transaction {
+	categorized := newTestTransaction(0, "tx1", "withdrawal", "2024-01-17T10:00:00Z", "Budgeted")
+	categorized.Splits[0].Budget = firefly.Budget{ID: "b1", Name: "Food"}
+	unbudgeted := newTestTransaction(1, "tx2", "withdrawal", "2024-01-16T10:00:00Z", "No budget")
+	open := newTestTransaction(2, "tx3", "withdrawal", "2024-01-15T10:00:00Z", "Coffee")
+	open.Splits[0].Category = firefly.Category{}
+	open.Splits[0].Budget = firefly.Budget{ID: "b1", Name: "Food"}
+	deposit := newTestTransaction(3, "tx4", "deposit", "2024-01-14T10:00:00Z", "Refund")
+	deposit.Splits[0].Category = firefly.Category{}
+	return []firefly.Transaction{categorized, unbudgeted, open, deposit}
+}
+
+func TestTransactions_UncategorizedFilter(t *testing.T) {
+	m := newFocusedTransactionModel(t, newUncategorizedTransactions())
+
+	shownIDs := func() []string {
+		ids := []string{}
+		for _, tx := range m.shown {
+			ids = append(ids, tx.TransactionID)
+		}
+		return ids
+	}
+	press := func() {
+		updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'U'}})
+		m = updated.(modelTransactions)
+		filter, ok := findMsg[FilterMsg](collectMsgsFromCmd(cmd))
+		if !ok {
+			t.Fatal("expected the list to be filtered again")
+		}
+		updated, _ = m.Update(filter)
+		m = updated.(modelTransactions)
+	}
+
+	press()
+	if got := strings.Join(shownIDs(), ","); got != "tx3,tx4" {
+		t.Errorf("expected the transactions without category, got %s", got)
+	}
+	if chips := m.filterChips(); len(chips) != 1 || chips[0].label != "Uncategorized" {
+		t.Errorf("unexpected chips %v", chips)
+	}
+
+	press()
+	if got := strings.Join(shownIDs(), ","); got != "tx2,tx3,tx4" {
+		t.Errorf("expected also the withdrawals without budget, got %s", got)
+	}
+
+	press()
+	if m.uncategorized != "" || len(m.shown) != 4 {
+		t.Errorf("expected the filter off, got %q with %d shown", m.uncategorized, len(m.shown))
+	}
+}
+
+func TestTransactions_AssignCategory(t *testing.T) {
+	m := newFocusedTransactionModel(t, newUncategorizedTransactions())
+	api := m.api.(*mockTransactionAPI)
+	api.categories = []firefly.Category{{ID: "cat2", Name: "Dining"}}
+	var sent firefly.RequestTransaction
+	api.updateTransactionFunc = func(id string, tx firefly.RequestTransaction) (string, error) {
+		sent = tx
+		return id, nil
+	}
+	m.uncategorized = uncategorizedCategory
+	updated, _ := m.Update(FilterMsg{})
+	m = updated.(modelTransactions)
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'C'}})
+	ask, ok := findMsg[prompt.PromptMsg](collectMsgsFromCmd(cmd))
+	if !ok {
+		t.Fatal("expected a category prompt")
+	}
+	if !strings.Contains(ask.Prompt, "Coffee") {
+		t.Errorf("expected the prompt for the selected transaction, got %q", ask.Prompt)
+	}
+
+	assigned, ok := findMsg[categoryAssignedMsg](collectMsgsFromCmd(ask.Callback("dining")))
+	if !ok {
+		t.Fatal("expected the category to be assigned")
+	}
+	if len(sent.Transactions) != 1 || sent.Transactions[0].CategoryID != "cat2" || sent.Transactions[0].TransactionJournalID != "split-2" {
+		t.Errorf("unexpected request %+v", sent)
+	}
+	if assigned.Next != "tx4" {
+		t.Errorf("expected to continue with tx4, got %q", assigned.Next)
+	}
+
+	updated, cmd = m.Update(assigned)
+	m = updated.(modelTransactions)
+	msgs := collectMsgsFromCmd(cmd)
+	filter, ok := findMsg[FilterMsg](msgs)
+	if !ok || filter.TrxID != "tx4" {
+		t.Errorf("expected the cursor on the next uncategorized transaction, got %v", msgs)
+	}
+	next, ok := findMsg[prompt.PromptMsg](msgs)
+	if !ok || !strings.Contains(next.Prompt, "Refund") {
+		t.Errorf("expected the prompt for the next transaction, got %v", msgs)
+	}
+	updated, _ = m.Update(filter)
+	m = updated.(modelTransactions)
+	if len(m.shown) != 1 || m.shown[0].TransactionID != "tx4" {
+		t.Errorf("expected the assigned transaction to leave the list, got %v", m.shown)
+	}
+}
+
+func TestTransactions_AssignCategoryUnknown(t *testing.T) {
+	m := newFocusedTransactionModel(t, newUncategorizedTransactions())
+	m.uncategorized = uncategorizedCategory
+	updated, _ := m.Update(FilterMsg{})
+	m = updated.(modelTransactions)
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'C'}})
+	ask, _ := findMsg[prompt.PromptMsg](collectMsgsFromCmd(cmd))
+	msgs := collectMsgsFromCmd(ask.Callback("Nope"))
+
+	if hasMsg[categoryAssignedMsg](msgs) {
+		t.Error("expected nothing to be assigned")
+	}
+}
+
+func TestTransactions_AssignCategoryAlreadySet(t *testing.T) {
+	m := newFocusedTransactionModel(t, newUncategorizedTransactions())
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'C'}})
+
+	if hasMsg[prompt.PromptMsg](collectMsgsFromCmd(cmd)) {
+		t.Error("expected no prompt for a categorized transaction")
+	}
+}
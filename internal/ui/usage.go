@@ -0,0 +1,184 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"ffiii-tui/internal/firefly"
+
+	"github.com/charmbracelet/huh"
+	"go.uber.org/zap"
+)
+
+// Days after which a use counts half as much as a use today.
+const usageHalfLife = 30
+
+// usageStats counts how often and how recently accounts and categories were
+// picked in submitted transactions, so the form can offer them first.
+type usageStats struct {
+	mu      sync.Mutex
+	path    string
+	entries map[string]usageEntry
+}
+
+type usageEntry struct {
+	Count    int
+	LastUsed time.Time
+}
+
+// usagePath returns the usage file of the active profile.
+func usagePath() string {
+	return profileCachePath(".usage.json")
+}
+
+// loadUsage reads the usage file at path. It returns nil, keeping no stats,
+// when path is empty.
+func loadUsage(path string) *usageStats {
+	if path == "" {
+		return nil
+	}
+	u := &usageStats{path: path, entries: map[string]usageEntry{}}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			zap.L().Warn("Ignoring usage stats", zap.Error(err))
+		}
+		return u
+	}
+	if err := json.Unmarshal(data, &u.entries); err != nil {
+		zap.L().Warn("Ignoring usage stats", zap.Error(err))
+		u.entries = map[string]usageEntry{}
+	}
+	return u
+}
+
+func (u *usageStats) save() error {
+	u.mu.Lock()
+	data, err := json.Marshal(u.entries)
+	u.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode usage stats: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(u.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	tmp := u.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write usage stats: %w", err)
+	}
+	return os.Rename(tmp, u.path)
+}
+
+func accountUsageKey(a firefly.Account) string {
+	if a.ID == "" {
+		return ""
+	}
+	return "account:" + a.ID
+}
+
+func categoryUsageKey(c firefly.Category) string {
+	if c.ID == "" {
+		return ""
+	}
+	return "category:" + c.ID
+}
+
+func (u *usageStats) record(key string, now time.Time) {
+	if u == nil || key == "" {
+		return
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	e := u.entries[key]
+	e.Count++
+	e.LastUsed = now
+	u.entries[key] = e
+}
+
+// score weights the number of uses by how long ago the last one was.
+func (u *usageStats) score(key string, now time.Time) float64 {
+	if u == nil || key == "" {
+		return 0
+	}
+	u.mu.Lock()
+	e, ok := u.entries[key]
+	u.mu.Unlock()
+	if !ok {
+		return 0
+	}
+	days := now.Sub(e.LastUsed).Hours() / 24
+	return float64(e.Count) / (1 + max(days, 0)/usageHalfLife)
+}
+
+// rankOptions orders options by usage, most used first. Options never used
+// keep their order after the used ones.
+func rankOptions[T comparable](u *usageStats, options []huh.Option[T], key func(T) string) []huh.Option[T] {
+	if u == nil {
+		return options
+	}
+	now := time.Now()
+	scores := make(map[string]float64, len(options))
+	for _, option := range options {
+		k := key(option.Value)
+		scores[k] = u.score(k, now)
+	}
+	slices.SortStableFunc(options, func(a, b huh.Option[T]) int {
+		sa, sb := scores[key(a.Value)], scores[key(b.Value)]
+		switch {
+		case sa > sb:
+			return -1
+		case sa < sb:
+			return 1
+		}
+		return 0
+	})
+	return options
+}
+
+// recordUsage counts the accounts and categories of the submitted splits.
+func (m *modelTransaction) recordUsage() {
+	if m.usage == nil {
+		return
+	}
+	now := time.Now()
+	for _, s := range m.splits {
+		m.usage.record(accountUsageKey(s.source), now)
+		m.usage.record(accountUsageKey(s.destination), now)
+		m.usage.record(categoryUsageKey(s.category), now)
+	}
+	if err := m.usage.save(); err != nil {
+		zap.L().Warn("Failed to save usage stats", zap.Error(err))
+	}
+}
+
+// accountOptions lists the accounts of the given types, most used first.
+func (m *modelTransaction) accountOptions(types ...string) []huh.Option[firefly.Account] {
+	options := []huh.Option[firefly.Account]{}
+	for _, accountType := range types {
+		for _, account := range m.api.AccountsByType(accountType) {
+			options = append(options, huh.NewOption(account.Name, account))
+		}
+	}
+	return rankOptions(m.usage, options, accountUsageKey)
+}
+
+// categoryOptions lists the categories, most used first.
+func (m *modelTransaction) categoryOptions() []huh.Option[firefly.Category] {
+	options := []huh.Option[firefly.Category]{}
+	for _, category := range m.api.CategoriesList() {
+		options = append(options, huh.NewOption(category.Name, category))
+	}
+	return rankOptions(m.usage, options, categoryUsageKey)
+}
@@ -0,0 +1,96 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"ffiii-tui/internal/firefly"
+
+	"github.com/charmbracelet/huh"
+)
+
+func optionNames[T comparable](options []huh.Option[T]) []string {
+	names := []string{}
+	for _, option := range options {
+		names = append(names, option.Key)
+	}
+	return names
+}
+
+func TestUsage_RankOptions(t *testing.T) {
+	u := loadUsage(filepath.Join(t.TempDir(), "default.usage.json"))
+	now := time.Now()
+
+	u.record(accountUsageKey(testExpenseUtilities), now.AddDate(0, 0, -90))
+	u.record(accountUsageKey(testExpenseUtilities), now.AddDate(0, 0, -90))
+	u.record(accountUsageKey(testAssetSavings), now)
+
+	m := newTestTransactionModel()
+	m.usage = u
+
+	got := optionNames(m.accountOptions("expense", "asset"))
+	want := []string{testAssetSavings.Name, testExpenseUtilities.Name, testExpenseGroceries.Name, testAssetChecking.Name}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestUsage_RecordedOnSubmitAndPersisted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "default.usage.json")
+	m := newTestTransactionModel()
+	m.usage = loadUsage(path)
+	m.splits = []*split{{
+		source:      testAssetChecking,
+		destination: testExpenseUtilities,
+		category:    testCategoryBills,
+		amount:      "10",
+	}}
+	m.attr.transactionType = "withdrawal"
+	m.CreateTransaction()
+
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected usage stats to be saved: %v", err)
+	}
+
+	m.usage = loadUsage(path)
+	if got := optionNames(m.accountOptions("expense")); got[0] != testExpenseUtilities.Name {
+		t.Errorf("expected the used expense account first, got %v", got)
+	}
+	if got := optionNames(m.categoryOptions()); got[0] != testCategoryBills.Name {
+		t.Errorf("expected the used category first, got %v", got)
+	}
+}
+
+func TestUsage_SharedAccountStaysFirst(t *testing.T) {
+	m := newTestTransactionModel()
+	m.usage = loadUsage(filepath.Join(t.TempDir(), "default.usage.json"))
+	m.usage.record(accountUsageKey(testLiabilityLoan), time.Now())
+
+	var account, followed firefly.Account
+	got := optionNames(m.sharedAccountOptions(&account, &followed, testAssetChecking, "asset", "liabilities"))
+	if got[0] != testAssetChecking.Name || got[1] != testLiabilityLoan.Name {
+		t.Errorf("expected the shared account, then the used one, got %v", got)
+	}
+}
+
+func TestUsage_Disabled(t *testing.T) {
+	if loadUsage("") != nil {
+		t.Error("expected no stats without a usage file")
+	}
+	m := newTestTransactionModel()
+	got := optionNames(m.accountOptions("asset", "expense"))
+	if got[0] != testAssetChecking.Name || got[2] != testExpenseGroceries.Name {
+		t.Errorf("expected accounts in the order of the API, got %v", got)
+	}
+}
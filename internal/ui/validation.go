@@ -0,0 +1,81 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"ffiii-tui/internal/firefly"
+)
+
+// splitFormFields maps the fields of a split in Firefly III to the fields
+// of the transaction form.
+var splitFormFields = map[string]string{
+	"source_id":             "source",
+	"source_name":           "source",
+	"destination_id":        "destination",
+	"destination_name":      "destination",
+	"category_id":           "category",
+	"category_name":         "category",
+	"amount":                "amount",
+	"foreign_amount":        "foreign_amount",
+	"foreign_currency_id":   "foreign_amount",
+	"foreign_currency_code": "foreign_amount",
+	"description":           "description",
+	"latitude":              "location",
+	"longitude":             "location",
+	"zoom_level":            "location",
+	"internal_reference":    "internal_reference",
+	"external_id":           "external_id",
+	"invoice_date":          "invoice_date",
+}
+
+// errorText describes err for a notification. The messages of fields the
+// server rejected are listed without the request details.
+func errorText(err error) string {
+	var invalid *firefly.ValidationError
+	if !errors.As(err, &invalid) {
+		return err.Error()
+	}
+	if len(invalid.Fields) == 0 {
+		return invalid.Message
+	}
+	messages := []string{}
+	for _, field := range slices.Sorted(maps.Keys(invalid.Fields)) {
+		message := strings.Join(invalid.Fields[field], " ")
+		if i, _, ok := firefly.SplitField(field); ok {
+			message = fmt.Sprintf("Split %d: %s", i, message)
+		}
+		messages = append(messages, message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// formFieldErrors returns the messages of the rejected split fields by the
+// key of the form field, e.g. "split1.amount", and the index of the first
+// rejected split, -1 when only fields of the whole transaction were.
+func formFieldErrors(invalid *firefly.ValidationError) (map[string][]string, int) {
+	fields := map[string][]string{}
+	first := -1
+	for field, messages := range invalid.Fields {
+		i, name, ok := firefly.SplitField(field)
+		if !ok {
+			continue
+		}
+		if formField, known := splitFormFields[name]; known {
+			name = formField
+		}
+		key := splitFieldKey(i, name)
+		fields[key] = append(fields[key], messages...)
+		if first < 0 || i < first {
+			first = i
+		}
+	}
+	return fields, first
+}
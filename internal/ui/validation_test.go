@@ -0,0 +1,109 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/notify"
+)
+
+func rejectedAmount() error {
+	return fmt.Errorf("failed to send request: %w", &firefly.HTTPError{
+		StatusCode: 422,
+		Validation: &firefly.ValidationError{
+			Message: "The given data was invalid.",
+			Fields: map[string][]string{
+				"transactions.1.amount": {"The amount must be more than zero."},
+				"group_title":           {"The group title is required."},
+			},
+		},
+	})
+}
+
+func TestErrorText(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"plain", errors.New("boom"), "boom"},
+		{"fields", rejectedAmount(), "The group title is required.; Split 1: The amount must be more than zero."},
+		{"message only", &firefly.HTTPError{
+			StatusCode: 422,
+			Validation: &firefly.ValidationError{Message: "Duplicate of transaction #5."},
+		}, "Duplicate of transaction #5."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorText(tt.err); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFormFieldErrors(t *testing.T) {
+	fields, first := formFieldErrors(&firefly.ValidationError{Fields: map[string][]string{
+		"transactions.2.source_name": {"Invalid source."},
+		"transactions.1.amount":      {"Too small."},
+		"group_title":                {"Required."},
+	}})
+
+	if first != 1 {
+		t.Errorf("expected the first rejected split to be 1, got %d", first)
+	}
+	if got := fields["split2.source"]; len(got) != 1 || got[0] != "Invalid source." {
+		t.Errorf("expected source_name to map to the source field, got %v", fields)
+	}
+	if _, ok := fields["split1.amount"]; !ok || len(fields) != 2 {
+		t.Errorf("expected only split fields, got %v", fields)
+	}
+}
+
+func TestTransaction_RejectedCreateKeepsForm(t *testing.T) {
+	api := &mockTransactionFormAPI{
+		createTransactionFunc: func(tx firefly.RequestTransaction) (string, error) {
+			return "", rejectedAmount()
+		},
+	}
+	m := newModelTransaction(api)
+	m.created = true
+	m.new = true
+	m.attr.transactionType = "withdrawal"
+	m.splits = []*split{
+		{source: testAssetChecking, destination: testExpenseGroceries, amount: "50.00"},
+		{source: testAssetChecking, destination: testExpenseGroceries, amount: "0"},
+	}
+
+	msgs := collectMsgsFromCmd(m.CreateTransaction())
+
+	if !m.created {
+		t.Error("expected the form to stay open")
+	}
+	if hasMsg[SetFocusedViewMsg](msgs) {
+		t.Error("expected to stay in the form")
+	}
+	if !hasMsg[RedrawFormMsg](msgs) {
+		t.Error("expected the form to be redrawn")
+	}
+	warn, ok := findMsg[notify.NotifyMsg](msgs)
+	if !ok || warn.Level != notify.Warn || !strings.Contains(warn.Message, "Split 1: The amount") {
+		t.Errorf("expected a warning with the rejected field, got %v", msgs)
+	}
+	if m.groups.focusSplit != m.splits[1] {
+		t.Error("expected the rejected split to be focused")
+	}
+	if note := m.splitNote(1)(); note != "The amount must be more than zero." {
+		t.Errorf("expected the message at the rejected split, got %q", note)
+	}
+	if note := m.splitNote(0)(); note != "" {
+		t.Errorf("expected no message at the accepted split, got %q", note)
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"strconv"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const maxVimCount = 999
+
+// vimLayer translates vim-style key sequences into the keys understood by
+// the underlying tables and lists. It keeps the pending count and "g" prefix
+// between key presses.
+type vimLayer struct {
+	enabled  bool
+	keymap   VimKeyMap
+	count    int
+	pendingG bool
+}
+
+func newVimLayer(enabled bool) vimLayer {
+	return vimLayer{
+		enabled: enabled,
+		keymap:  DefaultVimKeyMap(),
+	}
+}
+
+// translate returns the keys to replay for msg. If consumed is false the
+// message is not part of a vim sequence and must be handled as usual.
+func (v *vimLayer) translate(msg tea.KeyMsg) (keys []tea.KeyMsg, consumed bool) {
+	if !v.enabled {
+		return nil, false
+	}
+
+	if msg.Type == tea.KeyRunes && len(msg.Runes) == 1 {
+		r := msg.Runes[0]
+		if (r >= '1' && r <= '9') || (r == '0' && v.count > 0) {
+			v.count = min(v.count*10+int(r-'0'), maxVimCount)
+			v.pendingG = false
+			return nil, true
+		}
+	}
+
+	count := max(v.count, 1)
+	explicit := v.count > 0
+	pendingG := v.pendingG
+	v.count = 0
+	v.pendingG = false
+
+	switch {
+	case key.Matches(msg, v.keymap.Top):
+		if !pendingG {
+			v.pendingG = true
+			if explicit {
+				v.count = count
+			}
+			return nil, true
+		}
+		return gotoLine(count, explicit), true
+	case key.Matches(msg, v.keymap.Bottom):
+		if explicit {
+			return gotoLine(count, true), true
+		}
+		return []tea.KeyMsg{{Type: tea.KeyEnd}}, true
+	case key.Matches(msg, v.keymap.Down):
+		return repeatKey(tea.KeyDown, count), true
+	case key.Matches(msg, v.keymap.Up):
+		return repeatKey(tea.KeyUp, count), true
+	case key.Matches(msg, v.keymap.PageDown):
+		return repeatKey(tea.KeyPgDown, count), true
+	case key.Matches(msg, v.keymap.PageUp):
+		return repeatKey(tea.KeyPgUp, count), true
+	}
+
+	return nil, false
+}
+
+// pending returns the not yet completed sequence, e.g. "5" or "g".
+func (v *vimLayer) pending() string {
+	s := ""
+	if v.count > 0 {
+		s = strconv.Itoa(v.count)
+	}
+	if v.pendingG {
+		s += "g"
+	}
+	return s
+}
+
+func (v *vimLayer) reset() {
+	v.count = 0
+	v.pendingG = false
+}
+
+// gotoLine moves to the top and, for an explicit count, down to line n.
+func gotoLine(n int, explicit bool) []tea.KeyMsg {
+	keys := []tea.KeyMsg{{Type: tea.KeyHome}}
+	if explicit {
+		keys = append(keys, repeatKey(tea.KeyDown, n-1)...)
+	}
+	return keys
+}
+
+func repeatKey(t tea.KeyType, n int) []tea.KeyMsg {
+	keys := make([]tea.KeyMsg, 0, n)
+	for range n {
+		keys = append(keys, tea.KeyMsg{Type: t})
+	}
+	return keys
+}
@@ -0,0 +1,202 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func runeKey(r rune) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+}
+
+func feedVim(v *vimLayer, input string) ([]tea.KeyMsg, bool) {
+	var (
+		keys     []tea.KeyMsg
+		consumed bool
+	)
+	for _, r := range input {
+		keys, consumed = v.translate(runeKey(r))
+	}
+	return keys, consumed
+}
+
+func TestVimLayer_DisabledPassesThrough(t *testing.T) {
+	v := newVimLayer(false)
+
+	keys, consumed := v.translate(runeKey('j'))
+	if consumed || keys != nil {
+		t.Errorf("expected disabled layer to pass keys through, got %v %v", keys, consumed)
+	}
+}
+
+func TestVimLayer_Translate(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []tea.KeyType
+	}{
+		{"j", []tea.KeyType{tea.KeyDown}},
+		{"k", []tea.KeyType{tea.KeyUp}},
+		{"3j", []tea.KeyType{tea.KeyDown, tea.KeyDown, tea.KeyDown}},
+		{"2k", []tea.KeyType{tea.KeyUp, tea.KeyUp}},
+		{"gg", []tea.KeyType{tea.KeyHome}},
+		{"G", []tea.KeyType{tea.KeyEnd}},
+		{"3G", []tea.KeyType{tea.KeyHome, tea.KeyDown, tea.KeyDown}},
+		{"2gg", []tea.KeyType{tea.KeyHome, tea.KeyDown}},
+		{"10j", []tea.KeyType{
+			tea.KeyDown, tea.KeyDown, tea.KeyDown, tea.KeyDown, tea.KeyDown,
+			tea.KeyDown, tea.KeyDown, tea.KeyDown, tea.KeyDown, tea.KeyDown,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			v := newVimLayer(true)
+			keys, consumed := feedVim(&v, tt.input)
+			if !consumed {
+				t.Fatal("expected sequence to be consumed")
+			}
+			if len(keys) != len(tt.want) {
+				t.Fatalf("expected %d keys, got %d", len(tt.want), len(keys))
+			}
+			for i, k := range keys {
+				if k.Type != tt.want[i] {
+					t.Errorf("key %d: expected %v, got %v", i, tt.want[i], k.Type)
+				}
+			}
+			if v.pending() != "" {
+				t.Errorf("expected no pending sequence, got %q", v.pending())
+			}
+		})
+	}
+}
+
+func TestVimLayer_Paging(t *testing.T) {
+	v := newVimLayer(true)
+
+	keys, consumed := v.translate(tea.KeyMsg{Type: tea.KeyCtrlD})
+	if !consumed || len(keys) != 1 || keys[0].Type != tea.KeyPgDown {
+		t.Errorf("expected ctrl+d to page down, got %v %v", keys, consumed)
+	}
+
+	feedVim(&v, "2")
+	keys, consumed = v.translate(tea.KeyMsg{Type: tea.KeyCtrlU})
+	if !consumed || len(keys) != 2 || keys[0].Type != tea.KeyPgUp || keys[1].Type != tea.KeyPgUp {
+		t.Errorf("expected 2ctrl+u to page up twice, got %v %v", keys, consumed)
+	}
+}
+
+func TestVimLayer_LeavesHAndLToViews(t *testing.T) {
+	v := newVimLayer(true)
+
+	for _, r := range "hl" {
+		if keys, consumed := v.translate(runeKey(r)); consumed {
+			t.Errorf("expected %c to pass through, got %v", r, keys)
+		}
+	}
+}
+
+func TestVimLayer_PendingAndReset(t *testing.T) {
+	v := newVimLayer(true)
+
+	if _, consumed := feedVim(&v, "12"); !consumed {
+		t.Fatal("expected count to be consumed")
+	}
+	if v.pending() != "12" {
+		t.Errorf("expected pending '12', got %q", v.pending())
+	}
+
+	// An unrelated key clears the pending count and is not consumed.
+	if _, consumed := v.translate(runeKey('n')); consumed {
+		t.Error("expected unrelated key to pass through")
+	}
+	if v.pending() != "" {
+		t.Errorf("expected pending to be cleared, got %q", v.pending())
+	}
+
+	feedVim(&v, "g")
+	if v.pending() != "g" {
+		t.Errorf("expected pending 'g', got %q", v.pending())
+	}
+	v.reset()
+	if v.pending() != "" {
+		t.Errorf("expected reset to clear pending, got %q", v.pending())
+	}
+}
+
+func TestVimLayer_ZeroWithoutCountPassesThrough(t *testing.T) {
+	v := newVimLayer(true)
+
+	if _, consumed := v.translate(runeKey('0')); consumed {
+		t.Error("expected leading zero to pass through")
+	}
+}
+
+func TestVimLayer_CountIsCapped(t *testing.T) {
+	v := newVimLayer(true)
+
+	feedVim(&v, "99999")
+	if v.count != maxVimCount {
+		t.Errorf("expected count capped at %d, got %d", maxVimCount, v.count)
+	}
+}
+
+func TestUI_VimMode_MovesTransactionCursor(t *testing.T) {
+	var txs []firefly.Transaction
+	for i := range 10 {
+		txs = append(txs, newTestTransaction(uint(i), fmt.Sprint(i+1), "withdrawal", "2026-01-15T00:00:00Z", "tx"))
+	}
+
+	m := newTestModelUI()
+	m.vim = newVimLayer(true)
+	m.transactions = newFocusedTransactionModel(t, txs)
+	m.transactions.table.SetHeight(20)
+	m.state = transactionsView
+
+	press := func(r rune) {
+		updated, _ := m.Update(runeKey(r))
+		m = updated.(modelUI)
+	}
+
+	press('4')
+	if !strings.Contains(m.statusBar(), " 4") {
+		t.Error("expected pending count in status bar")
+	}
+	press('j')
+	if got := m.transactions.table.Cursor(); got != 4 {
+		t.Errorf("expected cursor 4 after 4j, got %d", got)
+	}
+
+	press('G')
+	if got := m.transactions.table.Cursor(); got != 9 {
+		t.Errorf("expected cursor 9 after G, got %d", got)
+	}
+
+	press('g')
+	press('g')
+	if got := m.transactions.table.Cursor(); got != 0 {
+		t.Errorf("expected cursor 0 after gg, got %d", got)
+	}
+}
+
+func TestUI_VimMode_HelpShowsVimBindings(t *testing.T) {
+	m := newTestModelUI()
+	m.help.ShowAll = true
+
+	if strings.Contains(m.HelpView(), "go to top") {
+		t.Error("expected vim bindings to be hidden when vim mode is off")
+	}
+
+	m.vim = newVimLayer(true)
+	if !strings.Contains(m.HelpView(), "go to top") {
+		t.Error("expected vim bindings in full help when vim mode is on")
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"ffiii-tui/internal/browser"
+	"ffiii-tui/internal/ui/notify"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Path of the API below the base URL of Firefly III
+const apiPath = "/api/v1"
+
+// openBrowser opens a URL in the browser, replaced in tests.
+var openBrowser = browser.Open
+
+// OpenInWebMsg opens a page of the Firefly III web interface, Path is
+// relative to its base URL, e.g. "transactions/show/12".
+type OpenInWebMsg struct {
+	Path string
+}
+
+// webURL returns the web page at path of the server whose API is at apiURL.
+func webURL(apiURL, path string) (string, error) {
+	u, err := url.Parse(apiURL)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("no web interface for %s", serverHost(apiURL))
+	}
+	base := strings.TrimSuffix(strings.TrimRight(u.Path, "/"), apiPath)
+	u.Path = base + "/" + strings.TrimLeft(path, "/")
+	u.RawQuery = ""
+	u.Fragment = ""
+	return u.String(), nil
+}
+
+func (m modelUI) openInWeb(path string) tea.Cmd {
+	link, err := webURL(m.api.ServerURL(), path)
+	if err != nil {
+		return notify.NotifyWarn(err.Error())
+	}
+	return func() tea.Msg {
+		if err := openBrowser(link); err != nil {
+			return notify.NotifyWarn(err.Error())()
+		}
+		return notify.NotifyLog("Opened " + link)()
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"errors"
+	"testing"
+
+	"ffiii-tui/internal/firefly"
+	"ffiii-tui/internal/ui/notify"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestWebURL(t *testing.T) {
+	tests := []struct {
+		apiURL  string
+		want    string
+		wantErr bool
+	}{
+		{"https://firefly.example.com/api/v1", "https://firefly.example.com/accounts/show/3", false},
+		{"https://example.com/firefly/api/v1/", "https://example.com/firefly/accounts/show/3", false},
+		{"http://localhost:8080", "http://localhost:8080/accounts/show/3", false},
+		{"demo", "", true},
+		{"", "", true},
+	}
+	for _, tt := range tests {
+		got, err := webURL(tt.apiURL, "accounts/show/3")
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("webURL(%q) = %q, %v, want %q, error %v", tt.apiURL, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func stubBrowser(t *testing.T, err error) *[]string {
+	t.Helper()
+	opened := []string{}
+	old := openBrowser
+	openBrowser = func(url string) error {
+		opened = append(opened, url)
+		return err
+	}
+	t.Cleanup(func() { openBrowser = old })
+	return &opened
+}
+
+func TestUI_OpenInWeb(t *testing.T) {
+	opened := stubBrowser(t, nil)
+	m := newTestModelUI()
+	m.api.(*mockUIAPI).serverURL = "https://firefly.example.com/api/v1"
+
+	_, cmd := m.Update(OpenInWebMsg{Path: "transactions/show/12"})
+	msgs := collectMsgsFromCmd(cmd)
+	if len(*opened) != 1 || (*opened)[0] != "https://firefly.example.com/transactions/show/12" {
+		t.Errorf("expected the transaction page opened, got %v", *opened)
+	}
+	if n, ok := findMsg[notify.NotifyMsg](msgs); !ok || n.Level != notify.Log {
+		t.Errorf("expected a log notification, got %+v", n)
+	}
+}
+
+func TestUI_OpenInWebFails(t *testing.T) {
+	opened := stubBrowser(t, errors.New("no browser opener found"))
+	m := newTestModelUI()
+	m.api.(*mockUIAPI).serverURL = "https://firefly.example.com/api/v1"
+
+	_, cmd := m.Update(OpenInWebMsg{Path: "categories/show/1"})
+	if n, ok := findMsg[notify.NotifyMsg](collectMsgsFromCmd(cmd)); !ok || n.Level != notify.Warn {
+		t.Errorf("expected a warning, got %+v", n)
+	}
+
+	m.api.(*mockUIAPI).serverURL = "demo"
+	_, cmd = m.Update(OpenInWebMsg{Path: "categories/show/1"})
+	if n, ok := findMsg[notify.NotifyMsg](collectMsgsFromCmd(cmd)); !ok || n.Level != notify.Warn {
+		t.Errorf("expected a warning without a web interface, got %+v", n)
+	}
+	if len(*opened) != 1 {
+		t.Errorf("expected no browser without a web interface, got %v", *opened)
+	}
+}
+
+func TestOpenInWebKeys(t *testing.T) {
+	w := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}}
+
+	transactions := newFocusedTransactionModel(t, []firefly.Transaction{
+		newTestTransaction(0, "12", "withdrawal", "2024-01-15T10:00:00Z", "Lunch"),
+	})
+	assets := newFocusedAssetsModelWithAccount(t, firefly.Account{ID: "3", Name: "Checking", Type: "asset"})
+	categories := newFocusedCategoriesModelWithCategory(t, firefly.Category{ID: "5", Name: "Groceries"})
+
+	tests := []struct {
+		name  string
+		model tea.Model
+		want  string
+	}{
+		{"transaction", transactions, "transactions/show/12"},
+		{"account", assets, "accounts/show/3"},
+		{"category", categories, "categories/show/5"},
+	}
+	for _, tt := range tests {
+		_, cmd := tt.model.Update(w)
+		msg, ok := findMsg[OpenInWebMsg](collectMsgsFromCmd(cmd))
+		if !ok || msg.Path != tt.want {
+			t.Errorf("%s: expected %q opened, got %+v", tt.name, tt.want, msg)
+		}
+	}
+}
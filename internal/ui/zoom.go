@@ -0,0 +1,63 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// toggleZoom shows the focused panel of the current view on the whole
+// screen, or restores the view. Each view keeps its own zoom, the
+// transactions view zooms as its full view kept in ui.full_view and the
+// transaction form does not zoom.
+func (m *modelUI) toggleZoom() tea.Cmd {
+	switch m.state {
+	case transactionsView:
+		return Cmd(ViewFullTransactionViewMsg{})
+	case newView:
+		return nil
+	}
+	m.zoomed[m.state] = !m.zoomed[m.state]
+	return Cmd(UpdatePositions{layout: m.layout})
+}
+
+// isZoomed reports whether the focused panel of the current view takes the
+// whole screen, other than in the transactions view.
+func (m modelUI) isZoomed() bool {
+	return m.zoomed[m.state]
+}
+
+// zoomedView renders the focused panel of the current view over the whole
+// width, without the tab bar and the transactions beside it. ok is false
+// unless the view is zoomed.
+func (m *modelUI) zoomedView() (view string, ok bool) {
+	if !m.isZoomed() {
+		return "", false
+	}
+	switch m.state {
+	case assetsView:
+		view = lipgloss.JoinVertical(lipgloss.Left,
+			m.panelView("summary", m.summary.list.Title, m.summary.View()),
+			m.panelView("asset", m.assets.list.Title, m.assets.View()))
+	case categoriesView:
+		view = m.panelView("categories", m.categories.list.Title, m.categories.View())
+	case tagsView:
+		view = m.panelView("tags", m.tags.list.Title, m.tags.View())
+	case expensesView:
+		view = m.panelView("expense", m.expenses.list.Title, m.expenses.View())
+	case revenuesView:
+		view = m.panelView("revenue", m.revenues.list.Title, m.revenues.View())
+	case liabilitiesView:
+		view = m.panelView("liabilities", m.liabilities.list.Title, m.liabilities.View())
+	case customView:
+		view = m.customPanelView()
+	default:
+		return "", false
+	}
+	style := m.styles.BaseFocused
+	width := m.layout.GetWidth() - style.GetHorizontalBorderSize() - style.GetHorizontalMargins()
+	return style.Width(max(width, 1)).Render(view), true
+}
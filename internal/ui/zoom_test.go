@@ -0,0 +1,87 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/viper"
+)
+
+func pressZoom(t *testing.T, m modelUI) (modelUI, tea.Cmd) {
+	t.Helper()
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'z'}})
+	return updated.(modelUI), cmd
+}
+
+func TestZoom_FocusedPanel(t *testing.T) {
+	m := newTestModelUI()
+	m.SetState(categoriesView)
+	updated, _ := m.Update(UpdatePositions{layout: m.layout.WithSize(120, 40)})
+	m = updated.(modelUI)
+
+	if view := m.View(); !strings.Contains(view, "Categ.") || !strings.Contains(view, "0 transactions") {
+		t.Fatal("expected the tab bar and the transactions next to the categories")
+	}
+
+	m, cmd := pressZoom(t, m)
+	if !m.isZoomed() {
+		t.Fatal("expected the categories zoomed")
+	}
+	msgs := collectMsgsFromCmd(cmd)
+	positions, ok := findMsg[UpdatePositions](msgs)
+	if !ok {
+		t.Fatalf("expected the positions updated, got %v", msgs)
+	}
+	updated, _ = m.Update(positions)
+	m = updated.(modelUI)
+	if m.layout.TabBarSize != 0 {
+		t.Errorf("expected no tab bar while zoomed, got %d", m.layout.TabBarSize)
+	}
+
+	view, ok := m.zoomedView()
+	if !ok {
+		t.Fatal("expected the zoomed panel rendered")
+	}
+	if got := lipgloss.Width(view); got != 120 {
+		t.Errorf("expected the panel over the whole width, got %d", got)
+	}
+	if view := m.View(); strings.Contains(view, "Categ.") || strings.Contains(view, "0 transactions") {
+		t.Error("expected the tab bar and the transactions hidden")
+	}
+
+	// Each view keeps its own zoom
+	m.SetState(tagsView)
+	if m.isZoomed() {
+		t.Error("expected the tags not zoomed")
+	}
+	m.SetState(categoriesView)
+	m, _ = pressZoom(t, m)
+	if m.isZoomed() {
+		t.Error("expected the categories restored")
+	}
+}
+
+func TestZoom_Transactions(t *testing.T) {
+	t.Cleanup(func() { viper.Set("ui.full_view", nil) })
+	m := newTestModelUI()
+	m.SetState(transactionsView)
+
+	_, cmd := pressZoom(t, m)
+	if !hasMsg[ViewFullTransactionViewMsg](collectMsgsFromCmd(cmd)) {
+		t.Error("expected the transactions zoomed as the full view")
+	}
+	if m.isZoomed() {
+		t.Error("expected no zoom of its own for the transactions view")
+	}
+
+	m.SetState(newView)
+	if _, cmd := pressZoom(t, m); cmd != nil {
+		t.Error("expected the transaction form not to zoom")
+	}
+}
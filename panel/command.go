@@ -0,0 +1,140 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package panel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// CommandTimeout stops panel commands that do not finish in time.
+const CommandTimeout = 30 * time.Second
+
+// Item is an entry of the list printed by the command of a Command panel.
+type Item struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+type commandItem struct {
+	item Item
+}
+
+func (i commandItem) Title() string       { return i.item.Title }
+func (i commandItem) Description() string { return i.item.Description }
+func (i commandItem) FilterValue() string { return i.item.Title }
+
+// commandDoneMsg reports the output of the command of the panel opened
+// with key.
+type commandDoneMsg struct {
+	key   string
+	items []Item
+	err   error
+}
+
+// Command is a generic list panel filled with the JSON array of items,
+// e.g. [{"title": "Rent", "description": "due on the 1st"}], a shell
+// command prints. The command is run when the panel is opened and on r.
+type Command struct {
+	title   string
+	key     string
+	command string
+	list    list.Model
+	err     error
+	failed  lipgloss.Style
+}
+
+func NewCommand(title, key, command string) Command {
+	m := Command{
+		title:   title,
+		key:     key,
+		command: command,
+		list:    list.New(nil, list.NewDefaultDelegate(), 0, 0),
+		failed: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#D75F5F")).
+			PaddingLeft(2),
+	}
+	m.list.Title = title
+	m.list.SetShowStatusBar(false)
+	// Typed letters would open the other panels
+	m.list.SetFilteringEnabled(false)
+	m.list.SetShowHelp(false)
+	m.list.DisableQuitKeybindings()
+	return m
+}
+
+func (m Command) Title() string { return m.title }
+func (m Command) Key() string   { return m.key }
+
+func (m Command) Init() tea.Cmd {
+	key, command := m.key, m.command
+	return func() tea.Msg {
+		items, err := runCommand(command)
+		return commandDoneMsg{key: key, items: items, err: err}
+	}
+}
+
+func (m Command) Update(msg tea.Msg) (Panel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case commandDoneMsg:
+		if msg.key != m.key {
+			return m, nil
+		}
+		m.err = msg.err
+		items := make([]list.Item, 0, len(msg.items))
+		for _, item := range msg.items {
+			items = append(items, commandItem{item: item})
+		}
+		return m, m.list.SetItems(items)
+	case tea.KeyMsg:
+		if msg.String() == "r" {
+			return m, m.Init()
+		}
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m Command) View(width, height int) string {
+	if m.err != nil {
+		title := list.DefaultStyles().TitleBar.Render(list.DefaultStyles().Title.Render(m.title))
+		return lipgloss.NewStyle().MaxWidth(width).MaxHeight(height).Render(
+			title + "\n" + m.failed.Width(max(width-2, 1)).Render(m.err.Error()))
+	}
+	m.list.SetSize(width, height)
+	return m.list.View()
+}
+
+// runCommand runs the command and decodes the items it prints.
+func runCommand(command string) ([]Item, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), CommandTimeout)
+	defer cancel()
+	name, args := shell(command)
+	cmd := exec.CommandContext(ctx, name, args...)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s failed: %w: %s", command, err, msg)
+		}
+		return nil, fmt.Errorf("%s failed: %w", command, err)
+	}
+	var items []Item
+	if err := json.Unmarshal(out, &items); err != nil {
+		return nil, fmt.Errorf("failed to decode the output of %s: %w", command, err)
+	}
+	return items, nil
+}
@@ -0,0 +1,69 @@
+//go:build !windows
+
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package panel
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestCommand_ListsItems(t *testing.T) {
+	m := NewCommand("Budgets", "b",
+		`echo '[{"title": "Groceries", "description": "120 left"}, {"title": "Fuel"}]'`)
+
+	msg := m.Init()()
+	p, _ := m.Update(msg)
+
+	view := p.View(40, 10)
+	if !strings.Contains(view, "Groceries") || !strings.Contains(view, "120 left") || !strings.Contains(view, "Fuel") {
+		t.Errorf("expected the items in the view, got:\n%s", view)
+	}
+}
+
+func TestCommand_Failure(t *testing.T) {
+	m := NewCommand("Budgets", "b", "echo broken >&2; exit 3")
+
+	p, _ := m.Update(m.Init()())
+
+	if view := p.View(60, 10); !strings.Contains(view, "broken") {
+		t.Errorf("expected the error in the view, got:\n%s", view)
+	}
+}
+
+func TestCommand_InvalidOutput(t *testing.T) {
+	m := NewCommand("Budgets", "b", "echo not json")
+
+	p, _ := m.Update(m.Init()())
+
+	if view := p.View(80, 10); !strings.Contains(view, "failed to decode") {
+		t.Errorf("expected a decode error in the view, got:\n%s", view)
+	}
+}
+
+func TestCommand_IgnoresOtherPanels(t *testing.T) {
+	m := NewCommand("Budgets", "b", "true")
+
+	p, _ := m.Update(commandDoneMsg{key: "g", items: []Item{{Title: "Other"}}})
+
+	if strings.Contains(p.View(40, 10), "Other") {
+		t.Error("expected the output of another panel to be ignored")
+	}
+}
+
+func TestCommand_RefreshKey(t *testing.T) {
+	m := NewCommand("Budgets", "b", `echo '[]'`)
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if cmd == nil {
+		t.Fatal("expected r to run the command again")
+	}
+	if _, ok := cmd().(commandDoneMsg); !ok {
+		t.Error("expected the command output")
+	}
+}
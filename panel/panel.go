@@ -0,0 +1,51 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package panel lets other Go packages add views to the UI. A panel
+// registered in an init function, e.g. of a package imported by a custom
+// main next to ffiii-tui/cmd, is opened with its key from the list views
+// and shown in the tab bar.
+package panel
+
+import (
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Panel is an extra view. It is a bubbletea model that renders itself in
+// the size it is given.
+type Panel interface {
+	// Title is shown in the tab bar.
+	Title() string
+	// Key opens the panel; it should not clash with the keys of the
+	// list views.
+	Key() string
+	// Init is run each time the panel is opened.
+	Init() tea.Cmd
+	// Update receives the keys while the panel is open and all other
+	// messages at any time. Esc leaves the panel.
+	Update(msg tea.Msg) (Panel, tea.Cmd)
+	View(width, height int) string
+}
+
+var (
+	mu     sync.Mutex
+	panels []Panel
+)
+
+// Register adds a panel to the UI. It must be called before the UI starts.
+func Register(p Panel) {
+	mu.Lock()
+	defer mu.Unlock()
+	panels = append(panels, p)
+}
+
+// Registered returns the registered panels in the order of registration.
+func Registered() []Panel {
+	mu.Lock()
+	defer mu.Unlock()
+	return append([]Panel(nil), panels...)
+}
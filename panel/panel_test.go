@@ -0,0 +1,23 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package panel
+
+import "testing"
+
+func TestRegister(t *testing.T) {
+	defer func() { panels = nil }()
+
+	Register(NewCommand("Budgets", "b", "true"))
+	Register(NewCommand("Goals", "g", "true"))
+
+	got := Registered()
+	if len(got) != 2 || got[0].Title() != "Budgets" || got[1].Key() != "g" {
+		t.Fatalf("unexpected panels %v", got)
+	}
+	got[0] = nil
+	if Registered()[0] == nil {
+		t.Error("expected a copy of the registered panels")
+	}
+}
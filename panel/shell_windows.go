@@ -0,0 +1,9 @@
+/*
+Copyright © 2025-2026 Artur Taranchiev <artur.taranchiev@gmail.com>
+SPDX-License-Identifier: Apache-2.0
+*/
+package panel
+
+func shell(command string) (string, []string) {
+	return "cmd", []string{"/C", command}
+}